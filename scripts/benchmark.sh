@@ -37,9 +37,9 @@ run_http_benchmarks() {
     echo -e "${BLUE}Running HTTP load tests...${NC}"
     
     # Check if server is running
-    if ! curl -s http://localhost:8080/health > /dev/null; then
+    if ! curl -s http://localhost:8080/healthz > /dev/null; then
         echo -e "${RED}Server is not running. Please start it first:${NC}"
-        echo "go run src/main.go"
+        echo "go run ./src"
         return 1
     fi
     
@@ -21,13 +21,13 @@ SERVER_URL="http://localhost:8080"
 # Function to check if server is running
 check_server() {
     echo -e "${BLUE}Checking server status...${NC}"
-    if curl -s "$SERVER_URL/health" > /dev/null; then
+    if curl -s "$SERVER_URL/healthz" > /dev/null; then
         echo -e "${GREEN}✅ Server is running${NC}"
         return 0
     else
         echo -e "${RED}❌ Server is not running${NC}"
         echo -e "${YELLOW}Please start the server first:${NC}"
-        echo "go run src/main.go"
+        echo "go run ./src"
         return 1
     fi
 }
@@ -60,7 +60,8 @@ setup_demo_data() {
             "venue": "Madison Square Garden",
             "date": "2024-12-31T20:00:00Z",
             "total_seats": 100,
-            "price": 75.0
+            "price": 75.0,
+            "status": "published"
         }')
     EVENT1_ID=$(echo $EVENT1_RESPONSE | grep -o '"event_id":"[^"]*"' | cut -d'"' -f4)
     echo "Created event: Rock Concert 2024 ($EVENT1_ID)"
@@ -73,7 +74,8 @@ setup_demo_data() {
             "venue": "Blue Note",
             "date": "2024-12-25T19:30:00Z",
             "total_seats": 50,
-            "price": 45.0
+            "price": 45.0,
+            "status": "published"
         }')
     EVENT2_ID=$(echo $EVENT2_RESPONSE | grep -o '"event_id":"[^"]*"' | cut -d'"' -f4)
     echo "Created event: Jazz Night ($EVENT2_ID)"
@@ -26,12 +26,12 @@ SERVER_URL="http://localhost:8080"
 # Function to check if server is running
 check_server() {
     echo -e "${BLUE}Checking if server is running...${NC}"
-    if curl -s "$SERVER_URL/health" > /dev/null; then
+    if curl -s "$SERVER_URL/healthz" > /dev/null; then
         echo -e "${GREEN}✅ Server is running${NC}"
         return 0
     else
         echo -e "${RED}❌ Server is not running. Please start the server first:${NC}"
-        echo "go run src/main.go"
+        echo "go run ./src"
         return 1
     fi
 }
@@ -64,4 +64,4 @@ echo -e "${YELLOW}Press Ctrl+C to stop the server${NC}"
 echo ""
 
 # Run the application
-go run src/main.go
+go run ./src
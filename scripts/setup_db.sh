@@ -60,23 +60,9 @@ create_database() {
 # Function to run migrations
 run_migrations() {
     echo -e "${BLUE}Running database migrations...${NC}"
-    
-    # Set password for psql
-    export PGPASSWORD=$DB_PASSWORD
-    
-    # Run each migration file
-    for migration in migrations/*.sql; do
-        if [ -f "$migration" ]; then
-            echo -e "${CYAN}Running $(basename $migration)...${NC}"
-            psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -f "$migration"
-            if [ $? -eq 0 ]; then
-                echo -e "${GREEN}✅ $(basename $migration) completed${NC}"
-            else
-                echo -e "${RED}❌ $(basename $migration) failed${NC}"
-                return 1
-            fi
-        fi
-    done
+
+    DB_HOST=$DB_HOST DB_PORT=$DB_PORT DB_USER=$DB_USER DB_PASSWORD=$DB_PASSWORD DB_NAME=$DB_NAME \
+        "$(dirname "$0")/migrate.sh" up
 }
 
 # Function to verify tables
@@ -101,41 +87,10 @@ verify_tables() {
 # Function to create sample data
 create_sample_data() {
     echo -e "${BLUE}Creating sample data...${NC}"
-    
-    # Set password for psql
-    export PGPASSWORD=$DB_PASSWORD
-    
-    # Create sample users
-    psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -c "
-        INSERT INTO users (id, email, name) VALUES 
-        ('550e8400-e29b-41d4-a716-446655440000', 'alice@example.com', 'Alice Johnson'),
-        ('550e8400-e29b-41d4-a716-446655440001', 'bob@example.com', 'Bob Smith')
-        ON CONFLICT (email) DO NOTHING;
-    "
-    
-    # Create sample events
-    psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -c "
-        INSERT INTO events (id, name, artist, venue, date, total_seats, price) VALUES 
-        ('550e8400-e29b-41d4-a716-446655440010', 'Rock Concert 2024', 'The Rockers', 'Madison Square Garden', '2024-12-31 20:00:00+00', 100, 75.00),
-        ('550e8400-e29b-41d4-a716-446655440011', 'Jazz Night', 'Smooth Jazz Band', 'Blue Note', '2024-12-25 19:30:00+00', 50, 45.00)
-        ON CONFLICT (id) DO NOTHING;
-    "
-    
-    # Create sample tickets for first event
-    psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -c "
-        INSERT INTO tickets (event_id, seat_number, price) 
-        SELECT '550e8400-e29b-41d4-a716-446655440010', generate_series(1, 100), 75.00
-        ON CONFLICT (event_id, seat_number) DO NOTHING;
-    "
-    
-    # Create sample tickets for second event
-    psql -h $DB_HOST -p $DB_PORT -U $DB_USER -d $DB_NAME -c "
-        INSERT INTO tickets (event_id, seat_number, price) 
-        SELECT '550e8400-e29b-41d4-a716-446655440011', generate_series(1, 50), 45.00
-        ON CONFLICT (event_id, seat_number) DO NOTHING;
-    "
-    
-    echo -e "${GREEN}✅ Sample data created successfully${NC}"
+
+    (cd "$(dirname "$0")/.." && \
+        DB_HOST=$DB_HOST DB_PORT=$DB_PORT DB_USER=$DB_USER DB_PASSWORD=$DB_PASSWORD DB_NAME=$DB_NAME \
+        go run ./src seed)
 }
 
 # Function to show database status
@@ -194,7 +149,7 @@ main() {
     
     echo -e "${GREEN}✅ Database setup completed successfully!${NC}"
     echo -e "${BLUE}You can now start the application with:${NC}"
-    echo "go run cmd/server/main.go"
+    echo "go run ./src"
 }
 
 # Run main function
@@ -0,0 +1,478 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+	"github.com/ojaswiii/booking-manager/src/utils/messaging"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+	"github.com/ojaswiii/booking-manager/src/utils/oauth"
+	"github.com/ojaswiii/booking-manager/src/utils/payments"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// app holds the connections, repositories and usecases shared by the serve
+// and worker commands
+type app struct {
+	config *utils.Config
+	logger *utils.Logger
+
+	db       *sqlx.DB              // nil with STORAGE_BACKEND=memory
+	replica  *database.Replica     // nil without DB_READ_DSN
+	redis    *database.RedisClient // nil with STORAGE_BACKEND=memory
+	repos    *repository.RepositoryContainer
+	usecases *usecase.UsecaseContainer
+
+	outboxRelay *usecase.OutboxRelay       // nil without Kafka brokers
+	leader      *concurrency.LeaderElector // nil when every instance runs every job
+
+	closers []func() error
+}
+
+// newApp opens the configured storage, a database and Redis or memory, and
+// builds every usecase. The booking processor starts consuming its queues
+// as soon as it is built.
+func newApp(config *utils.Config, logger *utils.Logger) (a *app, err error) {
+	a = &app{config: config, logger: logger}
+	defer func() {
+		if err != nil {
+			a.Close()
+		}
+	}()
+
+	columnMigrations, err := repository.ParseColumnMigrations(config.ColumnMigrations)
+	if err != nil {
+		return nil, fmt.Errorf("invalid column migration configuration: %w", err)
+	}
+
+	// Initialize repositories
+	var repos *repository.RepositoryContainer
+	if config.StorageBackend == "memory" {
+		repos, err = a.openMemory(columnMigrations)
+	} else {
+		repos, err = a.openDatabase(columnMigrations)
+	}
+	if err != nil {
+		return nil, err
+	}
+	a.repos = repos
+
+	// Initialize usecases
+	userUsecase := usecase.NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	confirmationGates := usecase.NewDefaultConfirmationGates(config)
+	eventUsecase := usecase.NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, repos.CacheInvalidation, confirmationGates, config, logger)
+	quoteUsecase := usecase.NewQuoteUsecase(repos.Event, repos.Ticket, config, logger)
+	seatSuggestionUsecase := usecase.NewSeatSuggestionUsecase(repos.Event, repos.Ticket, repos.SeatSuggestion, config, logger)
+	bookingSLA := usecase.NewBookingSLATracker(config, logger)
+	overloadPolicy, err := usecase.NewBookingOverloadPolicy(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid booking overload configuration: %w", err)
+	}
+	durableQueue, err := usecase.NewBookingDurableQueue(repos.BookingQueue, config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid booking queue configuration: %w", err)
+	}
+	waitingRoomUsecase := usecase.NewWaitingRoomUsecase(repos.Event, repos.WaitingRoom, bookingSLA, overloadPolicy, config, logger)
+	jobUsecase := usecase.NewJobUsecase(repos.Job, config, logger)
+	presaleUsecase := usecase.NewPresaleUsecase(repos.Presale, repos.Event, jobUsecase, logger)
+	columnMigrationUsecase := usecase.NewColumnMigrationUsecase(repos.ColumnMigration, jobUsecase, logger)
+	webhookUsecase := usecase.NewWebhookUsecase(repos.Webhook, userUsecase, config, logger)
+	templateUsecase := usecase.NewTemplateUsecase(repos.Template, repos.Event, repos.Ticket, eventUsecase, logger)
+	availabilityFeed := usecase.NewAvailabilityFeed(repos.AvailabilityFeed, logger)
+	bookingUpdates := usecase.NewBookingUpdateFeed(repos.BookingUpdates, logger)
+	bookingMetrics := usecase.NewBookingMetricsUsecase(repos.BookingMetrics, config, logger)
+	availabilityUsecase := usecase.NewAvailabilityUsecase(repos.Ticket, repos.Event, repos.Availability, availabilityFeed, config, logger)
+	readModelUsecase := usecase.NewReadModelUsecase(repos.ReadModel, repos.BookingEvent, repos.Event, repos.Ticket, config, logger)
+	holdUsecase := usecase.NewHoldUsecase(repos.Hold, repos.Event, availabilityUsecase, logger)
+	notifier, err := notify.NewNotifier(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification configuration: %w", err)
+	}
+	paymentProvider, err := payments.NewProvider(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("invalid payment configuration: %w", err)
+	}
+	notificationUsecase := usecase.NewNotificationUsecase(repos.Notification, repos.Booking, repos.Event, repos.User, notifier, config, logger)
+	broadcastUsecase := usecase.NewBroadcastUsecase(repos.Broadcast, repos.Event, repos.User, repos.Transactor, notificationUsecase, notifier, jobUsecase, config, logger)
+	ticketPassUsecase := usecase.NewTicketPassUsecase(repos.TicketPass, repos.Booking, repos.Event, repos.Ticket, repos.User, config, logger)
+	eventStatsUsecase := usecase.NewEventStatsUsecase(repos.EventStats, repos.EventStatsCache, repos.Event, config, logger)
+	bookingUsecase := usecase.NewBookingUsecase(repos.Booking, repos.BookingCache, repos.Ticket, repos.Event, repos.User, repos.Outbox, repos.BookingTransition, repos.BookingEvent, repos.DeadLetter, repos.Transactor, repos.Queries, quoteUsecase, confirmationGates, waitingRoomUsecase, presaleUsecase, bookingSLA, overloadPolicy, durableQueue, webhookUsecase, notificationUsecase, ticketPassUsecase, eventStatsUsecase, availabilityUsecase, bookingUpdates, bookingMetrics, readModelUsecase, config, logger)
+	a.closers = append(a.closers, func() error {
+		bookingUsecase.Shutdown()
+		// Save what the processor counted while draining
+		bookingMetrics.Flush(context.Background())
+		return nil
+	})
+	statusUsecase := usecase.NewStatusUsecase(bookingSLA, overloadPolicy, logger)
+	checkInUsecase := usecase.NewCheckInUsecase(repos.TicketPass, repos.Booking, repos.Event, repos.Ticket, repos.User, ticketPassUsecase, config, logger)
+	refundUsecase := usecase.NewRefundUsecase(repos.Refund, repos.Booking, repos.Event, repos.Ticket, repos.TicketPass, repos.Outbox, repos.BookingTransition, repos.BookingEvent, repos.Transactor, paymentProvider, webhookUsecase, eventStatsUsecase, availabilityUsecase, config, logger)
+	paymentUsecase := usecase.NewPaymentUsecase(paymentProvider, bookingUsecase, logger)
+	authUsecase := usecase.NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, userUsecase, oauth.NewProviders(config), notifier, config, logger)
+
+	// Create usecase container
+	a.usecases = &usecase.UsecaseContainer{
+		User:      userUsecase,
+		UserAdmin: usecase.NewUserAdminUsecase(repos.User, repos.Audit, repos.Transactor, userUsecase, bookingUsecase, authUsecase, logger),
+		Auth:      authUsecase,
+		APIKey:    usecase.NewAPIKeyUsecase(repos.APIKey, repos.RateLimit, userUsecase, config, logger),
+		Event:     eventUsecase,
+		Booking:   bookingUsecase,
+		Quote:     quoteUsecase,
+
+		WaitingRoom: waitingRoomUsecase,
+		Presale:     presaleUsecase,
+		Webhook:     webhookUsecase,
+		Template:    templateUsecase,
+
+		Notification: notificationUsecase,
+		Status:       statusUsecase,
+		TicketPass:   ticketPassUsecase,
+		CheckIn:      checkInUsecase,
+		Hold:         holdUsecase,
+		Job:          jobUsecase,
+		Refund:       refundUsecase,
+		Broadcast:    broadcastUsecase,
+		Payment:      paymentUsecase,
+
+		ColumnMigration: columnMigrationUsecase,
+		SeatSuggestion:  seatSuggestionUsecase,
+		EventStats:      eventStatsUsecase,
+		Report:          usecase.NewReportUsecase(repos.SalesReport, userUsecase, logger),
+		BookingExport:   usecase.NewBookingExportUsecase(repos.BookingExport, repos.Event, userUsecase, config, logger),
+		Calendar:        usecase.NewCalendarUsecase(eventUsecase, userUsecase, repos.Booking, repos.Event, config, logger),
+		Privacy:         usecase.NewPrivacyUsecase(repos.User, repos.Booking, repos.TicketPass, repos.Notification, repos.Audit, repos.Transactor, userUsecase, logger),
+		Deletion:        usecase.NewDeletionUsecase(repos.User, repos.Event, userUsecase, eventUsecase, logger),
+		Availability:    availabilityUsecase,
+		ReadModel:       readModelUsecase,
+
+		AvailabilityFeed:  availabilityFeed,
+		BookingUpdates:    bookingUpdates,
+		BookingMetrics:    bookingMetrics,
+		CacheInvalidation: usecase.NewCacheInvalidationListener(repos.CacheInvalidation, eventUsecase, userUsecase, logger),
+		Health:            usecase.NewHealthUsecase(bookingUsecase, config, logger),
+	}
+	a.addHealthChecks()
+
+	logger.Info("Usecases initialized with integrated concurrency")
+
+	// Relay outbox events to Kafka when a broker is configured
+	if len(config.OutboxKafkaBrokers) > 0 {
+		publisher := messaging.NewKafkaPublisher(config)
+		a.closers = append(a.closers, publisher.Close)
+		a.outboxRelay = usecase.NewOutboxRelay(repos.Outbox, publisher, config, logger)
+		logger.Info("Outbox relay initialized", "brokers", config.OutboxKafkaBrokers, "topic", config.OutboxKafkaTopic)
+	} else {
+		logger.Warn("No Kafka brokers configured; outbox events are recorded but not published")
+	}
+
+	// Elect one instance to run each singleton job
+	if config.LeaderElectionEnabled {
+		holder := config.BookingQueueConsumer
+		if holder == "" {
+			host, _ := os.Hostname()
+			holder = fmt.Sprintf("%s-%d", host, os.Getpid())
+		}
+		a.leader = concurrency.NewLeaderElector(repos.Lease, holder, time.Duration(config.LeaderLeaseTTLSeconds)*time.Second, logger)
+		logger.Info("Leader election enabled", "holder", holder, "lease_ttl_seconds", config.LeaderLeaseTTLSeconds)
+	}
+
+	return a, nil
+}
+
+// openDatabase connects to the database DB_DRIVER selects and Redis,
+// migrates if configured to, and creates the repositories over them
+func (a *app) openDatabase(columnMigrations repository.ColumnMigrations) (*repository.RepositoryContainer, error) {
+	config, logger := a.config, a.logger
+
+	// Initialize database connections
+	db, queries, err := connectDatabase(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	a.db = db
+	a.closers = append(a.closers, db.Close)
+
+	if config.DBMigrateOnStartup {
+		if err := migrateAll(context.Background(), a.db, config, logger); err != nil {
+			return nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+	}
+
+	// Lag-tolerant reads go to the read replica, if one is configured
+	var readReplica repository.ReadReplica
+	a.replica, err = database.NewReplica(config, queries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PostgreSQL read replica: %w", err)
+	}
+	if a.replica != nil {
+		a.closers = append(a.closers, a.replica.Close)
+		readReplica = a.replica
+		logger.Info("Read replica configured", "healthy", a.replica.Healthy(), "max_lag_ms", config.DBReplicaMaxLagMs)
+	}
+
+	redisClient, err := database.NewRedisClient(config, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+	}
+	a.redis = redisClient
+	a.closers = append(a.closers, redisClient.Close)
+	if !redisClient.Breaker.Available() {
+		logger.Warn("Starting without Redis; caches are bypassed until it is reachable")
+	}
+
+	isolation, err := repository.ParseTenantIsolation(config.TenantIsolation)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tenancy configuration: %w", err)
+	}
+	txRetry := repository.TxRetryPolicy{
+		MaxAttempts: config.DBTxMaxAttempts,
+		Backoff:     time.Duration(config.DBTxRetryBackoffMs) * time.Millisecond,
+		MaxBackoff:  time.Duration(config.DBTxRetryMaxBackoffMs) * time.Millisecond,
+	}
+	repos := repository.NewRepositoryContainer(a.db, redisClient.Client, isolation, columnMigrations, txRetry, readReplica, queries, cacheTTLs(config))
+	logger.Info("Repositories initialized", "tenant_isolation", isolation, "column_migrations", columnMigrations)
+	return repos, nil
+}
+
+// cacheTTLs returns how long each kind of entity is cached for
+func cacheTTLs(config *utils.Config) repository.CacheTTLs {
+	return repository.CacheTTLs{
+		User:      time.Duration(config.CacheUserTTLSeconds) * time.Second,
+		Event:     time.Duration(config.CacheEventTTLSeconds) * time.Second,
+		EventList: time.Duration(config.CacheEventListTTLSeconds) * time.Second,
+		Jitter:    config.CacheTTLJitterPercent / 100,
+	}
+}
+
+// connectDatabase opens a connection pool to the database DB_DRIVER
+// selects, with its statements recorded in the returned stats
+func connectDatabase(config *utils.Config, logger *utils.Logger) (*sqlx.DB, *database.QueryStats, error) {
+	if config.DBDriver == "sqlite" {
+		client, err := database.NewSQLiteClient(config, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.Info("Opened SQLite database", "path", config.DBSQLitePath)
+		return client.DB, client.Queries, nil
+	}
+	if config.DBDriver == "mysql" {
+		client, err := database.NewMySQLClient(config, logger)
+		if err != nil {
+			return nil, nil, err
+		}
+		logger.Info("Connected to MySQL", "host", config.DBHost, "database", config.DBName)
+		return client.DB, client.Queries, nil
+	}
+
+	client, err := database.NewPostgresClient(config, logger)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+	logger.Info("Connected to PostgreSQL",
+		"max_open_conns", client.DB.Stats().MaxOpenConnections,
+		"max_idle_conns", config.DBMaxIdleConns,
+		"conn_max_lifetime", time.Duration(config.DBConnMaxLifetimeSeconds)*time.Second,
+		"connect_timeout", time.Duration(config.DBConnectTimeoutSeconds)*time.Second,
+		"statement_timeout", time.Duration(config.DBStatementTimeoutMs)*time.Millisecond,
+		"slow_query_threshold", time.Duration(config.DBSlowQueryMs)*time.Millisecond,
+	)
+	return client.DB, client.Queries, nil
+}
+
+// openMemory creates repositories that keep everything in this process,
+// seeding every tenant with demo data if configured to
+func (a *app) openMemory(columnMigrations repository.ColumnMigrations) (*repository.RepositoryContainer, error) {
+	repos := repository.NewMemoryRepositoryContainer(columnMigrations, cacheTTLs(a.config))
+	a.logger.Warn("Using in-memory storage; data is lost on exit")
+
+	if !a.config.StorageSeedMemory {
+		return repos, nil
+	}
+	tenantIDs := a.config.TenantIDs
+	if len(tenantIDs) == 0 {
+		tenantIDs = []string{""}
+	}
+	seeder := &seeder{
+		repos:    repos,
+		pricing:  usecase.NewBookingPricing(a.config),
+		currency: a.config.Currency,
+		rand:     rand.New(rand.NewSource(1)),
+	}
+	for _, tenantID := range tenantIDs {
+		if err := seeder.seed(tenant.WithID(context.Background(), tenantID), len(seedNames)); err != nil {
+			return nil, fmt.Errorf("failed to seed memory store: %w", err)
+		}
+	}
+	a.logger.Info("Seeded memory store with demo data", "tenants", len(tenantIDs), "tickets", seeder.tickets, "bookings", seeder.bookings)
+	return repos, nil
+}
+
+// monitorReplica tracks the read replica's health and lag until ctx is
+// cancelled, so lag-tolerant reads fall back to the primary when needed
+func (a *app) monitorReplica(ctx context.Context) {
+	if a.replica != nil {
+		go a.replica.Monitor(ctx, time.Duration(a.config.DBReplicaCheckIntervalMs)*time.Millisecond, a.logger)
+	}
+}
+
+// warmCaches fills each tenant's event caches, booking counters and
+// available-ticket counters from the database before traffic arrives.
+// Failures are logged; the caches then fill on demand as they would
+// without a warm-up.
+func (a *app) warmCaches(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(a.config.CacheWarmupTimeoutSeconds)*time.Second)
+	defer cancel()
+
+	tenantIDs := a.config.TenantIDs
+	if len(tenantIDs) == 0 {
+		tenantIDs = []string{""}
+	}
+	started := time.Now()
+	for _, tenantID := range tenantIDs {
+		tenantCtx := tenant.WithID(ctx, tenantID)
+		events, err := a.usecases.Event.WarmCache(tenantCtx)
+		if err != nil {
+			a.logger.Warn("Failed to warm event cache", "tenant", tenantID, "error", err)
+		}
+		counters, err := a.usecases.EventStats.Reconcile(tenantCtx)
+		if err != nil {
+			a.logger.Warn("Failed to warm event stats", "tenant", tenantID, "error", err)
+		}
+		availability, err := a.usecases.Availability.Reconcile(tenantCtx)
+		if err != nil {
+			a.logger.Warn("Failed to warm ticket availability", "tenant", tenantID, "error", err)
+		}
+		a.logger.Info("Warmed caches", "tenant", tenantID, "upcoming_events", events, "event_stats", counters, "availability", availability)
+	}
+	a.logger.Info("Cache warm-up finished", "tenants", len(tenantIDs), "duration", time.Since(started))
+}
+
+// listenForInvalidations applies the cache invalidations any instance
+// publishes until ctx is cancelled
+func (a *app) listenForInvalidations(ctx context.Context) {
+	go a.usecases.CacheInvalidation.Run(ctx)
+}
+
+// addHealthChecks registers the storage connections for readiness. Only
+// the database is critical: without Redis caches are bypassed, and without
+// the replica reads go to the primary.
+func (a *app) addHealthChecks() {
+	health := a.usecases.Health
+	if a.db != nil {
+		health.AddCheck(a.config.DBDriver, true, a.db.PingContext)
+	}
+	if a.redis != nil {
+		health.AddCheck("redis", false, a.redis.Ping)
+	}
+	if a.replica != nil {
+		health.AddCheck("read_replica", false, a.replica.Check)
+	}
+}
+
+// runJobs starts the scheduled jobs until ctx is cancelled. They run once
+// per tenant so each only sees its own data. Jobs that claim their work
+// row by row run on every instance, beating a heartbeat for readiness; the
+// rest run on the elected leader.
+func (a *app) runJobs(ctx context.Context) {
+	tenantIDs := a.config.TenantIDs
+	if len(tenantIDs) == 0 {
+		tenantIDs = []string{""}
+	}
+	singleton := func(ctx context.Context, name string, job func(ctx context.Context)) {
+		if a.leader == nil {
+			go job(ctx)
+			return
+		}
+		go a.leader.Run(ctx, name, job)
+	}
+	heartbeats := a.usecases.Health.Heartbeats()
+	worker := func(ctx context.Context, name string) context.Context {
+		if tenantID := tenant.FromContext(ctx); tenantID != "" {
+			name += "/" + tenantID
+		}
+		return heartbeats.Start(ctx, name)
+	}
+
+	salesScheduler := usecase.NewSalesScheduler(a.repos.Event, a.usecases.Event, 30*time.Second, a.logger)
+	for _, tenantID := range tenantIDs {
+		tenantCtx := tenant.WithID(ctx, tenantID)
+
+		// Start on-sale scheduler
+		singleton(tenantCtx, "sales_scheduler", salesScheduler.Run)
+
+		// Start waiting room admission
+		singleton(tenantCtx, "waiting_room", a.usecases.WaitingRoom.Run)
+
+		// Start webhook delivery and booking expiry
+		go a.usecases.Webhook.Run(worker(tenantCtx, "webhooks"))
+		singleton(tenantCtx, "booking_expiry", func(ctx context.Context) {
+			a.usecases.Booking.RunExpiry(ctx, time.Minute)
+		})
+
+		// Start event stats reconciliation
+		singleton(tenantCtx, "event_stats", a.usecases.EventStats.Run)
+
+		// Start available-ticket counter reconciliation
+		singleton(tenantCtx, "availability", a.usecases.Availability.Run)
+
+		// Start the read model projector
+		if a.usecases.ReadModel.Enabled() {
+			singleton(tenantCtx, "read_models", a.usecases.ReadModel.Run)
+		}
+
+		// Start email notification worker
+		go a.usecases.Notification.Run(worker(tenantCtx, "notifications"))
+
+		// Start admin job worker
+		go a.usecases.Job.Run(worker(tenantCtx, "jobs"))
+
+		// Start outbox relay
+		if a.outboxRelay != nil {
+			go a.outboxRelay.Run(worker(tenantCtx, "outbox_relay"))
+		}
+	}
+}
+
+// reportMetrics logs the booking processor's stats, and any extra stats,
+// every interval until ctx is cancelled
+func (a *app) reportMetrics(ctx context.Context, interval time.Duration, extra func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			a.logger.Info("Booking concurrency metrics", "stats", a.usecases.Booking.GetConcurrencyStats())
+			a.logger.Info("Event cache loads", "stats", a.usecases.Event.CacheLoadStats())
+			if a.leader != nil {
+				a.logger.Info("Leader election", "stats", a.leader.Stats())
+			}
+			if extra != nil {
+				extra()
+			}
+		}
+	}
+}
+
+// Close drains the booking processor and closes every connection, most
+// recently opened first
+func (a *app) Close() {
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		if err := a.closers[i](); err != nil {
+			a.logger.Warn("Failed to close resource", "error", err)
+		}
+	}
+	a.closers = nil
+}
@@ -0,0 +1,196 @@
+package graphql
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Loads requested within this window of each other share one repository call
+const (
+	loaderWait     = 2 * time.Millisecond
+	loaderMaxBatch = 500
+)
+
+type loadersKey struct{}
+
+// Loaders batch the lookups made while resolving one request, so a list of
+// bookings costs one query for their tickets and one for their events
+// instead of one per booking
+type Loaders struct {
+	Events  *loader[*domain_event.Event]
+	Tickets *loader[*domain_ticket.Ticket]
+}
+
+// NewLoaders creates loaders for a single request
+func NewLoaders(ctx context.Context, events *usecase.EventUsecase) *Loaders {
+	return &Loaders{
+		Events: newLoader(ctx, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain_event.Event, error) {
+			found, err := events.GetEventsByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*domain_event.Event, len(found))
+			for _, event := range found {
+				byID[event.ID] = event
+			}
+			return byID, nil
+		}),
+		Tickets: newLoader(ctx, func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain_ticket.Ticket, error) {
+			found, err := events.GetTicketsByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[uuid.UUID]*domain_ticket.Ticket, len(found))
+			for _, ticket := range found {
+				byID[ticket.ID] = ticket
+			}
+			return byID, nil
+		}),
+	}
+}
+
+// Middleware attaches fresh loaders to every request. Loaders cache what
+// they load, so they must never outlive the request.
+func Middleware(events *usecase.EventUsecase) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), loadersKey{}, NewLoaders(r.Context(), events))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// For returns the loaders of the request
+func For(ctx context.Context) *Loaders {
+	return ctx.Value(loadersKey{}).(*Loaders)
+}
+
+// loader collects the IDs requested by concurrent resolvers and fetches them
+// in one batch once loaderWait has passed or the batch is full
+type loader[V any] struct {
+	ctx   context.Context // the request's, so batches run with its tenant
+	fetch func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]V, error)
+	wait  time.Duration
+
+	mu      sync.Mutex
+	results map[uuid.UUID]*loadResult[V]
+	pending *loadBatch
+}
+
+type loadResult[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type loadBatch struct {
+	ids   []uuid.UUID
+	timer *time.Timer
+}
+
+func newLoader[V any](ctx context.Context, fetch func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]V, error)) *loader[V] {
+	return &loader[V]{
+		ctx:     ctx,
+		fetch:   fetch,
+		wait:    loaderWait,
+		results: make(map[uuid.UUID]*loadResult[V]),
+	}
+}
+
+// Load returns the value for an ID, or domain.ErrNotFound if it doesn't exist
+func (l *loader[V]) Load(ctx context.Context, id uuid.UUID) (V, error) {
+	result := l.enqueue(id)
+	select {
+	case <-result.done:
+		return result.value, result.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// LoadMany returns the values for the IDs in order, batched together
+func (l *loader[V]) LoadMany(ctx context.Context, ids []uuid.UUID) ([]V, error) {
+	results := make([]*loadResult[V], len(ids))
+	for i, id := range ids {
+		results[i] = l.enqueue(id)
+	}
+
+	values := make([]V, len(ids))
+	for i, result := range results {
+		select {
+		case <-result.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if result.err != nil {
+			return nil, result.err
+		}
+		values[i] = result.value
+	}
+	return values, nil
+}
+
+// enqueue adds an ID to the pending batch unless it was already requested
+func (l *loader[V]) enqueue(id uuid.UUID) *loadResult[V] {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if result, ok := l.results[id]; ok {
+		return result
+	}
+	result := &loadResult[V]{done: make(chan struct{})}
+	l.results[id] = result
+
+	if l.pending == nil {
+		batch := &loadBatch{}
+		batch.timer = time.AfterFunc(l.wait, func() { l.dispatch(batch) })
+		l.pending = batch
+	}
+	l.pending.ids = append(l.pending.ids, id)
+	if len(l.pending.ids) >= loaderMaxBatch {
+		batch := l.pending
+		l.pending = nil
+		if batch.timer.Stop() {
+			go l.dispatch(batch)
+		}
+	}
+	return result
+}
+
+// dispatch fetches a batch and hands each waiting resolver its value
+func (l *loader[V]) dispatch(batch *loadBatch) {
+	l.mu.Lock()
+	if l.pending == batch {
+		l.pending = nil
+	}
+	l.mu.Unlock()
+
+	values, err := l.fetch(l.ctx, batch.ids)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range batch.ids {
+		result := l.results[id]
+		switch value, ok := values[id]; {
+		case err != nil:
+			result.err = err
+			// Let a later request retry what failed
+			delete(l.results, id)
+		case !ok:
+			result.err = domain.ErrNotFound
+		default:
+			result.value = value
+		}
+		close(result.done)
+	}
+}
@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func TestLoaderBatchesConcurrentLoads(t *testing.T) {
+	var mu sync.Mutex
+	var calls [][]uuid.UUID
+	known := map[uuid.UUID]int{uuid.New(): 1, uuid.New(): 2, uuid.New(): 3}
+
+	l := newLoader(context.Background(), func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]int, error) {
+		mu.Lock()
+		calls = append(calls, ids)
+		mu.Unlock()
+		found := map[uuid.UUID]int{}
+		for _, id := range ids {
+			if v, ok := known[id]; ok {
+				found[id] = v
+			}
+		}
+		return found, nil
+	})
+	// Leave the goroutines below plenty of time to join the batch
+	l.wait = 100 * time.Millisecond
+
+	ids := make([]uuid.UUID, 0, len(known))
+	for id := range known {
+		ids = append(ids, id)
+	}
+	missing := uuid.New()
+
+	var wg sync.WaitGroup
+	for _, id := range append(ids, ids[0]) {
+		wg.Add(1)
+		go func(id uuid.UUID) {
+			defer wg.Done()
+			if v, err := l.Load(context.Background(), id); err != nil || v != known[id] {
+				t.Errorf("Load(%s) = %d, %v, want %d", id, v, err, known[id])
+			}
+		}(id)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := l.Load(context.Background(), missing); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("Load(missing) error = %v, want ErrNotFound", err)
+		}
+	}()
+	wg.Wait()
+
+	if len(calls) != 1 || len(calls[0]) != len(known)+1 {
+		t.Fatalf("fetch calls = %v, want one batch of %d distinct IDs", calls, len(known)+1)
+	}
+
+	// Loaded values are cached for the rest of the request
+	values, err := l.LoadMany(context.Background(), ids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, id := range ids {
+		if values[i] != known[id] {
+			t.Errorf("LoadMany[%d] = %d, want %d", i, values[i], known[id])
+		}
+	}
+	if len(calls) != 1 {
+		t.Errorf("fetch calls = %d after cached LoadMany, want 1", len(calls))
+	}
+}
@@ -0,0 +1,135 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// Resolver is the root of the GraphQL resolvers. It holds no state of its
+// own; everything goes through the same usecases as the REST API.
+type Resolver struct {
+	usecases *usecase.UsecaseContainer
+	logger   *utils.Logger
+}
+
+// NewResolver creates the root resolver
+func NewResolver(usecases *usecase.UsecaseContainer, logger *utils.Logger) *Resolver {
+	return &Resolver{
+		usecases: usecases,
+		logger:   logger,
+	}
+}
+
+type queryResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type eventResolver struct{ *Resolver }
+type ticketResolver struct{ *Resolver }
+type bookingResolver struct{ *Resolver }
+
+// Queries
+
+func (r *queryResolver) Events(ctx context.Context) ([]*domain_event.Event, error) {
+	return r.usecases.Event.GetAllEvents(ctx)
+}
+
+func (r *queryResolver) Event(ctx context.Context, id uuid.UUID) (*domain_event.Event, error) {
+	event, err := r.usecases.Event.GetEvent(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return event, err
+}
+
+func (r *queryResolver) Tickets(ctx context.Context, eventID uuid.UUID, available *bool) ([]*domain_ticket.Ticket, error) {
+	if available != nil && *available {
+		return r.usecases.Event.GetAvailableTickets(ctx, eventID)
+	}
+	return r.usecases.Event.GetEventTickets(ctx, eventID)
+}
+
+func (r *queryResolver) Booking(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*domain_booking.Booking, error) {
+	booking, err := r.usecases.Booking.GetBooking(ctx, id, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return booking, err
+}
+
+func (r *queryResolver) Bookings(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error) {
+	return r.usecases.Booking.GetUserBookings(ctx, userID)
+}
+
+// Mutations
+
+func (r *mutationResolver) CreateBooking(ctx context.Context, input usecase.CreateBookingRequest) (*usecase.CreateBookingResponse, error) {
+	return r.usecases.Booking.CreateBooking(ctx, input)
+}
+
+func (r *mutationResolver) ConfirmBooking(ctx context.Context, input usecase.ConfirmBookingRequest) (*domain_booking.Booking, error) {
+	// A held payment is not a failure; the booking says it is in review
+	err := r.usecases.Booking.ConfirmBooking(ctx, input)
+	if err != nil && !errors.Is(err, usecase.ErrPaymentUnderReview) {
+		return nil, err
+	}
+	return r.usecases.Booking.GetBooking(ctx, input.BookingID, input.UserID)
+}
+
+func (r *mutationResolver) CancelBooking(ctx context.Context, input usecase.CancelBookingRequest) (*domain_booking.Booking, error) {
+	if err := r.usecases.Booking.CancelBooking(ctx, input); err != nil {
+		return nil, err
+	}
+	return r.usecases.Booking.GetBooking(ctx, input.BookingID, input.UserID)
+}
+
+// Field resolvers. References between types go through the request's
+// loaders so lists don't turn into one query per item.
+
+func (r *eventResolver) Tickets(ctx context.Context, obj *domain_event.Event, available *bool) ([]*domain_ticket.Ticket, error) {
+	if available != nil && *available {
+		return r.usecases.Event.GetAvailableTickets(ctx, obj.ID)
+	}
+	return r.usecases.Event.GetEventTickets(ctx, obj.ID)
+}
+
+func (r *ticketResolver) Event(ctx context.Context, obj *domain_ticket.Ticket) (*domain_event.Event, error) {
+	return For(ctx).Events.Load(ctx, obj.EventID)
+}
+
+func (r *bookingResolver) Event(ctx context.Context, obj *domain_booking.Booking) (*domain_event.Event, error) {
+	return For(ctx).Events.Load(ctx, obj.EventID)
+}
+
+func (r *bookingResolver) Tickets(ctx context.Context, obj *domain_booking.Booking) ([]*domain_ticket.Ticket, error) {
+	return For(ctx).Tickets.LoadMany(ctx, obj.TicketIDs)
+}
+
+// ErrorCode classifies a resolver error for the "code" extension of the
+// GraphQL error, mirroring the status codes the REST controllers use
+func ErrorCode(err error) string {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		return "NOT_FOUND"
+	case errors.Is(err, domain.ErrInvalidInput):
+		return "BAD_USER_INPUT"
+	case errors.Is(err, domain.ErrConflict):
+		return "CONFLICT"
+	case errors.Is(err, domain.ErrForbidden):
+		return "FORBIDDEN"
+	case errors.Is(err, domain.ErrUnauthorized):
+		return "UNAUTHENTICATED"
+	case errors.Is(err, usecase.ErrBookingOverloaded):
+		return "OVERLOADED"
+	case errors.Is(err, usecase.ErrBookingQueueFull), errors.Is(err, usecase.ErrTooManyQueuedBookings):
+		return "TOO_MANY_REQUESTS"
+	}
+	return "INTERNAL"
+}
@@ -0,0 +1,110 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type APIKeyController struct {
+	apiKeyUsecase *usecase.APIKeyUsecase
+	logger        *utils.Logger
+}
+
+// NewAPIKeyController creates a new API key controller
+func NewAPIKeyController(apiKeyUsecase *usecase.APIKeyUsecase, logger *utils.Logger) *APIKeyController {
+	return &APIKeyController{
+		apiKeyUsecase: apiKeyUsecase,
+		logger:        logger,
+	}
+}
+
+// CreateAPIKey handles POST /api/admin/api-keys
+func (c *APIKeyController) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	var req usecase.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	key, secret, err := c.apiKeyUsecase.CreateAPIKey(r.Context(), caller.UserID, req)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to create API key")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusCreated, CreatedAPIKeyResponse{APIKeyResponse: newAPIKeyResponse(key), Key: secret})
+}
+
+// ListAPIKeys handles GET /api/admin/api-keys
+func (c *APIKeyController) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+
+	keys, err := c.apiKeyUsecase.ListAPIKeys(r.Context(), caller.UserID)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list API keys")
+		return
+	}
+	c.respondWithJSON(w, http.StatusOK, newResponses(keys, newAPIKeyResponse))
+}
+
+// RevokeAPIKey handles DELETE /api/admin/api-keys/{id}
+func (c *APIKeyController) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	keyID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid API key ID")
+		return
+	}
+
+	key, err := c.apiKeyUsecase.RevokeAPIKey(r.Context(), caller.UserID, keyID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "API key not found or already revoked")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to revoke API key")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newAPIKeyResponse(key))
+}
+
+// Authenticate checks the key of a partner request, for the API key
+// middleware
+func (c *APIKeyController) Authenticate(ctx context.Context, key string, scope domain_apikey.Scope) (*domain_apikey.APIKey, error) {
+	return c.apiKeyUsecase.Authenticate(ctx, key, scope)
+}
+
+// Helper methods
+
+func (c *APIKeyController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *APIKeyController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
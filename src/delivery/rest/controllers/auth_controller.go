@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+type AuthController struct {
+	authUsecase *usecase.AuthUsecase
+	logger      *utils.Logger
+}
+
+// NewAuthController creates a new auth controller
+func NewAuthController(authUsecase *usecase.AuthUsecase, logger *utils.Logger) *AuthController {
+	return &AuthController{
+		authUsecase: authUsecase,
+		logger:      logger,
+	}
+}
+
+// Login handles POST /api/auth/login
+func (c *AuthController) Login(w http.ResponseWriter, r *http.Request) {
+	var req usecase.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tokens, err := c.authUsecase.Login(r.Context(), req, requestClient(r))
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to sign in")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, tokens)
+}
+
+// Refresh handles POST /api/auth/refresh
+func (c *AuthController) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req usecase.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tokens, err := c.authUsecase.Refresh(r.Context(), req, requestClient(r))
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to refresh session")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, tokens)
+}
+
+// ForgotPassword handles POST /api/auth/forgot. It answers the same
+// whether or not a user has the email.
+func (c *AuthController) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req usecase.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := c.authUsecase.ForgotPassword(r.Context(), req)
+	var limited *usecase.PasswordResetRateLimitError
+	if errors.As(err, &limited) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limited.RetryAfter.Seconds()))))
+		c.respondWithError(w, http.StatusTooManyRequests, "Too many password reset requests for this email; please retry later")
+		return
+	}
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to send password reset")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusAccepted, MessageResponse{Message: "If a user has this email, a reset token has been sent to it"})
+}
+
+// ResetPassword handles POST /api/auth/reset
+func (c *AuthController) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req usecase.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ended, err := c.authUsecase.ResetPassword(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to reset password")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, PasswordResetResponse{SessionsEnded: ended})
+}
+
+// VerifyEmail handles GET /api/auth/verify, the link emailed to users to
+// verify their email
+func (c *AuthController) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	user, err := c.authUsecase.VerifyEmail(r.Context(), r.URL.Query().Get("token"))
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to verify email")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+// Logout handles POST /api/auth/logout
+func (c *AuthController) Logout(w http.ResponseWriter, r *http.Request) {
+	var req usecase.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ended, err := c.authUsecase.Logout(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to sign out")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, LogoutResponse{Ended: ended})
+}
+
+// ListSessions handles GET /api/auth/sessions, listing the sessions of the
+// user the bearer access token is for
+func (c *AuthController) ListSessions(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := c.authUsecase.ListSessions(r.Context(), caller.UserID)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list sessions")
+		return
+	}
+
+	responses := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		responses[i] = newSessionResponse(session, caller.SessionID)
+	}
+	c.respondWithJSON(w, http.StatusOK, responses)
+}
+
+// oauthStateCookie keeps a social sign-in's state in the browser that
+// started it, for the callback to check
+const oauthStateCookie = "oauth_state"
+
+// StartOAuth handles GET /api/auth/oauth/{provider}/start, sending the
+// browser to sign in at the provider
+func (c *AuthController) StartOAuth(w http.ResponseWriter, r *http.Request) {
+	start, err := c.authUsecase.StartOAuth(mux.Vars(r)["provider"])
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to start sign-in")
+		return
+	}
+
+	// Lax, as the provider sends the browser back with a top-level GET
+	http.SetCookie(w, &http.Cookie{
+		Name:     oauthStateCookie,
+		Value:    start.State,
+		Path:     "/api/",
+		MaxAge:   int((10 * time.Minute).Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, start.URL, http.StatusFound)
+}
+
+// CompleteOAuth handles GET /api/auth/oauth/{provider}/callback, where the
+// provider sends the browser back to, and responds with the session's tokens
+func (c *AuthController) CompleteOAuth(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if reason := query.Get("error"); reason != "" {
+		c.respondWithError(w, http.StatusUnauthorized, "Sign-in was not completed: "+reason)
+		return
+	}
+	req := usecase.OAuthCallback{
+		Provider: mux.Vars(r)["provider"],
+		Code:     query.Get("code"),
+		State:    query.Get("state"),
+	}
+	if cookie, err := r.Cookie(oauthStateCookie); err == nil {
+		req.CookieState = cookie.Value
+	}
+	// The state is good for one callback
+	http.SetCookie(w, &http.Cookie{Name: oauthStateCookie, Path: "/api/", MaxAge: -1, HttpOnly: true})
+
+	tokens, err := c.authUsecase.CompleteOAuth(r.Context(), req, requestClient(r))
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to sign in")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, tokens)
+}
+
+// AuthenticateBearer checks an access token for the Authentication
+// middleware
+func (c *AuthController) AuthenticateBearer(ctx context.Context, token string) (*domain_session.Caller, error) {
+	claims, err := c.authUsecase.Authenticate(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return claims.Caller(), nil
+}
+
+// requireCaller returns who the request is signed in as, refusing it with
+// a 401 when it came without an access token
+func requireCaller(w http.ResponseWriter, r *http.Request) (*domain_session.Caller, bool) {
+	caller := domain_session.CallerFromContext(r.Context())
+	if caller == nil {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		problem.Write(w, http.StatusUnauthorized, "Bearer access token required")
+		return nil, false
+	}
+	return caller, true
+}
+
+// requestClient describes the client a request came from, for its session
+func requestClient(r *http.Request) domain_session.Client {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return domain_session.Client{UserAgent: r.UserAgent(), IPAddress: ip}
+}
+
+// Helper methods
+
+func (c *AuthController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *AuthController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,145 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type AvailabilityController struct {
+	availabilityUsecase *usecase.AvailabilityUsecase
+	feed                *usecase.AvailabilityFeed
+	logger              *utils.Logger
+}
+
+// NewAvailabilityController creates a new ticket availability controller
+func NewAvailabilityController(availabilityUsecase *usecase.AvailabilityUsecase, feed *usecase.AvailabilityFeed, logger *utils.Logger) *AvailabilityController {
+	return &AvailabilityController{
+		availabilityUsecase: availabilityUsecase,
+		feed:                feed,
+		logger:              logger,
+	}
+}
+
+// GetAvailability handles GET /api/events/{id}/availability
+func (c *AvailabilityController) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	summary, err := c.availabilityUsecase.GetSummary(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get ticket availability")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newAvailabilityResponse(summary))
+}
+
+// streamHeartbeat keeps idle streams from being closed by proxies
+const streamHeartbeat = 15 * time.Second
+
+// StreamAvailability handles GET /api/events/{id}/availability/stream. It
+// sends server-sent events: a snapshot of the summary, then each change to
+// the event's seats as it happens. A recounted change means the client
+// should fetch the summary again.
+func (c *AvailabilityController) StreamAvailability(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	// Watch before the snapshot so no change falls between the two
+	changes, stop := c.feed.Watch(r.Context(), eventID)
+	defer stop()
+
+	summary, err := c.availabilityUsecase.GetSummary(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get ticket availability")
+		return
+	}
+
+	rc := http.NewResponseController(w)
+	// Streams outlive the server's write timeout
+	rc.SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := c.sendEvent(w, rc, "snapshot", newAvailabilityResponse(summary)); err != nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(streamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case change, ok := <-changes:
+			if !ok {
+				// Shutting down, or too far behind: the client reconnects
+				return
+			}
+			if err := c.sendEvent(w, rc, string(change.Kind), change); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sendEvent writes one server-sent event and flushes it to the client
+func (c *AvailabilityController) sendEvent(w http.ResponseWriter, rc *http.ResponseController, name string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, data); err != nil {
+		return err
+	}
+	return rc.Flush()
+}
+
+// Helper methods
+
+func (c *AvailabilityController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *AvailabilityController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -1,9 +1,17 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
 	"github.com/ojaswiii/booking-manager/src/internal/usecase"
 	"github.com/ojaswiii/booking-manager/src/utils"
 
@@ -24,19 +32,66 @@ func NewBookingController(bookingUsecase *usecase.BookingUsecase, logger *utils.
 	}
 }
 
-// CreateBooking handles POST /api/bookings
+// CreateBooking handles POST /api/bookings, and POST /api/partner/bookings
+// where the booking is made for the API key's user
 func (c *BookingController) CreateBooking(w http.ResponseWriter, r *http.Request) {
 	var req usecase.CreateBookingRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	if key := domain_apikey.FromContext(r.Context()); key != nil {
+		req.UserID = key.UserID
+	}
 
 	// Use concurrent booking for better performance
 	response, err := c.bookingUsecase.CreateBooking(r.Context(), req)
 	if err != nil {
-		c.logger.Error("Failed to create booking", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to create booking")
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		if errors.Is(err, usecase.ErrEventNotBookable) {
+			c.respondWithError(w, http.StatusConflict, "Event is not open for booking")
+			return
+		}
+		if errors.Is(err, usecase.ErrEventSoldOut) {
+			c.respondWithError(w, http.StatusConflict, "Event is sold out")
+			return
+		}
+		if errors.Is(err, usecase.ErrPresaleAccessDenied) {
+			c.respondWithError(w, http.StatusForbidden, "Presale access required")
+			return
+		}
+		if errors.Is(err, usecase.ErrBookingOverloaded) {
+			// Send the client to the event's waiting room until load drops
+			waitingRoomURL := fmt.Sprintf("/api/events/%s/waiting-room", req.EventID)
+			w.Header().Set("Retry-After", "5")
+			w.Header().Set("Location", waitingRoomURL)
+			overloaded := problem.New(http.StatusServiceUnavailable, "Booking system is busy, please join the waiting room")
+			overloaded.Extensions = map[string]interface{}{"waiting_room": waitingRoomURL}
+			overloaded.Write(w)
+			return
+		}
+		if errors.Is(err, usecase.ErrBookingQueueFull) {
+			w.Header().Set("Retry-After", "5")
+			c.respondWithError(w, http.StatusTooManyRequests, "Booking queue is full, please retry shortly")
+			return
+		}
+		if errors.Is(err, usecase.ErrTooManyQueuedBookings) {
+			w.Header().Set("Retry-After", "5")
+			c.respondWithError(w, http.StatusTooManyRequests, "Too many booking requests waiting; please wait for them to be processed")
+			return
+		}
+		if errors.Is(err, usecase.ErrQuoteInvalid) {
+			c.respondWithError(w, http.StatusBadRequest, "Invalid quote token")
+			return
+		}
+		if errors.Is(err, usecase.ErrQuoteExpired) {
+			c.respondWithError(w, http.StatusConflict, "Quote has expired")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to create booking")
 		return
 	}
 
@@ -52,22 +107,25 @@ func (c *BookingController) ConfirmBooking(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	var req struct {
-		UserID uuid.UUID `json:"user_id"`
-	}
+	var req ConfirmBookingBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
 	confirmReq := usecase.ConfirmBookingRequest{
-		BookingID: bookingID,
-		UserID:    req.UserID,
+		BookingID:        bookingID,
+		UserID:           req.UserID,
+		Attestations:     req.Attestations,
+		PaymentReference: req.PaymentReference,
 	}
 
 	if err := c.bookingUsecase.ConfirmBooking(r.Context(), confirmReq); err != nil {
-		c.logger.Error("Failed to confirm booking", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to confirm booking")
+		if errors.Is(err, usecase.ErrPaymentUnderReview) {
+			c.respondWithJSON(w, http.StatusAccepted, map[string]string{"status": "review"})
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to confirm booking")
 		return
 	}
 
@@ -83,9 +141,7 @@ func (c *BookingController) CancelBooking(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	var req struct {
-		UserID uuid.UUID `json:"user_id"`
-	}
+	var req CancelBookingBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
 		return
@@ -97,14 +153,176 @@ func (c *BookingController) CancelBooking(w http.ResponseWriter, r *http.Request
 	}
 
 	if err := c.bookingUsecase.CancelBooking(r.Context(), cancelReq); err != nil {
-		c.logger.Error("Failed to cancel booking", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to cancel booking")
+		problem.WriteError(w, c.logger, err, "Failed to cancel booking")
 		return
 	}
 
 	c.respondWithJSON(w, http.StatusOK, map[string]string{"status": "cancelled"})
 }
 
+// ChangeSeats handles PATCH /api/bookings/{id}/tickets
+func (c *BookingController) ChangeSeats(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	var req ChangeSeatsBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	booking, err := c.bookingUsecase.ChangeSeats(r.Context(), usecase.ChangeSeatsRequest{
+		BookingID: bookingID,
+		UserID:    req.UserID,
+		Changes:   req.Changes,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to change seats")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newBookingResponse(booking))
+}
+
+// ListReviews handles GET /api/admin/bookings/reviews
+func (c *BookingController) ListReviews(w http.ResponseWriter, r *http.Request) {
+	bookings, err := c.bookingUsecase.ListReviews(r.Context())
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list bookings in review")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(bookings, newAdminBookingResponse))
+}
+
+// GetBookingHistory handles GET /api/admin/bookings/{id}/history
+func (c *BookingController) GetBookingHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	transitions, err := c.bookingUsecase.GetBookingHistory(r.Context(), bookingID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get booking history")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(transitions, newTransitionResponse))
+}
+
+// GetBookingEvents handles GET /api/admin/bookings/{id}/events
+func (c *BookingController) GetBookingEvents(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	events, err := c.bookingUsecase.GetBookingEvents(r.Context(), bookingID)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to get booking events")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(events, newBookingEventResponse))
+}
+
+// ListBookingEvents handles GET /api/admin/bookings/events
+func (c *BookingController) ListBookingEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	var after int64
+	if v := query.Get("after"); v != "" {
+		var err error
+		if after, err = strconv.ParseInt(v, 10, 64); err != nil {
+			c.respondWithError(w, http.StatusBadRequest, "Invalid position")
+			return
+		}
+	}
+	limit := 0
+	if v := query.Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.respondWithError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	events, err := c.bookingUsecase.ListBookingEvents(r.Context(), after, limit)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list booking events")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(events, newBookingEventResponse))
+}
+
+// RebuildBooking handles POST /api/admin/bookings/{id}/rebuild
+func (c *BookingController) RebuildBooking(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	booking, err := c.bookingUsecase.RebuildBooking(r.Context(), bookingID)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to rebuild booking")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newAdminBookingResponse(booking))
+}
+
+// ApproveReview handles POST /api/admin/bookings/{id}/approve
+func (c *BookingController) ApproveReview(w http.ResponseWriter, r *http.Request) {
+	c.review(w, r, c.bookingUsecase.ApproveReview)
+}
+
+// RejectReview handles POST /api/admin/bookings/{id}/reject
+func (c *BookingController) RejectReview(w http.ResponseWriter, r *http.Request) {
+	c.review(w, r, c.bookingUsecase.RejectReview)
+}
+
+func (c *BookingController) review(w http.ResponseWriter, r *http.Request, decide func(context.Context, uuid.UUID) (*domain_booking.Booking, error)) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	booking, err := decide(r.Context(), bookingID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to review booking")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newAdminBookingResponse(booking))
+}
+
 // GetUserBookings handles GET /api/users/{id}/bookings
 func (c *BookingController) GetUserBookings(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
@@ -116,12 +334,29 @@ func (c *BookingController) GetUserBookings(w http.ResponseWriter, r *http.Reque
 
 	bookings, err := c.bookingUsecase.GetUserBookings(r.Context(), userID)
 	if err != nil {
-		c.logger.Error("Failed to get user bookings", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to get user bookings")
+		problem.WriteError(w, c.logger, err, "Failed to get user bookings")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(bookings, newBookingResponse))
+}
+
+// GetPartnerBookings handles GET /api/partner/bookings, listing the
+// bookings of the API key's user
+func (c *BookingController) GetPartnerBookings(w http.ResponseWriter, r *http.Request) {
+	key := domain_apikey.FromContext(r.Context())
+	if key == nil {
+		c.respondWithError(w, http.StatusUnauthorized, "API key required")
+		return
+	}
+
+	bookings, err := c.bookingUsecase.GetUserBookings(r.Context(), key.UserID)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to get bookings")
 		return
 	}
 
-	c.respondWithJSON(w, http.StatusOK, bookings)
+	c.respondWithJSON(w, http.StatusOK, newResponses(bookings, newBookingResponse))
 }
 
 // GetStats handles GET /api/bookings/stats
@@ -130,6 +365,91 @@ func (c *BookingController) GetStats(w http.ResponseWriter, r *http.Request) {
 	c.respondWithJSON(w, http.StatusOK, stats)
 }
 
+// ListDeadLetters handles GET /api/admin/bookings/dead-letters
+func (c *BookingController) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if v := r.URL.Query().Get("limit"); v != "" {
+		var err error
+		if limit, err = strconv.Atoi(v); err != nil {
+			c.respondWithError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	letters, err := c.bookingUsecase.ListDeadLetters(r.Context(), limit)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list dead-lettered booking requests")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(letters, newDeadLetterResponse))
+}
+
+// GetDeadLetter handles GET /api/admin/bookings/dead-letters/{id}
+func (c *BookingController) GetDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, ok := c.deadLetterID(w, r)
+	if !ok {
+		return
+	}
+
+	letter, err := c.bookingUsecase.GetDeadLetter(r.Context(), id)
+	if err != nil {
+		c.respondWithDeadLetterError(w, err, "Failed to get dead-lettered booking request")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newDeadLetterResponse(letter))
+}
+
+// RequeueDeadLetter handles POST /api/admin/bookings/dead-letters/{id}/requeue
+func (c *BookingController) RequeueDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, ok := c.deadLetterID(w, r)
+	if !ok {
+		return
+	}
+
+	letter, err := c.bookingUsecase.RequeueDeadLetter(r.Context(), id)
+	if err != nil {
+		c.respondWithDeadLetterError(w, err, "Failed to requeue booking request")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusAccepted, newDeadLetterResponse(letter))
+}
+
+// DiscardDeadLetter handles DELETE /api/admin/bookings/dead-letters/{id}
+func (c *BookingController) DiscardDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, ok := c.deadLetterID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := c.bookingUsecase.DiscardDeadLetter(r.Context(), id); err != nil {
+		c.respondWithDeadLetterError(w, err, "Failed to discard booking request")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *BookingController) deadLetterID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid dead letter ID")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func (c *BookingController) respondWithDeadLetterError(w http.ResponseWriter, err error, msg string) {
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		c.respondWithError(w, http.StatusNotFound, "Dead letter not found")
+	default:
+		problem.WriteError(w, c.logger, err, msg)
+	}
+}
+
 // Helper methods
 
 func (c *BookingController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
@@ -140,5 +460,5 @@ func (c *BookingController) respondWithJSON(w http.ResponseWriter, code int, pay
 }
 
 func (c *BookingController) respondWithError(w http.ResponseWriter, code int, message string) {
-	c.respondWithJSON(w, code, map[string]string{"error": message})
+	problem.Write(w, code, message)
 }
@@ -0,0 +1,151 @@
+package controllers
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type BookingExportController struct {
+	exportUsecase *usecase.BookingExportUsecase
+	logger        *utils.Logger
+}
+
+// NewBookingExportController creates a new booking export controller
+func NewBookingExportController(exportUsecase *usecase.BookingExportUsecase, logger *utils.Logger) *BookingExportController {
+	return &BookingExportController{
+		exportUsecase: exportUsecase,
+		logger:        logger,
+	}
+}
+
+// attendeeCSVHeader names the columns of attendeeCSVRecord
+var attendeeCSVHeader = []string{
+	"booking_id", "status", "booked_at", "user_id", "name", "email",
+	"event_id", "event", "event_date", "ticket_id", "seat_number", "unit_price", "currency",
+}
+
+func attendeeCSVRecord(a *domain_booking.Attendee) []string {
+	return []string{
+		a.BookingID.String(),
+		string(a.Status),
+		a.BookedAt.UTC().Format(time.RFC3339),
+		a.UserID.String(),
+		csvText(a.UserName),
+		csvText(a.UserEmail),
+		a.EventID.String(),
+		csvText(a.EventName),
+		a.EventDate.UTC().Format(time.RFC3339),
+		a.TicketID.String(),
+		strconv.Itoa(a.SeatNumber),
+		strconv.FormatFloat(a.UnitPrice, 'f', 2, 64),
+		a.Currency,
+	}
+}
+
+// csvText keeps text customers typed from running as a formula when the
+// export is opened in a spreadsheet. Quotes, commas and line breaks are
+// escaped by the CSV writer.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// ExportEventAttendees handles GET /api/admin/events/{id}/bookings.csv, for
+// the organizer or admin the request is signed in as
+func (c *BookingExportController) ExportEventAttendees(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	c.stream(w, r, "bookings-"+eventID.String()+".csv", "Event not found", func(write func([]*domain_booking.Attendee) error) error {
+		return c.exportUsecase.ExportEventAttendees(r.Context(), caller.UserID, eventID, write)
+	})
+}
+
+// ExportUserBookings handles GET /api/users/{id}/bookings.csv
+func (c *BookingExportController) ExportUserBookings(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	c.stream(w, r, "bookings-"+userID.String()+".csv", "User not found", func(write func([]*domain_booking.Attendee) error) error {
+		return c.exportUsecase.ExportUserBookings(r.Context(), userID, write)
+	})
+}
+
+// stream writes an export as CSV, flushing each page to the client as it is
+// read. The response starts with the first page, so errors before it get a
+// problem response. Later ones abort the connection, so the client sees a
+// failed download rather than a file cut short.
+func (c *BookingExportController) stream(w http.ResponseWriter, r *http.Request, filename, notFound string, export func(write func([]*domain_booking.Attendee) error) error) {
+	rc := http.NewResponseController(w)
+	var out *csv.Writer
+	rows := 0
+	err := export(func(page []*domain_booking.Attendee) error {
+		if out == nil {
+			// Large exports outlive the server's write timeout
+			rc.SetWriteDeadline(time.Time{})
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Header().Set("Content-Disposition", `attachment; filename="`+filename+`"`)
+			w.WriteHeader(http.StatusOK)
+			out = csv.NewWriter(w)
+			out.Write(attendeeCSVHeader)
+		}
+		for _, attendee := range page {
+			out.Write(attendeeCSVRecord(attendee))
+		}
+		rows += len(page)
+		out.Flush()
+		if err := out.Error(); err != nil {
+			return err
+		}
+		return rc.Flush()
+	})
+	if err == nil {
+		return
+	}
+
+	if out == nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, notFound)
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to export bookings")
+		return
+	}
+	if r.Context().Err() == nil {
+		c.logger.Error("Booking export failed part way", "path", r.URL.Path, "rows", rows, "error", err)
+	}
+	panic(http.ErrAbortHandler)
+}
+
+// Helper methods
+
+func (c *BookingExportController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
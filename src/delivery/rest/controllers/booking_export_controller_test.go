@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+func TestExportEventAttendeesAsCSV(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{BookingExportPageSize: 2}
+	users := usecase.NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	c := NewBookingExportController(usecase.NewBookingExportUsecase(repos.BookingExport, repos.Event, users, config, logger), logger)
+	ctx := context.Background()
+
+	organizer := &domain_user.User{ID: uuid.New(), Email: "organizer@example.com", Name: "Organizer", Role: domain_user.RoleOrganizer}
+	// Names are free text: commas, quotes and line breaks must survive, and
+	// formulas must not run when the file is opened in a spreadsheet
+	fan := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "=HYPERLINK(\"x\"), \"Jr\"\nSmith", Role: domain_user.RoleCustomer}
+	for _, usr := range []*domain_user.User{organizer, fan} {
+		if err := repos.User.Create(ctx, usr); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam, Night 2", Date: time.Date(2026, 7, 1, 20, 0, 0, 0, time.UTC), TotalSeats: 5, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+
+	// Five tickets over three bookings, read two rows a page
+	bookedAt := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	seat := 0
+	for i, seats := range []int{2, 1, 2} {
+		bk := &domain_booking.Booking{ID: uuid.New(), UserID: fan.ID, EventID: event.ID, Status: domain_booking.BookingStatusConfirmed, Currency: "USD", CreatedAt: bookedAt.Add(time.Duration(i) * time.Hour)}
+		for j := 0; j < seats; j++ {
+			seat++
+			bk.Items = append(bk.Items, domain_booking.LineItem{BookingID: bk.ID, TicketID: uuid.New(), SeatNumber: seat, UnitPrice: 50, Currency: "USD"})
+		}
+		if err := repos.Booking.Create(ctx, bk); err != nil {
+			t.Fatalf("create booking: %v", err)
+		}
+	}
+
+	// A user_id in the query is ignored: the caller is who the token is for
+	export := func(caller *domain_session.Caller) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/events/"+event.ID.String()+"/bookings.csv?user_id="+organizer.ID.String(), nil)
+		req = mux.SetURLVars(req, map[string]string{"id": event.ID.String()})
+		if caller != nil {
+			req = req.WithContext(domain_session.WithCaller(req.Context(), caller))
+		}
+		w := httptest.NewRecorder()
+		c.ExportEventAttendees(w, req)
+		return w
+	}
+
+	if w := export(nil); w.Code != http.StatusUnauthorized {
+		t.Fatalf("anonymous export: status %d, want 401", w.Code)
+	}
+	if w := export(&domain_session.Caller{UserID: fan.ID}); w.Code != http.StatusForbidden {
+		t.Fatalf("customer export: status %d, want 403", w.Code)
+	}
+
+	w := export(&domain_session.Caller{UserID: organizer.ID})
+	if w.Code != http.StatusOK || !strings.HasPrefix(w.Header().Get("Content-Type"), "text/csv") {
+		t.Fatalf("export: status %d, Content-Type %q", w.Code, w.Header().Get("Content-Type"))
+	}
+	records, err := csv.NewReader(w.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("parse export: %v", err)
+	}
+	if len(records) != 6 {
+		t.Fatalf("export has %d records, want a header and 5 tickets", len(records))
+	}
+	for i, record := range records[1:] {
+		if record[10] != strconv.Itoa(i+1) {
+			t.Errorf("row %d is seat %s, want seats in booking order", i+1, record[10])
+		}
+		if record[4] != "'"+fan.Name || record[7] != event.Name || record[11] != "50.00" {
+			t.Errorf("row %d = %q", i+1, record)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+type BookingMetricsController struct {
+	bookingMetricsUsecase *usecase.BookingMetricsUsecase
+	logger                *utils.Logger
+}
+
+// NewBookingMetricsController creates a new booking metrics controller
+func NewBookingMetricsController(bookingMetricsUsecase *usecase.BookingMetricsUsecase, logger *utils.Logger) *BookingMetricsController {
+	return &BookingMetricsController{
+		bookingMetricsUsecase: bookingMetricsUsecase,
+		logger:                logger,
+	}
+}
+
+// GetBookingMetrics handles GET /api/admin/metrics/bookings
+func (c *BookingMetricsController) GetBookingMetrics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from, err := parseTimeParam(query.Get("from"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid from: "+err.Error())
+		return
+	}
+	to, err := parseTimeParam(query.Get("to"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid to: "+err.Error())
+		return
+	}
+
+	metrics, err := c.bookingMetricsUsecase.GetMetrics(r.Context(), from, to)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to get booking metrics")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newBookingMetricsResponse(metrics))
+}
+
+// Helper methods
+
+func (c *BookingMetricsController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *BookingMetricsController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
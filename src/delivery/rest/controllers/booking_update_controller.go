@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	bookingUpdatesWriteWait  = 10 * time.Second
+	bookingUpdatesPongWait   = 60 * time.Second
+	bookingUpdatesPingPeriod = bookingUpdatesPongWait * 9 / 10
+	bookingUpdatesMaxMessage = 4096
+)
+
+// The socket carries no cookies or credentials, and only delivers updates
+// for request IDs the client already knows, so any origin may open one
+var bookingUpdatesUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     func(r *http.Request) bool { return true },
+}
+
+// BookingUpdatesMessage is sent by clients to follow queued booking requests
+type BookingUpdatesMessage struct {
+	Subscribe []string `json:"subscribe"`
+}
+
+type BookingUpdateController struct {
+	updates *usecase.BookingUpdateFeed
+	logger  *utils.Logger
+}
+
+// NewBookingUpdateController creates a new booking update controller
+func NewBookingUpdateController(updates *usecase.BookingUpdateFeed, logger *utils.Logger) *BookingUpdateController {
+	return &BookingUpdateController{
+		updates: updates,
+		logger:  logger,
+	}
+}
+
+// WatchBookings handles GET /api/bookings/updates. The connection is
+// upgraded to a WebSocket on which the client sends the request IDs of its
+// queued bookings to follow, and receives each update to them as it
+// happens: created, failed, confirmed, expired or cancelled.
+func (c *BookingUpdateController) WatchBookings(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		problem.Write(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	conn, err := bookingUpdatesUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already responded
+		c.logger.Debug("Failed to open booking updates socket", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	watcher := c.updates.Watch(ctx, userID)
+	defer watcher.Close()
+
+	go c.readSubscriptions(ctx, cancel, conn, watcher)
+
+	ping := time.NewTicker(bookingUpdatesPingPeriod)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseGoingAway, ""), time.Now().Add(bookingUpdatesWriteWait))
+			return
+		case update, ok := <-watcher.Updates():
+			if !ok {
+				// Shutting down, or too far behind: the client reconnects
+				// and follows its requests again
+				conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, ""), time.Now().Add(bookingUpdatesWriteWait))
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(bookingUpdatesWriteWait))
+			if err := conn.WriteJSON(update); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(bookingUpdatesWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// readSubscriptions follows the requests the client sends until the
+// connection closes, then cancels the socket's context. Messages that
+// cannot be read are ignored.
+func (c *BookingUpdateController) readSubscriptions(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, watcher *usecase.BookingWatcher) {
+	defer cancel()
+
+	conn.SetReadLimit(bookingUpdatesMaxMessage)
+	conn.SetReadDeadline(time.Now().Add(bookingUpdatesPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(bookingUpdatesPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		var msg BookingUpdatesMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			continue
+		}
+		for _, requestID := range msg.Subscribe {
+			if err := watcher.Follow(ctx, requestID); err != nil {
+				c.logger.Debug("Failed to follow booking request", "request_id", requestID, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type BroadcastController struct {
+	broadcastUsecase *usecase.BroadcastUsecase
+	logger           *utils.Logger
+}
+
+// NewBroadcastController creates a new event broadcast controller
+func NewBroadcastController(broadcastUsecase *usecase.BroadcastUsecase, logger *utils.Logger) *BroadcastController {
+	return &BroadcastController{
+		broadcastUsecase: broadcastUsecase,
+		logger:           logger,
+	}
+}
+
+// CreateBroadcast handles POST /api/events/{id}/broadcast
+func (c *BroadcastController) CreateBroadcast(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req domain_broadcast.CreateBroadcastRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	job, err := c.broadcastUsecase.CreateBroadcast(r.Context(), eventID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to create broadcast")
+		}
+		return
+	}
+
+	// Messages go out in the background; the job links to the delivery report when done
+	w.Header().Set("Location", "/api/admin/jobs/"+job.ID.String())
+	c.respondWithJSON(w, http.StatusAccepted, newJobResponse(job))
+}
+
+// ListBroadcasts handles GET /api/events/{id}/broadcasts
+func (c *BroadcastController) ListBroadcasts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	broadcasts, err := c.broadcastUsecase.ListBroadcasts(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to list broadcasts")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(broadcasts, newBroadcastResponse))
+}
+
+// GetBroadcast handles GET /api/events/{id}/broadcasts/{broadcast_id}
+func (c *BroadcastController) GetBroadcast(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	broadcastID, err := uuid.Parse(vars["broadcast_id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid broadcast ID")
+		return
+	}
+
+	broadcast, err := c.broadcastUsecase.GetBroadcast(r.Context(), eventID, broadcastID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Broadcast not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get broadcast")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newBroadcastResponse(broadcast))
+}
+
+// Helper methods
+
+func (c *BroadcastController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *BroadcastController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
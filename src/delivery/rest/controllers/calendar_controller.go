@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type CalendarController struct {
+	calendarUsecase *usecase.CalendarUsecase
+	logger          *utils.Logger
+}
+
+// NewCalendarController creates a new calendar controller
+func NewCalendarController(calendarUsecase *usecase.CalendarUsecase, logger *utils.Logger) *CalendarController {
+	return &CalendarController{
+		calendarUsecase: calendarUsecase,
+		logger:          logger,
+	}
+}
+
+// GetEventCalendar handles GET /api/events/{id}.ics
+func (c *CalendarController) GetEventCalendar(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	calendar, err := c.calendarUsecase.EventCalendar(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get event calendar")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="event-`+eventID.String()+`.ics"`)
+	c.respondWithCalendar(w, calendar)
+}
+
+// GetUserCalendar handles GET /api/users/{id}/calendar.ics, a feed of the
+// user's confirmed bookings for calendar apps to subscribe to
+func (c *CalendarController) GetUserCalendar(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	calendar, err := c.calendarUsecase.UserCalendar(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get booking calendar")
+		return
+	}
+
+	c.respondWithCalendar(w, calendar)
+}
+
+// Helper methods
+
+func (c *CalendarController) respondWithCalendar(w http.ResponseWriter, calendar []byte) {
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Length", strconv.Itoa(len(calendar)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(calendar)
+}
+
+func (c *CalendarController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
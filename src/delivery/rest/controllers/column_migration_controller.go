@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+type ColumnMigrationController struct {
+	columnMigrationUsecase *usecase.ColumnMigrationUsecase
+	logger                 *utils.Logger
+}
+
+// NewColumnMigrationController creates a new column migration controller
+func NewColumnMigrationController(columnMigrationUsecase *usecase.ColumnMigrationUsecase, logger *utils.Logger) *ColumnMigrationController {
+	return &ColumnMigrationController{
+		columnMigrationUsecase: columnMigrationUsecase,
+		logger:                 logger,
+	}
+}
+
+// ListMigrations handles GET /api/admin/column-migrations
+func (c *ColumnMigrationController) ListMigrations(w http.ResponseWriter, r *http.Request) {
+	migrations, err := c.columnMigrationUsecase.ListMigrations(r.Context())
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list column migrations")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, migrations)
+}
+
+// Backfill handles POST /api/admin/column-migrations/{name}/backfill
+func (c *ColumnMigrationController) Backfill(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["name"]
+
+	job, err := c.columnMigrationUsecase.Backfill(r.Context(), name)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Column migration not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to start column backfill")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusAccepted, newJobResponse(job))
+}
+
+// Helper methods
+
+func (c *ColumnMigrationController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *ColumnMigrationController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type DeletionController struct {
+	deletionUsecase *usecase.DeletionUsecase
+	logger          *utils.Logger
+}
+
+// NewDeletionController creates a new deletion controller
+func NewDeletionController(deletionUsecase *usecase.DeletionUsecase, logger *utils.Logger) *DeletionController {
+	return &DeletionController{
+		deletionUsecase: deletionUsecase,
+		logger:          logger,
+	}
+}
+
+// DeleteEvent handles DELETE /api/admin/events/{id}
+func (c *DeletionController) DeleteEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, adminID, ok := c.parseIDs(w, r, "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	if err := c.deletionUsecase.DeleteEvent(r.Context(), adminID, eventID); err != nil {
+		c.respondWithFailure(w, err, "Event not found", "Failed to delete event")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, MessageResponse{Message: "Event deleted successfully"})
+}
+
+// RestoreEvent handles POST /api/admin/events/{id}/restore
+func (c *DeletionController) RestoreEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, adminID, ok := c.parseIDs(w, r, "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	event, err := c.deletionUsecase.RestoreEvent(r.Context(), adminID, eventID)
+	if err != nil {
+		c.respondWithFailure(w, err, "Deleted event not found", "Failed to restore event")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newEventResponse(event))
+}
+
+// PurgeEvent handles DELETE /api/admin/events/{id}/purge
+func (c *DeletionController) PurgeEvent(w http.ResponseWriter, r *http.Request) {
+	eventID, adminID, ok := c.parseIDs(w, r, "Invalid event ID")
+	if !ok {
+		return
+	}
+
+	if err := c.deletionUsecase.PurgeEvent(r.Context(), adminID, eventID); err != nil {
+		c.respondWithFailure(w, err, "Deleted event not found", "Failed to purge event")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, MessageResponse{Message: "Event purged successfully"})
+}
+
+// RestoreUser handles POST /api/admin/users/{id}/restore
+func (c *DeletionController) RestoreUser(w http.ResponseWriter, r *http.Request) {
+	userID, adminID, ok := c.parseIDs(w, r, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	user, err := c.deletionUsecase.RestoreUser(r.Context(), adminID, userID)
+	if err != nil {
+		c.respondWithFailure(w, err, "Deleted user not found", "Failed to restore user")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+// PurgeUser handles DELETE /api/admin/users/{id}/purge
+func (c *DeletionController) PurgeUser(w http.ResponseWriter, r *http.Request) {
+	userID, adminID, ok := c.parseIDs(w, r, "Invalid user ID")
+	if !ok {
+		return
+	}
+
+	if err := c.deletionUsecase.PurgeUser(r.Context(), adminID, userID); err != nil {
+		c.respondWithFailure(w, err, "Deleted user not found", "Failed to purge user")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, MessageResponse{Message: "User purged successfully"})
+}
+
+// Helper methods
+
+// parseIDs reads the {id} being acted on and the admin the request is
+// signed in as
+func (c *DeletionController) parseIDs(w http.ResponseWriter, r *http.Request, invalidID string) (id, adminID uuid.UUID, ok bool) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, invalidID)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, caller.UserID, true
+}
+
+// respondWithFailure writes err, with notFound as the message for a missing
+// record. A missing admin is reported as unauthorized by the usecase.
+func (c *DeletionController) respondWithFailure(w http.ResponseWriter, err error, notFound, message string) {
+	if errors.Is(err, domain.ErrNotFound) {
+		c.respondWithError(w, http.StatusNotFound, notFound)
+		return
+	}
+	problem.WriteError(w, c.logger, err, message)
+}
+
+func (c *DeletionController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *DeletionController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -1,9 +1,19 @@
 package controllers
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
 	"github.com/ojaswiii/booking-manager/src/internal/usecase"
 	"github.com/ojaswiii/booking-manager/src/utils"
 
@@ -34,8 +44,7 @@ func (c *EventController) CreateEvent(w http.ResponseWriter, r *http.Request) {
 
 	response, err := c.eventUsecase.CreateEvent(r.Context(), req)
 	if err != nil {
-		c.logger.Error("Failed to create event", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to create event")
+		problem.WriteError(w, c.logger, err, "Failed to create event")
 		return
 	}
 
@@ -53,28 +62,37 @@ func (c *EventController) GetEvent(w http.ResponseWriter, r *http.Request) {
 
 	event, err := c.eventUsecase.GetEvent(r.Context(), eventID)
 	if err != nil {
-		if err.Error() == "resource not found" {
+		if errors.Is(err, domain.ErrNotFound) {
 			c.respondWithError(w, http.StatusNotFound, "Event not found")
 			return
 		}
-		c.logger.Error("Failed to get event", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to get event")
+		problem.WriteError(w, c.logger, err, "Failed to get event")
 		return
 	}
 
-	c.respondWithJSON(w, http.StatusOK, event)
+	c.respondWithJSON(w, http.StatusOK, newEventResponse(event))
 }
 
-// GetAllEvents handles GET /api/events
+// GetAllEvents handles GET /api/events, with optional search filters
 func (c *EventController) GetAllEvents(w http.ResponseWriter, r *http.Request) {
-	events, err := c.eventUsecase.GetAllEvents(r.Context())
+	filter, filtered, err := parseEventFilter(r)
 	if err != nil {
-		c.logger.Error("Failed to get events", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to get events")
+		c.respondWithError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	c.respondWithJSON(w, http.StatusOK, events)
+	var events []*domain_event.Event
+	if filtered {
+		events, err = c.eventUsecase.SearchEvents(r.Context(), filter)
+	} else {
+		events, err = c.eventUsecase.GetAllEvents(r.Context())
+	}
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to get events")
+		return
+	}
+
+	c.respondWithETag(w, r, newResponses(events, newEventResponse))
 }
 
 // GetEventTickets handles GET /api/events/{id}/tickets
@@ -88,12 +106,15 @@ func (c *EventController) GetEventTickets(w http.ResponseWriter, r *http.Request
 
 	tickets, err := c.eventUsecase.GetEventTickets(r.Context(), eventID)
 	if err != nil {
-		c.logger.Error("Failed to get event tickets", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to get event tickets")
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get event tickets")
 		return
 	}
 
-	c.respondWithJSON(w, http.StatusOK, tickets)
+	c.respondWithETag(w, r, newResponses(tickets, newTicketResponse))
 }
 
 // GetAvailableTickets handles GET /api/events/{id}/tickets/available
@@ -107,12 +128,146 @@ func (c *EventController) GetAvailableTickets(w http.ResponseWriter, r *http.Req
 
 	tickets, err := c.eventUsecase.GetAvailableTickets(r.Context(), eventID)
 	if err != nil {
-		c.logger.Error("Failed to get available tickets", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to get available tickets")
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get available tickets")
+		return
+	}
+
+	c.respondWithETag(w, r, newResponses(tickets, newTicketResponse))
+}
+
+// GetAllEventsAdmin handles GET /api/admin/events, with optional search filters
+func (c *EventController) GetAllEventsAdmin(w http.ResponseWriter, r *http.Request) {
+	filter, filtered, err := parseEventFilter(r)
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var events []*domain_event.Event
+	if filtered {
+		events, err = c.eventUsecase.SearchEventsAdmin(r.Context(), filter)
+	} else {
+		events, err = c.eventUsecase.GetAllEventsAdmin(r.Context())
+	}
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to get events")
 		return
 	}
 
-	c.respondWithJSON(w, http.StatusOK, tickets)
+	c.respondWithJSON(w, http.StatusOK, newResponses(events, newEventResponse))
+}
+
+// TransitionEventStatus handles PUT /api/admin/events/{id}/status
+func (c *EventController) TransitionEventStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req TransitionEventStatusBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	event, err := c.eventUsecase.TransitionEventStatus(r.Context(), eventID, req.Status)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to update event status")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newEventResponse(event))
+}
+
+// eventFilterKeys are the query parameters parseEventFilter reads
+var eventFilterKeys = []string{
+	"q", "artist", "venue", "status", "from", "to",
+	"min_price", "max_price", "sort", "order", "limit", "offset",
+}
+
+// parseEventFilter reads search filters from the query string. The second
+// result is false when no filter was given, so callers can use the cached
+// listing; other parameters, such as a cache buster, are ignored.
+func parseEventFilter(r *http.Request) (domain_event.EventFilter, bool, error) {
+	query := r.URL.Query()
+	filtered := false
+	for _, key := range eventFilterKeys {
+		if query.Get(key) != "" {
+			filtered = true
+		}
+	}
+	filter := domain_event.EventFilter{
+		Query:   query.Get("q"),
+		Artist:  query.Get("artist"),
+		Venue:   query.Get("venue"),
+		SortBy:  query.Get("sort"),
+		SortDir: query.Get("order"),
+	}
+
+	if status := query.Get("status"); status != "" {
+		for _, s := range strings.Split(status, ",") {
+			filter.Statuses = append(filter.Statuses, domain_event.EventStatus(strings.TrimSpace(s)))
+		}
+	}
+
+	var err error
+	if filter.DateFrom, err = parseTimeParam(query.Get("from")); err != nil {
+		return filter, false, fmt.Errorf("invalid from: %v", err)
+	}
+	if filter.DateTo, err = parseTimeParam(query.Get("to")); err != nil {
+		return filter, false, fmt.Errorf("invalid to: %v", err)
+	}
+	if filter.MinPrice, err = parseFloatParam(query.Get("min_price")); err != nil {
+		return filter, false, fmt.Errorf("invalid min_price: %v", err)
+	}
+	if filter.MaxPrice, err = parseFloatParam(query.Get("max_price")); err != nil {
+		return filter, false, fmt.Errorf("invalid max_price: %v", err)
+	}
+	if v := query.Get("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil {
+			return filter, false, fmt.Errorf("invalid limit: %v", err)
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		if filter.Offset, err = strconv.Atoi(v); err != nil {
+			return filter, false, fmt.Errorf("invalid offset: %v", err)
+		}
+	}
+
+	return filter, filtered, nil
+}
+
+func parseTimeParam(v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := utils.ParseTime(v)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func parseFloatParam(v string) (*float64, error) {
+	if v == "" {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
 }
 
 // Helper methods
@@ -124,6 +279,38 @@ func (c *EventController) respondWithJSON(w http.ResponseWriter, code int, paylo
 	w.Write(response)
 }
 
+// respondWithETag sends a 200 with a weak ETag of the payload, or a 304
+// without a body when the client already has it. Clients polling listings
+// during an on-sale then only download them when they change.
+func (c *EventController) respondWithETag(w http.ResponseWriter, r *http.Request, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	sum := sha256.Sum256(response)
+	etag := `W/"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(response)
+}
+
+// etagMatches reports whether an If-None-Match header lists the ETag, using
+// the weak comparison GET requests call for
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *EventController) respondWithError(w http.ResponseWriter, code int, message string) {
-	c.respondWithJSON(w, code, map[string]string{"error": message})
+	problem.Write(w, code, message)
 }
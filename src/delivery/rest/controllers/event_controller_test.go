@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+func TestRespondWithETag(t *testing.T) {
+	c := NewEventController(nil, utils.NewLogger())
+	payload := []TicketResponse{{SeatNumber: 1, Price: 50}}
+
+	w := httptest.NewRecorder()
+	c.respondWithETag(w, httptest.NewRequest(http.MethodGet, "/api/v1/events/1/tickets/available", nil), payload)
+	etag := w.Header().Get("ETag")
+	if w.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first request: status %d, ETag %q", w.Code, etag)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/1/tickets/available", nil)
+	req.Header.Set("If-None-Match", `"other", `+etag)
+	w = httptest.NewRecorder()
+	c.respondWithETag(w, req, payload)
+	if w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("unchanged: status %d with %d bytes, want 304 and no body", w.Code, w.Body.Len())
+	}
+
+	payload[0].Status = "reserved"
+	w = httptest.NewRecorder()
+	c.respondWithETag(w, req, payload)
+	if w.Code != http.StatusOK || w.Header().Get("ETag") == etag {
+		t.Errorf("changed: status %d, ETag %q, want 200 and a new ETag", w.Code, w.Header().Get("ETag"))
+	}
+}
+
+func TestParseEventFilterIgnoresUnknownParameters(t *testing.T) {
+	tests := []struct {
+		query        string
+		wantFiltered bool
+	}{
+		{"", false},
+		{"?_=1718000000", false},
+		{"?q=", false},
+		{"?q=rock&_=1718000000", true},
+		{"?limit=20", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, filtered, err := parseEventFilter(httptest.NewRequest(http.MethodGet, "/api/v1/events"+tt.query, nil))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if filtered != tt.wantFiltered {
+				t.Errorf("filtered = %v, want %v", filtered, tt.wantFiltered)
+			}
+		})
+	}
+}
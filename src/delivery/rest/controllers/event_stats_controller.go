@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type EventStatsController struct {
+	eventStatsUsecase *usecase.EventStatsUsecase
+	logger            *utils.Logger
+}
+
+// NewEventStatsController creates a new event statistics controller
+func NewEventStatsController(eventStatsUsecase *usecase.EventStatsUsecase, logger *utils.Logger) *EventStatsController {
+	return &EventStatsController{
+		eventStatsUsecase: eventStatsUsecase,
+		logger:            logger,
+	}
+}
+
+// GetEventStats handles GET /api/admin/events/{id}/stats
+func (c *EventStatsController) GetEventStats(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	stats, err := c.eventStatsUsecase.GetEventStats(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get event stats")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newEventStatsResponse(stats))
+}
+
+// Helper methods
+
+func (c *EventStatsController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *EventStatsController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+type HealthController struct {
+	healthUsecase *usecase.HealthUsecase
+	logger        *utils.Logger
+}
+
+// NewHealthController creates a new health probe controller
+func NewHealthController(healthUsecase *usecase.HealthUsecase, logger *utils.Logger) *HealthController {
+	return &HealthController{
+		healthUsecase: healthUsecase,
+		logger:        logger,
+	}
+}
+
+// Liveness handles GET /healthz, answering while the process runs
+func (c *HealthController) Liveness(w http.ResponseWriter, r *http.Request) {
+	c.respondWithJSON(w, http.StatusOK, c.healthUsecase.Liveness())
+}
+
+// Readiness handles GET /readyz with the status of each dependency. It
+// answers 503 only while a critical one is down; a degraded instance still
+// takes traffic.
+func (c *HealthController) Readiness(w http.ResponseWriter, r *http.Request) {
+	report := c.healthUsecase.Readiness(r.Context())
+	code := http.StatusOK
+	if !report.Ready() {
+		code = http.StatusServiceUnavailable
+	}
+	c.respondWithJSON(w, code, report)
+}
+
+// Helper methods
+
+func (c *HealthController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	// Probes must see the current state, never a cached one
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	w.Write(response)
+}
@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type HoldController struct {
+	holdUsecase *usecase.HoldUsecase
+	logger      *utils.Logger
+}
+
+// NewHoldController creates a new seat hold controller
+func NewHoldController(holdUsecase *usecase.HoldUsecase, logger *utils.Logger) *HoldController {
+	return &HoldController{
+		holdUsecase: holdUsecase,
+		logger:      logger,
+	}
+}
+
+// CreateHold handles POST /api/admin/events/{id}/holds
+func (c *HoldController) CreateHold(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req domain_hold.CreateHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	hold, err := c.holdUsecase.CreateHold(r.Context(), eventID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to hold seats")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusCreated, newHoldResponse(hold))
+}
+
+// ListHolds handles GET /api/admin/events/{id}/holds
+func (c *HoldController) ListHolds(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	holds, err := c.holdUsecase.ListHolds(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to list seat holds")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(holds, newHoldResponse))
+}
+
+// ReleaseHold handles POST /api/admin/events/{id}/holds/{hold_id}/release
+func (c *HoldController) ReleaseHold(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	holdID, err := uuid.Parse(vars["hold_id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid hold ID")
+		return
+	}
+
+	response, err := c.holdUsecase.ReleaseHold(r.Context(), eventID, holdID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Seat hold not found")
+		case errors.Is(err, domain.ErrConflict):
+			c.respondWithError(w, http.StatusConflict, "Seat hold already released")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to release seat hold")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, ReleaseHoldResponse{Hold: newHoldResponse(response.Hold), Released: response.Released})
+}
+
+// Helper methods
+
+func (c *HoldController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *HoldController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
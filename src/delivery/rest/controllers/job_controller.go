@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type JobController struct {
+	jobUsecase *usecase.JobUsecase
+	logger     *utils.Logger
+}
+
+// NewJobController creates a new background job controller
+func NewJobController(jobUsecase *usecase.JobUsecase, logger *utils.Logger) *JobController {
+	return &JobController{
+		jobUsecase: jobUsecase,
+		logger:     logger,
+	}
+}
+
+// GetJob handles GET /api/admin/jobs/{id}
+func (c *JobController) GetJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid job ID")
+		return
+	}
+
+	job, err := c.jobUsecase.GetJob(r.Context(), jobID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Job not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get job")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newJobResponse(job))
+}
+
+// Helper methods
+
+func (c *JobController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *JobController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
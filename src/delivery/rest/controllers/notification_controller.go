@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type NotificationController struct {
+	notificationUsecase *usecase.NotificationUsecase
+	logger              *utils.Logger
+}
+
+// NewNotificationController creates a new notification controller
+func NewNotificationController(notificationUsecase *usecase.NotificationUsecase, logger *utils.Logger) *NotificationController {
+	return &NotificationController{
+		notificationUsecase: notificationUsecase,
+		logger:              logger,
+	}
+}
+
+// GetPreferences handles GET /api/users/{id}/preferences
+func (c *NotificationController) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	preferences, err := c.notificationUsecase.GetPreferences(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get notification preferences")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newPreferencesResponse(preferences))
+}
+
+// UpdatePreferences handles PUT /api/users/{id}/preferences
+func (c *NotificationController) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req domain_notification.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	preferences, err := c.notificationUsecase.UpdatePreferences(r.Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "User not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to update notification preferences")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newPreferencesResponse(preferences))
+}
+
+// Helper methods
+
+func (c *NotificationController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *NotificationController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
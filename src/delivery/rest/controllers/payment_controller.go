@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/payments"
+)
+
+// maxPaymentWebhookBytes caps the size of webhook bodies read from the provider
+const maxPaymentWebhookBytes = 1 << 20
+
+type PaymentController struct {
+	paymentUsecase *usecase.PaymentUsecase
+	logger         *utils.Logger
+}
+
+// NewPaymentController creates a new payment controller
+func NewPaymentController(paymentUsecase *usecase.PaymentUsecase, logger *utils.Logger) *PaymentController {
+	return &PaymentController{
+		paymentUsecase: paymentUsecase,
+		logger:         logger,
+	}
+}
+
+// Webhook handles POST /api/payments/webhook
+func (c *PaymentController) Webhook(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPaymentWebhookBytes))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	booking, err := c.paymentUsecase.HandleWebhook(r.Context(), payload, r.Header)
+	if err != nil {
+		switch {
+		case errors.Is(err, payments.ErrInvalidSignature):
+			c.logger.Warn("Rejected payment webhook", "error", err)
+			c.respondWithError(w, http.StatusUnauthorized, "Invalid signature")
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to handle payment webhook")
+		}
+		return
+	}
+
+	if booking == nil {
+		c.respondWithJSON(w, http.StatusOK, map[string]string{"status": "ignored"})
+		return
+	}
+	c.respondWithJSON(w, http.StatusOK, map[string]string{"status": string(booking.Status)})
+}
+
+// Helper methods
+
+func (c *PaymentController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *PaymentController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,128 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_presale "github.com/ojaswiii/booking-manager/src/internal/domain/presale"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type PresaleController struct {
+	presaleUsecase *usecase.PresaleUsecase
+	logger         *utils.Logger
+}
+
+// NewPresaleController creates a new presale controller
+func NewPresaleController(presaleUsecase *usecase.PresaleUsecase, logger *utils.Logger) *PresaleController {
+	return &PresaleController{
+		presaleUsecase: presaleUsecase,
+		logger:         logger,
+	}
+}
+
+// GenerateCodes handles POST /api/admin/events/{id}/presale-codes
+func (c *PresaleController) GenerateCodes(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req domain_presale.GenerateCodesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	job, err := c.presaleUsecase.GenerateCodes(r.Context(), eventID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to generate presale codes")
+		}
+		return
+	}
+
+	// Codes are generated in the background; the job links to the batch when done
+	w.Header().Set("Location", "/api/admin/jobs/"+job.ID.String())
+	c.respondWithJSON(w, http.StatusAccepted, newJobResponse(job))
+}
+
+// GetBatch handles GET /api/admin/events/{id}/presale-codes/batches/{batch_id}
+func (c *PresaleController) GetBatch(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	batchID, err := uuid.Parse(vars["batch_id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid batch ID")
+		return
+	}
+
+	response, err := c.presaleUsecase.GetBatch(r.Context(), eventID, batchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Presale code batch not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get presale code batch")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, response)
+}
+
+// RedeemCode handles POST /api/events/{id}/presale/redeem
+func (c *PresaleController) RedeemCode(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req domain_presale.RedeemCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if err := c.presaleUsecase.RedeemCode(r.Context(), eventID, req); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Presale code not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to redeem presale code")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, map[string]string{"status": "redeemed"})
+}
+
+// Helper methods
+
+func (c *PresaleController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *PresaleController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type PrivacyController struct {
+	privacyUsecase *usecase.PrivacyUsecase
+	logger         *utils.Logger
+}
+
+// NewPrivacyController creates a new privacy controller
+func NewPrivacyController(privacyUsecase *usecase.PrivacyUsecase, logger *utils.Logger) *PrivacyController {
+	return &PrivacyController{
+		privacyUsecase: privacyUsecase,
+		logger:         logger,
+	}
+}
+
+// ExportUserData handles GET /api/users/{id}/export, a JSON archive of
+// everything kept about the user
+func (c *PrivacyController) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	export, err := c.privacyUsecase.ExportUserData(r.Context(), userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to export user data")
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="user-`+userID.String()+`.json"`)
+	c.respondWithJSON(w, http.StatusOK, newUserDataExportResponse(export))
+}
+
+// EraseUser handles DELETE /api/users/{id}/erase, by the user the request
+// is signed in as or an admin
+func (c *PrivacyController) EraseUser(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	entry, err := c.privacyUsecase.EraseUser(r.Context(), caller.UserID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to erase user")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newAuditEntryResponse(entry))
+}
+
+// Helper methods
+
+func (c *PrivacyController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *PrivacyController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
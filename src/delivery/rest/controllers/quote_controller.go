@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+type QuoteController struct {
+	quoteUsecase *usecase.QuoteUsecase
+	logger       *utils.Logger
+}
+
+// NewQuoteController creates a new quote controller
+func NewQuoteController(quoteUsecase *usecase.QuoteUsecase, logger *utils.Logger) *QuoteController {
+	return &QuoteController{
+		quoteUsecase: quoteUsecase,
+		logger:       logger,
+	}
+}
+
+// CreateQuote handles POST /api/quotes
+func (c *QuoteController) CreateQuote(w http.ResponseWriter, r *http.Request) {
+	var req usecase.CreateQuoteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := c.quoteUsecase.CreateQuote(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to create quote")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, response)
+}
+
+// Helper methods
+
+func (c *QuoteController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *QuoteController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+type ReadModelController struct {
+	readModelUsecase *usecase.ReadModelUsecase
+	logger           *utils.Logger
+}
+
+// NewReadModelController creates a new read model controller
+func NewReadModelController(readModelUsecase *usecase.ReadModelUsecase, logger *utils.Logger) *ReadModelController {
+	return &ReadModelController{
+		readModelUsecase: readModelUsecase,
+		logger:           logger,
+	}
+}
+
+// ListEventAvailability handles GET /api/admin/dashboard/availability
+func (c *ReadModelController) ListEventAvailability(w http.ResponseWriter, r *http.Request) {
+	availability, err := c.readModelUsecase.ListEventAvailability(r.Context())
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to get event availability")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(availability, newEventAvailabilityResponse))
+}
+
+// Rebuild handles POST /api/admin/read-models/rebuild
+func (c *ReadModelController) Rebuild(w http.ResponseWriter, r *http.Request) {
+	if err := c.readModelUsecase.Rebuild(r.Context()); err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to rebuild read models")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusAccepted, MessageResponse{Message: "Read models are being rebuilt"})
+}
+
+// Helper methods
+
+func (c *ReadModelController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type RefundController struct {
+	refundUsecase *usecase.RefundUsecase
+	logger        *utils.Logger
+}
+
+// NewRefundController creates a new refund controller
+func NewRefundController(refundUsecase *usecase.RefundUsecase, logger *utils.Logger) *RefundController {
+	return &RefundController{
+		refundUsecase: refundUsecase,
+		logger:        logger,
+	}
+}
+
+// RefundBooking handles POST /api/bookings/{id}/refund
+func (c *RefundController) RefundBooking(w http.ResponseWriter, r *http.Request) {
+	c.refund(w, r, false)
+}
+
+// AdminRefundBooking handles POST /api/admin/bookings/{id}/refund
+func (c *RefundController) AdminRefundBooking(w http.ResponseWriter, r *http.Request) {
+	c.refund(w, r, true)
+}
+
+func (c *RefundController) refund(w http.ResponseWriter, r *http.Request, admin bool) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	var req domain_refund.RefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !admin && req.UserID == uuid.Nil {
+		c.respondWithError(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	refund, err := c.refundUsecase.RefundBooking(r.Context(), bookingID, req, admin)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+		case errors.Is(err, usecase.ErrRefundFailed):
+			c.respondWithError(w, http.StatusBadGateway, err.Error())
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to refund booking")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusCreated, newRefundResponse(refund))
+}
+
+// ListRefunds handles GET /api/bookings/{id}/refunds?user_id=...
+func (c *RefundController) ListRefunds(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	refunds, err := c.refundUsecase.ListRefunds(r.Context(), bookingID, &userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to list refunds")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(refunds, newRefundResponse))
+}
+
+// PreviewCancellation handles GET /api/bookings/{id}/cancellation-preview?user_id=...
+func (c *RefundController) PreviewCancellation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	preview, err := c.refundUsecase.PreviewCancellation(r.Context(), bookingID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to preview cancellation")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, preview)
+}
+
+// ReturnTickets handles POST /api/bookings/{id}/returns
+func (c *RefundController) ReturnTickets(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+
+	var req domain_refund.ReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.UserID == uuid.Nil {
+		c.respondWithError(w, http.StatusBadRequest, "user_id is required")
+		return
+	}
+
+	ret, err := c.refundUsecase.ReturnTickets(r.Context(), bookingID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to return tickets")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusCreated, newReturnResponse(ret))
+}
+
+// ListReturns handles GET /api/bookings/{id}/returns?user_id=...
+func (c *RefundController) ListReturns(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	returns, err := c.refundUsecase.ListReturns(r.Context(), bookingID, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to list returns")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(returns, newReturnResponse))
+}
+
+// GetPolicy handles GET /api/admin/events/{id}/refund-policy
+func (c *RefundController) GetPolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	policy, err := c.refundUsecase.GetPolicy(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get refund policy")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newRefundPolicyResponse(policy))
+}
+
+// UpdatePolicy handles PUT /api/admin/events/{id}/refund-policy
+func (c *RefundController) UpdatePolicy(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req domain_refund.UpdatePolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	policy, err := c.refundUsecase.UpdatePolicy(r.Context(), eventID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to update refund policy")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newRefundPolicyResponse(policy))
+}
+
+// Helper methods
+
+func (c *RefundController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *RefundController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
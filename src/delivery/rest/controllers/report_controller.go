@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type ReportController struct {
+	reportUsecase *usecase.ReportUsecase
+	logger        *utils.Logger
+}
+
+// NewReportController creates a new sales report controller
+func NewReportController(reportUsecase *usecase.ReportUsecase, logger *utils.Logger) *ReportController {
+	return &ReportController{
+		reportUsecase: reportUsecase,
+		logger:        logger,
+	}
+}
+
+// GetEventReport handles GET /api/admin/events/{id}/report
+func (c *ReportController) GetEventReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+
+	interval := domain_stats.ReportInterval(r.URL.Query().Get("interval"))
+	report, err := c.reportUsecase.GetEventReport(r.Context(), caller.UserID, eventID, interval)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get event report")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newSalesReportResponse(report))
+}
+
+// GetSalesReport handles GET /api/admin/reports/sales, over the events
+// taking place between the from and to query parameters
+func (c *ReportController) GetSalesReport(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	query := r.URL.Query()
+	from, err := parseTimeParam(query.Get("from"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid from: "+err.Error())
+		return
+	}
+	to, err := parseTimeParam(query.Get("to"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid to: "+err.Error())
+		return
+	}
+
+	report, err := c.reportUsecase.GetSalesReport(r.Context(), caller.UserID, from, to, domain_stats.ReportInterval(query.Get("interval")))
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to get sales report")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newSalesReportResponse(report))
+}
+
+// Helper methods
+
+func (c *ReportController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *ReportController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
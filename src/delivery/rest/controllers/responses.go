@@ -0,0 +1,908 @@
+package controllers
+
+import (
+	"encoding/json"
+	"time"
+
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_deadletter "github.com/ojaswiii/booking-manager/src/internal/domain/deadletter"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Response bodies built from domain models. Models are never encoded
+// directly: each field a client sees is copied here, so columns added for
+// internal use stay private until they are added below.
+
+// newResponses maps a list of models to their responses
+func newResponses[M, R any](models []M, newResponse func(M) R) []R {
+	responses := make([]R, len(models))
+	for i, model := range models {
+		responses[i] = newResponse(model)
+	}
+	return responses
+}
+
+// UserResponse is a user
+type UserResponse struct {
+	ID        uuid.UUID        `json:"id"`
+	Email     string           `json:"email"`
+	Name      string           `json:"name"`
+	Role      domain_user.Role `json:"role"`
+	CreatedAt time.Time        `json:"created_at"`
+
+	EmailVerified bool       `json:"email_verified"`
+	LockedAt      *time.Time `json:"locked_at,omitempty"`
+}
+
+func newUserResponse(user *domain_user.User) UserResponse {
+	return UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		Name:          user.Name,
+		Role:          user.Role,
+		CreatedAt:     user.CreatedAt,
+		EmailVerified: user.EmailVerified(),
+		LockedAt:      user.LockedAt,
+	}
+}
+
+// UserLockResponse is a user just locked out, with how many of their
+// sessions were ended
+type UserLockResponse struct {
+	UserResponse
+	SessionsEnded int `json:"sessions_ended"`
+}
+
+// EventResponse is an event with its sales state
+type EventResponse struct {
+	ID                       uuid.UUID                 `json:"id"`
+	Name                     string                    `json:"name"`
+	Artist                   string                    `json:"artist"`
+	Venue                    string                    `json:"venue"`
+	Date                     time.Time                 `json:"date"`
+	TotalSeats               int                       `json:"total_seats"`
+	Price                    float64                   `json:"price"`
+	Status                   domain_event.EventStatus  `json:"status"`
+	CreatedAt                time.Time                 `json:"created_at"`
+	SalesStartAt             *time.Time                `json:"sales_start_at,omitempty"`
+	SalesEndAt               *time.Time                `json:"sales_end_at,omitempty"`
+	PresaleStartAt           *time.Time                `json:"presale_start_at,omitempty"`
+	WaitingRoomEnabled       bool                      `json:"waiting_room_enabled"`
+	ConfirmationRequirements domain_event.Requirements `json:"confirmation_requirements,omitempty"`
+	SalesState               domain_event.SalesState   `json:"sales_state,omitempty"`
+	OnSaleInSeconds          *int64                    `json:"on_sale_in_seconds,omitempty"`
+}
+
+func newEventResponse(event *domain_event.Event) EventResponse {
+	return EventResponse{
+		ID:                       event.ID,
+		Name:                     event.Name,
+		Artist:                   event.Artist,
+		Venue:                    event.Venue,
+		Date:                     event.Date,
+		TotalSeats:               event.TotalSeats,
+		Price:                    event.Price,
+		Status:                   event.Status,
+		CreatedAt:                event.CreatedAt,
+		SalesStartAt:             event.SalesStartAt,
+		SalesEndAt:               event.SalesEndAt,
+		PresaleStartAt:           event.PresaleStartAt,
+		WaitingRoomEnabled:       event.WaitingRoomEnabled,
+		ConfirmationRequirements: event.ConfirmationRequirements,
+		SalesState:               event.SalesState,
+		OnSaleInSeconds:          event.OnSaleInSeconds,
+	}
+}
+
+// TicketResponse is a seat of an event
+type TicketResponse struct {
+	ID         uuid.UUID                  `json:"id"`
+	EventID    uuid.UUID                  `json:"event_id"`
+	SeatNumber int                        `json:"seat_number"`
+	Status     domain_ticket.TicketStatus `json:"status"`
+	Price      float64                    `json:"price"`
+}
+
+func newTicketResponse(ticket *domain_ticket.Ticket) TicketResponse {
+	return TicketResponse{
+		ID:         ticket.ID,
+		EventID:    ticket.EventID,
+		SeatNumber: ticket.SeatNumber,
+		Status:     ticket.Status,
+		Price:      ticket.Price,
+	}
+}
+
+// CheckInCountsResponse is an event's door check-in progress
+type CheckInCountsResponse struct {
+	EventID   uuid.UUID `json:"event_id"`
+	Issued    int       `json:"issued"`
+	CheckedIn int       `json:"checked_in"`
+	Remaining int       `json:"remaining"`
+}
+
+func newCheckInCountsResponse(counts *domain_ticket.CheckInCounts) CheckInCountsResponse {
+	return CheckInCountsResponse{
+		EventID:   counts.EventID,
+		Issued:    counts.Issued,
+		CheckedIn: counts.CheckedIn,
+		Remaining: counts.Remaining,
+	}
+}
+
+// AvailabilityResponse counts an event's tickets by status in total, then
+// per price tier and per section of the seat map
+type AvailabilityResponse struct {
+	EventID uuid.UUID `json:"event_id"`
+	domain_ticket.StatusCounts
+	Tiers    []domain_ticket.TierCounts    `json:"tiers"`
+	Sections []domain_ticket.SectionCounts `json:"sections"`
+}
+
+func newAvailabilityResponse(summary *domain_ticket.AvailabilitySummary) AvailabilityResponse {
+	return AvailabilityResponse{
+		EventID:      summary.EventID,
+		StatusCounts: summary.Total,
+		Tiers:        summary.Tiers,
+		Sections:     summary.Sections,
+	}
+}
+
+// BookingResponse is a booking as its customer sees it
+type BookingResponse struct {
+	ID           uuid.UUID                    `json:"id"`
+	UserID       uuid.UUID                    `json:"user_id"`
+	EventID      uuid.UUID                    `json:"event_id"`
+	TicketIDs    []uuid.UUID                  `json:"ticket_ids"`
+	Status       domain_booking.BookingStatus `json:"status"`
+	TotalAmount  float64                      `json:"total_amount"`
+	Currency     string                       `json:"currency"`
+	ExchangeRate *float64                     `json:"exchange_rate,omitempty"`
+	Items        []LineItemResponse           `json:"items,omitempty"`
+	CreatedAt    time.Time                    `json:"created_at"`
+	ExpiresAt    time.Time                    `json:"expires_at"`
+}
+
+// LineItemResponse is the price charged for one ticket of a booking
+type LineItemResponse struct {
+	TicketID     uuid.UUID `json:"ticket_id"`
+	SeatNumber   int       `json:"seat_number"`
+	BasePrice    float64   `json:"base_price"`
+	UnitPrice    float64   `json:"unit_price"`
+	Currency     string    `json:"currency"`
+	ExchangeRate *float64  `json:"exchange_rate,omitempty"`
+}
+
+func newBookingResponse(booking *domain_booking.Booking) BookingResponse {
+	response := BookingResponse{
+		ID:           booking.ID,
+		UserID:       booking.UserID,
+		EventID:      booking.EventID,
+		TicketIDs:    booking.TicketIDs,
+		Status:       booking.Status,
+		TotalAmount:  booking.TotalAmount,
+		Currency:     booking.Currency,
+		ExchangeRate: booking.ExchangeRate,
+		CreatedAt:    booking.CreatedAt,
+		ExpiresAt:    booking.ExpiresAt,
+	}
+	for _, item := range booking.Items {
+		response.Items = append(response.Items, LineItemResponse{
+			TicketID:     item.TicketID,
+			SeatNumber:   item.SeatNumber,
+			BasePrice:    item.BasePrice,
+			UnitPrice:    item.UnitPrice,
+			Currency:     item.Currency,
+			ExchangeRate: item.ExchangeRate,
+		})
+	}
+	return response
+}
+
+// AdminBookingResponse adds the payment details reviewed by admins
+type AdminBookingResponse struct {
+	BookingResponse
+	PaymentReference *string `json:"payment_reference,omitempty"`
+	RiskScore        *int    `json:"risk_score,omitempty"`
+}
+
+func newAdminBookingResponse(booking *domain_booking.Booking) AdminBookingResponse {
+	return AdminBookingResponse{
+		BookingResponse:  newBookingResponse(booking),
+		PaymentReference: booking.PaymentReference,
+		RiskScore:        booking.RiskScore,
+	}
+}
+
+// TransitionResponse is one status change in a booking's history
+type TransitionResponse struct {
+	ID         uuid.UUID            `json:"id"`
+	TicketID   *uuid.UUID           `json:"ticket_id,omitempty"` // absent for the booking itself
+	FromStatus string               `json:"from_status"`         // empty when the booking was created
+	ToStatus   string               `json:"to_status"`
+	Actor      domain_booking.Actor `json:"actor"`
+	Reason     string               `json:"reason"`
+	CreatedAt  time.Time            `json:"created_at"`
+}
+
+func newTransitionResponse(transition *domain_booking.Transition) TransitionResponse {
+	return TransitionResponse{
+		ID:         transition.ID,
+		TicketID:   transition.TicketID,
+		FromStatus: transition.FromStatus,
+		ToStatus:   transition.ToStatus,
+		Actor:      transition.Actor,
+		Reason:     transition.Reason,
+		CreatedAt:  transition.CreatedAt,
+	}
+}
+
+// BookingEventResponse is one event in a booking's stream
+type BookingEventResponse struct {
+	Position   int64                    `json:"position"`
+	BookingID  uuid.UUID                `json:"booking_id"`
+	Version    int                      `json:"version"`
+	Type       domain_booking.EventType `json:"type"`
+	Actor      domain_booking.Actor     `json:"actor"`
+	Data       json.RawMessage          `json:"data"`
+	OccurredAt time.Time                `json:"occurred_at"`
+}
+
+func newBookingEventResponse(event *domain_booking.BookingEvent) BookingEventResponse {
+	return BookingEventResponse{
+		Position:   event.Position,
+		BookingID:  event.BookingID,
+		Version:    event.Version,
+		Type:       event.Type,
+		Actor:      event.Actor,
+		Data:       event.Data,
+		OccurredAt: event.OccurredAt,
+	}
+}
+
+// HoldResponse is a block of seats withheld from sale
+type HoldResponse struct {
+	ID         uuid.UUID          `json:"id"`
+	EventID    uuid.UUID          `json:"event_id"`
+	Kind       domain_hold.Kind   `json:"kind"`
+	Label      string             `json:"label"`
+	FromSeat   int                `json:"from_seat"`
+	ToSeat     int                `json:"to_seat"`
+	Seats      int                `json:"seats"`
+	Status     domain_hold.Status `json:"status"`
+	CreatedAt  time.Time          `json:"created_at"`
+	ReleasedAt *time.Time         `json:"released_at,omitempty"`
+}
+
+func newHoldResponse(hold *domain_hold.Hold) HoldResponse {
+	return HoldResponse{
+		ID:         hold.ID,
+		EventID:    hold.EventID,
+		Kind:       hold.Kind,
+		Label:      hold.Label,
+		FromSeat:   hold.FromSeat,
+		ToSeat:     hold.ToSeat,
+		Seats:      hold.Seats,
+		Status:     hold.Status,
+		CreatedAt:  hold.CreatedAt,
+		ReleasedAt: hold.ReleasedAt,
+	}
+}
+
+// ReleaseHoldResponse is a released hold and how many seats went back on sale
+type ReleaseHoldResponse struct {
+	Hold     HoldResponse `json:"hold"`
+	Released int          `json:"released"`
+}
+
+// RefundResponse is a refund of some or all of a booking
+type RefundResponse struct {
+	ID                uuid.UUID            `json:"id"`
+	BookingID         uuid.UUID            `json:"booking_id"`
+	Amount            float64              `json:"amount"`
+	Currency          string               `json:"currency"`
+	Reason            string               `json:"reason,omitempty"`
+	Status            domain_refund.Status `json:"status"`
+	ProviderReference *string              `json:"provider_reference,omitempty"`
+	RequestedBy       string               `json:"requested_by"`
+	Items             []RefundItemResponse `json:"items"`
+	CreatedAt         time.Time            `json:"created_at"`
+}
+
+// RefundItemResponse is the amount refunded for one ticket
+type RefundItemResponse struct {
+	TicketID uuid.UUID `json:"ticket_id"`
+	Amount   float64   `json:"amount"`
+}
+
+func newRefundResponse(refund *domain_refund.Refund) RefundResponse {
+	response := RefundResponse{
+		ID:                refund.ID,
+		BookingID:         refund.BookingID,
+		Amount:            refund.Amount,
+		Currency:          refund.Currency,
+		Reason:            refund.Reason,
+		Status:            refund.Status,
+		ProviderReference: refund.ProviderReference,
+		RequestedBy:       refund.RequestedBy,
+		Items:             make([]RefundItemResponse, len(refund.Items)),
+		CreatedAt:         refund.CreatedAt,
+	}
+	for i, item := range refund.Items {
+		response.Items[i] = RefundItemResponse{TicketID: item.TicketID, Amount: item.Amount}
+	}
+	return response
+}
+
+// ReturnResponse is a set of tickets given back for resale
+type ReturnResponse struct {
+	ID        uuid.UUID   `json:"id"`
+	BookingID uuid.UUID   `json:"booking_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids"`
+	Reason    string      `json:"reason,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+func newReturnResponse(ret *domain_refund.Return) ReturnResponse {
+	return ReturnResponse{
+		ID:        ret.ID,
+		BookingID: ret.BookingID,
+		TicketIDs: ret.TicketIDs,
+		Reason:    ret.Reason,
+		CreatedAt: ret.CreatedAt,
+	}
+}
+
+// RefundPolicyResponse is an event's refund policy
+type RefundPolicyResponse struct {
+	EventID       uuid.UUID `json:"event_id"`
+	Refundable    bool      `json:"refundable"`
+	DeadlineHours int       `json:"deadline_hours"`
+	Percent       float64   `json:"percent"`
+	AllowPartial  bool      `json:"allow_partial"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func newRefundPolicyResponse(policy *domain_refund.Policy) RefundPolicyResponse {
+	return RefundPolicyResponse{
+		EventID:       policy.EventID,
+		Refundable:    policy.Refundable,
+		DeadlineHours: policy.DeadlineHours,
+		Percent:       policy.Percent,
+		AllowPartial:  policy.AllowPartial,
+		UpdatedAt:     policy.UpdatedAt,
+	}
+}
+
+// JobResponse is a background job and its progress
+type JobResponse struct {
+	ID         uuid.UUID        `json:"id"`
+	Kind       string           `json:"kind"`
+	State      domain_job.State `json:"state"`
+	Progress   int              `json:"progress"`
+	ResultURL  *string          `json:"result_url,omitempty"`
+	Error      *string          `json:"error,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	StartedAt  *time.Time       `json:"started_at,omitempty"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+}
+
+func newJobResponse(job *domain_job.Job) JobResponse {
+	return JobResponse{
+		ID:         job.ID,
+		Kind:       job.Kind,
+		State:      job.State,
+		Progress:   job.Progress,
+		ResultURL:  job.ResultURL,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
+
+// DeadLetterResponse is a booking request that failed for good
+type DeadLetterResponse struct {
+	ID        uuid.UUID       `json:"id"`
+	RequestID string          `json:"request_id"`
+	UserID    uuid.UUID       `json:"user_id"`
+	EventID   uuid.UUID       `json:"event_id"`
+	Request   json.RawMessage `json:"request"`
+	Reason    string          `json:"reason"`
+	Attempts  int             `json:"attempts"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+func newDeadLetterResponse(letter *domain_deadletter.DeadLetter) DeadLetterResponse {
+	return DeadLetterResponse{
+		ID:        letter.ID,
+		RequestID: letter.RequestID,
+		UserID:    letter.UserID,
+		EventID:   letter.EventID,
+		Request:   letter.Request,
+		Reason:    letter.Reason,
+		Attempts:  letter.Attempts,
+		CreatedAt: letter.CreatedAt,
+	}
+}
+
+// UserDataExportResponse is everything kept about a user
+type UserDataExportResponse struct {
+	ExportedAt    time.Time                 `json:"exported_at"`
+	Profile       UserResponse              `json:"profile"`
+	Bookings      []ExportedBookingResponse `json:"bookings"`
+	Notifications []NotificationResponse    `json:"notifications"`
+	Preferences   *PreferencesResponse      `json:"notification_preferences,omitempty"`
+}
+
+// ExportedBookingResponse is a booking with the tickets issued for it
+type ExportedBookingResponse struct {
+	BookingResponse
+	Tickets []PassResponse `json:"tickets"`
+}
+
+// PassResponse is an issued ticket, without the token that admits its holder
+type PassResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	TicketID    uuid.UUID  `json:"ticket_id"`
+	IssuedAt    time.Time  `json:"issued_at"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+}
+
+func newPassResponse(pass *domain_ticket.Pass) PassResponse {
+	return PassResponse{
+		ID:          pass.ID,
+		TicketID:    pass.TicketID,
+		IssuedAt:    pass.IssuedAt,
+		CheckedInAt: pass.CheckedInAt,
+	}
+}
+
+// NotificationResponse is a notification queued for or sent to a user
+type NotificationResponse struct {
+	ID        uuid.UUID                  `json:"id"`
+	Kind      domain_notification.Kind   `json:"kind"`
+	BookingID uuid.UUID                  `json:"booking_id"`
+	EventID   uuid.UUID                  `json:"event_id"`
+	Status    domain_notification.Status `json:"status"`
+	SentAt    *time.Time                 `json:"sent_at,omitempty"`
+	CreatedAt time.Time                  `json:"created_at"`
+}
+
+func newNotificationResponse(n *domain_notification.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:        n.ID,
+		Kind:      n.Kind,
+		BookingID: n.BookingID,
+		EventID:   n.EventID,
+		Status:    n.Status,
+		SentAt:    n.SentAt,
+		CreatedAt: n.CreatedAt,
+	}
+}
+
+func newUserDataExportResponse(export *usecase.UserDataExport) UserDataExportResponse {
+	response := UserDataExportResponse{
+		ExportedAt:    export.ExportedAt,
+		Profile:       newUserResponse(export.Profile),
+		Bookings:      make([]ExportedBookingResponse, len(export.Bookings)),
+		Notifications: newResponses(export.Notifications, newNotificationResponse),
+	}
+	for i, bk := range export.Bookings {
+		response.Bookings[i] = ExportedBookingResponse{
+			BookingResponse: newBookingResponse(bk.Booking),
+			Tickets:         newResponses(bk.Tickets, newPassResponse),
+		}
+	}
+	if export.Preferences != nil {
+		preferences := newPreferencesResponse(export.Preferences)
+		response.Preferences = &preferences
+	}
+	return response
+}
+
+// AuditEntryResponse is a recorded action, such as a user's erasure
+type AuditEntryResponse struct {
+	ID        uuid.UUID           `json:"id"`
+	Action    domain_audit.Action `json:"action"`
+	ActorID   uuid.UUID           `json:"actor_id"`
+	SubjectID uuid.UUID           `json:"subject_id"`
+	Details   json.RawMessage     `json:"details"`
+	CreatedAt time.Time           `json:"created_at"`
+}
+
+func newAuditEntryResponse(entry *domain_audit.Entry) AuditEntryResponse {
+	return AuditEntryResponse{
+		ID:        entry.ID,
+		Action:    entry.Action,
+		ActorID:   entry.ActorID,
+		SubjectID: entry.SubjectID,
+		Details:   entry.Details,
+		CreatedAt: entry.CreatedAt,
+	}
+}
+
+// BroadcastResponse is a message to an event's attendees and its delivery report
+type BroadcastResponse struct {
+	ID          uuid.UUID               `json:"id"`
+	EventID     uuid.UUID               `json:"event_id"`
+	Subject     string                  `json:"subject"`
+	Message     string                  `json:"message"`
+	CreatedAt   time.Time               `json:"created_at"`
+	CompletedAt *time.Time              `json:"completed_at,omitempty"`
+	Report      BroadcastReportResponse `json:"report"`
+}
+
+// BroadcastReportResponse counts a broadcast's recipients by delivery status
+type BroadcastReportResponse struct {
+	Recipients int `json:"recipients"`
+	Pending    int `json:"pending"`
+	Sent       int `json:"sent"`
+	Skipped    int `json:"skipped"`
+	Failed     int `json:"failed"`
+}
+
+func newBroadcastResponse(broadcast *domain_broadcast.Broadcast) BroadcastResponse {
+	return BroadcastResponse{
+		ID:          broadcast.ID,
+		EventID:     broadcast.EventID,
+		Subject:     broadcast.Subject,
+		Message:     broadcast.Message,
+		CreatedAt:   broadcast.CreatedAt,
+		CompletedAt: broadcast.CompletedAt,
+		Report: BroadcastReportResponse{
+			Recipients: broadcast.Report.Recipients,
+			Pending:    broadcast.Report.Pending,
+			Sent:       broadcast.Report.Sent,
+			Skipped:    broadcast.Report.Skipped,
+			Failed:     broadcast.Report.Failed,
+		},
+	}
+}
+
+// EventStatsResponse is an event's booking counters, with revenue in the
+// base currency
+type EventStatsResponse struct {
+	EventID           uuid.UUID `json:"event_id"`
+	BookingsCreated   int64     `json:"bookings_created"`
+	BookingsConfirmed int64     `json:"bookings_confirmed"`
+	BookingsCancelled int64     `json:"bookings_cancelled"`
+	Revenue           float64   `json:"revenue"`
+	TicketsSold       int64     `json:"tickets_sold"`
+	TicketsReturned   int64     `json:"tickets_returned"`
+	ReturnRate        float64   `json:"return_rate"` // share of sold tickets returned, 0 to 1
+	ReconciledAt      time.Time `json:"reconciled_at"`
+}
+
+func newEventStatsResponse(stats *domain_stats.EventStats) EventStatsResponse {
+	return EventStatsResponse{
+		EventID:           stats.EventID,
+		BookingsCreated:   stats.Created,
+		BookingsConfirmed: stats.Confirmed,
+		BookingsCancelled: stats.Cancelled,
+		Revenue:           stats.Revenue(),
+		TicketsSold:       stats.TicketsSold,
+		TicketsReturned:   stats.TicketsReturned,
+		ReturnRate:        stats.ReturnRate(),
+		ReconciledAt:      stats.ReconciledAt,
+	}
+}
+
+// EventAvailabilityResponse is one event's row of the availability dashboard
+type EventAvailabilityResponse struct {
+	EventID   uuid.UUID `json:"event_id"`
+	EventName string    `json:"event_name"`
+	EventDate time.Time `json:"event_date"`
+	Total     int64     `json:"total"`
+	Available int64     `json:"available"`
+	Reserved  int64     `json:"reserved"`
+	Sold      int64     `json:"sold"`
+	Held      int64     `json:"held"`
+	UpdatedAt time.Time `json:"updated_at"` // when the tickets were last counted
+}
+
+func newEventAvailabilityResponse(availability *domain_readmodel.EventAvailability) EventAvailabilityResponse {
+	return EventAvailabilityResponse{
+		EventID:   availability.EventID,
+		EventName: availability.EventName,
+		EventDate: availability.EventDate,
+		Total:     availability.Total(),
+		Available: availability.Available,
+		Reserved:  availability.Reserved,
+		Sold:      availability.Sold,
+		Held:      availability.Held,
+		UpdatedAt: availability.UpdatedAt,
+	}
+}
+
+// SessionResponse is one signed-in client of a user
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // the session of the access token listing them
+}
+
+func newSessionResponse(session *domain_session.Session, currentID uuid.UUID) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+		Current:    session.ID == currentID,
+	}
+}
+
+// LogoutResponse says how many sessions a logout ended
+type LogoutResponse struct {
+	Ended int `json:"ended"`
+}
+
+// PasswordResetResponse says how many sessions a password reset ended
+type PasswordResetResponse struct {
+	SessionsEnded int `json:"sessions_ended"`
+}
+
+// APIKeyResponse is a partner API key, without the key itself
+type APIKeyResponse struct {
+	ID         uuid.UUID             `json:"id"`
+	Name       string                `json:"name"`
+	AccountID  uuid.UUID             `json:"account_id"`
+	Prefix     string                `json:"prefix"`
+	Scopes     []domain_apikey.Scope `json:"scopes"`
+	RateLimit  int                   `json:"rate_limit"` // requests per minute
+	CreatedBy  uuid.UUID             `json:"created_by"`
+	CreatedAt  time.Time             `json:"created_at"`
+	ExpiresAt  *time.Time            `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time            `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time            `json:"revoked_at,omitempty"`
+}
+
+func newAPIKeyResponse(key *domain_apikey.APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		AccountID:  key.UserID,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		RateLimit:  key.RateLimit,
+		CreatedBy:  key.CreatedBy,
+		CreatedAt:  key.CreatedAt,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+	}
+}
+
+// CreatedAPIKeyResponse is a newly issued API key. The key is not kept, so
+// this is the only time it is shown.
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// SalesReportResponse is a sales report, with amounts in the base currency
+type SalesReportResponse struct {
+	EventID     *uuid.UUID                  `json:"event_id,omitempty"`
+	From        *time.Time                  `json:"from,omitempty"`
+	To          *time.Time                  `json:"to,omitempty"`
+	Interval    domain_stats.ReportInterval `json:"interval"`
+	Events      int64                       `json:"events"`
+	TotalSeats  int64                       `json:"total_seats"`
+	Bookings    int64                       `json:"bookings"`
+	TicketsSold int64                       `json:"tickets_sold"`
+	FillRate    float64                     `json:"fill_rate"` // share of seats sold, 0 to 1
+	Revenue     float64                     `json:"revenue"`
+	Refunded    float64                     `json:"refunded"`
+	NetRevenue  float64                     `json:"net_revenue"`
+	Buckets     []SalesBucketResponse       `json:"buckets"`
+}
+
+// SalesBucketResponse is what sold in one interval of a sales report
+type SalesBucketResponse struct {
+	Start       time.Time `json:"start"`
+	Bookings    int64     `json:"bookings"`
+	TicketsSold int64     `json:"tickets_sold"`
+	Revenue     float64   `json:"revenue"`
+	Refunded    float64   `json:"refunded"`
+}
+
+func newSalesReportResponse(report *domain_stats.SalesReport) SalesReportResponse {
+	response := SalesReportResponse{
+		EventID:     report.EventID,
+		From:        report.From,
+		To:          report.To,
+		Interval:    report.Interval,
+		Events:      report.Events,
+		TotalSeats:  report.TotalSeats,
+		Bookings:    report.Bookings,
+		TicketsSold: report.TicketsSold,
+		FillRate:    report.FillRate(),
+		Revenue:     float64(report.RevenueCents) / 100,
+		Refunded:    float64(report.RefundedCents) / 100,
+		NetRevenue:  float64(report.NetRevenueCents()) / 100,
+		Buckets:     make([]SalesBucketResponse, 0, len(report.Buckets)),
+	}
+	for _, bucket := range report.Buckets {
+		response.Buckets = append(response.Buckets, SalesBucketResponse{
+			Start:       bucket.Start,
+			Bookings:    bucket.Bookings,
+			TicketsSold: bucket.TicketsSold,
+			Revenue:     float64(bucket.RevenueCents) / 100,
+			Refunded:    float64(bucket.RefundedCents) / 100,
+		})
+	}
+	return response
+}
+
+// BookingCountsResponse is what the booking processor did, with revenue in
+// the base currency
+type BookingCountsResponse struct {
+	Requests  int64   `json:"requests"`
+	Successes int64   `json:"successes"`
+	Failures  int64   `json:"failures"`
+	Revenue   float64 `json:"revenue"`
+}
+
+func newBookingCountsResponse(counts domain_stats.BookingCounts) BookingCountsResponse {
+	return BookingCountsResponse{
+		Requests:  counts.Requests,
+		Successes: counts.Successes,
+		Failures:  counts.Failures,
+		Revenue:   float64(counts.RevenueCents) / 100,
+	}
+}
+
+// BookingMinuteResponse is the booking processor's counts for one minute
+type BookingMinuteResponse struct {
+	Minute time.Time `json:"minute"`
+	BookingCountsResponse
+}
+
+// BookingMetricsResponse is the booking processor's counts over a range,
+// in total and for each minute with any
+type BookingMetricsResponse struct {
+	From    time.Time               `json:"from"`
+	To      time.Time               `json:"to"`
+	Totals  BookingCountsResponse   `json:"totals"`
+	Minutes []BookingMinuteResponse `json:"minutes"`
+}
+
+func newBookingMetricsResponse(metrics *domain_stats.BookingMetrics) BookingMetricsResponse {
+	response := BookingMetricsResponse{
+		From:    metrics.From,
+		To:      metrics.To,
+		Totals:  newBookingCountsResponse(metrics.Totals),
+		Minutes: make([]BookingMinuteResponse, 0, len(metrics.Minutes)),
+	}
+	for _, minute := range metrics.Minutes {
+		response.Minutes = append(response.Minutes, BookingMinuteResponse{Minute: minute.Minute, BookingCountsResponse: newBookingCountsResponse(minute.BookingCounts)})
+	}
+	return response
+}
+
+// TemplateResponse is an event template
+type TemplateResponse struct {
+	ID                       uuid.UUID                  `json:"id"`
+	Name                     string                     `json:"name"`
+	SourceEventID            *uuid.UUID                 `json:"source_event_id,omitempty"`
+	EventName                string                     `json:"event_name"`
+	Artist                   string                     `json:"artist"`
+	Venue                    string                     `json:"venue"`
+	TotalSeats               int                        `json:"total_seats"`
+	Price                    float64                    `json:"price"`
+	Tiers                    domain_template.PriceTiers `json:"tiers"`
+	SalesStartOffset         *int64                     `json:"sales_start_offset_seconds,omitempty"`
+	SalesEndOffset           *int64                     `json:"sales_end_offset_seconds,omitempty"`
+	PresaleStartOffset       *int64                     `json:"presale_start_offset_seconds,omitempty"`
+	ConfirmationRequirements domain_event.Requirements  `json:"confirmation_requirements"`
+	WaitingRoomEnabled       bool                       `json:"waiting_room_enabled"`
+	CreatedAt                time.Time                  `json:"created_at"`
+}
+
+func newTemplateResponse(tmpl *domain_template.EventTemplate) TemplateResponse {
+	return TemplateResponse{
+		ID:                       tmpl.ID,
+		Name:                     tmpl.Name,
+		SourceEventID:            tmpl.SourceEventID,
+		EventName:                tmpl.EventName,
+		Artist:                   tmpl.Artist,
+		Venue:                    tmpl.Venue,
+		TotalSeats:               tmpl.TotalSeats,
+		Price:                    tmpl.Price,
+		Tiers:                    tmpl.Tiers,
+		SalesStartOffset:         tmpl.SalesStartOffset,
+		SalesEndOffset:           tmpl.SalesEndOffset,
+		PresaleStartOffset:       tmpl.PresaleStartOffset,
+		ConfirmationRequirements: tmpl.ConfirmationRequirements,
+		WaitingRoomEnabled:       tmpl.WaitingRoomEnabled,
+		CreatedAt:                tmpl.CreatedAt,
+	}
+}
+
+// PreferencesResponse is the channels a user is notified on
+type PreferencesResponse struct {
+	UserID   uuid.UUID                              `json:"user_id"`
+	Channels domain_notification.ChannelPreferences `json:"channels"`
+}
+
+func newPreferencesResponse(preferences *domain_notification.Preferences) PreferencesResponse {
+	return PreferencesResponse{UserID: preferences.UserID, Channels: preferences.Channels}
+}
+
+// SubscriptionResponse is a webhook subscription, without its secret
+type SubscriptionResponse struct {
+	ID         uuid.UUID                    `json:"id"`
+	URL        string                       `json:"url"`
+	EventTypes domain_webhook.EventTypeList `json:"event_types"`
+	Active     bool                         `json:"active"`
+	CreatedAt  time.Time                    `json:"created_at"`
+}
+
+func newSubscriptionResponse(sub *domain_webhook.Subscription) SubscriptionResponse {
+	return SubscriptionResponse{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+	}
+}
+
+// CreateSubscriptionResponse is a new subscription with its signing secret,
+// which is only ever shown here
+type CreateSubscriptionResponse struct {
+	SubscriptionResponse
+	Secret string `json:"secret"`
+}
+
+// WebhookDeliveryResponse is one attempt series to deliver an event to a subscription
+type WebhookDeliveryResponse struct {
+	ID             uuid.UUID                     `json:"id"`
+	SubscriptionID uuid.UUID                     `json:"subscription_id"`
+	EventType      string                        `json:"event_type"`
+	Payload        json.RawMessage               `json:"payload"`
+	Status         domain_webhook.DeliveryStatus `json:"status"`
+	Attempts       int                           `json:"attempts"`
+	NextAttemptAt  time.Time                     `json:"next_attempt_at"`
+	LastStatusCode *int                          `json:"last_status_code,omitempty"`
+	LastError      *string                       `json:"last_error,omitempty"`
+	CreatedAt      time.Time                     `json:"created_at"`
+}
+
+func newWebhookDeliveryResponse(delivery *domain_webhook.Delivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		SubscriptionID: delivery.SubscriptionID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		NextAttemptAt:  delivery.NextAttemptAt,
+		LastStatusCode: delivery.LastStatusCode,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt,
+	}
+}
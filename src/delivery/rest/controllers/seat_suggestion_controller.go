@@ -0,0 +1,94 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type SeatSuggestionController struct {
+	seatSuggestionUsecase *usecase.SeatSuggestionUsecase
+	logger                *utils.Logger
+}
+
+// NewSeatSuggestionController creates a new seat suggestion controller
+func NewSeatSuggestionController(seatSuggestionUsecase *usecase.SeatSuggestionUsecase, logger *utils.Logger) *SeatSuggestionController {
+	return &SeatSuggestionController{
+		seatSuggestionUsecase: seatSuggestionUsecase,
+		logger:                logger,
+	}
+}
+
+// SuggestSeats handles GET /api/events/{id}/seat-suggestions?party_size=...
+func (c *SeatSuggestionController) SuggestSeats(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	query := r.URL.Query()
+	req := usecase.SeatSuggestionRequest{
+		EventID:  eventID,
+		Position: usecase.SeatPosition(query.Get("position")),
+		Session:  query.Get("session"),
+	}
+	if req.PartySize, err = strconv.Atoi(query.Get("party_size")); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid party_size")
+		return
+	}
+	if v := query.Get("aisle"); v != "" {
+		if req.Aisle, err = strconv.ParseBool(v); err != nil {
+			c.respondWithError(w, http.StatusBadRequest, "Invalid aisle")
+			return
+		}
+	}
+	if v := query.Get("tier"); v != "" {
+		if req.Tier, err = strconv.Atoi(v); err != nil {
+			c.respondWithError(w, http.StatusBadRequest, "Invalid tier")
+			return
+		}
+	}
+	if v := query.Get("limit"); v != "" {
+		if req.Limit, err = strconv.Atoi(v); err != nil {
+			c.respondWithError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	response, err := c.seatSuggestionUsecase.SuggestSeats(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to suggest seats")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, response)
+}
+
+// Helper methods
+
+func (c *SeatSuggestionController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *SeatSuggestionController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
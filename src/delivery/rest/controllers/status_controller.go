@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+type StatusController struct {
+	statusUsecase *usecase.StatusUsecase
+	logger        *utils.Logger
+}
+
+// NewStatusController creates a new public status controller
+func NewStatusController(statusUsecase *usecase.StatusUsecase, logger *utils.Logger) *StatusController {
+	return &StatusController{
+		statusUsecase: statusUsecase,
+		logger:        logger,
+	}
+}
+
+// GetStatus handles GET /api/status
+func (c *StatusController) GetStatus(w http.ResponseWriter, r *http.Request) {
+	// Status pages poll frequently; let caches absorb most of it
+	w.Header().Set("Cache-Control", "public, max-age=10")
+	c.respondWithJSON(w, http.StatusOK, c.statusUsecase.GetStatus())
+}
+
+// Helper methods
+
+func (c *StatusController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
@@ -0,0 +1,147 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type TemplateController struct {
+	templateUsecase *usecase.TemplateUsecase
+	logger          *utils.Logger
+}
+
+// NewTemplateController creates a new event template controller
+func NewTemplateController(templateUsecase *usecase.TemplateUsecase, logger *utils.Logger) *TemplateController {
+	return &TemplateController{
+		templateUsecase: templateUsecase,
+		logger:          logger,
+	}
+}
+
+// CreateTemplate handles POST /api/templates
+func (c *TemplateController) CreateTemplate(w http.ResponseWriter, r *http.Request) {
+	var req domain_template.CreateTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	tmpl, err := c.templateUsecase.CreateTemplate(r.Context(), req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to create event template")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusCreated, newTemplateResponse(tmpl))
+}
+
+// ListTemplates handles GET /api/templates
+func (c *TemplateController) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := c.templateUsecase.ListTemplates(r.Context())
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list event templates")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(templates, newTemplateResponse))
+}
+
+// GetTemplate handles GET /api/templates/{id}
+func (c *TemplateController) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	templateID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	tmpl, err := c.templateUsecase.GetTemplate(r.Context(), templateID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event template not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get event template")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newTemplateResponse(tmpl))
+}
+
+// DeleteTemplate handles DELETE /api/templates/{id}
+func (c *TemplateController) DeleteTemplate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	templateID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	if err := c.templateUsecase.DeleteTemplate(r.Context(), templateID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event template not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to delete event template")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Instantiate handles POST /api/templates/{id}/instantiate
+func (c *TemplateController) Instantiate(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	templateID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid template ID")
+		return
+	}
+
+	var req domain_template.InstantiateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := c.templateUsecase.Instantiate(r.Context(), templateID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event template not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to create event from template")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusCreated, response)
+}
+
+// Helper methods
+
+func (c *TemplateController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *TemplateController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type TicketController struct {
+	ticketPassUsecase *usecase.TicketPassUsecase
+	checkInUsecase    *usecase.CheckInUsecase
+	logger            *utils.Logger
+}
+
+// NewTicketController creates a new ticket controller
+func NewTicketController(ticketPassUsecase *usecase.TicketPassUsecase, checkInUsecase *usecase.CheckInUsecase, logger *utils.Logger) *TicketController {
+	return &TicketController{
+		ticketPassUsecase: ticketPassUsecase,
+		checkInUsecase:    checkInUsecase,
+		logger:            logger,
+	}
+}
+
+// DownloadTickets handles GET /api/bookings/{id}/tickets.pdf?user_id=...
+func (c *TicketController) DownloadTickets(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	bookingID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid booking ID")
+		return
+	}
+	userID, err := uuid.Parse(r.URL.Query().Get("user_id"))
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	document, err := c.ticketPassUsecase.TicketsPDF(r.Context(), bookingID, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Booking not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to render tickets")
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="tickets-`+bookingID.String()+`.pdf"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(document)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(document)
+}
+
+// CheckIn handles POST /api/checkin
+func (c *TicketController) CheckIn(w http.ResponseWriter, r *http.Request) {
+	var req domain_ticket.CheckInRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := c.checkInUsecase.CheckIn(r.Context(), req)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to check in ticket")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, response)
+}
+
+// GetCheckInCounts handles GET /api/events/{id}/checkins
+func (c *TicketController) GetCheckInCounts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	counts, err := c.checkInUsecase.GetCounts(r.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get check-in counts")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newCheckInCountsResponse(counts))
+}
+
+// Helper methods
+
+func (c *TicketController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *TicketController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// Request bodies of endpoints that take the resource ID from the path. They
+// are named so the OpenAPI spec can describe them.
+
+// ConfirmBookingBody is the body of POST /api/bookings/{id}/confirm
+type ConfirmBookingBody struct {
+	UserID           uuid.UUID         `json:"user_id"`
+	Attestations     map[string]string `json:"attestations,omitempty"`
+	PaymentReference string            `json:"payment_reference,omitempty"`
+}
+
+// CancelBookingBody is the body of POST /api/bookings/{id}/cancel
+type CancelBookingBody struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// ChangeSeatsBody is the body of PATCH /api/bookings/{id}/tickets
+type ChangeSeatsBody struct {
+	UserID  uuid.UUID            `json:"user_id"`
+	Changes []usecase.SeatChange `json:"changes"`
+}
+
+// TransitionEventStatusBody is the body of PUT /api/admin/events/{id}/status
+type TransitionEventStatusBody struct {
+	Status domain_event.EventStatus `json:"status"`
+}
+
+// UpdateUserBody is the body of PUT /api/users/{id}
+type UpdateUserBody struct {
+	Email           string `json:"email"`
+	Name            string `json:"name"`
+	CurrentPassword string `json:"current_password,omitempty"` // needed to change your own email
+}
+
+// SetUserRoleBody is the body of PUT /api/admin/users/{id}/role
+type SetUserRoleBody struct {
+	Role domain_user.Role `json:"role"`
+}
+
+// JoinWaitingRoomBody is the body of POST /api/events/{id}/waiting-room
+type JoinWaitingRoomBody struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+// StatusResponse is returned by endpoints that only report a new status
+type StatusResponse struct {
+	Status string `json:"status"`
+}
+
+// MessageResponse is returned by endpoints that only report success
+type MessageResponse struct {
+	Message string `json:"message"`
+}
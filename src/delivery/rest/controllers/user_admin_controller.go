@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type UserAdminController struct {
+	userAdminUsecase *usecase.UserAdminUsecase
+	logger           *utils.Logger
+}
+
+// NewUserAdminController creates a new user admin controller
+func NewUserAdminController(userAdminUsecase *usecase.UserAdminUsecase, logger *utils.Logger) *UserAdminController {
+	return &UserAdminController{
+		userAdminUsecase: userAdminUsecase,
+		logger:           logger,
+	}
+}
+
+// SearchUsers handles GET /api/admin/users, filtered by email prefix and
+// signup date
+func (c *UserAdminController) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	filter, err := parseUserFilter(r)
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	users, err := c.userAdminUsecase.SearchUsers(r.Context(), caller.UserID, filter)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to search users")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(users, newUserResponse))
+}
+
+// GetUserBookings handles GET /api/admin/users/{id}/bookings
+func (c *UserAdminController) GetUserBookings(w http.ResponseWriter, r *http.Request) {
+	userID, adminID, ok := c.parseIDs(w, r)
+	if !ok {
+		return
+	}
+
+	bookings, err := c.userAdminUsecase.GetUserBookings(r.Context(), adminID, userID)
+	if err != nil {
+		c.respondWithFailure(w, err, "Failed to get user bookings")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(bookings, newAdminBookingResponse))
+}
+
+// LockUser handles POST /api/admin/users/{id}/lock
+func (c *UserAdminController) LockUser(w http.ResponseWriter, r *http.Request) {
+	userID, adminID, ok := c.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	var req usecase.UserAdminActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, ended, err := c.userAdminUsecase.LockUser(r.Context(), adminID, userID, req)
+	if err != nil {
+		c.respondWithFailure(w, err, "Failed to lock user")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, UserLockResponse{UserResponse: newUserResponse(user), SessionsEnded: ended})
+}
+
+// UnlockUser handles POST /api/admin/users/{id}/unlock
+func (c *UserAdminController) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userID, adminID, ok := c.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	var req usecase.UserAdminActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := c.userAdminUsecase.UnlockUser(r.Context(), adminID, userID, req)
+	if err != nil {
+		c.respondWithFailure(w, err, "Failed to unlock user")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newUserResponse(user))
+}
+
+// Impersonate handles POST /api/admin/users/{id}/impersonate,
+// handing the admin an access token to act as the user
+func (c *UserAdminController) Impersonate(w http.ResponseWriter, r *http.Request) {
+	userID, adminID, ok := c.parseIDs(w, r)
+	if !ok {
+		return
+	}
+	var req usecase.UserAdminActionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	token, err := c.userAdminUsecase.Impersonate(r.Context(), adminID, userID, req)
+	if err != nil {
+		c.respondWithFailure(w, err, "Failed to impersonate user")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, token)
+}
+
+// parseUserFilter reads the user search filters from the query string
+func parseUserFilter(r *http.Request) (domain_user.UserFilter, error) {
+	query := r.URL.Query()
+	filter := domain_user.UserFilter{EmailPrefix: query.Get("email")}
+
+	var err error
+	if filter.CreatedFrom, err = parseTimeParam(query.Get("created_from")); err != nil {
+		return filter, fmt.Errorf("invalid created_from: %v", err)
+	}
+	if filter.CreatedTo, err = parseTimeParam(query.Get("created_to")); err != nil {
+		return filter, fmt.Errorf("invalid created_to: %v", err)
+	}
+	if v := query.Get("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil {
+			return filter, fmt.Errorf("invalid limit: %v", err)
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		if filter.Offset, err = strconv.Atoi(v); err != nil {
+			return filter, fmt.Errorf("invalid offset: %v", err)
+		}
+	}
+	return filter, nil
+}
+
+// Helper methods
+
+// parseIDs reads the {id} of the user being acted on and the admin the
+// request is signed in as
+func (c *UserAdminController) parseIDs(w http.ResponseWriter, r *http.Request) (id, adminID uuid.UUID, ok bool) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, caller.UserID, true
+}
+
+// respondWithFailure writes err, reporting a missing user as not found. A
+// missing admin is reported as unauthorized by the usecase.
+func (c *UserAdminController) respondWithFailure(w http.ResponseWriter, err error, message string) {
+	if errors.Is(err, domain.ErrNotFound) {
+		c.respondWithError(w, http.StatusNotFound, "User not found")
+		return
+	}
+	problem.WriteError(w, c.logger, err, message)
+}
+
+func (c *UserAdminController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *UserAdminController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -2,8 +2,11 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
 	"github.com/ojaswiii/booking-manager/src/internal/usecase"
 	"github.com/ojaswiii/booking-manager/src/utils"
 
@@ -13,13 +16,15 @@ import (
 
 type UserController struct {
 	userUsecase *usecase.UserUsecase
+	authUsecase *usecase.AuthUsecase
 	logger      *utils.Logger
 }
 
 // NewUserController creates a new user controller
-func NewUserController(userUsecase *usecase.UserUsecase, logger *utils.Logger) *UserController {
+func NewUserController(userUsecase *usecase.UserUsecase, authUsecase *usecase.AuthUsecase, logger *utils.Logger) *UserController {
 	return &UserController{
 		userUsecase: userUsecase,
+		authUsecase: authUsecase,
 		logger:      logger,
 	}
 }
@@ -34,10 +39,10 @@ func (c *UserController) CreateUser(w http.ResponseWriter, r *http.Request) {
 
 	response, err := c.userUsecase.CreateUser(r.Context(), req)
 	if err != nil {
-		c.logger.Error("Failed to create user", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to create user")
+		problem.WriteError(w, c.logger, err, "Failed to create user")
 		return
 	}
+	c.sendVerification(r, response.UserID)
 
 	c.respondWithJSON(w, http.StatusCreated, response)
 }
@@ -53,20 +58,24 @@ func (c *UserController) GetUser(w http.ResponseWriter, r *http.Request) {
 
 	user, err := c.userUsecase.GetUser(r.Context(), userID)
 	if err != nil {
-		if err.Error() == "resource not found" {
+		if errors.Is(err, domain.ErrNotFound) {
 			c.respondWithError(w, http.StatusNotFound, "User not found")
 			return
 		}
-		c.logger.Error("Failed to get user", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to get user")
+		problem.WriteError(w, c.logger, err, "Failed to get user")
 		return
 	}
 
-	c.respondWithJSON(w, http.StatusOK, user)
+	c.respondWithJSON(w, http.StatusOK, newUserResponse(user))
 }
 
-// UpdateUser handles PUT /api/users/{id}
+// UpdateUser handles PUT /api/users/{id}, for the user the request is
+// signed in as or an admin
 func (c *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
 	vars := mux.Vars(r)
 	userID, err := uuid.Parse(vars["id"])
 	if err != nil {
@@ -74,42 +83,72 @@ func (c *UserController) UpdateUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		Email string `json:"email"`
-		Name  string `json:"name"`
-	}
+	var req UpdateUserBody
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Get existing user
-	user, err := c.userUsecase.GetUser(r.Context(), userID)
+	user, changed, err := c.userUsecase.UpdateProfile(r.Context(), caller.UserID, userID, usecase.UpdateProfileRequest{
+		Email:           req.Email,
+		Name:            req.Name,
+		CurrentPassword: req.CurrentPassword,
+	})
 	if err != nil {
-		if err.Error() == "resource not found" {
+		if errors.Is(err, domain.ErrNotFound) {
 			c.respondWithError(w, http.StatusNotFound, "User not found")
 			return
 		}
-		c.logger.Error("Failed to get user", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to get user")
+		problem.WriteError(w, c.logger, err, "Failed to update user")
 		return
 	}
+	if changed {
+		c.sendVerification(r, user.ID)
+	}
 
-	// Update user fields
-	user.Email = req.Email
-	user.Name = req.Name
+	c.respondWithJSON(w, http.StatusOK, newUserResponse(user))
+}
 
-	if err := c.userUsecase.UpdateUser(r.Context(), user); err != nil {
-		c.logger.Error("Failed to update user", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to update user")
+// SetUserRole handles PUT /api/admin/users/{id}/role, granted by the admin
+// the request is signed in as
+func (c *UserController) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	userID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid user ID")
 		return
 	}
 
-	c.respondWithJSON(w, http.StatusOK, user)
+	var req SetUserRoleBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := c.userUsecase.SetRole(r.Context(), caller.UserID, userID, req.Role)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "User not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to set user role")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newUserResponse(user))
 }
 
-// DeleteUser handles DELETE /api/users/{id}
+// DeleteUser handles DELETE /api/users/{id}, for the user the request is
+// signed in as or an admin
 func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
 	vars := mux.Vars(r)
 	userID, err := uuid.Parse(vars["id"])
 	if err != nil {
@@ -117,13 +156,12 @@ func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := c.userUsecase.DeleteUser(r.Context(), userID); err != nil {
-		if err.Error() == "resource not found" {
+	if err := c.userUsecase.DeleteUser(r.Context(), caller.UserID, userID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
 			c.respondWithError(w, http.StatusNotFound, "User not found")
 			return
 		}
-		c.logger.Error("Failed to delete user", "error", err)
-		c.respondWithError(w, http.StatusInternalServerError, "Failed to delete user")
+		problem.WriteError(w, c.logger, err, "Failed to delete user")
 		return
 	}
 
@@ -132,6 +170,14 @@ func (c *UserController) DeleteUser(w http.ResponseWriter, r *http.Request) {
 
 // Helper methods
 
+// sendVerification emails the user a link to verify their email. A failure
+// is only logged: the user is saved either way.
+func (c *UserController) sendVerification(r *http.Request, userID uuid.UUID) {
+	if err := c.authUsecase.SendVerification(r.Context(), userID); err != nil {
+		c.logger.Warn("Failed to send verification email", "user_id", userID, "error", err)
+	}
+}
+
 func (c *UserController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 	response, _ := json.Marshal(payload)
 	w.Header().Set("Content-Type", "application/json")
@@ -140,5 +186,5 @@ func (c *UserController) respondWithJSON(w http.ResponseWriter, code int, payloa
 }
 
 func (c *UserController) respondWithError(w http.ResponseWriter, code int, message string) {
-	c.respondWithJSON(w, code, map[string]string{"error": message})
+	problem.Write(w, code, message)
 }
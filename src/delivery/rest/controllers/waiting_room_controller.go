@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type WaitingRoomController struct {
+	waitingRoomUsecase *usecase.WaitingRoomUsecase
+	logger             *utils.Logger
+}
+
+// NewWaitingRoomController creates a new waiting room controller
+func NewWaitingRoomController(waitingRoomUsecase *usecase.WaitingRoomUsecase, logger *utils.Logger) *WaitingRoomController {
+	return &WaitingRoomController{
+		waitingRoomUsecase: waitingRoomUsecase,
+		logger:             logger,
+	}
+}
+
+// JoinWaitingRoom handles POST /api/events/{id}/waiting-room
+func (c *WaitingRoomController) JoinWaitingRoom(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	var req JoinWaitingRoomBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	status, err := c.waitingRoomUsecase.Join(r.Context(), eventID, req.UserID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			c.respondWithError(w, http.StatusNotFound, "Event not found")
+		default:
+			problem.WriteError(w, c.logger, err, "Failed to join waiting room")
+		}
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, status)
+}
+
+// GetWaitingRoomStatus handles GET /api/events/{id}/waiting-room/{token}
+func (c *WaitingRoomController) GetWaitingRoomStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	eventID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid event ID")
+		return
+	}
+
+	status, err := c.waitingRoomUsecase.GetStatus(r.Context(), eventID, vars["token"])
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Waiting room token not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to get waiting room status")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, status)
+}
+
+// Helper methods
+
+func (c *WaitingRoomController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *WaitingRoomController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+type WebhookController struct {
+	webhookUsecase *usecase.WebhookUsecase
+	logger         *utils.Logger
+}
+
+// NewWebhookController creates a new webhook controller
+func NewWebhookController(webhookUsecase *usecase.WebhookUsecase, logger *utils.Logger) *WebhookController {
+	return &WebhookController{
+		webhookUsecase: webhookUsecase,
+		logger:         logger,
+	}
+}
+
+// CreateSubscription handles POST /api/webhooks
+func (c *WebhookController) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	var req domain_webhook.CreateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	response, err := c.webhookUsecase.CreateSubscription(r.Context(), caller.UserID, req)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to create webhook subscription")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusCreated, CreateSubscriptionResponse{
+		SubscriptionResponse: newSubscriptionResponse(response.Subscription),
+		Secret:               response.Secret,
+	})
+}
+
+// ListSubscriptions handles GET /api/webhooks
+func (c *WebhookController) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+
+	subs, err := c.webhookUsecase.ListSubscriptions(r.Context(), caller.UserID)
+	if err != nil {
+		problem.WriteError(w, c.logger, err, "Failed to list webhook subscriptions")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(subs, newSubscriptionResponse))
+}
+
+// DeleteSubscription handles DELETE /api/webhooks/{id}
+func (c *WebhookController) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	subscriptionID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	if err := c.webhookUsecase.DeleteSubscription(r.Context(), caller.UserID, subscriptionID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Webhook subscription not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to delete webhook subscription")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /api/webhooks/{id}/deliveries
+func (c *WebhookController) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	caller, ok := requireCaller(w, r)
+	if !ok {
+		return
+	}
+	vars := mux.Vars(r)
+	subscriptionID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		c.respondWithError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	deliveries, err := c.webhookUsecase.ListDeliveries(r.Context(), caller.UserID, subscriptionID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			c.respondWithError(w, http.StatusNotFound, "Webhook subscription not found")
+			return
+		}
+		problem.WriteError(w, c.logger, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	c.respondWithJSON(w, http.StatusOK, newResponses(deliveries, newWebhookDeliveryResponse))
+}
+
+// Helper methods
+
+func (c *WebhookController) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (c *WebhookController) respondWithError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
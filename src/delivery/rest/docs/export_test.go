@@ -0,0 +1,3 @@
+package docs
+
+var Operations = operations
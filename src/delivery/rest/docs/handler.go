@@ -0,0 +1,77 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// Handler serves the OpenAPI spec of a router and a Swagger UI for it
+type Handler struct {
+	router *mux.Router
+	logger *utils.Logger
+
+	once sync.Once
+	spec []byte
+	err  error
+}
+
+// NewHandler creates a docs handler. The spec is built on first request, so
+// it covers every route registered by then.
+func NewHandler(router *mux.Router, logger *utils.Logger) *Handler {
+	return &Handler{
+		router: router,
+		logger: logger,
+	}
+}
+
+// Spec handles GET /docs/openapi.json
+func (h *Handler) Spec(w http.ResponseWriter, r *http.Request) {
+	h.once.Do(func() {
+		doc, err := Build(h.router)
+		if err != nil {
+			h.err = err
+			return
+		}
+		h.spec, h.err = json.Marshal(doc)
+	})
+	if h.err != nil {
+		h.logger.Error("Failed to build OpenAPI spec", "error", h.err)
+		problem.Write(w, http.StatusInternalServerError, "Failed to build OpenAPI spec")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(h.spec)
+}
+
+// UI handles GET /docs
+func (h *Handler) UI(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(swaggerUI))
+}
+
+// Swagger UI is loaded from a CDN so no assets need to be vendored
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Booking Manager API</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/docs/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
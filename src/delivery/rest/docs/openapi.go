@@ -0,0 +1,276 @@
+package docs
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+)
+
+// OpenAPI 3.0 document, limited to what this API uses
+
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*PathItem `json:"paths"`
+	Components Components                      `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type PathItem struct {
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// Version of the API contract, bumped on breaking changes
+const apiVersion = "1.0.0"
+
+var (
+	pathParam      = regexp.MustCompile(`{([^}:]+)(:[^}]*)?}`)
+	versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+)
+
+// Build describes every route registered on the router. Routes with an entry
+// in operations get typed request and response schemas; the rest are listed
+// with a generic response so nothing goes missing from the spec.
+func Build(router *mux.Router) (*Document, error) {
+	gen := &generator{schemas: map[string]*Schema{}, names: map[reflect.Type]string{}}
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: "Booking Manager API", Version: apiVersion},
+		Paths:   map[string]map[string]*PathItem{},
+	}
+
+	errorSchema := gen.schemaOf(reflect.TypeOf(errorResponse))
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		tmpl, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(tmpl, "/api/") {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		for _, method := range methods {
+			op := operations[method+" "+tmpl]
+			item := &PathItem{
+				Summary:   op.Summary,
+				Tags:      []string{tag(tmpl)},
+				Responses: map[string]*Response{},
+			}
+			for _, match := range pathParam.FindAllStringSubmatch(tmpl, -1) {
+				item.Parameters = append(item.Parameters, Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "string"}})
+			}
+			for _, q := range op.Query {
+				item.Parameters = append(item.Parameters, Parameter{Name: q.Name, In: "query", Required: q.Required, Schema: &Schema{Type: "string"}})
+			}
+			if op.Request != nil {
+				item.RequestBody = &RequestBody{
+					Required: true,
+					Content:  map[string]*MediaType{"application/json": {Schema: gen.schemaOf(reflect.TypeOf(op.Request))}},
+				}
+			}
+
+			status := op.Status
+			if status == 0 {
+				status = http.StatusOK
+			}
+			success := &Response{Description: http.StatusText(status)}
+			switch {
+			case op.ContentType != "":
+				success.Content = map[string]*MediaType{op.ContentType: {Schema: &Schema{Type: "string", Format: "binary"}}}
+			case op.Response != nil:
+				success.Content = map[string]*MediaType{"application/json": {Schema: gen.schemaOf(reflect.TypeOf(op.Response))}}
+			}
+			item.Responses[strconv.Itoa(status)] = success
+			item.Responses["default"] = &Response{
+				Description: "Error",
+				Content:     map[string]*MediaType{problem.ContentType: {Schema: errorSchema}},
+			}
+
+			// Drop mux's regexp constraints, OpenAPI only wants the name
+			key := pathParam.ReplaceAllString(tmpl, "{$1}")
+			if doc.Paths[key] == nil {
+				doc.Paths[key] = map[string]*PathItem{}
+			}
+			doc.Paths[key][strings.ToLower(method)] = item
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Components.Schemas = gen.schemas
+	return doc, nil
+}
+
+// tag groups operations by their first path segment after the version, with
+// admin routes grouped under the resource they manage
+func tag(tmpl string) string {
+	parts := strings.Split(strings.TrimPrefix(tmpl, "/api/"), "/")
+	if versionSegment.MatchString(parts[0]) && len(parts) > 1 {
+		parts = parts[1:]
+	}
+	if parts[0] == "admin" && len(parts) > 1 {
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// generator turns Go types into schemas the way encoding/json would encode
+// them. Named structs become components and are referenced by name.
+type generator struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+var (
+	uuidType    = reflect.TypeOf(uuid.UUID{})
+	timeType    = reflect.TypeOf(time.Time{})
+	rawJSONType = reflect.TypeOf(json.RawMessage{})
+)
+
+func (g *generator) schemaOf(t reflect.Type) *Schema {
+	switch t {
+	case uuidType:
+		return &Schema{Type: "string", Format: "uuid"}
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawJSONType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema := g.schemaOf(t.Elem())
+		if schema.Ref != "" {
+			return schema
+		}
+		schema.Nullable = true
+		return schema
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + g.component(t)}
+	}
+	// Interfaces can hold anything
+	return &Schema{}
+}
+
+// component registers a named struct once and returns its component name
+func (g *generator) component(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.schemas[name]; taken {
+		// Same name in another package, e.g. two LineItem types
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+	g.names[t] = name
+	g.schemas[name] = &Schema{} // placeholder, so recursive types terminate
+	*g.schemas[name] = *g.object(t)
+	return name
+}
+
+func (g *generator) object(t reflect.Type) *Schema {
+	schema := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	g.fields(t, schema)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+func (g *generator) fields(t reflect.Type, schema *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			// Embedded structs are flattened by encoding/json
+			g.fields(field.Type, schema)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		schema.Properties[name] = g.schemaOf(field.Type)
+		if !strings.Contains(options, "omitempty") && field.Type.Kind() != reflect.Ptr {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package docs
+
+import (
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+	domain_migration "github.com/ojaswiii/booking-manager/src/internal/domain/migration"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_presale "github.com/ojaswiii/booking-manager/src/internal/domain/presale"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+)
+
+// Operation describes one route: the types its controller decodes and
+// encodes, so the schemas follow the code when fields change
+type Operation struct {
+	Summary     string
+	Query       []QueryParam
+	Request     interface{} // request body, nil when there is none
+	Response    interface{} // success body, nil when there is none
+	Status      int         // success status, 200 when zero
+	ContentType string      // for non-JSON responses, which are described as binary
+}
+
+type QueryParam struct {
+	Name     string
+	Required bool
+}
+
+var errorResponse = problem.Problem{}
+
+var userIDParam = []QueryParam{{Name: "user_id", Required: true}}
+
+// The sales report's bucket interval
+var reportParams = []QueryParam{{Name: "interval"}}
+
+// Filters read by the event listing endpoints
+var eventFilterParams = []QueryParam{
+	{Name: "q"}, {Name: "artist"}, {Name: "venue"}, {Name: "status"},
+	{Name: "from"}, {Name: "to"}, {Name: "min_price"}, {Name: "max_price"},
+	{Name: "sort"}, {Name: "order"}, {Name: "limit"}, {Name: "offset"},
+}
+
+// Party size and seating preferences
+var seatSuggestionParams = []QueryParam{
+	{Name: "party_size", Required: true}, {Name: "position"}, {Name: "aisle"},
+	{Name: "tier"}, {Name: "session"}, {Name: "limit"},
+}
+
+// The admin, and filters read by the user search
+var userSearchParams = []QueryParam{
+	{Name: "email"}, {Name: "created_from"}, {Name: "created_to"},
+	{Name: "limit"}, {Name: "offset"},
+}
+
+// Position of the last booking event read, and how many to read after it
+var bookingEventParams = []QueryParam{{Name: "after"}, {Name: "limit"}}
+
+// operations is keyed by method and path template as registered on the router
+var operations = map[string]Operation{
+	// Users
+	"POST /api/v1/users":                        {Summary: "Create a user", Request: usecase.CreateUserRequest{}, Response: usecase.CreateUserResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/users/{id}":                    {Summary: "Get a user", Response: controllers.UserResponse{}},
+	"PUT /api/v1/users/{id}":                    {Summary: "Update a user", Request: controllers.UpdateUserBody{}, Response: controllers.UserResponse{}},
+	"DELETE /api/v1/users/{id}":                 {Summary: "Delete a user, keeping their bookings until they are purged", Response: controllers.MessageResponse{}},
+	"PUT /api/v1/admin/users/{id}/role":         {Summary: "Grant a user a role", Request: controllers.SetUserRoleBody{}, Response: controllers.UserResponse{}},
+	"POST /api/v1/admin/users/{id}/restore":     {Summary: "Restore a deleted user", Response: controllers.UserResponse{}},
+	"DELETE /api/v1/admin/users/{id}/purge":     {Summary: "Permanently delete a deleted user and their bookings", Response: controllers.MessageResponse{}},
+	"GET /api/v1/admin/users":                   {Summary: "Search users by email prefix and signup date, newest first", Query: userSearchParams, Response: []controllers.UserResponse{}},
+	"GET /api/v1/admin/users/{id}/bookings":     {Summary: "List a user's bookings with their payment details", Response: []controllers.AdminBookingResponse{}},
+	"POST /api/v1/admin/users/{id}/lock":        {Summary: "Lock a user out, ending their sessions", Request: usecase.UserAdminActionRequest{}, Response: controllers.UserLockResponse{}},
+	"POST /api/v1/admin/users/{id}/unlock":      {Summary: "Let a locked user sign in again", Request: usecase.UserAdminActionRequest{}, Response: controllers.UserResponse{}},
+	"POST /api/v1/admin/users/{id}/impersonate": {Summary: "Get a short-lived access token to act as a user, for support", Request: usecase.UserAdminActionRequest{}, Response: usecase.ImpersonationResponse{}},
+	"GET /api/v1/users/{id}/bookings":           {Summary: "List a user's bookings", Response: []controllers.BookingResponse{}},
+	"GET /api/v1/users/{id}/bookings.csv":       {Summary: "Export a user's booked tickets as CSV", ContentType: "text/csv"},
+	"GET /api/v1/users/{id}/calendar.ics":       {Summary: "Subscribe to a user's confirmed bookings as an iCalendar feed", ContentType: "text/calendar"},
+	"GET /api/v1/users/{id}/export":             {Summary: "Export everything kept about a user as JSON", Response: controllers.UserDataExportResponse{}},
+	"DELETE /api/v1/users/{id}/erase":           {Summary: "Erase a user's personal data, keeping their bookings", Response: controllers.AuditEntryResponse{}},
+	"GET /api/v1/users/{id}/preferences":        {Summary: "Get notification preferences", Response: controllers.PreferencesResponse{}},
+	"PUT /api/v1/users/{id}/preferences":        {Summary: "Update notification preferences", Request: domain_notification.UpdatePreferencesRequest{}, Response: controllers.PreferencesResponse{}},
+
+	// Sessions
+	"POST /api/v1/auth/login":   {Summary: "Sign in, starting a session", Request: usecase.LoginRequest{}, Response: usecase.TokenResponse{}},
+	"POST /api/v1/auth/refresh": {Summary: "Swap a refresh token for a new access token and refresh token", Request: usecase.RefreshRequest{}, Response: usecase.TokenResponse{}},
+	"POST /api/v1/auth/logout":  {Summary: "End the session of a refresh token, or every session of its user", Request: usecase.LogoutRequest{}, Response: controllers.LogoutResponse{}},
+	"GET /api/v1/auth/sessions": {Summary: "List the sessions of the user the bearer access token is for", Response: []controllers.SessionResponse{}},
+	"GET /api/v1/auth/verify":   {Summary: "Verify a user's email with the token emailed to them", Query: []QueryParam{{Name: "token", Required: true}}, Response: controllers.UserResponse{}},
+	"POST /api/v1/auth/forgot":  {Summary: "Email a single-use password reset token, if a user has the email", Request: usecase.ForgotPasswordRequest{}, Response: controllers.MessageResponse{}, Status: http.StatusAccepted},
+	"POST /api/v1/auth/reset":   {Summary: "Set a new password with a reset token, ending every session of the user", Request: usecase.ResetPasswordRequest{}, Response: controllers.PasswordResetResponse{}},
+
+	"GET /api/v1/auth/oauth/{provider}/start":    {Summary: "Redirect to sign in at an external provider", Status: http.StatusFound},
+	"GET /api/v1/auth/oauth/{provider}/callback": {Summary: "Finish signing in at an external provider, starting a session", Query: []QueryParam{{Name: "code", Required: true}, {Name: "state", Required: true}}, Response: usecase.TokenResponse{}},
+
+	// Partner API keys
+	"POST /api/v1/admin/api-keys":        {Summary: "Issue a partner API key, shown only in this response", Request: usecase.CreateAPIKeyRequest{}, Response: controllers.CreatedAPIKeyResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/admin/api-keys":         {Summary: "List partner API keys", Response: []controllers.APIKeyResponse{}},
+	"DELETE /api/v1/admin/api-keys/{id}": {Summary: "Revoke a partner API key", Response: controllers.APIKeyResponse{}},
+	"GET /api/v1/partner/events":         {Summary: "List published events, with an API key scoped events:read", Query: eventFilterParams, Response: []controllers.EventResponse{}},
+	"POST /api/v1/partner/bookings":      {Summary: "Create a booking for the API key's user, with an API key scoped bookings:write", Request: usecase.CreateBookingRequest{}, Response: usecase.CreateBookingResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/partner/bookings":       {Summary: "List the API key user's bookings, with an API key scoped bookings:read", Response: []controllers.BookingResponse{}},
+
+	// Events
+	"POST /api/v1/events":                                            {Summary: "Create an event", Request: usecase.CreateEventRequest{}, Response: usecase.CreateEventResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/events":                                             {Summary: "List or search published events", Query: eventFilterParams, Response: []controllers.EventResponse{}},
+	"GET /api/v1/events/{id}":                                        {Summary: "Get an event", Response: controllers.EventResponse{}},
+	"GET /api/v1/events/{id}.ics":                                    {Summary: "Download an event as an iCalendar file", ContentType: "text/calendar"},
+	"GET /api/v1/events/{id}/tickets":                                {Summary: "List an event's tickets", Response: []controllers.TicketResponse{}},
+	"GET /api/v1/events/{id}/tickets/available":                      {Summary: "List an event's available tickets", Response: []controllers.TicketResponse{}},
+	"GET /api/v1/admin/events":                                       {Summary: "List or search events in any status", Query: eventFilterParams, Response: []controllers.EventResponse{}},
+	"PUT /api/v1/admin/events/{id}/status":                           {Summary: "Change an event's lifecycle status", Request: controllers.TransitionEventStatusBody{}, Response: controllers.EventResponse{}},
+	"DELETE /api/v1/admin/events/{id}":                               {Summary: "Delete an event, keeping its tickets and bookings until it is purged", Response: controllers.MessageResponse{}},
+	"POST /api/v1/admin/events/{id}/restore":                         {Summary: "Restore a deleted event", Response: controllers.EventResponse{}},
+	"DELETE /api/v1/admin/events/{id}/purge":                         {Summary: "Permanently delete a deleted event with its tickets and bookings", Response: controllers.MessageResponse{}},
+	"POST /api/v1/events/{id}/waiting-room":                          {Summary: "Join an event's waiting room", Request: controllers.JoinWaitingRoomBody{}, Response: usecase.WaitingRoomStatus{}},
+	"GET /api/v1/events/{id}/waiting-room/{token}":                   {Summary: "Get a waiting room position", Response: usecase.WaitingRoomStatus{}},
+	"POST /api/v1/events/{id}/presale/redeem":                        {Summary: "Redeem a presale code", Request: domain_presale.RedeemCodeRequest{}, Response: controllers.StatusResponse{}},
+	"POST /api/v1/admin/events/{id}/presale-codes":                   {Summary: "Generate presale codes in a background job", Request: domain_presale.GenerateCodesRequest{}, Response: controllers.JobResponse{}, Status: http.StatusAccepted},
+	"GET /api/v1/admin/events/{id}/presale-codes/batches/{batch_id}": {Summary: "Get a batch of presale codes", Response: domain_presale.GenerateCodesResponse{}},
+	"POST /api/v1/admin/events/{id}/holds":                           {Summary: "Hold seats off sale", Request: domain_hold.CreateHoldRequest{}, Response: controllers.HoldResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/admin/events/{id}/holds":                            {Summary: "List an event's seat holds", Response: []controllers.HoldResponse{}},
+	"POST /api/v1/admin/events/{id}/holds/{hold_id}/release":         {Summary: "Release a seat hold", Response: controllers.ReleaseHoldResponse{}},
+	"GET /api/v1/admin/events/{id}/refund-policy":                    {Summary: "Get an event's refund policy", Response: controllers.RefundPolicyResponse{}},
+	"PUT /api/v1/admin/events/{id}/refund-policy":                    {Summary: "Update an event's refund policy", Request: domain_refund.UpdatePolicyRequest{}, Response: controllers.RefundPolicyResponse{}},
+	"GET /api/v1/events/{id}/seat-suggestions":                       {Summary: "Suggest groups of adjacent seats for a party", Query: seatSuggestionParams, Response: usecase.SeatSuggestionResponse{}},
+	"POST /api/v1/events/{id}/broadcast":                             {Summary: "Message an event's confirmed attendees in a background job", Request: domain_broadcast.CreateBroadcastRequest{}, Response: controllers.JobResponse{}, Status: http.StatusAccepted},
+	"GET /api/v1/events/{id}/broadcasts":                             {Summary: "List an event's broadcasts with delivery reports", Response: []controllers.BroadcastResponse{}},
+	"GET /api/v1/admin/events/{id}/stats":                            {Summary: "Get an event's booking counters and revenue", Response: controllers.EventStatsResponse{}},
+	"GET /api/v1/admin/events/{id}/bookings.csv":                     {Summary: "Export an event's attendees, one row per booked ticket, as CSV", ContentType: "text/csv"},
+	"GET /api/v1/admin/events/{id}/report":                           {Summary: "Report an event's sales, refunds and fill rate over time", Query: reportParams, Response: controllers.SalesReportResponse{}},
+	"GET /api/v1/admin/dashboard/availability":                       {Summary: "Count the tickets of every upcoming event by status, from the read models", Response: []controllers.EventAvailabilityResponse{}},
+	"POST /api/v1/admin/read-models/rebuild":                         {Summary: "Project the read models again from the booking event store", Response: controllers.MessageResponse{}, Status: http.StatusAccepted},
+	"GET /api/v1/admin/metrics/bookings":                             {Summary: "Get the booking processor's per-minute counts over a range", Query: []QueryParam{{Name: "from"}, {Name: "to"}}, Response: controllers.BookingMetricsResponse{}},
+	"GET /api/v1/admin/reports/sales":                                {Summary: "Report the sales of the events taking place in a period", Query: append([]QueryParam{{Name: "from"}, {Name: "to"}}, reportParams...), Response: controllers.SalesReportResponse{}},
+	"GET /api/v1/events/{id}/broadcasts/{broadcast_id}":              {Summary: "Get a broadcast's delivery report", Response: controllers.BroadcastResponse{}},
+	"GET /api/v1/events/{id}/checkins":                               {Summary: "Count an event's check-ins", Response: controllers.CheckInCountsResponse{}},
+	"GET /api/v1/events/{id}/availability":                           {Summary: "Count an event's tickets by status, price tier and section", Response: controllers.AvailabilityResponse{}},
+	"GET /api/v1/events/{id}/availability/stream":                    {Summary: "Stream changes to an event's seats as server-sent events", ContentType: "text/event-stream"},
+
+	// Bookings
+	"POST /api/v1/quotes":                                   {Summary: "Quote a price for tickets", Request: usecase.CreateQuoteRequest{}, Response: usecase.CreateQuoteResponse{}},
+	"POST /api/v1/bookings":                                 {Summary: "Create a booking", Request: usecase.CreateBookingRequest{}, Response: usecase.CreateBookingResponse{}, Status: http.StatusCreated},
+	"POST /api/v1/bookings/{id}/confirm":                    {Summary: "Confirm a booking; 202 with status review when the payment is held", Request: controllers.ConfirmBookingBody{}, Response: controllers.StatusResponse{}},
+	"POST /api/v1/bookings/{id}/cancel":                     {Summary: "Cancel a booking", Request: controllers.CancelBookingBody{}, Response: controllers.StatusResponse{}},
+	"PATCH /api/v1/bookings/{id}/tickets":                   {Summary: "Change a booking's seats", Request: controllers.ChangeSeatsBody{}, Response: controllers.BookingResponse{}},
+	"GET /api/v1/bookings/stats":                            {Summary: "Get booking processor statistics", Response: map[string]interface{}{}},
+	"GET /api/v1/bookings/updates":                          {Summary: "Open a WebSocket pushing updates to queued booking requests", Query: userIDParam, Status: http.StatusSwitchingProtocols},
+	"GET /api/v1/bookings/{id}/tickets.pdf":                 {Summary: "Download a confirmed booking's tickets", Query: userIDParam, ContentType: "application/pdf"},
+	"POST /api/v1/bookings/{id}/refund":                     {Summary: "Refund a booking", Request: domain_refund.RefundRequest{}, Response: controllers.RefundResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/bookings/{id}/refunds":                     {Summary: "List a booking's refunds", Query: userIDParam, Response: []controllers.RefundResponse{}},
+	"GET /api/v1/bookings/{id}/cancellation-preview":        {Summary: "Preview the fee and refund for cancelling a booking", Query: userIDParam, Response: domain_refund.CancellationPreview{}},
+	"POST /api/v1/bookings/{id}/returns":                    {Summary: "Give tickets back for resale without a refund", Request: domain_refund.ReturnRequest{}, Response: controllers.ReturnResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/bookings/{id}/returns":                     {Summary: "List a booking's ticket returns", Query: userIDParam, Response: []controllers.ReturnResponse{}},
+	"POST /api/v1/admin/bookings/{id}/refund":               {Summary: "Refund a booking outside the refund policy", Request: domain_refund.RefundRequest{}, Response: controllers.RefundResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/admin/bookings/reviews":                    {Summary: "List bookings held for payment review", Response: []controllers.AdminBookingResponse{}},
+	"POST /api/v1/admin/bookings/{id}/approve":              {Summary: "Approve a held payment and confirm the booking", Response: controllers.AdminBookingResponse{}},
+	"POST /api/v1/admin/bookings/{id}/reject":               {Summary: "Reject a held payment and release the tickets", Response: controllers.AdminBookingResponse{}},
+	"GET /api/v1/admin/bookings/{id}/history":               {Summary: "Replay every status change of a booking and its tickets", Response: []controllers.TransitionResponse{}},
+	"GET /api/v1/admin/bookings/events":                     {Summary: "Read the events of all bookings in the order they were stored", Query: bookingEventParams, Response: []controllers.BookingEventResponse{}},
+	"GET /api/v1/admin/bookings/{id}/events":                {Summary: "Get a booking's event stream", Response: []controllers.BookingEventResponse{}},
+	"POST /api/v1/admin/bookings/{id}/rebuild":              {Summary: "Rebuild a booking from its event stream", Response: controllers.AdminBookingResponse{}},
+	"GET /api/v1/admin/bookings/dead-letters":               {Summary: "List booking requests that failed for good, newest first; ?limit= up to 500", Response: []controllers.DeadLetterResponse{}},
+	"GET /api/v1/admin/bookings/dead-letters/{id}":          {Summary: "Get a dead-lettered booking request", Response: controllers.DeadLetterResponse{}},
+	"POST /api/v1/admin/bookings/dead-letters/{id}/requeue": {Summary: "Queue a dead-lettered booking request again", Response: controllers.DeadLetterResponse{}, Status: http.StatusAccepted},
+	"DELETE /api/v1/admin/bookings/dead-letters/{id}":       {Summary: "Discard a dead-lettered booking request", Status: http.StatusNoContent},
+	"POST /api/v1/checkin":                                  {Summary: "Check in a ticket pass at the door", Request: domain_ticket.CheckInRequest{}, Response: domain_ticket.CheckInResponse{}},
+	"POST /api/v1/payments/webhook":                         {Summary: "Receive payment provider events; the body is the provider's own payload", Response: controllers.StatusResponse{}},
+
+	// Templates
+	"POST /api/v1/templates":                  {Summary: "Create an event template", Request: domain_template.CreateTemplateRequest{}, Response: controllers.TemplateResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/templates":                   {Summary: "List event templates", Response: []controllers.TemplateResponse{}},
+	"GET /api/v1/templates/{id}":              {Summary: "Get an event template", Response: controllers.TemplateResponse{}},
+	"DELETE /api/v1/templates/{id}":           {Summary: "Delete an event template", Status: http.StatusNoContent},
+	"POST /api/v1/templates/{id}/instantiate": {Summary: "Create an event from a template", Request: domain_template.InstantiateRequest{}, Response: usecase.CreateEventResponse{}, Status: http.StatusCreated},
+
+	// Webhooks
+	"POST /api/v1/webhooks":                {Summary: "Subscribe to webhook events", Request: domain_webhook.CreateSubscriptionRequest{}, Response: controllers.CreateSubscriptionResponse{}, Status: http.StatusCreated},
+	"GET /api/v1/webhooks":                 {Summary: "List webhook subscriptions", Response: []controllers.SubscriptionResponse{}},
+	"DELETE /api/v1/webhooks/{id}":         {Summary: "Delete a webhook subscription", Status: http.StatusNoContent},
+	"GET /api/v1/webhooks/{id}/deliveries": {Summary: "List a subscription's recent deliveries", Response: []controllers.WebhookDeliveryResponse{}},
+
+	// Operations
+	"GET /api/v1/status":                                   {Summary: "Get public service status", Response: usecase.PublicStatus{}},
+	"GET /api/v1/admin/jobs/{id}":                          {Summary: "Get a background job", Response: controllers.JobResponse{}},
+	"GET /api/v1/admin/column-migrations":                  {Summary: "List column migrations with their phase and rows left to backfill", Response: []domain_migration.ColumnMigration{}},
+	"POST /api/v1/admin/column-migrations/{name}/backfill": {Summary: "Backfill a column migration's new column in a background job", Response: controllers.JobResponse{}, Status: http.StatusAccepted},
+}
@@ -0,0 +1,58 @@
+package docs_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/docs"
+)
+
+// TestResponsesOmitStorageModels keeps internal fields out of public JSON.
+// Storage models, the structs with db tags, gain columns as the schema
+// grows; responses map the fields they expose explicitly, so a new column
+// stays private until someone adds it to a response type.
+func TestResponsesOmitStorageModels(t *testing.T) {
+	for key, op := range docs.Operations {
+		if op.Response == nil {
+			continue
+		}
+		seen := map[reflect.Type]bool{}
+		if path := storageModel(reflect.TypeOf(op.Response), seen); path != "" {
+			t.Errorf("%s responds with storage model %s", key, path)
+		}
+	}
+}
+
+// storageModel returns the path to the first struct with db tags reachable
+// through the JSON fields of t, or "" when there is none
+func storageModel(t reflect.Type, seen map[reflect.Type]bool) string {
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return storageModel(t.Elem(), seen)
+	case reflect.Struct:
+	default:
+		return ""
+	}
+	if seen[t] {
+		return ""
+	}
+	seen[t] = true
+
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := t.Field(i).Tag.Lookup("db"); ok {
+			return t.String()
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if path := storageModel(field.Type, seen); path != "" {
+			return t.String() + "." + field.Name + " → " + path
+		}
+	}
+	return ""
+}
@@ -15,12 +15,41 @@ type RestContainer struct {
 // NewRestContainer creates a new REST container
 func NewRestContainer(usecases *usecase.UsecaseContainer, logger *utils.Logger) *RestContainer {
 	// Create controllers
-	userController := controllers.NewUserController(usecases.User, logger)
+	userController := controllers.NewUserController(usecases.User, usecases.Auth, logger)
+	userAdminController := controllers.NewUserAdminController(usecases.UserAdmin, logger)
 	eventController := controllers.NewEventController(usecases.Event, logger)
 	bookingController := controllers.NewBookingController(usecases.Booking, logger)
+	quoteController := controllers.NewQuoteController(usecases.Quote, logger)
+	waitingRoomController := controllers.NewWaitingRoomController(usecases.WaitingRoom, logger)
+	presaleController := controllers.NewPresaleController(usecases.Presale, logger)
+	webhookController := controllers.NewWebhookController(usecases.Webhook, logger)
+	templateController := controllers.NewTemplateController(usecases.Template, logger)
+	notificationController := controllers.NewNotificationController(usecases.Notification, logger)
+	statusController := controllers.NewStatusController(usecases.Status, logger)
+	ticketController := controllers.NewTicketController(usecases.TicketPass, usecases.CheckIn, logger)
+	holdController := controllers.NewHoldController(usecases.Hold, logger)
+	jobController := controllers.NewJobController(usecases.Job, logger)
+	refundController := controllers.NewRefundController(usecases.Refund, logger)
+	paymentController := controllers.NewPaymentController(usecases.Payment, logger)
+	columnMigrationController := controllers.NewColumnMigrationController(usecases.ColumnMigration, logger)
+	seatSuggestionController := controllers.NewSeatSuggestionController(usecases.SeatSuggestion, logger)
+	broadcastController := controllers.NewBroadcastController(usecases.Broadcast, logger)
+	eventStatsController := controllers.NewEventStatsController(usecases.EventStats, logger)
+	reportController := controllers.NewReportController(usecases.Report, logger)
+	bookingMetricsController := controllers.NewBookingMetricsController(usecases.BookingMetrics, logger)
+	availabilityController := controllers.NewAvailabilityController(usecases.Availability, usecases.AvailabilityFeed, logger)
+	bookingUpdateController := controllers.NewBookingUpdateController(usecases.BookingUpdates, logger)
+	bookingExportController := controllers.NewBookingExportController(usecases.BookingExport, logger)
+	calendarController := controllers.NewCalendarController(usecases.Calendar, logger)
+	privacyController := controllers.NewPrivacyController(usecases.Privacy, logger)
+	deletionController := controllers.NewDeletionController(usecases.Deletion, logger)
+	readModelController := controllers.NewReadModelController(usecases.ReadModel, logger)
+	authController := controllers.NewAuthController(usecases.Auth, logger)
+	apiKeyController := controllers.NewAPIKeyController(usecases.APIKey, logger)
+	healthController := controllers.NewHealthController(usecases.Health, logger)
 
 	// Create router
-	router := routers.NewRouter(userController, eventController, bookingController, logger)
+	router := routers.NewRouter(userController, userAdminController, eventController, bookingController, quoteController, waitingRoomController, presaleController, webhookController, templateController, notificationController, statusController, ticketController, holdController, jobController, refundController, paymentController, columnMigrationController, seatSuggestionController, broadcastController, eventStatsController, reportController, bookingMetricsController, availabilityController, bookingUpdateController, bookingExportController, calendarController, privacyController, deletionController, readModelController, authController, apiKeyController, healthController, logger)
 
 	return &RestContainer{
 		Router: router,
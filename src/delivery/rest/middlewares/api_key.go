@@ -0,0 +1,50 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// APIKeyHeader carries a partner's API key
+const APIKeyHeader = "X-API-Key"
+
+// APIKeyAuthenticator checks the key a partner request came with
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, key string, scope domain_apikey.Scope) (*domain_apikey.APIKey, error)
+}
+
+// APIKey middleware lets through only requests with an active key that has
+// scope and is within its rate limit, and puts the key in their context
+func APIKey(authenticator APIKeyAuthenticator, scope domain_apikey.Scope, logger *utils.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			secret := r.Header.Get(APIKeyHeader)
+			if secret == "" {
+				problem.Write(w, http.StatusUnauthorized, "Missing "+APIKeyHeader+" header")
+				return
+			}
+
+			key, err := authenticator.Authenticate(r.Context(), secret, scope)
+			var limited *usecase.APIKeyRateLimitError
+			if errors.As(err, &limited) {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(limited.RetryAfter.Seconds()))))
+				problem.Write(w, http.StatusTooManyRequests, "API key rate limit exceeded; please retry shortly")
+				return
+			}
+			if err != nil {
+				problem.WriteError(w, logger, err, "Failed to check API key")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain_apikey.WithKey(r.Context(), key)))
+		})
+	}
+}
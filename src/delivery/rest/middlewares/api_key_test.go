@@ -0,0 +1,67 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+type stubAuthenticator struct {
+	key *domain_apikey.APIKey
+	err error
+}
+
+func (s stubAuthenticator) Authenticate(ctx context.Context, key string, scope domain_apikey.Scope) (*domain_apikey.APIKey, error) {
+	return s.key, s.err
+}
+
+func TestAPIKey(t *testing.T) {
+	accountID := uuid.New()
+	var seen *domain_apikey.APIKey
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = domain_apikey.FromContext(r.Context())
+	})
+
+	tests := []struct {
+		name       string
+		header     string
+		auth       stubAuthenticator
+		wantStatus int
+		retryAfter string
+	}{
+		{"missing key", "", stubAuthenticator{}, http.StatusUnauthorized, ""},
+		{"invalid key", "bmk_nope", stubAuthenticator{err: domain_apikey.ErrInvalidAPIKey}, http.StatusUnauthorized, ""},
+		{"missing scope", "bmk_readonly", stubAuthenticator{err: domain_apikey.ErrScopeDenied}, http.StatusForbidden, ""},
+		{"rate limited", "bmk_busy", stubAuthenticator{err: &usecase.APIKeyRateLimitError{RetryAfter: 1500 * time.Millisecond}}, http.StatusTooManyRequests, "2"},
+		{"valid key", "bmk_good", stubAuthenticator{key: &domain_apikey.APIKey{UserID: accountID}}, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/partner/bookings", nil)
+			if tt.header != "" {
+				req.Header.Set(APIKeyHeader, tt.header)
+			}
+			w := httptest.NewRecorder()
+			APIKey(tt.auth, domain_apikey.ScopeBookingsWrite, utils.NewLogger())(next).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Retry-After"); got != tt.retryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.retryAfter)
+			}
+			if tt.wantStatus == http.StatusOK && (seen == nil || seen.UserID != accountID) {
+				t.Errorf("key in context = %+v, want the authenticated key", seen)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// BearerAuthenticator checks the access token a request came with
+type BearerAuthenticator interface {
+	AuthenticateBearer(ctx context.Context, token string) (*domain_session.Caller, error)
+}
+
+// Authentication middleware checks the bearer access token of requests
+// that send one and puts who they are signed in as in their context. A bad
+// token is refused with a 401; requests without one pass through, for the
+// routes that need a caller to refuse.
+func Authentication(authenticator BearerAuthenticator, logger *utils.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			scheme, token, _ := strings.Cut(header, " ")
+			if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				problem.Write(w, http.StatusUnauthorized, "Authorization must be a bearer access token")
+				return
+			}
+
+			caller, err := authenticator.AuthenticateBearer(r.Context(), strings.TrimSpace(token))
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				problem.WriteError(w, logger, err, "Failed to check access token")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain_session.WithCaller(r.Context(), caller)))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+type stubBearerAuthenticator struct {
+	caller *domain_session.Caller
+	err    error
+}
+
+func (s stubBearerAuthenticator) AuthenticateBearer(ctx context.Context, token string) (*domain_session.Caller, error) {
+	return s.caller, s.err
+}
+
+func TestAuthentication(t *testing.T) {
+	userID := uuid.New()
+	var seen *domain_session.Caller
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = domain_session.CallerFromContext(r.Context())
+	})
+
+	tests := []struct {
+		name       string
+		header     string
+		auth       stubBearerAuthenticator
+		wantStatus int
+		wantCaller bool
+	}{
+		{"no token", "", stubBearerAuthenticator{}, http.StatusOK, false},
+		{"other scheme", "Basic Zm9vOmJhcg==", stubBearerAuthenticator{}, http.StatusUnauthorized, false},
+		{"invalid token", "Bearer nope", stubBearerAuthenticator{err: usecase.ErrInvalidAccessToken}, http.StatusUnauthorized, false},
+		{"valid token", "Bearer good", stubBearerAuthenticator{caller: &domain_session.Caller{UserID: userID}}, http.StatusOK, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seen = nil
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			Authentication(tt.auth, utils.NewLogger())(next).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantCaller && (seen == nil || seen.UserID != userID) {
+				t.Errorf("caller in context = %+v, want the authenticated user", seen)
+			}
+			if !tt.wantCaller && seen != nil {
+				t.Errorf("caller in context = %+v, want none", seen)
+			}
+		})
+	}
+}
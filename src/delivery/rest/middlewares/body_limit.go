@@ -0,0 +1,48 @@
+package middlewares
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+)
+
+// BodyLimit middleware refuses requests with a body over maxBytes with a
+// 413, before the handler runs. A body of unknown length, sent chunked, is
+// read in first to find its length; one of known length is cut off at it
+// by the server. A maxBytes of 0 sets no limit.
+func BodyLimit(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if maxBytes <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				tooLarge(w)
+				return
+			}
+			if r.ContentLength < 0 {
+				body, err := io.ReadAll(io.LimitReader(r.Body, maxBytes+1))
+				r.Body.Close()
+				if err != nil {
+					problem.Write(w, http.StatusBadRequest, "Failed to read request body")
+					return
+				}
+				if int64(len(body)) > maxBytes {
+					tooLarge(w)
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// tooLarge refuses a request, closing the connection rather than reading
+// the rest of its body
+func tooLarge(w http.ResponseWriter) {
+	w.Header().Set("Connection", "close")
+	problem.Write(w, http.StatusRequestEntityTooLarge, "Request body is too large")
+}
@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestBodyLimit(t *testing.T) {
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		w.Write(body)
+	})
+	handler := BodyLimit(8)(echo)
+
+	tests := []struct {
+		name     string
+		body     string
+		chunked  bool
+		wantCode int
+	}{
+		{name: "within the limit", body: "12345678", wantCode: http.StatusOK},
+		{name: "over the limit", body: "123456789", wantCode: http.StatusRequestEntityTooLarge},
+		{name: "chunked within the limit", body: "1234", chunked: true, wantCode: http.StatusOK},
+		{name: "chunked over the limit", body: "123456789", chunked: true, wantCode: http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/bookings", strings.NewReader(tt.body))
+			if tt.chunked {
+				req.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			if tt.wantCode == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("handler read %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,219 @@
+package middlewares
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CompressionPolicy decides which responses are compressed
+type CompressionPolicy struct {
+	MinBytes      int      // smaller responses are sent as they are
+	ExcludedTypes []string // media types, or prefixes like image/, that are already compressed
+}
+
+// Compression middleware gzips or deflates responses of at least MinBytes,
+// as negotiated by the request's Accept-Encoding. Responses are buffered up
+// to MinBytes so small ones are never compressed.
+func Compression(policy CompressionPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			if encoding == "" || r.Method == http.MethodHead {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			cw := &compressWriter{ResponseWriter: w, policy: policy, encoding: encoding}
+			defer cw.Close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header,
+// preferring gzip when both are equally acceptable
+func negotiateEncoding(header string) string {
+	best, bestQ := "", 0.0
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "gzip" && name != "deflate" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > bestQ || (q == bestQ && name == "gzip") {
+			best, bestQ = name, q
+		}
+	}
+	if bestQ <= 0 {
+		return ""
+	}
+	return best
+}
+
+// compressWriter holds back the status and body until it knows whether the
+// response is worth compressing
+type compressWriter struct {
+	http.ResponseWriter
+	policy   CompressionPolicy
+	encoding string
+
+	status  int
+	buf     bytes.Buffer
+	decided bool
+	out     io.Writer // the compressor, or nil when writing through
+	closer  io.Closer
+}
+
+func (cw *compressWriter) WriteHeader(code int) {
+	if cw.status == 0 {
+		cw.status = code
+	}
+}
+
+func (cw *compressWriter) Write(b []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+	if cw.decided {
+		return cw.write(b)
+	}
+
+	cw.buf.Write(b)
+	if cw.buf.Len() >= cw.policy.MinBytes {
+		if err := cw.decide(true); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Close flushes a response that never reached the threshold and finishes
+// the compressed stream
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if cw.status == 0 {
+			return nil
+		}
+		if err := cw.decide(cw.buf.Len() >= cw.policy.MinBytes); err != nil {
+			return err
+		}
+	}
+	if cw.closer != nil {
+		return cw.closer.Close()
+	}
+	return nil
+}
+
+// decide sends the headers, compressed if large is set and nothing rules it
+// out, then the buffered body
+func (cw *compressWriter) decide(large bool) error {
+	cw.decided = true
+	header := cw.Header()
+
+	if large && cw.compressible() {
+		header.Set("Content-Encoding", cw.encoding)
+		header.Del("Content-Length")
+		if cw.encoding == "gzip" {
+			gz := gzip.NewWriter(cw.ResponseWriter)
+			cw.out, cw.closer = gz, gz
+		} else {
+			fl, _ := flate.NewWriter(cw.ResponseWriter, flate.DefaultCompression)
+			cw.out, cw.closer = fl, fl
+		}
+	}
+
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if cw.buf.Len() == 0 {
+		return nil
+	}
+	_, err := cw.write(cw.buf.Bytes())
+	cw.buf.Reset()
+	return err
+}
+
+// Flush sends what has been written so far, deciding on compression with
+// what is buffered. Streams such as server-sent events flush each message.
+func (cw *compressWriter) Flush() {
+	if !cw.decided {
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+		if err := cw.decide(cw.buf.Len() >= cw.policy.MinBytes); err != nil {
+			return
+		}
+	}
+	if flusher, ok := cw.out.(interface{ Flush() error }); ok {
+		if err := flusher.Flush(); err != nil {
+			return
+		}
+	}
+	http.NewResponseController(cw.ResponseWriter).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection
+func (cw *compressWriter) Unwrap() http.ResponseWriter {
+	return cw.ResponseWriter
+}
+
+// Hijack hands the connection over for a WebSocket. Nothing is written, or
+// compressed, once it has been taken.
+func (cw *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(cw.ResponseWriter).Hijack()
+	if err == nil {
+		cw.decided = true
+	}
+	return conn, brw, err
+}
+
+func (cw *compressWriter) write(b []byte) (int, error) {
+	if cw.out != nil {
+		return cw.out.Write(b)
+	}
+	return cw.ResponseWriter.Write(b)
+}
+
+// compressible reports whether the response may be compressed given its
+// status and headers
+func (cw *compressWriter) compressible() bool {
+	if cw.status < http.StatusOK || cw.status == http.StatusNoContent || cw.status == http.StatusNotModified {
+		return false
+	}
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(cw.buf.Bytes())
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, excluded := range cw.policy.ExcludedTypes {
+		if mediaType == excluded || (strings.HasSuffix(excluded, "/") && strings.HasPrefix(mediaType, excluded)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,100 @@
+package middlewares
+
+import (
+	"compress/flate"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	body := strings.Repeat(`{"seat_number":1,"status":"available"},`, 100)
+	policy := CompressionPolicy{MinBytes: 1024, ExcludedTypes: []string{"application/pdf", "image/"}}
+
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		contentType    string
+		body           string
+		wantEncoding   string
+	}{
+		{"large json", "gzip, deflate", "application/json", body, "gzip"},
+		{"deflate only", "deflate", "application/json", body, "deflate"},
+		{"gzip refused", "gzip;q=0, deflate;q=0.5", "application/json", body, "deflate"},
+		{"small json", "gzip", "application/json", `{"status":"ok"}`, ""},
+		{"excluded type", "gzip", "application/pdf", body, ""},
+		{"excluded prefix", "gzip", "image/png", body, ""},
+		{"not accepted", "br", "application/json", body, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := Compression(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				w.WriteHeader(http.StatusOK)
+				io.WriteString(w, tt.body[:len(tt.body)/2])
+				io.WriteString(w, tt.body[len(tt.body)/2:])
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/events/1/tickets", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want 200", w.Code)
+			}
+
+			var reader io.Reader = w.Body
+			switch tt.wantEncoding {
+			case "gzip":
+				gz, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				reader = gz
+			case "deflate":
+				reader = flate.NewReader(w.Body)
+			}
+			if got, _ := io.ReadAll(reader); string(got) != tt.body {
+				t.Errorf("body did not round-trip")
+			}
+		})
+	}
+}
+
+func TestCompressionFlushesStreams(t *testing.T) {
+	policy := CompressionPolicy{MinBytes: 1024, ExcludedTypes: []string{"text/event-stream"}}
+	flushed := make(chan bool, 1)
+	handler := Compression(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		io.WriteString(w, "event: snapshot\ndata: {}\n\n")
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("flush: %v", err)
+		}
+		flushed <- w.(*compressWriter).decided
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/1/availability/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if !<-flushed {
+		t.Fatalf("small message held back past a flush")
+	}
+	if !w.Flushed {
+		t.Errorf("flush did not reach the connection")
+	}
+	if got := w.Header().Get("Content-Encoding"); got != "" {
+		t.Errorf("Content-Encoding = %q, want none", got)
+	}
+	if got := w.Body.String(); got != "event: snapshot\ndata: {}\n\n" {
+		t.Errorf("body = %q", got)
+	}
+}
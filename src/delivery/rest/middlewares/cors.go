@@ -1,19 +1,78 @@
 package middlewares
 
-import "net/http"
-
-// CORS middleware
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
-
-		if r.Method == "OPTIONS" {
-			w.WriteHeader(http.StatusOK)
-			return
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CORSPolicy lists what browsers on other origins may do with the API
+type CORSPolicy struct {
+	AllowedOrigins   []string // exact origins, e.g. https://app.example.com, or "*" for any
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	AllowCredentials bool // let browsers send cookies and auth headers; never granted to "*"
+	MaxAgeSeconds    int  // how long browsers may cache a preflight, zero for their default
+}
+
+// CORS middleware applies a CORS policy. It must wrap the router rather than
+// be added to it, since preflight requests match no route. Requests from
+// origins outside the policy are served without CORS headers, so browsers
+// refuse to hand the response to the calling page.
+func CORS(policy CORSPolicy) func(http.Handler) http.Handler {
+	anyOrigin := false
+	origins := make(map[string]bool, len(policy.AllowedOrigins))
+	for _, origin := range policy.AllowedOrigins {
+		if origin == "*" {
+			anyOrigin = true
+			continue
 		}
+		origins[normalizeOrigin(origin)] = true
+	}
+	methods := strings.Join(policy.AllowedMethods, ", ")
+	headers := strings.Join(policy.AllowedHeaders, ", ")
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if !anyOrigin {
+				w.Header().Add("Vary", "Origin")
+			}
+
+			// Listed origins are echoed so credentials can be allowed for
+			// them; a wildcard is only ever answered with "*"
+			allowed := origin != "" && (anyOrigin || origins[normalizeOrigin(origin)])
+			if allowed {
+				if origins[normalizeOrigin(origin)] {
+					w.Header().Set("Access-Control-Allow-Origin", origin)
+					if policy.AllowCredentials {
+						w.Header().Set("Access-Control-Allow-Credentials", "true")
+					}
+				} else {
+					w.Header().Set("Access-Control-Allow-Origin", "*")
+				}
+			}
+
+			if !preflight {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if allowed {
+				w.Header().Set("Access-Control-Allow-Methods", methods)
+				w.Header().Set("Access-Control-Allow-Headers", headers)
+				if policy.MaxAgeSeconds > 0 {
+					w.Header().Set("Access-Control-Max-Age", strconv.Itoa(policy.MaxAgeSeconds))
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
 
-		next.ServeHTTP(w, r)
-	})
+// normalizeOrigin makes configured and requested origins comparable
+func normalizeOrigin(origin string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(origin), "/"))
 }
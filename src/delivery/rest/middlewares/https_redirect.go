@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"net"
+	"net/http"
+)
+
+// HTTPSRedirect answers plain HTTP requests with a permanent redirect to the
+// same URL on HTTPS. httpsPort is left out of the redirect when it is 443.
+func HTTPSRedirect(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "" && httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		target := "https://" + host + r.URL.RequestURI()
+		http.Redirect(w, r, target, http.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,117 @@
+package middlewares
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/utils/concurrency"
+
+	"github.com/gorilla/mux"
+)
+
+// Routes shed first: browsing that clients poll or simply retry. The route
+// tables are keyed by versioned templates, which unversioned /api paths
+// are matched to by routeKey.
+var lowPriorityRoutes = map[string]bool{
+	"GET /api/v1/events":                          true,
+	"GET /api/v1/events/{id}":                     true,
+	"GET /api/v1/events/{id}.ics":                 true,
+	"GET /api/v1/events/{id}/tickets":             true,
+	"GET /api/v1/events/{id}/tickets/available":   true,
+	"GET /api/v1/events/{id}/seat-suggestions":    true,
+	"GET /api/v1/events/{id}/availability":        true,
+	"GET /api/v1/events/{id}/availability/stream": true,
+	"GET /api/v1/templates":                       true,
+	"GET /api/v1/bookings/stats":                  true,
+	"GET /api/v1/events/{id}/broadcasts":          true,
+	"GET /api/v1/events/{id}/checkins":            true,
+	"GET /api/v1/bookings/{id}/tickets.pdf":       true,
+	"GET /api/v1/admin/events":                    true,
+	"GET /api/v1/admin/events/{id}/stats":         true,
+	"GET /api/v1/admin/events/{id}/report":        true,
+	"GET /api/v1/admin/reports/sales":             true,
+	"GET /api/v1/admin/metrics/bookings":          true,
+	"GET /api/v1/admin/events/{id}/bookings.csv":  true,
+	"GET /api/v1/users/{id}/bookings.csv":         true,
+	"GET /api/v1/users/{id}/calendar.ics":         true,
+	"GET /api/v1/users/{id}/export":               true,
+	"GET /api/v1/webhooks/{id}/deliveries":        true,
+	"GET /api/v1/admin/bookings/events":           true,
+	"GET /api/v1/admin/dashboard/availability":    true,
+}
+
+// Routes never shed: taking bookings and payments, getting customers into
+// the venue, and the health and status endpoints used to diagnose overload
+var criticalRoutes = map[string]bool{
+	"GET /healthz":                             true,
+	"GET /readyz":                              true,
+	"GET /api/v1/status":                       true,
+	"POST /api/v1/quotes":                      true,
+	"POST /api/v1/bookings":                    true,
+	"POST /api/v1/bookings/{id}/confirm":       true,
+	"POST /api/v1/bookings/{id}/cancel":        true,
+	"PATCH /api/v1/bookings/{id}/tickets":      true,
+	"POST /api/v1/events/{id}/waiting-room":    true,
+	"POST /api/v1/events/{id}/presale/redeem":  true,
+	"POST /api/v1/payments/webhook":            true,
+	"POST /api/v1/admin/bookings/{id}/approve": true,
+	"POST /api/v1/checkin":                     true,
+}
+
+// RoutePriority classifies a request by the route it matched
+func RoutePriority(r *http.Request) concurrency.Priority {
+	key, ok := routeKey(r)
+	if !ok {
+		return concurrency.PriorityNormal
+	}
+	switch {
+	case criticalRoutes[key]:
+		return concurrency.PriorityCritical
+	case lowPriorityRoutes[key]:
+		return concurrency.PriorityLow
+	}
+	return concurrency.PriorityNormal
+}
+
+// versionedRouteMatcher is the handler of unversioned /api paths, which
+// finds the versioned route it will serve a request from
+type versionedRouteMatcher interface {
+	MatchVersioned(r *http.Request) (*mux.Route, bool)
+}
+
+// routeKey is the method and path template of the route a request matched,
+// as the route tables here are keyed. Unversioned /api paths are keyed by
+// the versioned route they are negotiated to, as middleware runs before
+// negotiation.
+func routeKey(r *http.Request) (string, bool) {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "", false
+	}
+	if negotiator, ok := route.GetHandler().(versionedRouteMatcher); ok {
+		if versioned, ok := negotiator.MatchVersioned(r); ok {
+			route = versioned
+		}
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "", false
+	}
+	return r.Method + " " + template, true
+}
+
+// LoadShedding middleware turns requests away with a 503 while the shedder
+// reports their priority is being shed
+func LoadShedding(shedder *concurrency.LoadShedder) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !shedder.Admit(RoutePriority(r)) {
+				w.Header().Set("Retry-After", strconv.Itoa(shedder.RetryAfter()))
+				problem.Write(w, http.StatusServiceUnavailable, "The service is busy; please retry shortly")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
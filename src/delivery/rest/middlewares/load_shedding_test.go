@@ -0,0 +1,44 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ojaswiii/booking-manager/src/utils/concurrency"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRoutePriority(t *testing.T) {
+	var got concurrency.Priority
+	record := func(w http.ResponseWriter, r *http.Request) { got = RoutePriority(r) }
+	router := mux.NewRouter()
+	router.HandleFunc("/healthz", record).Methods("GET")
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+	v1.HandleFunc("/events", record).Methods("GET")
+	v1.HandleFunc("/bookings", record).Methods("GET", "POST")
+	router.PathPrefix("/api/").Handler(negotiator{v1})
+
+	tests := []struct {
+		method, path string
+		want         concurrency.Priority
+	}{
+		{http.MethodGet, "/healthz", concurrency.PriorityCritical},
+		{http.MethodPost, "/api/v1/bookings", concurrency.PriorityCritical},
+		{http.MethodGet, "/api/v1/events", concurrency.PriorityLow},
+		{http.MethodGet, "/api/v1/bookings", concurrency.PriorityNormal},
+		{http.MethodPost, "/api/bookings", concurrency.PriorityCritical},
+		{http.MethodGet, "/api/events", concurrency.PriorityLow},
+		{http.MethodGet, "/api/bookings", concurrency.PriorityNormal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			got = -1
+			router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+			if got != tt.want {
+				t.Errorf("priority = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
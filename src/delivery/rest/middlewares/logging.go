@@ -1,6 +1,8 @@
 package middlewares
 
 import (
+	"bufio"
+	"net"
 	"net/http"
 	"time"
 
@@ -26,6 +28,7 @@ func Logging(logger *utils.Logger) func(http.Handler) http.Handler {
 				"status", wrapped.statusCode,
 				"duration", duration,
 				"remote_addr", r.RemoteAddr,
+				"request_id", GetRequestID(r.Context()),
 			)
 		})
 	}
@@ -41,3 +44,25 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.statusCode = code
 	rw.ResponseWriter.WriteHeader(code)
 }
+
+func (rw *responseWriter) Write(b []byte) (int, error) {
+	if rw.statusCode == 0 {
+		rw.statusCode = http.StatusOK
+	}
+	return rw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the connection, for streams
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Hijack hands the connection over for a WebSocket, which is logged as
+// switching protocols
+func (rw *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, brw, err := http.NewResponseController(rw.ResponseWriter).Hijack()
+	if err == nil {
+		rw.statusCode = http.StatusSwitchingProtocols
+	}
+	return conn, brw, err
+}
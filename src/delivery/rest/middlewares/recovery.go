@@ -0,0 +1,46 @@
+package middlewares
+
+import (
+	"net/http"
+	"runtime/debug"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// Recovery middleware turns a panicking handler into a 500 problem response
+// and logs the stack with the request ID, instead of letting net/http drop
+// the connection. http.ErrAbortHandler is re-panicked as it is a deliberate
+// abort.
+func Recovery(logger *utils.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wrapped := &responseWriter{ResponseWriter: w}
+
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				logger.Error("Panic in HTTP handler",
+					"request_id", GetRequestID(r.Context()),
+					"method", r.Method,
+					"path", r.URL.Path,
+					"panic", rec,
+					"stack", string(debug.Stack()),
+				)
+
+				// Too late for a problem response once the handler has written
+				if wrapped.statusCode == 0 {
+					problem.Write(w, http.StatusInternalServerError, "Internal server error")
+				}
+			}()
+
+			next.ServeHTTP(wrapped, r)
+		})
+	}
+}
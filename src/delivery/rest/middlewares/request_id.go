@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/ojaswiii/booking-manager/src/utils/requestid"
+)
+
+// RequestIDHeader carries the request ID in both directions
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID middleware reuses the caller's X-Request-ID, or generates one,
+// stores it in the request context and echoes it on the response
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = uuid.New().String()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(requestid.WithID(r.Context(), id)))
+	})
+}
+
+// GetRequestID returns the request ID stored by the RequestID middleware, or
+// an empty string
+func GetRequestID(ctx context.Context) string {
+	return requestid.FromContext(ctx)
+}
@@ -0,0 +1,65 @@
+package middlewares
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+)
+
+// The liveness and readiness probes, which run before any tenant is known
+var healthPaths = map[string]bool{"/healthz": true, "/readyz": true}
+
+// The public status page, with or without an API version
+var statusPath = regexp.MustCompile(`^/api(/v[0-9]+)?/status$`)
+
+// TenantPolicy says which header names a request's tenant and which
+// tenants are accepted
+type TenantPolicy struct {
+	Header   string
+	Allowed  []string // any valid tenant ID when empty
+	Required bool     // reject requests without a tenant
+}
+
+// Tenant middleware resolves the tenant from a request header into the
+// request context. When required, requests without a known tenant are
+// rejected; the health probes and public status page are always allowed
+// through.
+func Tenant(policy TenantPolicy) func(http.Handler) http.Handler {
+	header, required := policy.Header, policy.Required
+	known := make(map[string]bool, len(policy.Allowed))
+	for _, id := range policy.Allowed {
+		known[id] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if healthPaths[r.URL.Path] || statusPath.MatchString(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			id := r.Header.Get(header)
+			if id == "" {
+				if required {
+					writeTenantError(w, "Missing "+header+" header")
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if tenant.Validate(id) != nil || (len(known) > 0 && !known[id]) {
+				writeTenantError(w, "Unknown tenant")
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(tenant.WithID(r.Context(), id)))
+		})
+	}
+}
+
+func writeTenantError(w http.ResponseWriter, message string) {
+	problem.Write(w, http.StatusBadRequest, message)
+}
@@ -0,0 +1,192 @@
+package middlewares
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+)
+
+// Routes creating bookings, which get TimeoutPolicy.Booking: a client
+// waiting on one is better told to retry than kept holding a seat request
+var bookingCreationRoutes = map[string]bool{
+	"POST /api/v1/bookings":         true,
+	"POST /api/v1/partner/bookings": true,
+}
+
+// Routes never timed out: streams, which stay open, and exports, which
+// are written as they are read
+var untimedRoutes = map[string]bool{
+	"GET /api/v1/events/{id}/availability/stream": true,
+	"GET /api/v1/bookings/updates":                true,
+	"GET /api/v1/users/{id}/bookings.csv":         true,
+	"GET /api/v1/admin/events/{id}/bookings.csv":  true,
+}
+
+// TimeoutPolicy decides how long each route's handler may run
+type TimeoutPolicy struct {
+	Default time.Duration // 0 for no limit
+	Booking time.Duration // for creating bookings; 0 for Default
+}
+
+// forRequest returns the timeout of the route r matched, 0 for none
+func (p TimeoutPolicy) forRequest(r *http.Request) time.Duration {
+	key, _ := routeKey(r)
+	switch {
+	case untimedRoutes[key]:
+		return 0
+	case bookingCreationRoutes[key] && p.Booking > 0:
+		return p.Booking
+	}
+	return p.Default
+}
+
+// Timeout middleware answers with a 504 once a handler runs past its
+// route's timeout. The handler's context is cancelled then, and what it
+// writes after is dropped, so responses are held until the handler returns
+// or flushes them. A response already flushed is cut short instead.
+func Timeout(policy TimeoutPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := policy.forRequest(r)
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+			tw := &timeoutWriter{w: w, header: make(http.Header)}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+						return
+					}
+					close(done)
+				}()
+				next.ServeHTTP(tw, r.WithContext(ctx))
+			}()
+
+			select {
+			case p := <-panicked:
+				// Raised again here for Recovery, which only sees this goroutine
+				panic(p)
+			case <-done:
+				tw.send()
+			case <-ctx.Done():
+				tw.mu.Lock()
+				tw.timedOut = true
+				sent := tw.sent
+				tw.mu.Unlock()
+				if !sent && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					problem.Write(w, http.StatusGatewayTimeout, "The request took too long to handle")
+				}
+			}
+		})
+	}
+}
+
+// timeoutWriter holds a handler's response until it returns in time, or
+// until it flushes or hijacks the connection, after which writes go
+// straight to w
+type timeoutWriter struct {
+	w        http.ResponseWriter
+	mu       sync.Mutex
+	header   http.Header
+	body     bytes.Buffer
+	code     int
+	sent     bool
+	timedOut bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.sent || tw.code != 0 {
+		return
+	}
+	tw.code = code
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if tw.sent {
+		return tw.w.Write(b)
+	}
+	if tw.code == 0 {
+		tw.code = http.StatusOK
+	}
+	return tw.body.Write(b)
+}
+
+// Flush sends what has been written so far, for streams such as
+// server-sent events. The rest of the response is written as it comes.
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return
+	}
+	tw.sendLocked()
+	http.NewResponseController(tw.w).Flush()
+}
+
+// Unwrap lets http.ResponseController reach the connection
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.w
+}
+
+// Hijack hands the connection over for a WebSocket. Nothing held is sent,
+// and no 504 is written once it has been taken.
+func (tw *timeoutWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return nil, nil, http.ErrHandlerTimeout
+	}
+	conn, brw, err := http.NewResponseController(tw.w).Hijack()
+	if err == nil {
+		tw.sent = true
+	}
+	return conn, brw, err
+}
+
+// send sends the held response, once the handler has returned
+func (tw *timeoutWriter) send() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	tw.sendLocked()
+}
+
+func (tw *timeoutWriter) sendLocked() {
+	if tw.sent {
+		return
+	}
+	tw.sent = true
+	for name, values := range tw.header {
+		tw.w.Header()[name] = values
+	}
+	if tw.code == 0 {
+		tw.code = http.StatusOK
+	}
+	tw.w.WriteHeader(tw.code)
+	tw.w.Write(tw.body.Bytes())
+	tw.body.Reset()
+}
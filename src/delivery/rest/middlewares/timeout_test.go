@@ -0,0 +1,107 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// negotiator serves unversioned /api paths from v1, as the API router does
+type negotiator struct {
+	v1 *mux.Router
+}
+
+func (n negotiator) versioned(r *http.Request) *http.Request {
+	req := r.Clone(r.Context())
+	req.URL.Path = "/api/v1" + strings.TrimPrefix(r.URL.Path, "/api")
+	return req
+}
+
+func (n negotiator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n.v1.ServeHTTP(w, n.versioned(r))
+}
+
+func (n negotiator) MatchVersioned(r *http.Request) (*mux.Route, bool) {
+	var match mux.RouteMatch
+	return match.Route, n.v1.Match(n.versioned(r), &match)
+}
+
+func TestTimeout(t *testing.T) {
+	// Each handler takes 50ms, or until its request is cancelled
+	slow := func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(50 * time.Millisecond):
+			w.Header().Set("X-Handled", "yes")
+			w.WriteHeader(http.StatusCreated)
+		case <-r.Context().Done():
+		}
+	}
+	router := mux.NewRouter()
+	router.Use(Recovery(utils.NewLogger()))
+	router.Use(Timeout(TimeoutPolicy{Default: time.Second, Booking: 10 * time.Millisecond}))
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+	v1.HandleFunc("/events", slow).Methods("GET")
+	v1.HandleFunc("/bookings", slow).Methods("POST")
+	v1.HandleFunc("/bookings/updates", slow).Methods("GET")
+	v1.HandleFunc("/panic", func(w http.ResponseWriter, r *http.Request) { panic("boom") }).Methods("GET")
+	router.PathPrefix("/api/").Handler(negotiator{v1})
+
+	tests := []struct {
+		method, path string
+		wantCode     int
+	}{
+		{http.MethodGet, "/api/v1/events", http.StatusCreated},
+		{http.MethodPost, "/api/v1/bookings", http.StatusGatewayTimeout},
+		{http.MethodGet, "/api/v1/bookings/updates", http.StatusCreated},
+		{http.MethodGet, "/api/v1/panic", http.StatusInternalServerError},
+		{http.MethodPost, "/api/bookings", http.StatusGatewayTimeout},
+		{http.MethodGet, "/api/bookings/updates", http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantCode)
+			}
+			switch tt.wantCode {
+			case http.StatusCreated:
+				if w.Header().Get("X-Handled") != "yes" {
+					t.Error("handler's headers were not sent")
+				}
+			case http.StatusGatewayTimeout:
+				if got := w.Header().Get("Content-Type"); got != problem.ContentType {
+					t.Errorf("Content-Type = %q, want %q", got, problem.ContentType)
+				}
+			}
+		})
+	}
+}
+
+func TestTimeoutSendsFlushedResponses(t *testing.T) {
+	router := mux.NewRouter()
+	router.Use(Timeout(TimeoutPolicy{Default: 10 * time.Millisecond}))
+	router.HandleFunc("/api/v1/stream", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: first\n\n"))
+		http.NewResponseController(w).Flush()
+		<-r.Context().Done()
+	}).Methods("GET")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/stream", nil))
+
+	if w.Code != http.StatusOK || !w.Flushed {
+		t.Fatalf("status = %d, flushed = %v; want a flushed 200", w.Code, w.Flushed)
+	}
+	if w.Body.String() != "data: first\n\n" {
+		t.Errorf("body = %q, want the flushed event", w.Body.String())
+	}
+}
@@ -0,0 +1,162 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// ContentType of every error response
+const ContentType = "application/problem+json"
+
+// Machine-readable error codes, one per status the API returns
+const (
+	CodeBadRequest   = "bad_request"       // the request could not be read
+	CodeValidation   = "validation_failed" // the request was read but its values are not acceptable
+	CodeUnauthorized = "unauthorized"
+	CodeForbidden    = "forbidden"
+	CodeNotFound     = "not_found"
+	CodeConflict     = "conflict"
+	CodeInternal     = "internal_error"
+	CodeUpstream     = "upstream_failed" // a provider the request depends on failed
+	CodeUnavailable  = "unavailable"
+)
+
+// Codes for errors that clients handle apart from others with their status
+const (
+	CodeEmailTaken = "email_taken" // a 409 for an email another user has
+)
+
+var codes = map[int]string{
+	http.StatusBadRequest:          CodeBadRequest,
+	http.StatusUnprocessableEntity: CodeValidation,
+	http.StatusUnauthorized:        CodeUnauthorized,
+	http.StatusForbidden:           CodeForbidden,
+	http.StatusNotFound:            CodeNotFound,
+	http.StatusConflict:            CodeConflict,
+	http.StatusInternalServerError: CodeInternal,
+	http.StatusBadGateway:          CodeUpstream,
+	http.StatusServiceUnavailable:  CodeUnavailable,
+}
+
+// Problem is an RFC 7807 problem details body. Code is a stable,
+// machine-readable name for the kind of error; Extensions are merged into
+// the body for errors that carry more, such as a URL to retry at.
+type Problem struct {
+	Type       string                 `json:"type"`
+	Title      string                 `json:"title"`
+	Status     int                    `json:"status"`
+	Detail     string                 `json:"detail,omitempty"`
+	Code       string                 `json:"code"`
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// New builds the problem for a status. The type is about:blank, so the
+// title is the status text and Code tells errors with one status apart.
+func New(status int, detail string) *Problem {
+	code, ok := codes[status]
+	if !ok {
+		code = CodeInternal
+		if status < http.StatusInternalServerError {
+			code = CodeBadRequest
+		}
+	}
+	return &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// MarshalJSON implements json.Marshaler
+func (p Problem) MarshalJSON() ([]byte, error) {
+	type problem Problem
+	body, err := json.Marshal(problem(p))
+	if err != nil || len(p.Extensions) == 0 {
+		return body, err
+	}
+	fields := make(map[string]interface{}, len(p.Extensions)+6)
+	for k, v := range p.Extensions {
+		fields[k] = v
+	}
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// Write sends the problem
+func (p *Problem) Write(w http.ResponseWriter) {
+	body, _ := json.Marshal(p)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(p.Status)
+	w.Write(body)
+}
+
+// Write sends a problem for a status
+func Write(w http.ResponseWriter, status int, detail string) {
+	New(status, detail).Write(w)
+}
+
+// Domain errors and the status each maps to. Errors wrapping one of these
+// get its status.
+var statuses = []struct {
+	err    error
+	status int
+}{
+	{domain.ErrNotFound, http.StatusNotFound},
+	{domain.ErrInvalidInput, http.StatusUnprocessableEntity},
+	{domain.ErrConflict, http.StatusConflict},
+	{domain.ErrUnauthorized, http.StatusUnauthorized},
+	{domain.ErrForbidden, http.StatusForbidden},
+}
+
+// Domain errors with a code of their own, used in place of their status's
+var errorCodes = []struct {
+	err  error
+	code string
+}{
+	{domain_user.ErrEmailTaken, CodeEmailTaken},
+}
+
+// StatusFor maps an error to its status, 500 for errors that are not domain errors
+func StatusFor(err error) int {
+	for _, s := range statuses {
+		if errors.Is(err, s.err) {
+			return s.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteError sends the problem for a usecase error. Domain errors are
+// reported with their message. Anything else is logged and reported as
+// message alone, so internals do not leak to clients.
+func WriteError(w http.ResponseWriter, logger *utils.Logger, err error, message string) {
+	status := StatusFor(err)
+	if status == http.StatusInternalServerError {
+		logger.Error(message, "error", err)
+		Write(w, status, message)
+		return
+	}
+	p := New(status, err.Error())
+	p.Code = CodeFor(err, p.Code)
+	p.Write(w)
+}
+
+// CodeFor returns the code of an error that has one of its own, otherwise
+// fallback
+func CodeFor(err error, fallback string) string {
+	for _, c := range errorCodes {
+		if errors.Is(err, c.err) {
+			return c.code
+		}
+	}
+	return fallback
+}
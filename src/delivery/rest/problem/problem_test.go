@@ -0,0 +1,108 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+func TestStatusFor(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{domain.ErrNotFound, http.StatusNotFound},
+		{fmt.Errorf("%w: party size must be positive", domain.ErrInvalidInput), http.StatusUnprocessableEntity},
+		{fmt.Errorf("failed to reserve: %w", domain.ErrConflict), http.StatusConflict},
+		{domain.ErrUnauthorized, http.StatusUnauthorized},
+		{fmt.Errorf("%w: reports are for organizers", domain.ErrForbidden), http.StatusForbidden},
+		{errors.New("connection refused"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		if got := StatusFor(tt.err); got != tt.want {
+			t.Errorf("StatusFor(%q) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+// Usecase errors carry the domain error of their status, so controllers
+// need no case of their own for them
+func TestStatusForUsecaseErrors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{usecase.ErrNotBookingOwner, http.StatusForbidden},
+		{usecase.ErrPresaleAccessDenied, http.StatusForbidden},
+		{fmt.Errorf("%w: position 3", usecase.ErrNotYourTurn), http.StatusForbidden},
+		{usecase.ErrBookingNotPending, http.StatusConflict},
+		{usecase.ErrQuoteExpired, http.StatusConflict},
+		{fmt.Errorf("%w: ticket is used", usecase.ErrCheckInRejected), http.StatusUnprocessableEntity},
+		{fmt.Errorf("%w: membership: rejected", usecase.ErrConfirmationRequirementNotMet), http.StatusUnprocessableEntity},
+		{fmt.Errorf("%w: too late", usecase.ErrRefundNotAllowed), http.StatusUnprocessableEntity},
+		{fmt.Errorf("failed to save user: %w", domain_user.ErrEmailTaken), http.StatusConflict},
+	}
+	for _, tt := range tests {
+		if got := StatusFor(tt.err); got != tt.want {
+			t.Errorf("StatusFor(%q) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	p := New(http.StatusServiceUnavailable, "busy")
+	p.Extensions = map[string]interface{}{"waiting_room": "/api/events/1/waiting-room"}
+	w := httptest.NewRecorder()
+	p.Write(w)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ContentType {
+		t.Errorf("content type = %q, want %q", got, ContentType)
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"type":         "about:blank",
+		"title":        "Service Unavailable",
+		"status":       float64(503),
+		"detail":       "busy",
+		"code":         CodeUnavailable,
+		"waiting_room": "/api/events/1/waiting-room",
+	}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("%s = %v, want %v", k, body[k], v)
+		}
+	}
+}
+
+func TestWriteErrorCodesEmailTaken(t *testing.T) {
+	w := httptest.NewRecorder()
+	WriteError(w, utils.NewLogger(), domain_user.ErrEmailTaken, "Failed to create user")
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("status = %d, want 409", w.Code)
+	}
+	var body Problem
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Code != CodeEmailTaken {
+		t.Errorf("code = %q, want %q", body.Code, CodeEmailTaken)
+	}
+	if got := CodeFor(fmt.Errorf("failed to reserve: %w", domain.ErrConflict), CodeConflict); got != CodeConflict {
+		t.Errorf("CodeFor(other conflict) = %q, want %q", got, CodeConflict)
+	}
+}
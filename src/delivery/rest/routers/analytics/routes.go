@@ -0,0 +1,21 @@
+package analytics
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterAnalyticsRoutes registers all analytics routes
+func RegisterAnalyticsRoutes(router *mux.Router, eventStatsController *controllers.EventStatsController, reportController *controllers.ReportController, bookingMetricsController *controllers.BookingMetricsController, logger *utils.Logger) {
+	// Event statistics routes (admin)
+	router.HandleFunc("/admin/events/{id}/stats", eventStatsController.GetEventStats).Methods("GET")
+
+	// Sales reports, for organizers and admins
+	router.HandleFunc("/admin/events/{id}/report", reportController.GetEventReport).Methods("GET")
+	router.HandleFunc("/admin/reports/sales", reportController.GetSalesReport).Methods("GET")
+
+	// Booking processor trends, per minute
+	router.HandleFunc("/admin/metrics/bookings", bookingMetricsController.GetBookingMetrics).Methods("GET")
+}
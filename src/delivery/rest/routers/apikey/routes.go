@@ -0,0 +1,16 @@
+package apikey
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterAPIKeyRoutes registers the admin routes for issuing and revoking
+// partner API keys
+func RegisterAPIKeyRoutes(router *mux.Router, apiKeyController *controllers.APIKeyController, logger *utils.Logger) {
+	router.HandleFunc("/admin/api-keys", apiKeyController.CreateAPIKey).Methods("POST")
+	router.HandleFunc("/admin/api-keys", apiKeyController.ListAPIKeys).Methods("GET")
+	router.HandleFunc("/admin/api-keys/{id}", apiKeyController.RevokeAPIKey).Methods("DELETE")
+}
@@ -0,0 +1,30 @@
+package auth
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterAuthRoutes registers the routes signing users in and out of
+// sessions
+func RegisterAuthRoutes(router *mux.Router, authController *controllers.AuthController, logger *utils.Logger) {
+	router.HandleFunc("/auth/login", authController.Login).Methods("POST")
+	router.HandleFunc("/auth/refresh", authController.Refresh).Methods("POST")
+	router.HandleFunc("/auth/logout", authController.Logout).Methods("POST")
+
+	// The link emailed to users to verify their email
+	router.HandleFunc("/auth/verify", authController.VerifyEmail).Methods("GET")
+
+	// Emailing a reset token, and setting a new password with it
+	router.HandleFunc("/auth/forgot", authController.ForgotPassword).Methods("POST")
+	router.HandleFunc("/auth/reset", authController.ResetPassword).Methods("POST")
+
+	// Signing in through an external provider, such as google or github
+	router.HandleFunc("/auth/oauth/{provider}/start", authController.StartOAuth).Methods("GET")
+	router.HandleFunc("/auth/oauth/{provider}/callback", authController.CompleteOAuth).Methods("GET")
+
+	// The sessions of the user the bearer access token is for
+	router.HandleFunc("/auth/sessions", authController.ListSessions).Methods("GET")
+}
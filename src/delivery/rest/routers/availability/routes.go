@@ -0,0 +1,15 @@
+package availability
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterAvailabilityRoutes registers all ticket availability routes
+func RegisterAvailabilityRoutes(router *mux.Router, availabilityController *controllers.AvailabilityController, logger *utils.Logger) {
+	// Availability summary routes
+	router.HandleFunc("/events/{id}/availability", availabilityController.GetAvailability).Methods("GET")
+	router.HandleFunc("/events/{id}/availability/stream", availabilityController.StreamAvailability).Methods("GET")
+}
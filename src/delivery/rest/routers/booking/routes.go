@@ -10,9 +10,29 @@ import (
 // RegisterBookingRoutes registers all booking-related routes
 func RegisterBookingRoutes(router *mux.Router, bookingController *controllers.BookingController, logger *utils.Logger) {
 	// Booking routes
-	router.HandleFunc("/api/bookings", bookingController.CreateBooking).Methods("POST")
-	router.HandleFunc("/api/bookings/{id}/confirm", bookingController.ConfirmBooking).Methods("POST")
-	router.HandleFunc("/api/bookings/{id}/cancel", bookingController.CancelBooking).Methods("POST")
-	router.HandleFunc("/api/users/{id}/bookings", bookingController.GetUserBookings).Methods("GET")
-	router.HandleFunc("/api/bookings/stats", bookingController.GetStats).Methods("GET")
+	router.HandleFunc("/bookings", bookingController.CreateBooking).Methods("POST")
+	router.HandleFunc("/bookings/{id}/confirm", bookingController.ConfirmBooking).Methods("POST")
+	router.HandleFunc("/bookings/{id}/cancel", bookingController.CancelBooking).Methods("POST")
+	router.HandleFunc("/bookings/{id}/tickets", bookingController.ChangeSeats).Methods("PATCH")
+	router.HandleFunc("/users/{id}/bookings", bookingController.GetUserBookings).Methods("GET")
+	router.HandleFunc("/bookings/stats", bookingController.GetStats).Methods("GET")
+
+	// Payment review routes
+	router.HandleFunc("/admin/bookings/reviews", bookingController.ListReviews).Methods("GET")
+	router.HandleFunc("/admin/bookings/{id}/approve", bookingController.ApproveReview).Methods("POST")
+	router.HandleFunc("/admin/bookings/{id}/reject", bookingController.RejectReview).Methods("POST")
+
+	// Every status change of a booking and its tickets
+	router.HandleFunc("/admin/bookings/{id}/history", bookingController.GetBookingHistory).Methods("GET")
+
+	// Event store routes
+	router.HandleFunc("/admin/bookings/events", bookingController.ListBookingEvents).Methods("GET")
+	router.HandleFunc("/admin/bookings/{id}/events", bookingController.GetBookingEvents).Methods("GET")
+	router.HandleFunc("/admin/bookings/{id}/rebuild", bookingController.RebuildBooking).Methods("POST")
+
+	// Dead-lettered booking request routes
+	router.HandleFunc("/admin/bookings/dead-letters", bookingController.ListDeadLetters).Methods("GET")
+	router.HandleFunc("/admin/bookings/dead-letters/{id}", bookingController.GetDeadLetter).Methods("GET")
+	router.HandleFunc("/admin/bookings/dead-letters/{id}", bookingController.DiscardDeadLetter).Methods("DELETE")
+	router.HandleFunc("/admin/bookings/dead-letters/{id}/requeue", bookingController.RequeueDeadLetter).Methods("POST")
 }
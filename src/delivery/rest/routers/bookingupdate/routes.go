@@ -0,0 +1,14 @@
+package bookingupdate
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterBookingUpdateRoutes registers all booking update routes
+func RegisterBookingUpdateRoutes(router *mux.Router, bookingUpdateController *controllers.BookingUpdateController, logger *utils.Logger) {
+	// Booking request update socket
+	router.HandleFunc("/bookings/updates", bookingUpdateController.WatchBookings).Methods("GET")
+}
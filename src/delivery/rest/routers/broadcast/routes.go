@@ -0,0 +1,16 @@
+package broadcast
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterBroadcastRoutes registers all event broadcast routes
+func RegisterBroadcastRoutes(router *mux.Router, broadcastController *controllers.BroadcastController, logger *utils.Logger) {
+	// Organizer broadcast routes
+	router.HandleFunc("/events/{id}/broadcast", broadcastController.CreateBroadcast).Methods("POST")
+	router.HandleFunc("/events/{id}/broadcasts", broadcastController.ListBroadcasts).Methods("GET")
+	router.HandleFunc("/events/{id}/broadcasts/{broadcast_id}", broadcastController.GetBroadcast).Methods("GET")
+}
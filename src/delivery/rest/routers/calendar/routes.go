@@ -0,0 +1,19 @@
+package calendar
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterCalendarRoutes registers all iCalendar routes. They must be
+// registered before the event routes, whose /events/{id} would otherwise
+// match /events/{id}.ics.
+func RegisterCalendarRoutes(router *mux.Router, calendarController *controllers.CalendarController, logger *utils.Logger) {
+	// A single event, to add to a calendar
+	router.HandleFunc("/events/{id}.ics", calendarController.GetEventCalendar).Methods("GET")
+
+	// A user's confirmed bookings, to subscribe to
+	router.HandleFunc("/users/{id}/calendar.ics", calendarController.GetUserCalendar).Methods("GET")
+}
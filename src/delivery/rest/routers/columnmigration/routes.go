@@ -0,0 +1,15 @@
+package columnmigration
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterColumnMigrationRoutes registers all column migration routes
+func RegisterColumnMigrationRoutes(router *mux.Router, columnMigrationController *controllers.ColumnMigrationController, logger *utils.Logger) {
+	// Admin column migration routes
+	router.HandleFunc("/admin/column-migrations", columnMigrationController.ListMigrations).Methods("GET")
+	router.HandleFunc("/admin/column-migrations/{name}/backfill", columnMigrationController.Backfill).Methods("POST")
+}
@@ -0,0 +1,21 @@
+package deletion
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterDeletionRoutes registers the admin routes for deleting, restoring
+// and purging users and events
+func RegisterDeletionRoutes(router *mux.Router, deletionController *controllers.DeletionController, logger *utils.Logger) {
+	// Soft delete: the event disappears but keeps its tickets and bookings
+	router.HandleFunc("/admin/events/{id}", deletionController.DeleteEvent).Methods("DELETE")
+	router.HandleFunc("/admin/events/{id}/restore", deletionController.RestoreEvent).Methods("POST")
+	router.HandleFunc("/admin/events/{id}/purge", deletionController.PurgeEvent).Methods("DELETE")
+
+	// Users are soft deleted by DELETE /users/{id}
+	router.HandleFunc("/admin/users/{id}/restore", deletionController.RestoreUser).Methods("POST")
+	router.HandleFunc("/admin/users/{id}/purge", deletionController.PurgeUser).Methods("DELETE")
+}
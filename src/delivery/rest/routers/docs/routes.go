@@ -0,0 +1,14 @@
+package docs
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/docs"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterDocsRoutes registers the API documentation routes
+func RegisterDocsRoutes(router *mux.Router, docsHandler *docs.Handler, logger *utils.Logger) {
+	router.HandleFunc("/docs", docsHandler.UI).Methods("GET")
+	router.HandleFunc("/docs/openapi.json", docsHandler.Spec).Methods("GET")
+}
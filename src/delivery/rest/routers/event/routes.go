@@ -10,9 +10,13 @@ import (
 // RegisterEventRoutes registers all event-related routes
 func RegisterEventRoutes(router *mux.Router, eventController *controllers.EventController, logger *utils.Logger) {
 	// Event routes
-	router.HandleFunc("/api/events", eventController.CreateEvent).Methods("POST")
-	router.HandleFunc("/api/events", eventController.GetAllEvents).Methods("GET")
-	router.HandleFunc("/api/events/{id}", eventController.GetEvent).Methods("GET")
-	router.HandleFunc("/api/events/{id}/tickets", eventController.GetEventTickets).Methods("GET")
-	router.HandleFunc("/api/events/{id}/tickets/available", eventController.GetAvailableTickets).Methods("GET")
+	router.HandleFunc("/events", eventController.CreateEvent).Methods("POST")
+	router.HandleFunc("/events", eventController.GetAllEvents).Methods("GET")
+	router.HandleFunc("/events/{id}", eventController.GetEvent).Methods("GET")
+	router.HandleFunc("/events/{id}/tickets", eventController.GetEventTickets).Methods("GET")
+	router.HandleFunc("/events/{id}/tickets/available", eventController.GetAvailableTickets).Methods("GET")
+
+	// Admin event routes
+	router.HandleFunc("/admin/events", eventController.GetAllEventsAdmin).Methods("GET")
+	router.HandleFunc("/admin/events/{id}/status", eventController.TransitionEventStatus).Methods("PUT")
 }
@@ -0,0 +1,17 @@
+package export
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterExportRoutes registers all CSV export routes
+func RegisterExportRoutes(router *mux.Router, bookingExportController *controllers.BookingExportController, logger *utils.Logger) {
+	// Attendee lists, for organizers and admins
+	router.HandleFunc("/admin/events/{id}/bookings.csv", bookingExportController.ExportEventAttendees).Methods("GET")
+
+	// A user's own bookings
+	router.HandleFunc("/users/{id}/bookings.csv", bookingExportController.ExportUserBookings).Methods("GET")
+}
@@ -0,0 +1,15 @@
+package health
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterHealthRoutes registers the liveness and readiness probes, outside
+// the versioned API
+func RegisterHealthRoutes(router *mux.Router, healthController *controllers.HealthController, logger *utils.Logger) {
+	router.HandleFunc("/healthz", healthController.Liveness).Methods("GET")
+	router.HandleFunc("/readyz", healthController.Readiness).Methods("GET")
+}
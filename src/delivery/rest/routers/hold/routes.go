@@ -0,0 +1,16 @@
+package hold
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterHoldRoutes registers all seat hold routes
+func RegisterHoldRoutes(router *mux.Router, holdController *controllers.HoldController, logger *utils.Logger) {
+	// Organizer seat hold routes
+	router.HandleFunc("/admin/events/{id}/holds", holdController.CreateHold).Methods("POST")
+	router.HandleFunc("/admin/events/{id}/holds", holdController.ListHolds).Methods("GET")
+	router.HandleFunc("/admin/events/{id}/holds/{hold_id}/release", holdController.ReleaseHold).Methods("POST")
+}
@@ -1,15 +1,41 @@
 package routers
 
 import (
-	"encoding/json"
-	"net/http"
-	"time"
-
 	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	apidocs "github.com/ojaswiii/booking-manager/src/delivery/rest/docs"
 	"github.com/ojaswiii/booking-manager/src/delivery/rest/middlewares"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/analytics"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/apikey"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/auth"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/availability"
 	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/booking"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/bookingupdate"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/broadcast"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/calendar"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/columnmigration"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/deletion"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/docs"
 	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/event"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/export"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/health"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/hold"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/job"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/notification"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/partner"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/payment"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/presale"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/privacy"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/quote"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/readmodel"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/refund"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/seating"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/status"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/template"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/ticket"
 	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/user"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/useradmin"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/waitingroom"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/webhook"
 	"github.com/ojaswiii/booking-manager/src/utils"
 
 	"github.com/gorilla/mux"
@@ -17,55 +43,171 @@ import (
 
 // Router contains all route handlers
 type Router struct {
-	userController    *controllers.UserController
-	eventController   *controllers.EventController
-	bookingController *controllers.BookingController
-	logger            *utils.Logger
+	userController            *controllers.UserController
+	userAdminController       *controllers.UserAdminController
+	eventController           *controllers.EventController
+	bookingController         *controllers.BookingController
+	quoteController           *controllers.QuoteController
+	waitingRoomController     *controllers.WaitingRoomController
+	presaleController         *controllers.PresaleController
+	webhookController         *controllers.WebhookController
+	templateController        *controllers.TemplateController
+	notificationController    *controllers.NotificationController
+	statusController          *controllers.StatusController
+	ticketController          *controllers.TicketController
+	holdController            *controllers.HoldController
+	jobController             *controllers.JobController
+	refundController          *controllers.RefundController
+	paymentController         *controllers.PaymentController
+	columnMigrationController *controllers.ColumnMigrationController
+	seatSuggestionController  *controllers.SeatSuggestionController
+	broadcastController       *controllers.BroadcastController
+	eventStatsController      *controllers.EventStatsController
+	reportController          *controllers.ReportController
+	bookingMetricsController  *controllers.BookingMetricsController
+	availabilityController    *controllers.AvailabilityController
+	bookingUpdateController   *controllers.BookingUpdateController
+	bookingExportController   *controllers.BookingExportController
+	calendarController        *controllers.CalendarController
+	privacyController         *controllers.PrivacyController
+	deletionController        *controllers.DeletionController
+	readModelController       *controllers.ReadModelController
+	authController            *controllers.AuthController
+	apiKeyController          *controllers.APIKeyController
+	healthController          *controllers.HealthController
+	logger                    *utils.Logger
 }
 
 // NewRouter creates a new router
 func NewRouter(
 	userController *controllers.UserController,
+	userAdminController *controllers.UserAdminController,
 	eventController *controllers.EventController,
 	bookingController *controllers.BookingController,
+	quoteController *controllers.QuoteController,
+	waitingRoomController *controllers.WaitingRoomController,
+	presaleController *controllers.PresaleController,
+	webhookController *controllers.WebhookController,
+	templateController *controllers.TemplateController,
+	notificationController *controllers.NotificationController,
+	statusController *controllers.StatusController,
+	ticketController *controllers.TicketController,
+	holdController *controllers.HoldController,
+	jobController *controllers.JobController,
+	refundController *controllers.RefundController,
+	paymentController *controllers.PaymentController,
+	columnMigrationController *controllers.ColumnMigrationController,
+	seatSuggestionController *controllers.SeatSuggestionController,
+	broadcastController *controllers.BroadcastController,
+	eventStatsController *controllers.EventStatsController,
+	reportController *controllers.ReportController,
+	bookingMetricsController *controllers.BookingMetricsController,
+	availabilityController *controllers.AvailabilityController,
+	bookingUpdateController *controllers.BookingUpdateController,
+	bookingExportController *controllers.BookingExportController,
+	calendarController *controllers.CalendarController,
+	privacyController *controllers.PrivacyController,
+	deletionController *controllers.DeletionController,
+	readModelController *controllers.ReadModelController,
+	authController *controllers.AuthController,
+	apiKeyController *controllers.APIKeyController,
+	healthController *controllers.HealthController,
 	logger *utils.Logger,
 ) *Router {
 	return &Router{
-		userController:    userController,
-		eventController:   eventController,
-		bookingController: bookingController,
-		logger:            logger,
+		userController:            userController,
+		userAdminController:       userAdminController,
+		eventController:           eventController,
+		bookingController:         bookingController,
+		quoteController:           quoteController,
+		waitingRoomController:     waitingRoomController,
+		presaleController:         presaleController,
+		webhookController:         webhookController,
+		templateController:        templateController,
+		notificationController:    notificationController,
+		statusController:          statusController,
+		ticketController:          ticketController,
+		holdController:            holdController,
+		jobController:             jobController,
+		refundController:          refundController,
+		paymentController:         paymentController,
+		columnMigrationController: columnMigrationController,
+		seatSuggestionController:  seatSuggestionController,
+		broadcastController:       broadcastController,
+		eventStatsController:      eventStatsController,
+		reportController:          reportController,
+		bookingMetricsController:  bookingMetricsController,
+		availabilityController:    availabilityController,
+		bookingUpdateController:   bookingUpdateController,
+		bookingExportController:   bookingExportController,
+		calendarController:        calendarController,
+		privacyController:         privacyController,
+		deletionController:        deletionController,
+		readModelController:       readModelController,
+		authController:            authController,
+		apiKeyController:          apiKeyController,
+		healthController:          healthController,
+		logger:                    logger,
 	}
 }
 
-// SetupRoutes configures all routes
-func (r *Router) SetupRoutes() *mux.Router {
+// SetupRoutes configures all routes, with requests resolving their tenant
+// by tenancy
+func (r *Router) SetupRoutes(tenancy middlewares.TenantPolicy) *mux.Router {
 	router := mux.NewRouter()
 
-	// Add middleware
-	router.Use(middlewares.CORS)
+	// Add middleware. The tenant comes first, since access tokens are
+	// checked against the tenant's sessions and users.
+	router.Use(middlewares.RequestID)
 	router.Use(middlewares.Logging(r.logger))
+	router.Use(middlewares.Recovery(r.logger))
+	router.Use(middlewares.Tenant(tenancy))
+	router.Use(middlewares.Authentication(r.authController, r.logger))
 
-	// Health check
-	router.HandleFunc("/health", r.healthCheck).Methods("GET")
+	// Liveness and readiness probes
+	health.RegisterHealthRoutes(router, r.healthController, r.logger)
 
-	// Register domain-specific routes
-	user.RegisterUserRoutes(router, r.userController, r.logger)
-	event.RegisterEventRoutes(router, r.eventController, r.logger)
-	booking.RegisterBookingRoutes(router, r.bookingController, r.logger)
+	// Register domain-specific routes under /api/v1. A breaking change gets
+	// a new subrouter, e.g. /api/v2, and v1 stays mounted beside it.
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+	v1.Use(versionHeader("v1"))
+	user.RegisterUserRoutes(v1, r.userController, r.logger)
+	useradmin.RegisterUserAdminRoutes(v1, r.userAdminController, r.logger)
+	auth.RegisterAuthRoutes(v1, r.authController, r.logger)
+	calendar.RegisterCalendarRoutes(v1, r.calendarController, r.logger)
+	event.RegisterEventRoutes(v1, r.eventController, r.logger)
+	booking.RegisterBookingRoutes(v1, r.bookingController, r.logger)
+	quote.RegisterQuoteRoutes(v1, r.quoteController, r.logger)
+	waitingroom.RegisterWaitingRoomRoutes(v1, r.waitingRoomController, r.logger)
+	presale.RegisterPresaleRoutes(v1, r.presaleController, r.logger)
+	webhook.RegisterWebhookRoutes(v1, r.webhookController, r.logger)
+	template.RegisterTemplateRoutes(v1, r.templateController, r.logger)
+	notification.RegisterNotificationRoutes(v1, r.notificationController, r.logger)
+	status.RegisterStatusRoutes(v1, r.statusController, r.logger)
+	ticket.RegisterTicketRoutes(v1, r.ticketController, r.logger)
+	hold.RegisterHoldRoutes(v1, r.holdController, r.logger)
+	job.RegisterJobRoutes(v1, r.jobController, r.logger)
+	refund.RegisterRefundRoutes(v1, r.refundController, r.logger)
+	payment.RegisterPaymentRoutes(v1, r.paymentController, r.logger)
+	columnmigration.RegisterColumnMigrationRoutes(v1, r.columnMigrationController, r.logger)
+	seating.RegisterSeatingRoutes(v1, r.seatSuggestionController, r.logger)
+	broadcast.RegisterBroadcastRoutes(v1, r.broadcastController, r.logger)
+	analytics.RegisterAnalyticsRoutes(v1, r.eventStatsController, r.reportController, r.bookingMetricsController, r.logger)
+	availability.RegisterAvailabilityRoutes(v1, r.availabilityController, r.logger)
+	bookingupdate.RegisterBookingUpdateRoutes(v1, r.bookingUpdateController, r.logger)
+	export.RegisterExportRoutes(v1, r.bookingExportController, r.logger)
+	privacy.RegisterPrivacyRoutes(v1, r.privacyController, r.logger)
+	deletion.RegisterDeletionRoutes(v1, r.deletionController, r.logger)
+	readmodel.RegisterReadModelRoutes(v1, r.readModelController, r.logger)
+	apikey.RegisterAPIKeyRoutes(v1, r.apiKeyController, r.logger)
+	partner.RegisterPartnerRoutes(v1, r.apiKeyController, r.bookingController, r.eventController, r.logger)
 
-	return router
-}
+	// Unversioned /api paths, as used before versioning, are negotiated.
+	// Registered after the versions so it only sees what they don't match.
+	router.PathPrefix("/api/").Handler(&versionNegotiator{versions: map[string]*mux.Router{"v1": v1}})
 
-// healthCheck handles GET /health
-func (r *Router) healthCheck(w http.ResponseWriter, req *http.Request) {
-	response := map[string]interface{}{
-		"status":    "healthy",
-		"timestamp": time.Now().UTC(),
-		"service":   "github.com/ojaswiii/booking-manager/src",
-	}
+	// API docs, describing every route registered above
+	docs.RegisterDocsRoutes(router, apidocs.NewHandler(router, r.logger), r.logger)
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(response)
+	return router
 }
@@ -0,0 +1,69 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/middlewares"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+func TestAccessTokensAreCheckedWithinTheirTenant(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{AuthSigningSecret: "secret", AccessTokenTTLSeconds: 60, SessionIdleHours: 1}
+	users := usecase.NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	auth := usecase.NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, users, nil, nil, config, logger)
+
+	// The user and their session only exist in acme's tables
+	acme := tenant.WithID(context.Background(), "acme")
+	user := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", Role: domain_user.RoleCustomer, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := repos.User.Create(acme, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := users.SetPassword(acme, user.ID, "correct horse"); err != nil {
+		t.Fatalf("set password: %v", err)
+	}
+	tokens, err := auth.Login(acme, usecase.LoginRequest{Email: user.Email, Password: "correct horse"}, domain_session.Client{})
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	r := &Router{authController: controllers.NewAuthController(auth, logger), logger: logger}
+	router := r.SetupRoutes(middlewares.TenantPolicy{Header: "X-Tenant-ID", Allowed: []string{"acme", "globex"}, Required: true})
+
+	tests := []struct {
+		name       string
+		tenant     string
+		wantStatus int
+	}{
+		{"own tenant", "acme", http.StatusOK},
+		{"other tenant", "globex", http.StatusUnauthorized},
+		{"no tenant", "", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/sessions", nil)
+			req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+			if tt.tenant != "" {
+				req.Header.Set("X-Tenant-ID", tt.tenant)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,14 @@
+package job
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterJobRoutes registers all background job routes
+func RegisterJobRoutes(router *mux.Router, jobController *controllers.JobController, logger *utils.Logger) {
+	// Admin job routes
+	router.HandleFunc("/admin/jobs/{id}", jobController.GetJob).Methods("GET")
+}
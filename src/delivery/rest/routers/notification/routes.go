@@ -0,0 +1,15 @@
+package notification
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterNotificationRoutes registers all notification-related routes
+func RegisterNotificationRoutes(router *mux.Router, notificationController *controllers.NotificationController, logger *utils.Logger) {
+	// Notification preference routes
+	router.HandleFunc("/users/{id}/preferences", notificationController.GetPreferences).Methods("GET")
+	router.HandleFunc("/users/{id}/preferences", notificationController.UpdatePreferences).Methods("PUT")
+}
@@ -0,0 +1,24 @@
+package partner
+
+import (
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/middlewares"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterPartnerRoutes registers the routes partners call with an API key
+// instead of a user. Each needs a key with the route's scope.
+func RegisterPartnerRoutes(router *mux.Router, apiKeyController *controllers.APIKeyController, bookingController *controllers.BookingController, eventController *controllers.EventController, logger *utils.Logger) {
+	withKey := func(scope domain_apikey.Scope, handler http.HandlerFunc) http.Handler {
+		return middlewares.APIKey(apiKeyController, scope, logger)(handler)
+	}
+
+	router.Handle("/partner/events", withKey(domain_apikey.ScopeEventsRead, eventController.GetAllEvents)).Methods("GET")
+	router.Handle("/partner/bookings", withKey(domain_apikey.ScopeBookingsWrite, bookingController.CreateBooking)).Methods("POST")
+	router.Handle("/partner/bookings", withKey(domain_apikey.ScopeBookingsRead, bookingController.GetPartnerBookings)).Methods("GET")
+}
@@ -0,0 +1,14 @@
+package payment
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterPaymentRoutes registers all payment provider routes
+func RegisterPaymentRoutes(router *mux.Router, paymentController *controllers.PaymentController, logger *utils.Logger) {
+	// Called by the payment provider
+	router.HandleFunc("/payments/webhook", paymentController.Webhook).Methods("POST")
+}
@@ -0,0 +1,18 @@
+package presale
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterPresaleRoutes registers all presale-related routes
+func RegisterPresaleRoutes(router *mux.Router, presaleController *controllers.PresaleController, logger *utils.Logger) {
+	// Presale routes
+	router.HandleFunc("/events/{id}/presale/redeem", presaleController.RedeemCode).Methods("POST")
+
+	// Admin presale routes
+	router.HandleFunc("/admin/events/{id}/presale-codes", presaleController.GenerateCodes).Methods("POST")
+	router.HandleFunc("/admin/events/{id}/presale-codes/batches/{batch_id}", presaleController.GetBatch).Methods("GET")
+}
@@ -0,0 +1,17 @@
+package privacy
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterPrivacyRoutes registers the routes for users' data requests
+func RegisterPrivacyRoutes(router *mux.Router, privacyController *controllers.PrivacyController, logger *utils.Logger) {
+	// A copy of everything kept about a user
+	router.HandleFunc("/users/{id}/export", privacyController.ExportUserData).Methods("GET")
+
+	// Anonymizes a user, keeping their bookings
+	router.HandleFunc("/users/{id}/erase", privacyController.EraseUser).Methods("DELETE")
+}
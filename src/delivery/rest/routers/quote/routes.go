@@ -0,0 +1,14 @@
+package quote
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterQuoteRoutes registers all quote-related routes
+func RegisterQuoteRoutes(router *mux.Router, quoteController *controllers.QuoteController, logger *utils.Logger) {
+	// Quote routes
+	router.HandleFunc("/quotes", quoteController.CreateQuote).Methods("POST")
+}
@@ -0,0 +1,18 @@
+package readmodel
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterReadModelRoutes registers the admin routes reading and rebuilding
+// the read models
+func RegisterReadModelRoutes(router *mux.Router, readModelController *controllers.ReadModelController, logger *utils.Logger) {
+	// Dashboards served from the read models
+	router.HandleFunc("/admin/dashboard/availability", readModelController.ListEventAvailability).Methods("GET")
+
+	// Project the read models again from the booking event store
+	router.HandleFunc("/admin/read-models/rebuild", readModelController.Rebuild).Methods("POST")
+}
@@ -0,0 +1,23 @@
+package refund
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterRefundRoutes registers all refund routes
+func RegisterRefundRoutes(router *mux.Router, refundController *controllers.RefundController, logger *utils.Logger) {
+	// Customer refund routes
+	router.HandleFunc("/bookings/{id}/refund", refundController.RefundBooking).Methods("POST")
+	router.HandleFunc("/bookings/{id}/refunds", refundController.ListRefunds).Methods("GET")
+	router.HandleFunc("/bookings/{id}/cancellation-preview", refundController.PreviewCancellation).Methods("GET")
+	router.HandleFunc("/bookings/{id}/returns", refundController.ReturnTickets).Methods("POST")
+	router.HandleFunc("/bookings/{id}/returns", refundController.ListReturns).Methods("GET")
+
+	// Organizer refund routes
+	router.HandleFunc("/admin/bookings/{id}/refund", refundController.AdminRefundBooking).Methods("POST")
+	router.HandleFunc("/admin/events/{id}/refund-policy", refundController.GetPolicy).Methods("GET")
+	router.HandleFunc("/admin/events/{id}/refund-policy", refundController.UpdatePolicy).Methods("PUT")
+}
@@ -0,0 +1,14 @@
+package seating
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterSeatingRoutes registers all seat selection routes
+func RegisterSeatingRoutes(router *mux.Router, seatSuggestionController *controllers.SeatSuggestionController, logger *utils.Logger) {
+	// Seat suggestion routes
+	router.HandleFunc("/events/{id}/seat-suggestions", seatSuggestionController.SuggestSeats).Methods("GET")
+}
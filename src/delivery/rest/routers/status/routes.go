@@ -0,0 +1,14 @@
+package status
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterStatusRoutes registers the public status page routes
+func RegisterStatusRoutes(router *mux.Router, statusController *controllers.StatusController, logger *utils.Logger) {
+	// Public status routes; unlike /healthz and /readyz these are meant for customers
+	router.HandleFunc("/status", statusController.GetStatus).Methods("GET")
+}
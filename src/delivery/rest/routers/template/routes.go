@@ -0,0 +1,18 @@
+package template
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterTemplateRoutes registers all event template routes
+func RegisterTemplateRoutes(router *mux.Router, templateController *controllers.TemplateController, logger *utils.Logger) {
+	// Event template routes
+	router.HandleFunc("/templates", templateController.CreateTemplate).Methods("POST")
+	router.HandleFunc("/templates", templateController.ListTemplates).Methods("GET")
+	router.HandleFunc("/templates/{id}", templateController.GetTemplate).Methods("GET")
+	router.HandleFunc("/templates/{id}", templateController.DeleteTemplate).Methods("DELETE")
+	router.HandleFunc("/templates/{id}/instantiate", templateController.Instantiate).Methods("POST")
+}
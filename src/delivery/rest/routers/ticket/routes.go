@@ -0,0 +1,18 @@
+package ticket
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterTicketRoutes registers all ticket-related routes
+func RegisterTicketRoutes(router *mux.Router, ticketController *controllers.TicketController, logger *utils.Logger) {
+	// Ticket routes
+	router.HandleFunc("/bookings/{id}/tickets.pdf", ticketController.DownloadTickets).Methods("GET")
+
+	// Door check-in routes
+	router.HandleFunc("/checkin", ticketController.CheckIn).Methods("POST")
+	router.HandleFunc("/events/{id}/checkins", ticketController.GetCheckInCounts).Methods("GET")
+}
@@ -10,8 +10,11 @@ import (
 // RegisterUserRoutes registers all user-related routes
 func RegisterUserRoutes(router *mux.Router, userController *controllers.UserController, logger *utils.Logger) {
 	// User routes
-	router.HandleFunc("/api/users", userController.CreateUser).Methods("POST")
-	router.HandleFunc("/api/users/{id}", userController.GetUser).Methods("GET")
-	router.HandleFunc("/api/users/{id}", userController.UpdateUser).Methods("PUT")
-	router.HandleFunc("/api/users/{id}", userController.DeleteUser).Methods("DELETE")
+	router.HandleFunc("/users", userController.CreateUser).Methods("POST")
+	router.HandleFunc("/users/{id}", userController.GetUser).Methods("GET")
+	router.HandleFunc("/users/{id}", userController.UpdateUser).Methods("PUT")
+	router.HandleFunc("/users/{id}", userController.DeleteUser).Methods("DELETE")
+
+	// Granting roles (admin)
+	router.HandleFunc("/admin/users/{id}/role", userController.SetUserRole).Methods("PUT")
 }
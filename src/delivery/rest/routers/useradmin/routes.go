@@ -0,0 +1,22 @@
+package useradmin
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterUserAdminRoutes registers the admin routes for finding users and
+// looking after their accounts
+func RegisterUserAdminRoutes(router *mux.Router, userAdminController *controllers.UserAdminController, logger *utils.Logger) {
+	router.HandleFunc("/admin/users", userAdminController.SearchUsers).Methods("GET")
+	router.HandleFunc("/admin/users/{id}/bookings", userAdminController.GetUserBookings).Methods("GET")
+
+	// Locking ends the user's sessions and keeps them from signing in
+	router.HandleFunc("/admin/users/{id}/lock", userAdminController.LockUser).Methods("POST")
+	router.HandleFunc("/admin/users/{id}/unlock", userAdminController.UnlockUser).Methods("POST")
+
+	// Support: an access token to act as the user, recorded in the audit log
+	router.HandleFunc("/admin/users/{id}/impersonate", userAdminController.Impersonate).Methods("POST")
+}
@@ -0,0 +1,103 @@
+package routers
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/problem"
+
+	"github.com/gorilla/mux"
+)
+
+// VersionHeader selects the API version for unversioned /api paths, and
+// reports the version that served a response
+const VersionHeader = "API-Version"
+
+// Version unversioned /api paths get when the client does not ask for one.
+// It stays at v1 so clients written before versioning keep working.
+const defaultAPIVersion = "v1"
+
+var versionSegment = regexp.MustCompile(`^v[0-9]+$`)
+
+// versionNegotiator serves unversioned /api paths from the version named in
+// the API-Version header, e.g. /api/users with "API-Version: 2" is served as
+// /api/v2/users
+type versionNegotiator struct {
+	versions map[string]*mux.Router
+}
+
+func (n *versionNegotiator) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	versioned, router, err := n.negotiate(req)
+	if err != nil {
+		writeVersionError(w, err.code, err.message)
+		return
+	}
+
+	w.Header().Add("Vary", VersionHeader)
+	// Middleware on the main router has already run for this request
+	router.ServeHTTP(w, versioned)
+}
+
+// MatchVersioned returns the route req will be served by once negotiated,
+// for middleware on the main router, which runs before negotiation and
+// keys its policies by route
+func (n *versionNegotiator) MatchVersioned(req *http.Request) (*mux.Route, bool) {
+	versioned, router, err := n.negotiate(req)
+	if err != nil {
+		return nil, false
+	}
+	var match mux.RouteMatch
+	if !router.Match(versioned, &match) || match.Route == nil {
+		return nil, false
+	}
+	return match.Route, true
+}
+
+// versionError is why a request could not be given a version
+type versionError struct {
+	code    int
+	message string
+}
+
+// negotiate returns req rewritten to the versioned path it asks for, with
+// the router of that version
+func (n *versionNegotiator) negotiate(req *http.Request) (*http.Request, *mux.Router, *versionError) {
+	rest := strings.TrimPrefix(req.URL.Path, "/api")
+	if segment, _, _ := strings.Cut(strings.TrimPrefix(rest, "/"), "/"); versionSegment.MatchString(segment) {
+		// Already versioned, but no version or route of that name
+		return nil, nil, &versionError{http.StatusNotFound, "Not found"}
+	}
+
+	version := req.Header.Get(VersionHeader)
+	if version == "" {
+		version = defaultAPIVersion
+	} else if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	router, ok := n.versions[version]
+	if !ok {
+		return nil, nil, &versionError{http.StatusBadRequest, "Unsupported API version " + req.Header.Get(VersionHeader)}
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.Path = "/api/" + version + rest
+	if req.URL.RawPath != "" {
+		req.URL.RawPath = "/api/" + version + strings.TrimPrefix(req.URL.RawPath, "/api")
+	}
+	return req, router, nil
+}
+
+// versionHeader reports the version serving each response
+func versionHeader(version string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set(VersionHeader, version)
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func writeVersionError(w http.ResponseWriter, code int, message string) {
+	problem.Write(w, code, message)
+}
@@ -0,0 +1,76 @@
+package routers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+)
+
+func TestVersionNegotiation(t *testing.T) {
+	router := mux.NewRouter()
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+	v1.Use(versionHeader("v1"))
+	v1.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.URL.Path + " " + mux.Vars(r)["id"]))
+	}).Methods("GET")
+	router.PathPrefix("/api/").Handler(&versionNegotiator{versions: map[string]*mux.Router{"v1": v1}})
+
+	tests := []struct {
+		name     string
+		path     string
+		version  string
+		wantCode int
+		wantBody string
+	}{
+		{"versioned", "/api/v1/users/42", "", http.StatusOK, "/api/v1/users/42 42"},
+		{"unversioned defaults to v1", "/api/users/42", "", http.StatusOK, "/api/v1/users/42 42"},
+		{"negotiated by number", "/api/users/42", "1", http.StatusOK, "/api/v1/users/42 42"},
+		{"negotiated by name", "/api/users/42", "v1", http.StatusOK, "/api/v1/users/42 42"},
+		{"unsupported version", "/api/users/42", "3", http.StatusBadRequest, ""},
+		{"unknown versioned path", "/api/v2/users/42", "", http.StatusNotFound, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.version != "" {
+				req.Header.Set(VersionHeader, tt.version)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantBody == "" {
+				return
+			}
+			if rec.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.wantBody)
+			}
+			if got := rec.Header().Get(VersionHeader); got != "v1" {
+				t.Errorf("%s = %q, want v1", VersionHeader, got)
+			}
+		})
+	}
+}
+
+func TestVersionNegotiatorMatchesVersionedRoute(t *testing.T) {
+	router := mux.NewRouter()
+	v1 := router.PathPrefix("/api/v1").Subrouter()
+	v1.HandleFunc("/bookings", func(w http.ResponseWriter, r *http.Request) {}).Methods("POST")
+	negotiator := &versionNegotiator{versions: map[string]*mux.Router{"v1": v1}}
+
+	route, ok := negotiator.MatchVersioned(httptest.NewRequest(http.MethodPost, "/api/bookings", nil))
+	if !ok {
+		t.Fatal("unversioned path matched no route")
+	}
+	if template, _ := route.GetPathTemplate(); template != "/api/v1/bookings" {
+		t.Errorf("template = %q, want /api/v1/bookings", template)
+	}
+	if _, ok := negotiator.MatchVersioned(httptest.NewRequest(http.MethodGet, "/api/bookings", nil)); ok {
+		t.Error("matched a route of another method")
+	}
+}
@@ -0,0 +1,15 @@
+package waitingroom
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterWaitingRoomRoutes registers all waiting room routes
+func RegisterWaitingRoomRoutes(router *mux.Router, waitingRoomController *controllers.WaitingRoomController, logger *utils.Logger) {
+	// Waiting room routes
+	router.HandleFunc("/events/{id}/waiting-room", waitingRoomController.JoinWaitingRoom).Methods("POST")
+	router.HandleFunc("/events/{id}/waiting-room/{token}", waitingRoomController.GetWaitingRoomStatus).Methods("GET")
+}
@@ -0,0 +1,17 @@
+package webhook
+
+import (
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// RegisterWebhookRoutes registers all webhook-related routes
+func RegisterWebhookRoutes(router *mux.Router, webhookController *controllers.WebhookController, logger *utils.Logger) {
+	// Webhook subscription routes
+	router.HandleFunc("/webhooks", webhookController.CreateSubscription).Methods("POST")
+	router.HandleFunc("/webhooks", webhookController.ListSubscriptions).Methods("GET")
+	router.HandleFunc("/webhooks/{id}", webhookController.DeleteSubscription).Methods("DELETE")
+	router.HandleFunc("/webhooks/{id}/deliveries", webhookController.ListDeliveries).Methods("GET")
+}
@@ -0,0 +1,154 @@
+package domain_apikey
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// API key errors
+var (
+	ErrInvalidAPIKey = fmt.Errorf("%w: API key is invalid, revoked or expired", domain.ErrUnauthorized)
+	ErrScopeDenied   = fmt.Errorf("%w: API key lacks the scope for this request", domain.ErrForbidden)
+)
+
+// keyPrefix starts every key, so leaked keys are easy to spot and scan for
+const keyPrefix = "bmk_"
+
+// displayLength is how much of a key is kept in the clear to tell keys apart
+const displayLength = len(keyPrefix) + 8
+
+// Scope is what a key may be used for
+type Scope string
+
+const (
+	ScopeEventsRead    Scope = "events:read"
+	ScopeBookingsRead  Scope = "bookings:read"
+	ScopeBookingsWrite Scope = "bookings:write"
+)
+
+// Valid reports whether s is a known scope
+func (s Scope) Valid() bool {
+	switch s {
+	case ScopeEventsRead, ScopeBookingsRead, ScopeBookingsWrite:
+		return true
+	}
+	return false
+}
+
+// APIKey lets a partner, such as a reseller, call the API without signing
+// in. Its requests act as the key's user. Only the hash of the key is kept;
+// the key itself is shown once, when it is issued.
+type APIKey struct {
+	ID        uuid.UUID `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	UserID    uuid.UUID `json:"user_id" db:"user_id"` // whose bookings the key makes
+	Prefix    string    `json:"prefix" db:"prefix"`   // the start of the key, to tell keys apart
+	KeyHash   string    `json:"-" db:"key_hash"`
+	Scopes    ScopeList `json:"scopes" db:"scopes"`
+	RateLimit int       `json:"rate_limit" db:"rate_limit"` // requests per minute
+	CreatedBy uuid.UUID `json:"created_by" db:"created_by"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" db:"expires_at"` // never when nil
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// NewAPIKey issues a key and returns it with the key itself
+func NewAPIKey(name string, userID uuid.UUID, scopes []Scope, rateLimit int, expiresAt *time.Time, createdBy uuid.UUID, now time.Time) (*APIKey, string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", err
+	}
+	key := keyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+	return &APIKey{
+		ID:        uuid.New(),
+		Name:      name,
+		UserID:    userID,
+		Prefix:    key[:displayLength],
+		KeyHash:   HashKey(key),
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+		CreatedBy: createdBy,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+	}, key, nil
+}
+
+// HashKey is how keys are kept and looked up, so the store never holds one
+// that can be used
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// LooksLikeKey reports whether s has the form of an API key
+func LooksLikeKey(s string) bool {
+	return strings.HasPrefix(s, keyPrefix) && len(s) > displayLength
+}
+
+// Active reports whether the key may be used at now
+func (k *APIKey) Active(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || now.Before(*k.ExpiresAt))
+}
+
+// HasScope reports whether the key may be used for scope
+func (k *APIKey) HasScope(scope Scope) bool {
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeList is a list of scopes stored as JSON
+type ScopeList []Scope
+
+// Value implements driver.Valuer
+func (l ScopeList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]Scope(l))
+}
+
+// Scan implements sql.Scanner
+func (l *ScopeList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]Scope)(l))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]Scope)(l))
+	}
+	return fmt.Errorf("cannot scan %T into ScopeList", src)
+}
+
+type contextKey struct{}
+
+// WithKey returns a context for a request authenticated by key
+func WithKey(ctx context.Context, key *APIKey) context.Context {
+	return context.WithValue(ctx, contextKey{}, key)
+}
+
+// FromContext returns the key the request was authenticated by, or nil
+// when it came without one
+func FromContext(ctx context.Context) *APIKey {
+	key, _ := ctx.Value(contextKey{}).(*APIKey)
+	return key
+}
@@ -0,0 +1,29 @@
+package domain_audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Action is what an audit entry records
+type Action string
+
+const (
+	ActionUserErased       Action = "user_erased"       // a user's personal data was anonymized
+	ActionUserLocked       Action = "user_locked"       // an admin locked a user out
+	ActionUserUnlocked     Action = "user_unlocked"     // an admin let a locked user back in
+	ActionUserImpersonated Action = "user_impersonated" // an admin signed in as a user, for support
+)
+
+// Entry records an action taken on a subject, such as a user, that must be
+// accounted for after the fact. Entries are never changed or deleted.
+type Entry struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	Action    Action          `json:"action" db:"action"`
+	ActorID   uuid.UUID       `json:"actor_id" db:"actor_id"`     // who took the action
+	SubjectID uuid.UUID       `json:"subject_id" db:"subject_id"` // what it was taken on
+	Details   json.RawMessage `json:"details" db:"details"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
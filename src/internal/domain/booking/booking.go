@@ -2,8 +2,13 @@ package domain_booking
 
 import (
 	"context"
+	"fmt"
+	"math"
 	"time"
 
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+
 	"github.com/google/uuid"
 )
 
@@ -15,19 +20,160 @@ const (
 	BookingStatusConfirmed BookingStatus = "confirmed"
 	BookingStatusCancelled BookingStatus = "cancelled"
 	BookingStatusExpired   BookingStatus = "expired"
+	BookingStatusRefunded  BookingStatus = "refunded"
+	BookingStatusReview    BookingStatus = "review"   // paid, but flagged as high-risk and waiting for an admin
+	BookingStatusRejected  BookingStatus = "rejected" // rejected in review, or not reviewed in time
+	BookingStatusReturned  BookingStatus = "returned" // every ticket given back for resale, without a refund
 )
 
+// ErrActiveBookings is returned when purging a user or event that still has
+// bookings holding tickets. They must be cancelled first, so their tickets
+// are released and the cancellations recorded.
+var ErrActiveBookings = fmt.Errorf("%w: bookings holding tickets must be cancelled before purging", domain.ErrConflict)
+
 // Booking represents a ticket booking
 type Booking struct {
 	ID          uuid.UUID     `json:"id" db:"id"`
 	UserID      uuid.UUID     `json:"user_id" db:"user_id"`
 	EventID     uuid.UUID     `json:"event_id" db:"event_id"`
-	TicketIDs   []uuid.UUID   `json:"ticket_ids" db:"ticket_ids"`
+	TicketIDs   []uuid.UUID   `json:"ticket_ids" db:"-"` // stored in booking_tickets
 	Status      BookingStatus `json:"status" db:"status"`
 	TotalAmount float64       `json:"total_amount" db:"total_amount"`
 	CreatedAt   time.Time     `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time     `json:"updated_at" db:"updated_at"`
 	ExpiresAt   time.Time     `json:"expires_at" db:"expires_at"`
+
+	// Currency and rate the booking is charged in, fixed at reservation.
+	// ExchangeRate is nil when the customer pays in the base currency.
+	Currency     string     `json:"currency" db:"currency"`
+	ExchangeRate *float64   `json:"exchange_rate,omitempty" db:"exchange_rate"`
+	Items        []LineItem `json:"items,omitempty" db:"-"`
+
+	// Payment provider's reference for the payment, used for refunds
+	PaymentReference *string `json:"payment_reference,omitempty" db:"payment_reference"`
+	// Provider's fraud risk score for the payment, 0 (safe) to 100
+	RiskScore *int `json:"risk_score,omitempty" db:"risk_score"`
+
+	// Events recorded on this copy of the booking, appended to its stream
+	// when it is saved
+	Changes []*BookingEvent `json:"-" db:"-"`
+}
+
+// LineItem is the price of one ticket of a booking, locked at reservation so
+// later price or rate changes never alter what the customer is charged
+type LineItem struct {
+	BookingID    uuid.UUID `json:"booking_id" db:"booking_id"`
+	TicketID     uuid.UUID `json:"ticket_id" db:"ticket_id"`
+	SeatNumber   int       `json:"seat_number" db:"seat_number"`
+	BasePrice    float64   `json:"base_price" db:"base_price"`
+	UnitPrice    float64   `json:"unit_price" db:"unit_price"`
+	Currency     string    `json:"currency" db:"currency"`
+	ExchangeRate *float64  `json:"exchange_rate,omitempty" db:"exchange_rate"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+}
+
+// LockPrices records the current price of each ticket as a line item of the
+// booking, converted at the booking's exchange rate, and returns their sum
+func (b *Booking) LockPrices(tickets []*domain_ticket.Ticket) float64 {
+	b.Items = make([]LineItem, len(tickets))
+	var total float64
+	for i, ticket := range tickets {
+		unitPrice := ticket.Price
+		if b.ExchangeRate != nil {
+			unitPrice = RoundCents(ticket.Price * *b.ExchangeRate)
+		}
+		b.Items[i] = LineItem{
+			BookingID:    b.ID,
+			TicketID:     ticket.ID,
+			SeatNumber:   ticket.SeatNumber,
+			BasePrice:    ticket.Price,
+			UnitPrice:    unitPrice,
+			Currency:     b.Currency,
+			ExchangeRate: b.ExchangeRate,
+			CreatedAt:    b.CreatedAt,
+		}
+		total += unitPrice
+	}
+	return RoundCents(total)
+}
+
+// Convert converts an amount in the base currency at the booking's exchange rate
+func (b *Booking) Convert(amount float64) float64 {
+	if b.ExchangeRate == nil {
+		return amount
+	}
+	return RoundCents(amount * *b.ExchangeRate)
+}
+
+// VolumeDiscount takes Percent off the tickets of bookings of at least
+// MinTickets tickets
+type VolumeDiscount struct {
+	MinTickets int
+	Percent    float64
+}
+
+// Pricing turns the ticket prices of a booking into what it is charged
+type Pricing struct {
+	ServiceFeePercent float64
+	TaxPercent        float64
+	VolumeDiscounts   []VolumeDiscount // the largest one a booking qualifies for applies
+}
+
+// PriceBreakdown is what a booking is charged and how it adds up
+type PriceBreakdown struct {
+	Subtotal   float64 `json:"subtotal"`
+	Discount   float64 `json:"discount"`
+	ServiceFee float64 `json:"service_fee"`
+	Tax        float64 `json:"tax"`
+	Total      float64 `json:"total"`
+}
+
+// Price applies the volume discount for the number of tickets to their
+// subtotal, then the service fee, then tax on both
+func (p Pricing) Price(subtotal float64, tickets int) PriceBreakdown {
+	price := PriceBreakdown{Subtotal: RoundCents(subtotal)}
+
+	discountPercent := 0.0
+	for _, discount := range p.VolumeDiscounts {
+		if tickets >= discount.MinTickets {
+			discountPercent = math.Max(discountPercent, discount.Percent)
+		}
+	}
+	price.Discount = RoundCents(price.Subtotal * discountPercent / 100)
+
+	discounted := price.Subtotal - price.Discount
+	price.ServiceFee = RoundCents(discounted * p.ServiceFeePercent / 100)
+	price.Tax = RoundCents((discounted + price.ServiceFee) * p.TaxPercent / 100)
+	price.Total = RoundCents(discounted + price.ServiceFee + price.Tax)
+	return price
+}
+
+// RoundCents rounds an amount to two decimal places
+func RoundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// RequestUpdateKind names what became of a queued booking request
+type RequestUpdateKind string
+
+const (
+	RequestCreated   RequestUpdateKind = "created" // processed; the booking waits for payment
+	RequestFailed    RequestUpdateKind = "failed"  // no booking could be made
+	RequestConfirmed RequestUpdateKind = "confirmed"
+	RequestExpired   RequestUpdateKind = "expired" // not processed before its deadline, or not paid for in time
+	RequestCancelled RequestUpdateKind = "cancelled"
+)
+
+// RequestUpdate is the latest news of a queued booking request, pushed to
+// the user who made it. BookingID is set once the request has been
+// processed into a booking.
+type RequestUpdate struct {
+	RequestID string            `json:"request_id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	Kind      RequestUpdateKind `json:"kind"`
+	BookingID *uuid.UUID        `json:"booking_id,omitempty"`
+	Reason    string            `json:"reason,omitempty"`
+	At        time.Time         `json:"at"`
 }
 
 // BookingRepository defines the interface for booking data operations
@@ -52,23 +198,29 @@ type BookingUsecase interface {
 
 // CreateBookingRequest represents a request to create a booking
 type CreateBookingRequest struct {
-	UserID    uuid.UUID   `json:"user_id"`
-	EventID   uuid.UUID   `json:"event_id"`
-	TicketIDs []uuid.UUID `json:"ticket_ids"`
+	UserID     uuid.UUID   `json:"user_id"`
+	EventID    uuid.UUID   `json:"event_id"`
+	TicketIDs  []uuid.UUID `json:"ticket_ids"`
+	QuoteToken string      `json:"quote_token,omitempty"`
+	Currency   string      `json:"currency,omitempty"`
 }
 
 // CreateBookingResponse represents the response of creating a booking
 type CreateBookingResponse struct {
 	BookingID   uuid.UUID `json:"booking_id"`
 	TotalAmount float64   `json:"total_amount"`
+	Currency    string    `json:"currency"`
 	ExpiresAt   string    `json:"expires_at"`
 	Status      string    `json:"status"`
 }
 
 // ConfirmBookingRequest represents a request to confirm a booking
 type ConfirmBookingRequest struct {
-	BookingID uuid.UUID `json:"booking_id"`
-	UserID    uuid.UUID `json:"user_id"`
+	BookingID    uuid.UUID         `json:"booking_id"`
+	UserID       uuid.UUID         `json:"user_id"`
+	Attestations map[string]string `json:"attestations,omitempty"`
+
+	PaymentReference string `json:"payment_reference,omitempty"`
 }
 
 // CancelBookingRequest represents a request to cancel a booking
@@ -76,3 +228,27 @@ type CancelBookingRequest struct {
 	BookingID uuid.UUID `json:"booking_id"`
 	UserID    uuid.UUID `json:"user_id"`
 }
+
+// Attendee is one ticket of a booking, with who booked it and for which
+// event: a row of a bookings export
+type Attendee struct {
+	BookingID  uuid.UUID     `json:"booking_id" db:"booking_id"`
+	Status     BookingStatus `json:"status" db:"status"`
+	BookedAt   time.Time     `json:"booked_at" db:"booked_at"`
+	UserID     uuid.UUID     `json:"user_id" db:"user_id"`
+	UserName   string        `json:"user_name" db:"user_name"`
+	UserEmail  string        `json:"user_email" db:"user_email"`
+	EventID    uuid.UUID     `json:"event_id" db:"event_id"`
+	EventName  string        `json:"event_name" db:"event_name"`
+	EventDate  time.Time     `json:"event_date" db:"event_date"`
+	TicketID   uuid.UUID     `json:"ticket_id" db:"ticket_id"`
+	SeatNumber int           `json:"seat_number" db:"seat_number"`
+	UnitPrice  float64       `json:"unit_price" db:"unit_price"`
+	Currency   string        `json:"currency" db:"currency"`
+}
+
+// AttendeeFilter picks the bookings exported: one event's, or one user's
+type AttendeeFilter struct {
+	EventID *uuid.UUID
+	UserID  *uuid.UUID
+}
@@ -0,0 +1,256 @@
+package domain_booking
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventType is the kind of a booking event
+type EventType string
+
+const (
+	BookingCreated       EventType = "BookingCreated"
+	TicketsReserved      EventType = "TicketsReserved"
+	PaymentReceived      EventType = "PaymentReceived"
+	BookingConfirmed     EventType = "BookingConfirmed"
+	BookingHeldForReview EventType = "BookingHeldForReview"
+	BookingRejected      EventType = "BookingRejected"
+	BookingCancelled     EventType = "BookingCancelled"
+	BookingExpired       EventType = "BookingExpired"
+	SeatsChanged         EventType = "SeatsChanged"
+	TicketsRefunded      EventType = "TicketsRefunded"
+	BookingRefunded      EventType = "BookingRefunded"
+	TicketsReturned      EventType = "TicketsReturned"
+	BookingReturned      EventType = "BookingReturned"
+)
+
+// BookingEvent is one fact in a booking's stream. The stream is the record
+// of a booking: the bookings table holds its projection, which Replay
+// rebuilds from the stream.
+type BookingEvent struct {
+	Position   int64           `json:"position" db:"position"` // order across all bookings, set when stored
+	BookingID  uuid.UUID       `json:"booking_id" db:"booking_id"`
+	Version    int             `json:"version" db:"version"` // 1 for BookingCreated, then one more per event; set when stored
+	Type       EventType       `json:"type" db:"type"`
+	Actor      Actor           `json:"actor" db:"actor"`
+	Data       json.RawMessage `json:"data" db:"data"`
+	OccurredAt time.Time       `json:"occurred_at" db:"occurred_at"`
+}
+
+// CreatedData is the data of BookingCreated
+type CreatedData struct {
+	UserID       uuid.UUID  `json:"user_id"`
+	EventID      uuid.UUID  `json:"event_id"`
+	TotalAmount  float64    `json:"total_amount"`
+	Currency     string     `json:"currency"`
+	ExchangeRate *float64   `json:"exchange_rate,omitempty"`
+	Items        []LineItem `json:"items,omitempty"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+}
+
+// TicketsData is the data of TicketsReserved, TicketsRefunded and
+// TicketsReturned
+type TicketsData struct {
+	TicketIDs []uuid.UUID `json:"ticket_ids"`
+}
+
+// PaymentData is the data of PaymentReceived. The reference is kept if none
+// is given; the risk score is always replaced.
+type PaymentData struct {
+	PaymentReference *string `json:"payment_reference,omitempty"`
+	RiskScore        *int    `json:"risk_score,omitempty"`
+}
+
+// ReviewData is the data of BookingHeldForReview
+type ReviewData struct {
+	ExpiresAt time.Time `json:"expires_at"` // the review deadline
+}
+
+// SeatsChangedData is the data of SeatsChanged. Released[i] was moved to
+// Reserved[i].
+type SeatsChangedData struct {
+	Released    []uuid.UUID `json:"released"`
+	Reserved    []LineItem  `json:"reserved"`
+	TotalAmount float64     `json:"total_amount"`
+}
+
+// statusEvents are the events that only move the booking to a status
+var statusEvents = map[EventType]BookingStatus{
+	BookingConfirmed: BookingStatusConfirmed,
+	BookingRejected:  BookingStatusRejected,
+	BookingCancelled: BookingStatusCancelled,
+	BookingExpired:   BookingStatusExpired,
+	BookingRefunded:  BookingStatusRefunded,
+	BookingReturned:  BookingStatusReturned,
+}
+
+func newBookingEvent(bookingID uuid.UUID, typ EventType, actor Actor, at time.Time, data interface{}) (*BookingEvent, error) {
+	raw := json.RawMessage(`{}`)
+	if data != nil {
+		var err error
+		if raw, err = json.Marshal(data); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", typ, err)
+		}
+	}
+	return &BookingEvent{
+		BookingID:  bookingID,
+		Type:       typ,
+		Actor:      actor,
+		Data:       raw,
+		OccurredAt: at,
+	}, nil
+}
+
+// decode reads the event's data into v
+func (e *BookingEvent) decode(v interface{}) error {
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", e.Type, err)
+	}
+	return nil
+}
+
+// RecordCreated records a new booking as it is: BookingCreated, then its
+// tickets being reserved
+func (b *Booking) RecordCreated(actor Actor) error {
+	created, err := newBookingEvent(b.ID, BookingCreated, actor, b.CreatedAt, CreatedData{
+		UserID:       b.UserID,
+		EventID:      b.EventID,
+		TotalAmount:  b.TotalAmount,
+		Currency:     b.Currency,
+		ExchangeRate: b.ExchangeRate,
+		Items:        b.Items,
+		ExpiresAt:    b.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	reserved, err := newBookingEvent(b.ID, TicketsReserved, actor, b.CreatedAt, TicketsData{TicketIDs: b.TicketIDs})
+	if err != nil {
+		return err
+	}
+	b.Changes = append(b.Changes, created, reserved)
+	return nil
+}
+
+// Record applies an event to the booking and keeps it to be stored with the
+// booking. data is the event's data type, or nil for status events.
+func (b *Booking) Record(typ EventType, actor Actor, at time.Time, data interface{}) error {
+	event, err := newBookingEvent(b.ID, typ, actor, at, data)
+	if err != nil {
+		return err
+	}
+	if err := b.Apply(event); err != nil {
+		return err
+	}
+	b.Changes = append(b.Changes, event)
+	return nil
+}
+
+// Apply folds an event into the booking
+func (b *Booking) Apply(event *BookingEvent) error {
+	if event.BookingID != b.ID {
+		return fmt.Errorf("event for booking %s applied to booking %s", event.BookingID, b.ID)
+	}
+
+	if status, ok := statusEvents[event.Type]; ok {
+		b.Status = status
+		b.UpdatedAt = event.OccurredAt
+		return nil
+	}
+
+	switch event.Type {
+	case BookingCreated:
+		var data CreatedData
+		if err := event.decode(&data); err != nil {
+			return err
+		}
+		b.UserID = data.UserID
+		b.EventID = data.EventID
+		b.Status = BookingStatusPending
+		b.TotalAmount = data.TotalAmount
+		b.Currency = data.Currency
+		b.ExchangeRate = data.ExchangeRate
+		b.Items = data.Items
+		b.ExpiresAt = data.ExpiresAt
+		b.CreatedAt = event.OccurredAt
+	case TicketsReserved:
+		var data TicketsData
+		if err := event.decode(&data); err != nil {
+			return err
+		}
+		b.TicketIDs = append(slices.Clip(b.TicketIDs), data.TicketIDs...)
+	case TicketsRefunded, TicketsReturned:
+		var data TicketsData
+		if err := event.decode(&data); err != nil {
+			return err
+		}
+		remaining := make([]uuid.UUID, 0, len(b.TicketIDs))
+		for _, id := range b.TicketIDs {
+			if !slices.Contains(data.TicketIDs, id) {
+				remaining = append(remaining, id)
+			}
+		}
+		b.TicketIDs = remaining
+	case PaymentReceived:
+		var data PaymentData
+		if err := event.decode(&data); err != nil {
+			return err
+		}
+		if data.PaymentReference != nil {
+			b.PaymentReference = data.PaymentReference
+		}
+		b.RiskScore = data.RiskScore
+	case BookingHeldForReview:
+		var data ReviewData
+		if err := event.decode(&data); err != nil {
+			return err
+		}
+		b.Status = BookingStatusReview
+		b.ExpiresAt = data.ExpiresAt
+	case SeatsChanged:
+		var data SeatsChangedData
+		if err := event.decode(&data); err != nil {
+			return err
+		}
+		if len(data.Released) != len(data.Reserved) {
+			return fmt.Errorf("%s releases %d seats for %d", event.Type, len(data.Released), len(data.Reserved))
+		}
+		ticketIDs := slices.Clone(b.TicketIDs)
+		items := make([]LineItem, 0, len(b.Items))
+		for _, item := range b.Items {
+			if !slices.Contains(data.Released, item.TicketID) {
+				items = append(items, item)
+			}
+		}
+		for i, released := range data.Released {
+			if j := slices.Index(ticketIDs, released); j >= 0 {
+				ticketIDs[j] = data.Reserved[i].TicketID
+			}
+		}
+		b.TicketIDs = ticketIDs
+		b.Items = append(items, data.Reserved...)
+		b.TotalAmount = data.TotalAmount
+	default:
+		return fmt.Errorf("unknown booking event %q", event.Type)
+	}
+	b.UpdatedAt = event.OccurredAt
+	return nil
+}
+
+// Replay rebuilds a booking from its stream, oldest event first
+func Replay(events []*BookingEvent) (*Booking, error) {
+	if len(events) == 0 || events[0].Type != BookingCreated {
+		return nil, fmt.Errorf("a booking's stream must start with %s", BookingCreated)
+	}
+	booking := &Booking{ID: events[0].BookingID}
+	for _, event := range events {
+		if err := booking.Apply(event); err != nil {
+			return nil, err
+		}
+	}
+	return booking, nil
+}
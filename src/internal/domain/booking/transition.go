@@ -0,0 +1,87 @@
+package domain_booking
+
+import (
+	"time"
+
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+// Actor is who made a booking change
+type Actor string
+
+const (
+	ActorCustomer Actor = "customer" // the booking's owner
+	ActorAdmin    Actor = "admin"
+	ActorPayments Actor = "payments" // the payment provider, through its webhooks
+	ActorSystem   Actor = "system"   // expiry and review deadlines
+)
+
+// Transition records a booking, or one of its tickets, changing status.
+// Transitions are written in the transaction that makes the change and are
+// never changed or deleted, so they replay everything that happened to a
+// booking.
+type Transition struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	BookingID  uuid.UUID  `json:"booking_id" db:"booking_id"`
+	TicketID   *uuid.UUID `json:"ticket_id,omitempty" db:"ticket_id"` // nil for the booking itself
+	FromStatus string     `json:"from_status" db:"from_status"`       // empty when the booking was created
+	ToStatus   string     `json:"to_status" db:"to_status"`
+	Actor      Actor      `json:"actor" db:"actor"`
+	Reason     string     `json:"reason" db:"reason"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// TicketStatus is the status a booking's tickets have while the booking has
+// this status
+func (s BookingStatus) TicketStatus() domain_ticket.TicketStatus {
+	switch s {
+	case BookingStatusPending, BookingStatusReview:
+		return domain_ticket.TicketStatusReserved
+	case BookingStatusConfirmed:
+		return domain_ticket.TicketStatusSold
+	default:
+		return domain_ticket.TicketStatusAvailable
+	}
+}
+
+// Transitions records the booking moving from status from to its current
+// one, at its UpdatedAt. Its tickets are recorded too if the move changed
+// their status. from is empty for a new booking.
+func (b *Booking) Transitions(from BookingStatus, actor Actor, reason string) []*Transition {
+	transitions := []*Transition{{
+		ID:         uuid.New(),
+		BookingID:  b.ID,
+		FromStatus: string(from),
+		ToStatus:   string(b.Status),
+		Actor:      actor,
+		Reason:     reason,
+		CreatedAt:  b.UpdatedAt,
+	}}
+	ticketsFrom, ticketsTo := from.TicketStatus(), b.Status.TicketStatus()
+	if ticketsFrom == ticketsTo {
+		return transitions
+	}
+	return append(transitions, b.TicketTransitions(b.TicketIDs, ticketsFrom, ticketsTo, actor, reason)...)
+}
+
+// TicketTransitions records some of the booking's tickets moving between
+// statuses, at the booking's UpdatedAt
+func (b *Booking) TicketTransitions(ticketIDs []uuid.UUID, from, to domain_ticket.TicketStatus, actor Actor, reason string) []*Transition {
+	transitions := make([]*Transition, len(ticketIDs))
+	for i, id := range ticketIDs {
+		ticketID := id
+		transitions[i] = &Transition{
+			ID:         uuid.New(),
+			BookingID:  b.ID,
+			TicketID:   &ticketID,
+			FromStatus: string(from),
+			ToStatus:   string(to),
+			Actor:      actor,
+			Reason:     reason,
+			CreatedAt:  b.UpdatedAt,
+		}
+	}
+	return transitions
+}
@@ -0,0 +1,57 @@
+package domain_broadcast
+
+import (
+	"time"
+
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+
+	"github.com/google/uuid"
+)
+
+// DeliveryStatus represents the state of a broadcast to one attendee
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	DeliverySkipped DeliveryStatus = "skipped" // opted out, or only on channels with no provider
+	DeliveryFailed  DeliveryStatus = "failed"
+)
+
+// Broadcast is a message from an organizer to everyone with a confirmed
+// booking for an event
+type Broadcast struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	EventID     uuid.UUID  `json:"event_id" db:"event_id"`
+	Subject     string     `json:"subject" db:"subject"`
+	Message     string     `json:"message" db:"message"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+	Report      Report     `json:"report" db:"-"`
+}
+
+// Report counts a broadcast's deliveries by status
+type Report struct {
+	Recipients int `json:"recipients" db:"recipients"`
+	Pending    int `json:"pending" db:"pending"`
+	Sent       int `json:"sent" db:"sent"`
+	Skipped    int `json:"skipped" db:"skipped"`
+	Failed     int `json:"failed" db:"failed"`
+}
+
+// Delivery is a broadcast to one attendee. Channel is the channel it went
+// out on, unset unless it was sent.
+type Delivery struct {
+	BroadcastID uuid.UUID                    `json:"broadcast_id" db:"broadcast_id"`
+	UserID      uuid.UUID                    `json:"user_id" db:"user_id"`
+	Status      DeliveryStatus               `json:"status" db:"status"`
+	Channel     *domain_notification.Channel `json:"channel,omitempty" db:"channel"`
+	Error       *string                      `json:"error,omitempty" db:"error"`
+	UpdatedAt   time.Time                    `json:"updated_at" db:"updated_at"`
+}
+
+// CreateBroadcastRequest represents a message to send to an event's attendees
+type CreateBroadcastRequest struct {
+	Subject string `json:"subject"`
+	Message string `json:"message"`
+}
@@ -0,0 +1,22 @@
+package domain_deadletter
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetter is a queued booking request that failed for good, either
+// because the failure was permanent or because its retries ran out. It is
+// kept until an admin requeues or discards it.
+type DeadLetter struct {
+	ID        uuid.UUID       `json:"id" db:"id"`
+	RequestID string          `json:"request_id" db:"request_id"`
+	UserID    uuid.UUID       `json:"user_id" db:"user_id"`
+	EventID   uuid.UUID       `json:"event_id" db:"event_id"`
+	Request   json.RawMessage `json:"request" db:"request"` // the queued booking request as it last failed
+	Reason    string          `json:"reason" db:"reason"`
+	Attempts  int             `json:"attempts" db:"attempts"`
+	CreatedAt time.Time       `json:"created_at" db:"created_at"`
+}
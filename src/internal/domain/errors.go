@@ -7,6 +7,7 @@ var (
 	ErrNotFound      = errors.New("resource not found")
 	ErrInvalidInput  = errors.New("invalid input")
 	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
 	ErrConflict      = errors.New("conflict")
 	ErrInternalError = errors.New("internal error")
 )
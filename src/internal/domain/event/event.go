@@ -2,6 +2,9 @@ package domain_event
 
 import (
 	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
@@ -9,17 +12,177 @@ import (
 	"github.com/google/uuid"
 )
 
+// EventStatus represents the lifecycle state of an event
+type EventStatus string
+
+const (
+	EventStatusDraft     EventStatus = "draft"
+	EventStatusPublished EventStatus = "published"
+	EventStatusArchived  EventStatus = "archived"
+)
+
+// IsValid reports whether the status is a known lifecycle state
+func (s EventStatus) IsValid() bool {
+	switch s {
+	case EventStatusDraft, EventStatusPublished, EventStatusArchived:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether an event may move from s to next.
+// Draft events can be published or archived, published events can be
+// archived (or pulled back to draft), and archived events are final.
+func (s EventStatus) CanTransitionTo(next EventStatus) bool {
+	switch s {
+	case EventStatusDraft:
+		return next == EventStatusPublished || next == EventStatusArchived
+	case EventStatusPublished:
+		return next == EventStatusDraft || next == EventStatusArchived
+	}
+	return false
+}
+
 // Event represents a show/concert event
 type Event struct {
-	ID         uuid.UUID `json:"id" db:"id"`
-	Name       string    `json:"name" db:"name"`
-	Artist     string    `json:"artist" db:"artist"`
-	Venue      string    `json:"venue" db:"venue"`
-	Date       time.Time `json:"date" db:"date"`
-	TotalSeats int       `json:"total_seats" db:"total_seats"`
-	Price      float64   `json:"price" db:"price"`
-	CreatedAt  time.Time `json:"created_at" db:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+	ID         uuid.UUID   `json:"id" db:"id"`
+	Name       string      `json:"name" db:"name"`
+	Artist     string      `json:"artist" db:"artist"`
+	Venue      string      `json:"venue" db:"venue"`
+	Date       time.Time   `json:"date" db:"date"`
+	TotalSeats int         `json:"total_seats" db:"total_seats"`
+	Price      float64     `json:"price" db:"price"`
+	Status     EventStatus `json:"status" db:"status"`
+	CreatedAt  time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at" db:"updated_at"`
+
+	// Sales window, nil means unbounded on that side
+	SalesStartAt *time.Time `json:"sales_start_at,omitempty" db:"sales_start_at"`
+	SalesEndAt   *time.Time `json:"sales_end_at,omitempty" db:"sales_end_at"`
+
+	// Presale runs from PresaleStartAt until SalesStartAt for code holders
+	PresaleStartAt *time.Time `json:"presale_start_at,omitempty" db:"presale_start_at"`
+
+	// Opt-in virtual waiting room for high-demand on-sales
+	WaitingRoomEnabled bool `json:"waiting_room_enabled" db:"waiting_room_enabled"`
+
+	// Extra steps a booking must pass before it can be confirmed
+	ConfirmationRequirements Requirements `json:"confirmation_requirements,omitempty" db:"confirmation_requirements"`
+
+	// Set when the event is deleted. Deleted events are kept, with their
+	// tickets and bookings, until an admin restores or purges them.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+
+	// Computed at read time for clients showing "on sale in 2h"
+	SalesState      SalesState `json:"sales_state,omitempty" db:"-"`
+	OnSaleInSeconds *int64     `json:"on_sale_in_seconds,omitempty" db:"-"`
+}
+
+// Requirements is a list of confirmation gate names stored as JSON
+type Requirements []string
+
+// Value implements driver.Valuer
+func (r Requirements) Value() (driver.Value, error) {
+	if r == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(r))
+}
+
+// Scan implements sql.Scanner
+func (r *Requirements) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*r = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(r))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(r))
+	}
+	return fmt.Errorf("cannot scan %T into Requirements", src)
+}
+
+// SalesState describes where an event is relative to its sales window
+type SalesState string
+
+const (
+	SalesStateUpcoming SalesState = "upcoming"
+	SalesStatePresale  SalesState = "presale"
+	SalesStateOnSale   SalesState = "on_sale"
+	SalesStateEnded    SalesState = "ended"
+)
+
+// SalesStateAt returns the sales state of the event at the given time
+func (e *Event) SalesStateAt(now time.Time) SalesState {
+	if e.SalesStartAt != nil && now.Before(*e.SalesStartAt) {
+		if e.PresaleStartAt != nil && !now.Before(*e.PresaleStartAt) {
+			return SalesStatePresale
+		}
+		return SalesStateUpcoming
+	}
+	if e.SalesEndAt != nil && !now.Before(*e.SalesEndAt) {
+		return SalesStateEnded
+	}
+	return SalesStateOnSale
+}
+
+// WithSalesCountdown fills in the computed sales fields for the given time
+func (e *Event) WithSalesCountdown(now time.Time) *Event {
+	e.SalesState = e.SalesStateAt(now)
+	e.OnSaleInSeconds = nil
+	if e.SalesState == SalesStateUpcoming || e.SalesState == SalesStatePresale {
+		seconds := int64(e.SalesStartAt.Sub(now).Seconds())
+		e.OnSaleInSeconds = &seconds
+	}
+	return e
+}
+
+// IsVisible reports whether customers can see the event
+func (e *Event) IsVisible() bool {
+	return e.Status == EventStatusPublished || e.Status == EventStatusArchived
+}
+
+// IsBookable reports whether tickets for the event can be booked now
+func (e *Event) IsBookable() bool {
+	return e.Status == EventStatusPublished && e.SalesStateAt(time.Now()) == SalesStateOnSale
+}
+
+// IsPresaleOpen reports whether code holders can book now
+func (e *Event) IsPresaleOpen() bool {
+	return e.Status == EventStatusPublished && e.SalesStateAt(time.Now()) == SalesStatePresale
+}
+
+// NotBookableReason describes why an event cannot be booked now
+func (e *Event) NotBookableReason() string {
+	if e.Status != EventStatusPublished {
+		return "event is " + string(e.Status)
+	}
+	switch e.SalesStateAt(time.Now()) {
+	case SalesStateUpcoming:
+		return "sales open at " + e.SalesStartAt.UTC().Format(time.RFC3339)
+	case SalesStatePresale:
+		return "presale access required until " + e.SalesStartAt.UTC().Format(time.RFC3339)
+	case SalesStateEnded:
+		return "sales closed at " + e.SalesEndAt.UTC().Format(time.RFC3339)
+	}
+	return ""
+}
+
+// EventFilter narrows an event listing; zero values mean "no constraint"
+type EventFilter struct {
+	Query    string // matched against name, artist and venue
+	Artist   string // exact artist match
+	Venue    string // exact venue match
+	Statuses []EventStatus
+	DateFrom *time.Time
+	DateTo   *time.Time
+	MinPrice *float64
+	MaxPrice *float64
+	SortBy   string // "date", "name", "price" or "created_at"
+	SortDir  string // "asc" or "desc"
+	Limit    int
+	Offset   int
 }
 
 // EventRepository defines the interface for event data operations
@@ -29,6 +192,10 @@ type EventRepository interface {
 	GetAll(ctx context.Context) ([]*Event, error)
 	Update(ctx context.Context, event *Event) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, id uuid.UUID) error
+	GetSalesWindowChanges(ctx context.Context, from, to time.Time) ([]*Event, error)
+	Search(ctx context.Context, filter EventFilter) ([]*Event, error)
 }
 
 // EventCacheRepository defines the interface for event cache operations
@@ -46,8 +213,10 @@ type EventUsecase interface {
 	CreateEvent(ctx context.Context, req CreateEventRequest) (*CreateEventResponse, error)
 	GetEvent(ctx context.Context, eventID uuid.UUID) (*Event, error)
 	GetAllEvents(ctx context.Context) ([]*Event, error)
+	SearchEvents(ctx context.Context, filter EventFilter) ([]*Event, error)
 	GetEventTickets(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error)
 	GetAvailableTickets(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error)
+	TransitionEventStatus(ctx context.Context, eventID uuid.UUID, status EventStatus) (*Event, error)
 }
 
 // CreateEventRequest represents a request to create an event
@@ -58,6 +227,14 @@ type CreateEventRequest struct {
 	Date       string  `json:"date"` // ISO 8601 format
 	TotalSeats int     `json:"total_seats"`
 	Price      float64 `json:"price"`
+	Status     string  `json:"status,omitempty"` // defaults to draft
+
+	SalesStartAt   string `json:"sales_start_at,omitempty"`   // ISO 8601 format
+	SalesEndAt     string `json:"sales_end_at,omitempty"`     // ISO 8601 format
+	PresaleStartAt string `json:"presale_start_at,omitempty"` // ISO 8601 format
+
+	ConfirmationRequirements []string `json:"confirmation_requirements,omitempty"`
+	WaitingRoomEnabled       bool     `json:"waiting_room_enabled,omitempty"`
 }
 
 // CreateEventResponse represents the response of creating an event
@@ -69,4 +246,12 @@ type CreateEventResponse struct {
 	Date       string    `json:"date"`
 	TotalSeats int       `json:"total_seats"`
 	Price      float64   `json:"price"`
+	Status     string    `json:"status"`
+
+	SalesStartAt   string `json:"sales_start_at,omitempty"`
+	SalesEndAt     string `json:"sales_end_at,omitempty"`
+	PresaleStartAt string `json:"presale_start_at,omitempty"`
+
+	ConfirmationRequirements []string `json:"confirmation_requirements,omitempty"`
+	WaitingRoomEnabled       bool     `json:"waiting_room_enabled"`
 }
@@ -0,0 +1,72 @@
+package domain_hold
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind records who a block of seats is held for
+type Kind string
+
+const (
+	KindSponsor Kind = "sponsor"
+	KindArtist  Kind = "artist"
+	KindPress   Kind = "press"
+)
+
+// Valid reports whether k is a known hold kind
+func (k Kind) Valid() bool {
+	switch k {
+	case KindSponsor, KindArtist, KindPress:
+		return true
+	}
+	return false
+}
+
+// Status represents the state of a hold
+type Status string
+
+const (
+	StatusActive   Status = "active"
+	StatusReleased Status = "released"
+)
+
+// Hold is a range of seats an organizer has taken off public sale. Held
+// tickets have their own status, so they never show up as available and are
+// never mistaken for customer reservations.
+type Hold struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	EventID    uuid.UUID  `json:"event_id" db:"event_id"`
+	Kind       Kind       `json:"kind" db:"kind"`
+	Label      string     `json:"label" db:"label"`
+	FromSeat   int        `json:"from_seat" db:"from_seat"`
+	ToSeat     int        `json:"to_seat" db:"to_seat"`
+	Seats      int        `json:"seats" db:"seats"`
+	Status     Status     `json:"status" db:"status"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	ReleasedAt *time.Time `json:"released_at,omitempty" db:"released_at"`
+}
+
+// HoldRepository defines the interface for seat hold data operations
+type HoldRepository interface {
+	Create(ctx context.Context, hold *Hold) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Hold, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*Hold, error)
+	Release(ctx context.Context, id uuid.UUID, at time.Time) (int, error)
+}
+
+// CreateHoldRequest represents a request to hold a range of seats
+type CreateHoldRequest struct {
+	Kind     Kind   `json:"kind"`
+	Label    string `json:"label"` // e.g. the sponsor's name
+	FromSeat int    `json:"from_seat"`
+	ToSeat   int    `json:"to_seat"`
+}
+
+// ReleaseHoldResponse reports how many seats went back on sale
+type ReleaseHoldResponse struct {
+	Hold     *Hold `json:"hold"`
+	Released int   `json:"released"`
+}
@@ -0,0 +1,50 @@
+package domain_job
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// State represents where a job is in its lifecycle
+type State string
+
+const (
+	StateQueued    State = "queued"
+	StateRunning   State = "running"
+	StateSucceeded State = "succeeded"
+	StateFailed    State = "failed"
+)
+
+// Finished reports whether the job has reached a terminal state
+func (s State) Finished() bool {
+	return s == StateSucceeded || s == StateFailed
+}
+
+// Job is a long-running operation executed in the background. Clients poll
+// it by ID instead of holding the request open.
+type Job struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	Kind       string          `json:"kind" db:"kind"`
+	State      State           `json:"state" db:"state"`
+	Params     json.RawMessage `json:"params" db:"params"`
+	Progress   int             `json:"progress" db:"progress"` // percent complete
+	ResultURL  *string         `json:"result_url,omitempty" db:"result_url"`
+	Error      *string         `json:"error,omitempty" db:"error"`
+	Attempts   int             `json:"attempts" db:"attempts"`
+	LeaseUntil *time.Time      `json:"-" db:"lease_until"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+	StartedAt  *time.Time      `json:"started_at,omitempty" db:"started_at"`
+	FinishedAt *time.Time      `json:"finished_at,omitempty" db:"finished_at"`
+	UpdatedAt  time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// JobRepository defines the interface for job data operations
+type JobRepository interface {
+	Create(ctx context.Context, job *Job) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Job, error)
+	Update(ctx context.Context, job *Job) error
+}
@@ -0,0 +1,47 @@
+package domain_migration
+
+// Phase is how far a column migration has got. A migration moves through
+// the phases in order, one deploy at a time, so that every running instance
+// can read what any other instance writes.
+type Phase string
+
+const (
+	PhaseOld       Phase = "old"        // read and write the old column only
+	PhaseDualWrite Phase = "dual_write" // write both columns, read the old one; backfill now
+	PhaseDualRead  Phase = "dual_read"  // write both columns, read the new one
+	PhaseNew       Phase = "new"        // read and write the new column only; the old one can go
+)
+
+// IsValid reports whether the phase is known
+func (p Phase) IsValid() bool {
+	switch p {
+	case PhaseOld, PhaseDualWrite, PhaseDualRead, PhaseNew:
+		return true
+	}
+	return false
+}
+
+// WritesOld reports whether writes still fill the old column
+func (p Phase) WritesOld() bool {
+	return p != PhaseNew
+}
+
+// WritesNew reports whether writes fill the new column
+func (p Phase) WritesNew() bool {
+	return p != PhaseOld
+}
+
+// ReadsNew reports whether reads come from the new column
+func (p Phase) ReadsNew() bool {
+	return p == PhaseDualRead || p == PhaseNew
+}
+
+// ColumnMigration describes a column being replaced by one of another type
+type ColumnMigration struct {
+	Name      string `json:"name"`
+	Table     string `json:"table"`
+	OldColumn string `json:"old_column"`
+	NewColumn string `json:"new_column"`
+	Phase     Phase  `json:"phase"`
+	Pending   int64  `json:"pending"` // rows whose new column has not been filled yet
+}
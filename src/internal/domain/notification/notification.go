@@ -0,0 +1,165 @@
+package domain_notification
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Kind selects the email template sent for a notification
+type Kind string
+
+const (
+	KindBookingConfirmed Kind = "booking_confirmed"
+	KindExpiryWarning    Kind = "booking_expiry_warning"
+	KindBookingCancelled Kind = "booking_cancelled"
+	KindEventReminder    Kind = "event_reminder"
+	KindEventBroadcast   Kind = "event_broadcast" // organizer messages to attendees
+)
+
+// Kinds lists every notification kind
+var Kinds = []Kind{KindBookingConfirmed, KindExpiryWarning, KindBookingCancelled, KindEventReminder, KindEventBroadcast}
+
+// Valid reports whether k is a known kind
+func (k Kind) Valid() bool {
+	for _, kind := range Kinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Channel is a way of reaching a user
+type Channel string
+
+const (
+	ChannelEmail   Channel = "email"
+	ChannelSMS     Channel = "sms"
+	ChannelWebhook Channel = "webhook"
+)
+
+// Status represents the delivery state of a notification
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusSent    Status = "sent"
+	StatusSkipped Status = "skipped" // the booking changed or the user opted out before it was sent
+	StatusFailed  Status = "failed"
+)
+
+// Notification is an email queued for a booking. Each booking receives at
+// most one notification of each kind.
+type Notification struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	Kind          Kind       `json:"kind" db:"kind"`
+	BookingID     uuid.UUID  `json:"booking_id" db:"booking_id"`
+	UserID        uuid.UUID  `json:"user_id" db:"user_id"`
+	EventID       uuid.UUID  `json:"event_id" db:"event_id"`
+	Status        Status     `json:"status" db:"status"`
+	Attempts      int        `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     *string    `json:"last_error,omitempty" db:"last_error"`
+	SentAt        *time.Time `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// ChannelSet selects the channels used for one kind of notification
+type ChannelSet struct {
+	Email   bool `json:"email"`
+	SMS     bool `json:"sms"`
+	Webhook bool `json:"webhook"`
+}
+
+// DefaultChannels applies to kinds a user has not configured: email only
+var DefaultChannels = ChannelSet{Email: true}
+
+// Allows reports whether the channel is enabled
+func (c ChannelSet) Allows(channel Channel) bool {
+	switch channel {
+	case ChannelEmail:
+		return c.Email
+	case ChannelSMS:
+		return c.SMS
+	case ChannelWebhook:
+		return c.Webhook
+	}
+	return false
+}
+
+// ChannelPreferences maps notification kinds to their channels, stored as JSON
+type ChannelPreferences map[Kind]ChannelSet
+
+// Value implements driver.Valuer
+func (p ChannelPreferences) Value() (driver.Value, error) {
+	if p == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(map[Kind]ChannelSet(p))
+}
+
+// Scan implements sql.Scanner
+func (p *ChannelPreferences) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*p = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*map[Kind]ChannelSet)(p))
+	case string:
+		return json.Unmarshal([]byte(v), (*map[Kind]ChannelSet)(p))
+	}
+	return fmt.Errorf("cannot scan %T into ChannelPreferences", src)
+}
+
+// Preferences records how a user wants to be notified. Kinds missing from
+// Channels use DefaultChannels.
+type Preferences struct {
+	UserID    uuid.UUID          `json:"user_id" db:"user_id"`
+	Channels  ChannelPreferences `json:"channels" db:"channels"`
+	CreatedAt time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// For returns the channels for a kind, falling back to the defaults
+func (p *Preferences) For(kind Kind) ChannelSet {
+	if p != nil {
+		if channels, ok := p.Channels[kind]; ok {
+			return channels
+		}
+	}
+	return DefaultChannels
+}
+
+// Resolved returns a copy with every kind filled in, as shown to clients
+func (p *Preferences) Resolved() *Preferences {
+	resolved := *p
+	resolved.Channels = make(ChannelPreferences, len(Kinds))
+	for _, kind := range Kinds {
+		resolved.Channels[kind] = p.For(kind)
+	}
+	return &resolved
+}
+
+// UpdatePreferencesRequest replaces a user's preferences; kinds left out
+// revert to the defaults
+type UpdatePreferencesRequest struct {
+	Channels ChannelPreferences `json:"channels"`
+}
+
+// NotificationRepository defines the interface for notification data operations
+type NotificationRepository interface {
+	Enqueue(ctx context.Context, notifications ...*Notification) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Notification, error)
+	Update(ctx context.Context, notification *Notification) error
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*Preferences, error)
+	SavePreferences(ctx context.Context, preferences *Preferences) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Notification, error)
+	ForgetUser(ctx context.Context, userID uuid.UUID, at time.Time) (int, error)
+}
@@ -0,0 +1,125 @@
+package domain_outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+
+	"github.com/google/uuid"
+)
+
+// Aggregate types; the aggregate ID is used as the message key so all
+// changes to one aggregate land on the same partition in order
+const (
+	AggregateBooking = "booking"
+	AggregateEvent   = "event"
+)
+
+// Domain event types written to the outbox
+const (
+	EventBookingCreated   = "booking.created"
+	EventBookingConfirmed = "booking.confirmed"
+	EventBookingCancelled = "booking.cancelled"
+	EventBookingExpired   = "booking.expired"
+	EventBookingRefunded  = "booking.refunded"
+	EventBookingModified  = "booking.modified"
+	EventBookingReview    = "booking.review"
+	EventBookingRejected  = "booking.rejected"
+
+	EventInventoryReserved = "inventory.reserved"
+	EventInventorySold     = "inventory.sold"
+	EventInventoryReleased = "inventory.released"
+)
+
+// Message is a domain event recorded in the same transaction as the state
+// change it describes, waiting to be relayed to the message broker
+type Message struct {
+	ID            uuid.UUID       `json:"id" db:"id"`
+	AggregateType string          `json:"aggregate_type" db:"aggregate_type"`
+	AggregateID   uuid.UUID       `json:"aggregate_id" db:"aggregate_id"`
+	EventType     string          `json:"event_type" db:"event_type"`
+	Payload       json.RawMessage `json:"payload" db:"payload"`
+	Attempts      int             `json:"attempts" db:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError     *string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	PublishedAt   *time.Time      `json:"published_at,omitempty" db:"published_at"`
+}
+
+// Envelope is the JSON body published for every message
+type Envelope struct {
+	ID            uuid.UUID   `json:"id"`
+	Type          string      `json:"type"`
+	AggregateType string      `json:"aggregate_type"`
+	AggregateID   uuid.UUID   `json:"aggregate_id"`
+	CreatedAt     time.Time   `json:"created_at"`
+	Data          interface{} `json:"data"`
+}
+
+// InventoryChange is the payload of inventory events
+type InventoryChange struct {
+	EventID   uuid.UUID   `json:"event_id"`
+	BookingID uuid.UUID   `json:"booking_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids"`
+}
+
+// NewMessage wraps data in an envelope ready to be appended to the outbox
+func NewMessage(aggregateType string, aggregateID uuid.UUID, eventType string, data interface{}) (*Message, error) {
+	now := time.Now()
+	id := uuid.New()
+	payload, err := json.Marshal(Envelope{
+		ID:            id,
+		Type:          eventType,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		CreatedAt:     now.UTC(),
+		Data:          data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		ID:            id,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		EventType:     eventType,
+		Payload:       payload,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}, nil
+}
+
+// BookingMessages builds the booking event for a state change together with
+// the inventory event for the booking's tickets
+func BookingMessages(bookingEvent, inventoryEvent string, booking *domain_booking.Booking) ([]*Message, error) {
+	bookingMsg, err := NewMessage(AggregateBooking, booking.ID, bookingEvent, booking)
+	if err != nil {
+		return nil, err
+	}
+	inventoryMsg, err := InventoryMessage(inventoryEvent, booking, booking.TicketIDs)
+	if err != nil {
+		return nil, err
+	}
+	return []*Message{bookingMsg, inventoryMsg}, nil
+}
+
+// InventoryMessage builds an inventory event for some tickets of a booking
+func InventoryMessage(inventoryEvent string, booking *domain_booking.Booking, ticketIDs []uuid.UUID) (*Message, error) {
+	return NewMessage(AggregateEvent, booking.EventID, inventoryEvent, InventoryChange{
+		EventID:   booking.EventID,
+		BookingID: booking.ID,
+		TicketIDs: ticketIDs,
+	})
+}
+
+// OutboxRepository defines the interface for outbox data operations
+type OutboxRepository interface {
+	Append(ctx context.Context, messages ...*Message) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Message, error)
+	MarkPublished(ctx context.Context, ids []uuid.UUID, at time.Time) error
+	MarkFailed(ctx context.Context, message *Message) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
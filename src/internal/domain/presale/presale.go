@@ -0,0 +1,45 @@
+package domain_presale
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PresaleCode represents a single-use code unlocking early booking access for an event
+type PresaleCode struct {
+	ID         uuid.UUID  `json:"id" db:"id"`
+	EventID    uuid.UUID  `json:"event_id" db:"event_id"`
+	BatchID    uuid.UUID  `json:"batch_id" db:"batch_id"`
+	Code       string     `json:"code" db:"code"`
+	RedeemedBy *uuid.UUID `json:"redeemed_by,omitempty" db:"redeemed_by"`
+	RedeemedAt *time.Time `json:"redeemed_at,omitempty" db:"redeemed_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// PresaleCodeRepository defines the interface for presale code data operations
+type PresaleCodeRepository interface {
+	CreateBatch(ctx context.Context, codes []*PresaleCode) error
+	GetBatch(ctx context.Context, eventID, batchID uuid.UUID) ([]*PresaleCode, error)
+	Redeem(ctx context.Context, eventID uuid.UUID, code string, userID uuid.UUID) error
+	HasEntitlement(ctx context.Context, eventID, userID uuid.UUID) (bool, error)
+}
+
+// GenerateCodesRequest represents a request to generate a batch of presale codes
+type GenerateCodesRequest struct {
+	Count int `json:"count"`
+}
+
+// GenerateCodesResponse represents a generated batch of presale codes
+type GenerateCodesResponse struct {
+	EventID uuid.UUID `json:"event_id"`
+	BatchID uuid.UUID `json:"batch_id"`
+	Codes   []string  `json:"codes"`
+}
+
+// RedeemCodeRequest represents a request to redeem a presale code
+type RedeemCodeRequest struct {
+	UserID uuid.UUID `json:"user_id"`
+	Code   string    `json:"code"`
+}
@@ -0,0 +1,106 @@
+package domain_readmodel
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+// BookingProjection names the checkpoint of the projector that keeps the
+// read models from the booking event store
+const BookingProjection = "bookings"
+
+// BookingSummary is a booking as its customer's booking list shows it, with
+// the name and date of its event, kept in one row so the list is read
+// without joining bookings, their tickets, line items and events
+type BookingSummary struct {
+	BookingID uuid.UUID                    `json:"booking_id" db:"booking_id"`
+	UserID    uuid.UUID                    `json:"user_id" db:"user_id"`
+	EventID   uuid.UUID                    `json:"event_id" db:"event_id"`
+	EventName string                       `json:"event_name" db:"event_name"`
+	EventDate time.Time                    `json:"event_date" db:"event_date"`
+	Status    domain_booking.BookingStatus `json:"status" db:"status"`
+	Booking   Snapshot                     `json:"booking" db:"booking"` // with its tickets and line items
+	CreatedAt time.Time                    `json:"created_at" db:"created_at"`
+	Position  int64                        `json:"position" db:"position"` // of the last booking event projected
+}
+
+// NewBookingSummary summarizes a booking for its customer's list
+func NewBookingSummary(booking *domain_booking.Booking, eventName string, eventDate time.Time, position int64) *BookingSummary {
+	snapshot := *booking
+	snapshot.Changes = nil
+	return &BookingSummary{
+		BookingID: booking.ID,
+		UserID:    booking.UserID,
+		EventID:   booking.EventID,
+		EventName: eventName,
+		EventDate: eventDate,
+		Status:    booking.Status,
+		Booking:   Snapshot(snapshot),
+		CreatedAt: booking.CreatedAt,
+		Position:  position,
+	}
+}
+
+// ToBooking returns the booking as it was when summarized
+func (s *BookingSummary) ToBooking() *domain_booking.Booking {
+	booking := domain_booking.Booking(s.Booking)
+	return &booking
+}
+
+// Snapshot is a booking stored as JSON
+type Snapshot domain_booking.Booking
+
+// Value implements driver.Valuer
+func (s Snapshot) Value() (driver.Value, error) {
+	return json.Marshal(domain_booking.Booking(s))
+}
+
+// Scan implements sql.Scanner
+func (s *Snapshot) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, (*domain_booking.Booking)(s))
+	case string:
+		return json.Unmarshal([]byte(v), (*domain_booking.Booking)(s))
+	}
+	return fmt.Errorf("cannot scan %T into Snapshot", src)
+}
+
+// EventAvailability is an event's tickets counted by status, with the name
+// and date of the event, for dashboards that list many events at once
+type EventAvailability struct {
+	EventID   uuid.UUID `json:"event_id" db:"event_id"`
+	EventName string    `json:"event_name" db:"event_name"`
+	EventDate time.Time `json:"event_date" db:"event_date"`
+	Available int64     `json:"available" db:"available"`
+	Reserved  int64     `json:"reserved" db:"reserved"`
+	Sold      int64     `json:"sold" db:"sold"`
+	Held      int64     `json:"held" db:"held"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"` // when the tickets were last counted
+}
+
+// NewEventAvailability records an event's ticket counts
+func NewEventAvailability(eventID uuid.UUID, eventName string, eventDate time.Time, counts domain_ticket.StatusCounts, at time.Time) *EventAvailability {
+	return &EventAvailability{
+		EventID:   eventID,
+		EventName: eventName,
+		EventDate: eventDate,
+		Available: counts.Available,
+		Reserved:  counts.Reserved,
+		Sold:      counts.Sold,
+		Held:      counts.Held,
+		UpdatedAt: at,
+	}
+}
+
+// Total counts the tickets still for sale or sold
+func (a *EventAvailability) Total() int64 {
+	return a.Available + a.Reserved + a.Sold + a.Held
+}
@@ -0,0 +1,131 @@
+package domain_refund
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status represents the state of a refund with the payment provider
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// Refund returns money for a confirmed booking, for some or all of its
+// tickets. Pending and succeeded refunds count against the booking total.
+type Refund struct {
+	ID                uuid.UUID `json:"id" db:"id"`
+	BookingID         uuid.UUID `json:"booking_id" db:"booking_id"`
+	Amount            float64   `json:"amount" db:"amount"`
+	Currency          string    `json:"currency" db:"currency"`
+	Reason            string    `json:"reason,omitempty" db:"reason"`
+	Status            Status    `json:"status" db:"status"`
+	ProviderReference *string   `json:"provider_reference,omitempty" db:"provider_reference"`
+	Error             *string   `json:"error,omitempty" db:"error"`
+	RequestedBy       string    `json:"requested_by" db:"requested_by"` // "owner" or "admin"
+	Items             []Item    `json:"items" db:"-"`
+	CreatedAt         time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Item is one ticket returned by a refund and the amount refunded for it
+type Item struct {
+	RefundID uuid.UUID `json:"-" db:"refund_id"`
+	TicketID uuid.UUID `json:"ticket_id" db:"ticket_id"`
+	Amount   float64   `json:"amount" db:"amount"`
+}
+
+// Counts reports whether the refund takes money or tickets off the booking
+func (r *Refund) Counts() bool {
+	return r.Status != StatusFailed
+}
+
+// Return gives tickets of a confirmed booking back to the organizer for
+// resale. Nothing is refunded or credited for them.
+type Return struct {
+	ID        uuid.UUID   `json:"id" db:"id"`
+	BookingID uuid.UUID   `json:"booking_id" db:"booking_id"`
+	EventID   uuid.UUID   `json:"event_id" db:"event_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids" db:"-"`
+	Reason    string      `json:"reason,omitempty" db:"reason"`
+	CreatedAt time.Time   `json:"created_at" db:"created_at"`
+}
+
+// Policy is an event's refund policy. Admins may refund outside it.
+type Policy struct {
+	EventID       uuid.UUID `json:"event_id" db:"event_id"`
+	Refundable    bool      `json:"refundable" db:"refundable"`
+	DeadlineHours int       `json:"deadline_hours" db:"deadline_hours"` // refunds close this long before the event
+	Percent       float64   `json:"percent" db:"percent"`               // share of the ticket price refunded
+	AllowPartial  bool      `json:"allow_partial" db:"allow_partial"`   // whether some tickets of a booking may be refunded
+	UpdatedAt     time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Deadline returns the last moment a customer may ask for a refund
+func (p *Policy) Deadline(eventDate time.Time) time.Time {
+	return eventDate.Add(-time.Duration(p.DeadlineHours) * time.Hour)
+}
+
+// Method says how a customer gets their money back
+type Method string
+
+const (
+	MethodNone            Method = "none"             // nothing has been charged
+	MethodOriginalPayment Method = "original_payment" // refunded to the card or account that paid
+)
+
+// CancellationPreview tells a customer what cancelling a booking would cost
+// before they do it
+type CancellationPreview struct {
+	BookingID    uuid.UUID  `json:"booking_id"`
+	Allowed      bool       `json:"allowed"`
+	Reason       string     `json:"reason,omitempty"` // why cancellation is not allowed
+	Currency     string     `json:"currency"`
+	Paid         float64    `json:"paid"` // amount paid and not yet refunded
+	Fee          float64    `json:"fee"`
+	RefundAmount float64    `json:"refund_amount"`
+	RefundMethod Method     `json:"refund_method"`
+	Deadline     *time.Time `json:"deadline,omitempty"` // when self-service cancellation closes
+}
+
+// RefundRepository defines the interface for refund data operations
+type RefundRepository interface {
+	Create(ctx context.Context, refund *Refund) error
+	Update(ctx context.Context, refund *Refund) error
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*Refund, error)
+	GetPolicy(ctx context.Context, eventID uuid.UUID) (*Policy, error)
+	SavePolicy(ctx context.Context, policy *Policy) error
+	CreateReturn(ctx context.Context, ret *Return) error
+	GetReturnsByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*Return, error)
+}
+
+// RefundRequest represents a request to refund a booking. Without tickets the
+// whole remaining booking is refunded; admins may instead give an amount to
+// refund money without returning tickets.
+type RefundRequest struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids,omitempty"`
+	Amount    *float64    `json:"amount,omitempty"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// ReturnRequest represents a request to give tickets back for resale.
+// Without tickets the whole remaining booking is returned.
+type ReturnRequest struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids,omitempty"`
+	Reason    string      `json:"reason,omitempty"`
+}
+
+// UpdatePolicyRequest represents a request to set an event's refund policy
+type UpdatePolicyRequest struct {
+	Refundable    bool    `json:"refundable"`
+	DeadlineHours int     `json:"deadline_hours"`
+	Percent       float64 `json:"percent"`
+	AllowPartial  bool    `json:"allow_partial"`
+}
@@ -0,0 +1,33 @@
+package domain_session
+
+import (
+	"context"
+
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+
+	"github.com/google/uuid"
+)
+
+// Caller is who a request was signed in as, from its access token. The
+// role is as of when the token was signed; authorization checks read the
+// user's current one.
+type Caller struct {
+	UserID         uuid.UUID
+	SessionID      uuid.UUID // uuid.Nil for a token an admin was given to act as the user
+	Role           domain_user.Role
+	ImpersonatorID *uuid.UUID
+}
+
+type contextKey struct{}
+
+// WithCaller returns a context for a request authenticated as caller
+func WithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, contextKey{}, caller)
+}
+
+// CallerFromContext returns who the request was authenticated as, or nil
+// when it came without an access token
+func CallerFromContext(ctx context.Context) *Caller {
+	caller, _ := ctx.Value(contextKey{}).(*Caller)
+	return caller
+}
@@ -0,0 +1,97 @@
+package domain_session
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidRefreshToken is returned for a refresh token that is malformed,
+// revoked, expired or already used
+var ErrInvalidRefreshToken = fmt.Errorf("%w: refresh token is invalid", domain.ErrUnauthorized)
+
+// Session is one signed-in client of a user. It holds the hash of the only
+// refresh token that is still good for it; each refresh swaps the token for
+// a new one.
+type Session struct {
+	ID         uuid.UUID `json:"id"`
+	UserID     uuid.UUID `json:"user_id"`
+	TokenHash  string    `json:"-"` // of the current refresh token, kept apart from the session
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"` // when the session was signed in to or last refreshed
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Client is what a request tells about the client it came from
+type Client struct {
+	UserAgent string
+	IPAddress string
+}
+
+// NewSession starts a session for a user, expiring after idle unless it is
+// refreshed, and returns it with its first refresh token
+func NewSession(userID uuid.UUID, client Client, now time.Time, idle time.Duration) (*Session, string, error) {
+	session := &Session{
+		ID:        uuid.New(),
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	token, err := session.Rotate(client, now, idle)
+	if err != nil {
+		return nil, "", err
+	}
+	return session, token, nil
+}
+
+// Rotate replaces the session's refresh token with a new one, returned, and
+// extends the session by idle from now
+func (s *Session) Rotate(client Client, now time.Time, idle time.Duration) (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(secret)
+	s.TokenHash = hashSecret(encoded)
+	s.UserAgent = client.UserAgent
+	s.IPAddress = client.IPAddress
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(idle)
+	return s.ID.String() + "." + encoded, nil
+}
+
+// Matches reports whether secret is the session's current refresh token secret
+func (s *Session) Matches(secret string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(s.TokenHash)) == 1
+}
+
+// ParseRefreshToken splits a refresh token into the session it is for and
+// its secret
+func ParseRefreshToken(token string) (uuid.UUID, string, error) {
+	id, secret, ok := strings.Cut(token, ".")
+	if !ok || secret == "" {
+		return uuid.Nil, "", ErrInvalidRefreshToken
+	}
+	sessionID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, "", ErrInvalidRefreshToken
+	}
+	return sessionID, secret, nil
+}
+
+// hashSecret is how refresh tokens are kept, so the store never holds one
+// that can be used
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
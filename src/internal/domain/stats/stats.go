@@ -0,0 +1,186 @@
+package domain_stats
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventStats are an event's booking counters. Confirmed counts bookings
+// that were confirmed, including ones refunded or returned since; revenue is
+// what they were charged, converted back to the base currency, in cents.
+// TicketsSold counts the tickets of those bookings, and TicketsReturned the
+// ones given back for resale.
+type EventStats struct {
+	EventID      uuid.UUID `json:"event_id" db:"event_id"`
+	Created      int64     `json:"created" db:"created"`
+	Confirmed    int64     `json:"confirmed" db:"confirmed"`
+	Cancelled    int64     `json:"cancelled" db:"cancelled"`
+	RevenueCents int64     `json:"revenue_cents" db:"revenue_cents"`
+
+	TicketsSold     int64 `json:"tickets_sold" db:"tickets_sold"`
+	TicketsReturned int64 `json:"tickets_returned" db:"tickets_returned"`
+
+	ReconciledAt time.Time `json:"reconciled_at" db:"-"` // when the counters were last checked against Postgres
+}
+
+// Revenue returns the revenue in the base currency
+func (s *EventStats) Revenue() float64 {
+	return float64(s.RevenueCents) / 100
+}
+
+// ReturnRate returns the share of sold tickets that were returned, from 0 to 1
+func (s *EventStats) ReturnRate() float64 {
+	if s.TicketsSold == 0 {
+		return 0
+	}
+	return float64(s.TicketsReturned) / float64(s.TicketsSold)
+}
+
+// Delta is a change to an event's counters
+type Delta struct {
+	Created      int64
+	Confirmed    int64
+	Cancelled    int64
+	RevenueCents int64
+
+	TicketsSold     int64
+	TicketsReturned int64
+}
+
+// ReportInterval is how long each bucket of a sales report covers
+type ReportInterval string
+
+const (
+	IntervalHour  ReportInterval = "hour"
+	IntervalDay   ReportInterval = "day"
+	IntervalWeek  ReportInterval = "week"
+	IntervalMonth ReportInterval = "month"
+)
+
+// Valid reports whether i is a known interval
+func (i ReportInterval) Valid() bool {
+	switch i {
+	case IntervalHour, IntervalDay, IntervalWeek, IntervalMonth:
+		return true
+	}
+	return false
+}
+
+// Truncate returns the start of t's bucket in UTC, as Postgres's date_trunc
+// does: weeks start on Monday
+func (i ReportInterval) Truncate(t time.Time) time.Time {
+	t = t.UTC()
+	switch i {
+	case IntervalHour:
+		return t.Truncate(time.Hour)
+	case IntervalWeek:
+		day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case IntervalMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// SalesReport sums up the sales of one event, or of every event taking
+// place in a period. As with EventStats, refunded and returned bookings
+// count as sold; what was refunded is reported separately. Amounts are in
+// the base currency, in cents.
+type SalesReport struct {
+	EventID  *uuid.UUID     `json:"event_id,omitempty"`
+	From     *time.Time     `json:"from,omitempty"`
+	To       *time.Time     `json:"to,omitempty"`
+	Interval ReportInterval `json:"interval"`
+
+	Events        int64 `json:"events"`
+	TotalSeats    int64 `json:"total_seats"`
+	Bookings      int64 `json:"bookings"`
+	TicketsSold   int64 `json:"tickets_sold"`
+	RevenueCents  int64 `json:"revenue_cents"`
+	RefundedCents int64 `json:"refunded_cents"`
+
+	Buckets []SalesBucket `json:"buckets"`
+}
+
+// SalesBucket is what sold, and was refunded, in one interval. Sales are
+// counted when booked and refunds when requested.
+type SalesBucket struct {
+	Start         time.Time `json:"start" db:"bucket"`
+	Bookings      int64     `json:"bookings" db:"bookings"`
+	TicketsSold   int64     `json:"tickets_sold" db:"tickets_sold"`
+	RevenueCents  int64     `json:"revenue_cents" db:"revenue_cents"`
+	RefundedCents int64     `json:"refunded_cents" db:"refunded_cents"`
+}
+
+// FillRate returns the share of seats sold, from 0 to 1
+func (r *SalesReport) FillRate() float64 {
+	if r.TotalSeats == 0 {
+		return 0
+	}
+	return float64(r.TicketsSold) / float64(r.TotalSeats)
+}
+
+// NetRevenueCents returns the revenue less refunds
+func (r *SalesReport) NetRevenueCents() int64 {
+	return r.RevenueCents - r.RefundedCents
+}
+
+// AddBucket merges a bucket into the report's totals and buckets, which
+// stay in time order
+func (r *SalesReport) AddBucket(bucket SalesBucket) {
+	r.Bookings += bucket.Bookings
+	r.TicketsSold += bucket.TicketsSold
+	r.RevenueCents += bucket.RevenueCents
+	r.RefundedCents += bucket.RefundedCents
+
+	i := sort.Search(len(r.Buckets), func(i int) bool { return !r.Buckets[i].Start.Before(bucket.Start) })
+	if i < len(r.Buckets) && r.Buckets[i].Start.Equal(bucket.Start) {
+		b := &r.Buckets[i]
+		b.Bookings += bucket.Bookings
+		b.TicketsSold += bucket.TicketsSold
+		b.RevenueCents += bucket.RevenueCents
+		b.RefundedCents += bucket.RefundedCents
+		return
+	}
+	r.Buckets = append(r.Buckets, SalesBucket{})
+	copy(r.Buckets[i+1:], r.Buckets[i:])
+	r.Buckets[i] = bucket
+}
+
+// BookingCounts count what the booking processor did: requests taken up,
+// attempts that created a booking or failed, and the value of the bookings
+// created in the base currency, in cents. A request retried after failing
+// counts once as a request and once per failed attempt.
+type BookingCounts struct {
+	Requests     int64 `json:"requests"`
+	Successes    int64 `json:"successes"`
+	Failures     int64 `json:"failures"`
+	RevenueCents int64 `json:"revenue_cents"`
+}
+
+// Add adds other's counts to c's
+func (c *BookingCounts) Add(other BookingCounts) {
+	c.Requests += other.Requests
+	c.Successes += other.Successes
+	c.Failures += other.Failures
+	c.RevenueCents += other.RevenueCents
+}
+
+// BookingMinute is the booking processor's counts for the minute starting
+// at Minute
+type BookingMinute struct {
+	Minute time.Time `json:"minute"`
+	BookingCounts
+}
+
+// BookingMetrics are the booking processor's counts from From until To, in
+// total and for each minute with any
+type BookingMetrics struct {
+	From    time.Time       `json:"from"`
+	To      time.Time       `json:"to"`
+	Totals  BookingCounts   `json:"totals"`
+	Minutes []BookingMinute `json:"minutes"`
+}
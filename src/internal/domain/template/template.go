@@ -0,0 +1,143 @@
+package domain_template
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+
+	"github.com/google/uuid"
+)
+
+// PriceTier prices a contiguous range of seats
+type PriceTier struct {
+	Name     string  `json:"name"`
+	FromSeat int     `json:"from_seat"`
+	ToSeat   int     `json:"to_seat"`
+	Price    float64 `json:"price"`
+}
+
+// PriceTiers is the seat map of a template, stored as JSON
+type PriceTiers []PriceTier
+
+// PriceFor returns the price of a seat and whether any tier covers it
+func (t PriceTiers) PriceFor(seat int) (float64, bool) {
+	for _, tier := range t {
+		if seat >= tier.FromSeat && seat <= tier.ToSeat {
+			return tier.Price, true
+		}
+	}
+	return 0, false
+}
+
+// TiersFromSeatPrices groups runs of consecutive seats sharing a price into
+// tiers. prices[i] is the price of seat i+1.
+func TiersFromSeatPrices(prices []float64) PriceTiers {
+	var tiers PriceTiers
+	for i, price := range prices {
+		seat := i + 1
+		if n := len(tiers); n > 0 && tiers[n-1].Price == price {
+			tiers[n-1].ToSeat = seat
+			continue
+		}
+		tiers = append(tiers, PriceTier{
+			Name:     fmt.Sprintf("Tier %d", len(tiers)+1),
+			FromSeat: seat,
+			ToSeat:   seat,
+			Price:    price,
+		})
+	}
+	return tiers
+}
+
+// Value implements driver.Valuer
+func (t PriceTiers) Value() (driver.Value, error) {
+	if t == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]PriceTier(t))
+}
+
+// Scan implements sql.Scanner
+func (t *PriceTiers) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*t = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]PriceTier)(t))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]PriceTier)(t))
+	}
+	return fmt.Errorf("cannot scan %T into PriceTiers", src)
+}
+
+// EventTemplate is a reusable snapshot of an event. Sales windows are kept as
+// offsets before the event date so they move with the date of each new event.
+type EventTemplate struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	Name          string     `json:"name" db:"name"`
+	SourceEventID *uuid.UUID `json:"source_event_id,omitempty" db:"source_event_id"`
+
+	EventName  string     `json:"event_name" db:"event_name"`
+	Artist     string     `json:"artist" db:"artist"`
+	Venue      string     `json:"venue" db:"venue"`
+	TotalSeats int        `json:"total_seats" db:"total_seats"`
+	Price      float64    `json:"price" db:"price"`
+	Tiers      PriceTiers `json:"tiers" db:"tiers"`
+
+	SalesStartOffset   *int64 `json:"sales_start_offset_seconds,omitempty" db:"sales_start_offset_seconds"`
+	SalesEndOffset     *int64 `json:"sales_end_offset_seconds,omitempty" db:"sales_end_offset_seconds"`
+	PresaleStartOffset *int64 `json:"presale_start_offset_seconds,omitempty" db:"presale_start_offset_seconds"`
+
+	ConfirmationRequirements domain_event.Requirements `json:"confirmation_requirements" db:"confirmation_requirements"`
+	WaitingRoomEnabled       bool                      `json:"waiting_room_enabled" db:"waiting_room_enabled"`
+
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// OffsetBefore returns how many seconds t falls before date, or nil for no time
+func OffsetBefore(date time.Time, t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	seconds := int64(date.Sub(*t) / time.Second)
+	return &seconds
+}
+
+// TimeBefore is the inverse of OffsetBefore
+func TimeBefore(date time.Time, offset *int64) *time.Time {
+	if offset == nil {
+		return nil
+	}
+	t := date.Add(-time.Duration(*offset) * time.Second)
+	return &t
+}
+
+// TemplateRepository defines the interface for event template data operations
+type TemplateRepository interface {
+	Create(ctx context.Context, tmpl *EventTemplate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*EventTemplate, error)
+	GetAll(ctx context.Context) ([]*EventTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// CreateTemplateRequest represents a request to save an event as a template
+type CreateTemplateRequest struct {
+	EventID uuid.UUID `json:"event_id"`
+	Name    string    `json:"name"`
+}
+
+// InstantiateRequest represents a request to create an event from a template.
+// Only the date is required; empty fields fall back to the template.
+type InstantiateRequest struct {
+	Date   string `json:"date"` // ISO 8601 format
+	Venue  string `json:"venue,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Artist string `json:"artist,omitempty"`
+	Status string `json:"status,omitempty"` // defaults to draft
+}
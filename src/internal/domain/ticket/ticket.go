@@ -2,6 +2,7 @@ package domain_ticket
 
 import (
 	"context"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +16,7 @@ const (
 	TicketStatusReserved  TicketStatus = "reserved"
 	TicketStatusSold      TicketStatus = "sold"
 	TicketStatusCancelled TicketStatus = "cancelled"
+	TicketStatusHeld      TicketStatus = "held" // blocked off by a seat hold
 )
 
 // Ticket represents a single ticket for an event
@@ -28,6 +30,143 @@ type Ticket struct {
 	UpdatedAt  time.Time    `json:"updated_at" db:"updated_at"`
 }
 
+// Availability counts an event's available tickets, overall and per price
+// tier. Tiers are keyed by ticket price in cents.
+type Availability struct {
+	EventID      uuid.UUID       `json:"event_id"`
+	Available    int64           `json:"available"`
+	Tiers        map[int64]int64 `json:"tiers"`
+	ReconciledAt time.Time       `json:"reconciled_at"` // when the counts were last checked against the database
+}
+
+// PriceCents returns a ticket price in cents, the key of its price tier
+func PriceCents(price float64) int64 {
+	return int64(math.Round(price * 100))
+}
+
+// StatusCount is how many of an event's tickets are in one status at one
+// price in one section
+type StatusCount struct {
+	Status  TicketStatus `db:"status"`
+	Price   float64      `db:"price"`
+	Section int          `db:"section"`
+	Tickets int64        `db:"tickets"`
+}
+
+// StatusCounts counts tickets by where they are in the sale
+type StatusCounts struct {
+	Available int64 `json:"available"`
+	Reserved  int64 `json:"reserved"`
+	Sold      int64 `json:"sold"`
+	Held      int64 `json:"held"`
+}
+
+// Add counts n tickets in a status. Cancelled tickets are not counted.
+func (c *StatusCounts) Add(status TicketStatus, n int64) {
+	switch status {
+	case TicketStatusAvailable:
+		c.Available += n
+	case TicketStatusReserved:
+		c.Reserved += n
+	case TicketStatusSold:
+		c.Sold += n
+	case TicketStatusHeld:
+		c.Held += n
+	}
+}
+
+// TierCounts counts the tickets of one price tier
+type TierCounts struct {
+	Price float64 `json:"price"`
+	StatusCounts
+}
+
+// SectionCounts counts the tickets of one section, a row of the seat map
+type SectionCounts struct {
+	Section  int `json:"section"`
+	FromSeat int `json:"from_seat"`
+	ToSeat   int `json:"to_seat"`
+	StatusCounts
+}
+
+// AvailabilitySummary counts an event's tickets by status, overall, per
+// price tier (cheapest first) and per section (front first)
+type AvailabilitySummary struct {
+	EventID  uuid.UUID       `json:"event_id"`
+	Total    StatusCounts    `json:"total"`
+	Tiers    []TierCounts    `json:"tiers"`
+	Sections []SectionCounts `json:"sections"`
+}
+
+// AvailabilityChangeKind names how an event's seats changed
+type AvailabilityChangeKind string
+
+const (
+	AvailabilityReserved  AvailabilityChangeKind = "reserved"
+	AvailabilityReleased  AvailabilityChangeKind = "released" // back on sale
+	AvailabilitySold      AvailabilityChangeKind = "sold"
+	AvailabilityRecounted AvailabilityChangeKind = "recounted" // too much changed to list; count again
+)
+
+// AvailabilityChange is a change to an event's seats as it happens. A
+// recounted change without an event covers every event, sent when changes
+// may have been missed.
+type AvailabilityChange struct {
+	EventID     uuid.UUID              `json:"event_id"`
+	Kind        AvailabilityChangeKind `json:"kind"`
+	TicketIDs   []uuid.UUID            `json:"ticket_ids,omitempty"`
+	SeatNumbers []int                  `json:"seat_numbers,omitempty"`
+	At          time.Time              `json:"at"`
+}
+
+// Pass is the scannable proof of a sold ticket, issued when its booking is
+// confirmed. The token is signed and encodes the booking and ticket IDs.
+type Pass struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	BookingID   uuid.UUID  `json:"booking_id" db:"booking_id"`
+	TicketID    uuid.UUID  `json:"ticket_id" db:"ticket_id"`
+	Token       string     `json:"token" db:"token"`
+	IssuedAt    time.Time  `json:"issued_at" db:"issued_at"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty" db:"checked_in_at"`
+}
+
+// CheckInCounts summarizes admissions for an event
+type CheckInCounts struct {
+	EventID   uuid.UUID `json:"event_id" db:"event_id"`
+	Issued    int       `json:"issued" db:"issued"` // passes of confirmed bookings
+	CheckedIn int       `json:"checked_in" db:"checked_in"`
+	Remaining int       `json:"remaining" db:"-"`
+}
+
+// PassRepository defines the interface for ticket pass data operations
+type PassRepository interface {
+	Create(ctx context.Context, passes ...*Pass) error
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*Pass, error)
+	GetByToken(ctx context.Context, token string) (*Pass, error)
+	CheckIn(ctx context.Context, id uuid.UUID, at time.Time) error
+	CountCheckIns(ctx context.Context, eventID uuid.UUID) (*CheckInCounts, error)
+	Revoke(ctx context.Context, bookingID uuid.UUID, ticketIDs []uuid.UUID) error
+}
+
+// CheckInRequest represents a scanned ticket pass presented at the door.
+// EventID, when set, is the event the scanner admits to.
+type CheckInRequest struct {
+	Token   string     `json:"token"`
+	EventID *uuid.UUID `json:"event_id,omitempty"`
+}
+
+// CheckInResponse describes the admitted attendee
+type CheckInResponse struct {
+	BookingID     uuid.UUID `json:"booking_id"`
+	TicketID      uuid.UUID `json:"ticket_id"`
+	EventID       uuid.UUID `json:"event_id"`
+	EventName     string    `json:"event_name"`
+	SeatNumber    int       `json:"seat_number"`
+	AttendeeName  string    `json:"attendee_name"`
+	AttendeeEmail string    `json:"attendee_email"`
+	CheckedInAt   time.Time `json:"checked_in_at"`
+}
+
 // TicketRepository defines the interface for ticket data operations
 type TicketRepository interface {
 	Create(ctx context.Context, ticket *Ticket) error
@@ -39,6 +178,7 @@ type TicketRepository interface {
 	ReserveTickets(ctx context.Context, ticketIDs []uuid.UUID) error
 	ConfirmTickets(ctx context.Context, ticketIDs []uuid.UUID) error
 	ReleaseTickets(ctx context.Context, ticketIDs []uuid.UUID) error
+	RestockTickets(ctx context.Context, ticketIDs []uuid.UUID) error
 }
 
 // TicketUsecase defines the interface for ticket business logic
@@ -0,0 +1,24 @@
+package domain_user
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ErrIdentityLinked is returned when a provider's account is linked to a
+// user while it is already linked to another
+var ErrIdentityLinked = fmt.Errorf("%w: account is already linked to a user", domain.ErrConflict)
+
+// Identity links a user to an account at an external sign-in provider, so
+// signing in there signs them in here
+type Identity struct {
+	UserID    uuid.UUID `json:"user_id" db:"user_id"`
+	Provider  string    `json:"provider" db:"provider"` // such as "google" or "github"
+	Subject   string    `json:"subject" db:"subject"`   // the provider's ID for the account, which unlike the email never changes
+	Email     string    `json:"email" db:"email"`       // as the provider gave it when the identity was linked
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
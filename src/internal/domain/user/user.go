@@ -2,18 +2,126 @@ package domain_user
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
 	"github.com/google/uuid"
 )
 
+// ErrEmailTaken is returned when a user is created, or changed, with an
+// email another user already has. Deleted users keep their email until
+// they are purged.
+var ErrEmailTaken = fmt.Errorf("%w: email is already registered", domain.ErrConflict)
+
+// ErrEmailUnverified is returned when an unverified user does what
+// REQUIRE_VERIFIED_EMAIL reserves for verified ones
+var ErrEmailUnverified = fmt.Errorf("%w: email address is not verified", domain.ErrForbidden)
+
+// ErrAccountLocked is returned when a user an admin locked out signs in or
+// acts
+var ErrAccountLocked = fmt.Errorf("%w: account is locked", domain.ErrForbidden)
+
+// Role is what a user may do beyond booking tickets
+type Role string
+
+const (
+	RoleCustomer  Role = "customer"
+	RoleOrganizer Role = "organizer" // runs events: sales reports
+	RoleAdmin     Role = "admin"     // everything, including granting roles
+)
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	switch r {
+	case RoleCustomer, RoleOrganizer, RoleAdmin:
+		return true
+	}
+	return false
+}
+
 // User represents a user in the system
 type User struct {
 	ID        uuid.UUID `json:"id" db:"id"`
 	Email     string    `json:"email" db:"email"`
 	Name      string    `json:"name" db:"name"`
+	Role      Role      `json:"role" db:"role"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+
+	// Set when the user proves they own their email. Changing the email
+	// clears it.
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" db:"email_verified_at"`
+
+	// Set while an admin has locked the user out. Locked users cannot sign
+	// in, refresh their sessions or act with their role.
+	LockedAt *time.Time `json:"locked_at,omitempty" db:"locked_at"`
+
+	// Set when the user is deleted. Deleted users are kept, with their
+	// bookings, until an admin restores or purges them.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+}
+
+// HasRole reports whether the user has one of roles
+func (u *User) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if u.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// EmailVerified reports whether the user has verified their current email
+func (u *User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// Locked reports whether an admin has locked the user out
+func (u *User) Locked() bool {
+	return u.LockedAt != nil
+}
+
+// ChangeEmail sets the user's email, which must be verified again if it
+// is a new one
+func (u *User) ChangeEmail(email string) {
+	if email != u.Email {
+		u.Email = email
+		u.EmailVerifiedAt = nil
+	}
+}
+
+// ErasedName replaces the name of an erased user
+const ErasedName = "Erased user"
+
+// erasedEmailDomain is the domain of erased users' placeholder addresses.
+// .invalid is reserved, so no mail is ever delivered to one.
+const erasedEmailDomain = "@erased.invalid"
+
+// Erase replaces the user's personal data with placeholders, keeping the
+// record their bookings refer to. Roles are dropped too.
+func (u *User) Erase(at time.Time) {
+	u.Name = ErasedName
+	u.Email = "erased-" + u.ID.String() + erasedEmailDomain
+	u.EmailVerifiedAt = nil
+	u.Role = RoleCustomer
+	u.UpdatedAt = at
+}
+
+// Erased reports whether the user's personal data has been erased
+func (u *User) Erased() bool {
+	return strings.HasSuffix(u.Email, erasedEmailDomain)
+}
+
+// UserFilter narrows a user listing; zero values mean "no constraint"
+type UserFilter struct {
+	EmailPrefix string // matched ignoring case
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	Limit       int
+	Offset      int
 }
 
 // UserRepository defines the interface for user data operations
@@ -23,6 +131,9 @@ type UserRepository interface {
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, id uuid.UUID) error
+	Search(ctx context.Context, filter UserFilter) ([]*User, error)
 }
 
 // UserCacheRepository defines the interface for user cache operations
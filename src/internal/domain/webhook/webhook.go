@@ -0,0 +1,148 @@
+package domain_webhook
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Booking lifecycle event types delivered to subscribers
+const (
+	EventBookingCreated   = "booking.created"
+	EventBookingConfirmed = "booking.confirmed"
+	EventBookingCancelled = "booking.cancelled"
+	EventBookingExpired   = "booking.expired"
+	EventBookingRefunded  = "booking.refunded"
+	EventBookingModified  = "booking.modified"
+	EventBookingReview    = "booking.review"
+	EventBookingRejected  = "booking.rejected"
+)
+
+// EventTypes lists every event type a subscription may ask for
+var EventTypes = []string{
+	EventBookingCreated,
+	EventBookingConfirmed,
+	EventBookingCancelled,
+	EventBookingExpired,
+	EventBookingRefunded,
+	EventBookingModified,
+	EventBookingReview,
+	EventBookingRejected,
+}
+
+// IsValidEventType reports whether t is a known event type
+func IsValidEventType(t string) bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus represents the state of a webhook delivery
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusSucceeded DeliveryStatus = "succeeded"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// Subscription is a callback URL registered by an API consumer
+type Subscription struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	URL        string        `json:"url" db:"url"`
+	Secret     string        `json:"-" db:"secret"`
+	EventTypes EventTypeList `json:"event_types" db:"event_types"`
+	Active     bool          `json:"active" db:"active"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// Wants reports whether the subscription should receive the event type
+func (s *Subscription) Wants(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTypeList is a list of event types stored as JSON
+type EventTypeList []string
+
+// Value implements driver.Valuer
+func (l EventTypeList) Value() (driver.Value, error) {
+	if l == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal([]string(l))
+}
+
+// Scan implements sql.Scanner
+func (l *EventTypeList) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(l))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(l))
+	}
+	return fmt.Errorf("cannot scan %T into EventTypeList", src)
+}
+
+// Delivery is a single payload queued for a subscription, with its retry state
+type Delivery struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	SubscriptionID uuid.UUID       `json:"subscription_id" db:"subscription_id"`
+	EventType      string          `json:"event_type" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         DeliveryStatus  `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at" db:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code,omitempty" db:"last_status_code"`
+	LastError      *string         `json:"last_error,omitempty" db:"last_error"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// Payload is the JSON body posted to subscribers
+type Payload struct {
+	ID        uuid.UUID   `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// WebhookRepository defines the interface for webhook data operations
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *Subscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	CreateDeliveries(ctx context.Context, deliveries []*Delivery) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*Delivery, error)
+}
+
+// CreateSubscriptionRequest represents a request to register a webhook
+type CreateSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret,omitempty"` // generated when omitted
+}
+
+// CreateSubscriptionResponse returns the subscription along with its signing secret
+type CreateSubscriptionResponse struct {
+	*Subscription
+	Secret string `json:"secret"`
+}
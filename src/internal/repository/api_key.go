@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const apiKeyColumns = `id, name, user_id, prefix, key_hash, scopes, rate_limit, created_by, created_at, expires_at, last_used_at, revoked_at`
+
+// PostgreSQL API Key Repository
+type postgresAPIKeyRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresAPIKeyRepository) Create(ctx context.Context, key *domain_apikey.APIKey) error {
+	query := `INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`
+	_, err := r.db.ExecContext(ctx, query,
+		key.ID, key.Name, key.UserID, key.Prefix, key.KeyHash, key.Scopes, key.RateLimit,
+		key.CreatedBy, key.CreatedAt, key.ExpiresAt, key.LastUsedAt, key.RevokedAt)
+	return err
+}
+
+func (r *postgresAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_apikey.APIKey, error) {
+	return r.get(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE id = $1`, id)
+}
+
+func (r *postgresAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain_apikey.APIKey, error) {
+	return r.get(ctx, `SELECT `+apiKeyColumns+` FROM api_keys WHERE key_hash = $1`, keyHash)
+}
+
+func (r *postgresAPIKeyRepository) get(ctx context.Context, query string, arg interface{}) (*domain_apikey.APIKey, error) {
+	var key domain_apikey.APIKey
+	if err := r.db.GetContext(ctx, &key, query, arg); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *postgresAPIKeyRepository) List(ctx context.Context) ([]*domain_apikey.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys ORDER BY created_at DESC`
+	var keys []*domain_apikey.APIKey
+	if err := r.db.SelectContext(ctx, &keys, query); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke stops a key from being used, or returns ErrNotFound if there is
+// no such key that is not revoked already
+func (r *postgresAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE api_keys SET revoked_at = $2 WHERE id = $1 AND revoked_at IS NULL`, id, at)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresAPIKeyRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = $2 WHERE id = $1`, id, at)
+	return err
+}
+
+// Redis Rate Limit Repository
+// Fixed windows: a counter per name that expires a window after its first
+// hit. Counters are tenant-scoped.
+type redisRateLimitRepository struct {
+	client *redis.Client
+}
+
+func rateLimitKey(ctx context.Context, name string) string {
+	return tenantKey(ctx, "ratelimit:"+name)
+}
+
+// hitRateLimit counts a hit and returns the count with the milliseconds
+// left in the window
+var hitRateLimit = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}`)
+
+func (r *redisRateLimitRepository) Allow(ctx context.Context, name string, limit int, window time.Duration) (bool, time.Duration, error) {
+	result, err := hitRateLimit.Run(ctx, r.client, []string{rateLimitKey(ctx, name)}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return false, 0, err
+	}
+	if len(result) != 2 {
+		return false, 0, redis.Nil
+	}
+	return result[0] <= int64(limit), time.Duration(max(result[1], 0)) * time.Millisecond, nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
+
+	"github.com/google/uuid"
+)
+
+const auditEntryColumns = `id, action, actor_id, subject_id, details, created_at`
+
+// PostgreSQL Audit Repository
+type postgresAuditRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresAuditRepository) Create(ctx context.Context, entry *domain_audit.Entry) error {
+	query := `INSERT INTO audit_entries (` + auditEntryColumns + `) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := r.db.ExecContext(ctx, query, entry.ID, entry.Action, entry.ActorID, entry.SubjectID, string(entry.Details), entry.CreatedAt)
+	return err
+}
+
+func (r *postgresAuditRepository) GetBySubject(ctx context.Context, subjectID uuid.UUID) ([]*domain_audit.Entry, error) {
+	query := `SELECT ` + auditEntryColumns + ` FROM audit_entries WHERE subject_id = $1 ORDER BY created_at ASC`
+	var entries []*domain_audit.Entry
+	if err := r.db.SelectContext(ctx, &entries, query, subjectID); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// ticketPriceCount is one row of an event's available tickets counted by
+// price
+type ticketPriceCount struct {
+	Price     float64 `db:"price"`
+	Available int64   `db:"available"`
+}
+
+// availabilityFromCounts totals counts by price into an event's availability
+func availabilityFromCounts(eventID uuid.UUID, counts []ticketPriceCount) *domain_ticket.Availability {
+	availability := &domain_ticket.Availability{EventID: eventID, Tiers: make(map[int64]int64)}
+	for _, count := range counts {
+		availability.Available += count.Available
+		availability.Tiers[domain_ticket.PriceCents(count.Price)] += count.Available
+	}
+	return availability
+}
+
+// availableTicketsQuery counts an event's available tickets by price. The
+// price is selected through the column migration before grouping.
+func availableTicketsQuery(price columnSwitch, eventParam string) string {
+	return `SELECT price, COUNT(*) AS available FROM (SELECT ` + price.selectExpr() +
+		` FROM tickets WHERE event_id = ` + eventParam + ` AND status = 'available') t GROUP BY price`
+}
+
+func (r *postgresTicketRepository) CountAvailable(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	var counts []ticketPriceCount
+	if err := r.db.SelectContext(ctx, &counts, availableTicketsQuery(r.price, "$1"), eventID); err != nil {
+		return nil, err
+	}
+	return availabilityFromCounts(eventID, counts), nil
+}
+
+func (r *mysqlTicketRepository) CountAvailable(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	var counts []ticketPriceCount
+	if err := r.db.SelectContext(ctx, &counts, availableTicketsQuery(r.price, "?"), eventID); err != nil {
+		return nil, err
+	}
+	return availabilityFromCounts(eventID, counts), nil
+}
+
+// ticketStatusQuery counts an event's tickets by status, price and section.
+// The section expression numbers runs of seats from 1.
+func ticketStatusQuery(price columnSwitch, eventParam, section string) string {
+	return `SELECT status, price, section, COUNT(*) AS tickets FROM (SELECT status, ` + price.selectExpr() + `, ` + section +
+		` AS section FROM tickets WHERE event_id = ` + eventParam + `) t GROUP BY status, price, section`
+}
+
+func (r *postgresTicketRepository) CountByStatus(ctx context.Context, eventID uuid.UUID, sectionSize int) ([]*domain_ticket.StatusCount, error) {
+	var counts []*domain_ticket.StatusCount
+	if err := r.db.readSelect(ctx, &counts, ticketStatusQuery(r.price, "$1", "(seat_number - 1) / $2 + 1"), eventID, sectionSize); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *mysqlTicketRepository) CountByStatus(ctx context.Context, eventID uuid.UUID, sectionSize int) ([]*domain_ticket.StatusCount, error) {
+	var counts []*domain_ticket.StatusCount
+	if err := r.db.SelectContext(ctx, &counts, ticketStatusQuery(r.price, "?", "(seat_number - 1) DIV ? + 1"), sectionSize, eventID); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// Redis Availability Repository
+// Each event's counters are a hash of the total and one field per price
+// tier. Taking tickets checks and decrements every counter in one script, so
+// concurrent bookings can never take the last ticket twice. As with the
+// event stats, changes only apply to a hash that exists: counters are seeded
+// from the database first.
+type redisAvailabilityRepository struct {
+	client *redis.Client
+}
+
+func availabilityKey(ctx context.Context, eventID uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("availability:%s", eventID.String()))
+}
+
+const (
+	availableField    = "available"
+	tierFieldPrefix   = "tier:"
+	reconciledAtField = "reconciled_at"
+)
+
+// availabilityArgs lists counter fields and amounts for the scripts below
+func availabilityArgs(tiers map[int64]int64) []interface{} {
+	var total int64
+	args := make([]interface{}, 0, 2*len(tiers)+2)
+	for cents, n := range tiers {
+		total += n
+		args = append(args, tierFieldPrefix+strconv.FormatInt(cents, 10), n)
+	}
+	return append(args, availableField, total)
+}
+
+// takeAvailability returns -1 for counters that were never seeded, 0 if any
+// counter is short, and 1 once every counter has been decremented
+var takeAvailability = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+for i = 1, #ARGV, 2 do
+	if tonumber(redis.call('HGET', KEYS[1], ARGV[i]) or '0') < tonumber(ARGV[i + 1]) then
+		return 0
+	end
+end
+for i = 1, #ARGV, 2 do
+	redis.call('HINCRBY', KEYS[1], ARGV[i], -tonumber(ARGV[i + 1]))
+end
+return 1`)
+
+var restoreAvailability = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+for i = 1, #ARGV, 2 do
+	redis.call('HINCRBY', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+return 1`)
+
+// setAvailability replaces the hash, dropping tiers that sold out
+var setAvailability = redis.NewScript(`
+redis.call('DEL', KEYS[1])
+redis.call('HSET', KEYS[1], unpack(ARGV))
+return 1`)
+
+func (r *redisAvailabilityRepository) Get(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	fields, err := r.client.HGetAll(ctx, availabilityKey(ctx, eventID)).Result()
+	if errors.Is(err, database.ErrRedisUnavailable) {
+		// Counted from the database instead
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	availability := &domain_ticket.Availability{EventID: eventID, Tiers: make(map[int64]int64)}
+	for field, value := range fields {
+		n, _ := strconv.ParseInt(value, 10, 64)
+		switch {
+		case field == availableField:
+			availability.Available = n
+		case field == reconciledAtField:
+			availability.ReconciledAt = time.Unix(n, 0).UTC()
+		case strings.HasPrefix(field, tierFieldPrefix):
+			cents, err := strconv.ParseInt(strings.TrimPrefix(field, tierFieldPrefix), 10, 64)
+			if err == nil && n > 0 {
+				availability.Tiers[cents] = n
+			}
+		}
+	}
+	return availability, nil
+}
+
+func (r *redisAvailabilityRepository) Set(ctx context.Context, availability *domain_ticket.Availability) error {
+	args := []interface{}{availableField, availability.Available, reconciledAtField, availability.ReconciledAt.Unix()}
+	for cents, n := range availability.Tiers {
+		args = append(args, tierFieldPrefix+strconv.FormatInt(cents, 10), n)
+	}
+	return skipUnavailable(setAvailability.Run(ctx, r.client, []string{availabilityKey(ctx, availability.EventID)}, args...).Err())
+}
+
+func (r *redisAvailabilityRepository) Take(ctx context.Context, eventID uuid.UUID, tiers map[int64]int64) (bool, error) {
+	if len(tiers) == 0 {
+		return true, nil
+	}
+	taken, err := takeAvailability.Run(ctx, r.client, []string{availabilityKey(ctx, eventID)}, availabilityArgs(tiers)...).Int64()
+	if errors.Is(err, database.ErrRedisUnavailable) {
+		// Nothing to check against; the database has the final say
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return taken != 0, nil
+}
+
+func (r *redisAvailabilityRepository) Restore(ctx context.Context, eventID uuid.UUID, tiers map[int64]int64) error {
+	if len(tiers) == 0 {
+		return nil
+	}
+	return skipUnavailable(restoreAvailability.Run(ctx, r.client, []string{availabilityKey(ctx, eventID)}, availabilityArgs(tiers)...).Err())
+}
+
+func (r *redisAvailabilityRepository) Delete(ctx context.Context, eventID uuid.UUID) error {
+	return skipUnavailable(r.client.Del(ctx, availabilityKey(ctx, eventID)).Err())
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Availability Feed Repository
+// Seat changes of every tenant share one pub/sub channel, so whichever
+// instance holds a client's stream hears about changes made on any other.
+// Pub/sub keeps nothing for subscribers that are not listening, so each
+// (re)subscription starts with a recount of every event.
+type redisAvailabilityFeedRepository struct {
+	client *redis.Client
+}
+
+const availabilityFeedChannel = "availability:changes"
+
+type availabilityFeedMessage struct {
+	Tenant string                           `json:"tenant,omitempty"`
+	Change domain_ticket.AvailabilityChange `json:"change"`
+}
+
+// recountAll is handed to subscribers whenever they may have missed changes
+func recountAll() *domain_ticket.AvailabilityChange {
+	return &domain_ticket.AvailabilityChange{Kind: domain_ticket.AvailabilityRecounted}
+}
+
+func (r *redisAvailabilityFeedRepository) Publish(ctx context.Context, change *domain_ticket.AvailabilityChange) error {
+	payload, err := json.Marshal(availabilityFeedMessage{Tenant: tenant.FromContext(ctx), Change: *change})
+	if err != nil {
+		return err
+	}
+	return skipUnavailable(r.client.Publish(ctx, availabilityFeedChannel, payload).Err())
+}
+
+// Subscribe passes seat changes to handle until ctx is cancelled, when it
+// returns nil, or the connection fails
+func (r *redisAvailabilityFeedRepository) Subscribe(ctx context.Context, handle func(ctx context.Context, change *domain_ticket.AvailabilityChange)) error {
+	pubsub := r.client.Subscribe(ctx, availabilityFeedChannel)
+	defer pubsub.Close()
+
+	for {
+		received, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		switch msg := received.(type) {
+		case *redis.Subscription:
+			handle(ctx, recountAll())
+		case *redis.Message:
+			var m availabilityFeedMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+				// Sent by a newer version; have everyone count again
+				handle(ctx, recountAll())
+				continue
+			}
+			handle(tenant.WithID(ctx, m.Tenant), &m.Change)
+		}
+	}
+}
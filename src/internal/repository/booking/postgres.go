@@ -3,210 +3,138 @@ package repository_booking
 import (
 	"context"
 	"database/sql"
-	"fmt"
-	"strings"
 	"time"
 
 	"github.com/ojaswiii/booking-manager/src/internal/domain"
 	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type postgresBookingRepository struct {
 	db *sqlx.DB
 }
 
-// uuidSliceToString converts []uuid.UUID to PostgreSQL array string format
-func uuidSliceToString(uuids []uuid.UUID) string {
-	if len(uuids) == 0 {
-		return "{}"
-	}
-
-	strs := make([]string, len(uuids))
-	for i, u := range uuids {
-		strs[i] = fmt.Sprintf("\"%s\"", u.String())
-	}
-	return "{" + strings.Join(strs, ",") + "}"
-}
-
-// stringToUUIDSlice converts PostgreSQL array string to []uuid.UUID
-func stringToUUIDSlice(s string) ([]uuid.UUID, error) {
-	// Remove curly braces
-	s = strings.Trim(s, "{}")
-	if s == "" {
-		return []uuid.UUID{}, nil
-	}
-
-	// Split by comma and parse each UUID
-	parts := strings.Split(s, ",")
-	uuids := make([]uuid.UUID, len(parts))
-	for i, part := range parts {
-		// Remove quotes if present
-		part = strings.Trim(part, "\"")
-		u, err := uuid.Parse(part)
-		if err != nil {
-			return nil, err
-		}
-		uuids[i] = u
-	}
-	return uuids, nil
-}
-
 // NewPostgresBookingRepository creates a new PostgreSQL booking repository
 func NewPostgresBookingRepository(db *sqlx.DB) *postgresBookingRepository {
 	return &postgresBookingRepository{db: db}
 }
 
-// Create stores a new booking
+// Create stores a new booking and the tickets it holds
 func (r *postgresBookingRepository) Create(ctx context.Context, booking *domain_booking.Booking) error {
-	query := `
-		INSERT INTO bookings (id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-
-	// Convert UUID slice to PostgreSQL array string
-	ticketIDsStr := uuidSliceToString(booking.TicketIDs)
+	return r.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `
+			INSERT INTO bookings (id, user_id, event_id, status, total_amount, created_at, updated_at, expires_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+
+		_, err := tx.ExecContext(ctx, query, booking.ID, booking.UserID, booking.EventID,
+			booking.Status, booking.TotalAmount, booking.CreatedAt,
+			booking.UpdatedAt, booking.ExpiresAt)
+		if err != nil {
+			return err
+		}
 
-	_, err := r.db.ExecContext(ctx, query, booking.ID, booking.UserID, booking.EventID,
-		ticketIDsStr, booking.Status, booking.TotalAmount, booking.CreatedAt,
-		booking.UpdatedAt, booking.ExpiresAt)
-	return err
+		return insertTickets(ctx, tx, booking)
+	})
 }
 
 // GetByID retrieves a booking by ID
 func (r *postgresBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_booking.Booking, error) {
 	query := `
-		SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at
+		SELECT id, user_id, event_id, status, total_amount, created_at, updated_at, expires_at
 		FROM bookings
 		WHERE id = $1`
 
 	var booking domain_booking.Booking
-	var ticketIDsStr string
+	if err := r.db.GetContext(ctx, &booking, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := r.loadTickets(ctx, &booking); err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
 
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&booking.ID, &booking.UserID, &booking.EventID, &ticketIDsStr,
-		&booking.Status, &booking.TotalAmount, &booking.CreatedAt,
-		&booking.UpdatedAt, &booking.ExpiresAt)
+// GetByTicketID retrieves the pending or confirmed booking holding a ticket
+func (r *postgresBookingRepository) GetByTicketID(ctx context.Context, ticketID uuid.UUID) (*domain_booking.Booking, error) {
+	query := `
+		SELECT b.id, b.user_id, b.event_id, b.status, b.total_amount, b.created_at, b.updated_at, b.expires_at
+		FROM bookings b
+		JOIN booking_tickets bt ON bt.booking_id = b.id
+		WHERE bt.ticket_id = $1 AND b.status IN ('pending', 'confirmed')
+		ORDER BY b.created_at DESC
+		LIMIT 1`
 
-	if err != nil {
+	var booking domain_booking.Booking
+	if err := r.db.GetContext(ctx, &booking, query, ticketID); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
 
-	// Convert PostgreSQL array string back to UUID slice
-	ticketIDs, err := stringToUUIDSlice(ticketIDsStr)
-	if err != nil {
+	if err := r.loadTickets(ctx, &booking); err != nil {
 		return nil, err
 	}
-	booking.TicketIDs = ticketIDs
-
 	return &booking, nil
 }
 
 // GetByUserID retrieves all bookings for a user
 func (r *postgresBookingRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error) {
 	query := `
-		SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at
+		SELECT id, user_id, event_id, status, total_amount, created_at, updated_at, expires_at
 		FROM bookings
 		WHERE user_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, userID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var bookings []*domain_booking.Booking
-	for rows.Next() {
-		var booking domain_booking.Booking
-		var ticketIDsStr string
-
-		err := rows.Scan(
-			&booking.ID, &booking.UserID, &booking.EventID, &ticketIDsStr,
-			&booking.Status, &booking.TotalAmount, &booking.CreatedAt,
-			&booking.UpdatedAt, &booking.ExpiresAt)
-		if err != nil {
-			return nil, err
-		}
-
-		// Convert PostgreSQL array string back to UUID slice
-		ticketIDs, err := stringToUUIDSlice(ticketIDsStr)
-		if err != nil {
-			return nil, err
-		}
-		booking.TicketIDs = ticketIDs
-
-		bookings = append(bookings, &booking)
-	}
-
-	return bookings, nil
+	return r.selectBookings(ctx, query, userID)
 }
 
 // GetByEventID retrieves all bookings for an event
 func (r *postgresBookingRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_booking.Booking, error) {
 	query := `
-		SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at
+		SELECT id, user_id, event_id, status, total_amount, created_at, updated_at, expires_at
 		FROM bookings
 		WHERE event_id = $1
 		ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query, eventID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
+	return r.selectBookings(ctx, query, eventID)
+}
 
-	var bookings []*domain_booking.Booking
-	for rows.Next() {
-		var booking domain_booking.Booking
-		var ticketIDsStr string
-
-		err := rows.Scan(
-			&booking.ID, &booking.UserID, &booking.EventID, &ticketIDsStr,
-			&booking.Status, &booking.TotalAmount, &booking.CreatedAt,
-			&booking.UpdatedAt, &booking.ExpiresAt)
+// Update updates an existing booking and replaces the tickets it holds
+func (r *postgresBookingRepository) Update(ctx context.Context, booking *domain_booking.Booking) error {
+	return r.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `
+			UPDATE bookings
+			SET status = $2, total_amount = $3, updated_at = $4, expires_at = $5
+			WHERE id = $1`
+
+		result, err := tx.ExecContext(ctx, query, booking.ID, booking.Status,
+			booking.TotalAmount, booking.UpdatedAt, booking.ExpiresAt)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		// Convert PostgreSQL array string back to UUID slice
-		ticketIDs, err := stringToUUIDSlice(ticketIDsStr)
+		rowsAffected, err := result.RowsAffected()
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if rowsAffected == 0 {
+			return domain.ErrNotFound
 		}
-		booking.TicketIDs = ticketIDs
-
-		bookings = append(bookings, &booking)
-	}
-
-	return bookings, nil
-}
-
-// Update updates an existing booking
-func (r *postgresBookingRepository) Update(ctx context.Context, booking *domain_booking.Booking) error {
-	query := `
-		UPDATE bookings
-		SET status = $2, total_amount = $3, updated_at = $4, expires_at = $5
-		WHERE id = $1`
-
-	result, err := r.db.ExecContext(ctx, query, booking.ID, booking.Status,
-		booking.TotalAmount, booking.UpdatedAt, booking.ExpiresAt)
-	if err != nil {
-		return err
-	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return domain.ErrNotFound
-	}
-	return nil
+		if _, err := tx.ExecContext(ctx, `DELETE FROM booking_tickets WHERE booking_id = $1`, booking.ID); err != nil {
+			return err
+		}
+		return insertTickets(ctx, tx, booking)
+	})
 }
 
 // Delete removes a booking
@@ -231,39 +159,90 @@ func (r *postgresBookingRepository) Delete(ctx context.Context, id uuid.UUID) er
 // GetExpiredBookings retrieves bookings that have expired
 func (r *postgresBookingRepository) GetExpiredBookings(ctx context.Context, before time.Time) ([]*domain_booking.Booking, error) {
 	query := `
-		SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at
+		SELECT id, user_id, event_id, status, total_amount, created_at, updated_at, expires_at
 		FROM bookings
 		WHERE expires_at < $1 AND status = 'pending'
 		ORDER BY expires_at ASC`
 
-	rows, err := r.db.QueryContext(ctx, query, before)
-	if err != nil {
+	return r.selectBookings(ctx, query, before)
+}
+
+// selectBookings runs a bookings query and fills in their tickets
+func (r *postgresBookingRepository) selectBookings(ctx context.Context, query string, args ...interface{}) ([]*domain_booking.Booking, error) {
+	var bookings []*domain_booking.Booking
+	if err := r.db.SelectContext(ctx, &bookings, query, args...); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var bookings []*domain_booking.Booking
-	for rows.Next() {
-		var booking domain_booking.Booking
-		var ticketIDsStr string
-
-		err := rows.Scan(
-			&booking.ID, &booking.UserID, &booking.EventID, &ticketIDsStr,
-			&booking.Status, &booking.TotalAmount, &booking.CreatedAt,
-			&booking.UpdatedAt, &booking.ExpiresAt)
-		if err != nil {
-			return nil, err
-		}
+	if err := r.loadTickets(ctx, bookings...); err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
 
-		// Convert PostgreSQL array string back to UUID slice
-		ticketIDs, err := stringToUUIDSlice(ticketIDsStr)
-		if err != nil {
-			return nil, err
-		}
-		booking.TicketIDs = ticketIDs
+// loadTickets reads the tickets each booking holds, in booking order
+func (r *postgresBookingRepository) loadTickets(ctx context.Context, bookings ...*domain_booking.Booking) error {
+	if len(bookings) == 0 {
+		return nil
+	}
 
-		bookings = append(bookings, &booking)
+	ids := make([]uuid.UUID, len(bookings))
+	byID := make(map[uuid.UUID]*domain_booking.Booking, len(bookings))
+	for i, booking := range bookings {
+		ids[i] = booking.ID
+		byID[booking.ID] = booking
+		booking.TicketIDs = []uuid.UUID{}
 	}
 
-	return bookings, nil
+	query := `
+		SELECT booking_id, ticket_id
+		FROM booking_tickets
+		WHERE booking_id = ANY($1)
+		ORDER BY booking_id, position`
+
+	var rows []struct {
+		BookingID uuid.UUID `db:"booking_id"`
+		TicketID  uuid.UUID `db:"ticket_id"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		booking := byID[row.BookingID]
+		booking.TicketIDs = append(booking.TicketIDs, row.TicketID)
+	}
+	return nil
+}
+
+// inTx runs fn in the transaction opened by repository.Transactor when
+// there is one, and otherwise in a transaction of its own
+func (r *postgresBookingRepository) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	if tx := repository.TxFromContext(ctx); tx != nil {
+		return fn(tx)
+	}
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// insertTickets records the tickets a booking holds, in order
+func insertTickets(ctx context.Context, tx *sqlx.Tx, booking *domain_booking.Booking) error {
+	query := `
+		INSERT INTO booking_tickets (booking_id, ticket_id, position)
+		VALUES ($1, $2, $3)`
+
+	for i, ticketID := range booking.TicketIDs {
+		if _, err := tx.ExecContext(ctx, query, booking.ID, ticketID, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const bookingEventColumns = `position, booking_id, version, type, actor, data, occurred_at`
+
+// PostgreSQL Booking Event Repository
+type postgresBookingEventRepository struct {
+	db *tenantDB
+}
+
+// Append adds each event to the end of its booking's stream and sets its
+// version and position. Two writers appending to one stream at once get the
+// same version, and the unique constraint rejects the second.
+func (r *postgresBookingEventRepository) Append(ctx context.Context, events ...*domain_booking.BookingEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO booking_events (booking_id, version, type, actor, data, occurred_at)
+			VALUES ($1, (SELECT COALESCE(MAX(version), 0) + 1 FROM booking_events WHERE booking_id = $1), $2, $3, $4, $5)
+			RETURNING position, version`
+		for _, e := range events {
+			if err := tx.QueryRowxContext(ctx, query, e.BookingID, e.Type, e.Actor, []byte(e.Data), e.OccurredAt).Scan(&e.Position, &e.Version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresBookingEventRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.BookingEvent, error) {
+	query := `SELECT ` + bookingEventColumns + ` FROM booking_events WHERE booking_id = $1 ORDER BY version ASC`
+	var events []*domain_booking.BookingEvent
+	if err := r.db.SelectContext(ctx, &events, query, bookingID); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *postgresBookingEventRepository) ListAfter(ctx context.Context, position int64, limit int) ([]*domain_booking.BookingEvent, error) {
+	query := `SELECT ` + bookingEventColumns + ` FROM booking_events WHERE position > $1 ORDER BY position ASC LIMIT $2`
+	var events []*domain_booking.BookingEvent
+	if err := r.db.SelectContext(ctx, &events, query, position, limit); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/utils/querybuilder"
+)
+
+// attendeeColumns lists the columns scanned into domain_booking.Attendee
+var attendeeColumns = []string{
+	"b.id AS booking_id", "b.status", "b.created_at AS booked_at",
+	"b.user_id", "u.name AS user_name", "u.email AS user_email",
+	"b.event_id", "e.name AS event_name", "e.date AS event_date",
+	"bi.ticket_id", "bi.seat_number", "bi.unit_price", "bi.currency",
+}
+
+// attendeeTables joins each booked ticket to its booking, user and event
+const attendeeTables = `bookings b
+	JOIN booking_items bi ON bi.booking_id = b.id
+	JOIN users u ON u.id = b.user_id
+	JOIN events e ON e.id = b.event_id`
+
+// PostgreSQL Booking Export Repository
+// Pages through booked tickets by booking time, booking and seat. Each page
+// starts after the last row of the one before rather than at an offset, so
+// bookings made during an export never shift rows between pages.
+type postgresBookingExportRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresBookingExportRepository) ListAttendees(ctx context.Context, filter domain_booking.AttendeeFilter, after *domain_booking.Attendee, limit int) ([]*domain_booking.Attendee, error) {
+	q := querybuilder.Select(attendeeColumns...).From(attendeeTables)
+	if filter.EventID != nil {
+		q.Where("b.event_id = ?", *filter.EventID)
+	}
+	if filter.UserID != nil {
+		q.Where("b.user_id = ?", *filter.UserID)
+	}
+	if after != nil {
+		q.Where("(b.created_at, b.id, bi.seat_number) > (?, ?, ?)", after.BookedAt, after.BookingID, after.SeatNumber)
+	}
+	q.OrderBy("b.created_at", querybuilder.Asc).OrderBy("b.id", querybuilder.Asc).OrderBy("bi.seat_number", querybuilder.Asc)
+	q.Limit(uint64(limit))
+
+	query, args, err := q.ToSQL()
+	if err != nil {
+		return nil, err
+	}
+	var attendees []*domain_booking.Attendee
+	if err := r.db.readSelect(ctx, &attendees, query, args...); err != nil {
+		return nil, err
+	}
+	return attendees, nil
+}
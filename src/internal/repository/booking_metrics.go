@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Booking Metrics Repository
+// Each day's counts are a hash with a field per minute and counter, such as
+// "14:05:requests", so a range is read with one HGETALL per day. A day's
+// hash expires once the retention has passed since its end.
+type redisBookingMetricsRepository struct {
+	client *redis.Client
+}
+
+func bookingMetricsKey(ctx context.Context, day time.Time) string {
+	return tenantKey(ctx, "metrics:bookings:"+day.Format("2006-01-02"))
+}
+
+// bookingMetricsFields are the counters kept for each minute
+var bookingMetricsFields = []string{"requests", "successes", "failures", "revenue_cents"}
+
+func bookingMetricsValues(counts domain_stats.BookingCounts) []int64 {
+	return []int64{counts.Requests, counts.Successes, counts.Failures, counts.RevenueCents}
+}
+
+func (r *redisBookingMetricsRepository) Add(ctx context.Context, minute *domain_stats.BookingMinute, retention time.Duration) error {
+	start := minute.Minute.UTC()
+	day := start.Truncate(24 * time.Hour)
+	key := bookingMetricsKey(ctx, day)
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, value := range bookingMetricsValues(minute.BookingCounts) {
+			if value != 0 {
+				pipe.HIncrBy(ctx, key, start.Format("15:04")+":"+bookingMetricsFields[i], value)
+			}
+		}
+		pipe.ExpireAt(ctx, key, day.Add(24*time.Hour+retention))
+		return nil
+	})
+	return err
+}
+
+func (r *redisBookingMetricsRepository) Range(ctx context.Context, from, to time.Time) ([]*domain_stats.BookingMinute, error) {
+	from, to = from.UTC(), to.UTC()
+	pipe := r.client.Pipeline()
+	var days []time.Time
+	var reads []*redis.MapStringStringCmd
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		days = append(days, day)
+		reads = append(reads, pipe.HGetAll(ctx, bookingMetricsKey(ctx, day)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, err
+	}
+
+	minutes := make(map[time.Time]*domain_stats.BookingMinute)
+	for i, read := range reads {
+		for field, value := range read.Val() {
+			if len(field) < 7 {
+				continue
+			}
+			t, err := time.Parse("15:04", field[:5])
+			if err != nil {
+				continue
+			}
+			start := days[i].Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+			if start.Before(from) || !start.Before(to) {
+				continue
+			}
+			n, _ := strconv.ParseInt(value, 10, 64)
+			addBookingMetric(minutes, start, field[6:], n)
+		}
+	}
+	return sortedBookingMinutes(minutes), nil
+}
+
+// addBookingMetric adds n to one counter of the minute starting at start
+func addBookingMetric(minutes map[time.Time]*domain_stats.BookingMinute, start time.Time, name string, n int64) {
+	minute, ok := minutes[start]
+	if !ok {
+		minute = &domain_stats.BookingMinute{Minute: start}
+		minutes[start] = minute
+	}
+	switch name {
+	case "requests":
+		minute.Requests += n
+	case "successes":
+		minute.Successes += n
+	case "failures":
+		minute.Failures += n
+	case "revenue_cents":
+		minute.RevenueCents += n
+	}
+}
+
+func sortedBookingMinutes(minutes map[time.Time]*domain_stats.BookingMinute) []*domain_stats.BookingMinute {
+	sorted := make([]*domain_stats.BookingMinute, 0, len(minutes))
+	for _, minute := range minutes {
+		sorted = append(sorted, minute)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Minute.Before(sorted[j].Minute) })
+	return sorted
+}
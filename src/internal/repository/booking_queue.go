@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// QueuedMessage is one entry read from a durable booking queue
+type QueuedMessage struct {
+	Queue   string
+	ID      string
+	Payload []byte
+}
+
+// Redis Booking Queue Repository
+// Each named booking queue is a stream read through one consumer group
+// shared by every instance. Entries stay pending until acknowledged, so an
+// entry read by an instance that dies can be claimed by another. Queues are not
+// tenant-scoped: the processor serves every tenant and each request carries
+// its own tenant.
+type redisBookingQueueRepository struct {
+	client *redis.Client
+}
+
+const bookingQueueGroup = "booking-processors"
+
+func bookingQueueKey(queue string) string {
+	return "bookingqueue:" + queue
+}
+
+func (r *redisBookingQueueRepository) Append(ctx context.Context, queue string, payload []byte) error {
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: bookingQueueKey(queue),
+		Values: map[string]interface{}{"request": payload},
+	}).Err()
+}
+
+// Read waits up to block for new entries in any of the queues, returning at
+// most one from each, in the order the queues are given
+func (r *redisBookingQueueRepository) Read(ctx context.Context, queues []string, consumer string, block time.Duration) ([]QueuedMessage, error) {
+	keys := make([]string, 0, 2*len(queues))
+	for _, queue := range queues {
+		keys = append(keys, bookingQueueKey(queue))
+	}
+	for range queues {
+		keys = append(keys, ">")
+	}
+	args := &redis.XReadGroupArgs{
+		Group:    bookingQueueGroup,
+		Consumer: consumer,
+		Streams:  keys,
+		Count:    1,
+		Block:    max(block, time.Millisecond), // zero would block forever
+	}
+	streams, err := r.client.XReadGroup(ctx, args).Result()
+	if isNoGroup(err) {
+		for _, queue := range queues {
+			if err := r.createGroup(ctx, queue); err != nil {
+				return nil, err
+			}
+		}
+		streams, err = r.client.XReadGroup(ctx, args).Result()
+	}
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var messages []QueuedMessage
+	for _, queue := range queues {
+		for _, stream := range streams {
+			if stream.Stream == bookingQueueKey(queue) {
+				messages = append(messages, queuedMessages(queue, stream.Messages)...)
+			}
+		}
+	}
+	return messages, nil
+}
+
+func (r *redisBookingQueueRepository) Reclaim(ctx context.Context, queue string, consumer string, minIdle time.Duration, count int) ([]QueuedMessage, error) {
+	args := &redis.XAutoClaimArgs{
+		Stream:   bookingQueueKey(queue),
+		Group:    bookingQueueGroup,
+		MinIdle:  minIdle,
+		Start:    "0-0",
+		Count:    int64(count),
+		Consumer: consumer,
+	}
+	messages, _, err := r.client.XAutoClaim(ctx, args).Result()
+	if isNoGroup(err) {
+		// Nothing can be pending before the group exists
+		return nil, r.createGroup(ctx, queue)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return queuedMessages(queue, messages), nil
+}
+
+func (r *redisBookingQueueRepository) Ack(ctx context.Context, queue string, id string) error {
+	key := bookingQueueKey(queue)
+	pipe := r.client.TxPipeline()
+	pipe.XAck(ctx, key, bookingQueueGroup, id)
+	pipe.XDel(ctx, key, id)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *redisBookingQueueRepository) Len(ctx context.Context, queue string) (int64, error) {
+	// Acknowledged entries are deleted, so this counts waiting and in-flight requests
+	return r.client.XLen(ctx, bookingQueueKey(queue)).Result()
+}
+
+// createGroup creates the consumer group from the start of the stream, so
+// entries appended before any instance read the queue are still delivered
+func (r *redisBookingQueueRepository) createGroup(ctx context.Context, queue string) error {
+	err := r.client.XGroupCreateMkStream(ctx, bookingQueueKey(queue), bookingQueueGroup, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+func isNoGroup(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "NOGROUP")
+}
+
+func queuedMessages(queue string, messages []redis.XMessage) []QueuedMessage {
+	queued := make([]QueuedMessage, 0, len(messages))
+	for _, message := range messages {
+		payload, _ := message.Values["request"].(string)
+		queued = append(queued, QueuedMessage{Queue: queue, ID: message.ID, Payload: []byte(payload)})
+	}
+	return queued
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const bookingTransitionColumns = `id, booking_id, ticket_id, from_status, to_status, actor, reason, created_at`
+
+// PostgreSQL Booking Transition Repository
+type postgresBookingTransitionRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresBookingTransitionRepository) Append(ctx context.Context, transitions ...*domain_booking.Transition) error {
+	if len(transitions) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO booking_transitions (` + bookingTransitionColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		for _, t := range transitions {
+			if _, err := tx.ExecContext(ctx, query, t.ID, t.BookingID, t.TicketID, t.FromStatus, t.ToStatus, t.Actor, t.Reason, t.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetByBookingID lists the booking's own transitions before its tickets' at
+// the same time
+func (r *postgresBookingTransitionRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.Transition, error) {
+	query := `SELECT ` + bookingTransitionColumns + ` FROM booking_transitions WHERE booking_id = $1
+		ORDER BY created_at ASC, ticket_id IS NOT NULL, ticket_id`
+	var transitions []*domain_booking.Transition
+	if err := r.db.SelectContext(ctx, &transitions, query, bookingID); err != nil {
+		return nil, err
+	}
+	return transitions, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// bookingUpdateTTL is how long a request's latest update, and the request a
+// booking came from, are kept for clients that subscribe late
+const bookingUpdateTTL = time.Hour
+
+// Redis Booking Update Repository
+// The latest update of each request is kept so a client subscribing after
+// its request was processed still hears the outcome, and each booking
+// remembers its request so later changes reach the same subscribers.
+// Updates of every tenant share one pub/sub channel. A (re)subscription
+// starts with an empty update: any update may have been missed.
+type redisBookingUpdateRepository struct {
+	client *redis.Client
+}
+
+const bookingUpdateChannel = "booking:updates"
+
+type bookingUpdateMessage struct {
+	Tenant string                       `json:"tenant,omitempty"`
+	Update domain_booking.RequestUpdate `json:"update"`
+}
+
+func bookingRequestKey(ctx context.Context, requestID string) string {
+	return tenantKey(ctx, "booking_request:"+requestID)
+}
+
+func bookingRequestOfKey(ctx context.Context, bookingID uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("booking_request_of:%s", bookingID.String()))
+}
+
+func (r *redisBookingUpdateRepository) Publish(ctx context.Context, update *domain_booking.RequestUpdate) error {
+	latest, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	payload, err := json.Marshal(bookingUpdateMessage{Tenant: tenant.FromContext(ctx), Update: *update})
+	if err != nil {
+		return err
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Set(ctx, bookingRequestKey(ctx, update.RequestID), latest, bookingUpdateTTL)
+	if update.BookingID != nil {
+		pipe.Set(ctx, bookingRequestOfKey(ctx, *update.BookingID), update.RequestID, bookingUpdateTTL)
+	}
+	pipe.Publish(ctx, bookingUpdateChannel, payload)
+	_, err = pipe.Exec(ctx)
+	return skipUnavailable(err)
+}
+
+func (r *redisBookingUpdateRepository) Latest(ctx context.Context, requestID string) (*domain_booking.RequestUpdate, error) {
+	data, err := r.client.Get(ctx, bookingRequestKey(ctx, requestID)).Bytes()
+	if errors.Is(err, redis.Nil) || errors.Is(err, database.ErrRedisUnavailable) {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var update domain_booking.RequestUpdate
+	if err := json.Unmarshal(data, &update); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
+func (r *redisBookingUpdateRepository) RequestOf(ctx context.Context, bookingID uuid.UUID) (string, error) {
+	requestID, err := r.client.Get(ctx, bookingRequestOfKey(ctx, bookingID)).Result()
+	if errors.Is(err, redis.Nil) || errors.Is(err, database.ErrRedisUnavailable) {
+		return "", domain.ErrNotFound
+	}
+	return requestID, err
+}
+
+// Subscribe passes updates to handle until ctx is cancelled, when it
+// returns nil, or the connection fails
+func (r *redisBookingUpdateRepository) Subscribe(ctx context.Context, handle func(ctx context.Context, update *domain_booking.RequestUpdate)) error {
+	pubsub := r.client.Subscribe(ctx, bookingUpdateChannel)
+	defer pubsub.Close()
+
+	for {
+		received, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		switch msg := received.(type) {
+		case *redis.Subscription:
+			handle(ctx, &domain_booking.RequestUpdate{})
+		case *redis.Message:
+			var m bookingUpdateMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+				continue
+			}
+			handle(tenant.WithID(ctx, m.Tenant), &m.Update)
+		}
+	}
+}
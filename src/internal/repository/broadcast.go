@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+
+	"github.com/google/uuid"
+)
+
+// Broadcasts with their deliveries counted by status
+const broadcastReportQuery = `
+	SELECT b.id, b.event_id, b.subject, b.message, b.created_at, b.completed_at,
+		COUNT(d.user_id) AS recipients,
+		COUNT(*) FILTER (WHERE d.status = 'pending') AS pending,
+		COUNT(*) FILTER (WHERE d.status = 'sent') AS sent,
+		COUNT(*) FILTER (WHERE d.status = 'skipped') AS skipped,
+		COUNT(*) FILTER (WHERE d.status = 'failed') AS failed
+	FROM event_broadcasts b
+	LEFT JOIN event_broadcast_deliveries d ON d.broadcast_id = b.id`
+
+// broadcastRow is a broadcast scanned together with its report
+type broadcastRow struct {
+	domain_broadcast.Broadcast
+	domain_broadcast.Report
+}
+
+func (row *broadcastRow) broadcast() *domain_broadcast.Broadcast {
+	b := row.Broadcast
+	b.Report = row.Report
+	return &b
+}
+
+// PostgreSQL Broadcast Repository
+type postgresBroadcastRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresBroadcastRepository) Create(ctx context.Context, b *domain_broadcast.Broadcast) error {
+	query := `INSERT INTO event_broadcasts (id, event_id, subject, message, created_at) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.ExecContext(ctx, query, b.ID, b.EventID, b.Subject, b.Message, b.CreatedAt)
+	return err
+}
+
+func (r *postgresBroadcastRepository) GetByID(ctx context.Context, eventID, id uuid.UUID) (*domain_broadcast.Broadcast, error) {
+	query := broadcastReportQuery + ` WHERE b.event_id = $1 AND b.id = $2 GROUP BY b.id`
+	var row broadcastRow
+	err := r.db.GetContext(ctx, &row, query, eventID, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return row.broadcast(), nil
+}
+
+func (r *postgresBroadcastRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_broadcast.Broadcast, error) {
+	query := broadcastReportQuery + ` WHERE b.event_id = $1 GROUP BY b.id ORDER BY b.created_at DESC`
+	var rows []broadcastRow
+	if err := r.db.SelectContext(ctx, &rows, query, eventID); err != nil {
+		return nil, err
+	}
+	broadcasts := make([]*domain_broadcast.Broadcast, len(rows))
+	for i := range rows {
+		broadcasts[i] = rows[i].broadcast()
+	}
+	return broadcasts, nil
+}
+
+// AddAttendees creates a pending delivery for every user with a confirmed
+// booking for the broadcast's event. Users already added keep their delivery.
+func (r *postgresBroadcastRepository) AddAttendees(ctx context.Context, b *domain_broadcast.Broadcast) error {
+	query := `INSERT INTO event_broadcast_deliveries (broadcast_id, user_id)
+		SELECT DISTINCT $1::uuid, user_id FROM bookings WHERE event_id = $2 AND status = 'confirmed'
+		ON CONFLICT (broadcast_id, user_id) DO NOTHING`
+	_, err := r.db.ExecContext(ctx, query, b.ID, b.EventID)
+	return err
+}
+
+func (r *postgresBroadcastRepository) GetPendingDeliveries(ctx context.Context, broadcastID uuid.UUID, limit int) ([]*domain_broadcast.Delivery, error) {
+	query := `SELECT broadcast_id, user_id, status, channel, error, updated_at FROM event_broadcast_deliveries
+		WHERE broadcast_id = $1 AND status = 'pending' ORDER BY user_id LIMIT $2`
+	var deliveries []*domain_broadcast.Delivery
+	if err := r.db.SelectContext(ctx, &deliveries, query, broadcastID, limit); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *postgresBroadcastRepository) UpdateDelivery(ctx context.Context, d *domain_broadcast.Delivery) error {
+	query := `UPDATE event_broadcast_deliveries SET status = $3, channel = $4, error = $5, updated_at = $6 WHERE broadcast_id = $1 AND user_id = $2`
+	result, err := r.db.ExecContext(ctx, query, d.BroadcastID, d.UserID, d.Status, d.Channel, d.Error, d.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresBroadcastRepository) Complete(ctx context.Context, b *domain_broadcast.Broadcast) error {
+	query := `UPDATE event_broadcasts SET completed_at = $2 WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, b.ID, b.CompletedAt)
+	return err
+}
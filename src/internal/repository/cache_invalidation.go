@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheInvalidationKind names what a cache invalidation covers
+type CacheInvalidationKind string
+
+const (
+	// CacheInvalidateEvents covers the tenant's cached events and listing
+	CacheInvalidateEvents CacheInvalidationKind = "events"
+	// CacheInvalidateUser covers one user
+	CacheInvalidateUser CacheInvalidationKind = "user"
+	// CacheInvalidateAll covers everything in every tenant. Subscribers
+	// receive it whenever they may have missed messages.
+	CacheInvalidateAll CacheInvalidationKind = "all"
+)
+
+// CacheInvalidation tells every instance that cached data changed. The
+// tenant travels in the context on both ends.
+type CacheInvalidation struct {
+	Kind CacheInvalidationKind
+	ID   uuid.UUID // the changed user; unset for other kinds
+}
+
+// Redis Cache Invalidation Repository
+// Invalidations for every tenant share one pub/sub channel. Pub/sub keeps
+// nothing for subscribers that are not listening, so each (re)subscription
+// starts with a CacheInvalidateAll.
+type redisCacheInvalidationRepository struct {
+	client *redis.Client
+}
+
+const cacheInvalidationChannel = "cache:invalidations"
+
+type cacheInvalidationMessage struct {
+	Tenant string                `json:"tenant,omitempty"`
+	Kind   CacheInvalidationKind `json:"kind"`
+	ID     uuid.UUID             `json:"id,omitempty"`
+}
+
+func (r *redisCacheInvalidationRepository) Publish(ctx context.Context, inv CacheInvalidation) error {
+	payload, err := json.Marshal(cacheInvalidationMessage{Tenant: tenant.FromContext(ctx), Kind: inv.Kind, ID: inv.ID})
+	if err != nil {
+		return err
+	}
+	return skipUnavailable(r.client.Publish(ctx, cacheInvalidationChannel, payload).Err())
+}
+
+// Subscribe passes invalidations to handle until ctx is cancelled, when it
+// returns nil, or the connection fails
+func (r *redisCacheInvalidationRepository) Subscribe(ctx context.Context, handle func(ctx context.Context, inv CacheInvalidation)) error {
+	pubsub := r.client.Subscribe(ctx, cacheInvalidationChannel)
+	defer pubsub.Close()
+
+	for {
+		received, err := pubsub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		switch msg := received.(type) {
+		case *redis.Subscription:
+			handle(ctx, CacheInvalidation{Kind: CacheInvalidateAll})
+		case *redis.Message:
+			var m cacheInvalidationMessage
+			if err := json.Unmarshal([]byte(msg.Payload), &m); err != nil {
+				// Sent by a newer version; drop everything to be safe
+				handle(ctx, CacheInvalidation{Kind: CacheInvalidateAll})
+				continue
+			}
+			handle(tenant.WithID(ctx, m.Tenant), CacheInvalidation{Kind: m.Kind, ID: m.ID})
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"math/rand"
+	"time"
+)
+
+// CacheTTLs sets how long each kind of cached entity is kept. A zero TTL
+// disables caching that entity: nothing is written and every lookup
+// misses, so reads go to the database.
+type CacheTTLs struct {
+	User      time.Duration // a user and their email index
+	Event     time.Duration // a single event
+	EventList time.Duration // the full event listing
+	Jitter    float64       // share of each TTL added or taken away at random, 0 to 1
+}
+
+// DefaultCacheTTLs returns the TTLs used unless configured otherwise
+func DefaultCacheTTLs() CacheTTLs {
+	return CacheTTLs{
+		User:      time.Hour,
+		Event:     2 * time.Hour,
+		EventList: time.Hour,
+		Jitter:    0.1,
+	}
+}
+
+// expiry returns ttl moved by up to Jitter of itself either way, so
+// entries cached together do not all expire together
+func (t CacheTTLs) expiry(ttl time.Duration) time.Duration {
+	spread := time.Duration(float64(ttl) * t.Jitter)
+	if spread <= 0 {
+		return ttl
+	}
+	return ttl - spread + time.Duration(rand.Int63n(int64(2*spread)+1))
+}
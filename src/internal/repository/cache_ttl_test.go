@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+
+	"github.com/google/uuid"
+)
+
+func TestCacheTTLJitterStaysInRange(t *testing.T) {
+	ttls := CacheTTLs{Jitter: 0.1}
+	for i := 0; i < 1000; i++ {
+		if got := ttls.expiry(time.Hour); got < 54*time.Minute || got > 66*time.Minute {
+			t.Fatalf("expiry of 1h with 10%% jitter is %v", got)
+		}
+	}
+	if got := (CacheTTLs{}).expiry(time.Hour); got != time.Hour {
+		t.Fatalf("expiry without jitter is %v, want 1h", got)
+	}
+}
+
+func TestDisabledCacheEntityAlwaysMisses(t *testing.T) {
+	ttls := DefaultCacheTTLs()
+	ttls.Event = 0
+	repos := NewMemoryRepositoryContainer(nil, ttls)
+	ctx := context.Background()
+
+	evt := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam"}
+	if err := repos.EventCache.Set(ctx, 0, evt); err != nil {
+		t.Fatalf("cache event: %v", err)
+	}
+	if _, err := repos.EventCache.GetByID(ctx, 0, evt.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("lookup of a disabled entity got %v, want a miss", err)
+	}
+
+	// The listing is cached on its own TTL
+	if err := repos.EventCache.SetAllEvents(ctx, 0, []*domain_event.Event{evt}); err != nil {
+		t.Fatalf("cache listing: %v", err)
+	}
+	if events, err := repos.EventCache.GetAll(ctx, 0); err != nil || len(events) != 1 {
+		t.Fatalf("listing lookup got %d events, err %v; want the cached event", len(events), err)
+	}
+}
@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_migration "github.com/ojaswiii/booking-manager/src/internal/domain/migration"
+)
+
+// columnChange replaces a column with a new one of another type, without
+// taking writes offline. Repositories read and write through a columnSwitch,
+// which follows the migration's configured phase.
+type columnChange struct {
+	name      string
+	table     string
+	oldColumn string
+	newColumn string
+	readNew   string                            // SQL reading the new column as the old type
+	fillNew   string                            // SQL computing the new column from the old one
+	toNew     func(old interface{}) interface{} // the same conversion in Go, for writes
+}
+
+// Ticket prices move from DECIMAL to integer cents
+var ticketPriceCents = &columnChange{
+	name:      "ticket_price_cents",
+	table:     "tickets",
+	oldColumn: "price",
+	newColumn: "price_cents",
+	readNew:   "price_cents / 100.0",
+	fillNew:   "ROUND(price * 100)",
+	toNew: func(old interface{}) interface{} {
+		return int64(math.Round(old.(float64) * 100))
+	},
+}
+
+// columnChanges lists every column migration the repositories know about
+var columnChanges = map[string]*columnChange{
+	ticketPriceCents.name: ticketPriceCents,
+}
+
+// ColumnMigrations holds the configured phase of each column migration.
+// Migrations that are not configured stay in the old phase.
+type ColumnMigrations map[string]domain_migration.Phase
+
+// ParseColumnMigrations validates configured migration phases
+func ParseColumnMigrations(phases map[string]string) (ColumnMigrations, error) {
+	migrations := make(ColumnMigrations, len(phases))
+	for name, value := range phases {
+		if _, ok := columnChanges[name]; !ok {
+			return nil, fmt.Errorf("unknown column migration %q", name)
+		}
+		phase := domain_migration.Phase(value)
+		if !phase.IsValid() {
+			return nil, fmt.Errorf("unknown phase %q for column migration %q", value, name)
+		}
+		migrations[name] = phase
+	}
+	return migrations, nil
+}
+
+func (m ColumnMigrations) phase(name string) domain_migration.Phase {
+	if phase, ok := m[name]; ok {
+		return phase
+	}
+	return domain_migration.PhaseOld
+}
+
+func (m ColumnMigrations) column(change *columnChange) columnSwitch {
+	return columnSwitch{change: change, phase: m.phase(change.name)}
+}
+
+// columnSwitch is a column migration at its configured phase
+type columnSwitch struct {
+	change *columnChange
+	phase  domain_migration.Phase
+}
+
+// selectExpr is the select list entry for the column, named after the old
+// column so rows scan into the same struct field in every phase
+func (c columnSwitch) selectExpr() string {
+	switch c.phase {
+	case domain_migration.PhaseNew:
+		return c.change.readNew + " AS " + c.change.oldColumn
+	case domain_migration.PhaseDualRead:
+		// Rows the backfill has not reached yet only have the old column
+		return fmt.Sprintf("COALESCE(%s, %s) AS %s", c.change.readNew, c.change.oldColumn, c.change.oldColumn)
+	}
+	return c.change.oldColumn
+}
+
+// writes returns the columns to write a value to, and the values for them
+func (c columnSwitch) writes(value interface{}) ([]string, []interface{}) {
+	var columns []string
+	var values []interface{}
+	if c.phase.WritesOld() {
+		columns = append(columns, c.change.oldColumn)
+		values = append(values, value)
+	}
+	if c.phase.WritesNew() {
+		columns = append(columns, c.change.newColumn)
+		values = append(values, c.change.toNew(value))
+	}
+	return columns, values
+}
+
+// PostgreSQL Column Migration Repository
+type postgresColumnMigrationRepository struct {
+	db         *tenantDB
+	migrations ColumnMigrations
+}
+
+func (r *postgresColumnMigrationRepository) List(ctx context.Context) ([]*domain_migration.ColumnMigration, error) {
+	names := make([]string, 0, len(columnChanges))
+	for name := range columnChanges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	migrations := make([]*domain_migration.ColumnMigration, len(names))
+	for i, name := range names {
+		migration, err := r.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		migrations[i] = migration
+	}
+	return migrations, nil
+}
+
+func (r *postgresColumnMigrationRepository) Get(ctx context.Context, name string) (*domain_migration.ColumnMigration, error) {
+	change, ok := columnChanges[name]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+
+	// Rows with neither column set have nothing to convert
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s IS NULL AND %s IS NOT NULL`, change.table, change.newColumn, change.oldColumn)
+	var pending int64
+	if err := r.db.GetContext(ctx, &pending, query); err != nil {
+		return nil, err
+	}
+
+	return &domain_migration.ColumnMigration{
+		Name:      change.name,
+		Table:     change.table,
+		OldColumn: change.oldColumn,
+		NewColumn: change.newColumn,
+		Phase:     r.migrations.phase(name),
+		Pending:   pending,
+	}, nil
+}
+
+func (r *postgresColumnMigrationRepository) Backfill(ctx context.Context, name string, limit int) (int, error) {
+	change, ok := columnChanges[name]
+	if !ok {
+		return 0, domain.ErrNotFound
+	}
+
+	// SKIP LOCKED keeps the backfill out of the way of booking writes
+	query := fmt.Sprintf(`
+		UPDATE %[1]s SET %[2]s = %[3]s
+		WHERE id IN (SELECT id FROM %[1]s WHERE %[2]s IS NULL AND %[4]s IS NOT NULL LIMIT $1 FOR UPDATE SKIP LOCKED)`,
+		change.table, change.newColumn, change.fillNew, change.oldColumn)
+	result, err := r.db.ExecContext(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"reflect"
+	"testing"
+
+	domain_migration "github.com/ojaswiii/booking-manager/src/internal/domain/migration"
+)
+
+func TestColumnSwitch(t *testing.T) {
+	tests := []struct {
+		phase       domain_migration.Phase
+		wantSelect  string
+		wantColumns []string
+		wantValues  []interface{}
+	}{
+		{domain_migration.PhaseOld, "price", []string{"price"}, []interface{}{12.34}},
+		{domain_migration.PhaseDualWrite, "price", []string{"price", "price_cents"}, []interface{}{12.34, int64(1234)}},
+		{domain_migration.PhaseDualRead, "COALESCE(price_cents / 100.0, price) AS price", []string{"price", "price_cents"}, []interface{}{12.34, int64(1234)}},
+		{domain_migration.PhaseNew, "price_cents / 100.0 AS price", []string{"price_cents"}, []interface{}{int64(1234)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.phase), func(t *testing.T) {
+			column := ColumnMigrations{ticketPriceCents.name: tt.phase}.column(ticketPriceCents)
+			if got := column.selectExpr(); got != tt.wantSelect {
+				t.Errorf("selectExpr() = %q, want %q", got, tt.wantSelect)
+			}
+			columns, values := column.writes(12.34)
+			if !reflect.DeepEqual(columns, tt.wantColumns) || !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("writes() = %v %v, want %v %v", columns, values, tt.wantColumns, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestParseColumnMigrations(t *testing.T) {
+	migrations, err := ParseColumnMigrations(map[string]string{"ticket_price_cents": "dual_read"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := migrations.phase("ticket_price_cents"); got != domain_migration.PhaseDualRead {
+		t.Errorf("phase = %q, want dual_read", got)
+	}
+	if got := (ColumnMigrations{}).phase("ticket_price_cents"); got != domain_migration.PhaseOld {
+		t.Errorf("unconfigured phase = %q, want old", got)
+	}
+
+	for _, phases := range []map[string]string{
+		{"ticket_price": "dual_write"},
+		{"ticket_price_cents": "both"},
+	} {
+		if _, err := ParseColumnMigrations(phases); err == nil {
+			t.Errorf("ParseColumnMigrations(%v) succeeded, want error", phases)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// pqUniqueViolation is Postgres's error code for a row clashing with a
+// unique constraint or index
+const pqUniqueViolation = "23505"
+
+// mysqlDuplicateEntry is MySQL's error number for the same
+const mysqlDuplicateEntry = 1062
+
+// isUniqueViolation reports whether err is a write rejected by a unique
+// constraint or index, from any of the database drivers
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqUniqueViolation
+	}
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDuplicateEntry
+	}
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique
+	}
+	return false
+}
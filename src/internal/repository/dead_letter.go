@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_deadletter "github.com/ojaswiii/booking-manager/src/internal/domain/deadletter"
+
+	"github.com/google/uuid"
+)
+
+const deadLetterColumns = `id, request_id, user_id, event_id, request, reason, attempts, created_at`
+
+// PostgreSQL Dead Letter Repository
+type postgresDeadLetterRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresDeadLetterRepository) Create(ctx context.Context, letter *domain_deadletter.DeadLetter) error {
+	query := `INSERT INTO booking_dead_letters (` + deadLetterColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.ExecContext(ctx, query, letter.ID, letter.RequestID, letter.UserID, letter.EventID, string(letter.Request), letter.Reason, letter.Attempts, letter.CreatedAt)
+	return err
+}
+
+func (r *postgresDeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_deadletter.DeadLetter, error) {
+	query := `SELECT ` + deadLetterColumns + ` FROM booking_dead_letters WHERE id = $1`
+	var letter domain_deadletter.DeadLetter
+	err := r.db.GetContext(ctx, &letter, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &letter, nil
+}
+
+func (r *postgresDeadLetterRepository) List(ctx context.Context, limit int) ([]*domain_deadletter.DeadLetter, error) {
+	query := `SELECT ` + deadLetterColumns + ` FROM booking_dead_letters ORDER BY created_at DESC LIMIT $1`
+	var letters []*domain_deadletter.DeadLetter
+	if err := r.db.SelectContext(ctx, &letters, query, limit); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+func (r *postgresDeadLetterRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM booking_dead_letters WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	"github.com/ojaswiii/booking-manager/src/utils/querybuilder"
+)
+
+// eventColumns lists the columns scanned into domain_event.Event
+var eventColumns = []string{
+	"id", "name", "artist", "venue", "date", "total_seats", "price", "status",
+	"sales_start_at", "sales_end_at", "presale_start_at", "confirmation_requirements",
+	"waiting_room_enabled", "created_at", "updated_at",
+}
+
+// eventSortColumns maps the sort keys clients may use to column names
+var eventSortColumns = map[string]string{
+	"date":       "date",
+	"name":       "name",
+	"price":      "price",
+	"created_at": "created_at",
+}
+
+// maxEventSearchLimit caps the page size of a single search
+const maxEventSearchLimit = 100
+
+func (r *postgresEventRepository) Search(ctx context.Context, filter domain_event.EventFilter) ([]*domain_event.Event, error) {
+	query, args, err := buildEventSearchQuery(filter, querybuilder.Postgres)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*domain_event.Event
+	if err := r.db.readSelect(ctx, &events, query, args...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// buildEventSearchQuery translates a filter into a parameterized SELECT in
+// the given dialect
+func buildEventSearchQuery(filter domain_event.EventFilter, dialect querybuilder.Dialect) (string, []interface{}, error) {
+	q := querybuilder.Select(eventColumns...).From("events").In(dialect)
+	q.Where("deleted_at IS NULL")
+
+	if filter.Query != "" {
+		pattern := "%" + escapeLike(filter.Query) + "%"
+		// MySQL's LIKE already ignores case under the schema's collation
+		like := "ILIKE"
+		if dialect == querybuilder.MySQL {
+			like = "LIKE"
+		}
+		q.Where(fmt.Sprintf("name %[1]s ? OR artist %[1]s ? OR venue %[1]s ?", like), pattern, pattern, pattern)
+	}
+	if filter.Artist != "" {
+		q.Where("artist = ?", filter.Artist)
+	}
+	if filter.Venue != "" {
+		q.Where("venue = ?", filter.Venue)
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]interface{}, len(filter.Statuses))
+		for i, status := range filter.Statuses {
+			if !status.IsValid() {
+				return "", nil, fmt.Errorf("%w: unknown event status %q", domain.ErrInvalidInput, status)
+			}
+			statuses[i] = string(status)
+		}
+		q.WhereIn("status", statuses)
+	}
+	if filter.DateFrom != nil {
+		q.Where("date >= ?", *filter.DateFrom)
+	}
+	if filter.DateTo != nil {
+		q.Where("date <= ?", *filter.DateTo)
+	}
+	if filter.MinPrice != nil {
+		q.Where("price >= ?", *filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		q.Where("price <= ?", *filter.MaxPrice)
+	}
+
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = "date"
+	}
+	column, ok := eventSortColumns[sortBy]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: cannot sort by %q", domain.ErrInvalidInput, filter.SortBy)
+	}
+	direction, err := querybuilder.ParseSortDirection(filter.SortDir)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	// Tie-break on id so paging is stable
+	q.OrderBy(column, direction).OrderBy("id", querybuilder.Asc)
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return "", nil, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrInvalidInput)
+	}
+	limit := filter.Limit
+	if limit == 0 || limit > maxEventSearchLimit {
+		limit = maxEventSearchLimit
+	}
+	q.Limit(uint64(limit))
+	if filter.Offset > 0 {
+		q.Offset(uint64(filter.Offset))
+	}
+
+	return q.ToSQL()
+}
+
+// escapeLike escapes LIKE wildcards so user input matches literally
+func escapeLike(s string) string {
+	out := make([]rune, 0, len(s))
+	for _, r := range s {
+		if r == '\\' || r == '%' || r == '_' {
+			out = append(out, '\\')
+		}
+		out = append(out, r)
+	}
+	return string(out)
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	"github.com/ojaswiii/booking-manager/src/utils/querybuilder"
+)
+
+const eventSelect = "SELECT id, name, artist, venue, date, total_seats, price, status, sales_start_at, sales_end_at, presale_start_at, confirmation_requirements, waiting_room_enabled, created_at, updated_at FROM events"
+
+// liveEvents is the condition every search starts with
+const liveEvents = "(deleted_at IS NULL)"
+
+func TestBuildEventSearchQuery(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	minPrice, maxPrice := 10.0, 99.5
+
+	tests := []struct {
+		name     string
+		filter   domain_event.EventFilter
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "defaults",
+			filter:   domain_event.EventFilter{},
+			wantSQL:  eventSelect + " WHERE deleted_at IS NULL ORDER BY date ASC, id ASC LIMIT $1",
+			wantArgs: []interface{}{uint64(100)},
+		},
+		{
+			name:     "free text search escapes wildcards",
+			filter:   domain_event.EventFilter{Query: "50%_off"},
+			wantSQL:  eventSelect + " WHERE " + liveEvents + " AND (name ILIKE $1 OR artist ILIKE $2 OR venue ILIKE $3) ORDER BY date ASC, id ASC LIMIT $4",
+			wantArgs: []interface{}{`%50\%\_off%`, `%50\%\_off%`, `%50\%\_off%`, uint64(100)},
+		},
+		{
+			name: "every filter",
+			filter: domain_event.EventFilter{
+				Query:    "rock",
+				Artist:   "Muse",
+				Venue:    "O2",
+				Statuses: []domain_event.EventStatus{domain_event.EventStatusPublished, domain_event.EventStatusArchived},
+				DateFrom: &from,
+				DateTo:   &to,
+				MinPrice: &minPrice,
+				MaxPrice: &maxPrice,
+				SortBy:   "price",
+				SortDir:  "desc",
+				Limit:    20,
+				Offset:   40,
+			},
+			wantSQL: eventSelect + " WHERE " + liveEvents + " AND (name ILIKE $1 OR artist ILIKE $2 OR venue ILIKE $3) AND (artist = $4) AND (venue = $5)" +
+				" AND (status IN ($6, $7)) AND (date >= $8) AND (date <= $9) AND (price >= $10) AND (price <= $11)" +
+				" ORDER BY price DESC, id ASC LIMIT $12 OFFSET $13",
+			wantArgs: []interface{}{"%rock%", "%rock%", "%rock%", "Muse", "O2", "published", "archived", from, to, minPrice, maxPrice, uint64(20), uint64(40)},
+		},
+		{
+			name:     "limit is capped",
+			filter:   domain_event.EventFilter{Limit: 5000},
+			wantSQL:  eventSelect + " WHERE deleted_at IS NULL ORDER BY date ASC, id ASC LIMIT $1",
+			wantArgs: []interface{}{uint64(100)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := buildEventSearchQuery(tt.filter, querybuilder.Postgres)
+			if err != nil {
+				t.Fatalf("buildEventSearchQuery() error = %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("sql = %q\nwant  %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+// TestBuildEventSearchQueryCombinations checks that every combination of
+// filters binds each value exactly once and never inlines it into the SQL.
+func TestBuildEventSearchQueryCombinations(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+	minPrice, maxPrice := 10.0, 99.5
+
+	setters := []struct {
+		clause string
+		args   int
+		apply  func(*domain_event.EventFilter)
+	}{
+		{"ILIKE", 3, func(f *domain_event.EventFilter) { f.Query = "x'; DROP TABLE events; --" }},
+		{"artist =", 1, func(f *domain_event.EventFilter) { f.Artist = "Muse" }},
+		{"venue =", 1, func(f *domain_event.EventFilter) { f.Venue = "O2" }},
+		{"status IN", 1, func(f *domain_event.EventFilter) {
+			f.Statuses = []domain_event.EventStatus{domain_event.EventStatusPublished}
+		}},
+		{"date >=", 1, func(f *domain_event.EventFilter) { f.DateFrom = &from }},
+		{"date <=", 1, func(f *domain_event.EventFilter) { f.DateTo = &to }},
+		{"price >=", 1, func(f *domain_event.EventFilter) { f.MinPrice = &minPrice }},
+		{"price <=", 1, func(f *domain_event.EventFilter) { f.MaxPrice = &maxPrice }},
+		{"OFFSET", 1, func(f *domain_event.EventFilter) { f.Offset = 10 }},
+	}
+
+	for mask := 0; mask < 1<<len(setters); mask++ {
+		var filter domain_event.EventFilter
+		wantArgs := 1 // LIMIT is always bound
+		var clauses []string
+		for i, s := range setters {
+			if mask&(1<<i) != 0 {
+				s.apply(&filter)
+				wantArgs += s.args
+				clauses = append(clauses, s.clause)
+			}
+		}
+
+		sql, args, err := buildEventSearchQuery(filter, querybuilder.Postgres)
+		if err != nil {
+			t.Fatalf("mask %b: error = %v", mask, err)
+		}
+		if len(args) != wantArgs {
+			t.Errorf("mask %b: got %d args, want %d", mask, len(args), wantArgs)
+		}
+		if strings.Contains(sql, "DROP TABLE") || strings.Contains(sql, "Muse") {
+			t.Errorf("mask %b: value inlined into sql %q", mask, sql)
+		}
+		if !strings.Contains(sql, fmt.Sprintf("$%d", len(args))) || strings.Contains(sql, fmt.Sprintf("$%d", len(args)+1)) {
+			t.Errorf("mask %b: placeholders do not match %d args in %q", mask, len(args), sql)
+		}
+		for _, clause := range clauses {
+			if !strings.Contains(sql, clause) {
+				t.Errorf("mask %b: missing %q in %q", mask, clause, sql)
+			}
+		}
+	}
+}
+
+func TestBuildEventSearchQueryRejectsBadInput(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter domain_event.EventFilter
+	}{
+		{"unknown sort column", domain_event.EventFilter{SortBy: "id; DROP TABLE events"}},
+		{"unknown sort direction", domain_event.EventFilter{SortDir: "sideways"}},
+		{"unknown status", domain_event.EventFilter{Statuses: []domain_event.EventStatus{"deleted"}}},
+		{"negative limit", domain_event.EventFilter{Limit: -1}},
+		{"negative offset", domain_event.EventFilter{Offset: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := buildEventSearchQuery(tt.filter, querybuilder.Postgres); !errors.Is(err, domain.ErrInvalidInput) {
+				t.Errorf("error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// PostgreSQL Event Stats Repository
+// Counts bookings from scratch. Refunded and returned bookings were
+// confirmed first, so they count as confirmed and towards revenue, and their
+// line items as tickets sold.
+type postgresEventStatsRepository struct {
+	db *tenantDB
+}
+
+const eventStatsQuery = `
+	SELECT e.id AS event_id,
+		COUNT(b.id) AS created,
+		COUNT(b.id) FILTER (WHERE b.status IN ('confirmed', 'refunded', 'returned')) AS confirmed,
+		COUNT(b.id) FILTER (WHERE b.status = 'cancelled') AS cancelled,
+		CAST(COALESCE(SUM(ROUND(b.total_amount / COALESCE(b.exchange_rate, 1) * 100))
+			FILTER (WHERE b.status IN ('confirmed', 'refunded', 'returned')), 0) AS BIGINT) AS revenue_cents,
+		(SELECT COUNT(*) FROM booking_items bi JOIN bookings sb ON sb.id = bi.booking_id
+			WHERE sb.event_id = e.id AND sb.status IN ('confirmed', 'refunded', 'returned')) AS tickets_sold,
+		(SELECT COUNT(*) FROM ticket_returns tr WHERE tr.event_id = e.id) AS tickets_returned
+	FROM events e
+	LEFT JOIN bookings b ON b.event_id = e.id`
+
+func (r *postgresEventStatsRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error) {
+	query := eventStatsQuery + ` WHERE e.id = $1 AND e.deleted_at IS NULL GROUP BY e.id`
+	var stats []*domain_stats.EventStats
+	if err := r.db.SelectContext(ctx, &stats, query, eventID); err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return stats[0], nil
+}
+
+func (r *postgresEventStatsRepository) GetForEventsFrom(ctx context.Context, from time.Time) ([]*domain_stats.EventStats, error) {
+	query := eventStatsQuery + ` WHERE e.date >= $1 AND e.deleted_at IS NULL GROUP BY e.id`
+	var stats []*domain_stats.EventStats
+	if err := r.db.SelectContext(ctx, &stats, query, from); err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+// Redis Event Stats Repository
+// Each event's counters are a hash. Increments only apply to a hash that
+// exists, so counters are always seeded from Postgres first and a missing
+// hash is never mistaken for an event without bookings.
+type redisEventStatsRepository struct {
+	client *redis.Client
+}
+
+func eventStatsKey(ctx context.Context, eventID uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("eventstats:%s", eventID.String()))
+}
+
+var addEventStats = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+for i = 1, #ARGV, 2 do
+	redis.call('HINCRBY', KEYS[1], ARGV[i], ARGV[i + 1])
+end
+return 1`)
+
+func (r *redisEventStatsRepository) Get(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error) {
+	fields, err := r.client.HGetAll(ctx, eventStatsKey(ctx, eventID)).Result()
+	if errors.Is(err, database.ErrRedisUnavailable) {
+		// Counted from the database instead
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	counter := func(name string) int64 {
+		n, _ := strconv.ParseInt(fields[name], 10, 64)
+		return n
+	}
+	return &domain_stats.EventStats{
+		EventID:      eventID,
+		Created:      counter("created"),
+		Confirmed:    counter("confirmed"),
+		Cancelled:    counter("cancelled"),
+		RevenueCents: counter("revenue_cents"),
+
+		TicketsSold:     counter("tickets_sold"),
+		TicketsReturned: counter("tickets_returned"),
+
+		ReconciledAt: time.Unix(counter("reconciled_at"), 0).UTC(),
+	}, nil
+}
+
+func (r *redisEventStatsRepository) Set(ctx context.Context, stats *domain_stats.EventStats) error {
+	return skipUnavailable(r.client.HSet(ctx, eventStatsKey(ctx, stats.EventID),
+		"created", stats.Created,
+		"confirmed", stats.Confirmed,
+		"cancelled", stats.Cancelled,
+		"revenue_cents", stats.RevenueCents,
+		"tickets_sold", stats.TicketsSold,
+		"tickets_returned", stats.TicketsReturned,
+		"reconciled_at", stats.ReconciledAt.Unix(),
+	).Err())
+}
+
+func (r *redisEventStatsRepository) Add(ctx context.Context, eventID uuid.UUID, delta domain_stats.Delta) error {
+	var args []interface{}
+	for _, counter := range []struct {
+		name  string
+		value int64
+	}{
+		{"created", delta.Created},
+		{"confirmed", delta.Confirmed},
+		{"cancelled", delta.Cancelled},
+		{"revenue_cents", delta.RevenueCents},
+		{"tickets_sold", delta.TicketsSold},
+		{"tickets_returned", delta.TicketsReturned},
+	} {
+		if counter.value != 0 {
+			args = append(args, counter.name, counter.value)
+		}
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	return skipUnavailable(addEventStats.Run(ctx, r.client, []string{eventStatsKey(ctx, eventID)}, args...).Err())
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const holdColumns = `id, event_id, kind, label, from_seat, to_seat, seats, status, created_at, released_at`
+
+// PostgreSQL Seat Hold Repository
+type postgresHoldRepository struct {
+	db *tenantDB
+}
+
+// Create records the hold and takes its seats off sale. Every seat in the
+// range must be available; otherwise nothing is held and domain.ErrConflict
+// is returned.
+func (r *postgresHoldRepository) Create(ctx context.Context, h *domain_hold.Hold) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		var total int
+		countQuery := `SELECT COUNT(*) FROM tickets WHERE event_id = $1 AND seat_number BETWEEN $2 AND $3`
+		if err := tx.GetContext(ctx, &total, countQuery, h.EventID, h.FromSeat, h.ToSeat); err != nil {
+			return err
+		}
+		if total == 0 {
+			return fmt.Errorf("%w: no seats between %d and %d", domain.ErrInvalidInput, h.FromSeat, h.ToSeat)
+		}
+
+		h.Seats = total
+		insertQuery := `INSERT INTO seat_holds (` + holdColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		if _, err := tx.ExecContext(ctx, insertQuery, h.ID, h.EventID, h.Kind, h.Label, h.FromSeat, h.ToSeat, h.Seats, h.Status, h.CreatedAt, h.ReleasedAt); err != nil {
+			return err
+		}
+
+		holdQuery := `UPDATE tickets SET status = 'held', hold_id = $1, updated_at = NOW() WHERE event_id = $2 AND seat_number BETWEEN $3 AND $4 AND status = 'available'`
+		result, err := tx.ExecContext(ctx, holdQuery, h.ID, h.EventID, h.FromSeat, h.ToSeat)
+		if err != nil {
+			return err
+		}
+		held, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if int(held) != total {
+			return fmt.Errorf("%w: %d of %d seats are not available", domain.ErrConflict, total-int(held), total)
+		}
+		return nil
+	})
+}
+
+func (r *postgresHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_hold.Hold, error) {
+	query := `SELECT ` + holdColumns + ` FROM seat_holds WHERE id = $1`
+	var h domain_hold.Hold
+	err := r.db.GetContext(ctx, &h, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *postgresHoldRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_hold.Hold, error) {
+	query := `SELECT ` + holdColumns + ` FROM seat_holds WHERE event_id = $1 ORDER BY from_seat ASC, created_at ASC`
+	var holds []*domain_hold.Hold
+	err := r.db.SelectContext(ctx, &holds, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// Release ends an active hold and puts its seats back on sale, returning how
+// many were released. Releasing a hold twice returns domain.ErrConflict.
+func (r *postgresHoldRepository) Release(ctx context.Context, id uuid.UUID, at time.Time) (int, error) {
+	var released int64
+	err := r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		result, err := tx.ExecContext(ctx, `UPDATE seat_holds SET status = 'released', released_at = $2 WHERE id = $1 AND status = 'active'`, id, at)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return domain.ErrConflict
+		}
+
+		result, err = tx.ExecContext(ctx, `UPDATE tickets SET status = 'available', hold_id = NULL, updated_at = NOW() WHERE hold_id = $1 AND status = 'held'`, id)
+		if err != nil {
+			return err
+		}
+		released, err = result.RowsAffected()
+		return err
+	})
+	return int(released), err
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+)
+
+const identityColumns = `user_id, provider, subject, email, created_at`
+
+// PostgreSQL Identity Repository
+type postgresIdentityRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresIdentityRepository) Create(ctx context.Context, identity *domain_user.Identity) error {
+	query := `INSERT INTO user_identities (` + identityColumns + `) VALUES ($1, $2, $3, $4, $5)`
+	_, err := r.db.ExecContext(ctx, query,
+		identity.UserID, identity.Provider, identity.Subject, identity.Email, identity.CreatedAt)
+	if isUniqueViolation(err) {
+		return domain_user.ErrIdentityLinked
+	}
+	return err
+}
+
+func (r *postgresIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain_user.Identity, error) {
+	query := `SELECT ` + identityColumns + ` FROM user_identities WHERE provider = $1 AND subject = $2`
+	var identity domain_user.Identity
+	if err := r.db.GetContext(ctx, &identity, query, provider, subject); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &identity, nil
+}
@@ -4,17 +4,37 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
 	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_deadletter "github.com/ojaswiii/booking-manager/src/internal/domain/deadletter"
 	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	domain_migration "github.com/ojaswiii/booking-manager/src/internal/domain/migration"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
+	domain_presale "github.com/ojaswiii/booking-manager/src/internal/domain/presale"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
 	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
 	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -24,32 +44,128 @@ type RepositoryContainer struct {
 	Event   EventRepository
 	Ticket  TicketRepository
 	Booking BookingRepository
+	Presale PresaleCodeRepository
+	Webhook WebhookRepository
+	Outbox  OutboxRepository
+
+	Notification NotificationRepository
+	Template     TemplateRepository
+	TicketPass   TicketPassRepository
+	Hold         HoldRepository
+	Job          JobRepository
+	Refund       RefundRepository
+	Broadcast    BroadcastRepository
+	EventStats   EventStatsRepository
+	SalesReport  SalesReportRepository
+	DeadLetter   DeadLetterRepository
+
+	// Booked tickets with their users and events, for CSV exports
+	BookingExport BookingExportRepository
+
+	// Actions recorded for accountability, such as erasing a user
+	Audit AuditRepository
+	// Every status change of bookings and their tickets
+	BookingTransition BookingTransitionRepository
+	BookingEvent      BookingEventRepository
+
+	// Denormalized rows for hot lists and dashboards, kept from BookingEvent
+	ReadModel ReadModelRepository
+
+	// In-flight column migrations and their backfills
+	ColumnMigration ColumnMigrationRepository
+
+	// Runs several repository writes in one transaction
+	Transactor Transactor
+
+	// Per-operation latency of the statements above
+	Queries QueryStats
 
 	// Cache repositories
-	UserCache  UserCacheRepository
-	EventCache EventCacheRepository
+	UserCache       UserCacheRepository
+	EventCache      EventCacheRepository
+	BookingCache    BookingCacheRepository
+	EventStatsCache EventStatsCacheRepository
+	Availability    AvailabilityCacheRepository
+
+	// Redis-backed coordination
+	WaitingRoom    WaitingRoomRepository
+	SeatSuggestion SeatSuggestionRepository
+	BookingQueue   BookingQueueRepository
+	Lease          LeaseRepository
+
+	// Tells every instance about cache changes
+	CacheInvalidation CacheInvalidationRepository
+
+	// Tells every instance about seat changes, for live seat maps
+	AvailabilityFeed AvailabilityFeedRepository
+
+	// Tells users what became of their queued booking requests
+	BookingUpdates BookingUpdateRepository
+
+	// The booking processor's per-minute counts
+	BookingMetrics BookingMetricsRepository
+
+	// Signed-in clients and their refresh tokens
+	Session SessionRepository
+
+	// Accounts at external sign-in providers linked to users
+	Identity IdentityRepository
+
+	// Keys partners call the API with, and the counters limiting them and
+	// password reset requests
+	APIKey    APIKeyRepository
+	RateLimit RateLimitRepository
+
+	// Password reset tokens waiting to be used
+	PasswordReset PasswordResetRepository
 }
 
 // Repository interfaces
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// QueryStats reports the latency of the statements each repository
+// operation runs
+type QueryStats interface {
+	Stats() map[string]interface{}
+}
+
 type UserRepository interface {
 	Create(ctx context.Context, usr *domain_user.User) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain_user.User, error)
 	GetByEmail(ctx context.Context, email string) (*domain_user.User, error)
 	Update(ctx context.Context, usr *domain_user.User) error
+	// Delete soft deletes a user: every other method skips them until
+	// they are restored. Purge deletes a soft deleted user for good.
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, id uuid.UUID) error
+	// Password hashes are kept apart from the user, so they are never
+	// cached or returned with one. A user without a password has "".
+	GetPasswordHash(ctx context.Context, id uuid.UUID) (string, error)
+	SetPasswordHash(ctx context.Context, id uuid.UUID, hash string) error
+	Search(ctx context.Context, filter domain_user.UserFilter) ([]*domain_user.User, error)
 }
 
 type EventRepository interface {
 	Create(ctx context.Context, evt *domain_event.Event) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain_event.Event, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_event.Event, error)
 	GetAll(ctx context.Context) ([]*domain_event.Event, error)
 	Update(ctx context.Context, evt *domain_event.Event) error
+	// Delete soft deletes an event as UserRepository.Delete does a user
 	Delete(ctx context.Context, id uuid.UUID) error
+	Restore(ctx context.Context, id uuid.UUID) error
+	Purge(ctx context.Context, id uuid.UUID) error
+	GetSalesWindowChanges(ctx context.Context, from, to time.Time) ([]*domain_event.Event, error)
+	Search(ctx context.Context, filter domain_event.EventFilter) ([]*domain_event.Event, error)
 }
 
 type TicketRepository interface {
 	Create(ctx context.Context, tkt *domain_ticket.Ticket) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain_ticket.Ticket, error)
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_ticket.Ticket, error)
 	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error)
 	GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error)
 	Update(ctx context.Context, tkt *domain_ticket.Ticket) error
@@ -57,16 +173,186 @@ type TicketRepository interface {
 	ReserveTickets(ctx context.Context, ticketIDs []uuid.UUID) error
 	ConfirmTickets(ctx context.Context, ticketIDs []uuid.UUID) error
 	ReleaseTickets(ctx context.Context, ticketIDs []uuid.UUID) error
+	RestockTickets(ctx context.Context, ticketIDs []uuid.UUID) error
+	CountAvailable(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error)
+	CountByStatus(ctx context.Context, eventID uuid.UUID, sectionSize int) ([]*domain_ticket.StatusCount, error)
 }
 
 type BookingRepository interface {
 	Create(ctx context.Context, bk *domain_booking.Booking) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain_booking.Booking, error)
+	GetByTicketID(ctx context.Context, ticketID uuid.UUID) (*domain_booking.Booking, error)
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error)
 	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_booking.Booking, error)
 	Update(ctx context.Context, bk *domain_booking.Booking) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetExpiredBookings(ctx context.Context, before time.Time) ([]*domain_booking.Booking, error)
+	GetByStatus(ctx context.Context, status domain_booking.BookingStatus) ([]*domain_booking.Booking, error)
+	GetExpiringBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error)
+	GetConfirmedForEventsBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error)
+	GetItems(ctx context.Context, bookingID uuid.UUID) ([]domain_booking.LineItem, error)
+	SwapItems(ctx context.Context, bookingID uuid.UUID, removed []uuid.UUID, added []domain_booking.LineItem) error
+}
+
+type PresaleCodeRepository interface {
+	CreateBatch(ctx context.Context, codes []*domain_presale.PresaleCode) error
+	GetBatch(ctx context.Context, eventID, batchID uuid.UUID) ([]*domain_presale.PresaleCode, error)
+	Redeem(ctx context.Context, eventID uuid.UUID, code string, userID uuid.UUID) error
+	HasEntitlement(ctx context.Context, eventID, userID uuid.UUID) (bool, error)
+}
+
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub *domain_webhook.Subscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*domain_webhook.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]*domain_webhook.Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	CreateDeliveries(ctx context.Context, deliveries []*domain_webhook.Delivery) error
+	ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_webhook.Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain_webhook.Delivery) error
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*domain_webhook.Delivery, error)
+}
+
+type OutboxRepository interface {
+	Append(ctx context.Context, messages ...*domain_outbox.Message) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_outbox.Message, error)
+	MarkPublished(ctx context.Context, ids []uuid.UUID, at time.Time) error
+	MarkFailed(ctx context.Context, message *domain_outbox.Message) error
+	DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type NotificationRepository interface {
+	Enqueue(ctx context.Context, notifications ...*domain_notification.Notification) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_notification.Notification, error)
+	Update(ctx context.Context, notification *domain_notification.Notification) error
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*domain_notification.Preferences, error)
+	SavePreferences(ctx context.Context, preferences *domain_notification.Preferences) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_notification.Notification, error)
+	// ForgetUser deletes a user's preferences and skips their pending
+	// notifications, returning how many were skipped
+	ForgetUser(ctx context.Context, userID uuid.UUID, at time.Time) (int, error)
+}
+
+type TemplateRepository interface {
+	Create(ctx context.Context, tmpl *domain_template.EventTemplate) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain_template.EventTemplate, error)
+	GetAll(ctx context.Context) ([]*domain_template.EventTemplate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type TicketPassRepository interface {
+	Create(ctx context.Context, passes ...*domain_ticket.Pass) error
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_ticket.Pass, error)
+	GetByToken(ctx context.Context, token string) (*domain_ticket.Pass, error)
+	CheckIn(ctx context.Context, id uuid.UUID, at time.Time) error
+	CountCheckIns(ctx context.Context, eventID uuid.UUID) (*domain_ticket.CheckInCounts, error)
+	Revoke(ctx context.Context, bookingID uuid.UUID, ticketIDs []uuid.UUID) error
+}
+
+type HoldRepository interface {
+	Create(ctx context.Context, hold *domain_hold.Hold) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain_hold.Hold, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_hold.Hold, error)
+	Release(ctx context.Context, id uuid.UUID, at time.Time) (int, error)
+}
+
+type JobRepository interface {
+	Create(ctx context.Context, job *domain_job.Job) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain_job.Job, error)
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_job.Job, error)
+	Update(ctx context.Context, job *domain_job.Job) error
+}
+
+type RefundRepository interface {
+	Create(ctx context.Context, refund *domain_refund.Refund) error
+	Update(ctx context.Context, refund *domain_refund.Refund) error
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_refund.Refund, error)
+	GetPolicy(ctx context.Context, eventID uuid.UUID) (*domain_refund.Policy, error)
+	SavePolicy(ctx context.Context, policy *domain_refund.Policy) error
+	CreateReturn(ctx context.Context, ret *domain_refund.Return) error
+	GetReturnsByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_refund.Return, error)
+}
+
+type BroadcastRepository interface {
+	Create(ctx context.Context, broadcast *domain_broadcast.Broadcast) error
+	GetByID(ctx context.Context, eventID, id uuid.UUID) (*domain_broadcast.Broadcast, error)
+	GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_broadcast.Broadcast, error)
+	AddAttendees(ctx context.Context, broadcast *domain_broadcast.Broadcast) error
+	GetPendingDeliveries(ctx context.Context, broadcastID uuid.UUID, limit int) ([]*domain_broadcast.Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *domain_broadcast.Delivery) error
+	Complete(ctx context.Context, broadcast *domain_broadcast.Broadcast) error
+}
+
+type DeadLetterRepository interface {
+	Create(ctx context.Context, letter *domain_deadletter.DeadLetter) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain_deadletter.DeadLetter, error)
+	List(ctx context.Context, limit int) ([]*domain_deadletter.DeadLetter, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type AuditRepository interface {
+	Create(ctx context.Context, entry *domain_audit.Entry) error
+	// GetBySubject returns the entries about a subject, oldest first
+	GetBySubject(ctx context.Context, subjectID uuid.UUID) ([]*domain_audit.Entry, error)
+}
+
+type BookingTransitionRepository interface {
+	Append(ctx context.Context, transitions ...*domain_booking.Transition) error
+	// GetByBookingID returns a booking's transitions, oldest first
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.Transition, error)
+}
+
+type BookingEventRepository interface {
+	Append(ctx context.Context, events ...*domain_booking.BookingEvent) error
+	// GetByBookingID returns a booking's stream, oldest event first
+	GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.BookingEvent, error)
+	// ListAfter returns up to limit events of every booking stored after position, oldest first
+	ListAfter(ctx context.Context, position int64, limit int) ([]*domain_booking.BookingEvent, error)
+}
+
+type ReadModelRepository interface {
+	UpsertBookingSummary(ctx context.Context, summary *domain_readmodel.BookingSummary) error
+	DeleteBookingSummary(ctx context.Context, bookingID uuid.UUID) error
+	// GetBookingSummariesByUserID returns a user's summaries, newest booking first
+	GetBookingSummariesByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_readmodel.BookingSummary, error)
+	UpsertEventAvailability(ctx context.Context, availability *domain_readmodel.EventAvailability) error
+	DeleteEventAvailability(ctx context.Context, eventID uuid.UUID) error
+	GetEventAvailability(ctx context.Context, eventID uuid.UUID) (*domain_readmodel.EventAvailability, error)
+	// ListEventAvailability returns the rows of events taking place from from, soonest first
+	ListEventAvailability(ctx context.Context, from time.Time) ([]*domain_readmodel.EventAvailability, error)
+	GetCheckpoint(ctx context.Context, name string) (int64, error)
+	SetCheckpoint(ctx context.Context, name string, position int64) error
+}
+
+type ColumnMigrationRepository interface {
+	List(ctx context.Context) ([]*domain_migration.ColumnMigration, error)
+	Get(ctx context.Context, name string) (*domain_migration.ColumnMigration, error)
+	Backfill(ctx context.Context, name string, limit int) (int, error)
+}
+
+type EventStatsRepository interface {
+	GetByEventID(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error)
+	GetForEventsFrom(ctx context.Context, from time.Time) ([]*domain_stats.EventStats, error)
+}
+
+type SalesReportRepository interface {
+	GetForEvent(ctx context.Context, eventID uuid.UUID, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error)
+	// GetForEvents reports on the events taking place from from until to,
+	// either of which may be nil for no bound
+	GetForEvents(ctx context.Context, from, to *time.Time, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error)
+}
+
+type BookingExportRepository interface {
+	// ListAttendees returns up to limit of the tickets of the bookings the
+	// filter picks, ordered by booking time, booking and seat, starting
+	// after the row given, or from the first when it is nil
+	ListAttendees(ctx context.Context, filter domain_booking.AttendeeFilter, after *domain_booking.Attendee, limit int) ([]*domain_booking.Attendee, error)
+}
+
+type BookingMetricsRepository interface {
+	// Add adds to a minute's counts, keeping them for at least retention
+	Add(ctx context.Context, minute *domain_stats.BookingMinute, retention time.Duration) error
+	// Range returns the minutes with counts from from until to, in time order
+	Range(ctx context.Context, from, to time.Time) ([]*domain_stats.BookingMinute, error)
 }
 
 type UserCacheRepository interface {
@@ -76,35 +362,256 @@ type UserCacheRepository interface {
 	Update(ctx context.Context, usr *domain_user.User) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	SetEmailIndex(ctx context.Context, email string, userID uuid.UUID) error
+	SetNotFound(ctx context.Context, id uuid.UUID, ttl time.Duration) error
 }
 
+// EventCacheRepository caches events and the full listing under a version
+// that every mutation bumps. Readers take the version before loading from
+// the database and fill the cache under it, so a load that races a
+// mutation is stored where no later reader looks.
 type EventCacheRepository interface {
-	Create(ctx context.Context, evt *domain_event.Event) error
-	GetByID(ctx context.Context, id uuid.UUID) (*domain_event.Event, error)
-	GetAll(ctx context.Context) ([]*domain_event.Event, error)
-	Update(ctx context.Context, evt *domain_event.Event) error
-	Delete(ctx context.Context, id uuid.UUID) error
-	SetAllEvents(ctx context.Context, events []*domain_event.Event) error
+	Version(ctx context.Context) (int64, error)
+	GetByID(ctx context.Context, version int64, id uuid.UUID) (*domain_event.Event, error)
+	GetAll(ctx context.Context, version int64) ([]*domain_event.Event, error)
+	Set(ctx context.Context, version int64, evt *domain_event.Event) error
+	SetNotFound(ctx context.Context, version int64, id uuid.UUID, ttl time.Duration) error
+	SetAllEvents(ctx context.Context, version int64, events []*domain_event.Event) error
+	Invalidate(ctx context.Context) error
 }
 
-// NewRepositoryContainer creates a new repository container
-func NewRepositoryContainer(db *sqlx.DB, redisClient *redis.Client) *RepositoryContainer {
-	// Create repository implementations directly
-	userRepo := &postgresUserRepository{db: db}
-	eventRepo := &postgresEventRepository{db: db}
-	ticketRepo := &postgresTicketRepository{db: db}
-	bookingRepo := &postgresBookingRepository{db: db}
+// BookingCacheRepository remembers lookups of bookings that do not exist.
+// CheckNotFound returns ErrCachedNotFound for a remembered booking.
+type BookingCacheRepository interface {
+	CheckNotFound(ctx context.Context, id uuid.UUID) error
+	SetNotFound(ctx context.Context, id uuid.UUID, ttl time.Duration) error
+}
 
-	userCache := &redisUserRepository{client: redisClient}
-	eventCache := &redisEventRepository{client: redisClient}
+type EventStatsCacheRepository interface {
+	Get(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error)
+	Set(ctx context.Context, stats *domain_stats.EventStats) error
+	Add(ctx context.Context, eventID uuid.UUID, delta domain_stats.Delta) error
+}
+
+// AvailabilityCacheRepository keeps counters of each event's available
+// tickets, in total and per price tier. Get returns domain.ErrNotFound for
+// counters that were never set. Take reports false, changing nothing, if any
+// tier has fewer tickets left than asked for; like Restore it is a no-op for
+// counters that were never set.
+type AvailabilityCacheRepository interface {
+	Get(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error)
+	Set(ctx context.Context, availability *domain_ticket.Availability) error
+	Take(ctx context.Context, eventID uuid.UUID, tiers map[int64]int64) (bool, error)
+	Restore(ctx context.Context, eventID uuid.UUID, tiers map[int64]int64) error
+	Delete(ctx context.Context, eventID uuid.UUID) error
+}
+
+type WaitingRoomRepository interface {
+	Join(ctx context.Context, eventID, userID uuid.UUID, token string) (string, error)
+	GetToken(ctx context.Context, eventID, userID uuid.UUID) (string, error)
+	GetUserID(ctx context.Context, eventID uuid.UUID, token string) (uuid.UUID, error)
+	Position(ctx context.Context, eventID uuid.UUID, token string) (int64, error)
+	Size(ctx context.Context, eventID uuid.UUID) (int64, error)
+	Admitted(ctx context.Context, eventID uuid.UUID) (int64, error)
+	Admit(ctx context.Context, eventID uuid.UUID, count int64) (int64, error)
+	ActiveEvents(ctx context.Context) ([]uuid.UUID, error)
+	Close(ctx context.Context, eventID uuid.UUID) error
+}
+
+type SessionRepository interface {
+	// Create stores a new session until it expires
+	Create(ctx context.Context, session *domain_session.Session) error
+	// Get returns a session, or ErrNotFound once it has ended
+	Get(ctx context.Context, id uuid.UUID) (*domain_session.Session, error)
+	// Rotate stores a session whose refresh token was replaced, only if the
+	// stored token is still previousHash. It reports whether it did.
+	Rotate(ctx context.Context, session *domain_session.Session, previousHash string) (bool, error)
+	// ListByUserID returns a user's sessions, most recently used first
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_session.Session, error)
+	Delete(ctx context.Context, session *domain_session.Session) error
+	// DeleteByUserID ends every session of a user, returning how many
+	DeleteByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+type IdentityRepository interface {
+	// Create links an account to a user, or returns ErrIdentityLinked if it
+	// is linked already
+	Create(ctx context.Context, identity *domain_user.Identity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*domain_user.Identity, error)
+}
+
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *domain_apikey.APIKey) error
+	GetByID(ctx context.Context, id uuid.UUID) (*domain_apikey.APIKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*domain_apikey.APIKey, error)
+	// List returns every key, revoked ones included, newest first
+	List(ctx context.Context) ([]*domain_apikey.APIKey, error)
+	Revoke(ctx context.Context, id uuid.UUID, at time.Time) error
+	// Touch records when the key was last used
+	Touch(ctx context.Context, id uuid.UUID, at time.Time) error
+}
+
+type PasswordResetRepository interface {
+	// Create stores a reset token's hash for a user until ttl passes
+	Create(ctx context.Context, tokenHash string, userID uuid.UUID, ttl time.Duration) error
+	// Take returns the user of a reset token and deletes it, so it is used
+	// once, or returns ErrNotFound if it was used or has expired
+	Take(ctx context.Context, tokenHash string) (uuid.UUID, error)
+}
+
+type RateLimitRepository interface {
+	// Allow counts a hit against name and reports whether it is within limit
+	// hits per window, with how long until the window resets
+	Allow(ctx context.Context, name string, limit int, window time.Duration) (bool, time.Duration, error)
+}
+
+type SeatSuggestionRepository interface {
+	GetShown(ctx context.Context, eventID uuid.UUID, session string) (map[int]bool, error)
+	AddShown(ctx context.Context, eventID uuid.UUID, session string, seats []int, ttl time.Duration) error
+}
+
+type BookingQueueRepository interface {
+	Append(ctx context.Context, queue string, payload []byte) error
+	Read(ctx context.Context, queues []string, consumer string, block time.Duration) ([]QueuedMessage, error)
+	Reclaim(ctx context.Context, queue string, consumer string, minIdle time.Duration, count int) ([]QueuedMessage, error)
+	Ack(ctx context.Context, queue string, id string) error
+	Len(ctx context.Context, queue string) (int64, error)
+}
+
+type LeaseRepository interface {
+	Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, name string, holder string) error
+}
+
+// BookingUpdateRepository keeps the latest update of each queued booking
+// request and tells every instance about new ones
+type BookingUpdateRepository interface {
+	Publish(ctx context.Context, update *domain_booking.RequestUpdate) error
+	Latest(ctx context.Context, requestID string) (*domain_booking.RequestUpdate, error)
+	RequestOf(ctx context.Context, bookingID uuid.UUID) (string, error)
+	Subscribe(ctx context.Context, handle func(ctx context.Context, update *domain_booking.RequestUpdate)) error
+}
+
+type AvailabilityFeedRepository interface {
+	Publish(ctx context.Context, change *domain_ticket.AvailabilityChange) error
+	Subscribe(ctx context.Context, handle func(ctx context.Context, change *domain_ticket.AvailabilityChange)) error
+}
+
+type CacheInvalidationRepository interface {
+	Publish(ctx context.Context, inv CacheInvalidation) error
+	Subscribe(ctx context.Context, handle func(ctx context.Context, inv CacheInvalidation)) error
+}
+
+// NewRepositoryContainer creates a new repository container
+func NewRepositoryContainer(sqlDB *sqlx.DB, redisClient *redis.Client, isolation TenantIsolation, migrations ColumnMigrations, retry TxRetryPolicy, replica ReadReplica, queries QueryStats, ttls CacheTTLs) *RepositoryContainer {
+	// All Postgres repositories share a tenant-aware handle
+	db := (&tenantDB{DB: sqlDB, isolation: isolation, retry: retry}).withReplica(replica)
+
+	// Create repository implementations directly. MySQL has its own
+	// implementations of the core repositories; the rest are Postgres's.
+	var (
+		userRepo    UserRepository    = &postgresUserRepository{db: db}
+		eventRepo   EventRepository   = &postgresEventRepository{db: db}
+		ticketRepo  TicketRepository  = &postgresTicketRepository{db: db, price: migrations.column(ticketPriceCents)}
+		bookingRepo BookingRepository = &postgresBookingRepository{db: db}
+	)
+	if sqlDB.DriverName() == "mysql" {
+		userRepo = &mysqlUserRepository{db: db}
+		eventRepo = &mysqlEventRepository{db: db}
+		ticketRepo = &mysqlTicketRepository{db: db, price: migrations.column(ticketPriceCents)}
+		bookingRepo = &mysqlBookingRepository{db: db}
+	}
+	presaleRepo := &postgresPresaleCodeRepository{db: db}
+	webhookRepo := &postgresWebhookRepository{db: db}
+	outboxRepo := &postgresOutboxRepository{db: db}
+	notificationRepo := &postgresNotificationRepository{db: db}
+	templateRepo := &postgresTemplateRepository{db: db}
+	ticketPassRepo := &postgresTicketPassRepository{db: db}
+	holdRepo := &postgresHoldRepository{db: db}
+	jobRepo := &postgresJobRepository{db: db}
+	refundRepo := &postgresRefundRepository{db: db}
+	broadcastRepo := &postgresBroadcastRepository{db: db}
+	eventStatsRepo := &postgresEventStatsRepository{db: db}
+	salesReportRepo := &postgresSalesReportRepository{db: db}
+	deadLetterRepo := &postgresDeadLetterRepository{db: db}
+	bookingExportRepo := &postgresBookingExportRepository{db: db}
+	auditRepo := &postgresAuditRepository{db: db}
+	bookingTransitionRepo := &postgresBookingTransitionRepository{db: db}
+	bookingEventRepo := &postgresBookingEventRepository{db: db}
+	readModelRepo := &postgresReadModelRepository{db: db}
+	identityRepo := &postgresIdentityRepository{db: db}
+	apiKeyRepo := &postgresAPIKeyRepository{db: db}
+	columnMigrationRepo := &postgresColumnMigrationRepository{db: db, migrations: migrations}
+
+	userCache := &redisUserRepository{client: redisClient, ttls: ttls}
+	eventCache := &redisEventRepository{client: redisClient, ttls: ttls}
+	bookingCache := &redisBookingCacheRepository{client: redisClient}
+	eventStatsCache := &redisEventStatsRepository{client: redisClient}
+	availability := &redisAvailabilityRepository{client: redisClient}
+	waitingRoom := &redisWaitingRoomRepository{client: redisClient}
+	seatSuggestions := &redisSeatSuggestionRepository{client: redisClient}
+	bookingQueue := &redisBookingQueueRepository{client: redisClient}
+	leases := &redisLeaseRepository{client: redisClient}
+	cacheInvalidations := &redisCacheInvalidationRepository{client: redisClient}
+	availabilityFeed := &redisAvailabilityFeedRepository{client: redisClient}
+	bookingUpdates := &redisBookingUpdateRepository{client: redisClient}
+	bookingMetrics := &redisBookingMetricsRepository{client: redisClient}
+	sessions := &redisSessionRepository{client: redisClient}
+	rateLimits := &redisRateLimitRepository{client: redisClient}
+	passwordResets := &redisPasswordResetRepository{client: redisClient}
 
 	return &RepositoryContainer{
-		User:       userRepo,
-		Event:      eventRepo,
-		Ticket:     ticketRepo,
-		Booking:    bookingRepo,
-		UserCache:  userCache,
-		EventCache: eventCache,
+		User:         userRepo,
+		Event:        eventRepo,
+		Ticket:       ticketRepo,
+		Booking:      bookingRepo,
+		Presale:      presaleRepo,
+		Webhook:      webhookRepo,
+		Outbox:       outboxRepo,
+		Notification: notificationRepo,
+		Template:     templateRepo,
+		TicketPass:   ticketPassRepo,
+		Hold:         holdRepo,
+		Job:          jobRepo,
+		Refund:       refundRepo,
+		Broadcast:    broadcastRepo,
+		EventStats:   eventStatsRepo,
+		SalesReport:  salesReportRepo,
+		DeadLetter:   deadLetterRepo,
+
+		BookingExport: bookingExportRepo,
+		Audit:         auditRepo,
+
+		BookingTransition: bookingTransitionRepo,
+		BookingEvent:      bookingEventRepo,
+		ReadModel:         readModelRepo,
+
+		ColumnMigration: columnMigrationRepo,
+
+		Transactor:      db,
+		Queries:         queries,
+		UserCache:       userCache,
+		EventCache:      eventCache,
+		BookingCache:    bookingCache,
+		EventStatsCache: eventStatsCache,
+		Availability:    availability,
+		WaitingRoom:     waitingRoom,
+
+		SeatSuggestion: seatSuggestions,
+		BookingQueue:   bookingQueue,
+		Lease:          leases,
+
+		CacheInvalidation: cacheInvalidations,
+		AvailabilityFeed:  availabilityFeed,
+		BookingUpdates:    bookingUpdates,
+
+		BookingMetrics: bookingMetrics,
+		Session:        sessions,
+
+		Identity:  identityRepo,
+		APIKey:    apiKeyRepo,
+		RateLimit: rateLimits,
+
+		PasswordReset: passwordResets,
 	}
 }
 
@@ -112,17 +619,29 @@ func NewRepositoryContainer(db *sqlx.DB, redisClient *redis.Client) *RepositoryC
 
 // PostgreSQL User Repository
 type postgresUserRepository struct {
-	db *sqlx.DB
+	db *tenantDB
+}
+
+// userRole is the role stored for usr, customer when none was given
+func userRole(usr *domain_user.User) domain_user.Role {
+	if usr.Role == "" {
+		return domain_user.RoleCustomer
+	}
+	return usr.Role
 }
 
 func (r *postgresUserRepository) Create(ctx context.Context, usr *domain_user.User) error {
-	query := `INSERT INTO users (id, email, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)`
-	_, err := r.db.ExecContext(ctx, query, usr.ID, usr.Email, usr.Name, usr.CreatedAt, usr.UpdatedAt)
+	query := `INSERT INTO users (id, email, name, role, email_verified_at, locked_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.ExecContext(ctx, query, usr.ID, usr.Email, usr.Name, userRole(usr), usr.EmailVerifiedAt, usr.LockedAt, usr.CreatedAt, usr.UpdatedAt)
+	if isUniqueViolation(err) {
+		// The email index is the only one a new user's row can clash with
+		return domain_user.ErrEmailTaken
+	}
 	return err
 }
 
 func (r *postgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_user.User, error) {
-	query := `SELECT id, email, name, created_at, updated_at FROM users WHERE id = $1`
+	query := `SELECT id, email, name, role, email_verified_at, locked_at, created_at, updated_at FROM users WHERE id = $1 AND deleted_at IS NULL`
 	var usr domain_user.User
 	err := r.db.GetContext(ctx, &usr, query, id)
 	if err != nil {
@@ -135,7 +654,7 @@ func (r *postgresUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*do
 }
 
 func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (*domain_user.User, error) {
-	query := `SELECT id, email, name, created_at, updated_at FROM users WHERE email = $1`
+	query := `SELECT id, email, name, role, email_verified_at, locked_at, created_at, updated_at FROM users WHERE email = $1 AND deleted_at IS NULL`
 	var usr domain_user.User
 	err := r.db.GetContext(ctx, &usr, query, email)
 	if err != nil {
@@ -148,8 +667,11 @@ func (r *postgresUserRepository) GetByEmail(ctx context.Context, email string) (
 }
 
 func (r *postgresUserRepository) Update(ctx context.Context, usr *domain_user.User) error {
-	query := `UPDATE users SET email = $2, name = $3, updated_at = $4 WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, usr.ID, usr.Email, usr.Name, usr.UpdatedAt)
+	query := `UPDATE users SET email = $2, name = $3, role = $4, email_verified_at = $5, locked_at = $6, updated_at = $7 WHERE id = $1 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, usr.ID, usr.Email, usr.Name, userRole(usr), usr.EmailVerifiedAt, usr.LockedAt, usr.UpdatedAt)
+	if isUniqueViolation(err) {
+		return domain_user.ErrEmailTaken
+	}
 	if err != nil {
 		return err
 	}
@@ -163,38 +685,84 @@ func (r *postgresUserRepository) Update(ctx context.Context, usr *domain_user.Us
 	return nil
 }
 
+// Delete soft deletes the user, keeping their bookings
 func (r *postgresUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM users WHERE id = $1`
+	query := `UPDATE users SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, id, time.Now())
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+// Restore undoes Delete
+func (r *postgresUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE users SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
+	return affectedOne(result)
+}
+
+// Purge removes a deleted user for good, with their bookings, as the
+// foreign keys cascade. It is refused while any of their bookings holds
+// tickets; the user's row is locked so none is made meanwhile.
+func (r *postgresUserRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := lockDeleted(ctx, tx, `SELECT id FROM users WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id); err != nil {
+			return err
+		}
+		if err := refuseActiveBookings(ctx, tx, `SELECT EXISTS (SELECT 1 FROM bookings WHERE user_id = $1 AND status IN ('pending', 'review', 'confirmed'))`, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = $1`, id)
+		return err
+	})
+}
+
+func (r *postgresUserRepository) GetPasswordHash(ctx context.Context, id uuid.UUID) (string, error) {
+	var hash string
+	err := r.db.GetContext(ctx, &hash, `SELECT password_hash FROM users WHERE id = $1 AND deleted_at IS NULL`, id)
+	if err == sql.ErrNoRows {
+		return "", domain.ErrNotFound
+	}
+	return hash, err
+}
+
+func (r *postgresUserRepository) SetPasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET password_hash = $2 WHERE id = $1 AND deleted_at IS NULL`, id, hash)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return domain.ErrNotFound
+	return affectedOne(result)
+}
+
+// skipUnavailable drops the error of a cache write refused because Redis
+// is unavailable. The cache is bypassed until Redis returns; the database
+// holds the data either way.
+func skipUnavailable(err error) error {
+	if errors.Is(err, database.ErrRedisUnavailable) {
+		return nil
 	}
-	return nil
+	return err
 }
 
 // Redis User Repository
 type redisUserRepository struct {
 	client *redis.Client
+	ttls   CacheTTLs
 }
 
 func (r *redisUserRepository) Create(ctx context.Context, usr *domain_user.User) error {
-	key := fmt.Sprintf("user:%s", usr.ID.String())
-	userJSON, err := json.Marshal(usr)
-	if err != nil {
-		return err
-	}
-	return r.client.Set(ctx, key, userJSON, time.Hour).Err()
+	return r.Update(ctx, usr)
 }
 
 func (r *redisUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_user.User, error) {
-	key := fmt.Sprintf("user:%s", id.String())
+	if r.ttls.User == 0 {
+		return nil, domain.ErrNotFound
+	}
+	key := tenantKey(ctx, fmt.Sprintf("user:%s", id.String()))
 	userJSON, err := r.client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -202,6 +770,9 @@ func (r *redisUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 		}
 		return nil, err
 	}
+	if userJSON == notFoundMarker {
+		return nil, ErrCachedNotFound
+	}
 	var usr domain_user.User
 	err = json.Unmarshal([]byte(userJSON), &usr)
 	if err != nil {
@@ -211,7 +782,10 @@ func (r *redisUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domai
 }
 
 func (r *redisUserRepository) GetByEmail(ctx context.Context, email string) (*domain_user.User, error) {
-	key := fmt.Sprintf("user:email:%s", email)
+	if r.ttls.User == 0 {
+		return nil, domain.ErrNotFound
+	}
+	key := tenantKey(ctx, fmt.Sprintf("user:email:%s", email))
 	userID, err := r.client.Get(ctx, key).Result()
 	if err != nil {
 		if err == redis.Nil {
@@ -227,37 +801,53 @@ func (r *redisUserRepository) GetByEmail(ctx context.Context, email string) (*do
 }
 
 func (r *redisUserRepository) Update(ctx context.Context, usr *domain_user.User) error {
-	key := fmt.Sprintf("user:%s", usr.ID.String())
+	if r.ttls.User == 0 {
+		return nil
+	}
+	key := tenantKey(ctx, fmt.Sprintf("user:%s", usr.ID.String()))
 	userJSON, err := json.Marshal(usr)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, userJSON, time.Hour).Err()
+	return skipUnavailable(r.client.Set(ctx, key, userJSON, r.ttls.expiry(r.ttls.User)).Err())
 }
 
 func (r *redisUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	key := fmt.Sprintf("user:%s", id.String())
-	return r.client.Del(ctx, key).Err()
+	key := tenantKey(ctx, fmt.Sprintf("user:%s", id.String()))
+	return skipUnavailable(r.client.Del(ctx, key).Err())
 }
 
 func (r *redisUserRepository) SetEmailIndex(ctx context.Context, email string, userID uuid.UUID) error {
-	key := fmt.Sprintf("user:email:%s", email)
-	return r.client.Set(ctx, key, userID.String(), time.Hour).Err()
+	if r.ttls.User == 0 {
+		return nil
+	}
+	key := tenantKey(ctx, fmt.Sprintf("user:email:%s", email))
+	return skipUnavailable(r.client.Set(ctx, key, userID.String(), r.ttls.expiry(r.ttls.User)).Err())
+}
+
+// SetNotFound stores a marker in place of the user; caching the user
+// replaces it
+func (r *redisUserRepository) SetNotFound(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	if r.ttls.User == 0 {
+		return nil
+	}
+	key := tenantKey(ctx, fmt.Sprintf("user:%s", id.String()))
+	return skipUnavailable(r.client.Set(ctx, key, notFoundMarker, ttl).Err())
 }
 
 // PostgreSQL Event Repository
 type postgresEventRepository struct {
-	db *sqlx.DB
+	db *tenantDB
 }
 
 func (r *postgresEventRepository) Create(ctx context.Context, evt *domain_event.Event) error {
-	query := `INSERT INTO events (id, name, artist, venue, date, total_seats, price, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err := r.db.ExecContext(ctx, query, evt.ID, evt.Name, evt.Artist, evt.Venue, evt.Date, evt.TotalSeats, evt.Price, evt.CreatedAt, evt.UpdatedAt)
+	query := `INSERT INTO events (id, name, artist, venue, date, total_seats, price, status, sales_start_at, sales_end_at, presale_start_at, confirmation_requirements, waiting_room_enabled, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)`
+	_, err := r.db.ExecContext(ctx, query, evt.ID, evt.Name, evt.Artist, evt.Venue, evt.Date, evt.TotalSeats, evt.Price, evt.Status, evt.SalesStartAt, evt.SalesEndAt, evt.PresaleStartAt, evt.ConfirmationRequirements, evt.WaitingRoomEnabled, evt.CreatedAt, evt.UpdatedAt)
 	return err
 }
 
 func (r *postgresEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_event.Event, error) {
-	query := `SELECT id, name, artist, venue, date, total_seats, price, created_at, updated_at FROM events WHERE id = $1`
+	query := `SELECT id, name, artist, venue, date, total_seats, price, status, sales_start_at, sales_end_at, presale_start_at, confirmation_requirements, waiting_room_enabled, created_at, updated_at FROM events WHERE id = $1 AND deleted_at IS NULL`
 	var evt domain_event.Event
 	err := r.db.GetContext(ctx, &evt, query, id)
 	if err != nil {
@@ -269,10 +859,23 @@ func (r *postgresEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*d
 	return &evt, nil
 }
 
+func (r *postgresEventRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_event.Event, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `SELECT id, name, artist, venue, date, total_seats, price, status, sales_start_at, sales_end_at, presale_start_at, confirmation_requirements, waiting_room_enabled, created_at, updated_at FROM events WHERE id = ANY($1) AND deleted_at IS NULL`
+	var events []*domain_event.Event
+	err := r.db.SelectContext(ctx, &events, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
 func (r *postgresEventRepository) GetAll(ctx context.Context) ([]*domain_event.Event, error) {
-	query := `SELECT id, name, artist, venue, date, total_seats, price, created_at, updated_at FROM events ORDER BY date ASC`
+	query := `SELECT id, name, artist, venue, date, total_seats, price, status, sales_start_at, sales_end_at, presale_start_at, confirmation_requirements, waiting_room_enabled, created_at, updated_at FROM events WHERE deleted_at IS NULL ORDER BY date ASC`
 	var events []*domain_event.Event
-	err := r.db.SelectContext(ctx, &events, query)
+	err := r.db.readSelect(ctx, &events, query)
 	if err != nil {
 		return nil, err
 	}
@@ -280,8 +883,8 @@ func (r *postgresEventRepository) GetAll(ctx context.Context) ([]*domain_event.E
 }
 
 func (r *postgresEventRepository) Update(ctx context.Context, evt *domain_event.Event) error {
-	query := `UPDATE events SET name = $2, artist = $3, venue = $4, date = $5, total_seats = $6, price = $7, updated_at = $8 WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, evt.ID, evt.Name, evt.Artist, evt.Venue, evt.Date, evt.TotalSeats, evt.Price, evt.UpdatedAt)
+	query := `UPDATE events SET name = $2, artist = $3, venue = $4, date = $5, total_seats = $6, price = $7, status = $8, sales_start_at = $9, sales_end_at = $10, presale_start_at = $11, confirmation_requirements = $12, waiting_room_enabled = $13, updated_at = $14 WHERE id = $1 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, evt.ID, evt.Name, evt.Artist, evt.Venue, evt.Date, evt.TotalSeats, evt.Price, evt.Status, evt.SalesStartAt, evt.SalesEndAt, evt.PresaleStartAt, evt.ConfirmationRequirements, evt.WaitingRoomEnabled, evt.UpdatedAt)
 	if err != nil {
 		return err
 	}
@@ -295,45 +898,94 @@ func (r *postgresEventRepository) Update(ctx context.Context, evt *domain_event.
 	return nil
 }
 
+func (r *postgresEventRepository) GetSalesWindowChanges(ctx context.Context, from, to time.Time) ([]*domain_event.Event, error) {
+	query := `SELECT id, name, artist, venue, date, total_seats, price, status, sales_start_at, sales_end_at, presale_start_at, confirmation_requirements, waiting_room_enabled, created_at, updated_at FROM events WHERE deleted_at IS NULL AND ((sales_start_at > $1 AND sales_start_at <= $2) OR (sales_end_at > $1 AND sales_end_at <= $2) OR (presale_start_at > $1 AND presale_start_at <= $2))`
+	var events []*domain_event.Event
+	err := r.db.SelectContext(ctx, &events, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// Delete soft deletes the event, keeping its tickets and bookings
 func (r *postgresEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `DELETE FROM events WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, id)
+	query := `UPDATE events SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, id, time.Now())
 	if err != nil {
 		return err
 	}
-	rowsAffected, err := result.RowsAffected()
+	return affectedOne(result)
+}
+
+// Restore undoes Delete
+func (r *postgresEventRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE events SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL`
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
 		return err
 	}
-	if rowsAffected == 0 {
-		return domain.ErrNotFound
-	}
-	return nil
+	return affectedOne(result)
+}
+
+// Purge removes a deleted event for good, with its tickets, bookings and
+// everything else that belongs to it, as the foreign keys cascade. It is
+// refused while any of its bookings holds tickets.
+func (r *postgresEventRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := lockDeleted(ctx, tx, `SELECT id FROM events WHERE id = $1 AND deleted_at IS NOT NULL FOR UPDATE`, id); err != nil {
+			return err
+		}
+		if err := refuseActiveBookings(ctx, tx, `SELECT EXISTS (SELECT 1 FROM bookings WHERE event_id = $1 AND status IN ('pending', 'review', 'confirmed'))`, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM events WHERE id = $1`, id)
+		return err
+	})
 }
 
 // Redis Event Repository
+// Cached events and listings are keyed by the tenant's cache version, so
+// bumping the version drops them all and old copies expire unread.
 type redisEventRepository struct {
 	client *redis.Client
+	ttls   CacheTTLs
 }
 
-func (r *redisEventRepository) Create(ctx context.Context, evt *domain_event.Event) error {
-	key := fmt.Sprintf("event:%s", evt.ID.String())
-	eventJSON, err := json.Marshal(evt)
-	if err != nil {
-		return err
+func eventCacheVersionKey(ctx context.Context) string {
+	return tenantKey(ctx, "events:version")
+}
+
+func cachedEventKey(ctx context.Context, version int64, id uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("event:%s:v%d", id.String(), version))
+}
+
+func cachedEventsKey(ctx context.Context, version int64) string {
+	return tenantKey(ctx, fmt.Sprintf("events:all:v%d", version))
+}
+
+func (r *redisEventRepository) Version(ctx context.Context) (int64, error) {
+	version, err := r.client.Get(ctx, eventCacheVersionKey(ctx)).Int64()
+	if err == redis.Nil {
+		return 0, nil
 	}
-	return r.client.Set(ctx, key, eventJSON, 2*time.Hour).Err()
+	return version, err
 }
 
-func (r *redisEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_event.Event, error) {
-	key := fmt.Sprintf("event:%s", id.String())
-	eventJSON, err := r.client.Get(ctx, key).Result()
+func (r *redisEventRepository) GetByID(ctx context.Context, version int64, id uuid.UUID) (*domain_event.Event, error) {
+	if r.ttls.Event == 0 {
+		return nil, domain.ErrNotFound
+	}
+	eventJSON, err := r.client.Get(ctx, cachedEventKey(ctx, version, id)).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
+	if eventJSON == notFoundMarker {
+		return nil, ErrCachedNotFound
+	}
 	var evt domain_event.Event
 	err = json.Unmarshal([]byte(eventJSON), &evt)
 	if err != nil {
@@ -342,9 +994,11 @@ func (r *redisEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*doma
 	return &evt, nil
 }
 
-func (r *redisEventRepository) GetAll(ctx context.Context) ([]*domain_event.Event, error) {
-	key := "events:all"
-	eventsJSON, err := r.client.Get(ctx, key).Result()
+func (r *redisEventRepository) GetAll(ctx context.Context, version int64) ([]*domain_event.Event, error) {
+	if r.ttls.EventList == 0 {
+		return nil, domain.ErrNotFound
+	}
+	eventsJSON, err := r.client.Get(ctx, cachedEventsKey(ctx, version)).Result()
 	if err != nil {
 		if err == redis.Nil {
 			return nil, domain.ErrNotFound
@@ -359,42 +1013,70 @@ func (r *redisEventRepository) GetAll(ctx context.Context) ([]*domain_event.Even
 	return events, nil
 }
 
-func (r *redisEventRepository) Update(ctx context.Context, evt *domain_event.Event) error {
-	key := fmt.Sprintf("event:%s", evt.ID.String())
+func (r *redisEventRepository) Set(ctx context.Context, version int64, evt *domain_event.Event) error {
+	if r.ttls.Event == 0 {
+		return nil
+	}
 	eventJSON, err := json.Marshal(evt)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, eventJSON, 2*time.Hour).Err()
+	return skipUnavailable(r.client.Set(ctx, cachedEventKey(ctx, version, evt.ID), eventJSON, r.ttls.expiry(r.ttls.Event)).Err())
 }
 
-func (r *redisEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	key := fmt.Sprintf("event:%s", id.String())
-	return r.client.Del(ctx, key).Err()
+// SetNotFound stores a marker in place of the event. Creating the event
+// bumps the version, so the marker is never read once it exists.
+func (r *redisEventRepository) SetNotFound(ctx context.Context, version int64, id uuid.UUID, ttl time.Duration) error {
+	if r.ttls.Event == 0 {
+		return nil
+	}
+	return skipUnavailable(r.client.Set(ctx, cachedEventKey(ctx, version, id), notFoundMarker, ttl).Err())
 }
 
-func (r *redisEventRepository) SetAllEvents(ctx context.Context, events []*domain_event.Event) error {
-	key := "events:all"
+func (r *redisEventRepository) SetAllEvents(ctx context.Context, version int64, events []*domain_event.Event) error {
+	if r.ttls.EventList == 0 {
+		return nil
+	}
 	eventsJSON, err := json.Marshal(events)
 	if err != nil {
 		return err
 	}
-	return r.client.Set(ctx, key, eventsJSON, time.Hour).Err()
+	return skipUnavailable(r.client.Set(ctx, cachedEventsKey(ctx, version), eventsJSON, r.ttls.expiry(r.ttls.EventList)).Err())
+}
+
+func (r *redisEventRepository) Invalidate(ctx context.Context) error {
+	return r.client.Incr(ctx, eventCacheVersionKey(ctx)).Err()
 }
 
 // PostgreSQL Ticket Repository
 type postgresTicketRepository struct {
-	db *sqlx.DB
+	db    *tenantDB
+	price columnSwitch // prices moving to integer cents
+}
+
+// columns is the select list scanned into domain_ticket.Ticket
+func (r *postgresTicketRepository) columns() string {
+	return "id, event_id, seat_number, status, " + r.price.selectExpr() + ", created_at, updated_at"
 }
 
 func (r *postgresTicketRepository) Create(ctx context.Context, tkt *domain_ticket.Ticket) error {
-	query := `INSERT INTO tickets (id, event_id, seat_number, status, price, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-	_, err := r.db.ExecContext(ctx, query, tkt.ID, tkt.EventID, tkt.SeatNumber, tkt.Status, tkt.Price, tkt.CreatedAt, tkt.UpdatedAt)
+	columns := []string{"id", "event_id", "seat_number", "status", "created_at", "updated_at"}
+	args := []interface{}{tkt.ID, tkt.EventID, tkt.SeatNumber, tkt.Status, tkt.CreatedAt, tkt.UpdatedAt}
+	priceColumns, priceArgs := r.price.writes(tkt.Price)
+	columns = append(columns, priceColumns...)
+	args = append(args, priceArgs...)
+
+	placeholders := make([]string, len(args))
+	for i := range args {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+	}
+	query := fmt.Sprintf(`INSERT INTO tickets (%s) VALUES (%s)`, strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+	_, err := r.db.ExecContext(ctx, query, args...)
 	return err
 }
 
 func (r *postgresTicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_ticket.Ticket, error) {
-	query := `SELECT id, event_id, seat_number, status, price, created_at, updated_at FROM tickets WHERE id = $1`
+	query := `SELECT ` + r.columns() + ` FROM tickets WHERE id = $1`
 	var tkt domain_ticket.Ticket
 	err := r.db.GetContext(ctx, &tkt, query, id)
 	if err != nil {
@@ -406,10 +1088,23 @@ func (r *postgresTicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*
 	return &tkt, nil
 }
 
+func (r *postgresTicketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query := `SELECT ` + r.columns() + ` FROM tickets WHERE id = ANY($1)`
+	var tickets []*domain_ticket.Ticket
+	err := r.db.SelectContext(ctx, &tickets, query, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
 func (r *postgresTicketRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
-	query := `SELECT id, event_id, seat_number, status, price, created_at, updated_at FROM tickets WHERE event_id = $1 ORDER BY seat_number ASC`
+	query := `SELECT ` + r.columns() + ` FROM tickets WHERE event_id = $1 ORDER BY seat_number ASC`
 	var tickets []*domain_ticket.Ticket
-	err := r.db.SelectContext(ctx, &tickets, query, eventID)
+	err := r.db.readSelect(ctx, &tickets, query, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -417,9 +1112,9 @@ func (r *postgresTicketRepository) GetByEventID(ctx context.Context, eventID uui
 }
 
 func (r *postgresTicketRepository) GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
-	query := `SELECT id, event_id, seat_number, status, price, created_at, updated_at FROM tickets WHERE event_id = $1 AND status = 'available' ORDER BY seat_number ASC`
+	query := `SELECT ` + r.columns() + ` FROM tickets WHERE event_id = $1 AND status = 'available' ORDER BY seat_number ASC`
 	var tickets []*domain_ticket.Ticket
-	err := r.db.SelectContext(ctx, &tickets, query, eventID)
+	err := r.db.readSelect(ctx, &tickets, query, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -458,73 +1153,39 @@ func (r *postgresTicketRepository) Delete(ctx context.Context, id uuid.UUID) err
 	return nil
 }
 
+// ReserveTickets moves tickets from available to reserved in a single
+// conditional update. A ticket reserved by a concurrent transaction no
+// longer matches, so both cannot take it; the row locks taken by the update
+// make the loser wait for the winner to commit and then see its status.
 func (r *postgresTicketRepository) ReserveTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
 	if len(ticketIDs) == 0 {
 		return nil
 	}
 
-	// Start transaction
-	tx, err := r.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	// Check if all tickets are available
-	placeholders := make([]string, len(ticketIDs))
-	args := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
-	}
-
-	query := fmt.Sprintf(`SELECT id, status FROM tickets WHERE id IN (%s)`, fmt.Sprintf("$%d", len(ticketIDs)+1))
-
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
-	}
-	args = append(args, ticketIDsInterface...)
-
-	rows, err := tx.QueryContext(ctx, query, args...)
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
-
-	availableTickets := make(map[uuid.UUID]bool)
-	for rows.Next() {
-		var id uuid.UUID
-		var status string
-		if err := rows.Scan(&id, &status); err != nil {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `UPDATE tickets SET status = 'reserved', updated_at = NOW() WHERE id = ANY($1) AND status = 'available' RETURNING id`
+		var reserved []uuid.UUID
+		if err := tx.SelectContext(ctx, &reserved, query, pq.Array(ticketIDs)); err != nil {
 			return err
 		}
-		availableTickets[id] = (status == "available")
-	}
-
-	// Check if all requested tickets are available
-	for _, id := range ticketIDs {
-		if !availableTickets[id] {
-			return fmt.Errorf("ticket %s is not available", id)
-		}
-	}
-
-	// Reserve all tickets
-	updateQuery := fmt.Sprintf(`UPDATE tickets SET status = 'reserved', updated_at = NOW() WHERE id IN (%s)`, fmt.Sprintf("$%d", len(ticketIDs)+1))
+		// Returning an error rolls back the tickets that were reserved
+		return unreservedTicket(ticketIDs, reserved)
+	})
+}
 
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface = make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
+// unreservedTicket reports the first requested ticket the update did not
+// reserve, because it is missing or no longer available
+func unreservedTicket(requested, reserved []uuid.UUID) error {
+	done := make(map[uuid.UUID]bool, len(reserved))
+	for _, id := range reserved {
+		done[id] = true
 	}
-	args = append(args, ticketIDsInterface...)
-	_, err = tx.ExecContext(ctx, updateQuery, args...)
-	if err != nil {
-		return err
+	for _, id := range requested {
+		if !done[id] {
+			return fmt.Errorf("%w: ticket %s is not available", domain.ErrConflict, id)
+		}
 	}
-
-	return tx.Commit()
+	return nil
 }
 
 func (r *postgresTicketRepository) ConfirmTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
@@ -532,22 +1193,8 @@ func (r *postgresTicketRepository) ConfirmTickets(ctx context.Context, ticketIDs
 		return nil
 	}
 
-	placeholders := make([]string, len(ticketIDs))
-	args := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
-	}
-
-	query := fmt.Sprintf(`UPDATE tickets SET status = 'sold', updated_at = NOW() WHERE id IN (%s) AND status = 'reserved'`, fmt.Sprintf("$%d", len(ticketIDs)+1))
-
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
-	}
-	args = append(args, ticketIDsInterface...)
-	result, err := r.db.ExecContext(ctx, query, args...)
+	query := `UPDATE tickets SET status = 'sold', updated_at = NOW() WHERE id = ANY($1) AND status = 'reserved'`
+	result, err := r.db.ExecContext(ctx, query, pq.Array(ticketIDs))
 	if err != nil {
 		return err
 	}
@@ -568,38 +1215,123 @@ func (r *postgresTicketRepository) ReleaseTickets(ctx context.Context, ticketIDs
 		return nil
 	}
 
-	placeholders := make([]string, len(ticketIDs))
-	args := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
-	}
-
-	query := fmt.Sprintf(`UPDATE tickets SET status = 'available', updated_at = NOW() WHERE id IN (%s) AND status IN ('reserved', 'cancelled')`, fmt.Sprintf("$%d", len(ticketIDs)+1))
-
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
-	}
-	args = append(args, ticketIDsInterface...)
-	_, err := r.db.ExecContext(ctx, query, args...)
+	query := `UPDATE tickets SET status = 'available', updated_at = NOW() WHERE id = ANY($1) AND status IN ('reserved', 'cancelled')`
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ticketIDs))
 	return err
 }
 
+// RestockTickets puts sold tickets back on sale after a refund
+func (r *postgresTicketRepository) RestockTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `UPDATE tickets SET status = 'available', updated_at = NOW() WHERE id = $1 AND status = 'sold'`
+		for _, id := range ticketIDs {
+			if _, err := tx.ExecContext(ctx, query, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // PostgreSQL Booking Repository
 type postgresBookingRepository struct {
-	db *sqlx.DB
+	db *tenantDB
 }
 
+// Create stores a booking together with its tickets and line items
 func (r *postgresBookingRepository) Create(ctx context.Context, bk *domain_booking.Booking) error {
-	query := `INSERT INTO bookings (id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
-	_, err := r.db.ExecContext(ctx, query, bk.ID, bk.UserID, bk.EventID, bk.TicketIDs, bk.Status, bk.TotalAmount, bk.CreatedAt, bk.UpdatedAt, bk.ExpiresAt)
-	return err
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO bookings (id, user_id, event_id, status, total_amount, currency, exchange_rate, created_at, updated_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+		if _, err := tx.ExecContext(ctx, query, bk.ID, bk.UserID, bk.EventID, bk.Status, bk.TotalAmount, bk.Currency, bk.ExchangeRate, bk.CreatedAt, bk.UpdatedAt, bk.ExpiresAt); err != nil {
+			return err
+		}
+		if err := insertBookingTickets(ctx, tx, bk); err != nil {
+			return err
+		}
+
+		query = `INSERT INTO booking_items (booking_id, ticket_id, seat_number, base_price, unit_price, currency, exchange_rate, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		for _, item := range bk.Items {
+			if _, err := tx.ExecContext(ctx, query, item.BookingID, item.TicketID, item.SeatNumber, item.BasePrice, item.UnitPrice, item.Currency, item.ExchangeRate, item.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetItems retrieves the line items of a booking
+func (r *postgresBookingRepository) GetItems(ctx context.Context, bookingID uuid.UUID) ([]domain_booking.LineItem, error) {
+	query := `SELECT booking_id, ticket_id, seat_number, base_price, unit_price, currency, exchange_rate, created_at FROM booking_items WHERE booking_id = $1 ORDER BY seat_number ASC`
+	var items []domain_booking.LineItem
+	err := r.db.SelectContext(ctx, &items, query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SwapItems replaces the line items of tickets taken off a booking with
+// the items of the tickets that took their place
+func (r *postgresBookingRepository) SwapItems(ctx context.Context, bookingID uuid.UUID, removed []uuid.UUID, added []domain_booking.LineItem) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `DELETE FROM booking_items WHERE booking_id = $1 AND ticket_id = $2`
+		for _, ticketID := range removed {
+			if _, err := tx.ExecContext(ctx, query, bookingID, ticketID); err != nil {
+				return err
+			}
+		}
+
+		query = `INSERT INTO booking_items (booking_id, ticket_id, seat_number, base_price, unit_price, currency, exchange_rate, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		for _, item := range added {
+			if _, err := tx.ExecContext(ctx, query, item.BookingID, item.TicketID, item.SeatNumber, item.BasePrice, item.UnitPrice, item.Currency, item.ExchangeRate, item.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// insertBookingTickets records the tickets a booking holds, in order
+func insertBookingTickets(ctx context.Context, tx *sqlx.Tx, bk *domain_booking.Booking) error {
+	query := `INSERT INTO booking_tickets (booking_id, ticket_id, position) VALUES ($1, $2, $3)`
+	for i, ticketID := range bk.TicketIDs {
+		if _, err := tx.ExecContext(ctx, query, bk.ID, ticketID, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withTicketIDs fills in the tickets each booking holds in one query
+func (r *postgresBookingRepository) withTicketIDs(ctx context.Context, bookings []*domain_booking.Booking) ([]*domain_booking.Booking, error) {
+	if len(bookings) == 0 {
+		return bookings, nil
+	}
+	ids := make([]uuid.UUID, len(bookings))
+	byID := make(map[uuid.UUID]*domain_booking.Booking, len(bookings))
+	for i, bk := range bookings {
+		ids[i] = bk.ID
+		byID[bk.ID] = bk
+		bk.TicketIDs = []uuid.UUID{}
+	}
+
+	query := `SELECT booking_id, ticket_id FROM booking_tickets WHERE booking_id = ANY($1) ORDER BY booking_id, position`
+	var rows []struct {
+		BookingID uuid.UUID `db:"booking_id"`
+		TicketID  uuid.UUID `db:"ticket_id"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, pq.Array(ids)); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		bk := byID[row.BookingID]
+		bk.TicketIDs = append(bk.TicketIDs, row.TicketID)
+	}
+	return bookings, nil
 }
 
 func (r *postgresBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_booking.Booking, error) {
-	query := `SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at FROM bookings WHERE id = $1`
+	query := `SELECT id, user_id, event_id, status, total_amount, currency, exchange_rate, payment_reference, risk_score, created_at, updated_at, expires_at FROM bookings WHERE id = $1`
 	var bk domain_booking.Booking
 	err := r.db.GetContext(ctx, &bk, query, id)
 	if err != nil {
@@ -608,43 +1340,70 @@ func (r *postgresBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (
 		}
 		return nil, err
 	}
+	if _, err := r.withTicketIDs(ctx, []*domain_booking.Booking{&bk}); err != nil {
+		return nil, err
+	}
+	return &bk, nil
+}
+
+// GetByTicketID retrieves the pending or confirmed booking holding a ticket
+func (r *postgresBookingRepository) GetByTicketID(ctx context.Context, ticketID uuid.UUID) (*domain_booking.Booking, error) {
+	query := `SELECT b.id, b.user_id, b.event_id, b.status, b.total_amount, b.currency, b.exchange_rate, b.payment_reference, b.risk_score, b.created_at, b.updated_at, b.expires_at FROM bookings b JOIN booking_tickets bt ON bt.booking_id = b.id WHERE bt.ticket_id = $1 AND b.status IN ('pending', 'confirmed') ORDER BY b.created_at DESC LIMIT 1`
+	var bk domain_booking.Booking
+	err := r.db.GetContext(ctx, &bk, query, ticketID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if _, err := r.withTicketIDs(ctx, []*domain_booking.Booking{&bk}); err != nil {
+		return nil, err
+	}
 	return &bk, nil
 }
 
 func (r *postgresBookingRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error) {
-	query := `SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at FROM bookings WHERE user_id = $1 ORDER BY created_at DESC`
+	query := `SELECT id, user_id, event_id, status, total_amount, currency, exchange_rate, payment_reference, risk_score, created_at, updated_at, expires_at FROM bookings WHERE user_id = $1 ORDER BY created_at DESC`
 	var bookings []*domain_booking.Booking
 	err := r.db.SelectContext(ctx, &bookings, query, userID)
 	if err != nil {
 		return nil, err
 	}
-	return bookings, nil
+	return r.withTicketIDs(ctx, bookings)
 }
 
 func (r *postgresBookingRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_booking.Booking, error) {
-	query := `SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at FROM bookings WHERE event_id = $1 ORDER BY created_at DESC`
+	query := `SELECT id, user_id, event_id, status, total_amount, currency, exchange_rate, payment_reference, risk_score, created_at, updated_at, expires_at FROM bookings WHERE event_id = $1 ORDER BY created_at DESC`
 	var bookings []*domain_booking.Booking
-	err := r.db.SelectContext(ctx, &bookings, query, eventID)
+	err := r.db.readSelect(ctx, &bookings, query, eventID)
 	if err != nil {
 		return nil, err
 	}
-	return bookings, nil
+	return r.withTicketIDs(ctx, bookings)
 }
 
+// Update saves a booking and replaces the tickets it holds
 func (r *postgresBookingRepository) Update(ctx context.Context, bk *domain_booking.Booking) error {
-	query := `UPDATE bookings SET status = $2, total_amount = $3, updated_at = $4, expires_at = $5 WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, bk.ID, bk.Status, bk.TotalAmount, bk.UpdatedAt, bk.ExpiresAt)
-	if err != nil {
-		return err
-	}
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-	if rowsAffected == 0 {
-		return domain.ErrNotFound
-	}
-	return nil
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `UPDATE bookings SET status = $2, total_amount = $3, payment_reference = $4, risk_score = $5, updated_at = $6, expires_at = $7 WHERE id = $1`
+		result, err := tx.ExecContext(ctx, query, bk.ID, bk.Status, bk.TotalAmount, bk.PaymentReference, bk.RiskScore, bk.UpdatedAt, bk.ExpiresAt)
+		if err != nil {
+			return err
+		}
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return domain.ErrNotFound
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM booking_tickets WHERE booking_id = $1`, bk.ID); err != nil {
+			return err
+		}
+		return insertBookingTickets(ctx, tx, bk)
+	})
 }
 
 func (r *postgresBookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -664,11 +1423,44 @@ func (r *postgresBookingRepository) Delete(ctx context.Context, id uuid.UUID) er
 }
 
 func (r *postgresBookingRepository) GetExpiredBookings(ctx context.Context, before time.Time) ([]*domain_booking.Booking, error) {
-	query := `SELECT id, user_id, event_id, ticket_ids, status, total_amount, created_at, updated_at, expires_at FROM bookings WHERE expires_at < $1 AND status = 'pending' ORDER BY expires_at ASC`
+	query := `SELECT id, user_id, event_id, status, total_amount, currency, exchange_rate, payment_reference, risk_score, created_at, updated_at, expires_at FROM bookings WHERE expires_at < $1 AND status = 'pending' ORDER BY expires_at ASC`
 	var bookings []*domain_booking.Booking
 	err := r.db.SelectContext(ctx, &bookings, query, before)
 	if err != nil {
 		return nil, err
 	}
-	return bookings, nil
+	return r.withTicketIDs(ctx, bookings)
+}
+
+// GetByStatus retrieves the bookings in a status, those expiring first first
+func (r *postgresBookingRepository) GetByStatus(ctx context.Context, status domain_booking.BookingStatus) ([]*domain_booking.Booking, error) {
+	query := `SELECT id, user_id, event_id, status, total_amount, currency, exchange_rate, payment_reference, risk_score, created_at, updated_at, expires_at FROM bookings WHERE status = $1 ORDER BY expires_at ASC`
+	var bookings []*domain_booking.Booking
+	err := r.db.SelectContext(ctx, &bookings, query, status)
+	if err != nil {
+		return nil, err
+	}
+	return r.withTicketIDs(ctx, bookings)
+}
+
+// GetExpiringBetween retrieves pending bookings whose hold runs out in (from, to]
+func (r *postgresBookingRepository) GetExpiringBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error) {
+	query := `SELECT id, user_id, event_id, status, total_amount, currency, exchange_rate, payment_reference, risk_score, created_at, updated_at, expires_at FROM bookings WHERE expires_at > $1 AND expires_at <= $2 AND status = 'pending' ORDER BY expires_at ASC`
+	var bookings []*domain_booking.Booking
+	err := r.db.SelectContext(ctx, &bookings, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return r.withTicketIDs(ctx, bookings)
+}
+
+// GetConfirmedForEventsBetween retrieves confirmed bookings for events taking place in (from, to]
+func (r *postgresBookingRepository) GetConfirmedForEventsBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error) {
+	query := `SELECT b.id, b.user_id, b.event_id, b.status, b.total_amount, b.currency, b.exchange_rate, b.payment_reference, b.risk_score, b.created_at, b.updated_at, b.expires_at FROM bookings b JOIN events e ON e.id = b.event_id WHERE e.deleted_at IS NULL AND e.date > $1 AND e.date <= $2 AND b.status = 'confirmed' ORDER BY e.date ASC`
+	var bookings []*domain_booking.Booking
+	err := r.db.SelectContext(ctx, &bookings, query, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return r.withTicketIDs(ctx, bookings)
 }
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+
+	"github.com/google/uuid"
+)
+
+const jobColumns = `id, kind, state, params, progress, result_url, error, attempts, lease_until, created_at, started_at, finished_at, updated_at`
+
+// PostgreSQL Job Repository
+type postgresJobRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresJobRepository) Create(ctx context.Context, job *domain_job.Job) error {
+	query := `INSERT INTO jobs (id, kind, state, params, progress, attempts, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := r.db.ExecContext(ctx, query, job.ID, job.Kind, job.State, string(job.Params), job.Progress, job.Attempts, job.CreatedAt, job.UpdatedAt)
+	return err
+}
+
+func (r *postgresJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_job.Job, error) {
+	query := `SELECT ` + jobColumns + ` FROM jobs WHERE id = $1`
+	var job domain_job.Job
+	err := r.db.GetContext(ctx, &job, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ClaimDue leases queued jobs, and running jobs whose worker stopped renewing
+// its lease, so concurrent workers never run the same job at once
+func (r *postgresJobRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_job.Job, error) {
+	query := `UPDATE jobs SET state = 'running', attempts = attempts + 1, lease_until = $2, started_at = COALESCE(started_at, $1), updated_at = $1
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE state = 'queued' OR (state = 'running' AND lease_until <= $1)
+			ORDER BY created_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+	var jobs []*domain_job.Job
+	err := r.db.SelectContext(ctx, &jobs, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+func (r *postgresJobRepository) Update(ctx context.Context, job *domain_job.Job) error {
+	query := `UPDATE jobs SET state = $2, progress = $3, result_url = $4, error = $5, lease_until = $6, finished_at = $7, updated_at = $8 WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, job.ID, job.State, job.Progress, job.ResultURL, job.Error, job.LeaseUntil, job.FinishedAt, job.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Lease Repository
+// A lease is a key holding its holder's name that expires unless renewed,
+// so a lease whose holder dies passes to the next instance asking for it.
+// Leases are tenant-scoped like the jobs they guard.
+type redisLeaseRepository struct {
+	client *redis.Client
+}
+
+func leaseKey(ctx context.Context, name string) string {
+	return tenantKey(ctx, "lease:"+name)
+}
+
+// acquireLease extends the lease if the holder has it, or takes it if free
+var acquireLease = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('PEXPIRE', KEYS[1], ARGV[2])
+end
+if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+	return 1
+end
+return 0`)
+
+// releaseLease deletes the lease only if the holder still has it
+var releaseLease = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0`)
+
+// Acquire takes the lease for ttl, or extends it if holder already has it.
+// It reports whether holder has the lease afterwards.
+func (r *redisLeaseRepository) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	held, err := acquireLease.Run(ctx, r.client, []string{leaseKey(ctx, name)}, holder, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return held == 1, nil
+}
+
+func (r *redisLeaseRepository) Release(ctx context.Context, name string, holder string) error {
+	return releaseLease.Run(ctx, r.client, []string{leaseKey(ctx, name)}, holder).Err()
+}
@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_deadletter "github.com/ojaswiii/booking-manager/src/internal/domain/deadletter"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
+	domain_presale "github.com/ojaswiii/booking-manager/src/internal/domain/presale"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+// memoryStore keeps every table in maps, one set per tenant. Rows are
+// stored and returned as copies, so callers never share them.
+//
+// Writes outside a transaction are atomic. Transactions run one at a time
+// and, on error, restore the tables as they found them; plain writes wait
+// for a running transaction, but reads see its changes before it commits.
+type memoryStore struct {
+	mu      sync.Mutex // guards the tables
+	tx      sync.Mutex // held by the running transaction
+	tenants map[string]*memoryTables
+}
+
+// memoryTables are the rows of one tenant. The Postgres tables are here;
+// the Redis keys, which transactions do not cover, are in memoryKeys.
+type memoryTables struct {
+	users         map[uuid.UUID]domain_user.User
+	passwords     map[uuid.UUID]string // hashes, by user
+	events        map[uuid.UUID]domain_event.Event
+	tickets       map[uuid.UUID]domain_ticket.Ticket
+	ticketHolds   map[uuid.UUID]uuid.UUID // ticket to the hold taking it off sale
+	bookings      map[uuid.UUID]domain_booking.Booking
+	bookingItems  map[uuid.UUID][]domain_booking.LineItem
+	presaleCodes  map[uuid.UUID]domain_presale.PresaleCode
+	subscriptions map[uuid.UUID]domain_webhook.Subscription
+	deliveries    map[uuid.UUID]domain_webhook.Delivery
+	outbox        map[uuid.UUID]domain_outbox.Message
+	notifications map[uuid.UUID]domain_notification.Notification
+	preferences   map[uuid.UUID]domain_notification.Preferences
+	templates     map[uuid.UUID]domain_template.EventTemplate
+	passes        map[uuid.UUID]domain_ticket.Pass
+	holds         map[uuid.UUID]domain_hold.Hold
+	jobs          map[uuid.UUID]domain_job.Job
+	refunds       map[uuid.UUID]domain_refund.Refund
+	policies      map[uuid.UUID]domain_refund.Policy
+	returns       map[uuid.UUID]domain_refund.Return
+	broadcasts    map[uuid.UUID]domain_broadcast.Broadcast
+	recipients    map[broadcastRecipient]domain_broadcast.Delivery
+	deadLetters   map[uuid.UUID]domain_deadletter.DeadLetter
+	auditEntries  map[uuid.UUID]domain_audit.Entry
+	transitions   map[uuid.UUID][]domain_booking.Transition   // by booking, oldest first
+	streams       map[uuid.UUID][]domain_booking.BookingEvent // by booking, oldest first
+	lastPosition  int64                                       // of the newest booking event
+	summaries     map[uuid.UUID]domain_readmodel.BookingSummary
+	eventCounts   map[uuid.UUID]domain_readmodel.EventAvailability
+	checkpoints   map[string]int64
+	identities    map[identityKey]domain_user.Identity
+	apiKeys       map[uuid.UUID]domain_apikey.APIKey
+}
+
+type identityKey struct {
+	provider string
+	subject  string
+}
+
+type broadcastRecipient struct {
+	broadcastID uuid.UUID
+	userID      uuid.UUID
+}
+
+func newMemoryTables() *memoryTables {
+	return &memoryTables{
+		users:         make(map[uuid.UUID]domain_user.User),
+		passwords:     make(map[uuid.UUID]string),
+		events:        make(map[uuid.UUID]domain_event.Event),
+		tickets:       make(map[uuid.UUID]domain_ticket.Ticket),
+		ticketHolds:   make(map[uuid.UUID]uuid.UUID),
+		bookings:      make(map[uuid.UUID]domain_booking.Booking),
+		bookingItems:  make(map[uuid.UUID][]domain_booking.LineItem),
+		presaleCodes:  make(map[uuid.UUID]domain_presale.PresaleCode),
+		subscriptions: make(map[uuid.UUID]domain_webhook.Subscription),
+		deliveries:    make(map[uuid.UUID]domain_webhook.Delivery),
+		outbox:        make(map[uuid.UUID]domain_outbox.Message),
+		notifications: make(map[uuid.UUID]domain_notification.Notification),
+		preferences:   make(map[uuid.UUID]domain_notification.Preferences),
+		templates:     make(map[uuid.UUID]domain_template.EventTemplate),
+		passes:        make(map[uuid.UUID]domain_ticket.Pass),
+		holds:         make(map[uuid.UUID]domain_hold.Hold),
+		jobs:          make(map[uuid.UUID]domain_job.Job),
+		refunds:       make(map[uuid.UUID]domain_refund.Refund),
+		policies:      make(map[uuid.UUID]domain_refund.Policy),
+		returns:       make(map[uuid.UUID]domain_refund.Return),
+		broadcasts:    make(map[uuid.UUID]domain_broadcast.Broadcast),
+		recipients:    make(map[broadcastRecipient]domain_broadcast.Delivery),
+		deadLetters:   make(map[uuid.UUID]domain_deadletter.DeadLetter),
+		auditEntries:  make(map[uuid.UUID]domain_audit.Entry),
+		transitions:   make(map[uuid.UUID][]domain_booking.Transition),
+		streams:       make(map[uuid.UUID][]domain_booking.BookingEvent),
+		summaries:     make(map[uuid.UUID]domain_readmodel.BookingSummary),
+		eventCounts:   make(map[uuid.UUID]domain_readmodel.EventAvailability),
+		checkpoints:   make(map[string]int64),
+		identities:    make(map[identityKey]domain_user.Identity),
+		apiKeys:       make(map[uuid.UUID]domain_apikey.APIKey),
+	}
+}
+
+// clone copies the tables for rolling back. Rows are values whose slices
+// are replaced rather than changed in place, so copying the maps is enough.
+func (t *memoryTables) clone() *memoryTables {
+	c := newMemoryTables()
+	for k, v := range t.users {
+		c.users[k] = v
+	}
+	for k, v := range t.passwords {
+		c.passwords[k] = v
+	}
+	for k, v := range t.events {
+		c.events[k] = v
+	}
+	for k, v := range t.tickets {
+		c.tickets[k] = v
+	}
+	for k, v := range t.ticketHolds {
+		c.ticketHolds[k] = v
+	}
+	for k, v := range t.bookings {
+		c.bookings[k] = v
+	}
+	for k, v := range t.bookingItems {
+		c.bookingItems[k] = v
+	}
+	for k, v := range t.presaleCodes {
+		c.presaleCodes[k] = v
+	}
+	for k, v := range t.subscriptions {
+		c.subscriptions[k] = v
+	}
+	for k, v := range t.deliveries {
+		c.deliveries[k] = v
+	}
+	for k, v := range t.outbox {
+		c.outbox[k] = v
+	}
+	for k, v := range t.notifications {
+		c.notifications[k] = v
+	}
+	for k, v := range t.preferences {
+		c.preferences[k] = v
+	}
+	for k, v := range t.templates {
+		c.templates[k] = v
+	}
+	for k, v := range t.passes {
+		c.passes[k] = v
+	}
+	for k, v := range t.holds {
+		c.holds[k] = v
+	}
+	for k, v := range t.jobs {
+		c.jobs[k] = v
+	}
+	for k, v := range t.refunds {
+		c.refunds[k] = v
+	}
+	for k, v := range t.policies {
+		c.policies[k] = v
+	}
+	for k, v := range t.returns {
+		c.returns[k] = v
+	}
+	for k, v := range t.broadcasts {
+		c.broadcasts[k] = v
+	}
+	for k, v := range t.recipients {
+		c.recipients[k] = v
+	}
+	for k, v := range t.deadLetters {
+		c.deadLetters[k] = v
+	}
+	for k, v := range t.auditEntries {
+		c.auditEntries[k] = v
+	}
+	for k, v := range t.transitions {
+		c.transitions[k] = v
+	}
+	for k, v := range t.streams {
+		c.streams[k] = v
+	}
+	c.lastPosition = t.lastPosition
+	for k, v := range t.summaries {
+		c.summaries[k] = v
+	}
+	for k, v := range t.eventCounts {
+		c.eventCounts[k] = v
+	}
+	for k, v := range t.checkpoints {
+		c.checkpoints[k] = v
+	}
+	for k, v := range t.identities {
+		c.identities[k] = v
+	}
+	for k, v := range t.apiKeys {
+		c.apiKeys[k] = v
+	}
+	return c
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{tenants: make(map[string]*memoryTables)}
+}
+
+// memoryTxKey marks a context inside a memory store transaction
+type memoryTxKey struct{ store *memoryStore }
+
+func (s *memoryStore) inTx(ctx context.Context) bool {
+	return ctx.Value(memoryTxKey{s}) != nil
+}
+
+// tables returns the tenant's tables. The caller holds s.mu.
+func (s *memoryStore) tables(ctx context.Context) *memoryTables {
+	id := tenant.FromContext(ctx)
+	t, ok := s.tenants[id]
+	if !ok {
+		t = newMemoryTables()
+		s.tenants[id] = t
+	}
+	return t
+}
+
+// read runs fn over the tenant's tables
+func (s *memoryStore) read(ctx context.Context, fn func(t *memoryTables) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return fn(s.tables(ctx))
+}
+
+// write runs fn over the tenant's tables, after any running transaction
+// unless it is part of it. fn must check everything that can fail before
+// changing anything, since a failed write is not rolled back on its own.
+func (s *memoryStore) write(ctx context.Context, fn func(t *memoryTables) error) error {
+	if !s.inTx(ctx) {
+		s.tx.Lock()
+		defer s.tx.Unlock()
+	}
+	return s.read(ctx, fn)
+}
+
+// WithinTx runs fn in a transaction, joining one already running in ctx
+func (s *memoryStore) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.inTx(ctx) {
+		return fn(ctx)
+	}
+
+	s.tx.Lock()
+	defer s.tx.Unlock()
+
+	s.mu.Lock()
+	snapshot := make(map[string]*memoryTables, len(s.tenants))
+	for id, t := range s.tenants {
+		snapshot[id] = t.clone()
+	}
+	s.mu.Unlock()
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{s}, true)); err != nil {
+		s.mu.Lock()
+		s.tenants = snapshot
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// duplicate reports a row clashing with a unique constraint
+func duplicate(what string, key interface{}) error {
+	return fmt.Errorf("%w: duplicate %s %v", domain.ErrConflict, what, key)
+}
+
+// missingParent reports a row referring to one that does not exist
+func missingParent(what string, id uuid.UUID) error {
+	return fmt.Errorf("%w: %s %s does not exist", domain.ErrInvalidInput, what, id)
+}
+
+// memoryKeys holds what the Redis repositories keep, with expiry. Keys are
+// tenant-scoped the way tenantKey scopes Redis keys.
+type memoryKeys struct {
+	mu   sync.Mutex
+	vals map[string]memoryValue
+}
+
+type memoryValue struct {
+	value     interface{}
+	expiresAt time.Time // zero for no expiry
+}
+
+func newMemoryKeys() *memoryKeys {
+	return &memoryKeys{vals: make(map[string]memoryValue)}
+}
+
+// get returns the live value at key. The caller holds k.mu.
+func (k *memoryKeys) get(key string) (interface{}, bool) {
+	v, ok := k.vals[key]
+	if !ok {
+		return nil, false
+	}
+	if !v.expiresAt.IsZero() && !time.Now().Before(v.expiresAt) {
+		delete(k.vals, key)
+		return nil, false
+	}
+	return v.value, true
+}
+
+// set stores value at key for ttl, or without expiry for 0. The caller
+// holds k.mu.
+func (k *memoryKeys) set(key string, value interface{}, ttl time.Duration) {
+	v := memoryValue{value: value}
+	if ttl > 0 {
+		v.expiresAt = time.Now().Add(ttl)
+	}
+	k.vals[key] = v
+}
+
+// NewMemoryRepositoryContainer creates repositories that keep everything in
+// process memory, for tests and local development without Postgres or
+// Redis. Data is scoped by tenant whatever the isolation, and lost on exit.
+func NewMemoryRepositoryContainer(migrations ColumnMigrations, ttls CacheTTLs) *RepositoryContainer {
+	store := newMemoryStore()
+	keys := newMemoryKeys()
+
+	return &RepositoryContainer{
+		User:         &memoryUserRepository{store: store},
+		Event:        &memoryEventRepository{store: store},
+		Ticket:       &memoryTicketRepository{store: store},
+		Booking:      &memoryBookingRepository{store: store},
+		Presale:      &memoryPresaleCodeRepository{store: store},
+		Webhook:      &memoryWebhookRepository{store: store},
+		Outbox:       &memoryOutboxRepository{store: store},
+		Notification: &memoryNotificationRepository{store: store},
+		Template:     &memoryTemplateRepository{store: store},
+		TicketPass:   &memoryTicketPassRepository{store: store},
+		Hold:         &memoryHoldRepository{store: store},
+		Job:          &memoryJobRepository{store: store},
+		Refund:       &memoryRefundRepository{store: store},
+		Broadcast:    &memoryBroadcastRepository{store: store},
+		EventStats:   &memoryEventStatsRepository{store: store},
+		SalesReport:  &memorySalesReportRepository{store: store},
+		DeadLetter:   &memoryDeadLetterRepository{store: store},
+
+		BookingExport: &memoryBookingExportRepository{store: store},
+		Audit:         &memoryAuditRepository{store: store},
+
+		BookingTransition: &memoryBookingTransitionRepository{store: store},
+		BookingEvent:      &memoryBookingEventRepository{store: store},
+		ReadModel:         &memoryReadModelRepository{store: store},
+
+		ColumnMigration: &memoryColumnMigrationRepository{migrations: migrations},
+
+		Transactor:      store,
+		UserCache:       &memoryUserCacheRepository{keys: keys, ttls: ttls},
+		EventCache:      &memoryEventCacheRepository{keys: keys, ttls: ttls},
+		BookingCache:    &memoryBookingCacheRepository{keys: keys},
+		EventStatsCache: &memoryEventStatsCacheRepository{keys: keys},
+		Availability:    &memoryAvailabilityRepository{keys: keys},
+		WaitingRoom:     &memoryWaitingRoomRepository{keys: keys},
+
+		SeatSuggestion: &memorySeatSuggestionRepository{keys: keys},
+		BookingQueue:   newMemoryBookingQueueRepository(),
+		Lease:          &memoryLeaseRepository{keys: keys},
+
+		CacheInvalidation: &memoryCacheInvalidationRepository{},
+		AvailabilityFeed:  &memoryAvailabilityFeedRepository{},
+		BookingUpdates:    &memoryBookingUpdateRepository{keys: keys},
+
+		BookingMetrics: &memoryBookingMetricsRepository{keys: keys},
+		Session:        &memorySessionRepository{keys: keys},
+
+		Identity:  &memoryIdentityRepository{store: store},
+		APIKey:    &memoryAPIKeyRepository{store: store},
+		RateLimit: &memoryRateLimitRepository{keys: keys},
+
+		PasswordReset: &memoryPasswordResetRepository{keys: keys},
+	}
+}
@@ -0,0 +1,989 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/utils/querybuilder"
+
+	"github.com/google/uuid"
+)
+
+// In-memory User Repository
+type memoryUserRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, usr *domain_user.User) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.users[usr.ID]; ok {
+			return duplicate("user", usr.ID)
+		}
+		if userWithEmail(t, usr.Email, uuid.Nil) {
+			return domain_user.ErrEmailTaken
+		}
+		stored := *usr
+		stored.Role = userRole(usr)
+		t.users[usr.ID] = stored
+		return nil
+	})
+}
+
+// userWithEmail reports whether a user other than except has the email
+func userWithEmail(t *memoryTables, email string, except uuid.UUID) bool {
+	for id, usr := range t.users {
+		if usr.Email == email && id != except {
+			return true
+		}
+	}
+	return false
+}
+
+// liveUser returns the user unless they are missing or soft deleted
+func liveUser(t *memoryTables, id uuid.UUID) (domain_user.User, bool) {
+	usr, ok := t.users[id]
+	return usr, ok && usr.DeletedAt == nil
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_user.User, error) {
+	var usr domain_user.User
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if usr, ok = liveUser(t, id); !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &usr, nil
+}
+
+func (r *memoryUserRepository) GetByEmail(ctx context.Context, email string) (*domain_user.User, error) {
+	var found *domain_user.User
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, usr := range t.users {
+			if usr.Email == email && usr.DeletedAt == nil {
+				usr := usr
+				found = &usr
+				return nil
+			}
+		}
+		return domain.ErrNotFound
+	})
+	return found, err
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, usr *domain_user.User) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := liveUser(t, usr.ID)
+		if !ok {
+			return domain.ErrNotFound
+		}
+		if userWithEmail(t, usr.Email, usr.ID) {
+			return domain_user.ErrEmailTaken
+		}
+		stored.Email, stored.Name, stored.Role, stored.UpdatedAt = usr.Email, usr.Name, userRole(usr), usr.UpdatedAt
+		stored.EmailVerifiedAt, stored.LockedAt = usr.EmailVerifiedAt, usr.LockedAt
+		t.users[usr.ID] = stored
+		return nil
+	})
+}
+
+// Delete soft deletes the user, keeping their bookings
+func (r *memoryUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		usr, ok := liveUser(t, id)
+		if !ok {
+			return domain.ErrNotFound
+		}
+		now := time.Now()
+		usr.DeletedAt = &now
+		t.users[id] = usr
+		return nil
+	})
+}
+
+// Restore undoes Delete
+func (r *memoryUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		usr, ok := t.users[id]
+		if !ok || usr.DeletedAt == nil {
+			return domain.ErrNotFound
+		}
+		usr.DeletedAt = nil
+		t.users[id] = usr
+		return nil
+	})
+}
+
+// Purge removes a deleted user for good, with their bookings, as the
+// foreign keys cascade. It is refused while any of their bookings holds
+// tickets.
+func (r *memoryUserRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if usr, ok := t.users[id]; !ok || usr.DeletedAt == nil {
+			return domain.ErrNotFound
+		}
+		for _, bk := range t.bookings {
+			if bk.UserID == id && bk.Status.TicketStatus() != domain_ticket.TicketStatusAvailable {
+				return domain_booking.ErrActiveBookings
+			}
+		}
+		delete(t.users, id)
+		delete(t.passwords, id)
+		delete(t.preferences, id)
+		for bookingID, bk := range t.bookings {
+			if bk.UserID == id {
+				deleteBooking(t, bookingID)
+			}
+		}
+		for key := range t.recipients {
+			if key.userID == id {
+				delete(t.recipients, key)
+			}
+		}
+		for key, identity := range t.identities {
+			if identity.UserID == id {
+				delete(t.identities, key)
+			}
+		}
+		for keyID, key := range t.apiKeys {
+			if key.UserID == id {
+				delete(t.apiKeys, keyID)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *memoryUserRepository) GetPasswordHash(ctx context.Context, id uuid.UUID) (string, error) {
+	var hash string
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		if _, ok := liveUser(t, id); !ok {
+			return domain.ErrNotFound
+		}
+		hash = t.passwords[id]
+		return nil
+	})
+	return hash, err
+}
+
+func (r *memoryUserRepository) SetPasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := liveUser(t, id); !ok {
+			return domain.ErrNotFound
+		}
+		t.passwords[id] = hash
+		return nil
+	})
+}
+
+// Search applies the filter the way buildUserSearchQuery does in SQL
+func (r *memoryUserRepository) Search(ctx context.Context, filter domain_user.UserFilter) ([]*domain_user.User, error) {
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrInvalidInput)
+	}
+
+	prefix := strings.ToLower(filter.EmailPrefix)
+	var users []*domain_user.User
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, usr := range t.users {
+			if usr.DeletedAt != nil || !strings.HasPrefix(strings.ToLower(usr.Email), prefix) {
+				continue
+			}
+			if filter.CreatedFrom != nil && usr.CreatedAt.Before(*filter.CreatedFrom) {
+				continue
+			}
+			if filter.CreatedTo != nil && usr.CreatedAt.After(*filter.CreatedTo) {
+				continue
+			}
+			usr := usr
+			users = append(users, &usr)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.After(users[j].CreatedAt)
+		}
+		// Tie-break on id so paging is stable
+		return users[i].ID.String() < users[j].ID.String()
+	})
+
+	limit := filter.Limit
+	if limit == 0 || limit > maxUserSearchLimit {
+		limit = maxUserSearchLimit
+	}
+	if filter.Offset >= len(users) {
+		return nil, nil
+	}
+	users = users[filter.Offset:]
+	if len(users) > limit {
+		users = users[:limit]
+	}
+	return users, nil
+}
+
+// In-memory Event Repository
+type memoryEventRepository struct {
+	store *memoryStore
+}
+
+// storedEvent drops the fields computed on read, which are not stored
+func storedEvent(evt *domain_event.Event) domain_event.Event {
+	stored := *evt
+	stored.SalesState = ""
+	stored.OnSaleInSeconds = nil
+	return stored
+}
+
+func (r *memoryEventRepository) Create(ctx context.Context, evt *domain_event.Event) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.events[evt.ID]; ok {
+			return duplicate("event", evt.ID)
+		}
+		t.events[evt.ID] = storedEvent(evt)
+		return nil
+	})
+}
+
+// liveEvent returns the event unless it is missing or soft deleted
+func liveEvent(t *memoryTables, id uuid.UUID) (domain_event.Event, bool) {
+	evt, ok := t.events[id]
+	return evt, ok && evt.DeletedAt == nil
+}
+
+func (r *memoryEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_event.Event, error) {
+	var evt domain_event.Event
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if evt, ok = liveEvent(t, id); !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &evt, nil
+}
+
+func (r *memoryEventRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_event.Event, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return r.selectEvents(ctx, func(evt *domain_event.Event) bool {
+		for _, id := range ids {
+			if evt.ID == id {
+				return true
+			}
+		}
+		return false
+	}, nil)
+}
+
+func (r *memoryEventRepository) GetAll(ctx context.Context) ([]*domain_event.Event, error) {
+	return r.selectEvents(ctx, nil, func(a, b *domain_event.Event) bool {
+		return a.Date.Before(b.Date)
+	})
+}
+
+// selectEvents returns the events matching where, or all of them, sorted by
+// less if given. Deleted events are skipped.
+func (r *memoryEventRepository) selectEvents(ctx context.Context, where func(*domain_event.Event) bool, less func(a, b *domain_event.Event) bool) ([]*domain_event.Event, error) {
+	var events []*domain_event.Event
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, evt := range t.events {
+			evt := evt
+			if evt.DeletedAt == nil && (where == nil || where(&evt)) {
+				events = append(events, &evt)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if less != nil {
+		sort.SliceStable(events, func(i, j int) bool { return less(events[i], events[j]) })
+	}
+	return events, nil
+}
+
+func (r *memoryEventRepository) Update(ctx context.Context, evt *domain_event.Event) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := liveEvent(t, evt.ID)
+		if !ok {
+			return domain.ErrNotFound
+		}
+		updated := storedEvent(evt)
+		updated.DeletedAt = nil
+		updated.CreatedAt = stored.CreatedAt
+		t.events[evt.ID] = updated
+		return nil
+	})
+}
+
+// Delete soft deletes the event, keeping its tickets and bookings
+func (r *memoryEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		evt, ok := liveEvent(t, id)
+		if !ok {
+			return domain.ErrNotFound
+		}
+		now := time.Now()
+		evt.DeletedAt = &now
+		t.events[id] = evt
+		return nil
+	})
+}
+
+// Restore undoes Delete
+func (r *memoryEventRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		evt, ok := t.events[id]
+		if !ok || evt.DeletedAt == nil {
+			return domain.ErrNotFound
+		}
+		evt.DeletedAt = nil
+		t.events[id] = evt
+		return nil
+	})
+}
+
+// Purge removes a deleted event for good, with its tickets, bookings and
+// everything else that belongs to it, as the foreign keys cascade. It is
+// refused while any of its bookings holds tickets.
+func (r *memoryEventRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if evt, ok := t.events[id]; !ok || evt.DeletedAt == nil {
+			return domain.ErrNotFound
+		}
+		for _, bk := range t.bookings {
+			if bk.EventID == id && bk.Status.TicketStatus() != domain_ticket.TicketStatusAvailable {
+				return domain_booking.ErrActiveBookings
+			}
+		}
+		delete(t.events, id)
+		for bookingID, bk := range t.bookings {
+			if bk.EventID == id {
+				deleteBooking(t, bookingID)
+			}
+		}
+		for ticketID, tkt := range t.tickets {
+			if tkt.EventID == id {
+				delete(t.tickets, ticketID)
+				delete(t.ticketHolds, ticketID)
+			}
+		}
+		for codeID, code := range t.presaleCodes {
+			if code.EventID == id {
+				delete(t.presaleCodes, codeID)
+			}
+		}
+		for holdID, h := range t.holds {
+			if h.EventID == id {
+				delete(t.holds, holdID)
+			}
+		}
+		delete(t.policies, id)
+		for broadcastID, b := range t.broadcasts {
+			if b.EventID == id {
+				delete(t.broadcasts, broadcastID)
+				for key := range t.recipients {
+					if key.broadcastID == broadcastID {
+						delete(t.recipients, key)
+					}
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *memoryEventRepository) GetSalesWindowChanges(ctx context.Context, from, to time.Time) ([]*domain_event.Event, error) {
+	within := func(at *time.Time) bool {
+		return at != nil && at.After(from) && !at.After(to)
+	}
+	return r.selectEvents(ctx, func(evt *domain_event.Event) bool {
+		return within(evt.SalesStartAt) || within(evt.SalesEndAt) || within(evt.PresaleStartAt)
+	}, nil)
+}
+
+// Search applies the filter the way buildEventSearchQuery does in SQL
+func (r *memoryEventRepository) Search(ctx context.Context, filter domain_event.EventFilter) ([]*domain_event.Event, error) {
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: unknown event status %q", domain.ErrInvalidInput, status)
+		}
+	}
+	sortBy := filter.SortBy
+	if sortBy == "" {
+		sortBy = "date"
+	}
+	if _, ok := eventSortColumns[sortBy]; !ok {
+		return nil, fmt.Errorf("%w: cannot sort by %q", domain.ErrInvalidInput, filter.SortBy)
+	}
+	direction, err := querybuilder.ParseSortDirection(filter.SortDir)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidInput, err)
+	}
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return nil, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrInvalidInput)
+	}
+
+	query := strings.ToLower(filter.Query)
+	contains := func(s string) bool { return strings.Contains(strings.ToLower(s), query) }
+	events, err := r.selectEvents(ctx, func(evt *domain_event.Event) bool {
+		if query != "" && !contains(evt.Name) && !contains(evt.Artist) && !contains(evt.Venue) {
+			return false
+		}
+		if filter.Artist != "" && evt.Artist != filter.Artist {
+			return false
+		}
+		if filter.Venue != "" && evt.Venue != filter.Venue {
+			return false
+		}
+		if len(filter.Statuses) > 0 && !hasEventStatus(filter.Statuses, evt.Status) {
+			return false
+		}
+		if filter.DateFrom != nil && evt.Date.Before(*filter.DateFrom) {
+			return false
+		}
+		if filter.DateTo != nil && evt.Date.After(*filter.DateTo) {
+			return false
+		}
+		if filter.MinPrice != nil && evt.Price < *filter.MinPrice {
+			return false
+		}
+		if filter.MaxPrice != nil && evt.Price > *filter.MaxPrice {
+			return false
+		}
+		return true
+	}, func(a, b *domain_event.Event) bool {
+		if c := compareEvents(sortBy, a, b); c != 0 {
+			return (c < 0) == (direction == querybuilder.Asc)
+		}
+		// Tie-break on id so paging is stable
+		return a.ID.String() < b.ID.String()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	limit := filter.Limit
+	if limit == 0 || limit > maxEventSearchLimit {
+		limit = maxEventSearchLimit
+	}
+	if filter.Offset >= len(events) {
+		return nil, nil
+	}
+	events = events[filter.Offset:]
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func hasEventStatus(statuses []domain_event.EventStatus, status domain_event.EventStatus) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// compareEvents orders two events by one of eventSortColumns
+func compareEvents(sortBy string, a, b *domain_event.Event) int {
+	switch sortBy {
+	case "name":
+		return strings.Compare(a.Name, b.Name)
+	case "price":
+		switch {
+		case a.Price < b.Price:
+			return -1
+		case a.Price > b.Price:
+			return 1
+		}
+		return 0
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	}
+	return a.Date.Compare(b.Date)
+}
+
+// In-memory Ticket Repository
+type memoryTicketRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryTicketRepository) Create(ctx context.Context, tkt *domain_ticket.Ticket) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.tickets[tkt.ID]; ok {
+			return duplicate("ticket", tkt.ID)
+		}
+		if _, ok := t.events[tkt.EventID]; !ok {
+			return missingParent("event", tkt.EventID)
+		}
+		for _, other := range t.tickets {
+			if other.EventID == tkt.EventID && other.SeatNumber == tkt.SeatNumber {
+				return duplicate("seat", tkt.SeatNumber)
+			}
+		}
+		t.tickets[tkt.ID] = *tkt
+		return nil
+	})
+}
+
+func (r *memoryTicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_ticket.Ticket, error) {
+	var tkt domain_ticket.Ticket
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if tkt, ok = t.tickets[id]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tkt, nil
+}
+
+func (r *memoryTicketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var tickets []*domain_ticket.Ticket
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		seen := make(map[uuid.UUID]bool, len(ids))
+		for _, id := range ids {
+			if tkt, ok := t.tickets[id]; ok && !seen[id] {
+				seen[id] = true
+				tickets = append(tickets, &tkt)
+			}
+		}
+		return nil
+	})
+	return tickets, err
+}
+
+func (r *memoryTicketRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	return r.eventTickets(ctx, eventID, "")
+}
+
+func (r *memoryTicketRepository) GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	return r.eventTickets(ctx, eventID, domain_ticket.TicketStatusAvailable)
+}
+
+func (r *memoryTicketRepository) CountAvailable(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	tickets, err := r.eventTickets(ctx, eventID, domain_ticket.TicketStatusAvailable)
+	if err != nil {
+		return nil, err
+	}
+	availability := &domain_ticket.Availability{EventID: eventID, Tiers: make(map[int64]int64)}
+	for _, tkt := range tickets {
+		availability.Available++
+		availability.Tiers[domain_ticket.PriceCents(tkt.Price)]++
+	}
+	return availability, nil
+}
+
+func (r *memoryTicketRepository) CountByStatus(ctx context.Context, eventID uuid.UUID, sectionSize int) ([]*domain_ticket.StatusCount, error) {
+	tickets, err := r.eventTickets(ctx, eventID, "")
+	if err != nil {
+		return nil, err
+	}
+	type group struct {
+		status  domain_ticket.TicketStatus
+		price   float64
+		section int
+	}
+	var counts []*domain_ticket.StatusCount
+	index := make(map[group]*domain_ticket.StatusCount)
+	for _, tkt := range tickets {
+		key := group{tkt.Status, tkt.Price, (tkt.SeatNumber-1)/sectionSize + 1}
+		count, ok := index[key]
+		if !ok {
+			count = &domain_ticket.StatusCount{Status: key.status, Price: key.price, Section: key.section}
+			index[key] = count
+			counts = append(counts, count)
+		}
+		count.Tickets++
+	}
+	return counts, nil
+}
+
+// eventTickets returns an event's tickets in a status, or in any status if
+// it is empty, by seat number
+func (r *memoryTicketRepository) eventTickets(ctx context.Context, eventID uuid.UUID, status domain_ticket.TicketStatus) ([]*domain_ticket.Ticket, error) {
+	var tickets []*domain_ticket.Ticket
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, tkt := range t.tickets {
+			tkt := tkt
+			if tkt.EventID == eventID && (status == "" || tkt.Status == status) {
+				tickets = append(tickets, &tkt)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].SeatNumber < tickets[j].SeatNumber })
+	return tickets, nil
+}
+
+func (r *memoryTicketRepository) Update(ctx context.Context, tkt *domain_ticket.Ticket) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := t.tickets[tkt.ID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		stored.Status, stored.UpdatedAt = tkt.Status, tkt.UpdatedAt
+		t.tickets[tkt.ID] = stored
+		return nil
+	})
+}
+
+func (r *memoryTicketRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.tickets[id]; !ok {
+			return domain.ErrNotFound
+		}
+		delete(t.tickets, id)
+		delete(t.ticketHolds, id)
+		return nil
+	})
+}
+
+// ReserveTickets moves tickets from available to reserved, all of them or
+// none, returning domain.ErrConflict for the first that is not available
+func (r *memoryTicketRepository) ReserveTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		reserved := ticketsInStatus(t, ticketIDs, domain_ticket.TicketStatusAvailable)
+		if err := unreservedTicket(ticketIDs, reserved); err != nil {
+			return err
+		}
+		setTicketStatus(t, reserved, domain_ticket.TicketStatusReserved)
+		return nil
+	})
+}
+
+func (r *memoryTicketRepository) ConfirmTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		reserved := ticketsInStatus(t, ticketIDs, domain_ticket.TicketStatusReserved)
+		if len(reserved) != len(ticketIDs) {
+			return fmt.Errorf("not all tickets could be confirmed")
+		}
+		setTicketStatus(t, reserved, domain_ticket.TicketStatusSold)
+		return nil
+	})
+}
+
+func (r *memoryTicketRepository) ReleaseTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		released := ticketsInStatus(t, ticketIDs, domain_ticket.TicketStatusReserved, domain_ticket.TicketStatusCancelled)
+		setTicketStatus(t, released, domain_ticket.TicketStatusAvailable)
+		return nil
+	})
+}
+
+// RestockTickets puts sold tickets back on sale after a refund
+func (r *memoryTicketRepository) RestockTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		sold := ticketsInStatus(t, ticketIDs, domain_ticket.TicketStatusSold)
+		setTicketStatus(t, sold, domain_ticket.TicketStatusAvailable)
+		return nil
+	})
+}
+
+// ticketsInStatus returns the distinct tickets of ids in one of statuses
+func ticketsInStatus(t *memoryTables, ids []uuid.UUID, statuses ...domain_ticket.TicketStatus) []uuid.UUID {
+	var matched []uuid.UUID
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		tkt, ok := t.tickets[id]
+		if !ok || seen[id] {
+			continue
+		}
+		for _, status := range statuses {
+			if tkt.Status == status {
+				seen[id] = true
+				matched = append(matched, id)
+				break
+			}
+		}
+	}
+	return matched
+}
+
+func setTicketStatus(t *memoryTables, ids []uuid.UUID, status domain_ticket.TicketStatus) {
+	now := time.Now()
+	for _, id := range ids {
+		tkt := t.tickets[id]
+		tkt.Status, tkt.UpdatedAt = status, now
+		t.tickets[id] = tkt
+	}
+}
+
+// In-memory Booking Repository
+type memoryBookingRepository struct {
+	store *memoryStore
+}
+
+// Create stores a booking together with its tickets and line items
+func (r *memoryBookingRepository) Create(ctx context.Context, bk *domain_booking.Booking) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.bookings[bk.ID]; ok {
+			return duplicate("booking", bk.ID)
+		}
+		if _, ok := t.users[bk.UserID]; !ok {
+			return missingParent("user", bk.UserID)
+		}
+		if _, ok := t.events[bk.EventID]; !ok {
+			return missingParent("event", bk.EventID)
+		}
+		t.bookings[bk.ID] = storedBooking(bk)
+		if len(bk.Items) > 0 {
+			t.bookingItems[bk.ID] = append([]domain_booking.LineItem(nil), bk.Items...)
+		}
+		return nil
+	})
+}
+
+// storedBooking copies a booking for storing, with its own ticket list and
+// without the line items, which are kept apart
+func storedBooking(bk *domain_booking.Booking) domain_booking.Booking {
+	stored := *bk
+	stored.TicketIDs = append([]uuid.UUID{}, bk.TicketIDs...)
+	stored.Items = nil
+	stored.Changes = nil
+	return stored
+}
+
+// loadedBooking copies a stored booking for returning, as a query would
+func loadedBooking(bk domain_booking.Booking) *domain_booking.Booking {
+	bk.TicketIDs = append([]uuid.UUID{}, bk.TicketIDs...)
+	return &bk
+}
+
+// deleteBooking removes a booking with the rows that cascade from it
+func deleteBooking(t *memoryTables, id uuid.UUID) {
+	delete(t.bookings, id)
+	delete(t.bookingItems, id)
+	for passID, pass := range t.passes {
+		if pass.BookingID == id {
+			delete(t.passes, passID)
+		}
+	}
+	for notificationID, n := range t.notifications {
+		if n.BookingID == id {
+			delete(t.notifications, notificationID)
+		}
+	}
+	for refundID, refund := range t.refunds {
+		if refund.BookingID == id {
+			delete(t.refunds, refundID)
+		}
+	}
+	for returnID, ret := range t.returns {
+		if ret.BookingID == id {
+			delete(t.returns, returnID)
+		}
+	}
+}
+
+// GetItems retrieves the line items of a booking
+func (r *memoryBookingRepository) GetItems(ctx context.Context, bookingID uuid.UUID) ([]domain_booking.LineItem, error) {
+	var items []domain_booking.LineItem
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		items = append(items, t.bookingItems[bookingID]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].SeatNumber < items[j].SeatNumber })
+	return items, nil
+}
+
+// SwapItems replaces the line items of tickets taken off a booking with
+// the items of the tickets that took their place
+func (r *memoryBookingRepository) SwapItems(ctx context.Context, bookingID uuid.UUID, removed []uuid.UUID, added []domain_booking.LineItem) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if len(added) > 0 {
+			if _, ok := t.bookings[bookingID]; !ok {
+				return missingParent("booking", bookingID)
+			}
+		}
+		gone := make(map[uuid.UUID]bool, len(removed))
+		for _, ticketID := range removed {
+			gone[ticketID] = true
+		}
+		var items []domain_booking.LineItem
+		for _, item := range t.bookingItems[bookingID] {
+			if !gone[item.TicketID] {
+				items = append(items, item)
+			}
+		}
+		items = append(items, added...)
+		t.bookingItems[bookingID] = items
+		return nil
+	})
+}
+
+func (r *memoryBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_booking.Booking, error) {
+	var found *domain_booking.Booking
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		bk, ok := t.bookings[id]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		found = loadedBooking(bk)
+		return nil
+	})
+	return found, err
+}
+
+// GetByTicketID retrieves the pending or confirmed booking holding a ticket
+func (r *memoryBookingRepository) GetByTicketID(ctx context.Context, ticketID uuid.UUID) (*domain_booking.Booking, error) {
+	bookings, err := r.selectBookings(ctx, func(t *memoryTables, bk *domain_booking.Booking) bool {
+		if bk.Status != domain_booking.BookingStatusPending && bk.Status != domain_booking.BookingStatusConfirmed {
+			return false
+		}
+		for _, id := range bk.TicketIDs {
+			if id == ticketID {
+				return true
+			}
+		}
+		return false
+	}, newestBookingFirst)
+	if err != nil {
+		return nil, err
+	}
+	if len(bookings) == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return bookings[0], nil
+}
+
+func (r *memoryBookingRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error) {
+	return r.selectBookings(ctx, func(t *memoryTables, bk *domain_booking.Booking) bool {
+		return bk.UserID == userID
+	}, newestBookingFirst)
+}
+
+func (r *memoryBookingRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_booking.Booking, error) {
+	return r.selectBookings(ctx, func(t *memoryTables, bk *domain_booking.Booking) bool {
+		return bk.EventID == eventID
+	}, newestBookingFirst)
+}
+
+// selectBookings returns the bookings matching where, sorted by less
+func (r *memoryBookingRepository) selectBookings(ctx context.Context, where func(t *memoryTables, bk *domain_booking.Booking) bool, less func(t *memoryTables, a, b *domain_booking.Booking) bool) ([]*domain_booking.Booking, error) {
+	var bookings []*domain_booking.Booking
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, bk := range t.bookings {
+			if where(t, &bk) {
+				bookings = append(bookings, loadedBooking(bk))
+			}
+		}
+		sort.SliceStable(bookings, func(i, j int) bool { return less(t, bookings[i], bookings[j]) })
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bookings, nil
+}
+
+func newestBookingFirst(t *memoryTables, a, b *domain_booking.Booking) bool {
+	return a.CreatedAt.After(b.CreatedAt)
+}
+
+func expiringBookingFirst(t *memoryTables, a, b *domain_booking.Booking) bool {
+	return a.ExpiresAt.Before(b.ExpiresAt)
+}
+
+// Update saves a booking and replaces the tickets it holds
+func (r *memoryBookingRepository) Update(ctx context.Context, bk *domain_booking.Booking) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := t.bookings[bk.ID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		stored.Status = bk.Status
+		stored.TotalAmount = bk.TotalAmount
+		stored.PaymentReference = bk.PaymentReference
+		stored.RiskScore = bk.RiskScore
+		stored.UpdatedAt = bk.UpdatedAt
+		stored.ExpiresAt = bk.ExpiresAt
+		stored.TicketIDs = append([]uuid.UUID{}, bk.TicketIDs...)
+		t.bookings[bk.ID] = stored
+		return nil
+	})
+}
+
+func (r *memoryBookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.bookings[id]; !ok {
+			return domain.ErrNotFound
+		}
+		deleteBooking(t, id)
+		return nil
+	})
+}
+
+func (r *memoryBookingRepository) GetExpiredBookings(ctx context.Context, before time.Time) ([]*domain_booking.Booking, error) {
+	return r.selectBookings(ctx, func(t *memoryTables, bk *domain_booking.Booking) bool {
+		return bk.ExpiresAt.Before(before) && bk.Status == domain_booking.BookingStatusPending
+	}, expiringBookingFirst)
+}
+
+// GetByStatus retrieves the bookings in a status, those expiring first first
+func (r *memoryBookingRepository) GetByStatus(ctx context.Context, status domain_booking.BookingStatus) ([]*domain_booking.Booking, error) {
+	return r.selectBookings(ctx, func(t *memoryTables, bk *domain_booking.Booking) bool {
+		return bk.Status == status
+	}, expiringBookingFirst)
+}
+
+// GetExpiringBetween retrieves pending bookings whose hold runs out in (from, to]
+func (r *memoryBookingRepository) GetExpiringBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error) {
+	return r.selectBookings(ctx, func(t *memoryTables, bk *domain_booking.Booking) bool {
+		return bk.ExpiresAt.After(from) && !bk.ExpiresAt.After(to) && bk.Status == domain_booking.BookingStatusPending
+	}, expiringBookingFirst)
+}
+
+// GetConfirmedForEventsBetween retrieves confirmed bookings for events taking place in (from, to]
+func (r *memoryBookingRepository) GetConfirmedForEventsBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error) {
+	return r.selectBookings(ctx, func(t *memoryTables, bk *domain_booking.Booking) bool {
+		evt, ok := liveEvent(t, bk.EventID)
+		return ok && evt.Date.After(from) && !evt.Date.After(to) && bk.Status == domain_booking.BookingStatusConfirmed
+	}, func(t *memoryTables, a, b *domain_booking.Booking) bool {
+		return t.events[a.EventID].Date.Before(t.events[b.EventID].Date)
+	})
+}
@@ -0,0 +1,1795 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_deadletter "github.com/ojaswiii/booking-manager/src/internal/domain/deadletter"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	domain_migration "github.com/ojaswiii/booking-manager/src/internal/domain/migration"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
+	domain_presale "github.com/ojaswiii/booking-manager/src/internal/domain/presale"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+
+	"github.com/google/uuid"
+)
+
+// claimLimit caps a claimed batch at limit, as a LIMIT clause would
+func claimLimit(n, limit int) int {
+	if limit >= 0 && n > limit {
+		return limit
+	}
+	return n
+}
+
+// In-memory Presale Code Repository
+type memoryPresaleCodeRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryPresaleCodeRepository) CreateBatch(ctx context.Context, codes []*domain_presale.PresaleCode) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		taken := make(map[string]bool)
+		for _, code := range t.presaleCodes {
+			taken[code.EventID.String()+"/"+code.Code] = true
+		}
+		for _, code := range codes {
+			if _, ok := t.events[code.EventID]; !ok {
+				return missingParent("event", code.EventID)
+			}
+			key := code.EventID.String() + "/" + code.Code
+			if _, ok := t.presaleCodes[code.ID]; ok || taken[key] {
+				return duplicate("presale code", code.Code)
+			}
+			taken[key] = true
+		}
+		for _, code := range codes {
+			t.presaleCodes[code.ID] = *code
+		}
+		return nil
+	})
+}
+
+func (r *memoryPresaleCodeRepository) GetBatch(ctx context.Context, eventID, batchID uuid.UUID) ([]*domain_presale.PresaleCode, error) {
+	var codes []*domain_presale.PresaleCode
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, code := range t.presaleCodes {
+			code := code
+			if code.EventID == eventID && code.BatchID == batchID {
+				codes = append(codes, &code)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if !codes[i].CreatedAt.Equal(codes[j].CreatedAt) {
+			return codes[i].CreatedAt.Before(codes[j].CreatedAt)
+		}
+		return codes[i].Code < codes[j].Code
+	})
+	return codes, nil
+}
+
+func (r *memoryPresaleCodeRepository) Redeem(ctx context.Context, eventID uuid.UUID, code string, userID uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		for id, c := range t.presaleCodes {
+			if c.EventID != eventID || c.Code != code {
+				continue
+			}
+			// Redeeming the same code twice as the same user is a no-op
+			if c.RedeemedBy != nil && *c.RedeemedBy != userID {
+				return domain.ErrConflict
+			}
+			redeemer := userID
+			c.RedeemedBy = &redeemer
+			if c.RedeemedAt == nil {
+				now := time.Now()
+				c.RedeemedAt = &now
+			}
+			t.presaleCodes[id] = c
+			return nil
+		}
+		return domain.ErrNotFound
+	})
+}
+
+func (r *memoryPresaleCodeRepository) HasEntitlement(ctx context.Context, eventID, userID uuid.UUID) (bool, error) {
+	var exists bool
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, c := range t.presaleCodes {
+			if c.EventID == eventID && c.RedeemedBy != nil && *c.RedeemedBy == userID {
+				exists = true
+				break
+			}
+		}
+		return nil
+	})
+	return exists, err
+}
+
+// In-memory Webhook Repository
+type memoryWebhookRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryWebhookRepository) CreateSubscription(ctx context.Context, sub *domain_webhook.Subscription) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.subscriptions[sub.ID]; ok {
+			return duplicate("webhook subscription", sub.ID)
+		}
+		stored := *sub
+		stored.EventTypes = append(domain_webhook.EventTypeList(nil), sub.EventTypes...)
+		t.subscriptions[sub.ID] = stored
+		return nil
+	})
+}
+
+func (r *memoryWebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*domain_webhook.Subscription, error) {
+	var sub domain_webhook.Subscription
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if sub, ok = t.subscriptions[id]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *memoryWebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain_webhook.Subscription, error) {
+	var subs []*domain_webhook.Subscription
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, sub := range t.subscriptions {
+			sub := sub
+			subs = append(subs, &sub)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].CreatedAt.Before(subs[j].CreatedAt) })
+	return subs, nil
+}
+
+// DeleteSubscription removes the subscription with its deliveries
+func (r *memoryWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.subscriptions[id]; !ok {
+			return domain.ErrNotFound
+		}
+		delete(t.subscriptions, id)
+		for deliveryID, d := range t.deliveries {
+			if d.SubscriptionID == id {
+				delete(t.deliveries, deliveryID)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *memoryWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*domain_webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		for _, d := range deliveries {
+			if _, ok := t.subscriptions[d.SubscriptionID]; !ok {
+				return missingParent("webhook subscription", d.SubscriptionID)
+			}
+			if _, ok := t.deliveries[d.ID]; ok {
+				return duplicate("webhook delivery", d.ID)
+			}
+		}
+		for _, d := range deliveries {
+			t.deliveries[d.ID] = *d
+		}
+		return nil
+	})
+}
+
+// ClaimDueDeliveries leases pending deliveries that are due by pushing their
+// next attempt into the future, so concurrent dispatchers never send the same one
+func (r *memoryWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_webhook.Delivery, error) {
+	var claimed []*domain_webhook.Delivery
+	err := r.store.write(ctx, func(t *memoryTables) error {
+		var due []domain_webhook.Delivery
+		for _, d := range t.deliveries {
+			if d.Status == domain_webhook.DeliveryStatusPending && !d.NextAttemptAt.After(now) {
+				due = append(due, d)
+			}
+		}
+		sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+		for _, d := range due[:claimLimit(len(due), limit)] {
+			d := d
+			d.NextAttemptAt = now.Add(lease)
+			t.deliveries[d.ID] = d
+			claimed = append(claimed, &d)
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *memoryWebhookRepository) UpdateDelivery(ctx context.Context, d *domain_webhook.Delivery) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := t.deliveries[d.ID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		stored.Status = d.Status
+		stored.Attempts = d.Attempts
+		stored.NextAttemptAt = d.NextAttemptAt
+		stored.LastStatusCode = d.LastStatusCode
+		stored.LastError = d.LastError
+		stored.UpdatedAt = d.UpdatedAt
+		t.deliveries[d.ID] = stored
+		return nil
+	})
+}
+
+func (r *memoryWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*domain_webhook.Delivery, error) {
+	var deliveries []*domain_webhook.Delivery
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, d := range t.deliveries {
+			d := d
+			if d.SubscriptionID == subscriptionID {
+				deliveries = append(deliveries, &d)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].CreatedAt.After(deliveries[j].CreatedAt) })
+	return deliveries[:claimLimit(len(deliveries), limit)], nil
+}
+
+// In-memory Outbox Repository
+type memoryOutboxRepository struct {
+	store *memoryStore
+}
+
+// Append records messages; called inside WithinTx it commits with the state change
+func (r *memoryOutboxRepository) Append(ctx context.Context, messages ...*domain_outbox.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		for _, m := range messages {
+			if _, ok := t.outbox[m.ID]; ok {
+				return duplicate("outbox message", m.ID)
+			}
+		}
+		for _, m := range messages {
+			stored := *m
+			stored.LastError, stored.PublishedAt = nil, nil
+			t.outbox[m.ID] = stored
+		}
+		return nil
+	})
+}
+
+// ClaimDue leases unpublished messages that are due, oldest first, so
+// concurrent relays never publish the same batch at the same time
+func (r *memoryOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_outbox.Message, error) {
+	var claimed []*domain_outbox.Message
+	err := r.store.write(ctx, func(t *memoryTables) error {
+		var due []domain_outbox.Message
+		for _, m := range t.outbox {
+			if m.PublishedAt == nil && !m.NextAttemptAt.After(now) {
+				due = append(due, m)
+			}
+		}
+		sort.Slice(due, func(i, j int) bool { return due[i].CreatedAt.Before(due[j].CreatedAt) })
+		for _, m := range due[:claimLimit(len(due), limit)] {
+			m := m
+			m.NextAttemptAt = now.Add(lease)
+			t.outbox[m.ID] = m
+			claimed = append(claimed, &m)
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *memoryOutboxRepository) MarkPublished(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		for _, id := range ids {
+			if m, ok := t.outbox[id]; ok {
+				published := at
+				m.PublishedAt, m.LastError = &published, nil
+				t.outbox[id] = m
+			}
+		}
+		return nil
+	})
+}
+
+func (r *memoryOutboxRepository) MarkFailed(ctx context.Context, m *domain_outbox.Message) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := t.outbox[m.ID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		stored.Attempts, stored.NextAttemptAt, stored.LastError = m.Attempts, m.NextAttemptAt, m.LastError
+		t.outbox[m.ID] = stored
+		return nil
+	})
+}
+
+func (r *memoryOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := r.store.write(ctx, func(t *memoryTables) error {
+		for id, m := range t.outbox {
+			if m.PublishedAt != nil && m.PublishedAt.Before(before) {
+				delete(t.outbox, id)
+				deleted++
+			}
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// In-memory Notification Repository
+type memoryNotificationRepository struct {
+	store *memoryStore
+}
+
+// Enqueue queues notifications, skipping any a booking has already been sent
+func (r *memoryNotificationRepository) Enqueue(ctx context.Context, notifications ...*domain_notification.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		type bookingKind struct {
+			bookingID uuid.UUID
+			kind      domain_notification.Kind
+		}
+		queued := make(map[bookingKind]bool)
+		for _, n := range t.notifications {
+			queued[bookingKind{n.BookingID, n.Kind}] = true
+		}
+		for _, n := range notifications {
+			if _, ok := t.notifications[n.ID]; ok {
+				return duplicate("notification", n.ID)
+			}
+		}
+		for _, n := range notifications {
+			key := bookingKind{n.BookingID, n.Kind}
+			if queued[key] {
+				continue
+			}
+			queued[key] = true
+			stored := *n
+			stored.LastError, stored.SentAt = nil, nil
+			t.notifications[n.ID] = stored
+		}
+		return nil
+	})
+}
+
+// ClaimDue leases pending notifications that are due by pushing their next
+// attempt into the future, so concurrent workers never send the same one
+func (r *memoryNotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_notification.Notification, error) {
+	var claimed []*domain_notification.Notification
+	err := r.store.write(ctx, func(t *memoryTables) error {
+		var due []domain_notification.Notification
+		for _, n := range t.notifications {
+			if n.Status == domain_notification.StatusPending && !n.NextAttemptAt.After(now) {
+				due = append(due, n)
+			}
+		}
+		sort.Slice(due, func(i, j int) bool { return due[i].NextAttemptAt.Before(due[j].NextAttemptAt) })
+		for _, n := range due[:claimLimit(len(due), limit)] {
+			n := n
+			n.NextAttemptAt = now.Add(lease)
+			t.notifications[n.ID] = n
+			claimed = append(claimed, &n)
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *memoryNotificationRepository) Update(ctx context.Context, n *domain_notification.Notification) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := t.notifications[n.ID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		stored.Status = n.Status
+		stored.Attempts = n.Attempts
+		stored.NextAttemptAt = n.NextAttemptAt
+		stored.LastError = n.LastError
+		stored.SentAt = n.SentAt
+		stored.UpdatedAt = n.UpdatedAt
+		t.notifications[n.ID] = stored
+		return nil
+	})
+}
+
+func (r *memoryNotificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain_notification.Preferences, error) {
+	var p domain_notification.Preferences
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if p, ok = t.preferences[userID]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SavePreferences creates or replaces a user's notification preferences
+func (r *memoryNotificationRepository) SavePreferences(ctx context.Context, p *domain_notification.Preferences) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.users[p.UserID]; !ok {
+			return missingParent("user", p.UserID)
+		}
+		stored := *p
+		if existing, ok := t.preferences[p.UserID]; ok {
+			stored.CreatedAt = existing.CreatedAt
+		}
+		t.preferences[p.UserID] = stored
+		return nil
+	})
+}
+
+func (r *memoryNotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_notification.Notification, error) {
+	var notifications []*domain_notification.Notification
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, n := range t.notifications {
+			if n.UserID == userID {
+				n := n
+				notifications = append(notifications, &n)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(notifications, func(i, j int) bool { return notifications[i].CreatedAt.Before(notifications[j].CreatedAt) })
+	return notifications, nil
+}
+
+// ForgetUser deletes a user's preferences and skips their pending
+// notifications, returning how many were skipped
+func (r *memoryNotificationRepository) ForgetUser(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	skipped := 0
+	err := r.store.write(ctx, func(t *memoryTables) error {
+		delete(t.preferences, userID)
+		for id, n := range t.notifications {
+			if n.UserID == userID && n.Status == domain_notification.StatusPending {
+				n.Status = domain_notification.StatusSkipped
+				n.UpdatedAt = at
+				t.notifications[id] = n
+				skipped++
+			}
+		}
+		return nil
+	})
+	return skipped, err
+}
+
+// In-memory Event Template Repository
+type memoryTemplateRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryTemplateRepository) Create(ctx context.Context, tmpl *domain_template.EventTemplate) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.templates[tmpl.ID]; ok {
+			return duplicate("event template", tmpl.ID)
+		}
+		t.templates[tmpl.ID] = *tmpl
+		return nil
+	})
+}
+
+func (r *memoryTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_template.EventTemplate, error) {
+	var tmpl domain_template.EventTemplate
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if tmpl, ok = t.templates[id]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tmpl, nil
+}
+
+func (r *memoryTemplateRepository) GetAll(ctx context.Context) ([]*domain_template.EventTemplate, error) {
+	var templates []*domain_template.EventTemplate
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, tmpl := range t.templates {
+			tmpl := tmpl
+			templates = append(templates, &tmpl)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].CreatedAt.Before(templates[j].CreatedAt)
+	})
+	return templates, nil
+}
+
+func (r *memoryTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.templates[id]; !ok {
+			return domain.ErrNotFound
+		}
+		delete(t.templates, id)
+		return nil
+	})
+}
+
+// In-memory Ticket Pass Repository
+type memoryTicketPassRepository struct {
+	store *memoryStore
+}
+
+// Create stores passes, keeping any already issued for the same booking and ticket
+func (r *memoryTicketPassRepository) Create(ctx context.Context, passes ...*domain_ticket.Pass) error {
+	if len(passes) == 0 {
+		return nil
+	}
+	return r.store.write(ctx, func(t *memoryTables) error {
+		type bookingTicket struct{ bookingID, ticketID uuid.UUID }
+		issued := make(map[bookingTicket]bool)
+		tokens := make(map[string]bool)
+		for _, p := range t.passes {
+			issued[bookingTicket{p.BookingID, p.TicketID}] = true
+			tokens[p.Token] = true
+		}
+		var added []*domain_ticket.Pass
+		for _, p := range passes {
+			key := bookingTicket{p.BookingID, p.TicketID}
+			if issued[key] {
+				continue
+			}
+			if _, ok := t.bookings[p.BookingID]; !ok {
+				return missingParent("booking", p.BookingID)
+			}
+			if _, ok := t.passes[p.ID]; ok || tokens[p.Token] {
+				return duplicate("ticket pass", p.ID)
+			}
+			issued[key], tokens[p.Token] = true, true
+			added = append(added, p)
+		}
+		for _, p := range added {
+			stored := *p
+			stored.CheckedInAt = nil
+			t.passes[p.ID] = stored
+		}
+		return nil
+	})
+}
+
+func (r *memoryTicketPassRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_ticket.Pass, error) {
+	var passes []*domain_ticket.Pass
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, p := range t.passes {
+			p := p
+			if p.BookingID == bookingID {
+				passes = append(passes, &p)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(passes, func(i, j int) bool {
+		if !passes[i].IssuedAt.Equal(passes[j].IssuedAt) {
+			return passes[i].IssuedAt.Before(passes[j].IssuedAt)
+		}
+		return passes[i].TicketID.String() < passes[j].TicketID.String()
+	})
+	return passes, nil
+}
+
+func (r *memoryTicketPassRepository) GetByToken(ctx context.Context, token string) (*domain_ticket.Pass, error) {
+	var found *domain_ticket.Pass
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, p := range t.passes {
+			if p.Token == token {
+				p := p
+				found = &p
+				return nil
+			}
+		}
+		return domain.ErrNotFound
+	})
+	return found, err
+}
+
+// CheckIn marks a pass as used. Only the first call succeeds; later ones
+// return domain.ErrConflict, so concurrent scans admit the ticket once.
+func (r *memoryTicketPassRepository) CheckIn(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		p, ok := t.passes[id]
+		if !ok || p.CheckedInAt != nil {
+			return domain.ErrConflict
+		}
+		checkedIn := at
+		p.CheckedInAt = &checkedIn
+		t.passes[id] = p
+		return nil
+	})
+}
+
+// CountCheckIns counts the passes of an event's confirmed bookings and how many have been used
+func (r *memoryTicketPassRepository) CountCheckIns(ctx context.Context, eventID uuid.UUID) (*domain_ticket.CheckInCounts, error) {
+	counts := domain_ticket.CheckInCounts{EventID: eventID}
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, p := range t.passes {
+			bk, ok := t.bookings[p.BookingID]
+			if !ok || bk.EventID != eventID || bk.Status != domain_booking.BookingStatusConfirmed {
+				continue
+			}
+			counts.Issued++
+			if p.CheckedInAt != nil {
+				counts.CheckedIn++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	counts.Remaining = counts.Issued - counts.CheckedIn
+	return &counts, nil
+}
+
+// Revoke deletes the passes of tickets that no longer belong to a booking
+func (r *memoryTicketPassRepository) Revoke(ctx context.Context, bookingID uuid.UUID, ticketIDs []uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		revoked := make(map[uuid.UUID]bool, len(ticketIDs))
+		for _, id := range ticketIDs {
+			revoked[id] = true
+		}
+		for id, p := range t.passes {
+			if p.BookingID == bookingID && revoked[p.TicketID] {
+				delete(t.passes, id)
+			}
+		}
+		return nil
+	})
+}
+
+// In-memory Seat Hold Repository
+type memoryHoldRepository struct {
+	store *memoryStore
+}
+
+// Create records the hold and takes its seats off sale. Every seat in the
+// range must be available; otherwise nothing is held and domain.ErrConflict
+// is returned.
+func (r *memoryHoldRepository) Create(ctx context.Context, h *domain_hold.Hold) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		var seats, unavailable []uuid.UUID
+		for id, tkt := range t.tickets {
+			if tkt.EventID != h.EventID || tkt.SeatNumber < h.FromSeat || tkt.SeatNumber > h.ToSeat {
+				continue
+			}
+			seats = append(seats, id)
+			if tkt.Status != domain_ticket.TicketStatusAvailable {
+				unavailable = append(unavailable, id)
+			}
+		}
+		if len(seats) == 0 {
+			return fmt.Errorf("%w: no seats between %d and %d", domain.ErrInvalidInput, h.FromSeat, h.ToSeat)
+		}
+		if _, ok := t.holds[h.ID]; ok {
+			return duplicate("hold", h.ID)
+		}
+		if len(unavailable) > 0 {
+			return fmt.Errorf("%w: %d of %d seats are not available", domain.ErrConflict, len(unavailable), len(seats))
+		}
+
+		h.Seats = len(seats)
+		t.holds[h.ID] = *h
+		setTicketStatus(t, seats, domain_ticket.TicketStatusHeld)
+		for _, id := range seats {
+			t.ticketHolds[id] = h.ID
+		}
+		return nil
+	})
+}
+
+func (r *memoryHoldRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_hold.Hold, error) {
+	var h domain_hold.Hold
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if h, ok = t.holds[id]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &h, nil
+}
+
+func (r *memoryHoldRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_hold.Hold, error) {
+	var holds []*domain_hold.Hold
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, h := range t.holds {
+			h := h
+			if h.EventID == eventID {
+				holds = append(holds, &h)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(holds, func(i, j int) bool {
+		if holds[i].FromSeat != holds[j].FromSeat {
+			return holds[i].FromSeat < holds[j].FromSeat
+		}
+		return holds[i].CreatedAt.Before(holds[j].CreatedAt)
+	})
+	return holds, nil
+}
+
+// Release ends an active hold and puts its seats back on sale, returning how
+// many were released. Releasing a hold twice returns domain.ErrConflict.
+func (r *memoryHoldRepository) Release(ctx context.Context, id uuid.UUID, at time.Time) (int, error) {
+	var released int
+	err := r.store.write(ctx, func(t *memoryTables) error {
+		h, ok := t.holds[id]
+		if !ok || h.Status != domain_hold.StatusActive {
+			return domain.ErrConflict
+		}
+		releasedAt := at
+		h.Status, h.ReleasedAt = domain_hold.StatusReleased, &releasedAt
+		t.holds[id] = h
+
+		var seats []uuid.UUID
+		for ticketID, holdID := range t.ticketHolds {
+			if holdID != id {
+				continue
+			}
+			delete(t.ticketHolds, ticketID)
+			if t.tickets[ticketID].Status == domain_ticket.TicketStatusHeld {
+				seats = append(seats, ticketID)
+			}
+		}
+		setTicketStatus(t, seats, domain_ticket.TicketStatusAvailable)
+		released = len(seats)
+		return nil
+	})
+	return released, err
+}
+
+// In-memory Job Repository
+type memoryJobRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryJobRepository) Create(ctx context.Context, job *domain_job.Job) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.jobs[job.ID]; ok {
+			return duplicate("job", job.ID)
+		}
+		t.jobs[job.ID] = domain_job.Job{
+			ID:        job.ID,
+			Kind:      job.Kind,
+			State:     job.State,
+			Params:    job.Params,
+			Progress:  job.Progress,
+			Attempts:  job.Attempts,
+			CreatedAt: job.CreatedAt,
+			UpdatedAt: job.UpdatedAt,
+		}
+		return nil
+	})
+}
+
+func (r *memoryJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_job.Job, error) {
+	var job domain_job.Job
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if job, ok = t.jobs[id]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// ClaimDue leases queued jobs, and running jobs whose worker stopped renewing
+// its lease, so concurrent workers never run the same job at once
+func (r *memoryJobRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_job.Job, error) {
+	var claimed []*domain_job.Job
+	err := r.store.write(ctx, func(t *memoryTables) error {
+		var due []domain_job.Job
+		for _, job := range t.jobs {
+			abandoned := job.State == domain_job.StateRunning && job.LeaseUntil != nil && !job.LeaseUntil.After(now)
+			if job.State == domain_job.StateQueued || abandoned {
+				due = append(due, job)
+			}
+		}
+		sort.Slice(due, func(i, j int) bool { return due[i].CreatedAt.Before(due[j].CreatedAt) })
+		for _, job := range due[:claimLimit(len(due), limit)] {
+			job := job
+			leaseUntil, startedAt := now.Add(lease), now
+			job.State = domain_job.StateRunning
+			job.Attempts++
+			job.LeaseUntil = &leaseUntil
+			if job.StartedAt == nil {
+				job.StartedAt = &startedAt
+			}
+			job.UpdatedAt = now
+			t.jobs[job.ID] = job
+			claimed = append(claimed, &job)
+		}
+		return nil
+	})
+	return claimed, err
+}
+
+func (r *memoryJobRepository) Update(ctx context.Context, job *domain_job.Job) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := t.jobs[job.ID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		stored.State = job.State
+		stored.Progress = job.Progress
+		stored.ResultURL = job.ResultURL
+		stored.Error = job.Error
+		stored.LeaseUntil = job.LeaseUntil
+		stored.FinishedAt = job.FinishedAt
+		stored.UpdatedAt = job.UpdatedAt
+		t.jobs[job.ID] = stored
+		return nil
+	})
+}
+
+// In-memory Refund Repository
+type memoryRefundRepository struct {
+	store *memoryStore
+}
+
+// Create stores a refund together with its items
+func (r *memoryRefundRepository) Create(ctx context.Context, refund *domain_refund.Refund) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.refunds[refund.ID]; ok {
+			return duplicate("refund", refund.ID)
+		}
+		if _, ok := t.bookings[refund.BookingID]; !ok {
+			return missingParent("booking", refund.BookingID)
+		}
+		stored := *refund
+		stored.Items = make([]domain_refund.Item, len(refund.Items))
+		for i, item := range refund.Items {
+			item.RefundID = refund.ID
+			stored.Items[i] = item
+		}
+		t.refunds[refund.ID] = stored
+		return nil
+	})
+}
+
+func (r *memoryRefundRepository) Update(ctx context.Context, refund *domain_refund.Refund) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		stored, ok := t.refunds[refund.ID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		stored.Status = refund.Status
+		stored.ProviderReference = refund.ProviderReference
+		stored.Error = refund.Error
+		stored.UpdatedAt = refund.UpdatedAt
+		t.refunds[refund.ID] = stored
+		return nil
+	})
+}
+
+// GetByBookingID retrieves the refunds of a booking with their items, oldest first
+func (r *memoryRefundRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_refund.Refund, error) {
+	refunds := []*domain_refund.Refund{}
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, refund := range t.refunds {
+			if refund.BookingID == bookingID {
+				refund := refund
+				refund.Items = append([]domain_refund.Item{}, refund.Items...)
+				refunds = append(refunds, &refund)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(refunds, func(i, j int) bool { return refunds[i].CreatedAt.Before(refunds[j].CreatedAt) })
+	return refunds, nil
+}
+
+func (r *memoryRefundRepository) GetPolicy(ctx context.Context, eventID uuid.UUID) (*domain_refund.Policy, error) {
+	var policy domain_refund.Policy
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if policy, ok = t.policies[eventID]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *memoryRefundRepository) SavePolicy(ctx context.Context, policy *domain_refund.Policy) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.events[policy.EventID]; !ok {
+			return missingParent("event", policy.EventID)
+		}
+		t.policies[policy.EventID] = *policy
+		return nil
+	})
+}
+
+// CreateReturn stores a ticket return. Each ticket of a booking can only be
+// returned once.
+func (r *memoryRefundRepository) CreateReturn(ctx context.Context, ret *domain_refund.Return) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.bookings[ret.BookingID]; !ok {
+			return missingParent("booking", ret.BookingID)
+		}
+		returned := make(map[uuid.UUID]bool)
+		for _, other := range t.returns {
+			if other.BookingID == ret.BookingID {
+				for _, id := range other.TicketIDs {
+					returned[id] = true
+				}
+			}
+		}
+		for _, id := range ret.TicketIDs {
+			if returned[id] {
+				return duplicate("ticket return", id)
+			}
+			returned[id] = true
+		}
+		if len(ret.TicketIDs) == 0 {
+			return nil
+		}
+		stored := *ret
+		stored.TicketIDs = append([]uuid.UUID{}, ret.TicketIDs...)
+		t.returns[ret.ID] = stored
+		return nil
+	})
+}
+
+// GetReturnsByBookingID retrieves the ticket returns of a booking, oldest first
+func (r *memoryRefundRepository) GetReturnsByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_refund.Return, error) {
+	returns := []*domain_refund.Return{}
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, ret := range t.returns {
+			if ret.BookingID == bookingID {
+				ret := ret
+				ret.TicketIDs = append([]uuid.UUID{}, ret.TicketIDs...)
+				returns = append(returns, &ret)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(returns, func(i, j int) bool {
+		if !returns[i].CreatedAt.Equal(returns[j].CreatedAt) {
+			return returns[i].CreatedAt.Before(returns[j].CreatedAt)
+		}
+		return returns[i].ID.String() < returns[j].ID.String()
+	})
+	return returns, nil
+}
+
+// In-memory Broadcast Repository
+type memoryBroadcastRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryBroadcastRepository) Create(ctx context.Context, b *domain_broadcast.Broadcast) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.broadcasts[b.ID]; ok {
+			return duplicate("broadcast", b.ID)
+		}
+		if _, ok := t.events[b.EventID]; !ok {
+			return missingParent("event", b.EventID)
+		}
+		stored := *b
+		stored.CompletedAt, stored.Report = nil, domain_broadcast.Report{}
+		t.broadcasts[b.ID] = stored
+		return nil
+	})
+}
+
+// withReport copies a broadcast with its deliveries counted by status
+func withReport(t *memoryTables, b domain_broadcast.Broadcast) *domain_broadcast.Broadcast {
+	b.Report = domain_broadcast.Report{}
+	for key, d := range t.recipients {
+		if key.broadcastID != b.ID {
+			continue
+		}
+		b.Report.Recipients++
+		switch d.Status {
+		case domain_broadcast.DeliveryPending:
+			b.Report.Pending++
+		case domain_broadcast.DeliverySent:
+			b.Report.Sent++
+		case domain_broadcast.DeliverySkipped:
+			b.Report.Skipped++
+		case domain_broadcast.DeliveryFailed:
+			b.Report.Failed++
+		}
+	}
+	return &b
+}
+
+func (r *memoryBroadcastRepository) GetByID(ctx context.Context, eventID, id uuid.UUID) (*domain_broadcast.Broadcast, error) {
+	var found *domain_broadcast.Broadcast
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		b, ok := t.broadcasts[id]
+		if !ok || b.EventID != eventID {
+			return domain.ErrNotFound
+		}
+		found = withReport(t, b)
+		return nil
+	})
+	return found, err
+}
+
+func (r *memoryBroadcastRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_broadcast.Broadcast, error) {
+	broadcasts := []*domain_broadcast.Broadcast{}
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, b := range t.broadcasts {
+			if b.EventID == eventID {
+				broadcasts = append(broadcasts, withReport(t, b))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(broadcasts, func(i, j int) bool { return broadcasts[i].CreatedAt.After(broadcasts[j].CreatedAt) })
+	return broadcasts, nil
+}
+
+// AddAttendees creates a pending delivery for every user with a confirmed
+// booking for the broadcast's event. Users already added keep their delivery.
+func (r *memoryBroadcastRepository) AddAttendees(ctx context.Context, b *domain_broadcast.Broadcast) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.broadcasts[b.ID]; !ok {
+			return missingParent("broadcast", b.ID)
+		}
+		now := time.Now()
+		for _, bk := range t.bookings {
+			if bk.EventID != b.EventID || bk.Status != domain_booking.BookingStatusConfirmed {
+				continue
+			}
+			key := broadcastRecipient{broadcastID: b.ID, userID: bk.UserID}
+			if _, ok := t.recipients[key]; !ok {
+				t.recipients[key] = domain_broadcast.Delivery{
+					BroadcastID: b.ID,
+					UserID:      bk.UserID,
+					Status:      domain_broadcast.DeliveryPending,
+					UpdatedAt:   now,
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *memoryBroadcastRepository) GetPendingDeliveries(ctx context.Context, broadcastID uuid.UUID, limit int) ([]*domain_broadcast.Delivery, error) {
+	var deliveries []*domain_broadcast.Delivery
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for key, d := range t.recipients {
+			d := d
+			if key.broadcastID == broadcastID && d.Status == domain_broadcast.DeliveryPending {
+				deliveries = append(deliveries, &d)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].UserID.String() < deliveries[j].UserID.String() })
+	return deliveries[:claimLimit(len(deliveries), limit)], nil
+}
+
+func (r *memoryBroadcastRepository) UpdateDelivery(ctx context.Context, d *domain_broadcast.Delivery) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		key := broadcastRecipient{broadcastID: d.BroadcastID, userID: d.UserID}
+		if _, ok := t.recipients[key]; !ok {
+			return domain.ErrNotFound
+		}
+		t.recipients[key] = *d
+		return nil
+	})
+}
+
+func (r *memoryBroadcastRepository) Complete(ctx context.Context, b *domain_broadcast.Broadcast) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if stored, ok := t.broadcasts[b.ID]; ok {
+			stored.CompletedAt = b.CompletedAt
+			t.broadcasts[b.ID] = stored
+		}
+		return nil
+	})
+}
+
+// In-memory Event Stats Repository
+// Counts bookings from scratch the way eventStatsQuery does
+type memoryEventStatsRepository struct {
+	store *memoryStore
+}
+
+// eventStats counts an event's bookings. Refunded and returned bookings
+// were confirmed first, so they count as confirmed.
+func eventStats(t *memoryTables, eventID uuid.UUID) *domain_stats.EventStats {
+	stats := &domain_stats.EventStats{EventID: eventID}
+	for id, bk := range t.bookings {
+		if bk.EventID != eventID {
+			continue
+		}
+		stats.Created++
+		switch bk.Status {
+		case domain_booking.BookingStatusConfirmed, domain_booking.BookingStatusRefunded, domain_booking.BookingStatusReturned:
+			stats.Confirmed++
+			rate := 1.0
+			if bk.ExchangeRate != nil {
+				rate = *bk.ExchangeRate
+			}
+			stats.RevenueCents += int64(math.Round(bk.TotalAmount / rate * 100))
+			stats.TicketsSold += int64(len(t.bookingItems[id]))
+		case domain_booking.BookingStatusCancelled:
+			stats.Cancelled++
+		}
+	}
+	for _, ret := range t.returns {
+		if ret.EventID == eventID {
+			stats.TicketsReturned += int64(len(ret.TicketIDs))
+		}
+	}
+	return stats
+}
+
+func (r *memoryEventStatsRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error) {
+	var stats *domain_stats.EventStats
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		if _, ok := liveEvent(t, eventID); !ok {
+			return domain.ErrNotFound
+		}
+		stats = eventStats(t, eventID)
+		return nil
+	})
+	return stats, err
+}
+
+func (r *memoryEventStatsRepository) GetForEventsFrom(ctx context.Context, from time.Time) ([]*domain_stats.EventStats, error) {
+	var stats []*domain_stats.EventStats
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for id, evt := range t.events {
+			if evt.DeletedAt == nil && !evt.Date.Before(from) {
+				stats = append(stats, eventStats(t, id))
+			}
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// In-memory Sales Report Repository
+// Sums sales per bucket the way the sales report queries do
+type memorySalesReportRepository struct {
+	store *memoryStore
+}
+
+// salesReport sums the sales and refunds of the events in scope, which
+// never include deleted events
+func salesReport(t *memoryTables, report *domain_stats.SalesReport, where func(evt domain_event.Event) bool) {
+	inScope := func(evt domain_event.Event) bool { return evt.DeletedAt == nil && where(evt) }
+	report.Buckets = []domain_stats.SalesBucket{}
+	for _, evt := range t.events {
+		if inScope(evt) {
+			report.Events++
+			report.TotalSeats += int64(evt.TotalSeats)
+		}
+	}
+	for id, bk := range t.bookings {
+		if !inScope(t.events[bk.EventID]) {
+			continue
+		}
+		rate := 1.0
+		if bk.ExchangeRate != nil {
+			rate = *bk.ExchangeRate
+		}
+		switch bk.Status {
+		case domain_booking.BookingStatusConfirmed, domain_booking.BookingStatusRefunded, domain_booking.BookingStatusReturned:
+			report.AddBucket(domain_stats.SalesBucket{
+				Start:        report.Interval.Truncate(bk.CreatedAt),
+				Bookings:     1,
+				TicketsSold:  int64(len(t.bookingItems[id])),
+				RevenueCents: int64(math.Round(bk.TotalAmount / rate * 100)),
+			})
+		}
+		for _, ref := range t.refunds {
+			if ref.BookingID == id && ref.Status == domain_refund.StatusSucceeded {
+				report.AddBucket(domain_stats.SalesBucket{
+					Start:         report.Interval.Truncate(ref.CreatedAt),
+					RefundedCents: int64(math.Round(ref.Amount / rate * 100)),
+				})
+			}
+		}
+	}
+}
+
+func (r *memorySalesReportRepository) GetForEvent(ctx context.Context, eventID uuid.UUID, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error) {
+	report := &domain_stats.SalesReport{EventID: &eventID, Interval: interval}
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		if _, ok := liveEvent(t, eventID); !ok {
+			return domain.ErrNotFound
+		}
+		salesReport(t, report, func(evt domain_event.Event) bool { return evt.ID == eventID })
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (r *memorySalesReportRepository) GetForEvents(ctx context.Context, from, to *time.Time, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error) {
+	report := &domain_stats.SalesReport{From: from, To: to, Interval: interval}
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		salesReport(t, report, func(evt domain_event.Event) bool {
+			return evt.ID != uuid.Nil && (from == nil || !evt.Date.Before(*from)) && (to == nil || evt.Date.Before(*to))
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// In-memory Booking Export Repository
+// Pages through booked tickets in the order the export query sorts them
+type memoryBookingExportRepository struct {
+	store *memoryStore
+}
+
+// attendeeBefore orders attendees by booking time, booking and seat
+func attendeeBefore(a, b *domain_booking.Attendee) bool {
+	if !a.BookedAt.Equal(b.BookedAt) {
+		return a.BookedAt.Before(b.BookedAt)
+	}
+	if a.BookingID != b.BookingID {
+		return a.BookingID.String() < b.BookingID.String()
+	}
+	return a.SeatNumber < b.SeatNumber
+}
+
+func (r *memoryBookingExportRepository) ListAttendees(ctx context.Context, filter domain_booking.AttendeeFilter, after *domain_booking.Attendee, limit int) ([]*domain_booking.Attendee, error) {
+	var attendees []*domain_booking.Attendee
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for id, bk := range t.bookings {
+			if (filter.EventID != nil && bk.EventID != *filter.EventID) || (filter.UserID != nil && bk.UserID != *filter.UserID) {
+				continue
+			}
+			usr, ok := t.users[bk.UserID]
+			if !ok {
+				continue
+			}
+			evt, ok := t.events[bk.EventID]
+			if !ok {
+				continue
+			}
+			for _, item := range t.bookingItems[id] {
+				attendee := &domain_booking.Attendee{
+					BookingID:  id,
+					Status:     bk.Status,
+					BookedAt:   bk.CreatedAt,
+					UserID:     usr.ID,
+					UserName:   usr.Name,
+					UserEmail:  usr.Email,
+					EventID:    evt.ID,
+					EventName:  evt.Name,
+					EventDate:  evt.Date,
+					TicketID:   item.TicketID,
+					SeatNumber: item.SeatNumber,
+					UnitPrice:  item.UnitPrice,
+					Currency:   item.Currency,
+				}
+				if after == nil || attendeeBefore(after, attendee) {
+					attendees = append(attendees, attendee)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(attendees, func(i, j int) bool { return attendeeBefore(attendees[i], attendees[j]) })
+	if len(attendees) > limit {
+		attendees = attendees[:limit]
+	}
+	return attendees, nil
+}
+
+// In-memory Dead Letter Repository
+type memoryDeadLetterRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryDeadLetterRepository) Create(ctx context.Context, letter *domain_deadletter.DeadLetter) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.deadLetters[letter.ID]; ok {
+			return duplicate("dead letter", letter.ID)
+		}
+		t.deadLetters[letter.ID] = *letter
+		return nil
+	})
+}
+
+func (r *memoryDeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_deadletter.DeadLetter, error) {
+	var letter domain_deadletter.DeadLetter
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		var ok bool
+		if letter, ok = t.deadLetters[id]; !ok {
+			return domain.ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &letter, nil
+}
+
+func (r *memoryDeadLetterRepository) List(ctx context.Context, limit int) ([]*domain_deadletter.DeadLetter, error) {
+	var letters []*domain_deadletter.DeadLetter
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, letter := range t.deadLetters {
+			letter := letter
+			letters = append(letters, &letter)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i].CreatedAt.After(letters[j].CreatedAt) })
+	return letters[:claimLimit(len(letters), limit)], nil
+}
+
+func (r *memoryDeadLetterRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.deadLetters[id]; !ok {
+			return domain.ErrNotFound
+		}
+		delete(t.deadLetters, id)
+		return nil
+	})
+}
+
+// In-memory Audit Repository
+type memoryAuditRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryAuditRepository) Create(ctx context.Context, entry *domain_audit.Entry) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if _, ok := t.auditEntries[entry.ID]; ok {
+			return duplicate("audit entry", entry.ID)
+		}
+		t.auditEntries[entry.ID] = *entry
+		return nil
+	})
+}
+
+func (r *memoryAuditRepository) GetBySubject(ctx context.Context, subjectID uuid.UUID) ([]*domain_audit.Entry, error) {
+	var entries []*domain_audit.Entry
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, entry := range t.auditEntries {
+			if entry.SubjectID == subjectID {
+				entry := entry
+				entries = append(entries, &entry)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].CreatedAt.Before(entries[j].CreatedAt) })
+	return entries, nil
+}
+
+// In-memory Booking Transition Repository
+type memoryBookingTransitionRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryBookingTransitionRepository) Append(ctx context.Context, transitions ...*domain_booking.Transition) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		for _, transition := range transitions {
+			// Copied on append, so a rolled back write never shows through
+			history := t.transitions[transition.BookingID]
+			t.transitions[transition.BookingID] = append(history[:len(history):len(history)], *transition)
+		}
+		return nil
+	})
+}
+
+func (r *memoryBookingTransitionRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.Transition, error) {
+	var transitions []*domain_booking.Transition
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, transition := range t.transitions[bookingID] {
+			transition := transition
+			transitions = append(transitions, &transition)
+		}
+		return nil
+	})
+	return transitions, err
+}
+
+// In-memory Booking Event Repository
+type memoryBookingEventRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryBookingEventRepository) Append(ctx context.Context, events ...*domain_booking.BookingEvent) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		for _, event := range events {
+			t.lastPosition++
+			stream := t.streams[event.BookingID]
+			event.Position = t.lastPosition
+			event.Version = len(stream) + 1
+			// Copied on append, so a rolled back write never shows through
+			t.streams[event.BookingID] = append(stream[:len(stream):len(stream)], *event)
+		}
+		return nil
+	})
+}
+
+func (r *memoryBookingEventRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.BookingEvent, error) {
+	var events []*domain_booking.BookingEvent
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, event := range t.streams[bookingID] {
+			event := event
+			events = append(events, &event)
+		}
+		return nil
+	})
+	return events, err
+}
+
+func (r *memoryBookingEventRepository) ListAfter(ctx context.Context, position int64, limit int) ([]*domain_booking.BookingEvent, error) {
+	var events []*domain_booking.BookingEvent
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, stream := range t.streams {
+			for _, event := range stream {
+				if event.Position > position {
+					event := event
+					events = append(events, &event)
+				}
+			}
+		}
+		return nil
+	})
+	sort.Slice(events, func(i, j int) bool { return events[i].Position < events[j].Position })
+	if len(events) > limit {
+		events = events[:limit]
+	}
+	return events, err
+}
+
+// In-memory Read Model Repository
+type memoryReadModelRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryReadModelRepository) UpsertBookingSummary(ctx context.Context, summary *domain_readmodel.BookingSummary) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if stored, ok := t.summaries[summary.BookingID]; ok && stored.Position > summary.Position {
+			return nil
+		}
+		t.summaries[summary.BookingID] = *summary
+		return nil
+	})
+}
+
+func (r *memoryReadModelRepository) DeleteBookingSummary(ctx context.Context, bookingID uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		delete(t.summaries, bookingID)
+		return nil
+	})
+}
+
+func (r *memoryReadModelRepository) GetBookingSummariesByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_readmodel.BookingSummary, error) {
+	var summaries []*domain_readmodel.BookingSummary
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, summary := range t.summaries {
+			if summary.UserID == userID {
+				summary := summary
+				summaries = append(summaries, &summary)
+			}
+		}
+		return nil
+	})
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].CreatedAt.After(summaries[j].CreatedAt) })
+	return summaries, err
+}
+
+func (r *memoryReadModelRepository) UpsertEventAvailability(ctx context.Context, availability *domain_readmodel.EventAvailability) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		t.eventCounts[availability.EventID] = *availability
+		return nil
+	})
+}
+
+func (r *memoryReadModelRepository) DeleteEventAvailability(ctx context.Context, eventID uuid.UUID) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		delete(t.eventCounts, eventID)
+		return nil
+	})
+}
+
+func (r *memoryReadModelRepository) GetEventAvailability(ctx context.Context, eventID uuid.UUID) (*domain_readmodel.EventAvailability, error) {
+	var availability domain_readmodel.EventAvailability
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		stored, ok := t.eventCounts[eventID]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		availability = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &availability, nil
+}
+
+func (r *memoryReadModelRepository) ListEventAvailability(ctx context.Context, from time.Time) ([]*domain_readmodel.EventAvailability, error) {
+	var availability []*domain_readmodel.EventAvailability
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, row := range t.eventCounts {
+			if !row.EventDate.Before(from) {
+				row := row
+				availability = append(availability, &row)
+			}
+		}
+		return nil
+	})
+	sort.Slice(availability, func(i, j int) bool { return availability[i].EventDate.Before(availability[j].EventDate) })
+	return availability, err
+}
+
+func (r *memoryReadModelRepository) GetCheckpoint(ctx context.Context, name string) (int64, error) {
+	var position int64
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		position = t.checkpoints[name]
+		return nil
+	})
+	return position, err
+}
+
+func (r *memoryReadModelRepository) SetCheckpoint(ctx context.Context, name string, position int64) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		t.checkpoints[name] = position
+		return nil
+	})
+}
+
+// In-memory Column Migration Repository
+// Rows in memory have no columns to move, so nothing is ever pending
+type memoryColumnMigrationRepository struct {
+	migrations ColumnMigrations
+}
+
+func (r *memoryColumnMigrationRepository) List(ctx context.Context) ([]*domain_migration.ColumnMigration, error) {
+	names := make([]string, 0, len(columnChanges))
+	for name := range columnChanges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	migrations := make([]*domain_migration.ColumnMigration, len(names))
+	for i, name := range names {
+		migration, err := r.Get(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		migrations[i] = migration
+	}
+	return migrations, nil
+}
+
+func (r *memoryColumnMigrationRepository) Get(ctx context.Context, name string) (*domain_migration.ColumnMigration, error) {
+	change, ok := columnChanges[name]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return &domain_migration.ColumnMigration{
+		Name:      change.name,
+		Table:     change.table,
+		OldColumn: change.oldColumn,
+		NewColumn: change.newColumn,
+		Phase:     r.migrations.phase(name),
+	}, nil
+}
+
+func (r *memoryColumnMigrationRepository) Backfill(ctx context.Context, name string, limit int) (int, error) {
+	if _, ok := columnChanges[name]; !ok {
+		return 0, domain.ErrNotFound
+	}
+	return 0, nil
+}
+
+// In-memory Identity Repository
+type memoryIdentityRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryIdentityRepository) Create(ctx context.Context, identity *domain_user.Identity) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		key := identityKey{provider: identity.Provider, subject: identity.Subject}
+		if _, ok := t.identities[key]; ok {
+			return domain_user.ErrIdentityLinked
+		}
+		t.identities[key] = *identity
+		return nil
+	})
+}
+
+func (r *memoryIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain_user.Identity, error) {
+	var identity domain_user.Identity
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		stored, ok := t.identities[identityKey{provider: provider, subject: subject}]
+		if !ok {
+			return domain.ErrNotFound
+		}
+		identity = stored
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// In-memory API Key Repository
+type memoryAPIKeyRepository struct {
+	store *memoryStore
+}
+
+func (r *memoryAPIKeyRepository) Create(ctx context.Context, key *domain_apikey.APIKey) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		for _, stored := range t.apiKeys {
+			if stored.KeyHash == key.KeyHash {
+				return fmt.Errorf("%w: api key hash already exists", domain.ErrConflict)
+			}
+		}
+		t.apiKeys[key.ID] = *key
+		return nil
+	})
+}
+
+func (r *memoryAPIKeyRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_apikey.APIKey, error) {
+	return r.find(ctx, func(key domain_apikey.APIKey) bool { return key.ID == id })
+}
+
+func (r *memoryAPIKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain_apikey.APIKey, error) {
+	return r.find(ctx, func(key domain_apikey.APIKey) bool { return key.KeyHash == keyHash })
+}
+
+func (r *memoryAPIKeyRepository) find(ctx context.Context, match func(key domain_apikey.APIKey) bool) (*domain_apikey.APIKey, error) {
+	var found *domain_apikey.APIKey
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, key := range t.apiKeys {
+			if match(key) {
+				key := key
+				found = &key
+				return nil
+			}
+		}
+		return domain.ErrNotFound
+	})
+	return found, err
+}
+
+func (r *memoryAPIKeyRepository) List(ctx context.Context) ([]*domain_apikey.APIKey, error) {
+	var keys []*domain_apikey.APIKey
+	err := r.store.read(ctx, func(t *memoryTables) error {
+		for _, key := range t.apiKeys {
+			key := key
+			keys = append(keys, &key)
+		}
+		return nil
+	})
+	sort.Slice(keys, func(i, j int) bool { return keys[i].CreatedAt.After(keys[j].CreatedAt) })
+	return keys, err
+}
+
+func (r *memoryAPIKeyRepository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		key, ok := t.apiKeys[id]
+		if !ok || key.RevokedAt != nil {
+			return domain.ErrNotFound
+		}
+		key.RevokedAt = &at
+		t.apiKeys[id] = key
+		return nil
+	})
+}
+
+func (r *memoryAPIKeyRepository) Touch(ctx context.Context, id uuid.UUID, at time.Time) error {
+	return r.store.write(ctx, func(t *memoryTables) error {
+		if key, ok := t.apiKeys[id]; ok {
+			key.LastUsedAt = &at
+			t.apiKeys[id] = key
+		}
+		return nil
+	})
+}
@@ -0,0 +1,1077 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+// In-memory User Cache Repository
+type memoryUserCacheRepository struct {
+	keys *memoryKeys
+	ttls CacheTTLs
+}
+
+func (r *memoryUserCacheRepository) Create(ctx context.Context, usr *domain_user.User) error {
+	return r.Update(ctx, usr)
+}
+
+func (r *memoryUserCacheRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_user.User, error) {
+	if r.ttls.User == 0 {
+		return nil, domain.ErrNotFound
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(tenantKey(ctx, fmt.Sprintf("user:%s", id.String())))
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	if value == notFoundMarker {
+		return nil, ErrCachedNotFound
+	}
+	usr := value.(domain_user.User)
+	return &usr, nil
+}
+
+func (r *memoryUserCacheRepository) GetByEmail(ctx context.Context, email string) (*domain_user.User, error) {
+	if r.ttls.User == 0 {
+		return nil, domain.ErrNotFound
+	}
+	r.keys.mu.Lock()
+	value, ok := r.keys.get(tenantKey(ctx, fmt.Sprintf("user:email:%s", email)))
+	r.keys.mu.Unlock()
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return r.GetByID(ctx, value.(uuid.UUID))
+}
+
+func (r *memoryUserCacheRepository) Update(ctx context.Context, usr *domain_user.User) error {
+	if r.ttls.User == 0 {
+		return nil
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(tenantKey(ctx, fmt.Sprintf("user:%s", usr.ID.String())), *usr, r.ttls.expiry(r.ttls.User))
+	return nil
+}
+
+func (r *memoryUserCacheRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	delete(r.keys.vals, tenantKey(ctx, fmt.Sprintf("user:%s", id.String())))
+	return nil
+}
+
+func (r *memoryUserCacheRepository) SetEmailIndex(ctx context.Context, email string, userID uuid.UUID) error {
+	if r.ttls.User == 0 {
+		return nil
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(tenantKey(ctx, fmt.Sprintf("user:email:%s", email)), userID, r.ttls.expiry(r.ttls.User))
+	return nil
+}
+
+func (r *memoryUserCacheRepository) SetNotFound(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	if r.ttls.User == 0 {
+		return nil
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(tenantKey(ctx, fmt.Sprintf("user:%s", id.String())), notFoundMarker, ttl)
+	return nil
+}
+
+// In-memory Event Cache Repository
+type memoryEventCacheRepository struct {
+	keys *memoryKeys
+	ttls CacheTTLs
+}
+
+// copyEvents copies a list of events so the cache and its callers never
+// share them
+func copyEvents(events []*domain_event.Event) []*domain_event.Event {
+	copied := make([]*domain_event.Event, len(events))
+	for i, evt := range events {
+		evt := *evt
+		copied[i] = &evt
+	}
+	return copied
+}
+
+func (r *memoryEventCacheRepository) Version(ctx context.Context) (int64, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(eventCacheVersionKey(ctx))
+	if !ok {
+		return 0, nil
+	}
+	return value.(int64), nil
+}
+
+func (r *memoryEventCacheRepository) GetByID(ctx context.Context, version int64, id uuid.UUID) (*domain_event.Event, error) {
+	if r.ttls.Event == 0 {
+		return nil, domain.ErrNotFound
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(cachedEventKey(ctx, version, id))
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	if value == notFoundMarker {
+		return nil, ErrCachedNotFound
+	}
+	evt := value.(domain_event.Event)
+	return &evt, nil
+}
+
+func (r *memoryEventCacheRepository) GetAll(ctx context.Context, version int64) ([]*domain_event.Event, error) {
+	if r.ttls.EventList == 0 {
+		return nil, domain.ErrNotFound
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(cachedEventsKey(ctx, version))
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return copyEvents(value.([]*domain_event.Event)), nil
+}
+
+func (r *memoryEventCacheRepository) Set(ctx context.Context, version int64, evt *domain_event.Event) error {
+	if r.ttls.Event == 0 {
+		return nil
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(cachedEventKey(ctx, version, evt.ID), *evt, r.ttls.expiry(r.ttls.Event))
+	return nil
+}
+
+func (r *memoryEventCacheRepository) SetNotFound(ctx context.Context, version int64, id uuid.UUID, ttl time.Duration) error {
+	if r.ttls.Event == 0 {
+		return nil
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(cachedEventKey(ctx, version, id), notFoundMarker, ttl)
+	return nil
+}
+
+func (r *memoryEventCacheRepository) SetAllEvents(ctx context.Context, version int64, events []*domain_event.Event) error {
+	if r.ttls.EventList == 0 {
+		return nil
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(cachedEventsKey(ctx, version), copyEvents(events), r.ttls.expiry(r.ttls.EventList))
+	return nil
+}
+
+func (r *memoryEventCacheRepository) Invalidate(ctx context.Context) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := eventCacheVersionKey(ctx)
+	version, _ := r.keys.get(key)
+	next, _ := version.(int64)
+	r.keys.set(key, next+1, 0)
+	return nil
+}
+
+// In-memory Booking Cache Repository
+type memoryBookingCacheRepository struct {
+	keys *memoryKeys
+}
+
+func (r *memoryBookingCacheRepository) CheckNotFound(ctx context.Context, id uuid.UUID) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	if value, ok := r.keys.get(cachedBookingKey(ctx, id)); ok && value == notFoundMarker {
+		return ErrCachedNotFound
+	}
+	return nil
+}
+
+func (r *memoryBookingCacheRepository) SetNotFound(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(cachedBookingKey(ctx, id), notFoundMarker, ttl)
+	return nil
+}
+
+// In-memory Event Stats Cache Repository
+// As in Redis, increments only apply to counters that were set first
+type memoryEventStatsCacheRepository struct {
+	keys *memoryKeys
+}
+
+func (r *memoryEventStatsCacheRepository) Get(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(eventStatsKey(ctx, eventID))
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	stats := value.(domain_stats.EventStats)
+	return &stats, nil
+}
+
+func (r *memoryEventStatsCacheRepository) Set(ctx context.Context, stats *domain_stats.EventStats) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	stored := *stats
+	// Redis keeps the reconciliation time in whole seconds
+	stored.ReconciledAt = time.Unix(stats.ReconciledAt.Unix(), 0).UTC()
+	r.keys.set(eventStatsKey(ctx, stats.EventID), stored, 0)
+	return nil
+}
+
+func (r *memoryEventStatsCacheRepository) Add(ctx context.Context, eventID uuid.UUID, delta domain_stats.Delta) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := eventStatsKey(ctx, eventID)
+	value, ok := r.keys.get(key)
+	if !ok {
+		return nil
+	}
+	stats := value.(domain_stats.EventStats)
+	stats.Created += delta.Created
+	stats.Confirmed += delta.Confirmed
+	stats.Cancelled += delta.Cancelled
+	stats.RevenueCents += delta.RevenueCents
+	stats.TicketsSold += delta.TicketsSold
+	stats.TicketsReturned += delta.TicketsReturned
+	r.keys.set(key, stats, 0)
+	return nil
+}
+
+// In-memory Availability Repository
+// As in Redis, taking and restoring tickets only changes counters that were
+// set first
+type memoryAvailabilityRepository struct {
+	keys *memoryKeys
+}
+
+// copyAvailability copies counters so the store and its callers never share
+// the tiers
+func copyAvailability(availability domain_ticket.Availability) *domain_ticket.Availability {
+	tiers := make(map[int64]int64, len(availability.Tiers))
+	for cents, n := range availability.Tiers {
+		if n > 0 {
+			tiers[cents] = n
+		}
+	}
+	availability.Tiers = tiers
+	return &availability
+}
+
+func (r *memoryAvailabilityRepository) Get(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(availabilityKey(ctx, eventID))
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return copyAvailability(value.(domain_ticket.Availability)), nil
+}
+
+func (r *memoryAvailabilityRepository) Set(ctx context.Context, availability *domain_ticket.Availability) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	stored := copyAvailability(*availability)
+	// Redis keeps the reconciliation time in whole seconds
+	stored.ReconciledAt = time.Unix(availability.ReconciledAt.Unix(), 0).UTC()
+	r.keys.set(availabilityKey(ctx, availability.EventID), *stored, 0)
+	return nil
+}
+
+func (r *memoryAvailabilityRepository) Take(ctx context.Context, eventID uuid.UUID, tiers map[int64]int64) (bool, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := availabilityKey(ctx, eventID)
+	value, ok := r.keys.get(key)
+	if !ok {
+		return true, nil
+	}
+	availability := copyAvailability(value.(domain_ticket.Availability))
+	var total int64
+	for cents, n := range tiers {
+		if availability.Tiers[cents] < n {
+			return false, nil
+		}
+		total += n
+	}
+	if availability.Available < total {
+		return false, nil
+	}
+	for cents, n := range tiers {
+		availability.Tiers[cents] -= n
+	}
+	availability.Available -= total
+	r.keys.set(key, *availability, 0)
+	return true, nil
+}
+
+func (r *memoryAvailabilityRepository) Restore(ctx context.Context, eventID uuid.UUID, tiers map[int64]int64) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := availabilityKey(ctx, eventID)
+	value, ok := r.keys.get(key)
+	if !ok {
+		return nil
+	}
+	availability := copyAvailability(value.(domain_ticket.Availability))
+	for cents, n := range tiers {
+		availability.Tiers[cents] += n
+		availability.Available += n
+	}
+	r.keys.set(key, *availability, 0)
+	return nil
+}
+
+func (r *memoryAvailabilityRepository) Delete(ctx context.Context, eventID uuid.UUID) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	delete(r.keys.vals, availabilityKey(ctx, eventID))
+	return nil
+}
+
+// In-memory Waiting Room Repository
+type memoryWaitingRoomRepository struct {
+	keys *memoryKeys
+}
+
+// memoryWaitingRoom is one event's queue, tokens in the order users joined
+type memoryWaitingRoom struct {
+	tokens []string
+	users  map[uuid.UUID]string
+	owners map[string]uuid.UUID
+}
+
+// room returns the event's queue, or nil if no one is waiting. The caller
+// holds keys.mu.
+func (r *memoryWaitingRoomRepository) room(ctx context.Context, eventID uuid.UUID) *memoryWaitingRoom {
+	value, ok := r.keys.get(waitingRoomKey(ctx, eventID))
+	if !ok {
+		return nil
+	}
+	return value.(*memoryWaitingRoom)
+}
+
+// active returns the set of events with a waiting room. The caller holds
+// keys.mu.
+func (r *memoryWaitingRoomRepository) active(ctx context.Context) map[uuid.UUID]bool {
+	key := tenantKey(ctx, "waitingrooms:active")
+	value, ok := r.keys.get(key)
+	if !ok {
+		value = make(map[uuid.UUID]bool)
+		r.keys.set(key, value, 0)
+	}
+	return value.(map[uuid.UUID]bool)
+}
+
+func (r *memoryWaitingRoomRepository) Join(ctx context.Context, eventID, userID uuid.UUID, token string) (string, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+
+	room := r.room(ctx, eventID)
+	if room == nil {
+		room = &memoryWaitingRoom{users: make(map[uuid.UUID]string), owners: make(map[string]uuid.UUID)}
+	}
+	// A user keeps their original place if they join twice
+	if existing, ok := room.users[userID]; ok {
+		return existing, nil
+	}
+	room.tokens = append(room.tokens, token)
+	room.users[userID] = token
+	room.owners[token] = userID
+	r.keys.set(waitingRoomKey(ctx, eventID), room, waitingRoomTTL)
+	r.active(ctx)[eventID] = true
+	return token, nil
+}
+
+func (r *memoryWaitingRoomRepository) GetToken(ctx context.Context, eventID, userID uuid.UUID) (string, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	if room := r.room(ctx, eventID); room != nil {
+		if token, ok := room.users[userID]; ok {
+			return token, nil
+		}
+	}
+	return "", domain.ErrNotFound
+}
+
+func (r *memoryWaitingRoomRepository) GetUserID(ctx context.Context, eventID uuid.UUID, token string) (uuid.UUID, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	if room := r.room(ctx, eventID); room != nil {
+		if userID, ok := room.owners[token]; ok {
+			return userID, nil
+		}
+	}
+	return uuid.Nil, domain.ErrNotFound
+}
+
+func (r *memoryWaitingRoomRepository) Position(ctx context.Context, eventID uuid.UUID, token string) (int64, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	if room := r.room(ctx, eventID); room != nil {
+		for i, t := range room.tokens {
+			if t == token {
+				return int64(i), nil
+			}
+		}
+	}
+	return 0, domain.ErrNotFound
+}
+
+func (r *memoryWaitingRoomRepository) Size(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	if room := r.room(ctx, eventID); room != nil {
+		return int64(len(room.tokens)), nil
+	}
+	return 0, nil
+}
+
+func (r *memoryWaitingRoomRepository) Admitted(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	if value, ok := r.keys.get(waitingRoomKey(ctx, eventID) + ":admitted"); ok {
+		return value.(int64), nil
+	}
+	return 0, nil
+}
+
+func (r *memoryWaitingRoomRepository) Admit(ctx context.Context, eventID uuid.UUID, count int64) (int64, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := waitingRoomKey(ctx, eventID) + ":admitted"
+	admitted := count
+	if value, ok := r.keys.get(key); ok {
+		admitted += value.(int64)
+	}
+	r.keys.set(key, admitted, waitingRoomTTL)
+	return admitted, nil
+}
+
+func (r *memoryWaitingRoomRepository) ActiveEvents(ctx context.Context) ([]uuid.UUID, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	active := r.active(ctx)
+	eventIDs := make([]uuid.UUID, 0, len(active))
+	for eventID := range active {
+		eventIDs = append(eventIDs, eventID)
+	}
+	return eventIDs, nil
+}
+
+func (r *memoryWaitingRoomRepository) Close(ctx context.Context, eventID uuid.UUID) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := waitingRoomKey(ctx, eventID)
+	delete(r.keys.vals, key)
+	delete(r.keys.vals, key+":admitted")
+	delete(r.active(ctx), eventID)
+	return nil
+}
+
+// In-memory Seat Suggestion Repository
+type memorySeatSuggestionRepository struct {
+	keys *memoryKeys
+}
+
+func (r *memorySeatSuggestionRepository) GetShown(ctx context.Context, eventID uuid.UUID, session string) (map[int]bool, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	shown := make(map[int]bool)
+	if value, ok := r.keys.get(seatSuggestionKey(ctx, eventID, session)); ok {
+		for seat := range value.(map[int]bool) {
+			shown[seat] = true
+		}
+	}
+	return shown, nil
+}
+
+func (r *memorySeatSuggestionRepository) AddShown(ctx context.Context, eventID uuid.UUID, session string, seats []int, ttl time.Duration) error {
+	if len(seats) == 0 {
+		return nil
+	}
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := seatSuggestionKey(ctx, eventID, session)
+	shown := make(map[int]bool)
+	if value, ok := r.keys.get(key); ok {
+		shown = value.(map[int]bool)
+	}
+	for _, seat := range seats {
+		shown[seat] = true
+	}
+	r.keys.set(key, shown, ttl)
+	return nil
+}
+
+// In-memory Lease Repository
+type memoryLeaseRepository struct {
+	keys *memoryKeys
+}
+
+// Acquire takes the lease for ttl, or extends it if holder already has it.
+// It reports whether holder has the lease afterwards.
+func (r *memoryLeaseRepository) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := leaseKey(ctx, name)
+	if current, ok := r.keys.get(key); ok && current.(string) != holder {
+		return false, nil
+	}
+	r.keys.set(key, holder, ttl)
+	return true, nil
+}
+
+func (r *memoryLeaseRepository) Release(ctx context.Context, name string, holder string) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := leaseKey(ctx, name)
+	if current, ok := r.keys.get(key); ok && current.(string) == holder {
+		delete(r.keys.vals, key)
+	}
+	return nil
+}
+
+// In-memory Booking Queue Repository
+// Like the Redis streams, entries stay in the queue from Append until Ack,
+// and an entry read but not acknowledged can be reclaimed once idle.
+type memoryBookingQueueRepository struct {
+	mu     sync.Mutex
+	queues map[string][]*memoryQueueEntry
+	lastID int64
+	wake   chan struct{} // closed and replaced on every append
+}
+
+type memoryQueueEntry struct {
+	id          string
+	payload     []byte
+	consumer    string // empty until read
+	deliveredAt time.Time
+}
+
+func newMemoryBookingQueueRepository() *memoryBookingQueueRepository {
+	return &memoryBookingQueueRepository{
+		queues: make(map[string][]*memoryQueueEntry),
+		wake:   make(chan struct{}),
+	}
+}
+
+func (r *memoryBookingQueueRepository) Append(ctx context.Context, queue string, payload []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	r.queues[queue] = append(r.queues[queue], &memoryQueueEntry{
+		id:      fmt.Sprintf("%d-%d", time.Now().UnixMilli(), r.lastID),
+		payload: append([]byte(nil), payload...),
+	})
+	close(r.wake)
+	r.wake = make(chan struct{})
+	return nil
+}
+
+// Read waits up to block for new entries in any of the queues, returning at
+// most one from each, in the order the queues are given
+func (r *memoryBookingQueueRepository) Read(ctx context.Context, queues []string, consumer string, block time.Duration) ([]QueuedMessage, error) {
+	timer := time.NewTimer(max(block, time.Millisecond))
+	defer timer.Stop()
+	for {
+		r.mu.Lock()
+		var messages []QueuedMessage
+		for _, queue := range queues {
+			for _, entry := range r.queues[queue] {
+				if entry.consumer == "" {
+					entry.consumer, entry.deliveredAt = consumer, time.Now()
+					messages = append(messages, entry.message(queue))
+					break
+				}
+			}
+		}
+		wake := r.wake
+		r.mu.Unlock()
+		if len(messages) > 0 {
+			return messages, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timer.C:
+			return nil, nil
+		case <-wake:
+		}
+	}
+}
+
+func (r *memoryBookingQueueRepository) Reclaim(ctx context.Context, queue string, consumer string, minIdle time.Duration, count int) ([]QueuedMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	var messages []QueuedMessage
+	for _, entry := range r.queues[queue] {
+		if len(messages) >= count {
+			break
+		}
+		if entry.consumer != "" && now.Sub(entry.deliveredAt) >= minIdle {
+			entry.consumer, entry.deliveredAt = consumer, now
+			messages = append(messages, entry.message(queue))
+		}
+	}
+	return messages, nil
+}
+
+func (r *memoryBookingQueueRepository) Ack(ctx context.Context, queue string, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entries := r.queues[queue]
+	for i, entry := range entries {
+		if entry.id == id {
+			r.queues[queue] = append(entries[:i:i], entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *memoryBookingQueueRepository) Len(ctx context.Context, queue string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.queues[queue])), nil
+}
+
+func (e *memoryQueueEntry) message(queue string) QueuedMessage {
+	return QueuedMessage{Queue: queue, ID: e.id, Payload: append([]byte(nil), e.payload...)}
+}
+
+// In-memory Cache Invalidation Repository
+// Delivers each invalidation to the subscribers in this process
+type memoryCacheInvalidationRepository struct {
+	mu          sync.Mutex
+	subscribers map[*memoryCacheSubscriber]struct{}
+}
+
+type memoryCacheSubscriber struct {
+	ch   chan memoryCacheInvalidation
+	done chan struct{} // closed when the subscriber stops
+}
+
+type memoryCacheInvalidation struct {
+	tenant string
+	inv    CacheInvalidation
+}
+
+func (r *memoryCacheInvalidationRepository) Publish(ctx context.Context, inv CacheInvalidation) error {
+	r.mu.Lock()
+	subscribers := make([]*memoryCacheSubscriber, 0, len(r.subscribers))
+	for sub := range r.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	r.mu.Unlock()
+
+	m := memoryCacheInvalidation{tenant: tenant.FromContext(ctx), inv: inv}
+	for _, sub := range subscribers {
+		select {
+		case sub.ch <- m:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (r *memoryCacheInvalidationRepository) Subscribe(ctx context.Context, handle func(ctx context.Context, inv CacheInvalidation)) error {
+	sub := &memoryCacheSubscriber{ch: make(chan memoryCacheInvalidation, 64), done: make(chan struct{})}
+	r.mu.Lock()
+	if r.subscribers == nil {
+		r.subscribers = make(map[*memoryCacheSubscriber]struct{})
+	}
+	r.subscribers[sub] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.subscribers, sub)
+		r.mu.Unlock()
+		close(sub.done)
+	}()
+
+	handle(ctx, CacheInvalidation{Kind: CacheInvalidateAll})
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m := <-sub.ch:
+			handle(tenant.WithID(ctx, m.tenant), m.inv)
+		}
+	}
+}
+
+// In-memory Availability Feed Repository
+// Delivers each seat change to the subscribers in this process
+type memoryAvailabilityFeedRepository struct {
+	mu          sync.Mutex
+	subscribers map[*memoryAvailabilitySubscriber]struct{}
+}
+
+type memoryAvailabilitySubscriber struct {
+	ch   chan memoryAvailabilityChange
+	done chan struct{} // closed when the subscriber stops
+}
+
+type memoryAvailabilityChange struct {
+	tenant string
+	change domain_ticket.AvailabilityChange
+}
+
+func (r *memoryAvailabilityFeedRepository) Publish(ctx context.Context, change *domain_ticket.AvailabilityChange) error {
+	r.mu.Lock()
+	subscribers := make([]*memoryAvailabilitySubscriber, 0, len(r.subscribers))
+	for sub := range r.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	r.mu.Unlock()
+
+	m := memoryAvailabilityChange{tenant: tenant.FromContext(ctx), change: *change}
+	for _, sub := range subscribers {
+		select {
+		case sub.ch <- m:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (r *memoryAvailabilityFeedRepository) Subscribe(ctx context.Context, handle func(ctx context.Context, change *domain_ticket.AvailabilityChange)) error {
+	sub := &memoryAvailabilitySubscriber{ch: make(chan memoryAvailabilityChange, 64), done: make(chan struct{})}
+	r.mu.Lock()
+	if r.subscribers == nil {
+		r.subscribers = make(map[*memoryAvailabilitySubscriber]struct{})
+	}
+	r.subscribers[sub] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.subscribers, sub)
+		r.mu.Unlock()
+		close(sub.done)
+	}()
+
+	handle(ctx, recountAll())
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m := <-sub.ch:
+			change := m.change
+			handle(tenant.WithID(ctx, m.tenant), &change)
+		}
+	}
+}
+
+// In-memory Booking Update Repository
+// Keeps each request's latest update and delivers it to the subscribers in
+// this process
+type memoryBookingUpdateRepository struct {
+	keys *memoryKeys
+
+	mu          sync.Mutex
+	subscribers map[*memoryBookingUpdateSubscriber]struct{}
+}
+
+type memoryBookingUpdateSubscriber struct {
+	ch   chan memoryBookingUpdate
+	done chan struct{} // closed when the subscriber stops
+}
+
+type memoryBookingUpdate struct {
+	tenant string
+	update domain_booking.RequestUpdate
+}
+
+func (r *memoryBookingUpdateRepository) Publish(ctx context.Context, update *domain_booking.RequestUpdate) error {
+	r.keys.mu.Lock()
+	latest := *update
+	r.keys.set(bookingRequestKey(ctx, update.RequestID), &latest, bookingUpdateTTL)
+	if update.BookingID != nil {
+		r.keys.set(bookingRequestOfKey(ctx, *update.BookingID), update.RequestID, bookingUpdateTTL)
+	}
+	r.keys.mu.Unlock()
+
+	r.mu.Lock()
+	subscribers := make([]*memoryBookingUpdateSubscriber, 0, len(r.subscribers))
+	for sub := range r.subscribers {
+		subscribers = append(subscribers, sub)
+	}
+	r.mu.Unlock()
+
+	m := memoryBookingUpdate{tenant: tenant.FromContext(ctx), update: *update}
+	for _, sub := range subscribers {
+		select {
+		case sub.ch <- m:
+		case <-sub.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (r *memoryBookingUpdateRepository) Latest(ctx context.Context, requestID string) (*domain_booking.RequestUpdate, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(bookingRequestKey(ctx, requestID))
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	update := *value.(*domain_booking.RequestUpdate)
+	return &update, nil
+}
+
+func (r *memoryBookingUpdateRepository) RequestOf(ctx context.Context, bookingID uuid.UUID) (string, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(bookingRequestOfKey(ctx, bookingID))
+	if !ok {
+		return "", domain.ErrNotFound
+	}
+	return value.(string), nil
+}
+
+func (r *memoryBookingUpdateRepository) Subscribe(ctx context.Context, handle func(ctx context.Context, update *domain_booking.RequestUpdate)) error {
+	sub := &memoryBookingUpdateSubscriber{ch: make(chan memoryBookingUpdate, 64), done: make(chan struct{})}
+	r.mu.Lock()
+	if r.subscribers == nil {
+		r.subscribers = make(map[*memoryBookingUpdateSubscriber]struct{})
+	}
+	r.subscribers[sub] = struct{}{}
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		delete(r.subscribers, sub)
+		r.mu.Unlock()
+		close(sub.done)
+	}()
+
+	handle(ctx, &domain_booking.RequestUpdate{})
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case m := <-sub.ch:
+			update := m.update
+			handle(tenant.WithID(ctx, m.tenant), &update)
+		}
+	}
+}
+
+// In-memory Booking Metrics Repository
+// Keeps each day's counts in one map, as Redis keeps them in one hash
+type memoryBookingMetricsRepository struct {
+	keys *memoryKeys
+}
+
+func (r *memoryBookingMetricsRepository) Add(ctx context.Context, minute *domain_stats.BookingMinute, retention time.Duration) error {
+	start := minute.Minute.UTC()
+	day := start.Truncate(24 * time.Hour)
+	key := bookingMetricsKey(ctx, day)
+
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	counts := make(map[string]int64)
+	if value, ok := r.keys.get(key); ok {
+		for field, n := range value.(map[string]int64) {
+			counts[field] = n
+		}
+	}
+	for i, value := range bookingMetricsValues(minute.BookingCounts) {
+		if value != 0 {
+			counts[start.Format("15:04")+":"+bookingMetricsFields[i]] += value
+		}
+	}
+	r.keys.set(key, counts, time.Until(day.Add(24*time.Hour+retention)))
+	return nil
+}
+
+func (r *memoryBookingMetricsRepository) Range(ctx context.Context, from, to time.Time) ([]*domain_stats.BookingMinute, error) {
+	from, to = from.UTC(), to.UTC()
+	minutes := make(map[time.Time]*domain_stats.BookingMinute)
+
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	for day := from.Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		value, ok := r.keys.get(bookingMetricsKey(ctx, day))
+		if !ok {
+			continue
+		}
+		for field, n := range value.(map[string]int64) {
+			t, err := time.Parse("15:04", field[:5])
+			if err != nil {
+				continue
+			}
+			start := day.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+			if !start.Before(from) && start.Before(to) {
+				addBookingMetric(minutes, start, field[6:], n)
+			}
+		}
+	}
+	return sortedBookingMinutes(minutes), nil
+}
+
+// In-memory Session Repository
+// Sessions are kept under the Redis key of each, with their token hash, and
+// expire the same way. The per-user index is a map under the Redis key of
+// the set.
+type memorySessionRepository struct {
+	keys *memoryKeys
+}
+
+// userSessions returns a user's session IDs. The caller holds keys.mu.
+func (r *memorySessionRepository) userSessions(ctx context.Context, userID uuid.UUID) map[uuid.UUID]bool {
+	key := userSessionsKey(ctx, userID)
+	value, ok := r.keys.get(key)
+	if !ok {
+		value = make(map[uuid.UUID]bool)
+		r.keys.set(key, value, 0)
+	}
+	return value.(map[uuid.UUID]bool)
+}
+
+func (r *memorySessionRepository) Create(ctx context.Context, session *domain_session.Session) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(sessionKey(ctx, session.ID), *session, time.Until(session.ExpiresAt))
+	r.userSessions(ctx, session.UserID)[session.ID] = true
+	return nil
+}
+
+func (r *memorySessionRepository) Get(ctx context.Context, id uuid.UUID) (*domain_session.Session, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	value, ok := r.keys.get(sessionKey(ctx, id))
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	session := value.(domain_session.Session)
+	return &session, nil
+}
+
+func (r *memorySessionRepository) Rotate(ctx context.Context, session *domain_session.Session, previousHash string) (bool, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := sessionKey(ctx, session.ID)
+	value, ok := r.keys.get(key)
+	if !ok || value.(domain_session.Session).TokenHash != previousHash {
+		return false, nil
+	}
+	r.keys.set(key, *session, time.Until(session.ExpiresAt))
+	return true, nil
+}
+
+func (r *memorySessionRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_session.Session, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	ids := r.userSessions(ctx, userID)
+	var sessions []*domain_session.Session
+	for id := range ids {
+		value, ok := r.keys.get(sessionKey(ctx, id))
+		if !ok {
+			delete(ids, id)
+			continue
+		}
+		session := value.(domain_session.Session)
+		sessions = append(sessions, &session)
+	}
+	sortSessions(sessions)
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) Delete(ctx context.Context, session *domain_session.Session) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	delete(r.keys.vals, sessionKey(ctx, session.ID))
+	delete(r.userSessions(ctx, session.UserID), session.ID)
+	return nil
+}
+
+func (r *memorySessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	deleted := 0
+	for id := range r.userSessions(ctx, userID) {
+		if _, ok := r.keys.get(sessionKey(ctx, id)); ok {
+			delete(r.keys.vals, sessionKey(ctx, id))
+			deleted++
+		}
+	}
+	delete(r.keys.vals, userSessionsKey(ctx, userID))
+	return deleted, nil
+}
+
+// In-memory Rate Limit Repository
+type memoryRateLimitRepository struct {
+	keys *memoryKeys
+}
+
+type memoryRateWindow struct {
+	count   int
+	resetAt time.Time
+}
+
+func (r *memoryRateLimitRepository) Allow(ctx context.Context, name string, limit int, window time.Duration) (bool, time.Duration, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := rateLimitKey(ctx, name)
+	current := memoryRateWindow{resetAt: time.Now().Add(window)}
+	if stored, ok := r.keys.get(key); ok {
+		current = stored.(memoryRateWindow)
+	}
+	current.count++
+	r.keys.set(key, current, time.Until(current.resetAt))
+	return current.count <= limit, time.Until(current.resetAt), nil
+}
+
+// In-memory Password Reset Repository
+type memoryPasswordResetRepository struct {
+	keys *memoryKeys
+}
+
+func (r *memoryPasswordResetRepository) Create(ctx context.Context, tokenHash string, userID uuid.UUID, ttl time.Duration) error {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	r.keys.set(passwordResetKey(ctx, tokenHash), userID, ttl)
+	return nil
+}
+
+func (r *memoryPasswordResetRepository) Take(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	r.keys.mu.Lock()
+	defer r.keys.mu.Unlock()
+	key := passwordResetKey(ctx, tokenHash)
+	value, ok := r.keys.get(key)
+	if !ok {
+		return uuid.Nil, domain.ErrNotFound
+	}
+	delete(r.keys.vals, key)
+	return value.(uuid.UUID), nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+// memoryEventWithTickets stores an event with one available ticket per seat
+func memoryEventWithTickets(t *testing.T, repos *RepositoryContainer, ctx context.Context, seats int) []uuid.UUID {
+	t.Helper()
+	evt := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Artist: "The Band", Venue: "Arena", Date: time.Now().Add(24 * time.Hour), TotalSeats: seats, Price: 50, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, evt); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	ids := make([]uuid.UUID, seats)
+	for i := range ids {
+		ids[i] = uuid.New()
+		tkt := &domain_ticket.Ticket{ID: ids[i], EventID: evt.ID, SeatNumber: i + 1, Status: domain_ticket.TicketStatusAvailable, Price: 50}
+		if err := repos.Ticket.Create(ctx, tkt); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+	}
+	return ids
+}
+
+func TestMemoryReserveTicketsIsAllOrNothing(t *testing.T) {
+	repos := NewMemoryRepositoryContainer(nil, DefaultCacheTTLs())
+	ctx := context.Background()
+	ids := memoryEventWithTickets(t, repos, ctx, 3)
+
+	if err := repos.Ticket.ReserveTickets(ctx, ids[:1]); err != nil {
+		t.Fatalf("reserve first seat: %v", err)
+	}
+	if err := repos.Ticket.ReserveTickets(ctx, ids); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("reserve overlapping seats: got %v, want ErrConflict", err)
+	}
+	tkt, err := repos.Ticket.GetByID(ctx, ids[1])
+	if err != nil || tkt.Status != domain_ticket.TicketStatusAvailable {
+		t.Fatalf("seat 2 after failed reserve: got %v, %v, want available", tkt, err)
+	}
+
+	if _, err := repos.Ticket.GetByID(ctx, uuid.New()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("unknown ticket: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestMemoryWithinTxRollsBack(t *testing.T) {
+	repos := NewMemoryRepositoryContainer(nil, DefaultCacheTTLs())
+	ctx := context.Background()
+	ids := memoryEventWithTickets(t, repos, ctx, 2)
+
+	failed := errors.New("payment declined")
+	err := repos.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := repos.Ticket.ReserveTickets(ctx, ids); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Fatalf("WithinTx: got %v, want %v", err, failed)
+	}
+	for _, id := range ids {
+		tkt, err := repos.Ticket.GetByID(ctx, id)
+		if err != nil || tkt.Status != domain_ticket.TicketStatusAvailable {
+			t.Fatalf("ticket after rollback: got %v, %v, want available", tkt, err)
+		}
+	}
+}
+
+func TestMemoryTenantsAreIsolated(t *testing.T) {
+	repos := NewMemoryRepositoryContainer(nil, DefaultCacheTTLs())
+	acme := tenant.WithID(context.Background(), "acme")
+	ids := memoryEventWithTickets(t, repos, acme, 1)
+
+	if _, err := repos.Ticket.GetByID(tenant.WithID(context.Background(), "globex"), ids[0]); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("another tenant's ticket: got %v, want ErrNotFound", err)
+	}
+	if _, err := repos.Ticket.GetByID(acme, ids[0]); err != nil {
+		t.Fatalf("own ticket: %v", err)
+	}
+}
+
+func TestMemoryEventCacheInvalidateDropsEntries(t *testing.T) {
+	repos := NewMemoryRepositoryContainer(nil, DefaultCacheTTLs())
+	ctx := context.Background()
+	evt := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Status: domain_event.EventStatusPublished}
+
+	version, err := repos.EventCache.Version(ctx)
+	if err != nil {
+		t.Fatalf("version: %v", err)
+	}
+	if err := repos.EventCache.Set(ctx, version, evt); err != nil {
+		t.Fatalf("set event: %v", err)
+	}
+	if err := repos.EventCache.SetAllEvents(ctx, version, []*domain_event.Event{evt}); err != nil {
+		t.Fatalf("set listing: %v", err)
+	}
+
+	if err := repos.EventCache.Invalidate(ctx); err != nil {
+		t.Fatalf("invalidate: %v", err)
+	}
+	next, err := repos.EventCache.Version(ctx)
+	if err != nil || next == version {
+		t.Fatalf("version after invalidate: got %d, %v, want a new version", next, err)
+	}
+	if _, err := repos.EventCache.GetByID(ctx, next, evt.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("event after invalidate: got %v, want ErrNotFound", err)
+	}
+	if _, err := repos.EventCache.GetAll(ctx, next); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("listing after invalidate: got %v, want ErrNotFound", err)
+	}
+
+	// A fill under the old version stays out of sight
+	if err := repos.EventCache.Set(ctx, version, evt); err != nil {
+		t.Fatalf("stale fill: %v", err)
+	}
+	if _, err := repos.EventCache.GetByID(ctx, next, evt.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("event after stale fill: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,610 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/utils/querybuilder"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// The MySQL repositories mirror the Postgres ones in MySQL's dialect:
+// "?" placeholders, lists expanded with sqlx.In instead of bound as arrays,
+// and no RETURNING.
+
+// mysqlBookingColumns is the select list scanned into domain_booking.Booking
+const mysqlBookingColumns = "id, user_id, event_id, status, total_amount, currency, exchange_rate, payment_reference, risk_score, created_at, updated_at, expires_at"
+
+// affectedOne maps an update or delete that matched no row to ErrNotFound
+func affectedOne(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// lockDeleted locks the soft deleted row query selects for a purge,
+// returning domain.ErrNotFound when there is none
+func lockDeleted(ctx context.Context, tx *sqlx.Tx, query string, id uuid.UUID) error {
+	var locked uuid.UUID
+	err := tx.GetContext(ctx, &locked, query, id)
+	if err == sql.ErrNoRows {
+		return domain.ErrNotFound
+	}
+	return err
+}
+
+// refuseActiveBookings returns domain_booking.ErrActiveBookings when query
+// finds bookings still holding tickets
+func refuseActiveBookings(ctx context.Context, tx *sqlx.Tx, query string, id uuid.UUID) error {
+	var active bool
+	if err := tx.GetContext(ctx, &active, query, id); err != nil {
+		return err
+	}
+	if active {
+		return domain_booking.ErrActiveBookings
+	}
+	return nil
+}
+
+// MySQL User Repository
+type mysqlUserRepository struct {
+	db *tenantDB
+}
+
+func (r *mysqlUserRepository) Create(ctx context.Context, usr *domain_user.User) error {
+	query := `INSERT INTO users (id, email, name, role, email_verified_at, locked_at, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, usr.ID, usr.Email, usr.Name, userRole(usr), usr.EmailVerifiedAt, usr.LockedAt, usr.CreatedAt, usr.UpdatedAt)
+	if isUniqueViolation(err) {
+		return domain_user.ErrEmailTaken
+	}
+	return err
+}
+
+func (r *mysqlUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_user.User, error) {
+	return r.get(ctx, `SELECT id, email, name, role, email_verified_at, locked_at, created_at, updated_at FROM users WHERE id = ? AND deleted_at IS NULL`, id)
+}
+
+func (r *mysqlUserRepository) GetByEmail(ctx context.Context, email string) (*domain_user.User, error) {
+	return r.get(ctx, `SELECT id, email, name, role, email_verified_at, locked_at, created_at, updated_at FROM users WHERE email = ? AND deleted_at IS NULL`, email)
+}
+
+func (r *mysqlUserRepository) get(ctx context.Context, query string, arg interface{}) (*domain_user.User, error) {
+	var usr domain_user.User
+	if err := r.db.GetContext(ctx, &usr, query, arg); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &usr, nil
+}
+
+func (r *mysqlUserRepository) Update(ctx context.Context, usr *domain_user.User) error {
+	query := `UPDATE users SET email = ?, name = ?, role = ?, email_verified_at = ?, locked_at = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, usr.Email, usr.Name, userRole(usr), usr.EmailVerifiedAt, usr.LockedAt, usr.UpdatedAt, usr.ID)
+	if isUniqueViolation(err) {
+		return domain_user.ErrEmailTaken
+	}
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlUserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlUserRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlUserRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := lockDeleted(ctx, tx, `SELECT id FROM users WHERE id = ? AND deleted_at IS NOT NULL FOR UPDATE`, id); err != nil {
+			return err
+		}
+		if err := refuseActiveBookings(ctx, tx, `SELECT EXISTS (SELECT 1 FROM bookings WHERE user_id = ? AND status IN ('pending', 'review', 'confirmed'))`, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM users WHERE id = ?`, id)
+		return err
+	})
+}
+
+func (r *mysqlUserRepository) GetPasswordHash(ctx context.Context, id uuid.UUID) (string, error) {
+	var hash string
+	err := r.db.GetContext(ctx, &hash, `SELECT password_hash FROM users WHERE id = ? AND deleted_at IS NULL`, id)
+	if err == sql.ErrNoRows {
+		return "", domain.ErrNotFound
+	}
+	return hash, err
+}
+
+func (r *mysqlUserRepository) SetPasswordHash(ctx context.Context, id uuid.UUID, hash string) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE users SET password_hash = ? WHERE id = ? AND deleted_at IS NULL`, hash, id)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+// MySQL Event Repository
+type mysqlEventRepository struct {
+	db *tenantDB
+}
+
+func (r *mysqlEventRepository) Create(ctx context.Context, evt *domain_event.Event) error {
+	query := `INSERT INTO events (id, name, artist, venue, date, total_seats, price, status, sales_start_at, sales_end_at, presale_start_at, confirmation_requirements, waiting_room_enabled, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := r.db.ExecContext(ctx, query, evt.ID, evt.Name, evt.Artist, evt.Venue, evt.Date, evt.TotalSeats, evt.Price, evt.Status, evt.SalesStartAt, evt.SalesEndAt, evt.PresaleStartAt, evt.ConfirmationRequirements, evt.WaitingRoomEnabled, evt.CreatedAt, evt.UpdatedAt)
+	return err
+}
+
+func (r *mysqlEventRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_event.Event, error) {
+	query := `SELECT ` + strings.Join(eventColumns, ", ") + ` FROM events WHERE id = ? AND deleted_at IS NULL`
+	var evt domain_event.Event
+	if err := r.db.GetContext(ctx, &evt, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &evt, nil
+}
+
+func (r *mysqlEventRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_event.Event, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In(`SELECT `+strings.Join(eventColumns, ", ")+` FROM events WHERE id IN (?) AND deleted_at IS NULL`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var events []*domain_event.Event
+	if err := r.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *mysqlEventRepository) GetAll(ctx context.Context) ([]*domain_event.Event, error) {
+	query := `SELECT ` + strings.Join(eventColumns, ", ") + ` FROM events WHERE deleted_at IS NULL ORDER BY date ASC`
+	var events []*domain_event.Event
+	if err := r.db.SelectContext(ctx, &events, query); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *mysqlEventRepository) Update(ctx context.Context, evt *domain_event.Event) error {
+	query := `UPDATE events SET name = ?, artist = ?, venue = ?, date = ?, total_seats = ?, price = ?, status = ?, sales_start_at = ?, sales_end_at = ?, presale_start_at = ?, confirmation_requirements = ?, waiting_room_enabled = ?, updated_at = ? WHERE id = ? AND deleted_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, evt.Name, evt.Artist, evt.Venue, evt.Date, evt.TotalSeats, evt.Price, evt.Status, evt.SalesStartAt, evt.SalesEndAt, evt.PresaleStartAt, evt.ConfirmationRequirements, evt.WaitingRoomEnabled, evt.UpdatedAt, evt.ID)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlEventRepository) GetSalesWindowChanges(ctx context.Context, from, to time.Time) ([]*domain_event.Event, error) {
+	query := `SELECT ` + strings.Join(eventColumns, ", ") + ` FROM events WHERE deleted_at IS NULL AND ((sales_start_at > ? AND sales_start_at <= ?) OR (sales_end_at > ? AND sales_end_at <= ?) OR (presale_start_at > ? AND presale_start_at <= ?))`
+	var events []*domain_event.Event
+	if err := r.db.SelectContext(ctx, &events, query, from, to, from, to, from, to); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *mysqlEventRepository) Search(ctx context.Context, filter domain_event.EventFilter) ([]*domain_event.Event, error) {
+	query, args, err := buildEventSearchQuery(filter, querybuilder.MySQL)
+	if err != nil {
+		return nil, err
+	}
+	var events []*domain_event.Event
+	if err := r.db.SelectContext(ctx, &events, query, args...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (r *mysqlEventRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE events SET deleted_at = ? WHERE id = ? AND deleted_at IS NULL`, time.Now(), id)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlEventRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `UPDATE events SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`, id)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlEventRepository) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		if err := lockDeleted(ctx, tx, `SELECT id FROM events WHERE id = ? AND deleted_at IS NOT NULL FOR UPDATE`, id); err != nil {
+			return err
+		}
+		if err := refuseActiveBookings(ctx, tx, `SELECT EXISTS (SELECT 1 FROM bookings WHERE event_id = ? AND status IN ('pending', 'review', 'confirmed'))`, id); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `DELETE FROM events WHERE id = ?`, id)
+		return err
+	})
+}
+
+// MySQL Ticket Repository
+type mysqlTicketRepository struct {
+	db    *tenantDB
+	price columnSwitch // prices moving to integer cents
+}
+
+// columns is the select list scanned into domain_ticket.Ticket
+func (r *mysqlTicketRepository) columns() string {
+	return "id, event_id, seat_number, status, " + r.price.selectExpr() + ", created_at, updated_at"
+}
+
+func (r *mysqlTicketRepository) Create(ctx context.Context, tkt *domain_ticket.Ticket) error {
+	columns := []string{"id", "event_id", "seat_number", "status", "created_at", "updated_at"}
+	args := []interface{}{tkt.ID, tkt.EventID, tkt.SeatNumber, tkt.Status, tkt.CreatedAt, tkt.UpdatedAt}
+	priceColumns, priceArgs := r.price.writes(tkt.Price)
+	columns = append(columns, priceColumns...)
+	args = append(args, priceArgs...)
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ")
+	query := fmt.Sprintf(`INSERT INTO tickets (%s) VALUES (%s)`, strings.Join(columns, ", "), placeholders)
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *mysqlTicketRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_ticket.Ticket, error) {
+	query := `SELECT ` + r.columns() + ` FROM tickets WHERE id = ?`
+	var tkt domain_ticket.Ticket
+	if err := r.db.GetContext(ctx, &tkt, query, id); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &tkt, nil
+}
+
+func (r *mysqlTicketRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	query, args, err := sqlx.In(`SELECT `+r.columns()+` FROM tickets WHERE id IN (?)`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var tickets []*domain_ticket.Ticket
+	if err := r.db.SelectContext(ctx, &tickets, query, args...); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *mysqlTicketRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	query := `SELECT ` + r.columns() + ` FROM tickets WHERE event_id = ? ORDER BY seat_number ASC`
+	var tickets []*domain_ticket.Ticket
+	if err := r.db.SelectContext(ctx, &tickets, query, eventID); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *mysqlTicketRepository) GetAvailableByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	query := `SELECT ` + r.columns() + ` FROM tickets WHERE event_id = ? AND status = 'available' ORDER BY seat_number ASC`
+	var tickets []*domain_ticket.Ticket
+	if err := r.db.SelectContext(ctx, &tickets, query, eventID); err != nil {
+		return nil, err
+	}
+	return tickets, nil
+}
+
+func (r *mysqlTicketRepository) Update(ctx context.Context, tkt *domain_ticket.Ticket) error {
+	query := `UPDATE tickets SET status = ?, updated_at = ? WHERE id = ?`
+	result, err := r.db.ExecContext(ctx, query, tkt.Status, tkt.UpdatedAt, tkt.ID)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlTicketRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM tickets WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+// ReserveTickets moves tickets from available to reserved. Without
+// RETURNING the available tickets are first read with locks, which make a
+// concurrent reservation wait for this one to commit and then see the
+// tickets are taken.
+func (r *mysqlTicketRepository) ReserveTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query, args, err := sqlx.In(`SELECT id FROM tickets WHERE id IN (?) AND status = 'available' FOR UPDATE`, ticketIDs)
+		if err != nil {
+			return err
+		}
+		var available []uuid.UUID
+		if err := tx.SelectContext(ctx, &available, query, args...); err != nil {
+			return err
+		}
+		if err := unreservedTicket(ticketIDs, available); err != nil {
+			return err
+		}
+
+		query, args, err = sqlx.In(`UPDATE tickets SET status = 'reserved', updated_at = NOW(6) WHERE id IN (?)`, ticketIDs)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, query, args...)
+		return err
+	})
+}
+
+func (r *mysqlTicketRepository) ConfirmTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(`UPDATE tickets SET status = 'sold', updated_at = NOW(6) WHERE id IN (?) AND status = 'reserved'`, ticketIDs)
+	if err != nil {
+		return err
+	}
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if int(rowsAffected) != len(ticketIDs) {
+		return fmt.Errorf("not all tickets could be confirmed")
+	}
+	return nil
+}
+
+func (r *mysqlTicketRepository) ReleaseTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	if len(ticketIDs) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(`UPDATE tickets SET status = 'available', updated_at = NOW(6) WHERE id IN (?) AND status IN ('reserved', 'cancelled')`, ticketIDs)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// RestockTickets puts sold tickets back on sale after a refund
+func (r *mysqlTicketRepository) RestockTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `UPDATE tickets SET status = 'available', updated_at = NOW(6) WHERE id = ? AND status = 'sold'`
+		for _, id := range ticketIDs {
+			if _, err := tx.ExecContext(ctx, query, id); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MySQL Booking Repository
+type mysqlBookingRepository struct {
+	db *tenantDB
+}
+
+// Create stores a booking together with its tickets and line items
+func (r *mysqlBookingRepository) Create(ctx context.Context, bk *domain_booking.Booking) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO bookings (id, user_id, event_id, status, total_amount, currency, exchange_rate, created_at, updated_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+		if _, err := tx.ExecContext(ctx, query, bk.ID, bk.UserID, bk.EventID, bk.Status, bk.TotalAmount, bk.Currency, bk.ExchangeRate, bk.CreatedAt, bk.UpdatedAt, bk.ExpiresAt); err != nil {
+			return err
+		}
+		if err := r.insertTickets(ctx, tx, bk); err != nil {
+			return err
+		}
+		return r.insertItems(ctx, tx, bk.Items)
+	})
+}
+
+// insertTickets records the tickets a booking holds, in order
+func (r *mysqlBookingRepository) insertTickets(ctx context.Context, tx *sqlx.Tx, bk *domain_booking.Booking) error {
+	query := `INSERT INTO booking_tickets (booking_id, ticket_id, position) VALUES (?, ?, ?)`
+	for i, ticketID := range bk.TicketIDs {
+		if _, err := tx.ExecContext(ctx, query, bk.ID, ticketID, i+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *mysqlBookingRepository) insertItems(ctx context.Context, tx *sqlx.Tx, items []domain_booking.LineItem) error {
+	query := `INSERT INTO booking_items (booking_id, ticket_id, seat_number, base_price, unit_price, currency, exchange_rate, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	for _, item := range items {
+		if _, err := tx.ExecContext(ctx, query, item.BookingID, item.TicketID, item.SeatNumber, item.BasePrice, item.UnitPrice, item.Currency, item.ExchangeRate, item.CreatedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetItems retrieves the line items of a booking
+func (r *mysqlBookingRepository) GetItems(ctx context.Context, bookingID uuid.UUID) ([]domain_booking.LineItem, error) {
+	query := `SELECT booking_id, ticket_id, seat_number, base_price, unit_price, currency, exchange_rate, created_at FROM booking_items WHERE booking_id = ? ORDER BY seat_number ASC`
+	var items []domain_booking.LineItem
+	if err := r.db.SelectContext(ctx, &items, query, bookingID); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// SwapItems replaces the line items of tickets taken off a booking with
+// the items of the tickets that took their place
+func (r *mysqlBookingRepository) SwapItems(ctx context.Context, bookingID uuid.UUID, removed []uuid.UUID, added []domain_booking.LineItem) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `DELETE FROM booking_items WHERE booking_id = ? AND ticket_id = ?`
+		for _, ticketID := range removed {
+			if _, err := tx.ExecContext(ctx, query, bookingID, ticketID); err != nil {
+				return err
+			}
+		}
+		return r.insertItems(ctx, tx, added)
+	})
+}
+
+// withTicketIDs fills in the tickets each booking holds in one query
+func (r *mysqlBookingRepository) withTicketIDs(ctx context.Context, bookings []*domain_booking.Booking) ([]*domain_booking.Booking, error) {
+	if len(bookings) == 0 {
+		return bookings, nil
+	}
+	ids := make([]uuid.UUID, len(bookings))
+	byID := make(map[uuid.UUID]*domain_booking.Booking, len(bookings))
+	for i, bk := range bookings {
+		ids[i] = bk.ID
+		byID[bk.ID] = bk
+		bk.TicketIDs = []uuid.UUID{}
+	}
+
+	query, args, err := sqlx.In(`SELECT booking_id, ticket_id FROM booking_tickets WHERE booking_id IN (?) ORDER BY booking_id, position`, ids)
+	if err != nil {
+		return nil, err
+	}
+	var rows []struct {
+		BookingID uuid.UUID `db:"booking_id"`
+		TicketID  uuid.UUID `db:"ticket_id"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		bk := byID[row.BookingID]
+		bk.TicketIDs = append(bk.TicketIDs, row.TicketID)
+	}
+	return bookings, nil
+}
+
+// get retrieves the one booking query selects
+func (r *mysqlBookingRepository) get(ctx context.Context, query string, arg interface{}) (*domain_booking.Booking, error) {
+	var bk domain_booking.Booking
+	if err := r.db.GetContext(ctx, &bk, query, arg); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	if _, err := r.withTicketIDs(ctx, []*domain_booking.Booking{&bk}); err != nil {
+		return nil, err
+	}
+	return &bk, nil
+}
+
+// list retrieves the bookings query selects
+func (r *mysqlBookingRepository) list(ctx context.Context, query string, args ...interface{}) ([]*domain_booking.Booking, error) {
+	var bookings []*domain_booking.Booking
+	if err := r.db.SelectContext(ctx, &bookings, query, args...); err != nil {
+		return nil, err
+	}
+	return r.withTicketIDs(ctx, bookings)
+}
+
+func (r *mysqlBookingRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_booking.Booking, error) {
+	return r.get(ctx, `SELECT `+mysqlBookingColumns+` FROM bookings WHERE id = ?`, id)
+}
+
+// GetByTicketID retrieves the pending or confirmed booking holding a ticket
+func (r *mysqlBookingRepository) GetByTicketID(ctx context.Context, ticketID uuid.UUID) (*domain_booking.Booking, error) {
+	query := `SELECT b.id, b.user_id, b.event_id, b.status, b.total_amount, b.currency, b.exchange_rate, b.payment_reference, b.risk_score, b.created_at, b.updated_at, b.expires_at FROM bookings b JOIN booking_tickets bt ON bt.booking_id = b.id WHERE bt.ticket_id = ? AND b.status IN ('pending', 'confirmed') ORDER BY b.created_at DESC LIMIT 1`
+	return r.get(ctx, query, ticketID)
+}
+
+func (r *mysqlBookingRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error) {
+	return r.list(ctx, `SELECT `+mysqlBookingColumns+` FROM bookings WHERE user_id = ? ORDER BY created_at DESC`, userID)
+}
+
+func (r *mysqlBookingRepository) GetByEventID(ctx context.Context, eventID uuid.UUID) ([]*domain_booking.Booking, error) {
+	return r.list(ctx, `SELECT `+mysqlBookingColumns+` FROM bookings WHERE event_id = ? ORDER BY created_at DESC`, eventID)
+}
+
+// Update saves a booking and replaces the tickets it holds
+func (r *mysqlBookingRepository) Update(ctx context.Context, bk *domain_booking.Booking) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `UPDATE bookings SET status = ?, total_amount = ?, payment_reference = ?, risk_score = ?, updated_at = ?, expires_at = ? WHERE id = ?`
+		result, err := tx.ExecContext(ctx, query, bk.Status, bk.TotalAmount, bk.PaymentReference, bk.RiskScore, bk.UpdatedAt, bk.ExpiresAt, bk.ID)
+		if err != nil {
+			return err
+		}
+		if err := affectedOne(result); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM booking_tickets WHERE booking_id = ?`, bk.ID); err != nil {
+			return err
+		}
+		return r.insertTickets(ctx, tx, bk)
+	})
+}
+
+func (r *mysqlBookingRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM bookings WHERE id = ?`, id)
+	if err != nil {
+		return err
+	}
+	return affectedOne(result)
+}
+
+func (r *mysqlBookingRepository) GetExpiredBookings(ctx context.Context, before time.Time) ([]*domain_booking.Booking, error) {
+	return r.list(ctx, `SELECT `+mysqlBookingColumns+` FROM bookings WHERE expires_at < ? AND status = 'pending' ORDER BY expires_at ASC`, before)
+}
+
+// GetByStatus retrieves the bookings in a status, those expiring first first
+func (r *mysqlBookingRepository) GetByStatus(ctx context.Context, status domain_booking.BookingStatus) ([]*domain_booking.Booking, error) {
+	return r.list(ctx, `SELECT `+mysqlBookingColumns+` FROM bookings WHERE status = ? ORDER BY expires_at ASC`, status)
+}
+
+// GetExpiringBetween retrieves pending bookings whose hold runs out in (from, to]
+func (r *mysqlBookingRepository) GetExpiringBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error) {
+	return r.list(ctx, `SELECT `+mysqlBookingColumns+` FROM bookings WHERE expires_at > ? AND expires_at <= ? AND status = 'pending' ORDER BY expires_at ASC`, from, to)
+}
+
+// GetConfirmedForEventsBetween retrieves confirmed bookings for events taking place in (from, to]
+func (r *mysqlBookingRepository) GetConfirmedForEventsBetween(ctx context.Context, from, to time.Time) ([]*domain_booking.Booking, error) {
+	query := `SELECT b.id, b.user_id, b.event_id, b.status, b.total_amount, b.currency, b.exchange_rate, b.payment_reference, b.risk_score, b.created_at, b.updated_at, b.expires_at FROM bookings b JOIN events e ON e.id = b.event_id WHERE e.deleted_at IS NULL AND e.date > ? AND e.date <= ? AND b.status = 'confirmed' ORDER BY e.date ASC`
+	return r.list(ctx, query, from, to)
+}
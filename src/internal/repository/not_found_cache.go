@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrCachedNotFound is returned by cache lookups that found a marker left
+// by an earlier lookup of something that did not exist. Unlike a plain
+// cache miss, the caller can answer not found without the database.
+var ErrCachedNotFound = fmt.Errorf("%w (cached)", domain.ErrNotFound)
+
+// notFoundMarker is stored at a cache key in place of the cached value
+const notFoundMarker = "!notfound"
+
+// Redis Booking Cache Repository
+// Bookings change too often to cache, so only lookups of bookings that do
+// not exist are remembered.
+type redisBookingCacheRepository struct {
+	client *redis.Client
+}
+
+func cachedBookingKey(ctx context.Context, id uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("booking:%s", id.String()))
+}
+
+func (r *redisBookingCacheRepository) CheckNotFound(ctx context.Context, id uuid.UUID) error {
+	value, err := r.client.Get(ctx, cachedBookingKey(ctx, id)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil
+		}
+		return err
+	}
+	if value == notFoundMarker {
+		return ErrCachedNotFound
+	}
+	return nil
+}
+
+func (r *redisBookingCacheRepository) SetNotFound(ctx context.Context, id uuid.UUID, ttl time.Duration) error {
+	return skipUnavailable(r.client.Set(ctx, cachedBookingKey(ctx, id), notFoundMarker, ttl).Err())
+}
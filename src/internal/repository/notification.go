@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgreSQL Notification Repository
+type postgresNotificationRepository struct {
+	db *tenantDB
+}
+
+// Enqueue queues notifications, skipping any a booking has already been sent
+func (r *postgresNotificationRepository) Enqueue(ctx context.Context, notifications ...*domain_notification.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO notifications (id, kind, booking_id, user_id, event_id, status, attempts, next_attempt_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (booking_id, kind) DO NOTHING`
+		for _, n := range notifications {
+			if _, err := tx.ExecContext(ctx, query, n.ID, n.Kind, n.BookingID, n.UserID, n.EventID, n.Status, n.Attempts, n.NextAttemptAt, n.CreatedAt, n.UpdatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimDue leases pending notifications that are due by pushing their next
+// attempt into the future, so concurrent workers never send the same one
+func (r *postgresNotificationRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_notification.Notification, error) {
+	query := `UPDATE notifications SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM notifications
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, booking_id, user_id, event_id, status, attempts, next_attempt_at, last_error, sent_at, created_at, updated_at`
+	var notifications []*domain_notification.Notification
+	err := r.db.SelectContext(ctx, &notifications, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+func (r *postgresNotificationRepository) Update(ctx context.Context, n *domain_notification.Notification) error {
+	query := `UPDATE notifications SET status = $2, attempts = $3, next_attempt_at = $4, last_error = $5, sent_at = $6, updated_at = $7 WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, n.ID, n.Status, n.Attempts, n.NextAttemptAt, n.LastError, n.SentAt, n.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresNotificationRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain_notification.Preferences, error) {
+	query := `SELECT user_id, channels, created_at, updated_at FROM notification_preferences WHERE user_id = $1`
+	var p domain_notification.Preferences
+	err := r.db.GetContext(ctx, &p, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// SavePreferences creates or replaces a user's notification preferences
+func (r *postgresNotificationRepository) SavePreferences(ctx context.Context, p *domain_notification.Preferences) error {
+	query := `INSERT INTO notification_preferences (user_id, channels, created_at, updated_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE SET channels = EXCLUDED.channels, updated_at = EXCLUDED.updated_at`
+	_, err := r.db.ExecContext(ctx, query, p.UserID, p.Channels, p.CreatedAt, p.UpdatedAt)
+	return err
+}
+
+func (r *postgresNotificationRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_notification.Notification, error) {
+	query := `SELECT id, kind, booking_id, user_id, event_id, status, attempts, next_attempt_at, last_error, sent_at, created_at, updated_at
+		FROM notifications WHERE user_id = $1 ORDER BY created_at ASC`
+	var notifications []*domain_notification.Notification
+	if err := r.db.SelectContext(ctx, &notifications, query, userID); err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// ForgetUser deletes a user's preferences and skips their pending
+// notifications, returning how many were skipped
+func (r *postgresNotificationRepository) ForgetUser(ctx context.Context, userID uuid.UUID, at time.Time) (int, error) {
+	var skipped int64
+	err := r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM notification_preferences WHERE user_id = $1`, userID); err != nil {
+			return err
+		}
+		result, err := tx.ExecContext(ctx, `UPDATE notifications SET status = 'skipped', updated_at = $2 WHERE user_id = $1 AND status = 'pending'`, userID, at)
+		if err != nil {
+			return err
+		}
+		skipped, err = result.RowsAffected()
+		return err
+	})
+	return int(skipped), err
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgreSQL Outbox Repository
+type postgresOutboxRepository struct {
+	db *tenantDB
+}
+
+// Append records messages; called inside WithinTx it commits with the state change
+func (r *postgresOutboxRepository) Append(ctx context.Context, messages ...*domain_outbox.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO outbox_events (id, aggregate_type, aggregate_id, event_type, payload, attempts, next_attempt_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+		for _, m := range messages {
+			if _, err := tx.ExecContext(ctx, query, m.ID, m.AggregateType, m.AggregateID, m.EventType, []byte(m.Payload), m.Attempts, m.NextAttemptAt, m.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ClaimDue leases unpublished messages that are due, oldest first, so
+// concurrent relays never publish the same batch at the same time
+func (r *postgresOutboxRepository) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_outbox.Message, error) {
+	query := `UPDATE outbox_events SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE published_at IS NULL AND next_attempt_at <= $1
+			ORDER BY created_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, aggregate_type, aggregate_id, event_type, payload, attempts, next_attempt_at, last_error, created_at, published_at`
+	var messages []*domain_outbox.Message
+	err := r.db.SelectContext(ctx, &messages, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func (r *postgresOutboxRepository) MarkPublished(ctx context.Context, ids []uuid.UUID, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := `UPDATE outbox_events SET published_at = $2, last_error = NULL WHERE id = ANY($1)`
+	_, err := r.db.ExecContext(ctx, query, pq.Array(ids), at)
+	return err
+}
+
+func (r *postgresOutboxRepository) MarkFailed(ctx context.Context, m *domain_outbox.Message) error {
+	query := `UPDATE outbox_events SET attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, m.ID, m.Attempts, m.NextAttemptAt, m.LastError)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresOutboxRepository) DeletePublishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM outbox_events WHERE published_at IS NOT NULL AND published_at < $1`
+	result, err := r.db.ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
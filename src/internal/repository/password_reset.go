@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Password Reset Repository
+// A key per token hash holding the user's ID, expiring with the token.
+// Keys are tenant-scoped.
+type redisPasswordResetRepository struct {
+	client *redis.Client
+}
+
+func passwordResetKey(ctx context.Context, tokenHash string) string {
+	return tenantKey(ctx, "passwordreset:"+tokenHash)
+}
+
+// takePasswordReset gets a token's user and deletes the token in one step,
+// so two requests with the same token cannot both get it
+var takePasswordReset = redis.NewScript(`
+local userID = redis.call('GET', KEYS[1])
+if userID then
+	redis.call('DEL', KEYS[1])
+end
+return userID`)
+
+func (r *redisPasswordResetRepository) Create(ctx context.Context, tokenHash string, userID uuid.UUID, ttl time.Duration) error {
+	return r.client.Set(ctx, passwordResetKey(ctx, tokenHash), userID.String(), ttl).Err()
+}
+
+func (r *redisPasswordResetRepository) Take(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	value, err := takePasswordReset.Run(ctx, r.client, []string{passwordResetKey(ctx, tokenHash)}).Text()
+	if errors.Is(err, redis.Nil) {
+		return uuid.Nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return uuid.Parse(value)
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_presale "github.com/ojaswiii/booking-manager/src/internal/domain/presale"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgreSQL Presale Code Repository
+type postgresPresaleCodeRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresPresaleCodeRepository) CreateBatch(ctx context.Context, codes []*domain_presale.PresaleCode) error {
+	if len(codes) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO presale_codes (id, event_id, batch_id, code, created_at) VALUES ($1, $2, $3, $4, $5)`
+		for _, code := range codes {
+			if _, err := tx.ExecContext(ctx, query, code.ID, code.EventID, code.BatchID, code.Code, code.CreatedAt); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *postgresPresaleCodeRepository) GetBatch(ctx context.Context, eventID, batchID uuid.UUID) ([]*domain_presale.PresaleCode, error) {
+	query := `SELECT id, event_id, batch_id, code, redeemed_by, redeemed_at, created_at FROM presale_codes WHERE event_id = $1 AND batch_id = $2 ORDER BY created_at ASC, code ASC`
+	var codes []*domain_presale.PresaleCode
+	err := r.db.SelectContext(ctx, &codes, query, eventID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (r *postgresPresaleCodeRepository) Redeem(ctx context.Context, eventID uuid.UUID, code string, userID uuid.UUID) error {
+	// Redeeming the same code twice as the same user is a no-op
+	query := `UPDATE presale_codes SET redeemed_by = $3, redeemed_at = COALESCE(redeemed_at, NOW()) WHERE event_id = $1 AND code = $2 AND (redeemed_by IS NULL OR redeemed_by = $3)`
+	result, err := r.db.ExecContext(ctx, query, eventID, code, userID)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	// Distinguish an unknown code from one redeemed by someone else
+	var exists bool
+	err = r.db.GetContext(ctx, &exists, `SELECT EXISTS(SELECT 1 FROM presale_codes WHERE event_id = $1 AND code = $2)`, eventID, code)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	if !exists {
+		return domain.ErrNotFound
+	}
+	return domain.ErrConflict
+}
+
+func (r *postgresPresaleCodeRepository) HasEntitlement(ctx context.Context, eventID, userID uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM presale_codes WHERE event_id = $1 AND redeemed_by = $2)`
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, eventID, userID); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
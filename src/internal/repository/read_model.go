@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+
+	"github.com/google/uuid"
+)
+
+const bookingSummaryColumns = `booking_id, user_id, event_id, event_name, event_date, status, booking, created_at, position`
+
+const eventAvailabilityColumns = `event_id, event_name, event_date, available, reserved, sold, held, updated_at`
+
+// PostgreSQL Read Model Repository
+// The rows are only written by the projector, one statement each, so none
+// of them need a transaction.
+type postgresReadModelRepository struct {
+	db *tenantDB
+}
+
+// UpsertBookingSummary stores a booking's summary unless the stored one was
+// projected from a later event
+func (r *postgresReadModelRepository) UpsertBookingSummary(ctx context.Context, summary *domain_readmodel.BookingSummary) error {
+	query := `INSERT INTO user_booking_summaries (` + bookingSummaryColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (booking_id) DO UPDATE SET
+			event_name = EXCLUDED.event_name,
+			event_date = EXCLUDED.event_date,
+			status = EXCLUDED.status,
+			booking = EXCLUDED.booking,
+			position = EXCLUDED.position
+		WHERE user_booking_summaries.position <= EXCLUDED.position`
+	_, err := r.db.ExecContext(ctx, query,
+		summary.BookingID, summary.UserID, summary.EventID, summary.EventName, summary.EventDate,
+		summary.Status, summary.Booking, summary.CreatedAt, summary.Position)
+	return err
+}
+
+func (r *postgresReadModelRepository) DeleteBookingSummary(ctx context.Context, bookingID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM user_booking_summaries WHERE booking_id = $1`, bookingID)
+	return err
+}
+
+func (r *postgresReadModelRepository) GetBookingSummariesByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_readmodel.BookingSummary, error) {
+	query := `SELECT ` + bookingSummaryColumns + ` FROM user_booking_summaries WHERE user_id = $1 ORDER BY created_at DESC`
+	var summaries []*domain_readmodel.BookingSummary
+	if err := r.db.SelectContext(ctx, &summaries, query, userID); err != nil {
+		return nil, err
+	}
+	return summaries, nil
+}
+
+func (r *postgresReadModelRepository) UpsertEventAvailability(ctx context.Context, availability *domain_readmodel.EventAvailability) error {
+	query := `INSERT INTO event_availability (` + eventAvailabilityColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (event_id) DO UPDATE SET
+			event_name = EXCLUDED.event_name,
+			event_date = EXCLUDED.event_date,
+			available = EXCLUDED.available,
+			reserved = EXCLUDED.reserved,
+			sold = EXCLUDED.sold,
+			held = EXCLUDED.held,
+			updated_at = EXCLUDED.updated_at`
+	_, err := r.db.ExecContext(ctx, query,
+		availability.EventID, availability.EventName, availability.EventDate,
+		availability.Available, availability.Reserved, availability.Sold, availability.Held, availability.UpdatedAt)
+	return err
+}
+
+func (r *postgresReadModelRepository) DeleteEventAvailability(ctx context.Context, eventID uuid.UUID) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM event_availability WHERE event_id = $1`, eventID)
+	return err
+}
+
+func (r *postgresReadModelRepository) GetEventAvailability(ctx context.Context, eventID uuid.UUID) (*domain_readmodel.EventAvailability, error) {
+	query := `SELECT ` + eventAvailabilityColumns + ` FROM event_availability WHERE event_id = $1`
+	var availability domain_readmodel.EventAvailability
+	if err := r.db.GetContext(ctx, &availability, query, eventID); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &availability, nil
+}
+
+func (r *postgresReadModelRepository) ListEventAvailability(ctx context.Context, from time.Time) ([]*domain_readmodel.EventAvailability, error) {
+	query := `SELECT ` + eventAvailabilityColumns + ` FROM event_availability WHERE event_date >= $1 ORDER BY event_date ASC`
+	var availability []*domain_readmodel.EventAvailability
+	if err := r.db.SelectContext(ctx, &availability, query, from); err != nil {
+		return nil, err
+	}
+	return availability, nil
+}
+
+// GetCheckpoint returns the position a projector has reached, 0 if it has
+// not started
+func (r *postgresReadModelRepository) GetCheckpoint(ctx context.Context, name string) (int64, error) {
+	var position int64
+	err := r.db.GetContext(ctx, &position, `SELECT position FROM read_model_checkpoints WHERE name = $1`, name)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	return position, err
+}
+
+func (r *postgresReadModelRepository) SetCheckpoint(ctx context.Context, name string, position int64) error {
+	query := `INSERT INTO read_model_checkpoints (name, position, updated_at) VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, name) DO UPDATE SET position = EXCLUDED.position, updated_at = EXCLUDED.updated_at`
+	_, err := r.db.ExecContext(ctx, query, name, position, time.Now().UTC())
+	return err
+}
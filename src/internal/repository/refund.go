@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const refundColumns = `id, booking_id, amount, currency, reason, status, provider_reference, error, requested_by, created_at, updated_at`
+
+// PostgreSQL Refund Repository
+type postgresRefundRepository struct {
+	db *tenantDB
+}
+
+// Create stores a refund together with its items
+func (r *postgresRefundRepository) Create(ctx context.Context, refund *domain_refund.Refund) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO refunds (` + refundColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
+		if _, err := tx.ExecContext(ctx, query, refund.ID, refund.BookingID, refund.Amount, refund.Currency, refund.Reason, refund.Status, refund.ProviderReference, refund.Error, refund.RequestedBy, refund.CreatedAt, refund.UpdatedAt); err != nil {
+			return err
+		}
+
+		query = `INSERT INTO refund_items (refund_id, ticket_id, amount) VALUES ($1, $2, $3)`
+		for _, item := range refund.Items {
+			if _, err := tx.ExecContext(ctx, query, refund.ID, item.TicketID, item.Amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresRefundRepository) Update(ctx context.Context, refund *domain_refund.Refund) error {
+	query := `UPDATE refunds SET status = $2, provider_reference = $3, error = $4, updated_at = $5 WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, refund.ID, refund.Status, refund.ProviderReference, refund.Error, refund.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+// GetByBookingID retrieves the refunds of a booking with their items, oldest first
+func (r *postgresRefundRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_refund.Refund, error) {
+	query := `SELECT ` + refundColumns + ` FROM refunds WHERE booking_id = $1 ORDER BY created_at ASC`
+	var refunds []*domain_refund.Refund
+	if err := r.db.SelectContext(ctx, &refunds, query, bookingID); err != nil {
+		return nil, err
+	}
+	if len(refunds) == 0 {
+		return refunds, nil
+	}
+
+	query = `SELECT i.refund_id, i.ticket_id, i.amount FROM refund_items i JOIN refunds f ON f.id = i.refund_id WHERE f.booking_id = $1`
+	var items []domain_refund.Item
+	if err := r.db.SelectContext(ctx, &items, query, bookingID); err != nil {
+		return nil, err
+	}
+	byRefund := make(map[uuid.UUID]*domain_refund.Refund, len(refunds))
+	for _, refund := range refunds {
+		refund.Items = []domain_refund.Item{}
+		byRefund[refund.ID] = refund
+	}
+	for _, item := range items {
+		if refund, ok := byRefund[item.RefundID]; ok {
+			refund.Items = append(refund.Items, item)
+		}
+	}
+	return refunds, nil
+}
+
+func (r *postgresRefundRepository) GetPolicy(ctx context.Context, eventID uuid.UUID) (*domain_refund.Policy, error) {
+	query := `SELECT event_id, refundable, deadline_hours, percent, allow_partial, updated_at FROM refund_policies WHERE event_id = $1`
+	var policy domain_refund.Policy
+	err := r.db.GetContext(ctx, &policy, query, eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func (r *postgresRefundRepository) SavePolicy(ctx context.Context, policy *domain_refund.Policy) error {
+	query := `INSERT INTO refund_policies (event_id, refundable, deadline_hours, percent, allow_partial, updated_at) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (event_id) DO UPDATE SET refundable = EXCLUDED.refundable, deadline_hours = EXCLUDED.deadline_hours, percent = EXCLUDED.percent, allow_partial = EXCLUDED.allow_partial, updated_at = EXCLUDED.updated_at`
+	_, err := r.db.ExecContext(ctx, query, policy.EventID, policy.Refundable, policy.DeadlineHours, policy.Percent, policy.AllowPartial, policy.UpdatedAt)
+	return err
+}
+
+// CreateReturn stores a ticket return, one row per ticket
+func (r *postgresRefundRepository) CreateReturn(ctx context.Context, ret *domain_refund.Return) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ticket_returns (id, booking_id, event_id, ticket_id, reason, created_at) VALUES ($1, $2, $3, $4, $5, $6)`
+		for _, ticketID := range ret.TicketIDs {
+			if _, err := tx.ExecContext(ctx, query, ret.ID, ret.BookingID, ret.EventID, ticketID, ret.Reason, ret.CreatedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetReturnsByBookingID retrieves the ticket returns of a booking, oldest first
+func (r *postgresRefundRepository) GetReturnsByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_refund.Return, error) {
+	query := `SELECT id, booking_id, event_id, ticket_id, reason, created_at FROM ticket_returns WHERE booking_id = $1 ORDER BY created_at ASC, id`
+	var rows []struct {
+		domain_refund.Return
+		TicketID uuid.UUID `db:"ticket_id"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, bookingID); err != nil {
+		return nil, err
+	}
+
+	returns := []*domain_refund.Return{}
+	byID := make(map[uuid.UUID]*domain_refund.Return)
+	for i := range rows {
+		ret, ok := byID[rows[i].ID]
+		if !ok {
+			ret = &rows[i].Return
+			ret.TicketIDs = []uuid.UUID{}
+			byID[ret.ID] = ret
+			returns = append(returns, ret)
+		}
+		ret.TicketIDs = append(ret.TicketIDs, rows[i].TicketID)
+	}
+	return returns, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+
+	"github.com/google/uuid"
+)
+
+// PostgreSQL Sales Report Repository
+// Sums sales per bucket in SQL. Each query is scoped to the events of the
+// report by a condition over e, whose parameters come first; the interval is
+// the parameter after them.
+type postgresSalesReportRepository struct {
+	db *tenantDB
+}
+
+const (
+	// reportEventsQuery counts the events in scope and their seats
+	reportEventsQuery = `
+	SELECT COUNT(*) AS events, COALESCE(SUM(e.total_seats), 0) AS total_seats
+	FROM events e WHERE `
+
+	// reportSalesQuery sums the bookings sold in each bucket, counted as
+	// EventStats counts them
+	reportSalesQuery = `
+	SELECT date_trunc(%s, b.created_at) AS bucket,
+		COUNT(*) AS bookings,
+		CAST(COALESCE(SUM((SELECT COUNT(*) FROM booking_items bi WHERE bi.booking_id = b.id)), 0) AS BIGINT) AS tickets_sold,
+		CAST(COALESCE(SUM(ROUND(b.total_amount / COALESCE(b.exchange_rate, 1) * 100)), 0) AS BIGINT) AS revenue_cents
+	FROM bookings b JOIN events e ON e.id = b.event_id
+	WHERE b.status IN ('confirmed', 'refunded', 'returned') AND %s
+	GROUP BY bucket`
+
+	// reportRefundsQuery sums the refunds paid out in each bucket, converted
+	// at their booking's exchange rate
+	reportRefundsQuery = `
+	SELECT date_trunc(%s, r.created_at) AS bucket,
+		CAST(COALESCE(SUM(ROUND(r.amount / COALESCE(b.exchange_rate, 1) * 100)), 0) AS BIGINT) AS refunded_cents
+	FROM refunds r JOIN bookings b ON b.id = r.booking_id JOIN events e ON e.id = b.event_id
+	WHERE r.status = 'succeeded' AND %s
+	GROUP BY bucket`
+)
+
+func (r *postgresSalesReportRepository) GetForEvent(ctx context.Context, eventID uuid.UUID, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error) {
+	report := &domain_stats.SalesReport{EventID: &eventID, Interval: interval}
+	if err := r.fill(ctx, report, `e.id = $1 AND e.deleted_at IS NULL`, eventID); err != nil {
+		return nil, err
+	}
+	if report.Events == 0 {
+		return nil, domain.ErrNotFound
+	}
+	return report, nil
+}
+
+func (r *postgresSalesReportRepository) GetForEvents(ctx context.Context, from, to *time.Time, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error) {
+	report := &domain_stats.SalesReport{From: from, To: to, Interval: interval}
+	scope := `($1::timestamptz IS NULL OR e.date >= $1) AND ($2::timestamptz IS NULL OR e.date < $2) AND e.deleted_at IS NULL`
+	if err := r.fill(ctx, report, scope, from, to); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// fill counts the report's events and sums its buckets
+func (r *postgresSalesReportRepository) fill(ctx context.Context, report *domain_stats.SalesReport, scope string, args ...interface{}) error {
+	var seats []struct {
+		Events     int64 `db:"events"`
+		TotalSeats int64 `db:"total_seats"`
+	}
+	if err := r.db.readSelect(ctx, &seats, reportEventsQuery+scope, args...); err != nil {
+		return err
+	}
+	if len(seats) > 0 {
+		report.Events, report.TotalSeats = seats[0].Events, seats[0].TotalSeats
+	}
+
+	intervalParam := fmt.Sprintf("$%d::text", len(args)+1)
+	args = append(args, string(report.Interval))
+	var sales []domain_stats.SalesBucket
+	if err := r.db.readSelect(ctx, &sales, fmt.Sprintf(reportSalesQuery, intervalParam, scope), args...); err != nil {
+		return err
+	}
+	var refunds []domain_stats.SalesBucket
+	if err := r.db.readSelect(ctx, &refunds, fmt.Sprintf(reportRefundsQuery, intervalParam, scope), args...); err != nil {
+		return err
+	}
+
+	report.Buckets = []domain_stats.SalesBucket{}
+	for _, bucket := range append(sales, refunds...) {
+		report.AddBucket(bucket)
+	}
+	return nil
+}
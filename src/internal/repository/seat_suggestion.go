@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Seat Suggestion Repository
+// Each session's shown seats are a set per event, expiring with the session.
+type redisSeatSuggestionRepository struct {
+	client *redis.Client
+}
+
+func seatSuggestionKey(ctx context.Context, eventID uuid.UUID, session string) string {
+	return tenantKey(ctx, fmt.Sprintf("seatsuggestions:%s:%s", eventID.String(), session))
+}
+
+func (r *redisSeatSuggestionRepository) GetShown(ctx context.Context, eventID uuid.UUID, session string) (map[int]bool, error) {
+	members, err := r.client.SMembers(ctx, seatSuggestionKey(ctx, eventID, session)).Result()
+	if errors.Is(err, database.ErrRedisUnavailable) {
+		// Suggestions may repeat until Redis returns
+		return map[int]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	shown := make(map[int]bool, len(members))
+	for _, member := range members {
+		seat, err := strconv.Atoi(member)
+		if err != nil {
+			continue
+		}
+		shown[seat] = true
+	}
+	return shown, nil
+}
+
+func (r *redisSeatSuggestionRepository) AddShown(ctx context.Context, eventID uuid.UUID, session string, seats []int, ttl time.Duration) error {
+	if len(seats) == 0 {
+		return nil
+	}
+	key := seatSuggestionKey(ctx, eventID, session)
+	members := make([]interface{}, len(seats))
+	for i, seat := range seats {
+		members[i] = seat
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.SAdd(ctx, key, members...)
+	pipe.Expire(ctx, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return skipUnavailable(err)
+}
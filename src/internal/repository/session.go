@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Session Repository
+// Each session is a hash of its JSON and the hash of its current refresh
+// token, expiring when the session does, with a set per user of their
+// session IDs. The set outlives some of its sessions; IDs whose session has
+// expired are dropped when the set is read.
+type redisSessionRepository struct {
+	client *redis.Client
+}
+
+func sessionKey(ctx context.Context, id uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("session:%s", id.String()))
+}
+
+func userSessionsKey(ctx context.Context, userID uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("sessions:user:%s", userID.String()))
+}
+
+// rotateSession stores the session with its new token only if the token it
+// has is still the one the caller saw, so a refresh token is used once
+var rotateSession = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'token') ~= ARGV[1] then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'token', ARGV[2], 'data', ARGV[3])
+redis.call('PEXPIRE', KEYS[1], ARGV[4])
+redis.call('PEXPIRE', KEYS[2], ARGV[4])
+return 1`)
+
+func (r *redisSessionRepository) Create(ctx context.Context, session *domain_session.Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	ttl := time.Until(session.ExpiresAt)
+	key, userKey := sessionKey(ctx, session.ID), userSessionsKey(ctx, session.UserID)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key, "token", session.TokenHash, "data", data)
+	pipe.PExpire(ctx, key, ttl)
+	pipe.SAdd(ctx, userKey, session.ID.String())
+	pipe.PExpire(ctx, userKey, ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (r *redisSessionRepository) Get(ctx context.Context, id uuid.UUID) (*domain_session.Session, error) {
+	fields, err := r.client.HMGet(ctx, sessionKey(ctx, id), "token", "data").Result()
+	if err != nil {
+		return nil, err
+	}
+	token, _ := fields[0].(string)
+	data, _ := fields[1].(string)
+	if token == "" || data == "" {
+		return nil, domain.ErrNotFound
+	}
+	var session domain_session.Session
+	if err := json.Unmarshal([]byte(data), &session); err != nil {
+		return nil, err
+	}
+	session.TokenHash = token
+	return &session, nil
+}
+
+// Rotate stores the session with its new token hash if its stored token is
+// still previousHash. It reports whether it was stored.
+func (r *redisSessionRepository) Rotate(ctx context.Context, session *domain_session.Session, previousHash string) (bool, error) {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return false, err
+	}
+	keys := []string{sessionKey(ctx, session.ID), userSessionsKey(ctx, session.UserID)}
+	rotated, err := rotateSession.Run(ctx, r.client, keys, previousHash, session.TokenHash, data, time.Until(session.ExpiresAt).Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return rotated == 1, nil
+}
+
+// ListByUserID returns a user's sessions that have not ended, most recently
+// used first
+func (r *redisSessionRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain_session.Session, error) {
+	userKey := userSessionsKey(ctx, userID)
+	members, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	var sessions []*domain_session.Session
+	var ended []interface{}
+	for _, member := range members {
+		id, err := uuid.Parse(member)
+		if err != nil {
+			ended = append(ended, member)
+			continue
+		}
+		session, err := r.Get(ctx, id)
+		if err == domain.ErrNotFound {
+			ended = append(ended, member)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	if len(ended) > 0 {
+		if err := r.client.SRem(ctx, userKey, ended...).Err(); err != nil {
+			return nil, err
+		}
+	}
+	sortSessions(sessions)
+	return sessions, nil
+}
+
+func (r *redisSessionRepository) Delete(ctx context.Context, session *domain_session.Session) error {
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, sessionKey(ctx, session.ID))
+	pipe.SRem(ctx, userSessionsKey(ctx, session.UserID), session.ID.String())
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// DeleteByUserID ends every session of a user, returning how many there were
+func (r *redisSessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	userKey := userSessionsKey(ctx, userID)
+	members, err := r.client.SMembers(ctx, userKey).Result()
+	if err != nil {
+		return 0, err
+	}
+	keys := []string{userKey}
+	for _, member := range members {
+		if id, err := uuid.Parse(member); err == nil {
+			keys = append(keys, sessionKey(ctx, id))
+		}
+	}
+	// Only sessions that had not expired are counted
+	deleted, err := r.client.Del(ctx, keys...).Result()
+	if err != nil {
+		return 0, err
+	}
+	if len(members) > 0 {
+		deleted-- // the set itself
+	}
+	return int(deleted), nil
+}
+
+// sortSessions orders sessions most recently used first
+func sortSessions(sessions []*domain_session.Session) {
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+}
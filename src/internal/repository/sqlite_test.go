@@ -0,0 +1,405 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/migrations"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+
+	"github.com/google/uuid"
+)
+
+// newSQLiteRepositories migrates a fresh SQLite database and creates the
+// Postgres repositories over it
+func newSQLiteRepositories(t *testing.T) *RepositoryContainer {
+	t.Helper()
+	logger := utils.NewLogger()
+	config := &utils.Config{DBDriver: "sqlite", DBSQLitePath: filepath.Join(t.TempDir(), "test.db"), DBMaxOpenConns: 4}
+
+	client, err := database.NewSQLiteClient(config, logger)
+	if err != nil {
+		t.Fatalf("open SQLite: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	migrator, err := database.NewMigrator(client.DB, migrations.ForDriver("sqlite"), logger)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewRepositoryContainer(client.DB, nil, TenantIsolationNone, nil, TxRetryPolicy{MaxAttempts: 1}, nil, client.Queries, CacheTTLs{})
+}
+
+func TestSQLiteBooksTickets(t *testing.T) {
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	usr := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", CreatedAt: now, UpdatedAt: now}
+	if err := repos.User.Create(ctx, usr); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	evt := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Artist: "The Band", Venue: "Arena", Date: now.Add(24 * time.Hour), TotalSeats: 3, Price: 50, Status: domain_event.EventStatusPublished, CreatedAt: now, UpdatedAt: now}
+	if err := repos.Event.Create(ctx, evt); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	ids := make([]uuid.UUID, 3)
+	for i := range ids {
+		ids[i] = uuid.New()
+		tkt := &domain_ticket.Ticket{ID: ids[i], EventID: evt.ID, SeatNumber: i + 1, Status: domain_ticket.TicketStatusAvailable, Price: 50, CreatedAt: now, UpdatedAt: now}
+		if err := repos.Ticket.Create(ctx, tkt); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+	}
+
+	// Arrays are bound as JSON
+	if err := repos.Ticket.ReserveTickets(ctx, ids[:2]); err != nil {
+		t.Fatalf("reserve: %v", err)
+	}
+	if err := repos.Ticket.ReserveTickets(ctx, ids[1:]); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("reserve taken seat: got %v, want ErrConflict", err)
+	}
+	tickets, err := repos.Ticket.GetByIDs(ctx, ids)
+	if err != nil || len(tickets) != 3 {
+		t.Fatalf("get tickets: got %d, %v", len(tickets), err)
+	}
+	availability, err := repos.Ticket.CountAvailable(ctx, evt.ID)
+	if err != nil || availability.Available != 1 || availability.Tiers[5000] != 1 {
+		t.Fatalf("count available: got %+v, %v; want 1 at 5000 cents", availability, err)
+	}
+	counts, err := repos.Ticket.CountByStatus(ctx, evt.ID, 2)
+	if err != nil || len(counts) != 2 {
+		t.Fatalf("count by status: got %d groups, %v; want 2", len(counts), err)
+	}
+	for _, count := range counts {
+		if wantSection := map[domain_ticket.TicketStatus]int{domain_ticket.TicketStatusReserved: 1, domain_ticket.TicketStatusAvailable: 2}[count.Status]; count.Section != wantSection || count.Price != 50 {
+			t.Fatalf("count by status: got %+v", count)
+		}
+	}
+
+	bk := &domain_booking.Booking{ID: uuid.New(), UserID: usr.ID, EventID: evt.ID, TicketIDs: ids[:2], Status: domain_booking.BookingStatusPending, TotalAmount: 100, Currency: "USD", CreatedAt: now, UpdatedAt: now, ExpiresAt: now.Add(10 * time.Minute)}
+	for i, id := range bk.TicketIDs {
+		bk.Items = append(bk.Items, domain_booking.LineItem{BookingID: bk.ID, TicketID: id, SeatNumber: i + 1, BasePrice: 50, UnitPrice: 50, Currency: "USD", CreatedAt: now})
+	}
+	if err := repos.Booking.Create(ctx, bk); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+	if err := repos.Ticket.ConfirmTickets(ctx, ids[:2]); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	bk.Status = domain_booking.BookingStatusConfirmed
+	if err := repos.Booking.Update(ctx, bk); err != nil {
+		t.Fatalf("update booking: %v", err)
+	}
+
+	got, err := repos.Booking.GetByID(ctx, bk.ID)
+	if err != nil {
+		t.Fatalf("get booking: %v", err)
+	}
+	if len(got.TicketIDs) != 2 || got.TicketIDs[0] != ids[0] || !got.ExpiresAt.Equal(bk.ExpiresAt) {
+		t.Fatalf("booking read back as %+v, want tickets %v expiring %v", got, ids[:2], bk.ExpiresAt)
+	}
+	expiring, err := repos.Booking.GetExpiringBetween(ctx, now, now.Add(time.Hour))
+	if err != nil || len(expiring) != 0 {
+		t.Fatalf("confirmed booking listed as expiring: %d, %v", len(expiring), err)
+	}
+
+	stats, err := repos.EventStats.GetByEventID(ctx, evt.ID)
+	if err != nil {
+		t.Fatalf("event stats: %v", err)
+	}
+	if stats.Confirmed != 1 || stats.RevenueCents != 10000 {
+		t.Fatalf("event stats: got %+v, want 1 confirmed and 10000 cents", stats)
+	}
+	report, err := repos.SalesReport.GetForEvent(ctx, evt.ID, domain_stats.IntervalDay)
+	if err != nil {
+		t.Fatalf("sales report: %v", err)
+	}
+	if report.TotalSeats != 3 || report.TicketsSold != 2 || report.RevenueCents != 10000 || len(report.Buckets) != 1 ||
+		!report.Buckets[0].Start.Equal(domain_stats.IntervalDay.Truncate(now)) {
+		t.Fatalf("sales report: got %+v, want 2 of 3 seats sold for 10000 cents today", report)
+	}
+	// Pages start after the last row of the one before
+	attendees, err := repos.BookingExport.ListAttendees(ctx, domain_booking.AttendeeFilter{EventID: &evt.ID}, nil, 1)
+	if err != nil || len(attendees) != 1 || attendees[0].SeatNumber != 1 || attendees[0].UserEmail != usr.Email || attendees[0].EventName != evt.Name {
+		t.Fatalf("first page of attendees: got %+v, %v", attendees, err)
+	}
+	attendees, err = repos.BookingExport.ListAttendees(ctx, domain_booking.AttendeeFilter{UserID: &usr.ID}, attendees[0], 10)
+	if err != nil || len(attendees) != 1 || attendees[0].SeatNumber != 2 {
+		t.Fatalf("attendees after seat 1: got %+v, %v; want seat 2", attendees, err)
+	}
+	if reader, err := repos.User.GetByID(ctx, usr.ID); err != nil || reader.Role != domain_user.RoleCustomer {
+		t.Fatalf("new users are customers: got %+v, %v", reader, err)
+	}
+
+	found, err := repos.Event.Search(ctx, domain_event.EventFilter{Query: "summer"})
+	if err != nil || len(found) != 1 {
+		t.Fatalf("search is case-insensitive: got %d, %v", len(found), err)
+	}
+
+	// Deleted events are hidden but keep their bookings until purged
+	if err := repos.Event.Delete(ctx, evt.ID); err != nil {
+		t.Fatalf("delete event: %v", err)
+	}
+	if _, err := repos.Event.GetByID(ctx, evt.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("deleted event: got %v, want ErrNotFound", err)
+	}
+	if found, err := repos.Event.Search(ctx, domain_event.EventFilter{}); err != nil || len(found) != 0 {
+		t.Fatalf("deleted event is searchable: got %d, %v", len(found), err)
+	}
+	if _, err := repos.Booking.GetByID(ctx, bk.ID); err != nil {
+		t.Fatalf("booking of a deleted event: %v", err)
+	}
+	if err := repos.Event.Restore(ctx, evt.ID); err != nil {
+		t.Fatalf("restore event: %v", err)
+	}
+	if err := repos.Event.Purge(ctx, evt.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("purge live event: got %v, want ErrNotFound", err)
+	}
+	if err := repos.Event.Delete(ctx, evt.ID); err != nil {
+		t.Fatalf("delete event again: %v", err)
+	}
+	if err := repos.Event.Purge(ctx, evt.ID); !errors.Is(err, domain_booking.ErrActiveBookings) {
+		t.Fatalf("purge event with a pending booking: got %v, want ErrActiveBookings", err)
+	}
+	bk.Status = domain_booking.BookingStatusCancelled
+	if err := repos.Booking.Update(ctx, bk); err != nil {
+		t.Fatalf("cancel booking: %v", err)
+	}
+	if err := repos.Event.Purge(ctx, evt.ID); err != nil {
+		t.Fatalf("purge event: %v", err)
+	}
+	if _, err := repos.Booking.GetByID(ctx, bk.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("booking of a purged event: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestSQLiteClaimsDueJobs(t *testing.T) {
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	job := &domain_job.Job{ID: uuid.New(), Kind: "export", State: domain_job.StateQueued, Params: json.RawMessage(`{}`), CreatedAt: now, UpdatedAt: now}
+	if err := repos.Job.Create(ctx, job); err != nil {
+		t.Fatalf("create job: %v", err)
+	}
+
+	claimed, err := repos.Job.ClaimDue(ctx, now, time.Minute, 10)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("claim: got %d, %v", len(claimed), err)
+	}
+	if claimed[0].StartedAt == nil || !claimed[0].StartedAt.Equal(now) {
+		t.Fatalf("claimed job started at %v, want %v", claimed[0].StartedAt, now)
+	}
+
+	// Leased until a minute from now
+	claimed, err = repos.Job.ClaimDue(ctx, now.Add(time.Second), time.Minute, 10)
+	if err != nil || len(claimed) != 0 {
+		t.Fatalf("claim leased job: got %d, %v", len(claimed), err)
+	}
+}
+
+func TestSQLiteKeepsBookingHistory(t *testing.T) {
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	bk := &domain_booking.Booking{ID: uuid.New(), TicketIDs: []uuid.UUID{uuid.New(), uuid.New()}, Status: domain_booking.BookingStatusPending, UpdatedAt: now}
+	if err := repos.BookingTransition.Append(ctx, bk.Transitions("", domain_booking.ActorCustomer, "booked")...); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	bk.Status = domain_booking.BookingStatusExpired
+	bk.UpdatedAt = now.Add(15 * time.Minute)
+	if err := repos.BookingTransition.Append(ctx, bk.Transitions(domain_booking.BookingStatusPending, domain_booking.ActorSystem, "not paid for in time")...); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+
+	history, err := repos.BookingTransition.GetByBookingID(ctx, bk.ID)
+	if err != nil || len(history) != 6 {
+		t.Fatalf("history: got %d, %v; want 6", len(history), err)
+	}
+	// The booking's own transition comes before its tickets'
+	for i, want := range []string{"pending", "reserved", "reserved", "expired", "available", "available"} {
+		if history[i].ToStatus != want || (history[i].TicketID == nil) != (i%3 == 0) {
+			t.Fatalf("transition %d: got %+v, want to %s", i, history[i], want)
+		}
+	}
+	if history[3].FromStatus != "pending" || history[3].Actor != domain_booking.ActorSystem || !history[3].CreatedAt.Equal(bk.UpdatedAt) {
+		t.Fatalf("expiry: got %+v", history[3])
+	}
+}
+
+func TestSQLiteStoresBookingEvents(t *testing.T) {
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	first := &domain_booking.Booking{ID: uuid.New(), TicketIDs: []uuid.UUID{uuid.New()}, Currency: "USD", CreatedAt: now, ExpiresAt: now.Add(15 * time.Minute)}
+	second := &domain_booking.Booking{ID: uuid.New(), TicketIDs: []uuid.UUID{uuid.New()}, Currency: "USD", CreatedAt: now, ExpiresAt: now.Add(15 * time.Minute)}
+	for _, bk := range []*domain_booking.Booking{first, second} {
+		if err := bk.RecordCreated(domain_booking.ActorCustomer); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+		if err := repos.BookingEvent.Append(ctx, bk.Changes...); err != nil {
+			t.Fatalf("append: %v", err)
+		}
+	}
+	if err := first.Record(domain_booking.BookingConfirmed, domain_booking.ActorPayments, now.Add(time.Minute), nil); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	confirmed := first.Changes[len(first.Changes)-1]
+	if err := repos.BookingEvent.Append(ctx, confirmed); err != nil {
+		t.Fatalf("append: %v", err)
+	}
+	if confirmed.Version != 3 || confirmed.Position != 5 {
+		t.Fatalf("confirmation stored as version %d at %d, want 3 at 5", confirmed.Version, confirmed.Position)
+	}
+
+	stream, err := repos.BookingEvent.GetByBookingID(ctx, first.ID)
+	if err != nil || len(stream) != 3 {
+		t.Fatalf("stream: got %d, %v; want 3", len(stream), err)
+	}
+	replayed, err := domain_booking.Replay(stream)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if replayed.Status != domain_booking.BookingStatusConfirmed || len(replayed.TicketIDs) != 1 || replayed.TicketIDs[0] != first.TicketIDs[0] || !replayed.UpdatedAt.Equal(now.Add(time.Minute)) {
+		t.Fatalf("replayed: got %+v", replayed)
+	}
+
+	after, err := repos.BookingEvent.ListAfter(ctx, 2, 2)
+	if err != nil || len(after) != 2 || after[0].Position != 3 || after[0].BookingID != second.ID || after[1].BookingID != second.ID {
+		t.Fatalf("after 2: got %+v, %v", after, err)
+	}
+
+	// Events are never changed
+	if _, err := repos.Transactor.(*tenantDB).ExecContext(ctx, `DELETE FROM booking_events`); err == nil {
+		t.Fatal("deleted booking events")
+	}
+}
+
+func TestSQLiteStoresReadModels(t *testing.T) {
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	userID, ticketID := uuid.New(), uuid.New()
+	bk := &domain_booking.Booking{
+		ID:        uuid.New(),
+		UserID:    userID,
+		EventID:   uuid.New(),
+		TicketIDs: []uuid.UUID{ticketID},
+		Status:    domain_booking.BookingStatusConfirmed,
+		Currency:  "USD",
+		CreatedAt: now,
+		Items:     []domain_booking.LineItem{{TicketID: ticketID, SeatNumber: 4, UnitPrice: 50, Currency: "USD"}},
+	}
+	summary := domain_readmodel.NewBookingSummary(bk, "Summer Jam", now.Add(24*time.Hour), 7)
+	if err := repos.ReadModel.UpsertBookingSummary(ctx, summary); err != nil {
+		t.Fatalf("upsert summary: %v", err)
+	}
+	// A summary from an earlier event never replaces a later one
+	stale := domain_readmodel.NewBookingSummary(&domain_booking.Booking{ID: bk.ID, UserID: userID, Status: domain_booking.BookingStatusPending, CreatedAt: now}, "Summer Jam", now, 3)
+	if err := repos.ReadModel.UpsertBookingSummary(ctx, stale); err != nil {
+		t.Fatalf("upsert stale summary: %v", err)
+	}
+
+	summaries, err := repos.ReadModel.GetBookingSummariesByUserID(ctx, userID)
+	if err != nil || len(summaries) != 1 {
+		t.Fatalf("summaries: got %d, %v; want 1", len(summaries), err)
+	}
+	got := summaries[0].ToBooking()
+	if summaries[0].Position != 7 || summaries[0].EventName != "Summer Jam" || got.Status != domain_booking.BookingStatusConfirmed || len(got.Items) != 1 || got.Items[0].SeatNumber != 4 || got.TicketIDs[0] != ticketID {
+		t.Fatalf("summary: got %+v", summaries[0])
+	}
+
+	availability := &domain_readmodel.EventAvailability{EventID: bk.EventID, EventName: "Summer Jam", EventDate: now.Add(24 * time.Hour), Available: 8, Sold: 2, UpdatedAt: now}
+	if err := repos.ReadModel.UpsertEventAvailability(ctx, availability); err != nil {
+		t.Fatalf("upsert availability: %v", err)
+	}
+	rows, err := repos.ReadModel.ListEventAvailability(ctx, now)
+	if err != nil || len(rows) != 1 || rows[0].Available != 8 || rows[0].Total() != 10 {
+		t.Fatalf("availability: got %+v, %v", rows, err)
+	}
+
+	if position, err := repos.ReadModel.GetCheckpoint(ctx, domain_readmodel.BookingProjection); err != nil || position != 0 {
+		t.Fatalf("new checkpoint: got %d, %v; want 0", position, err)
+	}
+	for _, position := range []int64{7, 9} {
+		if err := repos.ReadModel.SetCheckpoint(ctx, domain_readmodel.BookingProjection, position); err != nil {
+			t.Fatalf("set checkpoint: %v", err)
+		}
+	}
+	if position, err := repos.ReadModel.GetCheckpoint(ctx, domain_readmodel.BookingProjection); err != nil || position != 9 {
+		t.Fatalf("checkpoint: got %d, %v; want 9", position, err)
+	}
+}
+
+func TestSQLiteRejectsTakenEmails(t *testing.T) {
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	first := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", CreatedAt: now, UpdatedAt: now}
+	if err := repos.User.Create(ctx, first); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	second := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Other Fan", CreatedAt: now, UpdatedAt: now}
+	if err := repos.User.Create(ctx, second); !errors.Is(err, domain_user.ErrEmailTaken) || !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("same email: got %v, want ErrEmailTaken", err)
+	}
+
+	second.Email = "other@example.com"
+	if err := repos.User.Create(ctx, second); err != nil {
+		t.Fatalf("create second user: %v", err)
+	}
+	second.Email = first.Email
+	if err := repos.User.Update(ctx, second); !errors.Is(err, domain_user.ErrEmailTaken) {
+		t.Fatalf("update to a taken email: got %v, want ErrEmailTaken", err)
+	}
+}
+
+func TestSQLiteSearchesUsers(t *testing.T) {
+	repos := newSQLiteRepositories(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	older := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", CreatedAt: now.Add(-time.Hour), UpdatedAt: now}
+	newer := &domain_user.User{ID: uuid.New(), Email: "Fanatic@example.com", Name: "Fanatic", CreatedAt: now, UpdatedAt: now}
+	other := &domain_user.User{ID: uuid.New(), Email: "other@example.com", Name: "Other", CreatedAt: now, UpdatedAt: now}
+	for _, usr := range []*domain_user.User{older, newer, other} {
+		if err := repos.User.Create(ctx, usr); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	older.LockedAt = &now
+	if err := repos.User.Update(ctx, older); err != nil {
+		t.Fatalf("lock user: %v", err)
+	}
+
+	found, err := repos.User.Search(ctx, domain_user.UserFilter{EmailPrefix: "FAN"})
+	if err != nil || len(found) != 2 || found[0].ID != newer.ID || !found[1].Locked() {
+		t.Fatalf("search by prefix: got %+v, %v", found, err)
+	}
+	from := now.Add(-time.Minute)
+	found, err = repos.User.Search(ctx, domain_user.UserFilter{EmailPrefix: "fan", CreatedFrom: &from})
+	if err != nil || len(found) != 1 || found[0].ID != newer.ID {
+		t.Fatalf("search by signup date: got %+v, %v", found, err)
+	}
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+
+	"github.com/google/uuid"
+)
+
+const templateColumns = `id, name, source_event_id, event_name, artist, venue, total_seats, price, tiers, sales_start_offset_seconds, sales_end_offset_seconds, presale_start_offset_seconds, confirmation_requirements, waiting_room_enabled, created_at, updated_at`
+
+// PostgreSQL Event Template Repository
+type postgresTemplateRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresTemplateRepository) Create(ctx context.Context, t *domain_template.EventTemplate) error {
+	query := `INSERT INTO event_templates (` + templateColumns + `) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
+	_, err := r.db.ExecContext(ctx, query, t.ID, t.Name, t.SourceEventID, t.EventName, t.Artist, t.Venue, t.TotalSeats, t.Price, t.Tiers, t.SalesStartOffset, t.SalesEndOffset, t.PresaleStartOffset, t.ConfirmationRequirements, t.WaitingRoomEnabled, t.CreatedAt, t.UpdatedAt)
+	return err
+}
+
+func (r *postgresTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain_template.EventTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM event_templates WHERE id = $1`
+	var t domain_template.EventTemplate
+	err := r.db.GetContext(ctx, &t, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *postgresTemplateRepository) GetAll(ctx context.Context) ([]*domain_template.EventTemplate, error) {
+	query := `SELECT ` + templateColumns + ` FROM event_templates ORDER BY name ASC, created_at ASC`
+	var templates []*domain_template.EventTemplate
+	err := r.db.SelectContext(ctx, &templates, query)
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *postgresTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM event_templates WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
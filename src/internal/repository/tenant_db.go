@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// TenantIsolation selects how tenants are separated in Postgres
+type TenantIsolation string
+
+const (
+	// TenantIsolationNone runs every query against the default schema
+	TenantIsolationNone TenantIsolation = "none"
+	// TenantIsolationSchema runs each tenant's queries in its own schema
+	TenantIsolationSchema TenantIsolation = "schema"
+	// TenantIsolationRLS tags the session so row-level security policies apply
+	TenantIsolationRLS TenantIsolation = "rls"
+)
+
+// ParseTenantIsolation validates a configured isolation mode
+func ParseTenantIsolation(s string) (TenantIsolation, error) {
+	switch mode := TenantIsolation(s); mode {
+	case "", TenantIsolationNone:
+		return TenantIsolationNone, nil
+	case TenantIsolationSchema, TenantIsolationRLS:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown tenant isolation mode %q", s)
+}
+
+// tenantDB scopes queries to the tenant found in the context. Scoping uses
+// transaction-local settings so pooled connections never carry a tenant over.
+type tenantDB struct {
+	*sqlx.DB
+	isolation TenantIsolation
+	retry     TxRetryPolicy
+	replica   ReadReplica // nil when reads all go to the primary
+	reader    *tenantDB   // the replica's handle, scoped the same way
+}
+
+// ReadReplica is a replica of the primary that reads which tolerate
+// replication lag are sent to while it is healthy
+type ReadReplica interface {
+	Conn() *sqlx.DB
+	Healthy() bool
+}
+
+// withReplica routes lag-tolerant reads to replica
+func (db *tenantDB) withReplica(replica ReadReplica) *tenantDB {
+	if replica != nil {
+		db.replica = replica
+		db.reader = &tenantDB{DB: replica.Conn(), isolation: db.isolation, retry: db.retry}
+	}
+	return db
+}
+
+// readSelect runs a read that tolerates replication lag on the replica
+// while it is healthy, and on the primary otherwise or if the replica fails.
+// Reads inside a transaction stay in it.
+func (db *tenantDB) readSelect(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if db.replica != nil && db.replica.Healthy() && ambientTx(ctx) == nil {
+		err := db.reader.SelectContext(ctx, dest, query, args...)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		// Drop any rows scanned before the replica failed
+		reflect.ValueOf(dest).Elem().Set(reflect.Zero(reflect.TypeOf(dest).Elem()))
+	}
+	return db.SelectContext(ctx, dest, query, args...)
+}
+
+// scoped reports whether queries for ctx need a tenant-scoped transaction
+func (db *tenantDB) scoped(ctx context.Context) bool {
+	return db.isolation != TenantIsolationNone && tenant.FromContext(ctx) != ""
+}
+
+// applyScope points the transaction at the context's tenant
+func (db *tenantDB) applyScope(ctx context.Context, tx *sqlx.Tx) error {
+	id := tenant.FromContext(ctx)
+	if err := tenant.Validate(id); err != nil {
+		return err
+	}
+
+	var err error
+	switch db.isolation {
+	case TenantIsolationSchema:
+		searchPath := pq.QuoteIdentifier(tenant.SchemaName(id)) + ", public"
+		_, err = tx.ExecContext(ctx, `SELECT set_config('search_path', $1, true)`, searchPath)
+	case TenantIsolationRLS:
+		_, err = tx.ExecContext(ctx, `SELECT set_config('app.tenant_id', $1, true)`, id)
+	}
+	return err
+}
+
+// BeginTxx starts a transaction already scoped to the context's tenant
+func (db *tenantDB) BeginTxx(ctx context.Context, opts *sql.TxOptions) (*sqlx.Tx, error) {
+	tx, err := db.DB.BeginTxx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if db.scoped(ctx) {
+		if err := db.applyScope(ctx, tx); err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+	return tx, nil
+}
+
+// txKey carries the transaction opened by WithinTx
+type txKey struct{}
+
+// ambientTx returns the transaction opened by WithinTx, if any
+func ambientTx(ctx context.Context) *sqlx.Tx {
+	tx, _ := ctx.Value(txKey{}).(*sqlx.Tx)
+	return tx
+}
+
+// TxFromContext returns the transaction opened by WithinTx, if any, so
+// repositories outside this package can take part in it
+func TxFromContext(ctx context.Context) *sqlx.Tx {
+	return ambientTx(ctx)
+}
+
+// WithinTx runs fn in one tenant-scoped transaction. Repository calls made
+// with the context passed to fn join it, so their writes commit together.
+// A transaction lost to a serialization conflict or deadlock is run again,
+// so fn may be called more than once.
+func (db *tenantDB) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ambientTx(ctx) != nil {
+		return fn(ctx)
+	}
+
+	return db.retry.retryTx(ctx, func() error {
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+// inTx runs fn in a tenant-scoped transaction, joining the one opened by
+// WithinTx when there is one. A transaction of its own is retried like
+// WithinTx's.
+func (db *tenantDB) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	if tx := ambientTx(ctx); tx != nil {
+		return fn(tx)
+	}
+
+	return db.retry.retryTx(ctx, func() error {
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+}
+
+func (db *tenantDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if !db.scoped(ctx) && ambientTx(ctx) == nil {
+		return db.DB.ExecContext(ctx, query, args...)
+	}
+	var result sql.Result
+	err := db.inTx(ctx, func(tx *sqlx.Tx) error {
+		var err error
+		result, err = tx.ExecContext(ctx, query, args...)
+		return err
+	})
+	return result, err
+}
+
+func (db *tenantDB) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if !db.scoped(ctx) && ambientTx(ctx) == nil {
+		return db.DB.GetContext(ctx, dest, query, args...)
+	}
+	return db.inTx(ctx, func(tx *sqlx.Tx) error {
+		return tx.GetContext(ctx, dest, query, args...)
+	})
+}
+
+func (db *tenantDB) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if !db.scoped(ctx) && ambientTx(ctx) == nil {
+		return db.DB.SelectContext(ctx, dest, query, args...)
+	}
+	return db.inTx(ctx, func(tx *sqlx.Tx) error {
+		return tx.SelectContext(ctx, dest, query, args...)
+	})
+}
+
+// tenantKey prefixes a cache key with the context's tenant
+func tenantKey(ctx context.Context, key string) string {
+	if id := tenant.FromContext(ctx); id != "" {
+		return "tenant:" + id + ":" + key
+	}
+	return key
+}
@@ -7,9 +7,11 @@ import (
 
 	"github.com/ojaswiii/booking-manager/src/internal/domain"
 	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 )
 
 type postgresTicketRepository struct {
@@ -123,82 +125,37 @@ func (r *postgresTicketRepository) Delete(ctx context.Context, id uuid.UUID) err
 	return nil
 }
 
-// ReserveTickets reserves multiple tickets atomically
+// ReserveTickets moves tickets from available to reserved in a single
+// conditional update, so concurrent transactions cannot both reserve one
 func (r *postgresTicketRepository) ReserveTickets(ctx context.Context, ticketIDs []uuid.UUID) error {
 	if len(ticketIDs) == 0 {
 		return nil
 	}
 
-	// Start transaction
-	tx, err := r.db.BeginTxx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	// Check if all tickets are available
-	placeholders := make([]string, len(ticketIDs))
-	args := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
-	}
-
-	query := fmt.Sprintf(`
-		SELECT id, status
-		FROM tickets
-		WHERE id IN (%s)`,
-		fmt.Sprintf("$%d", len(ticketIDs)+1))
-
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
-	}
-	args = append(args, ticketIDsInterface...)
-
-	rows, err := tx.QueryContext(ctx, query, args...)
-	if err != nil {
-		return err
-	}
-	defer rows.Close()
-
-	availableTickets := make(map[uuid.UUID]bool)
-	for rows.Next() {
-		var id uuid.UUID
-		var status string
-		if err := rows.Scan(&id, &status); err != nil {
+	return r.inTx(ctx, func(tx *sqlx.Tx) error {
+		// Reserve only the tickets still available, locking their rows
+		query := `
+			UPDATE tickets
+			SET status = 'reserved', updated_at = NOW()
+			WHERE id = ANY($1) AND status = 'available'
+			RETURNING id`
+		var reserved []uuid.UUID
+		if err := tx.SelectContext(ctx, &reserved, query, pq.Array(ticketIDs)); err != nil {
 			return err
 		}
-		availableTickets[id] = (status == "available")
-	}
 
-	// Check if all requested tickets are available
-	for _, id := range ticketIDs {
-		if !availableTickets[id] {
-			return fmt.Errorf("ticket %s is not available", id)
+		// Roll back unless every requested ticket was reserved
+		done := make(map[uuid.UUID]bool, len(reserved))
+		for _, id := range reserved {
+			done[id] = true
 		}
-	}
-
-	// Reserve all tickets
-	updateQuery := fmt.Sprintf(`
-		UPDATE tickets
-		SET status = 'reserved', updated_at = NOW()
-		WHERE id IN (%s)`,
-		fmt.Sprintf("$%d", len(ticketIDs)+1))
-
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface = make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
-	}
-	args = append(args, ticketIDsInterface...)
-	_, err = tx.ExecContext(ctx, updateQuery, args...)
-	if err != nil {
-		return err
-	}
-
-	return tx.Commit()
+		for _, id := range ticketIDs {
+			if !done[id] {
+				return fmt.Errorf("%w: ticket %s is not available", domain.ErrConflict, id)
+			}
+		}
+		return nil
+	})
 }
 
 // ConfirmTickets confirms multiple tickets atomically
@@ -207,26 +164,11 @@ func (r *postgresTicketRepository) ConfirmTickets(ctx context.Context, ticketIDs
 		return nil
 	}
 
-	placeholders := make([]string, len(ticketIDs))
-	args := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE tickets
 		SET status = 'sold', updated_at = NOW()
-		WHERE id IN (%s) AND status = 'reserved'`,
-		fmt.Sprintf("$%d", len(ticketIDs)+1))
-
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
-	}
-	args = append(args, ticketIDsInterface...)
-	result, err := r.db.ExecContext(ctx, query, args...)
+		WHERE id = ANY($1) AND status = 'reserved'`
+	result, err := r.conn(ctx).ExecContext(ctx, query, pq.Array(ticketIDs))
 	if err != nil {
 		return err
 	}
@@ -248,25 +190,38 @@ func (r *postgresTicketRepository) ReleaseTickets(ctx context.Context, ticketIDs
 		return nil
 	}
 
-	placeholders := make([]string, len(ticketIDs))
-	args := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		placeholders[i] = fmt.Sprintf("$%d", i+1)
-		args[i] = id
-	}
-
-	query := fmt.Sprintf(`
+	query := `
 		UPDATE tickets
 		SET status = 'available', updated_at = NOW()
-		WHERE id IN (%s) AND status IN ('reserved', 'cancelled')`,
-		fmt.Sprintf("$%d", len(ticketIDs)+1))
+		WHERE id = ANY($1) AND status IN ('reserved', 'cancelled')`
+	_, err := r.conn(ctx).ExecContext(ctx, query, pq.Array(ticketIDs))
+	return err
+}
 
-	// Convert ticketIDs to interface{} slice
-	ticketIDsInterface := make([]interface{}, len(ticketIDs))
-	for i, id := range ticketIDs {
-		ticketIDsInterface[i] = id
+// inTx runs fn in the transaction opened by repository.Transactor when
+// there is one, and otherwise in a transaction of its own
+func (r *postgresTicketRepository) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	if tx := repository.TxFromContext(ctx); tx != nil {
+		return fn(tx)
 	}
-	args = append(args, ticketIDsInterface...)
-	_, err := r.db.ExecContext(ctx, query, args...)
-	return err
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// conn returns the transaction opened by repository.Transactor, if any, so
+// writes commit or roll back with the rest of the unit of work
+func (r *postgresTicketRepository) conn(ctx context.Context) sqlx.ExecerContext {
+	if tx := repository.TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return r.db
 }
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+const ticketPassColumns = `id, booking_id, ticket_id, token, issued_at, checked_in_at`
+
+// PostgreSQL Ticket Pass Repository
+type postgresTicketPassRepository struct {
+	db *tenantDB
+}
+
+// Create stores passes, keeping any already issued for the same booking and ticket
+func (r *postgresTicketPassRepository) Create(ctx context.Context, passes ...*domain_ticket.Pass) error {
+	if len(passes) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO ticket_passes (id, booking_id, ticket_id, token, issued_at) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (booking_id, ticket_id) DO NOTHING`
+		for _, p := range passes {
+			if _, err := tx.ExecContext(ctx, query, p.ID, p.BookingID, p.TicketID, p.Token, p.IssuedAt); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresTicketPassRepository) GetByBookingID(ctx context.Context, bookingID uuid.UUID) ([]*domain_ticket.Pass, error) {
+	query := `SELECT ` + ticketPassColumns + ` FROM ticket_passes WHERE booking_id = $1 ORDER BY issued_at ASC, ticket_id ASC`
+	var passes []*domain_ticket.Pass
+	err := r.db.SelectContext(ctx, &passes, query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	return passes, nil
+}
+
+func (r *postgresTicketPassRepository) GetByToken(ctx context.Context, token string) (*domain_ticket.Pass, error) {
+	query := `SELECT ` + ticketPassColumns + ` FROM ticket_passes WHERE token = $1`
+	var p domain_ticket.Pass
+	err := r.db.GetContext(ctx, &p, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CheckIn marks a pass as used. Only the first call succeeds; later ones
+// return domain.ErrConflict, so concurrent scans admit the ticket once.
+func (r *postgresTicketPassRepository) CheckIn(ctx context.Context, id uuid.UUID, at time.Time) error {
+	query := `UPDATE ticket_passes SET checked_in_at = $2 WHERE id = $1 AND checked_in_at IS NULL`
+	result, err := r.db.ExecContext(ctx, query, id, at)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrConflict
+	}
+	return nil
+}
+
+// CountCheckIns counts the passes of an event's confirmed bookings and how many have been used
+func (r *postgresTicketPassRepository) CountCheckIns(ctx context.Context, eventID uuid.UUID) (*domain_ticket.CheckInCounts, error) {
+	query := `SELECT $1::uuid AS event_id, COUNT(*) AS issued, COUNT(p.checked_in_at) AS checked_in
+		FROM ticket_passes p
+		JOIN bookings b ON b.id = p.booking_id
+		WHERE b.event_id = $1 AND b.status = 'confirmed'`
+	var counts domain_ticket.CheckInCounts
+	err := r.db.GetContext(ctx, &counts, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	counts.Remaining = counts.Issued - counts.CheckedIn
+	return &counts, nil
+}
+
+// Revoke deletes the passes of tickets that no longer belong to a booking
+func (r *postgresTicketPassRepository) Revoke(ctx context.Context, bookingID uuid.UUID, ticketIDs []uuid.UUID) error {
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `DELETE FROM ticket_passes WHERE booking_id = $1 AND ticket_id = $2`
+		for _, ticketID := range ticketIDs {
+			if _, err := tx.ExecContext(ctx, query, bookingID, ticketID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
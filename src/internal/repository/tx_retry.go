@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+// Postgres error codes for a transaction that lost to a concurrent one and
+// may succeed when run again
+const (
+	pqSerializationFailure = "40001"
+	pqDeadlockDetected     = "40P01"
+)
+
+// mysqlDeadlock is MySQL's error number for a transaction rolled back to
+// break a deadlock
+const mysqlDeadlock = 1213
+
+// TxRetryPolicy bounds how often a transaction that failed on a
+// serialization conflict or deadlock is run again
+type TxRetryPolicy struct {
+	MaxAttempts int           // runs in total; 1 disables retries
+	Backoff     time.Duration // wait before the first retry, doubled for each one after
+	MaxBackoff  time.Duration // longest wait between attempts
+}
+
+// delay returns a jittered wait before the retry following the given
+// attempt, between half and all of the doubled backoff
+func (p TxRetryPolicy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || delay < p.MaxBackoff); i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + time.Duration(rand.Int63n(int64(delay/2)+1))
+}
+
+// isTxConflict reports whether err is a serialization failure or deadlock
+func isTxConflict(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		return mysqlErr.Number == mysqlDeadlock
+	}
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == pqSerializationFailure || pqErr.Code == pqDeadlockDetected
+}
+
+// retryTx runs a transaction until it succeeds, fails for another reason
+// or runs out of attempts
+func (p TxRetryPolicy) retryTx(ctx context.Context, run func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := run()
+		if err == nil || attempt >= p.MaxAttempts || !isTxConflict(err) {
+			return err
+		}
+
+		timer := time.NewTimer(p.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
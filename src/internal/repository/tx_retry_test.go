@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+)
+
+func TestRetryTxRerunsConflictsOnly(t *testing.T) {
+	policy := TxRetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	runs := 0
+	err := policy.retryTx(context.Background(), func() error {
+		runs++
+		if runs < 3 {
+			return &pq.Error{Code: pqDeadlockDetected}
+		}
+		return nil
+	})
+	if err != nil || runs != 3 {
+		t.Fatalf("deadlocked twice: got %v after %d runs, want success after 3", err, runs)
+	}
+
+	runs = 0
+	err = policy.retryTx(context.Background(), func() error {
+		runs++
+		return &pq.Error{Code: pqSerializationFailure}
+	})
+	if !isTxConflict(err) || runs != 3 {
+		t.Fatalf("always conflicting: got %v after %d runs, want the conflict after 3", err, runs)
+	}
+
+	if !isTxConflict(&mysql.MySQLError{Number: mysqlDeadlock}) {
+		t.Fatal("MySQL deadlock not retried")
+	}
+
+	runs = 0
+	failed := errors.New("constraint violated")
+	err = policy.retryTx(context.Background(), func() error {
+		runs++
+		return failed
+	})
+	if err != failed || runs != 1 {
+		t.Fatalf("other error: got %v after %d runs, want it after 1", err, runs)
+	}
+}
+
+func TestTxRetryDelayIsJitteredAndCapped(t *testing.T) {
+	policy := TxRetryPolicy{Backoff: 20 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 20 * time.Millisecond, 2: 40 * time.Millisecond, 5: 50 * time.Millisecond} {
+		for i := 0; i < 20; i++ {
+			if delay := policy.delay(attempt); delay < want/2 || delay > want {
+				t.Fatalf("attempt %d: delay %v, want within [%v, %v]", attempt, delay, want/2, want)
+			}
+		}
+	}
+}
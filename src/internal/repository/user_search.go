@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/utils/querybuilder"
+)
+
+// userColumns lists the columns scanned into domain_user.User
+var userColumns = []string{
+	"id", "email", "name", "role", "email_verified_at", "locked_at", "created_at", "updated_at",
+}
+
+// maxUserSearchLimit caps the page size of a single search
+const maxUserSearchLimit = 100
+
+func (r *postgresUserRepository) Search(ctx context.Context, filter domain_user.UserFilter) ([]*domain_user.User, error) {
+	query, args, err := buildUserSearchQuery(filter, querybuilder.Postgres)
+	if err != nil {
+		return nil, err
+	}
+
+	var users []*domain_user.User
+	if err := r.db.readSelect(ctx, &users, query, args...); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *mysqlUserRepository) Search(ctx context.Context, filter domain_user.UserFilter) ([]*domain_user.User, error) {
+	query, args, err := buildUserSearchQuery(filter, querybuilder.MySQL)
+	if err != nil {
+		return nil, err
+	}
+	var users []*domain_user.User
+	if err := r.db.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// buildUserSearchQuery translates a filter into a parameterized SELECT in
+// the given dialect, newest users first
+func buildUserSearchQuery(filter domain_user.UserFilter, dialect querybuilder.Dialect) (string, []interface{}, error) {
+	q := querybuilder.Select(userColumns...).From("users").In(dialect)
+	q.Where("deleted_at IS NULL")
+
+	if filter.EmailPrefix != "" {
+		// MySQL's LIKE already ignores case under the schema's collation
+		like := "ILIKE"
+		if dialect == querybuilder.MySQL {
+			like = "LIKE"
+		}
+		q.Where(fmt.Sprintf("email %s ?", like), escapeLike(filter.EmailPrefix)+"%")
+	}
+	if filter.CreatedFrom != nil {
+		q.Where("created_at >= ?", *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		q.Where("created_at <= ?", *filter.CreatedTo)
+	}
+	// Tie-break on id so paging is stable
+	q.OrderBy("created_at", querybuilder.Desc).OrderBy("id", querybuilder.Asc)
+
+	if filter.Limit < 0 || filter.Offset < 0 {
+		return "", nil, fmt.Errorf("%w: limit and offset must not be negative", domain.ErrInvalidInput)
+	}
+	limit := filter.Limit
+	if limit == 0 || limit > maxUserSearchLimit {
+		limit = maxUserSearchLimit
+	}
+	q.Limit(uint64(limit))
+	if filter.Offset > 0 {
+		q.Offset(uint64(filter.Offset))
+	}
+
+	return q.ToSQL()
+}
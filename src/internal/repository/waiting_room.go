@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Redis Waiting Room Repository
+// Each event's queue is a sorted set of tokens scored by join time, with
+// hashes mapping users to tokens and back, and a counter of how many
+// queue positions have been admitted so far.
+type redisWaitingRoomRepository struct {
+	client *redis.Client
+}
+
+const waitingRoomTTL = 24 * time.Hour
+
+func waitingRoomKey(ctx context.Context, eventID uuid.UUID) string {
+	return tenantKey(ctx, fmt.Sprintf("waitingroom:%s", eventID.String()))
+}
+
+func (r *redisWaitingRoomRepository) Join(ctx context.Context, eventID, userID uuid.UUID, token string) (string, error) {
+	key := waitingRoomKey(ctx, eventID)
+
+	// A user keeps their original place if they join twice
+	set, err := r.client.HSetNX(ctx, key+":users", userID.String(), token).Result()
+	if err != nil {
+		return "", err
+	}
+	if !set {
+		return r.GetToken(ctx, eventID, userID)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(time.Now().UnixNano()), Member: token})
+	pipe.HSet(ctx, key+":tokens", token, userID.String())
+	pipe.SAdd(ctx, tenantKey(ctx, "waitingrooms:active"), eventID.String())
+	for _, k := range []string{key, key + ":users", key + ":tokens"} {
+		pipe.Expire(ctx, k, waitingRoomTTL)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (r *redisWaitingRoomRepository) GetToken(ctx context.Context, eventID, userID uuid.UUID) (string, error) {
+	token, err := r.client.HGet(ctx, waitingRoomKey(ctx, eventID)+":users", userID.String()).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return "", domain.ErrNotFound
+		}
+		return "", err
+	}
+	return token, nil
+}
+
+func (r *redisWaitingRoomRepository) GetUserID(ctx context.Context, eventID uuid.UUID, token string) (uuid.UUID, error) {
+	userID, err := r.client.HGet(ctx, waitingRoomKey(ctx, eventID)+":tokens", token).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return uuid.Nil, domain.ErrNotFound
+		}
+		return uuid.Nil, err
+	}
+	return uuid.Parse(userID)
+}
+
+func (r *redisWaitingRoomRepository) Position(ctx context.Context, eventID uuid.UUID, token string) (int64, error) {
+	rank, err := r.client.ZRank(ctx, waitingRoomKey(ctx, eventID), token).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, domain.ErrNotFound
+		}
+		return 0, err
+	}
+	return rank, nil
+}
+
+func (r *redisWaitingRoomRepository) Size(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	return r.client.ZCard(ctx, waitingRoomKey(ctx, eventID)).Result()
+}
+
+func (r *redisWaitingRoomRepository) Admitted(ctx context.Context, eventID uuid.UUID) (int64, error) {
+	admitted, err := r.client.Get(ctx, waitingRoomKey(ctx, eventID)+":admitted").Int64()
+	if err != nil {
+		if err == redis.Nil {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return admitted, nil
+}
+
+func (r *redisWaitingRoomRepository) Admit(ctx context.Context, eventID uuid.UUID, count int64) (int64, error) {
+	key := waitingRoomKey(ctx, eventID) + ":admitted"
+	pipe := r.client.TxPipeline()
+	incr := pipe.IncrBy(ctx, key, count)
+	pipe.Expire(ctx, key, waitingRoomTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
+
+func (r *redisWaitingRoomRepository) ActiveEvents(ctx context.Context) ([]uuid.UUID, error) {
+	members, err := r.client.SMembers(ctx, tenantKey(ctx, "waitingrooms:active")).Result()
+	if err != nil {
+		return nil, err
+	}
+	eventIDs := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		eventID, err := uuid.Parse(member)
+		if err != nil {
+			continue
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+	return eventIDs, nil
+}
+
+func (r *redisWaitingRoomRepository) Close(ctx context.Context, eventID uuid.UUID) error {
+	key := waitingRoomKey(ctx, eventID)
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, key, key+":users", key+":tokens", key+":admitted")
+	pipe.SRem(ctx, tenantKey(ctx, "waitingrooms:active"), eventID.String())
+	_, err := pipe.Exec(ctx)
+	return err
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PostgreSQL Webhook Repository
+type postgresWebhookRepository struct {
+	db *tenantDB
+}
+
+func (r *postgresWebhookRepository) CreateSubscription(ctx context.Context, sub *domain_webhook.Subscription) error {
+	query := `INSERT INTO webhook_subscriptions (id, url, secret, event_types, active, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)`
+	_, err := r.db.ExecContext(ctx, query, sub.ID, sub.URL, sub.Secret, sub.EventTypes, sub.Active, sub.CreatedAt, sub.UpdatedAt)
+	return err
+}
+
+func (r *postgresWebhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*domain_webhook.Subscription, error) {
+	query := `SELECT id, url, secret, event_types, active, created_at, updated_at FROM webhook_subscriptions WHERE id = $1`
+	var sub domain_webhook.Subscription
+	err := r.db.GetContext(ctx, &sub, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return &sub, nil
+}
+
+func (r *postgresWebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain_webhook.Subscription, error) {
+	query := `SELECT id, url, secret, event_types, active, created_at, updated_at FROM webhook_subscriptions ORDER BY created_at ASC`
+	var subs []*domain_webhook.Subscription
+	err := r.db.SelectContext(ctx, &subs, query)
+	if err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *postgresWebhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresWebhookRepository) CreateDeliveries(ctx context.Context, deliveries []*domain_webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return r.db.inTx(ctx, func(tx *sqlx.Tx) error {
+		query := `INSERT INTO webhook_deliveries (id, subscription_id, event_type, payload, status, attempts, next_attempt_at, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		for _, d := range deliveries {
+			if _, err := tx.ExecContext(ctx, query, d.ID, d.SubscriptionID, d.EventType, []byte(d.Payload), d.Status, d.Attempts, d.NextAttemptAt, d.CreatedAt, d.UpdatedAt); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// ClaimDueDeliveries leases pending deliveries that are due by pushing their
+// next attempt into the future, so concurrent dispatchers never send the same one
+func (r *postgresWebhookRepository) ClaimDueDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain_webhook.Delivery, error) {
+	query := `UPDATE webhook_deliveries SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at ASC
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, subscription_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, updated_at`
+	var deliveries []*domain_webhook.Delivery
+	err := r.db.SelectContext(ctx, &deliveries, query, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *postgresWebhookRepository) UpdateDelivery(ctx context.Context, d *domain_webhook.Delivery) error {
+	query := `UPDATE webhook_deliveries SET status = $2, attempts = $3, next_attempt_at = $4, last_status_code = $5, last_error = $6, updated_at = $7 WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, d.ID, d.Status, d.Attempts, d.NextAttemptAt, d.LastStatusCode, d.LastError, d.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresWebhookRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*domain_webhook.Delivery, error) {
+	query := `SELECT id, subscription_id, event_type, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, updated_at FROM webhook_deliveries WHERE subscription_id = $1 ORDER BY created_at DESC LIMIT $2`
+	var deliveries []*domain_webhook.Delivery
+	err := r.db.SelectContext(ctx, &deliveries, query, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
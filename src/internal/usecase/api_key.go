@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// ErrAPIKeyRateLimited is returned, as an APIKeyRateLimitError, when a key
+// has made as many requests as its rate limit allows for now
+var ErrAPIKeyRateLimited = errors.New("api key rate limit exceeded")
+
+// APIKeyRateLimitError tells a rate limited partner when to retry
+type APIKeyRateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *APIKeyRateLimitError) Error() string { return ErrAPIKeyRateLimited.Error() }
+func (e *APIKeyRateLimitError) Unwrap() error { return ErrAPIKeyRateLimited }
+
+// apiKeyRateWindow is what a key's rate limit is counted over
+const apiKeyRateWindow = time.Minute
+
+// apiKeyTouchInterval is how stale a key's last use may get, so not every
+// request writes it
+const apiKeyTouchInterval = time.Minute
+
+// APIKeyUsecase issues keys to partners, such as resellers, and checks the
+// keys their requests come with
+type APIKeyUsecase struct {
+	apiKeyRepo       repository.APIKeyRepository
+	rateLimitRepo    repository.RateLimitRepository
+	users            *UserUsecase
+	logger           *utils.Logger
+	defaultRateLimit int
+}
+
+// NewAPIKeyUsecase creates a new API key usecase
+func NewAPIKeyUsecase(apiKeyRepo repository.APIKeyRepository, rateLimitRepo repository.RateLimitRepository, users *UserUsecase, config *utils.Config, logger *utils.Logger) *APIKeyUsecase {
+	return &APIKeyUsecase{
+		apiKeyRepo:       apiKeyRepo,
+		rateLimitRepo:    rateLimitRepo,
+		users:            users,
+		logger:           logger,
+		defaultRateLimit: max(config.APIKeyRateLimitPerMinute, 1),
+	}
+}
+
+// CreateAPIKeyRequest represents a request to issue an API key
+type CreateAPIKeyRequest struct {
+	Name      string                `json:"name"`
+	AccountID uuid.UUID             `json:"account_id"` // the user the key's requests act as
+	Scopes    []domain_apikey.Scope `json:"scopes"`
+	RateLimit int                   `json:"rate_limit,omitempty"` // requests per minute, API_KEY_RATE_LIMIT_PER_MINUTE if zero
+	ExpiresAt *time.Time            `json:"expires_at,omitempty"` // never if unset
+}
+
+// CreateAPIKey issues a key, for admins, and returns it with the key
+// itself. The key is not kept, so this is the only time it is known.
+func (u *APIKeyUsecase) CreateAPIKey(ctx context.Context, adminID uuid.UUID, req CreateAPIKeyRequest) (*domain_apikey.APIKey, string, error) {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, "", err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, "", fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+	if len(req.Scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", domain.ErrInvalidInput)
+	}
+	for _, scope := range req.Scopes {
+		if !scope.Valid() {
+			return nil, "", fmt.Errorf("%w: unknown scope %q", domain.ErrInvalidInput, scope)
+		}
+	}
+	if req.RateLimit < 0 {
+		return nil, "", fmt.Errorf("%w: rate_limit must not be negative", domain.ErrInvalidInput)
+	}
+	now := time.Now().UTC()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, "", fmt.Errorf("%w: expires_at must be in the future", domain.ErrInvalidInput)
+	}
+	if _, err := u.users.GetUser(ctx, req.AccountID); err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, "", fmt.Errorf("%w: account %s does not exist", domain.ErrInvalidInput, req.AccountID)
+		}
+		return nil, "", err
+	}
+
+	rateLimit := req.RateLimit
+	if rateLimit == 0 {
+		rateLimit = u.defaultRateLimit
+	}
+	key, secret, err := domain_apikey.NewAPIKey(name, req.AccountID, req.Scopes, rateLimit, req.ExpiresAt, adminID, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := u.apiKeyRepo.Create(ctx, key); err != nil {
+		return nil, "", fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	u.logger.Info("API key issued", "api_key_id", key.ID, "account_id", key.UserID, "by", adminID)
+	return key, secret, nil
+}
+
+// ListAPIKeys returns every key, revoked ones included, newest first, for admins
+func (u *APIKeyUsecase) ListAPIKeys(ctx context.Context, adminID uuid.UUID) ([]*domain_apikey.APIKey, error) {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.apiKeyRepo.List(ctx)
+}
+
+// RevokeAPIKey stops a key from being used, for admins
+func (u *APIKeyUsecase) RevokeAPIKey(ctx context.Context, adminID, keyID uuid.UUID) (*domain_apikey.APIKey, error) {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := u.apiKeyRepo.Revoke(ctx, keyID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+	u.logger.Info("API key revoked", "api_key_id", keyID, "by", adminID)
+	return u.apiKeyRepo.GetByID(ctx, keyID)
+}
+
+// Authenticate returns the key a partner request came with, if it is
+// active, has the scope and is within its rate limit
+func (u *APIKeyUsecase) Authenticate(ctx context.Context, secret string, scope domain_apikey.Scope) (*domain_apikey.APIKey, error) {
+	if !domain_apikey.LooksLikeKey(secret) {
+		return nil, domain_apikey.ErrInvalidAPIKey
+	}
+	key, err := u.apiKeyRepo.GetByHash(ctx, domain_apikey.HashKey(secret))
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain_apikey.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+	now := time.Now().UTC()
+	if !key.Active(now) {
+		return nil, domain_apikey.ErrInvalidAPIKey
+	}
+	if !key.HasScope(scope) {
+		return nil, domain_apikey.ErrScopeDenied
+	}
+
+	allowed, retryAfter, err := u.rateLimitRepo.Allow(ctx, "apikey:"+key.ID.String(), key.RateLimit, apiKeyRateWindow)
+	if err != nil {
+		// Partners are not locked out while Redis is unreachable
+		u.logger.Warn("Failed to count API key request", "api_key_id", key.ID, "error", err)
+	} else if !allowed {
+		return nil, &APIKeyRateLimitError{RetryAfter: retryAfter}
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apiKeyTouchInterval {
+		if err := u.apiKeyRepo.Touch(ctx, key.ID, now); err != nil {
+			u.logger.Warn("Failed to record API key use", "api_key_id", key.ID, "error", err)
+		}
+	}
+	return key, nil
+}
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_apikey "github.com/ojaswiii/booking-manager/src/internal/domain/apikey"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestAPIKeysAreScopedRateLimitedAndRevocable(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{APIKeyRateLimitPerMinute: 100}
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	apiKeys := NewAPIKeyUsecase(repos.APIKey, repos.RateLimit, users, config, logger)
+	ctx := context.Background()
+
+	admin := &domain_user.User{ID: uuid.New(), Email: "admin@example.com", Role: domain_user.RoleAdmin, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	reseller := &domain_user.User{ID: uuid.New(), Email: "tickets@reseller.example", Role: domain_user.RoleCustomer, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	for _, user := range []*domain_user.User{admin, reseller} {
+		if err := repos.User.Create(ctx, user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	req := CreateAPIKeyRequest{Name: "Reseller", AccountID: reseller.ID, Scopes: []domain_apikey.Scope{domain_apikey.ScopeBookingsWrite}, RateLimit: 2}
+	if _, _, err := apiKeys.CreateAPIKey(ctx, reseller.ID, req); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("issued by a customer: got %v, want forbidden", err)
+	}
+	bad := req
+	bad.Scopes = []domain_apikey.Scope{"bookings:delete"}
+	if _, _, err := apiKeys.CreateAPIKey(ctx, admin.ID, bad); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("unknown scope: got %v, want invalid input", err)
+	}
+
+	key, secret, err := apiKeys.CreateAPIKey(ctx, admin.ID, req)
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	if key.KeyHash == secret || key.KeyHash != domain_apikey.HashKey(secret) || secret[:len(key.Prefix)] != key.Prefix {
+		t.Fatalf("stored key: got %+v for %q", key, secret)
+	}
+
+	// The key acts as its account, for the scopes it has
+	authed, err := apiKeys.Authenticate(ctx, secret, domain_apikey.ScopeBookingsWrite)
+	if err != nil || authed.UserID != reseller.ID {
+		t.Fatalf("authenticate: got %+v, %v", authed, err)
+	}
+	if _, err := apiKeys.Authenticate(ctx, secret, domain_apikey.ScopeBookingsRead); !errors.Is(err, domain_apikey.ErrScopeDenied) {
+		t.Fatalf("missing scope: got %v", err)
+	}
+	if _, err := apiKeys.Authenticate(ctx, secret+"x", domain_apikey.ScopeBookingsWrite); !errors.Is(err, domain_apikey.ErrInvalidAPIKey) {
+		t.Fatalf("wrong key: got %v", err)
+	}
+
+	// Two requests a minute; requests turned away above do not count
+	if _, err := apiKeys.Authenticate(ctx, secret, domain_apikey.ScopeBookingsWrite); err != nil {
+		t.Fatalf("second request: %v", err)
+	}
+	var limited *APIKeyRateLimitError
+	if _, err := apiKeys.Authenticate(ctx, secret, domain_apikey.ScopeBookingsWrite); !errors.As(err, &limited) || limited.RetryAfter <= 0 {
+		t.Fatalf("over the limit: got %v", err)
+	}
+
+	if _, err := apiKeys.RevokeAPIKey(ctx, admin.ID, key.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, err := apiKeys.RevokeAPIKey(ctx, admin.ID, key.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("revoke twice: got %v, want not found", err)
+	}
+	if _, err := apiKeys.Authenticate(ctx, secret, domain_apikey.ScopeBookingsWrite); !errors.Is(err, domain_apikey.ErrInvalidAPIKey) {
+		t.Fatalf("revoked key: got %v", err)
+	}
+	listed, err := apiKeys.ListAPIKeys(ctx, admin.ID)
+	if err != nil || len(listed) != 1 || listed[0].RevokedAt == nil || listed[0].LastUsedAt == nil {
+		t.Fatalf("list: got %d, %v", len(listed), err)
+	}
+}
@@ -0,0 +1,820 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+	"github.com/ojaswiii/booking-manager/src/utils/oauth"
+
+	"github.com/google/uuid"
+)
+
+// Sign-in errors
+var (
+	ErrInvalidLogin       = fmt.Errorf("%w: email or password is wrong", domain.ErrUnauthorized)
+	ErrUnknownLogin       = fmt.Errorf("%w: no user has this email", domain.ErrUnauthorized)
+	ErrWrongPassword      = fmt.Errorf("%w: password is wrong", domain.ErrUnauthorized)
+	ErrNoPassword         = fmt.Errorf("%w: account has no password; reset it or sign in with a provider", domain.ErrUnauthorized)
+	ErrInvalidAccessToken = fmt.Errorf("%w: access token is invalid or expired", domain.ErrUnauthorized)
+
+	ErrUnknownProvider   = fmt.Errorf("%w: sign-in provider is not configured", domain.ErrNotFound)
+	ErrInvalidOAuthState = fmt.Errorf("%w: sign-in state is invalid or expired", domain.ErrUnauthorized)
+	ErrOAuthRejected     = fmt.Errorf("%w: sign-in provider rejected the sign-in", domain.ErrUnauthorized)
+	ErrUnverifiedEmail   = fmt.Errorf("%w: sign-in provider has not verified the account's email", domain.ErrForbidden)
+
+	ErrInvalidVerificationToken = fmt.Errorf("%w: verification token is invalid or expired", domain.ErrInvalidInput)
+	ErrInvalidResetToken        = fmt.Errorf("%w: reset token is invalid, expired or used", domain.ErrInvalidInput)
+)
+
+// ErrPasswordResetRateLimited is returned, as a PasswordResetRateLimitError,
+// when an email has been sent as many reset tokens as it may for now
+var ErrPasswordResetRateLimited = errors.New("password reset rate limit exceeded")
+
+// PasswordResetRateLimitError tells a rate limited client when to retry
+type PasswordResetRateLimitError struct {
+	RetryAfter time.Duration
+}
+
+func (e *PasswordResetRateLimitError) Error() string { return ErrPasswordResetRateLimited.Error() }
+func (e *PasswordResetRateLimitError) Unwrap() error { return ErrPasswordResetRateLimited }
+
+// passwordResetWindow is what PASSWORD_RESET_PER_HOUR is counted over
+const passwordResetWindow = time.Hour
+
+// oauthStateTTL is how long a user has to sign in at the provider
+const oauthStateTTL = 10 * time.Minute
+
+// AuthUsecase signs users in to sessions. A session hands out short-lived
+// access tokens, signed and checked without a lookup, and one refresh
+// token at a time, which is swapped for a new one each time it is used.
+// Users sign in by email or through an external provider; both start the
+// same kind of session.
+type AuthUsecase struct {
+	sessionRepo   repository.SessionRepository
+	identityRepo  repository.IdentityRepository
+	resetRepo     repository.PasswordResetRepository
+	rateLimitRepo repository.RateLimitRepository
+	users         *UserUsecase
+	providers     map[string]oauth.Provider
+	notifier      notify.Notifier
+	logger        *utils.Logger
+
+	secret      []byte
+	accessTTL   time.Duration
+	idle        time.Duration
+	callbackURL string // with %s for the provider's name
+	verifyURL   string // the token is appended
+	verifyTTL   time.Duration
+	resetURL    string // the token is appended; empty to email the bare token
+	resetTTL    time.Duration
+	resetLimit  int // reset emails per address per passwordResetWindow
+}
+
+// NewAuthUsecase creates a new auth usecase
+func NewAuthUsecase(sessionRepo repository.SessionRepository, identityRepo repository.IdentityRepository, resetRepo repository.PasswordResetRepository, rateLimitRepo repository.RateLimitRepository, users *UserUsecase, providers map[string]oauth.Provider, notifier notify.Notifier, config *utils.Config, logger *utils.Logger) *AuthUsecase {
+	secret := []byte(config.AuthSigningSecret)
+	if len(secret) == 0 {
+		// Without a configured secret, access tokens are only valid on this
+		// instance until restart; refresh tokens keep working
+		secret = make([]byte, 32)
+		rand.Read(secret)
+		logger.Warn("AUTH_SIGNING_SECRET not set, using an ephemeral signing key")
+	}
+
+	return &AuthUsecase{
+		sessionRepo:   sessionRepo,
+		identityRepo:  identityRepo,
+		resetRepo:     resetRepo,
+		rateLimitRepo: rateLimitRepo,
+		users:         users,
+		providers:     providers,
+		notifier:      notifier,
+		logger:        logger,
+		secret:        secret,
+		accessTTL:     time.Duration(max(config.AccessTokenTTLSeconds, 1)) * time.Second,
+		idle:          time.Duration(max(config.SessionIdleHours, 1)) * time.Hour,
+		callbackURL:   strings.TrimRight(config.OAuthRedirectBaseURL, "/") + "/api/v1/auth/oauth/%s/callback",
+		verifyURL:     strings.TrimRight(config.OAuthRedirectBaseURL, "/") + "/api/v1/auth/verify?token=",
+		verifyTTL:     time.Duration(max(config.EmailVerificationTTLHours, 1)) * time.Hour,
+		resetURL:      config.PasswordResetURL,
+		resetTTL:      time.Duration(max(config.PasswordResetTTLMinutes, 1)) * time.Minute,
+		resetLimit:    max(config.PasswordResetPerHour, 1),
+	}
+}
+
+// LoginRequest represents a request to sign in with a password
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// ForgotPasswordRequest represents a request to email a password reset token
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest represents a request to set a password with a reset token
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// RefreshRequest represents a request to swap a refresh token for new tokens
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogoutRequest represents a request to end the session of a refresh token,
+// or with All, every session of its user
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+	All          bool   `json:"all,omitempty"`
+}
+
+// TokenResponse represents the tokens of a session
+type TokenResponse struct {
+	AccessToken  string    `json:"access_token"`
+	TokenType    string    `json:"token_type"`
+	ExpiresIn    int       `json:"expires_in"` // seconds the access token is accepted for
+	RefreshToken string    `json:"refresh_token"`
+	SessionID    uuid.UUID `json:"session_id"`
+}
+
+// AccessClaims is what an access token says about its bearer. A token an
+// admin was given to act as the user names the admin and has no session.
+type AccessClaims struct {
+	UserID         uuid.UUID        `json:"uid"`
+	SessionID      uuid.UUID        `json:"sid"`
+	Role           domain_user.Role `json:"role"`
+	ExpiresAt      int64            `json:"exp"`
+	ImpersonatorID *uuid.UUID       `json:"imp,omitempty"`
+}
+
+// Caller returns who the claims' bearer is
+func (c *AccessClaims) Caller() *domain_session.Caller {
+	return &domain_session.Caller{
+		UserID:         c.UserID,
+		SessionID:      c.SessionID,
+		Role:           c.Role,
+		ImpersonatorID: c.ImpersonatorID,
+	}
+}
+
+// ImpersonationResponse represents an access token for acting as a user.
+// It cannot be refreshed.
+type ImpersonationResponse struct {
+	AccessToken    string    `json:"access_token"`
+	TokenType      string    `json:"token_type"`
+	ExpiresIn      int       `json:"expires_in"`
+	UserID         uuid.UUID `json:"user_id"`
+	ImpersonatorID uuid.UUID `json:"impersonator_id"`
+}
+
+// Login starts a session for the user with the email and password
+func (a *AuthUsecase) Login(ctx context.Context, req LoginRequest, client domain_session.Client) (*TokenResponse, error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" || req.Password == "" {
+		return nil, fmt.Errorf("%w: email and password are required", domain.ErrInvalidInput)
+	}
+	// Unknown emails, wrong passwords and users without one all get
+	// ErrInvalidLogin, so login cannot be used to find out who is registered
+	user, err := a.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, ErrInvalidLogin
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = a.users.CheckPassword(ctx, user.ID, req.Password)
+	if errors.Is(err, ErrWrongPassword) || errors.Is(err, ErrNoPassword) {
+		return nil, ErrInvalidLogin
+	}
+	if err != nil {
+		return nil, err
+	}
+	return a.startSession(ctx, user, client)
+}
+
+// OAuthStart is where to send a user to sign in at a provider, with the
+// state the provider hands back
+type OAuthStart struct {
+	URL   string
+	State string
+}
+
+// OAuthCallback is what a provider sends a signed-in user back with
+type OAuthCallback struct {
+	Provider    string
+	Code        string
+	State       string
+	CookieState string // the state kept by the browser that started the sign-in
+}
+
+// oauthState is what a sign-in's state says. It is signed, so the callback
+// can trust it without it being stored.
+type oauthState struct {
+	Provider  string `json:"p"`
+	Nonce     string `json:"n"` // the PKCE verifier is derived from it
+	ExpiresAt int64  `json:"exp"`
+}
+
+// StartOAuth begins signing in at a provider. The state must be kept by
+// the browser, such as in a cookie, for CompleteOAuth to check.
+func (a *AuthUsecase) StartOAuth(providerName string) (*OAuthStart, error) {
+	provider, ok := a.providers[providerName]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	state := oauthState{
+		Provider:  provider.Name(),
+		Nonce:     base64.RawURLEncoding.EncodeToString(nonce),
+		ExpiresAt: time.Now().Add(oauthStateTTL).Unix(),
+	}
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	signed := payload + "." + base64.RawURLEncoding.EncodeToString(a.signState(payload))
+
+	challenge := sha256.Sum256([]byte(a.verifier(state.Nonce)))
+	authURL := provider.AuthCodeURL(signed, base64.RawURLEncoding.EncodeToString(challenge[:]), a.callback(provider))
+	return &OAuthStart{URL: authURL, State: signed}, nil
+}
+
+// CompleteOAuth finishes signing in at a provider and starts a session for
+// the user its account is linked to. An account signing in for the first
+// time is linked to the user with its email, who is created if there is
+// none; the provider must have verified the email either way.
+func (a *AuthUsecase) CompleteOAuth(ctx context.Context, req OAuthCallback, client domain_session.Client) (*TokenResponse, error) {
+	provider, ok := a.providers[req.Provider]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	// The state must come back to the browser that was sent off with it;
+	// otherwise anyone could sign a victim in to their own account by
+	// getting them to open a callback URL
+	if req.State == "" || subtle.ConstantTimeCompare([]byte(req.State), []byte(req.CookieState)) != 1 {
+		return nil, ErrInvalidOAuthState
+	}
+	state, err := a.openState(req.State)
+	if err != nil || state.Provider != provider.Name() {
+		return nil, ErrInvalidOAuthState
+	}
+	if req.Code == "" {
+		return nil, fmt.Errorf("%w: code is required", domain.ErrInvalidInput)
+	}
+
+	account, err := provider.Identify(ctx, req.Code, a.verifier(state.Nonce), a.callback(provider))
+	if errors.Is(err, oauth.ErrRejected) {
+		a.logger.Warn("Sign-in rejected by provider", "provider", provider.Name(), "error", err)
+		return nil, ErrOAuthRejected
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign in with %s: %w", provider.Name(), err)
+	}
+
+	user, err := a.linkedUser(ctx, provider.Name(), account)
+	if err != nil {
+		return nil, err
+	}
+	return a.startSession(ctx, user, client)
+}
+
+// linkedUser returns the user a provider's account is linked to, linking
+// it first if it is new
+func (a *AuthUsecase) linkedUser(ctx context.Context, providerName string, account *oauth.Identity) (*domain_user.User, error) {
+	identity, err := a.identityRepo.GetByProviderSubject(ctx, providerName, account.Subject)
+	if err == nil {
+		user, err := a.users.GetUser(ctx, identity.UserID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrUnknownLogin
+		}
+		return user, err
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	// Anyone can claim an email they do not own at some providers, so only
+	// verified ones may take over, or make, the user with that email
+	if account.Email == "" || !account.EmailVerified {
+		return nil, ErrUnverifiedEmail
+	}
+	user, err := a.users.GetUserByEmail(ctx, account.Email)
+	if errors.Is(err, domain.ErrNotFound) {
+		var created *CreateUserResponse
+		created, err = a.users.create(ctx, CreateUserRequest{Email: account.Email, Name: account.Name}, "")
+		if err != nil {
+			return nil, err
+		}
+		user, err = a.users.GetUser(ctx, created.UserID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The provider has verified the email, so the user need not
+	if !user.EmailVerified() {
+		if user, err = a.users.VerifyEmail(ctx, user.ID, account.Email); err != nil {
+			return nil, err
+		}
+	}
+
+	err = a.identityRepo.Create(ctx, &domain_user.Identity{
+		UserID:    user.ID,
+		Provider:  providerName,
+		Subject:   account.Subject,
+		Email:     account.Email,
+		CreatedAt: time.Now().UTC(),
+	})
+	// A sign-in racing this one linked the account first, to the same user
+	// as it has the same email
+	if err != nil && !errors.Is(err, domain_user.ErrIdentityLinked) {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+	a.logger.Info("Identity linked", "user_id", user.ID, "provider", providerName)
+	return user, nil
+}
+
+// verificationClaims is what an email verification token says. The email
+// is included so a token stops working once the user changes their email.
+type verificationClaims struct {
+	UserID    uuid.UUID `json:"uid"`
+	Email     string    `json:"email"`
+	ExpiresAt int64     `json:"exp"`
+}
+
+// SendVerification emails a user a link to verify their email, unless it
+// is verified already
+func (a *AuthUsecase) SendVerification(ctx context.Context, userID uuid.UUID) error {
+	user, err := a.users.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return nil
+	}
+
+	raw, err := json.Marshal(verificationClaims{
+		UserID:    user.ID,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(a.verifyTTL).Unix(),
+	})
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	token := payload + "." + base64.RawURLEncoding.EncodeToString(a.signVerification(payload))
+
+	email, err := verificationEmail.render(EmailData{User: user, VerifyURL: a.verifyURL + token})
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	if err := a.notifier.Send(ctx, email); err != nil {
+		return fmt.Errorf("failed to send verification email: %w", err)
+	}
+	a.logger.Info("Verification email sent", "user_id", user.ID)
+	return nil
+}
+
+// VerifyEmail checks an email verification token and marks the email it
+// was sent to as verified
+func (a *AuthUsecase) VerifyEmail(ctx context.Context, token string) (*domain_user.User, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidVerificationToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(a.signVerification(payload), got) {
+		return nil, ErrInvalidVerificationToken
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	var claims verificationClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, ErrInvalidVerificationToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidVerificationToken
+	}
+
+	user, err := a.users.VerifyEmail(ctx, claims.UserID, claims.Email)
+	if errors.Is(err, domain.ErrNotFound) || errors.Is(err, domain.ErrConflict) {
+		return nil, ErrInvalidVerificationToken
+	}
+	return user, err
+}
+
+// ForgotPassword emails a single-use token for resetting the password of
+// the user with the email. Emails no user has are not told apart, so the
+// endpoint cannot be used to find out who is registered; both count
+// towards the email's rate limit.
+func (a *AuthUsecase) ForgotPassword(ctx context.Context, req ForgotPasswordRequest) error {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return fmt.Errorf("%w: email is required", domain.ErrInvalidInput)
+	}
+
+	allowed, retryAfter, err := a.rateLimitRepo.Allow(ctx, "password_reset:"+strings.ToLower(email), a.resetLimit, passwordResetWindow)
+	if err != nil {
+		// Redis being down should not stop users getting back in
+		a.logger.Error("Failed to check password reset rate limit", "error", err)
+	} else if !allowed {
+		return &PasswordResetRateLimitError{RetryAfter: retryAfter}
+	}
+
+	user, err := a.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, domain.ErrNotFound) {
+		a.logger.Info("Password reset asked for unknown email")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return err
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+	if err := a.resetRepo.Create(ctx, resetTokenHash(token), user.ID, a.resetTTL); err != nil {
+		return fmt.Errorf("failed to save reset token: %w", err)
+	}
+
+	data := EmailData{User: user, ResetToken: token, ResetMinutes: int(a.resetTTL.Minutes())}
+	if a.resetURL != "" {
+		data.ResetURL = a.resetURL + token
+	}
+	message, err := passwordResetEmail.render(data)
+	if err != nil {
+		return fmt.Errorf("failed to render email: %w", err)
+	}
+	if err := a.notifier.Send(ctx, message); err != nil {
+		return fmt.Errorf("failed to send reset email: %w", err)
+	}
+	a.logger.Info("Password reset email sent", "user_id", user.ID)
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token, using up the token,
+// and ends every session of the user, since whoever reset it may not be
+// the one signed in. It returns how many sessions were ended.
+func (a *AuthUsecase) ResetPassword(ctx context.Context, req ResetPasswordRequest) (int, error) {
+	// Checked first so a weak password does not use up the token
+	if err := domain_user.ValidatePassword(req.Password); err != nil {
+		return 0, err
+	}
+	if req.Token == "" {
+		return 0, ErrInvalidResetToken
+	}
+	userID, err := a.resetRepo.Take(ctx, resetTokenHash(req.Token))
+	if errors.Is(err, domain.ErrNotFound) {
+		return 0, ErrInvalidResetToken
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reset token: %w", err)
+	}
+
+	err = a.users.SetPassword(ctx, userID, req.Password)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Deleted since the token was sent
+		return 0, ErrInvalidResetToken
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to set password: %w", err)
+	}
+
+	ended, err := a.sessionRepo.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to end sessions: %w", err)
+	}
+	a.logger.Info("Password reset", "user_id", userID, "sessions", ended)
+	return ended, nil
+}
+
+// resetTokenHash is how reset tokens are kept, so the store never holds
+// one that can be used
+func resetTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Refresh swaps a refresh token for a new access token and refresh token.
+// A refresh token is good once: presenting one that was already swapped
+// means it was copied, so the session is ended for whoever holds either.
+func (a *AuthUsecase) Refresh(ctx context.Context, req RefreshRequest, client domain_session.Client) (*TokenResponse, error) {
+	session, err := a.session(ctx, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users deleted since signing in lose their sessions
+	user, err := a.users.GetUser(ctx, session.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		a.end(ctx, session)
+		return nil, domain_session.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Locked() {
+		a.end(ctx, session)
+		return nil, domain_user.ErrAccountLocked
+	}
+
+	previous := session.TokenHash
+	refreshToken, err := session.Rotate(client, time.Now().UTC(), a.idle)
+	if err != nil {
+		return nil, err
+	}
+	rotated, err := a.sessionRepo.Rotate(ctx, session, previous)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	if !rotated {
+		// Another request swapped the same token first
+		a.logger.Warn("Refresh token used twice, ending session", "user_id", session.UserID, "session_id", session.ID)
+		a.end(ctx, session)
+		return nil, domain_session.ErrInvalidRefreshToken
+	}
+	return a.tokens(session, user, refreshToken)
+}
+
+// Logout ends the session of a refresh token, or every session of its user,
+// and returns how many sessions were ended. Access tokens already handed
+// out are accepted until they expire.
+func (a *AuthUsecase) Logout(ctx context.Context, req LogoutRequest) (int, error) {
+	session, err := a.session(ctx, req.RefreshToken)
+	if err != nil {
+		return 0, err
+	}
+	if req.All {
+		ended, err := a.sessionRepo.DeleteByUserID(ctx, session.UserID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to end sessions: %w", err)
+		}
+		a.logger.Info("Signed out everywhere", "user_id", session.UserID, "sessions", ended)
+		return ended, nil
+	}
+	if err := a.sessionRepo.Delete(ctx, session); err != nil {
+		return 0, fmt.Errorf("failed to end session: %w", err)
+	}
+	a.logger.Info("Session ended", "user_id", session.UserID, "session_id", session.ID)
+	return 1, nil
+}
+
+// ListSessions returns a user's sessions, most recently used first
+func (a *AuthUsecase) ListSessions(ctx context.Context, userID uuid.UUID) ([]*domain_session.Session, error) {
+	return a.sessionRepo.ListByUserID(ctx, userID)
+}
+
+// Authenticate checks an access token and returns its claims. Beyond its
+// signature and expiry, the session it was issued for must not have ended,
+// by signing out, a password reset or a lock, and its user must not be
+// locked. An impersonation token, which has no session, also needs the
+// admin it names to still be an unlocked admin.
+func (a *AuthUsecase) Authenticate(ctx context.Context, token string) (*AccessClaims, error) {
+	claims, err := a.verifyAccess(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.SessionID != uuid.Nil {
+		session, err := a.sessionRepo.Get(ctx, claims.SessionID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, ErrInvalidAccessToken
+		}
+		if err != nil {
+			return nil, err
+		}
+		if session.UserID != claims.UserID {
+			return nil, ErrInvalidAccessToken
+		}
+	}
+	user, err := a.users.GetUser(ctx, claims.UserID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, ErrInvalidAccessToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Locked() {
+		return nil, domain_user.ErrAccountLocked
+	}
+	if claims.ImpersonatorID != nil {
+		if _, err := a.users.Authorize(ctx, *claims.ImpersonatorID, domain_user.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+	return claims, nil
+}
+
+// verifyAccess checks an access token's signature and expiry, returning
+// its claims
+func (a *AuthUsecase) verifyAccess(token string) (*AccessClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidAccessToken
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(a.sign([]byte(payload)), got) {
+		return nil, ErrInvalidAccessToken
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+	var claims AccessClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+	if time.Now().Unix() >= claims.ExpiresAt {
+		return nil, ErrInvalidAccessToken
+	}
+	return &claims, nil
+}
+
+// Impersonate signs an access token for adminID to act as user, for
+// support. There is no session, so nothing to refresh: the admin asks again
+// once it expires.
+func (a *AuthUsecase) Impersonate(user *domain_user.User, adminID uuid.UUID) (*ImpersonationResponse, error) {
+	token, err := a.signAccess(AccessClaims{
+		UserID:         user.ID,
+		Role:           user.Role,
+		ExpiresAt:      time.Now().Add(a.accessTTL).Unix(),
+		ImpersonatorID: &adminID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &ImpersonationResponse{
+		AccessToken:    token,
+		TokenType:      "Bearer",
+		ExpiresIn:      int(a.accessTTL.Seconds()),
+		UserID:         user.ID,
+		ImpersonatorID: adminID,
+	}, nil
+}
+
+// EndSessions ends every session of userID and returns how many there were
+func (a *AuthUsecase) EndSessions(ctx context.Context, userID uuid.UUID) (int, error) {
+	ended, err := a.sessionRepo.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to end sessions: %w", err)
+	}
+	return ended, nil
+}
+
+// startSession signs a user in to a new session. Locked users are refused.
+func (a *AuthUsecase) startSession(ctx context.Context, user *domain_user.User, client domain_session.Client) (*TokenResponse, error) {
+	if user.Locked() {
+		return nil, domain_user.ErrAccountLocked
+	}
+	session, refreshToken, err := domain_session.NewSession(user.ID, client, time.Now().UTC(), a.idle)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.sessionRepo.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	a.logger.Info("Session started", "user_id", user.ID, "session_id", session.ID)
+	return a.tokens(session, user, refreshToken)
+}
+
+// session returns the session a refresh token is good for. A token that
+// is not the session's current one ends the session.
+func (a *AuthUsecase) session(ctx context.Context, refreshToken string) (*domain_session.Session, error) {
+	sessionID, secret, err := domain_session.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	session, err := a.sessionRepo.Get(ctx, sessionID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, domain_session.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if !session.Matches(secret) {
+		a.logger.Warn("Swapped refresh token reused, ending session", "user_id", session.UserID, "session_id", session.ID)
+		a.end(ctx, session)
+		return nil, domain_session.ErrInvalidRefreshToken
+	}
+	return session, nil
+}
+
+// end deletes a session that can no longer be trusted
+func (a *AuthUsecase) end(ctx context.Context, session *domain_session.Session) {
+	if err := a.sessionRepo.Delete(ctx, session); err != nil {
+		a.logger.Error("Failed to end session", "session_id", session.ID, "error", err)
+	}
+}
+
+// tokens signs an access token for the session and returns it with the
+// refresh token
+func (a *AuthUsecase) tokens(session *domain_session.Session, user *domain_user.User, refreshToken string) (*TokenResponse, error) {
+	accessToken, err := a.signAccess(AccessClaims{
+		UserID:    user.ID,
+		SessionID: session.ID,
+		Role:      user.Role,
+		ExpiresAt: time.Now().Add(a.accessTTL).Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenResponse{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(a.accessTTL.Seconds()),
+		RefreshToken: refreshToken,
+		SessionID:    session.ID,
+	}, nil
+}
+
+// signAccess encodes and signs an access token's claims
+func (a *AuthUsecase) signAccess(claims AccessClaims) (string, error) {
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(a.sign([]byte(payload))), nil
+}
+
+// sign computes the HMAC-SHA256 of data
+func (a *AuthUsecase) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, a.secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// signState signs a sign-in state. The prefix keeps a state from passing
+// as an access token, which is signed with the same key.
+func (a *AuthUsecase) signState(payload string) []byte {
+	return a.sign([]byte("oauth_state." + payload))
+}
+
+// signVerification signs an email verification token, prefixed as
+// signState is
+func (a *AuthUsecase) signVerification(payload string) []byte {
+	return a.sign([]byte("verify_email." + payload))
+}
+
+// openState checks a sign-in state and returns what it says
+func (a *AuthUsecase) openState(signed string) (*oauthState, error) {
+	payload, signature, ok := strings.Cut(signed, ".")
+	if !ok {
+		return nil, ErrInvalidOAuthState
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(a.signState(payload), got) {
+		return nil, ErrInvalidOAuthState
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidOAuthState
+	}
+	var state oauthState
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, ErrInvalidOAuthState
+	}
+	if time.Now().Unix() >= state.ExpiresAt {
+		return nil, ErrInvalidOAuthState
+	}
+	return &state, nil
+}
+
+// verifier derives a sign-in's PKCE verifier from its nonce, so it need
+// not be stored between sending the user off and the callback
+func (a *AuthUsecase) verifier(nonce string) string {
+	return base64.RawURLEncoding.EncodeToString(a.sign([]byte("pkce." + nonce)))
+}
+
+// callback is where a provider sends users back to
+func (a *AuthUsecase) callback(provider oauth.Provider) string {
+	return fmt.Sprintf(a.callbackURL, provider.Name())
+}
@@ -0,0 +1,339 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+	"github.com/ojaswiii/booking-manager/src/utils/oauth"
+
+	"github.com/google/uuid"
+)
+
+func TestSessionsRotateRefreshTokens(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{AuthSigningSecret: "secret", AccessTokenTTLSeconds: 60, SessionIdleHours: 1}
+	auth := NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger), nil, nil, config, logger)
+	ctx := context.Background()
+	phone := domain_session.Client{UserAgent: "phone", IPAddress: "10.0.0.1"}
+	laptop := domain_session.Client{UserAgent: "laptop", IPAddress: "10.0.0.2"}
+
+	user := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", Role: domain_user.RoleCustomer, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := repos.User.Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if _, err := auth.Login(ctx, LoginRequest{Email: "nobody@example.com", Password: "correct horse"}, phone); !errors.Is(err, ErrInvalidLogin) {
+		t.Fatalf("unknown email: got %v, want ErrInvalidLogin", err)
+	}
+
+	// An email alone never signs in, nor does any password for a user without one
+	if _, err := auth.Login(ctx, LoginRequest{Email: user.Email}, phone); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("login without password: got %v, want invalid input", err)
+	}
+	if _, err := auth.Login(ctx, LoginRequest{Email: user.Email, Password: "correct horse"}, phone); !errors.Is(err, ErrInvalidLogin) {
+		t.Fatalf("login to user without password: got %v", err)
+	}
+	if err := auth.users.SetPassword(ctx, user.ID, "correct horse"); err != nil {
+		t.Fatalf("set password: %v", err)
+	}
+	if _, err := auth.Login(ctx, LoginRequest{Email: user.Email, Password: "wrong horse"}, phone); !errors.Is(err, ErrInvalidLogin) {
+		t.Fatalf("wrong password: got %v", err)
+	}
+
+	first, err := auth.Login(ctx, LoginRequest{Email: user.Email, Password: "correct horse"}, phone)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	claims, err := auth.Authenticate(ctx, first.AccessToken)
+	if err != nil || claims.UserID != user.ID || claims.SessionID != first.SessionID {
+		t.Fatalf("access token: got %+v, %v", claims, err)
+	}
+	if _, err := auth.Authenticate(ctx, first.AccessToken+"x"); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Fatalf("tampered access token: got %v", err)
+	}
+
+	// Each refresh swaps the token, and the old one stops working
+	refreshed, err := auth.Refresh(ctx, RefreshRequest{RefreshToken: first.RefreshToken}, phone)
+	if err != nil || refreshed.SessionID != first.SessionID || refreshed.RefreshToken == first.RefreshToken {
+		t.Fatalf("refresh: got %+v, %v", refreshed, err)
+	}
+	second, err := auth.Login(ctx, LoginRequest{Email: user.Email, Password: "correct horse"}, laptop)
+	if err != nil {
+		t.Fatalf("second login: %v", err)
+	}
+	sessions, err := auth.ListSessions(ctx, user.ID)
+	if err != nil || len(sessions) != 2 || sessions[0].ID != second.SessionID || sessions[1].UserAgent != "phone" {
+		t.Fatalf("sessions: got %d, %v", len(sessions), err)
+	}
+
+	// Reusing a swapped token ends that session, but not the others
+	if _, err := auth.Refresh(ctx, RefreshRequest{RefreshToken: first.RefreshToken}, phone); !errors.Is(err, domain_session.ErrInvalidRefreshToken) {
+		t.Fatalf("reused token: got %v", err)
+	}
+	if _, err := auth.Refresh(ctx, RefreshRequest{RefreshToken: refreshed.RefreshToken}, phone); !errors.Is(err, domain_session.ErrInvalidRefreshToken) {
+		t.Fatalf("session after reuse: got %v, want it ended", err)
+	}
+	if sessions, _ := auth.ListSessions(ctx, user.ID); len(sessions) != 1 {
+		t.Fatalf("sessions after reuse: got %d, want 1", len(sessions))
+	}
+
+	if ended, err := auth.Logout(ctx, LogoutRequest{RefreshToken: second.RefreshToken}); err != nil || ended != 1 {
+		t.Fatalf("logout: got %d, %v", ended, err)
+	}
+	if _, err := auth.Refresh(ctx, RefreshRequest{RefreshToken: second.RefreshToken}, laptop); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("refresh after logout: got %v", err)
+	}
+	if _, err := auth.Refresh(ctx, RefreshRequest{RefreshToken: strings.Repeat("x", 20)}, laptop); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("malformed token: got %v", err)
+	}
+}
+
+// fakeProvider signs in whichever account is set, once the PKCE verifier
+// matches the challenge it was sent off with
+type fakeProvider struct {
+	account   *oauth.Identity
+	challenge string
+}
+
+func (p *fakeProvider) Name() string { return "fake" }
+
+func (p *fakeProvider) AuthCodeURL(state, challenge, redirectURL string) string {
+	p.challenge = challenge
+	return "https://provider.example.com/authorize?state=" + url.QueryEscape(state)
+}
+
+func (p *fakeProvider) Identify(ctx context.Context, code, verifier, redirectURL string) (*oauth.Identity, error) {
+	sum := sha256.Sum256([]byte(verifier))
+	if code != "code" || base64.RawURLEncoding.EncodeToString(sum[:]) != p.challenge {
+		return nil, oauth.ErrRejected
+	}
+	return p.account, nil
+}
+
+func TestOAuthLinksAccountsByVerifiedEmail(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{AuthSigningSecret: "secret", AccessTokenTTLSeconds: 60, SessionIdleHours: 1}
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	provider := &fakeProvider{}
+	auth := NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, users, map[string]oauth.Provider{"fake": provider}, nil, config, logger)
+	ctx := context.Background()
+	client := domain_session.Client{UserAgent: "browser"}
+
+	signIn := func(account oauth.Identity) (*TokenResponse, error) {
+		provider.account = &account
+		start, err := auth.StartOAuth("fake")
+		if err != nil {
+			t.Fatalf("start: %v", err)
+		}
+		return auth.CompleteOAuth(ctx, OAuthCallback{Provider: "fake", Code: "code", State: start.State, CookieState: start.State}, client)
+	}
+
+	if _, err := auth.StartOAuth("other"); !errors.Is(err, ErrUnknownProvider) {
+		t.Fatalf("unknown provider: got %v", err)
+	}
+
+	// An account with an unknown email makes a user
+	first, err := signIn(oauth.Identity{Subject: "1", Email: "new@example.com", EmailVerified: true, Name: "New"})
+	if err != nil {
+		t.Fatalf("first sign-in: %v", err)
+	}
+	created, err := users.GetUserByEmail(ctx, "new@example.com")
+	if err != nil || created.Name != "New" || created.Role != domain_user.RoleCustomer || !created.EmailVerified() {
+		t.Fatalf("created user: got %+v, %v", created, err)
+	}
+	if claims, err := auth.Authenticate(ctx, first.AccessToken); err != nil || claims.UserID != created.ID {
+		t.Fatalf("access token: got %+v, %v", claims, err)
+	}
+
+	// The account stays linked after its email changes at the provider
+	again, err := signIn(oauth.Identity{Subject: "1", Email: "renamed@example.com", EmailVerified: false})
+	if err != nil {
+		t.Fatalf("second sign-in: %v", err)
+	}
+	if claims, _ := auth.Authenticate(ctx, again.AccessToken); claims == nil || claims.UserID != created.ID {
+		t.Fatalf("second sign-in: got %+v, want %s", claims, created.ID)
+	}
+
+	// Another account is linked to the user with its email, if verified
+	existing := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", Role: domain_user.RoleOrganizer, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := repos.User.Create(ctx, existing); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if _, err := signIn(oauth.Identity{Subject: "2", Email: "fan@example.com", EmailVerified: false}); !errors.Is(err, ErrUnverifiedEmail) {
+		t.Fatalf("unverified email: got %v", err)
+	}
+	linked, err := signIn(oauth.Identity{Subject: "2", Email: "fan@example.com", EmailVerified: true})
+	if err != nil {
+		t.Fatalf("linking sign-in: %v", err)
+	}
+	if claims, _ := auth.Authenticate(ctx, linked.AccessToken); claims == nil || claims.UserID != existing.ID || claims.Role != domain_user.RoleOrganizer {
+		t.Fatalf("linked sign-in: got %+v", claims)
+	}
+
+	// The state must match the browser's and not be tampered with
+	start, err := auth.StartOAuth("fake")
+	if err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if _, err := auth.CompleteOAuth(ctx, OAuthCallback{Provider: "fake", Code: "code", State: start.State}, client); !errors.Is(err, ErrInvalidOAuthState) {
+		t.Fatalf("missing cookie: got %v", err)
+	}
+	forged := start.State + "x"
+	if _, err := auth.CompleteOAuth(ctx, OAuthCallback{Provider: "fake", Code: "code", State: forged, CookieState: forged}, client); !errors.Is(err, ErrInvalidOAuthState) {
+		t.Fatalf("forged state: got %v", err)
+	}
+	if _, err := auth.Authenticate(ctx, start.State); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Fatalf("state as access token: got %v", err)
+	}
+	if _, err := auth.CompleteOAuth(ctx, OAuthCallback{Provider: "fake", Code: "stale", State: start.State, CookieState: start.State}, client); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("rejected code: got %v", err)
+	}
+}
+
+// outbox keeps the emails it is given
+type outbox struct {
+	sent []notify.Email
+}
+
+func (o *outbox) Send(ctx context.Context, email notify.Email) error {
+	o.sent = append(o.sent, email)
+	return nil
+}
+
+func TestEmailVerificationTokens(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{AuthSigningSecret: "secret", AccessTokenTTLSeconds: 60, SessionIdleHours: 1, EmailVerificationTTLHours: 1, OAuthRedirectBaseURL: "https://tickets.example.com/"}
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	mail := &outbox{}
+	auth := NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, users, nil, mail, config, logger)
+	ctx := context.Background()
+
+	created, err := users.CreateUser(ctx, CreateUserRequest{Email: "fan@example.com", Name: "Fan", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if err := auth.SendVerification(ctx, created.UserID); err != nil || len(mail.sent) != 1 {
+		t.Fatalf("send: got %d emails, %v", len(mail.sent), err)
+	}
+	email := mail.sent[0]
+	const prefix = "https://tickets.example.com/api/v1/auth/verify?token="
+	start := strings.Index(email.Text, prefix)
+	if email.To != "fan@example.com" || start < 0 {
+		t.Fatalf("email: got %+v", email)
+	}
+	token := strings.Fields(email.Text[start+len(prefix):])[0]
+
+	if _, err := auth.VerifyEmail(ctx, token+"x"); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Fatalf("tampered token: got %v", err)
+	}
+	if _, err := auth.Authenticate(ctx, token); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Fatalf("verification token as access token: got %v", err)
+	}
+	verified, err := auth.VerifyEmail(ctx, token)
+	if err != nil || !verified.EmailVerified() {
+		t.Fatalf("verify: got %+v, %v", verified, err)
+	}
+	if stored, _ := repos.User.GetByID(ctx, created.UserID); stored == nil || !stored.EmailVerified() {
+		t.Fatalf("stored user: got %+v", stored)
+	}
+
+	// Verified users are not sent another link
+	if err := auth.SendVerification(ctx, created.UserID); err != nil || len(mail.sent) != 1 {
+		t.Fatalf("send again: got %d emails, %v", len(mail.sent), err)
+	}
+
+	// A new email must be verified again, and the old link no longer works
+	verified.ChangeEmail("new@example.com")
+	if err := users.UpdateUser(ctx, verified); err != nil {
+		t.Fatalf("change email: %v", err)
+	}
+	if _, err := auth.VerifyEmail(ctx, token); !errors.Is(err, ErrInvalidVerificationToken) {
+		t.Fatalf("token for old email: got %v", err)
+	}
+	if stored, _ := repos.User.GetByID(ctx, created.UserID); stored == nil || stored.EmailVerified() {
+		t.Fatalf("user with new email: got %+v", stored)
+	}
+}
+
+func TestPasswordResetIsSingleUseAndEndsSessions(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{AuthSigningSecret: "secret", AccessTokenTTLSeconds: 60, SessionIdleHours: 1, PasswordResetTTLMinutes: 30, PasswordResetPerHour: 2}
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	mail := &outbox{}
+	auth := NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, users, nil, mail, config, logger)
+	ctx := context.Background()
+	client := domain_session.Client{UserAgent: "browser"}
+
+	if _, err := users.CreateUser(ctx, CreateUserRequest{Email: "fan@example.com", Name: "Fan"}); !errors.Is(err, domain_user.ErrWeakPassword) {
+		t.Fatalf("create user without password: got %v", err)
+	}
+	created, err := users.CreateUser(ctx, CreateUserRequest{Email: "fan@example.com", Name: "Fan", Password: "forgotten horse"})
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	signedIn, err := auth.Login(ctx, LoginRequest{Email: "fan@example.com", Password: "forgotten horse"}, client)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+
+	// Unknown emails are answered the same, without an email
+	if err := auth.ForgotPassword(ctx, ForgotPasswordRequest{Email: "nobody@example.com"}); err != nil || len(mail.sent) != 0 {
+		t.Fatalf("unknown email: got %d emails, %v", len(mail.sent), err)
+	}
+	if err := auth.ForgotPassword(ctx, ForgotPasswordRequest{Email: "fan@example.com"}); err != nil || len(mail.sent) != 1 {
+		t.Fatalf("forgot: got %d emails, %v", len(mail.sent), err)
+	}
+	lines := strings.Split(strings.TrimSpace(mail.sent[0].Text), "\n")
+	token := lines[3]
+
+	// A weak password is refused without using up the token
+	if _, err := auth.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "short"}); !errors.Is(err, domain_user.ErrWeakPassword) {
+		t.Fatalf("weak password: got %v", err)
+	}
+	ended, err := auth.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "correct horse"})
+	if err != nil || ended != 1 {
+		t.Fatalf("reset: ended %d, %v; want 1", ended, err)
+	}
+	if sessions, _ := auth.ListSessions(ctx, created.UserID); len(sessions) != 0 {
+		t.Fatalf("sessions after reset: got %d, want 0", len(sessions))
+	}
+	if _, err := auth.Authenticate(ctx, signedIn.AccessToken); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Fatalf("access token after reset: got %v, want ErrInvalidAccessToken", err)
+	}
+	if _, err := auth.ResetPassword(ctx, ResetPasswordRequest{Token: token, Password: "another horse"}); !errors.Is(err, ErrInvalidResetToken) {
+		t.Fatalf("reused token: got %v", err)
+	}
+
+	// The new password is now needed to sign in
+	if _, err := auth.Login(ctx, LoginRequest{Email: "fan@example.com", Password: "forgotten horse"}, client); !errors.Is(err, ErrInvalidLogin) {
+		t.Fatalf("login with old password: got %v", err)
+	}
+	if _, err := auth.Login(ctx, LoginRequest{Email: "fan@example.com", Password: "correct horse"}, client); err != nil {
+		t.Fatalf("login with new password: %v", err)
+	}
+
+	// Each email gets PASSWORD_RESET_PER_HOUR requests, however it is cased
+	if err := auth.ForgotPassword(ctx, ForgotPasswordRequest{Email: "fan@example.com"}); err != nil {
+		t.Fatalf("second request: %v", err)
+	}
+	err = auth.ForgotPassword(ctx, ForgotPasswordRequest{Email: "Fan@example.com"})
+	var limited *PasswordResetRateLimitError
+	if !errors.As(err, &limited) || limited.RetryAfter <= 0 || !errors.Is(err, ErrPasswordResetRateLimited) {
+		t.Fatalf("third request: got %v, want rate limited", err)
+	}
+}
@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// ErrEventSoldOut is returned when a booking is asked for after an event's
+// last ticket has gone
+var ErrEventSoldOut = fmt.Errorf("%w: event is sold out", domain.ErrConflict)
+
+type AvailabilityUsecase struct {
+	ticketRepo repository.TicketRepository
+	eventRepo  repository.EventRepository
+	cache      repository.AvailabilityCacheRepository
+	feed       *AvailabilityFeed
+	logger     *utils.Logger
+
+	reconcileInterval time.Duration
+	sectionSize       int // seats per section of the summary, a row of the seat map
+}
+
+// NewAvailabilityUsecase creates a new ticket availability usecase
+func NewAvailabilityUsecase(ticketRepo repository.TicketRepository, eventRepo repository.EventRepository, cache repository.AvailabilityCacheRepository, feed *AvailabilityFeed, config *utils.Config, logger *utils.Logger) *AvailabilityUsecase {
+	return &AvailabilityUsecase{
+		ticketRepo:        ticketRepo,
+		eventRepo:         eventRepo,
+		cache:             cache,
+		feed:              feed,
+		logger:            logger,
+		reconcileInterval: time.Duration(max(config.AvailabilityReconcileSeconds, 1)) * time.Second,
+		sectionSize:       max(config.SeatMapRowSize, 1),
+	}
+}
+
+// ticketTiers counts tickets by price tier
+func ticketTiers(tickets []*domain_ticket.Ticket) map[int64]int64 {
+	counts := make(map[int64]int64)
+	for _, tkt := range tickets {
+		counts[domain_ticket.PriceCents(tkt.Price)]++
+	}
+	return counts
+}
+
+// publish tells watchers of an event which of its seats changed
+func (a *AvailabilityUsecase) publish(ctx context.Context, eventID uuid.UUID, kind domain_ticket.AvailabilityChangeKind, tickets []*domain_ticket.Ticket) {
+	change := &domain_ticket.AvailabilityChange{EventID: eventID, Kind: kind}
+	for _, tkt := range tickets {
+		change.TicketIDs = append(change.TicketIDs, tkt.ID)
+		change.SeatNumbers = append(change.SeatNumbers, tkt.SeatNumber)
+	}
+	a.feed.Publish(ctx, change)
+}
+
+// Take counts tickets an event has just reserved or sold as no longer
+// available. Counters that disagree, with fewer tickets left than were
+// taken, are dropped to be recounted on their next read. Failures are
+// logged rather than returned so counters never block the booking flow.
+func (a *AvailabilityUsecase) Take(ctx context.Context, eventID uuid.UUID, ticketIDs []uuid.UUID) {
+	tickets, err := a.ticketRepo.GetByIDs(ctx, ticketIDs)
+	if err != nil {
+		a.logger.Warn("Failed to load taken tickets", "event_id", eventID, "error", err)
+		return
+	}
+	a.publish(ctx, eventID, domain_ticket.AvailabilityReserved, tickets)
+
+	taken, err := a.cache.Take(ctx, eventID, ticketTiers(tickets))
+	if err != nil {
+		a.logger.Warn("Failed to update ticket availability", "event_id", eventID, "error", err)
+		return
+	}
+	if !taken {
+		a.logger.Warn("Ticket availability drifted; recounting", "event_id", eventID, "tickets", len(tickets))
+		if err := a.cache.Delete(ctx, eventID); err != nil {
+			a.logger.Warn("Failed to drop ticket availability", "event_id", eventID, "error", err)
+		}
+	}
+}
+
+// Restore counts tickets put back on sale as available again
+func (a *AvailabilityUsecase) Restore(ctx context.Context, eventID uuid.UUID, ticketIDs []uuid.UUID) {
+	tickets, err := a.ticketRepo.GetByIDs(ctx, ticketIDs)
+	if err != nil {
+		a.logger.Warn("Failed to load restored tickets", "event_id", eventID, "error", err)
+		return
+	}
+	a.publish(ctx, eventID, domain_ticket.AvailabilityReleased, tickets)
+
+	if err := a.cache.Restore(ctx, eventID, ticketTiers(tickets)); err != nil {
+		a.logger.Warn("Failed to update ticket availability", "event_id", eventID, "error", err)
+	}
+}
+
+// Sold tells watchers that reserved tickets were paid for. They were
+// already counted as taken when reserved.
+func (a *AvailabilityUsecase) Sold(ctx context.Context, eventID uuid.UUID, ticketIDs []uuid.UUID) {
+	tickets, err := a.ticketRepo.GetByIDs(ctx, ticketIDs)
+	if err != nil {
+		a.logger.Warn("Failed to load sold tickets", "event_id", eventID, "error", err)
+		return
+	}
+	a.publish(ctx, eventID, domain_ticket.AvailabilitySold, tickets)
+}
+
+// Refresh recounts an event's available tickets after a change that moves
+// a range of seats at once, such as a hold
+func (a *AvailabilityUsecase) Refresh(ctx context.Context, eventID uuid.UUID) {
+	if _, err := a.reconcile(ctx, eventID); err != nil {
+		a.logger.Warn("Failed to recount ticket availability", "event_id", eventID, "error", err)
+	}
+	a.feed.Publish(ctx, &domain_ticket.AvailabilityChange{EventID: eventID, Kind: domain_ticket.AvailabilityRecounted})
+}
+
+// GetAvailability returns an event's available tickets from the cache,
+// counting them from the database the first time they are asked for
+func (a *AvailabilityUsecase) GetAvailability(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	if _, err := a.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return a.availability(ctx, eventID)
+}
+
+// GetSummary counts an event's tickets by status, overall, per price tier
+// and per section, without loading the tickets themselves
+func (a *AvailabilityUsecase) GetSummary(ctx context.Context, eventID uuid.UUID) (*domain_ticket.AvailabilitySummary, error) {
+	event, err := a.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := a.ticketRepo.CountByStatus(ctx, eventID, a.sectionSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count tickets: %w", err)
+	}
+
+	summary := &domain_ticket.AvailabilitySummary{
+		EventID:  eventID,
+		Tiers:    []domain_ticket.TierCounts{},
+		Sections: []domain_ticket.SectionCounts{},
+	}
+	tiers := make(map[int64]*domain_ticket.TierCounts)
+	sections := make(map[int]*domain_ticket.SectionCounts)
+	for _, count := range counts {
+		summary.Total.Add(count.Status, count.Tickets)
+
+		cents := domain_ticket.PriceCents(count.Price)
+		tier, ok := tiers[cents]
+		if !ok {
+			tier = &domain_ticket.TierCounts{Price: count.Price}
+			tiers[cents] = tier
+		}
+		tier.Add(count.Status, count.Tickets)
+
+		section, ok := sections[count.Section]
+		if !ok {
+			from := (count.Section-1)*a.sectionSize + 1
+			section = &domain_ticket.SectionCounts{
+				Section:  count.Section,
+				FromSeat: from,
+				ToSeat:   max(min(count.Section*a.sectionSize, event.TotalSeats), from), // the last row may be short
+			}
+			sections[count.Section] = section
+		}
+		section.Add(count.Status, count.Tickets)
+	}
+
+	for _, tier := range tiers {
+		summary.Tiers = append(summary.Tiers, *tier)
+	}
+	sort.Slice(summary.Tiers, func(i, j int) bool { return summary.Tiers[i].Price < summary.Tiers[j].Price })
+	for _, section := range sections {
+		summary.Sections = append(summary.Sections, *section)
+	}
+	sort.Slice(summary.Sections, func(i, j int) bool { return summary.Sections[i].Section < summary.Sections[j].Section })
+	return summary, nil
+}
+
+// SoldOut reports whether an event has no tickets left. Availability that
+// cannot be read counts as not sold out, leaving the booking to find out.
+func (a *AvailabilityUsecase) SoldOut(ctx context.Context, eventID uuid.UUID) bool {
+	availability, err := a.availability(ctx, eventID)
+	if err != nil {
+		a.logger.Warn("Failed to read ticket availability", "event_id", eventID, "error", err)
+		return false
+	}
+	return availability.Available <= 0
+}
+
+func (a *AvailabilityUsecase) availability(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	availability, err := a.cache.Get(ctx, eventID)
+	if err == nil {
+		return availability, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		a.logger.Warn("Failed to read cached ticket availability", "event_id", eventID, "error", err)
+	}
+	return a.reconcile(ctx, eventID)
+}
+
+// reconcile recounts one event's available tickets and replaces its cached
+// counters
+func (a *AvailabilityUsecase) reconcile(ctx context.Context, eventID uuid.UUID) (*domain_ticket.Availability, error) {
+	availability, err := a.ticketRepo.CountAvailable(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	availability.ReconciledAt = time.Now().UTC()
+	if err := a.cache.Set(ctx, availability); err != nil {
+		a.logger.Warn("Failed to cache ticket availability", "event_id", eventID, "error", err)
+	}
+	return availability, nil
+}
+
+// Reconcile recounts the available tickets of upcoming events and replaces
+// their cached counters, correcting any drift from missed updates. It
+// returns how many events were reconciled.
+func (a *AvailabilityUsecase) Reconcile(ctx context.Context) (int, error) {
+	events, err := a.eventRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	reconciled := 0
+	for _, event := range events {
+		if !event.Date.After(now) {
+			continue
+		}
+		if _, err := a.reconcile(ctx, event.ID); err != nil {
+			a.logger.Warn("Failed to recount ticket availability", "event_id", event.ID, "error", err)
+			continue
+		}
+		reconciled++
+	}
+	return reconciled, nil
+}
+
+// Run reconciles the cached counters on each tick until the context is cancelled
+func (a *AvailabilityUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(a.reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := a.Reconcile(ctx)
+			if err != nil {
+				a.logger.Error("Failed to reconcile ticket availability", "error", err)
+				continue
+			}
+			a.logger.Debug("Reconciled ticket availability", "events", count)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+// availabilityWatchBuffer is how many changes a watcher may fall behind by
+// before it is dropped
+const availabilityWatchBuffer = 64
+
+// availabilityTopic is one tenant's event
+type availabilityTopic struct {
+	tenant  string
+	eventID uuid.UUID
+}
+
+type availabilityWatcher struct {
+	ch     chan *domain_ticket.AvailabilityChange
+	closed bool
+}
+
+// AvailabilityFeed passes seat changes from every instance to the clients
+// watching an event on this one. It runs on every instance that serves
+// requests.
+type AvailabilityFeed struct {
+	feed   repository.AvailabilityFeedRepository
+	logger *utils.Logger
+
+	mu       sync.Mutex
+	watchers map[availabilityTopic]map[*availabilityWatcher]struct{}
+}
+
+// NewAvailabilityFeed creates a new availability feed
+func NewAvailabilityFeed(feed repository.AvailabilityFeedRepository, logger *utils.Logger) *AvailabilityFeed {
+	return &AvailabilityFeed{
+		feed:     feed,
+		logger:   logger,
+		watchers: make(map[availabilityTopic]map[*availabilityWatcher]struct{}),
+	}
+}
+
+// Publish tells every instance about a change. Failures are logged rather
+// than returned: watchers catch up on the next recount.
+func (f *AvailabilityFeed) Publish(ctx context.Context, change *domain_ticket.AvailabilityChange) {
+	if change.At.IsZero() {
+		change.At = time.Now().UTC()
+	}
+	if err := f.feed.Publish(ctx, change); err != nil {
+		f.logger.Warn("Failed to publish availability change", "event_id", change.EventID, "kind", change.Kind, "error", err)
+	}
+}
+
+// Watch returns the changes to an event's seats and a func to stop
+// watching. The channel is closed when the watcher stops, falls too far
+// behind, or the feed shuts down.
+func (f *AvailabilityFeed) Watch(ctx context.Context, eventID uuid.UUID) (<-chan *domain_ticket.AvailabilityChange, func()) {
+	topic := availabilityTopic{tenant: tenant.FromContext(ctx), eventID: eventID}
+	w := &availabilityWatcher{ch: make(chan *domain_ticket.AvailabilityChange, availabilityWatchBuffer)}
+
+	f.mu.Lock()
+	if f.watchers[topic] == nil {
+		f.watchers[topic] = make(map[*availabilityWatcher]struct{})
+	}
+	f.watchers[topic][w] = struct{}{}
+	f.mu.Unlock()
+
+	return w.ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.drop(topic, w)
+	}
+}
+
+// drop closes a watcher's channel and forgets it. Callers hold mu.
+func (f *AvailabilityFeed) drop(topic availabilityTopic, w *availabilityWatcher) {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.ch)
+	delete(f.watchers[topic], w)
+	if len(f.watchers[topic]) == 0 {
+		delete(f.watchers, topic)
+	}
+}
+
+// Run listens until ctx is cancelled, subscribing again a second after
+// the subscription fails. Every watcher is closed on the way out, ending
+// their streams.
+func (f *AvailabilityFeed) Run(ctx context.Context) {
+	defer f.closeAll()
+	for {
+		err := f.feed.Subscribe(ctx, f.deliver)
+		if ctx.Err() != nil {
+			return
+		}
+		f.logger.Warn("Availability feed subscription failed", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// deliver hands a change to the watchers of its event, or to every watcher
+// for a recount of all events. Watchers too far behind to take it are
+// dropped; their clients reconnect and start from a fresh summary.
+func (f *AvailabilityFeed) deliver(ctx context.Context, change *domain_ticket.AvailabilityChange) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for topic, watchers := range f.watchers {
+		if change.EventID != uuid.Nil && topic != (availabilityTopic{tenant: tenant.FromContext(ctx), eventID: change.EventID}) {
+			continue
+		}
+		delivered := change
+		if change.EventID == uuid.Nil {
+			delivered = &domain_ticket.AvailabilityChange{EventID: topic.eventID, Kind: change.Kind, At: time.Now().UTC()}
+		}
+		for w := range watchers {
+			select {
+			case w.ch <- delivered:
+			default:
+				f.logger.Debug("Dropped slow availability watcher", "event_id", topic.eventID)
+				f.drop(topic, w)
+			}
+		}
+	}
+}
+
+func (f *AvailabilityFeed) closeAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for topic, watchers := range f.watchers {
+		for w := range watchers {
+			f.drop(topic, w)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestAvailabilityCountersFollowTakeAndRestore(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	availability := NewAvailabilityUsecase(repos.Ticket, repos.Event, repos.Availability, NewAvailabilityFeed(repos.AvailabilityFeed, utils.NewLogger()), &utils.Config{}, utils.NewLogger())
+	ctx := context.Background()
+
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: time.Now().Add(24 * time.Hour), TotalSeats: 3, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	// Two seats at 50.00 and one at 80.00
+	var tickets []*domain_ticket.Ticket
+	for i, price := range []float64{50, 50, 80} {
+		tkt := &domain_ticket.Ticket{ID: uuid.New(), EventID: event.ID, SeatNumber: i + 1, Status: domain_ticket.TicketStatusAvailable, Price: price}
+		if err := repos.Ticket.Create(ctx, tkt); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+		tickets = append(tickets, tkt)
+	}
+
+	check := func(wantAvailable, wantStandard, wantPremium int64) {
+		t.Helper()
+		got, err := availability.GetAvailability(ctx, event.ID)
+		if err != nil {
+			t.Fatalf("get availability: %v", err)
+		}
+		if got.Available != wantAvailable || got.Tiers[5000] != wantStandard || got.Tiers[8000] != wantPremium {
+			t.Fatalf("availability = %d (tiers %v), want %d (5000:%d 8000:%d)", got.Available, got.Tiers, wantAvailable, wantStandard, wantPremium)
+		}
+	}
+
+	// Counted from the tickets on first read
+	check(3, 2, 1)
+
+	reserved := []uuid.UUID{tickets[0].ID, tickets[2].ID}
+	if err := repos.Ticket.ReserveTickets(ctx, reserved); err != nil {
+		t.Fatalf("reserve tickets: %v", err)
+	}
+	availability.Take(ctx, event.ID, reserved)
+	check(1, 1, 0)
+	if availability.SoldOut(ctx, event.ID) {
+		t.Fatalf("event sold out with a ticket left")
+	}
+
+	// Taking the premium seat again finds its tier short: the counters are
+	// dropped rather than driven negative
+	availability.Take(ctx, event.ID, []uuid.UUID{tickets[2].ID})
+	if _, err := repos.Availability.Get(ctx, event.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("drifted counters kept, err %v", err)
+	}
+	check(1, 1, 0)
+
+	if err := repos.Ticket.ReleaseTickets(ctx, reserved); err != nil {
+		t.Fatalf("release tickets: %v", err)
+	}
+	availability.Restore(ctx, event.ID, reserved)
+	check(3, 2, 1)
+
+	all := []uuid.UUID{tickets[0].ID, tickets[1].ID, tickets[2].ID}
+	availability.Take(ctx, event.ID, all)
+	if !availability.SoldOut(ctx, event.ID) {
+		t.Fatalf("event not sold out with every ticket taken")
+	}
+}
+
+func TestAvailabilitySummaryCountsByTierAndSection(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	availability := NewAvailabilityUsecase(repos.Ticket, repos.Event, repos.Availability, NewAvailabilityFeed(repos.AvailabilityFeed, utils.NewLogger()), &utils.Config{SeatMapRowSize: 2}, utils.NewLogger())
+	ctx := context.Background()
+
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: time.Now().Add(24 * time.Hour), TotalSeats: 5, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	// Rows of two seats: 1-2 at 80.00, 3-5 at 50.00
+	for i, tkt := range []struct {
+		price  float64
+		status domain_ticket.TicketStatus
+	}{
+		{80, domain_ticket.TicketStatusSold},
+		{80, domain_ticket.TicketStatusAvailable},
+		{50, domain_ticket.TicketStatusReserved},
+		{50, domain_ticket.TicketStatusAvailable},
+		{50, domain_ticket.TicketStatusHeld},
+	} {
+		if err := repos.Ticket.Create(ctx, &domain_ticket.Ticket{ID: uuid.New(), EventID: event.ID, SeatNumber: i + 1, Status: tkt.status, Price: tkt.price}); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+	}
+
+	summary, err := availability.GetSummary(ctx, event.ID)
+	if err != nil {
+		t.Fatalf("get summary: %v", err)
+	}
+	if want := (domain_ticket.StatusCounts{Available: 2, Reserved: 1, Sold: 1, Held: 1}); summary.Total != want {
+		t.Errorf("total = %+v, want %+v", summary.Total, want)
+	}
+	wantTiers := []domain_ticket.TierCounts{
+		{Price: 50, StatusCounts: domain_ticket.StatusCounts{Available: 1, Reserved: 1, Held: 1}},
+		{Price: 80, StatusCounts: domain_ticket.StatusCounts{Available: 1, Sold: 1}},
+	}
+	if len(summary.Tiers) != len(wantTiers) || summary.Tiers[0] != wantTiers[0] || summary.Tiers[1] != wantTiers[1] {
+		t.Errorf("tiers = %+v, want %+v", summary.Tiers, wantTiers)
+	}
+	wantSections := []domain_ticket.SectionCounts{
+		{Section: 1, FromSeat: 1, ToSeat: 2, StatusCounts: domain_ticket.StatusCounts{Available: 1, Sold: 1}},
+		{Section: 2, FromSeat: 3, ToSeat: 4, StatusCounts: domain_ticket.StatusCounts{Available: 1, Reserved: 1}},
+		{Section: 3, FromSeat: 5, ToSeat: 5, StatusCounts: domain_ticket.StatusCounts{Held: 1}},
+	}
+	if len(summary.Sections) != len(wantSections) {
+		t.Fatalf("sections = %+v, want %+v", summary.Sections, wantSections)
+	}
+	for i := range wantSections {
+		if summary.Sections[i] != wantSections[i] {
+			t.Errorf("section %d = %+v, want %+v", i+1, summary.Sections[i], wantSections[i])
+		}
+	}
+
+	if _, err := availability.GetSummary(ctx, uuid.New()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("summary of unknown event: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestAvailabilityFeedStreamsSeatChanges(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	feed := NewAvailabilityFeed(repos.AvailabilityFeed, logger)
+	availability := NewAvailabilityUsecase(repos.Ticket, repos.Event, repos.Availability, feed, &utils.Config{}, logger)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: time.Now().Add(24 * time.Hour), TotalSeats: 2, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	tkt := &domain_ticket.Ticket{ID: uuid.New(), EventID: event.ID, SeatNumber: 2, Status: domain_ticket.TicketStatusAvailable, Price: 50}
+	if err := repos.Ticket.Create(ctx, tkt); err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+
+	changes, _ := feed.Watch(ctx, event.ID)
+	others, _ := feed.Watch(ctx, uuid.New())
+	stopped := make(chan struct{})
+	go func() {
+		feed.Run(ctx)
+		close(stopped)
+	}()
+
+	next := func() *domain_ticket.AvailabilityChange {
+		t.Helper()
+		select {
+		case change := <-changes:
+			return change
+		case <-time.After(time.Second):
+			t.Fatalf("no availability change")
+			return nil
+		}
+	}
+
+	// Subscribing may have missed changes, so watchers start with a recount
+	if change := next(); change.Kind != domain_ticket.AvailabilityRecounted || change.EventID != event.ID {
+		t.Fatalf("first change = %+v, want a recount of the event", change)
+	}
+	<-others
+
+	availability.Take(ctx, event.ID, []uuid.UUID{tkt.ID})
+	change := next()
+	if change.Kind != domain_ticket.AvailabilityReserved || len(change.SeatNumbers) != 1 || change.SeatNumbers[0] != 2 {
+		t.Fatalf("change = %+v, want seat 2 reserved", change)
+	}
+	availability.Sold(ctx, event.ID, []uuid.UUID{tkt.ID})
+	if change := next(); change.Kind != domain_ticket.AvailabilitySold {
+		t.Fatalf("change = %+v, want sold", change)
+	}
+	select {
+	case change := <-others:
+		t.Fatalf("watcher of another event got %+v", change)
+	default:
+	}
+
+	// Shutting down ends every stream
+	cancel()
+	<-stopped
+	if _, ok := <-changes; ok {
+		t.Fatalf("watch still open after the feed stopped")
+	}
+}
@@ -2,28 +2,100 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
 	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_deadletter "github.com/ojaswiii/booking-manager/src/internal/domain/deadletter"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
 	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
 	"github.com/ojaswiii/booking-manager/src/internal/repository"
 	"github.com/ojaswiii/booking-manager/src/utils"
 	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+	"github.com/ojaswiii/booking-manager/src/utils/requestid"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
 
 	"github.com/google/uuid"
 )
 
+// ErrEventNotBookable is returned when an event is not published
+var ErrEventNotBookable = fmt.Errorf("%w: event is not open for booking", domain.ErrConflict)
+
+// ErrNotBookingOwner is returned when a user acts on another user's booking
+var ErrNotBookingOwner = fmt.Errorf("%w: booking does not belong to user", domain.ErrForbidden)
+
+// ErrBookingNotPending is returned when a booking that has expired, been
+// cancelled or been confirmed is confirmed
+var ErrBookingNotPending = fmt.Errorf("%w: booking is not valid (expired or cancelled)", domain.ErrConflict)
+
+// ErrTooManyQueuedBookings is returned when a user already has as many
+// booking requests waiting as one user may
+var ErrTooManyQueuedBookings = errors.New("too many booking requests waiting for this user")
+
+// ErrBookingQueueFull is returned when the queue a booking request would
+// join has no room and the overload policy cannot take it either
+var ErrBookingQueueFull = errors.New("booking queue is full")
+
+// ErrBookingOverloaded is returned when new bookings are shed to protect the queue latency SLA
+var ErrBookingOverloaded = errors.New("booking system is overloaded")
+
+// ErrPaymentUnderReview is returned when a booking was put in review instead of being confirmed
+var ErrPaymentUnderReview = errors.New("payment is under review")
+
 type BookingUsecase struct {
-	bookingRepo repository.BookingRepository
-	ticketRepo  repository.TicketRepository
-	eventRepo   repository.EventRepository
-	userRepo    repository.UserRepository
-	logger      *utils.Logger
+	bookingRepo    repository.BookingRepository
+	bookingCache   repository.BookingCacheRepository
+	ticketRepo     repository.TicketRepository
+	eventRepo      repository.EventRepository
+	userRepo       repository.UserRepository
+	outboxRepo     repository.OutboxRepository
+	transitionRepo repository.BookingTransitionRepository
+	streamRepo     repository.BookingEventRepository
+	deadLetters    repository.DeadLetterRepository
+	transactor     repository.Transactor
+	queries        repository.QueryStats
+	quotes         *QuoteUsecase
+	gates          *ConfirmationGateRegistry
+	waitingRoom    *WaitingRoomUsecase
+	presale        *PresaleUsecase
+	overload       *concurrency.OverloadPolicy
+	webhooks       *WebhookUsecase
+	notifier       *NotificationUsecase
+	passes         *TicketPassUsecase
+	stats          *EventStatsUsecase
+	availability   *AvailabilityUsecase
+	updates        *BookingUpdateFeed
+	readModels     *ReadModelUsecase
+	logger         *utils.Logger
+
+	// Lookups of bookings that do not exist are remembered this long; 0 for never
+	notFoundTTL time.Duration
+
+	// Booking requests from these users are queued at high priority
+	vipUsers map[uuid.UUID]bool
+
+	// Payments scored at or above reviewRiskScore wait up to reviewTimeout for an admin
+	reviewRiskScore int
+	reviewTimeout   time.Duration
+
+	// Only users who verified their email may confirm bookings
+	requireVerifiedEmail bool
 
 	// Concurrency components
-	processor *concurrency.BookingProcessor
+	processor     *concurrency.BookingProcessor
+	pricing       domain_booking.Pricing
+	drainTimeout  time.Duration
+	requestMaxAge time.Duration // queued requests older than this are skipped; 0 for never
 
 	// Legacy concurrency control (for backward compatibility)
 	bookingMutex sync.RWMutex
@@ -34,70 +106,363 @@ type BookingUsecase struct {
 // NewBookingUsecase creates a new booking usecase
 func NewBookingUsecase(
 	bookingRepo repository.BookingRepository,
+	bookingCache repository.BookingCacheRepository,
 	ticketRepo repository.TicketRepository,
 	eventRepo repository.EventRepository,
 	userRepo repository.UserRepository,
+	outboxRepo repository.OutboxRepository,
+	transitionRepo repository.BookingTransitionRepository,
+	streamRepo repository.BookingEventRepository,
+	deadLetters repository.DeadLetterRepository,
+	transactor repository.Transactor,
+	queries repository.QueryStats,
+	quotes *QuoteUsecase,
+	gates *ConfirmationGateRegistry,
+	waitingRoom *WaitingRoomUsecase,
+	presale *PresaleUsecase,
+	sla *concurrency.SLATracker,
+	overload *concurrency.OverloadPolicy,
+	durable *concurrency.DurableQueue,
+	webhooks *WebhookUsecase,
+	notifications *NotificationUsecase,
+	passes *TicketPassUsecase,
+	stats *EventStatsUsecase,
+	availability *AvailabilityUsecase,
+	updates *BookingUpdateFeed,
+	metrics *BookingMetricsUsecase,
+	readModels *ReadModelUsecase,
+	config *utils.Config,
 	logger *utils.Logger,
 ) *BookingUsecase {
+	pricing := NewBookingPricing(config)
+	dedicatedEvents := make([]uuid.UUID, 0, len(config.BookingDedicatedEventIDs))
+	for _, id := range config.BookingDedicatedEventIDs {
+		eventID, err := uuid.Parse(id)
+		if err != nil {
+			logger.Warn("Ignoring invalid dedicated event ID", "event_id", id)
+			continue
+		}
+		dedicatedEvents = append(dedicatedEvents, eventID)
+	}
+
 	// Initialize the concurrent booking processor
 	processor := concurrency.NewBookingProcessor(
 		bookingRepo,
 		ticketRepo,
 		eventRepo,
 		userRepo,
+		outboxRepo,
+		transitionRepo,
+		streamRepo,
+		deadLetters,
+		transactor,
+		sla,
+		durable,
+		concurrency.RetryPolicy{
+			MaxAttempts: config.BookingRetryMaxAttempts,
+			Backoff:     time.Duration(config.BookingRetryBackoffMs) * time.Millisecond,
+			MaxBackoff:  time.Duration(config.BookingRetryMaxBackoffMs) * time.Millisecond,
+		},
+		concurrency.ProcessorConfig{
+			QueueCount:          config.BookingQueueCount,
+			QueueBufferSize:     config.BookingQueueBufferSize,
+			MaxQueuedPerUser:    config.BookingQueueMaxPerUser,
+			TicketLockTTL:       time.Duration(config.TicketLockTTLSeconds) * time.Second,
+			EventLockTTL:        time.Duration(config.EventLockTTLSeconds) * time.Second,
+			EventLockMaxIdle:    time.Duration(config.EventLockMaxIdleSeconds) * time.Second,
+			LockCleanupInterval: time.Duration(config.LockCleanupIntervalSeconds) * time.Second,
+			ProcessingTimeout:   time.Duration(config.BookingProcessingTimeoutMs) * time.Millisecond,
+			Pricing:             pricing,
+			Dedicated: concurrency.DedicatedQueueConfig{
+				EventIDs: dedicatedEvents,
+				HotSlots: config.BookingHotEventQueues,
+				HotRate:  config.BookingHotEventRate,
+				Window:   time.Duration(config.BookingHotEventWindowSeconds) * time.Second,
+			},
+			EnqueueOnly: !config.BookingProcessorEnabled,
+		},
+		concurrency.WorkerPoolConfig{
+			MinWorkers:     config.BookingWorkersMin,
+			MaxWorkers:     config.BookingWorkersMax,
+			ScaleUpDepth:   config.BookingWorkersScaleUpDepth,
+			ScaleUpLatency: time.Duration(config.BookingWorkersScaleUpLatencyMs) * time.Millisecond,
+			IdleTimeout:    time.Duration(config.BookingWorkersIdleSeconds) * time.Second,
+			Interval:       time.Duration(config.BookingWorkersScaleIntervalMs) * time.Millisecond,
+		},
 		logger,
 	)
+	processor.OnBookingCreated(func(ctx context.Context, requestID string, booking *domain_booking.Booking) {
+		webhooks.Publish(ctx, domain_webhook.EventBookingCreated, booking)
+		stats.BookingCreated(ctx, booking)
+		availability.Take(ctx, booking.EventID, booking.TicketIDs)
+		updates.BookingCreated(ctx, requestID, booking)
+	})
+	processor.OnRequestFailed(updates.RequestFailed)
+	if metrics != nil {
+		processor.SetMetrics(metrics)
+	}
+
+	vipUsers := make(map[uuid.UUID]bool, len(config.BookingVIPUserIDs))
+	for _, id := range config.BookingVIPUserIDs {
+		userID, err := uuid.Parse(id)
+		if err != nil {
+			logger.Warn("Ignoring invalid VIP user ID", "user_id", id)
+			continue
+		}
+		vipUsers[userID] = true
+	}
 
 	return &BookingUsecase{
-		bookingRepo: bookingRepo,
-		ticketRepo:  ticketRepo,
-		eventRepo:   eventRepo,
-		userRepo:    userRepo,
-		logger:      logger,
-		processor:   processor,
-		eventLocks:  make(map[uuid.UUID]*sync.Mutex),
+		bookingRepo:    bookingRepo,
+		bookingCache:   bookingCache,
+		ticketRepo:     ticketRepo,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		outboxRepo:     outboxRepo,
+		transitionRepo: transitionRepo,
+		streamRepo:     streamRepo,
+		deadLetters:    deadLetters,
+		transactor:     transactor,
+		queries:        queries,
+		quotes:         quotes,
+		gates:          gates,
+		waitingRoom:    waitingRoom,
+		presale:        presale,
+		overload:       overload,
+		webhooks:       webhooks,
+		notifier:       notifications,
+		passes:         passes,
+		stats:          stats,
+		availability:   availability,
+		updates:        updates,
+		readModels:     readModels,
+		logger:         logger,
+		processor:      processor,
+		eventLocks:     make(map[uuid.UUID]*sync.Mutex),
+		vipUsers:       vipUsers,
+		notFoundTTL:    time.Duration(config.CacheNotFoundTTLMs) * time.Millisecond,
+
+		reviewRiskScore:      config.PaymentReviewRiskScore,
+		reviewTimeout:        time.Duration(config.PaymentReviewTimeoutMinutes) * time.Minute,
+		requireVerifiedEmail: config.RequireVerifiedEmail,
+		pricing:              pricing,
+		drainTimeout:         time.Duration(config.BookingDrainTimeoutSeconds) * time.Second,
+		requestMaxAge:        time.Duration(config.BookingRequestMaxAgeSeconds) * time.Second,
+	}
+}
+
+// NewBookingSLATracker creates the queue latency SLA tracker from configuration
+func NewBookingSLATracker(config *utils.Config, logger *utils.Logger) *concurrency.SLATracker {
+	return concurrency.NewSLATracker(concurrency.SLAConfig{
+		Target:     time.Duration(config.BookingSLATargetMs) * time.Millisecond,
+		Percentile: config.BookingSLAPercentile,
+		Window:     time.Duration(config.BookingSLAWindowSeconds) * time.Second,
+		MinSamples: config.BookingSLAMinSamples,
+		Shed:       config.BookingSLAShedEnabled,
+	}, logger)
+}
+
+// NewLoadShedder creates the HTTP load shedder from configuration
+func NewLoadShedder(config *utils.Config, probes concurrency.HealthProbes, logger *utils.Logger) *concurrency.LoadShedder {
+	return concurrency.NewLoadShedder(concurrency.LoadShedConfig{
+		Enabled:        config.LoadShedEnabled,
+		Interval:       time.Duration(config.LoadShedIntervalMs) * time.Millisecond,
+		DBLatency:      time.Duration(config.LoadShedDBLatencyMs) * time.Millisecond,
+		QueueFraction:  config.LoadShedQueueFraction,
+		MemoryBytes:    uint64(max(config.LoadShedMemoryMB, 0)) << 20,
+		LowPriorityAt:  config.LoadShedLowPriorityAt,
+		ProbeTimeout:   time.Second,
+		RetryAfterSecs: config.LoadShedRetryAfterSeconds,
+	}, probes, logger)
+}
+
+// NewBookingPricing creates the pricing applied to every booking from
+// configuration
+func NewBookingPricing(config *utils.Config) domain_booking.Pricing {
+	pricing := domain_booking.Pricing{
+		ServiceFeePercent: config.QuoteServiceFeePercent,
+		TaxPercent:        config.QuoteTaxPercent,
+	}
+	for tickets, percent := range config.BookingVolumeDiscounts {
+		pricing.VolumeDiscounts = append(pricing.VolumeDiscounts, domain_booking.VolumeDiscount{MinTickets: tickets, Percent: percent})
+	}
+	return pricing
+}
+
+// NewBookingOverloadPolicy creates the queue overload policy from configuration
+func NewBookingOverloadPolicy(config *utils.Config, logger *utils.Logger) (*concurrency.OverloadPolicy, error) {
+	action, err := concurrency.ParseOverloadAction(config.BookingOverloadAction)
+	if err != nil {
+		return nil, err
+	}
+	fullQueue, err := concurrency.ParseFullQueueAction(config.BookingQueueFullAction)
+	if err != nil {
+		return nil, err
 	}
+	return concurrency.NewOverloadPolicy(concurrency.OverloadPolicyConfig{
+		MaxQueueDepth: config.BookingQueueMaxDepth,
+		MaxQueueWait:  time.Duration(config.BookingQueueMaxWaitMs) * time.Millisecond,
+		Action:        action,
+		MaxFallbacks:  config.BookingFallbackMaxInFlight,
+		FullQueue:     fullQueue,
+		FullQueueWait: time.Duration(config.BookingQueueFullWaitMs) * time.Millisecond,
+	}, logger), nil
+}
+
+// NewBookingDurableQueue configures the durable store behind the booking
+// queues, or returns nil when requests are queued in memory
+func NewBookingDurableQueue(store repository.BookingQueueRepository, config *utils.Config) (*concurrency.DurableQueue, error) {
+	switch config.BookingQueueBackend {
+	case "memory":
+		return nil, nil
+	case "redis":
+	default:
+		return nil, fmt.Errorf("unknown booking queue backend %q", config.BookingQueueBackend)
+	}
+
+	consumer := config.BookingQueueConsumer
+	if consumer == "" {
+		host, _ := os.Hostname()
+		consumer = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	return &concurrency.DurableQueue{
+		Store:     store,
+		Consumer:  consumer,
+		ClaimIdle: time.Duration(config.BookingQueueClaimIdleSeconds) * time.Second,
+	}, nil
 }
 
 // CreateBookingRequest represents a request to create a booking
 type CreateBookingRequest struct {
-	UserID    uuid.UUID   `json:"user_id"`
-	EventID   uuid.UUID   `json:"event_id"`
-	TicketIDs []uuid.UUID `json:"ticket_ids"`
+	UserID     uuid.UUID   `json:"user_id"`
+	EventID    uuid.UUID   `json:"event_id"`
+	TicketIDs  []uuid.UUID `json:"ticket_ids"`
+	QuoteToken string      `json:"quote_token,omitempty"` // guarantees the quoted price
+	Currency   string      `json:"currency,omitempty"`    // charge currency, the base currency if empty
+
+	WaitingRoomToken string `json:"waiting_room_token,omitempty"` // required when the event has a waiting room
 }
 
 // CreateBookingResponse represents the response of creating a booking
 type CreateBookingResponse struct {
 	BookingID   uuid.UUID `json:"booking_id"`
 	TotalAmount float64   `json:"total_amount"`
+	Currency    string    `json:"currency"`
 	ExpiresAt   string    `json:"expires_at"`
 	Status      string    `json:"status"`
+	// Set for queued bookings; watch it for the outcome at /api/bookings/updates
+	RequestID string `json:"request_id,omitempty"`
 }
 
 // CreateBooking creates a new booking using the concurrent processor
 func (b *BookingUsecase) CreateBooking(ctx context.Context, req CreateBookingRequest) (*CreateBookingResponse, error) {
+	// Reject bookings for events that are not live before queueing
+	event, err := b.eventRepo.GetByID(ctx, req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+	presaleAccess, err := b.checkBookingAccess(ctx, event, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// A sold out event is turned away from the cached counters, without
+	// queueing a request bound to fail
+	if b.availability.SoldOut(ctx, req.EventID) {
+		return nil, ErrEventSoldOut
+	}
+
+	// While the queue is breaching its SLA, only presale holders and users
+	// admitted from a waiting room get through; everyone else is sent to queue
+	if !presaleAccess && req.WaitingRoomToken == "" && b.processor.ShouldShed() {
+		b.logger.Warn("Shedding booking request", "event_id", req.EventID, "user_id", req.UserID)
+		return nil, ErrBookingOverloaded
+	}
+
+	// The exchange rate is fixed now; the processor prices the tickets with it
+	currency, rate, err := b.quotes.LockCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Estimate the total from current prices; the processor locks them in
+	tickets, err := b.ticketRepo.GetByIDs(ctx, req.TicketIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+	estimate := &domain_booking.Booking{Currency: currency, ExchangeRate: rate}
+	totalAmount := b.pricing.Price(estimate.LockPrices(tickets), len(tickets)).Total
+
+	// Honour a previously issued quote if one is supplied
+	var quotedTotal float64
+	if req.QuoteToken != "" {
+		claims, err := b.quotes.VerifyQuote(req.QuoteToken, req.UserID, req.EventID, req.TicketIDs)
+		if err != nil {
+			return nil, err
+		}
+		quotedTotal = claims.Total
+		totalAmount = estimate.Convert(claims.Total)
+	}
+
 	// Create booking request for the processor
 	bookingReq := concurrency.BookingRequest{
-		ID:        uuid.New().String(),
-		UserID:    req.UserID,
-		EventID:   req.EventID,
-		TicketIDs: req.TicketIDs,
-		Timestamp: time.Now(),
-		Priority:  1,
+		ID:            uuid.New().String(),
+		UserID:        req.UserID,
+		EventID:       req.EventID,
+		TicketIDs:     req.TicketIDs,
+		QuotedTotal:   quotedTotal,
+		Currency:      currency,
+		ExchangeRate:  rate,
+		PresaleAccess: presaleAccess,
+		TenantID:      tenant.FromContext(ctx),
+		Timestamp:     time.Now(),
+		TraceID:       requestid.FromContext(ctx),
+		Priority:      b.priorityFor(req.UserID),
 	}
+	bookingReq.Deadline = b.deadlineFor(ctx, bookingReq.Timestamp)
 
-	// Enqueue the request
-	if err := b.processor.EnqueueBookingRequest(bookingReq); err != nil {
-		return nil, fmt.Errorf("failed to enqueue booking request: %w", err)
+	// A saturated queue is handled by the overload policy instead of
+	// letting requests wait indefinitely
+	decision := b.overload.Decide(b.processor.QueueLoad(req.EventID))
+	queueFull := false
+	if decision.Action == "" {
+		err := b.processor.EnqueueBookingRequestWithin(bookingReq, b.overload.EnqueueWait())
+		if err == nil {
+			return &CreateBookingResponse{
+				BookingID:   uuid.New(), // Temporary, will be updated when processed
+				RequestID:   bookingReq.ID,
+				TotalAmount: totalAmount,
+				Currency:    currency,
+				ExpiresAt:   time.Now().Add(15 * time.Minute).Format("2006-01-02T15:04:05Z"),
+				Status:      "pending",
+			}, nil
+		}
+		if errors.Is(err, concurrency.ErrUserQueueFull) {
+			return nil, ErrTooManyQueuedBookings
+		}
+		// A full or draining queue is treated alike
+		if !errors.Is(err, concurrency.ErrQueueFull) && !errors.Is(err, concurrency.ErrDraining) {
+			return nil, fmt.Errorf("failed to enqueue booking request: %w", err)
+		}
+		queueFull = errors.Is(err, concurrency.ErrQueueFull)
+		decision = b.overload.Decide(concurrency.QueueLoad{Full: true})
 	}
 
-	// Return immediate response
-	return &CreateBookingResponse{
-		BookingID:   uuid.New(), // Temporary, will be updated when processed
-		TotalAmount: float64(len(req.TicketIDs)) * 50.0,
-		ExpiresAt:   time.Now().Add(15 * time.Minute).Format("2006-01-02T15:04:05Z"),
-		Status:      "pending",
-	}, nil
+	if decision.Action == concurrency.OverloadActionReject {
+		b.logger.Warn("Rejecting booking request", "event_id", req.EventID, "user_id", req.UserID, "reason", decision.Reason)
+		if queueFull {
+			return nil, ErrBookingQueueFull
+		}
+		return nil, ErrBookingOverloaded
+	}
+
+	// Fall back to booking synchronously; access was already checked above
+	defer b.overload.ReleaseFallback()
+	b.logger.Info("Booking synchronously", "event_id", req.EventID, "user_id", req.UserID, "reason", decision.Reason)
+	if _, err := b.userRepo.GetByID(ctx, req.UserID); err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	return b.createBookingSync(ctx, req)
 }
 
 // CreateBookingLegacy creates a new booking with legacy concurrency control (for comparison)
@@ -115,9 +480,18 @@ func (b *BookingUsecase) CreateBookingLegacy(ctx context.Context, req CreateBook
 		return nil, fmt.Errorf("event not found: %w", err)
 	}
 	if event == nil {
-		return nil, fmt.Errorf("event is not valid for booking")
+		return nil, fmt.Errorf("%w: event is not valid for booking", domain.ErrNotFound)
+	}
+	if _, err := b.checkBookingAccess(ctx, event, req); err != nil {
+		return nil, err
 	}
 
+	return b.createBookingSync(ctx, req)
+}
+
+// createBookingSync reserves the tickets and creates the booking in the
+// request goroutine, serialised per event
+func (b *BookingUsecase) createBookingSync(ctx context.Context, req CreateBookingRequest) (*CreateBookingResponse, error) {
 	// Get event-specific lock
 	eventLock := b.getEventLock(req.EventID)
 	eventLock.Lock()
@@ -136,7 +510,6 @@ func (b *BookingUsecase) CreateBookingLegacy(ctx context.Context, req CreateBook
 	}
 
 	var selectedTickets []*domain_ticket.Ticket
-	var totalAmount float64
 
 	for _, ticketID := range req.TicketIDs {
 		ticket, exists := availableTicketMap[ticketID]
@@ -144,36 +517,61 @@ func (b *BookingUsecase) CreateBookingLegacy(ctx context.Context, req CreateBook
 			return nil, fmt.Errorf("ticket %s is not available", ticketID)
 		}
 		selectedTickets = append(selectedTickets, ticket)
-		totalAmount += ticket.Price
 	}
 
-	// Reserve tickets atomically
+	currency, rate, err := b.quotes.LockCurrency(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
 	ticketIDs := make([]uuid.UUID, len(selectedTickets))
 	for i, ticket := range selectedTickets {
 		ticketIDs[i] = ticket.ID
 	}
 
-	if err := b.ticketRepo.ReserveTickets(ctx, ticketIDs); err != nil {
-		return nil, fmt.Errorf("failed to reserve tickets: %w", err)
+	// Create booking, locking in the current ticket prices and exchange rate
+	booking := &domain_booking.Booking{
+		ID:           uuid.New(),
+		UserID:       req.UserID,
+		EventID:      req.EventID,
+		TicketIDs:    ticketIDs,
+		Status:       domain_booking.BookingStatusPending,
+		Currency:     currency,
+		ExchangeRate: rate,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(15 * time.Minute), // 15 minutes expiry
 	}
+	totalAmount := b.pricing.Price(booking.LockPrices(selectedTickets), len(selectedTickets)).Total
 
-	// Create booking
-	booking := &domain_booking.Booking{
-		ID:          uuid.New(),
-		UserID:      req.UserID,
-		EventID:     req.EventID,
-		TicketIDs:   ticketIDs,
-		Status:      domain_booking.BookingStatusPending,
-		TotalAmount: totalAmount,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		ExpiresAt:   time.Now().Add(15 * time.Minute), // 15 minutes expiry
+	// A valid quote guarantees the price the customer was shown
+	if req.QuoteToken != "" {
+		claims, err := b.quotes.VerifyQuote(req.QuoteToken, req.UserID, req.EventID, req.TicketIDs)
+		if err != nil {
+			return nil, err
+		}
+		totalAmount = booking.Convert(claims.Total)
+	}
+	booking.TotalAmount = totalAmount
+	if err := booking.RecordCreated(domain_booking.ActorCustomer); err != nil {
+		return nil, err
 	}
 
-	if err := b.bookingRepo.Create(ctx, booking); err != nil {
-		// Release tickets if booking save fails
-		b.ticketRepo.ReleaseTickets(ctx, ticketIDs)
-		return nil, fmt.Errorf("failed to save booking: %w", err)
+	// Reserve tickets and save the booking atomically
+	err = b.withEvents(ctx, booking, domain_outbox.EventBookingCreated, domain_outbox.EventInventoryReserved, func(ctx context.Context) error {
+		if err := b.ticketRepo.ReserveTickets(ctx, ticketIDs); err != nil {
+			return fmt.Errorf("failed to reserve tickets: %w", err)
+		}
+		if err := b.bookingRepo.Create(ctx, booking); err != nil {
+			return fmt.Errorf("failed to save booking: %w", err)
+		}
+		if err := b.storeChanges(ctx, booking); err != nil {
+			return err
+		}
+		return b.recordTransition(ctx, booking, "", domain_booking.ActorCustomer, "booked")
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	b.logger.Info("Booking created successfully",
@@ -182,21 +580,58 @@ func (b *BookingUsecase) CreateBookingLegacy(ctx context.Context, req CreateBook
 		"event_id", req.EventID,
 		"tickets", len(ticketIDs))
 
+	b.webhooks.Publish(ctx, domain_webhook.EventBookingCreated, booking)
+	b.stats.BookingCreated(ctx, booking)
+	b.availability.Take(ctx, booking.EventID, ticketIDs)
+
 	return &CreateBookingResponse{
 		BookingID:   booking.ID,
 		TotalAmount: totalAmount,
+		Currency:    booking.Currency,
 		ExpiresAt:   booking.ExpiresAt.Format("2006-01-02T15:04:05Z"),
 		Status:      string(booking.Status),
 	}, nil
 }
 
+// checkBookingAccess verifies the user may book the event right now. During a
+// presale only code holders get through; during general sale the waiting room
+// (if enabled) decides. It reports whether access was granted via presale.
+func (b *BookingUsecase) checkBookingAccess(ctx context.Context, event *domain_event.Event, req CreateBookingRequest) (bool, error) {
+	if event.IsPresaleOpen() {
+		if err := b.presale.CheckAccess(ctx, event.ID, req.UserID); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	if !event.IsBookable() {
+		return false, fmt.Errorf("%w: %s", ErrEventNotBookable, event.NotBookableReason())
+	}
+
+	// Only users admitted from the waiting room may book. Tokens are also
+	// checked when the room was opened as overflow during an SLA breach.
+	if event.WaitingRoomEnabled || req.WaitingRoomToken != "" {
+		if err := b.waitingRoom.CheckAdmitted(ctx, event.ID, req.UserID, req.WaitingRoomToken); err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
 // ConfirmBookingRequest represents a request to confirm a booking
 type ConfirmBookingRequest struct {
-	BookingID uuid.UUID `json:"booking_id"`
-	UserID    uuid.UUID `json:"user_id"`
+	BookingID    uuid.UUID         `json:"booking_id"`
+	UserID       uuid.UUID         `json:"user_id"`
+	Attestations map[string]string `json:"attestations,omitempty"`
+
+	PaymentReference string `json:"payment_reference,omitempty"` // payment provider's reference, needed for refunds
 }
 
-// ConfirmBooking confirms a booking and marks tickets as sold
+// ConfirmBooking confirms a booking and marks tickets as sold. A booking
+// whose payment was scored as high-risk goes to review instead, and
+// ErrPaymentUnderReview is returned. With REQUIRE_VERIFIED_EMAIL, users
+// must have verified their email first.
 func (b *BookingUsecase) ConfirmBooking(ctx context.Context, req ConfirmBookingRequest) error {
 	booking, err := b.bookingRepo.GetByID(ctx, req.BookingID)
 	if err != nil {
@@ -204,31 +639,299 @@ func (b *BookingUsecase) ConfirmBooking(ctx context.Context, req ConfirmBookingR
 	}
 
 	if booking.UserID != req.UserID {
-		return fmt.Errorf("unauthorized: booking does not belong to user")
+		return ErrNotBookingOwner
 	}
 
 	if booking.Status != domain_booking.BookingStatusPending {
-		return fmt.Errorf("booking is not valid (expired or cancelled)")
+		return ErrBookingNotPending
 	}
 
-	// Confirm booking
-	booking.Status = domain_booking.BookingStatusConfirmed
-	booking.UpdatedAt = time.Now()
+	if b.requireVerifiedEmail {
+		user, err := b.userRepo.GetByID(ctx, booking.UserID)
+		if err != nil {
+			return fmt.Errorf("user not found: %w", err)
+		}
+		if !user.EmailVerified() {
+			return domain_user.ErrEmailUnverified
+		}
+	}
 
-	// Confirm tickets
-	if err := b.ticketRepo.ConfirmTickets(ctx, booking.TicketIDs); err != nil {
-		return fmt.Errorf("failed to confirm tickets: %w", err)
+	// Run the organizer's confirmation gates
+	event, err := b.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return fmt.Errorf("event not found: %w", err)
+	}
+	if err := b.gates.Evaluate(ctx, GateInput{
+		Booking:      booking,
+		Event:        event,
+		Attestations: req.Attestations,
+	}); err != nil {
+		return err
 	}
 
-	// Update booking in repository
-	if err := b.bookingRepo.Update(ctx, booking); err != nil {
-		return fmt.Errorf("failed to update booking: %w", err)
+	if req.PaymentReference != "" {
+		err := booking.Record(domain_booking.PaymentReceived, domain_booking.ActorCustomer, time.Now(), domain_booking.PaymentData{
+			PaymentReference: &req.PaymentReference,
+			RiskScore:        booking.RiskScore,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if b.needsReview(booking) {
+		if err := b.holdForReview(ctx, booking, domain_booking.ActorCustomer); err != nil {
+			return err
+		}
+		return ErrPaymentUnderReview
+	}
+	return b.confirm(ctx, booking, domain_booking.ActorCustomer, "confirmed")
+}
+
+// confirm confirms a booking, sells its tickets and issues their passes
+func (b *BookingUsecase) confirm(ctx context.Context, booking *domain_booking.Booking, actor domain_booking.Actor, reason string) error {
+	from := booking.Status
+	if err := booking.Record(domain_booking.BookingConfirmed, actor, time.Now(), nil); err != nil {
+		return err
+	}
+
+	// Confirm tickets and update the booking atomically
+	err := b.withEvents(ctx, booking, domain_outbox.EventBookingConfirmed, domain_outbox.EventInventorySold, func(ctx context.Context) error {
+		if err := b.ticketRepo.ConfirmTickets(ctx, booking.TicketIDs); err != nil {
+			return fmt.Errorf("failed to confirm tickets: %w", err)
+		}
+		if err := b.bookingRepo.Update(ctx, booking); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		if err := b.storeChanges(ctx, booking); err != nil {
+			return err
+		}
+		if err := b.passes.Issue(ctx, booking); err != nil {
+			return fmt.Errorf("failed to issue ticket passes: %w", err)
+		}
+		return b.recordTransition(ctx, booking, from, actor, reason)
+	})
+	if err != nil {
+		return err
 	}
 
 	b.logger.Info("Booking confirmed successfully",
 		"booking_id", booking.ID,
-		"user_id", req.UserID)
+		"user_id", booking.UserID)
+
+	b.webhooks.Publish(ctx, domain_webhook.EventBookingConfirmed, booking)
+	b.stats.BookingConfirmed(ctx, booking)
+	b.availability.Sold(ctx, booking.EventID, booking.TicketIDs)
+	b.updates.BookingChanged(ctx, booking, domain_booking.RequestConfirmed, "")
+	b.notifier.Notify(ctx, domain_notification.KindBookingConfirmed, booking)
+
+	return nil
+}
+
+// RecordPaymentRequest represents a successful payment reported by the payment provider
+type RecordPaymentRequest struct {
+	BookingID        uuid.UUID `json:"booking_id"`
+	PaymentReference string    `json:"payment_reference"`
+	RiskScore        *int      `json:"risk_score,omitempty"`
+}
+
+// RecordPayment stores the payment and its risk score on a pending booking.
+// Bookings for events without confirmation gates are confirmed, or sent to
+// review, right away; otherwise the customer's confirmation completes them.
+// Payments for bookings that have moved on are acknowledged and ignored.
+func (b *BookingUsecase) RecordPayment(ctx context.Context, req RecordPaymentRequest) (*domain_booking.Booking, error) {
+	if req.RiskScore != nil && (*req.RiskScore < 0 || *req.RiskScore > 100) {
+		return nil, fmt.Errorf("%w: risk score must be between 0 and 100", domain.ErrInvalidInput)
+	}
+
+	booking, err := b.bookingRepo.GetByID(ctx, req.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != domain_booking.BookingStatusPending {
+		b.logger.Warn("Payment received for booking that is not pending",
+			"booking_id", booking.ID,
+			"status", booking.Status,
+			"payment_reference", req.PaymentReference,
+			"risk_score", req.RiskScore)
+		return booking, nil
+	}
+
+	payment := domain_booking.PaymentData{RiskScore: req.RiskScore}
+	if req.PaymentReference != "" {
+		payment.PaymentReference = &req.PaymentReference
+	}
+	if err := booking.Record(domain_booking.PaymentReceived, domain_booking.ActorPayments, time.Now(), payment); err != nil {
+		return nil, err
+	}
+
+	event, err := b.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case b.needsReview(booking):
+		err = b.holdForReview(ctx, booking, domain_booking.ActorPayments)
+	case len(event.ConfirmationRequirements) == 0:
+		err = b.confirm(ctx, booking, domain_booking.ActorPayments, "paid")
+	default:
+		err = b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			if err := b.bookingRepo.Update(ctx, booking); err != nil {
+				return err
+			}
+			return b.storeChanges(ctx, booking)
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// needsReview reports whether the booking's payment was scored as high-risk
+func (b *BookingUsecase) needsReview(booking *domain_booking.Booking) bool {
+	return booking.RiskScore != nil && *booking.RiskScore >= b.reviewRiskScore
+}
+
+// holdForReview parks a paid booking for an admin to approve or reject. Its
+// tickets stay reserved until the review deadline.
+func (b *BookingUsecase) holdForReview(ctx context.Context, booking *domain_booking.Booking, actor domain_booking.Actor) error {
+	now := time.Now()
+	from := booking.Status
+	if err := booking.Record(domain_booking.BookingHeldForReview, actor, now, domain_booking.ReviewData{ExpiresAt: now.Add(b.reviewTimeout)}); err != nil {
+		return err
+	}
 
+	err := b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := b.bookingRepo.Update(ctx, booking); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		if err := b.storeChanges(ctx, booking); err != nil {
+			return err
+		}
+		if err := b.recordTransition(ctx, booking, from, actor, "payment scored as high-risk"); err != nil {
+			return err
+		}
+		msg, err := domain_outbox.NewMessage(domain_outbox.AggregateBooking, booking.ID, domain_outbox.EventBookingReview, booking)
+		if err != nil {
+			return err
+		}
+		return b.outboxRepo.Append(ctx, msg)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.logger.Warn("Booking held for payment review",
+		"booking_id", booking.ID,
+		"risk_score", *booking.RiskScore,
+		"review_deadline", booking.ExpiresAt)
+
+	b.webhooks.Publish(ctx, domain_webhook.EventBookingReview, booking)
+	return nil
+}
+
+// ListReviews returns the bookings waiting for review, closest to their deadline first
+func (b *BookingUsecase) ListReviews(ctx context.Context) ([]*domain_booking.Booking, error) {
+	return b.bookingRepo.GetByStatus(ctx, domain_booking.BookingStatusReview)
+}
+
+// GetBookingHistory returns every status change of a booking and its
+// tickets, oldest first. The history outlives the booking, so a purged
+// booking's is still returned.
+func (b *BookingUsecase) GetBookingHistory(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.Transition, error) {
+	transitions, err := b.transitionRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking history: %w", err)
+	}
+	if len(transitions) == 0 {
+		// Bookings made before history was kept have none
+		if _, err := b.bookingRepo.GetByID(ctx, bookingID); err != nil {
+			return nil, err
+		}
+	}
+	return transitions, nil
+}
+
+// ApproveReview confirms a booking held for review
+func (b *BookingUsecase) ApproveReview(ctx context.Context, bookingID uuid.UUID) (*domain_booking.Booking, error) {
+	booking, err := b.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != domain_booking.BookingStatusReview {
+		return nil, fmt.Errorf("%w: booking is %s", domain.ErrConflict, booking.Status)
+	}
+	if err := b.confirm(ctx, booking, domain_booking.ActorAdmin, "approved in payment review"); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// RejectReview rejects a booking held for review and releases its tickets.
+// The payment itself is not refunded here; that is settled with the provider.
+func (b *BookingUsecase) RejectReview(ctx context.Context, bookingID uuid.UUID) (*domain_booking.Booking, error) {
+	booking, err := b.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.Status != domain_booking.BookingStatusReview {
+		return nil, fmt.Errorf("%w: booking is %s", domain.ErrConflict, booking.Status)
+	}
+	if err := b.reject(ctx, booking, domain_booking.ActorAdmin, "rejected in payment review"); err != nil {
+		return nil, err
+	}
+	return booking, nil
+}
+
+// RejectExpiredReviews rejects bookings that were not reviewed before their deadline
+func (b *BookingUsecase) RejectExpiredReviews(ctx context.Context) (int, error) {
+	bookings, err := b.bookingRepo.GetByStatus(ctx, domain_booking.BookingStatusReview)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get bookings in review: %w", err)
+	}
+
+	now := time.Now()
+	rejected := 0
+	for _, booking := range bookings {
+		if booking.ExpiresAt.After(now) {
+			break
+		}
+		if err := b.reject(ctx, booking, domain_booking.ActorSystem, "not reviewed in time"); err != nil {
+			b.logger.Warn("Failed to reject unreviewed booking", "booking_id", booking.ID, "error", err)
+			continue
+		}
+		rejected++
+	}
+	return rejected, nil
+}
+
+// reject marks a booking rejected and puts its tickets back on sale
+func (b *BookingUsecase) reject(ctx context.Context, booking *domain_booking.Booking, actor domain_booking.Actor, reason string) error {
+	from := booking.Status
+	if err := booking.Record(domain_booking.BookingRejected, actor, time.Now(), nil); err != nil {
+		return err
+	}
+
+	err := b.withEvents(ctx, booking, domain_outbox.EventBookingRejected, domain_outbox.EventInventoryReleased, func(ctx context.Context) error {
+		if err := b.ticketRepo.ReleaseTickets(ctx, booking.TicketIDs); err != nil {
+			return fmt.Errorf("failed to release tickets: %w", err)
+		}
+		if err := b.bookingRepo.Update(ctx, booking); err != nil {
+			return err
+		}
+		if err := b.storeChanges(ctx, booking); err != nil {
+			return err
+		}
+		return b.recordTransition(ctx, booking, from, actor, reason)
+	})
+	if err != nil {
+		return err
+	}
+
+	b.logger.Info("Booking rejected in payment review", "booking_id", booking.ID)
+	b.availability.Restore(ctx, booking.EventID, booking.TicketIDs)
+	b.updates.BookingChanged(ctx, booking, domain_booking.RequestFailed, "payment was rejected")
+	b.webhooks.Publish(ctx, domain_webhook.EventBookingRejected, booking)
 	return nil
 }
 
@@ -246,37 +949,418 @@ func (b *BookingUsecase) CancelBooking(ctx context.Context, req CancelBookingReq
 	}
 
 	if booking.UserID != req.UserID {
-		return fmt.Errorf("unauthorized: booking does not belong to user")
+		return ErrNotBookingOwner
 	}
 
 	if booking.Status == domain_booking.BookingStatusConfirmed {
-		return fmt.Errorf("confirmed bookings cannot be cancelled")
+		return fmt.Errorf("%w: confirmed bookings cannot be cancelled", domain.ErrConflict)
 	}
 
 	// Cancel booking
-	booking.Status = domain_booking.BookingStatusCancelled
-	booking.UpdatedAt = time.Now()
-
-	// Release tickets
-	if err := b.ticketRepo.ReleaseTickets(ctx, booking.TicketIDs); err != nil {
-		return fmt.Errorf("failed to release tickets: %w", err)
+	from := booking.Status
+	if err := booking.Record(domain_booking.BookingCancelled, domain_booking.ActorCustomer, time.Now(), nil); err != nil {
+		return err
 	}
 
-	// Update booking in repository
-	if err := b.bookingRepo.Update(ctx, booking); err != nil {
-		return fmt.Errorf("failed to update booking: %w", err)
+	// Release tickets and update the booking atomically
+	err = b.withEvents(ctx, booking, domain_outbox.EventBookingCancelled, domain_outbox.EventInventoryReleased, func(ctx context.Context) error {
+		if err := b.ticketRepo.ReleaseTickets(ctx, booking.TicketIDs); err != nil {
+			return fmt.Errorf("failed to release tickets: %w", err)
+		}
+		if err := b.bookingRepo.Update(ctx, booking); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		if err := b.storeChanges(ctx, booking); err != nil {
+			return err
+		}
+		return b.recordTransition(ctx, booking, from, domain_booking.ActorCustomer, "cancelled")
+	})
+	if err != nil {
+		return err
 	}
 
 	b.logger.Info("Booking cancelled successfully",
 		"booking_id", booking.ID,
 		"user_id", req.UserID)
 
+	b.webhooks.Publish(ctx, domain_webhook.EventBookingCancelled, booking)
+	b.stats.BookingCancelled(ctx, booking)
+	b.availability.Restore(ctx, booking.EventID, booking.TicketIDs)
+	b.updates.BookingChanged(ctx, booking, domain_booking.RequestCancelled, "")
+	b.notifier.Notify(ctx, domain_notification.KindBookingCancelled, booking)
+
+	return nil
+}
+
+// SeatChange moves one ticket of a booking to another seat
+type SeatChange struct {
+	TicketID    uuid.UUID `json:"ticket_id"`
+	NewTicketID uuid.UUID `json:"new_ticket_id"`
+}
+
+// ChangeSeatsRequest represents a request to move some tickets of a booking to other seats
+type ChangeSeatsRequest struct {
+	BookingID uuid.UUID    `json:"booking_id"`
+	UserID    uuid.UUID    `json:"user_id"`
+	Changes   []SeatChange `json:"changes"`
+}
+
+// ChangeSeats swaps tickets of a pending or confirmed booking for other
+// available seats on the same event. The new seats are priced as at booking
+// time, in the booking's currency, and the total is adjusted by the
+// difference. Everything happens in one transaction, so a failure leaves the
+// booking on its old seats and no seat reserved or released.
+func (b *BookingUsecase) ChangeSeats(ctx context.Context, req ChangeSeatsRequest) (*domain_booking.Booking, error) {
+	if len(req.Changes) == 0 {
+		return nil, fmt.Errorf("%w: no seat changes given", domain.ErrInvalidInput)
+	}
+
+	booking, err := b.bookingRepo.GetByID(ctx, req.BookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != req.UserID {
+		return nil, domain.ErrNotFound
+	}
+
+	onBooking := make(map[uuid.UUID]bool, len(booking.TicketIDs))
+	for _, id := range booking.TicketIDs {
+		onBooking[id] = true
+	}
+	moved := make(map[uuid.UUID]uuid.UUID, len(req.Changes))
+	taken := make(map[uuid.UUID]bool, len(req.Changes))
+	for _, change := range req.Changes {
+		if !onBooking[change.TicketID] {
+			return nil, fmt.Errorf("%w: ticket %s is not part of this booking", domain.ErrInvalidInput, change.TicketID)
+		}
+		if _, ok := moved[change.TicketID]; ok {
+			return nil, fmt.Errorf("%w: ticket %s is changed twice", domain.ErrInvalidInput, change.TicketID)
+		}
+		if onBooking[change.NewTicketID] || taken[change.NewTicketID] {
+			return nil, fmt.Errorf("%w: ticket %s is already on this booking", domain.ErrInvalidInput, change.NewTicketID)
+		}
+		moved[change.TicketID] = change.NewTicketID
+		taken[change.NewTicketID] = true
+	}
+
+	// Serialize with other bookings for the event so the new seats cannot be
+	// taken between the checks below and the swap
+	lock := b.getEventLock(booking.EventID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	// Re-read the booking under the lock in case it changed meanwhile
+	if booking, err = b.bookingRepo.GetByID(ctx, req.BookingID); err != nil {
+		return nil, err
+	}
+	confirmed := booking.Status == domain_booking.BookingStatusConfirmed
+	if !confirmed && booking.Status != domain_booking.BookingStatusPending {
+		return nil, fmt.Errorf("%w: booking is %s", domain.ErrConflict, booking.Status)
+	}
+	for id := range moved {
+		if !slices.Contains(booking.TicketIDs, id) {
+			return nil, fmt.Errorf("%w: ticket %s is no longer part of this booking", domain.ErrConflict, id)
+		}
+	}
+
+	event, err := b.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.Date.After(time.Now()) {
+		return nil, fmt.Errorf("%w: the event has already started", domain.ErrConflict)
+	}
+
+	items, err := b.bookingRepo.GetItems(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load booking items: %w", err)
+	}
+	prices := make(map[uuid.UUID]float64, len(items))
+	for _, item := range items {
+		prices[item.TicketID] = item.UnitPrice
+	}
+
+	now := time.Now()
+	previousTotal := booking.TotalAmount
+	removed := make([]uuid.UUID, 0, len(moved))
+	added := make([]uuid.UUID, 0, len(moved))
+	newItems := make([]domain_booking.LineItem, 0, len(moved))
+	var delta float64
+	for _, id := range booking.TicketIDs {
+		newID, ok := moved[id]
+		if !ok {
+			continue
+		}
+		ticket, err := b.ticketRepo.GetByID(ctx, newID)
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: ticket %s does not exist", domain.ErrInvalidInput, newID)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if ticket.EventID != booking.EventID {
+			return nil, fmt.Errorf("%w: ticket %s is for another event", domain.ErrInvalidInput, newID)
+		}
+		if ticket.Status != domain_ticket.TicketStatusAvailable {
+			return nil, fmt.Errorf("%w: seat %d is not available", domain.ErrConflict, ticket.SeatNumber)
+		}
+
+		oldPrice, ok := prices[id]
+		if !ok {
+			oldPrice = booking.TotalAmount / float64(len(booking.TicketIDs))
+		}
+		item := domain_booking.LineItem{
+			BookingID:    booking.ID,
+			TicketID:     ticket.ID,
+			SeatNumber:   ticket.SeatNumber,
+			BasePrice:    ticket.Price,
+			UnitPrice:    booking.Convert(ticket.Price),
+			Currency:     booking.Currency,
+			ExchangeRate: booking.ExchangeRate,
+			CreatedAt:    now,
+		}
+		delta += item.UnitPrice - oldPrice
+
+		removed = append(removed, id)
+		added = append(added, newID)
+		newItems = append(newItems, item)
+	}
+	err = booking.Record(domain_booking.SeatsChanged, domain_booking.ActorCustomer, now, domain_booking.SeatsChangedData{
+		Released:    removed,
+		Reserved:    newItems,
+		TotalAmount: domain_booking.RoundCents(max(booking.TotalAmount+delta, 0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	addedEvent := domain_outbox.EventInventoryReserved
+	if confirmed {
+		addedEvent = domain_outbox.EventInventorySold
+	}
+	err = b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := b.ticketRepo.ReserveTickets(ctx, added); err != nil {
+			return fmt.Errorf("%w: new seats could not be reserved: %v", domain.ErrConflict, err)
+		}
+		if confirmed {
+			if err := b.ticketRepo.ConfirmTickets(ctx, added); err != nil {
+				return fmt.Errorf("failed to confirm tickets: %w", err)
+			}
+			if err := b.ticketRepo.RestockTickets(ctx, removed); err != nil {
+				return fmt.Errorf("failed to restock tickets: %w", err)
+			}
+		} else if err := b.ticketRepo.ReleaseTickets(ctx, removed); err != nil {
+			return fmt.Errorf("failed to release tickets: %w", err)
+		}
+
+		if err := b.bookingRepo.Update(ctx, booking); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		if err := b.bookingRepo.SwapItems(ctx, booking.ID, removed, newItems); err != nil {
+			return fmt.Errorf("failed to update booking items: %w", err)
+		}
+		if err := b.storeChanges(ctx, booking); err != nil {
+			return err
+		}
+		if confirmed {
+			if err := b.passes.Reissue(ctx, booking, removed); err != nil {
+				return err
+			}
+		}
+		held := booking.Status.TicketStatus()
+		transitions := append(
+			booking.TicketTransitions(removed, held, domain_ticket.TicketStatusAvailable, domain_booking.ActorCustomer, "seat changed"),
+			booking.TicketTransitions(added, domain_ticket.TicketStatusAvailable, held, domain_booking.ActorCustomer, "seat changed")...)
+		if err := b.transitionRepo.Append(ctx, transitions...); err != nil {
+			return fmt.Errorf("failed to record booking history: %w", err)
+		}
+
+		bookingMsg, err := domain_outbox.NewMessage(domain_outbox.AggregateBooking, booking.ID, domain_outbox.EventBookingModified, booking)
+		if err != nil {
+			return err
+		}
+		releasedMsg, err := domain_outbox.InventoryMessage(domain_outbox.EventInventoryReleased, booking, removed)
+		if err != nil {
+			return err
+		}
+		addedMsg, err := domain_outbox.InventoryMessage(addedEvent, booking, added)
+		if err != nil {
+			return err
+		}
+		return b.outboxRepo.Append(ctx, bookingMsg, releasedMsg, addedMsg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if booking.Items, err = b.bookingRepo.GetItems(ctx, booking.ID); err != nil {
+		return nil, fmt.Errorf("failed to load booking items: %w", err)
+	}
+
+	b.logger.Info("Booking seats changed",
+		"booking_id", booking.ID,
+		"user_id", req.UserID,
+		"seats", len(added),
+		"total_amount", booking.TotalAmount)
+
+	b.webhooks.Publish(ctx, domain_webhook.EventBookingModified, booking)
+	b.availability.Take(ctx, booking.EventID, added)
+	b.availability.Restore(ctx, booking.EventID, removed)
+	if confirmed {
+		b.availability.Sold(ctx, booking.EventID, added)
+		b.stats.BookingRepriced(ctx, booking, previousTotal)
+	}
+
+	return booking, nil
+}
+
+// ExpirePendingBookings expires pending bookings past their hold and releases their tickets
+func (b *BookingUsecase) ExpirePendingBookings(ctx context.Context) (int, error) {
+	bookings, err := b.bookingRepo.GetExpiredBookings(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get expired bookings: %w", err)
+	}
+
+	expired := 0
+	for _, booking := range bookings {
+		from := booking.Status
+		if err := booking.Record(domain_booking.BookingExpired, domain_booking.ActorSystem, time.Now(), nil); err != nil {
+			b.logger.Warn("Failed to expire booking", "booking_id", booking.ID, "error", err)
+			continue
+		}
+		err := b.withEvents(ctx, booking, domain_outbox.EventBookingExpired, domain_outbox.EventInventoryReleased, func(ctx context.Context) error {
+			if err := b.ticketRepo.ReleaseTickets(ctx, booking.TicketIDs); err != nil {
+				return fmt.Errorf("failed to release tickets: %w", err)
+			}
+			if err := b.bookingRepo.Update(ctx, booking); err != nil {
+				return err
+			}
+			if err := b.storeChanges(ctx, booking); err != nil {
+				return err
+			}
+			return b.recordTransition(ctx, booking, from, domain_booking.ActorSystem, "not paid for in time")
+		})
+		if err != nil {
+			b.logger.Warn("Failed to expire booking", "booking_id", booking.ID, "error", err)
+			continue
+		}
+
+		b.webhooks.Publish(ctx, domain_webhook.EventBookingExpired, booking)
+		b.availability.Restore(ctx, booking.EventID, booking.TicketIDs)
+		b.updates.BookingChanged(ctx, booking, domain_booking.RequestExpired, "not paid for in time")
+		expired++
+	}
+
+	return expired, nil
+}
+
+// RunExpiry expires stale pending bookings and rejects bookings left in review
+// past their deadline on each tick until the context is cancelled
+func (b *BookingUsecase) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := b.ExpirePendingBookings(ctx)
+			if err != nil {
+				b.logger.Error("Failed to expire bookings", "error", err)
+				continue
+			}
+			if count > 0 {
+				b.logger.Info("Expired pending bookings", "count", count)
+			}
+
+			rejected, err := b.RejectExpiredReviews(ctx)
+			if err != nil {
+				b.logger.Error("Failed to reject unreviewed bookings", "error", err)
+				continue
+			}
+			if rejected > 0 {
+				b.logger.Info("Rejected unreviewed bookings", "count", rejected)
+			}
+		}
+	}
+}
+
+// withEvents runs fn and records the booking and inventory events for it in
+// one transaction, so the outbox never disagrees with the stored state
+func (b *BookingUsecase) withEvents(ctx context.Context, booking *domain_booking.Booking, bookingEvent, inventoryEvent string, fn func(ctx context.Context) error) error {
+	return b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+		messages, err := domain_outbox.BookingMessages(bookingEvent, inventoryEvent, booking)
+		if err != nil {
+			return err
+		}
+		return b.outboxRepo.Append(ctx, messages...)
+	})
+}
+
+// storeChanges appends the events recorded on the booking to its stream.
+// Call it in the transaction that updates the booking, which is their
+// projection.
+func (b *BookingUsecase) storeChanges(ctx context.Context, booking *domain_booking.Booking) error {
+	if err := b.streamRepo.Append(ctx, booking.Changes...); err != nil {
+		return fmt.Errorf("failed to record booking events: %w", err)
+	}
+	return nil
+}
+
+// recordTransition adds the booking's move from status from, with its
+// tickets, to its history. Call it in the transaction that makes the move.
+func (b *BookingUsecase) recordTransition(ctx context.Context, booking *domain_booking.Booking, from domain_booking.BookingStatus, actor domain_booking.Actor, reason string) error {
+	if err := b.transitionRepo.Append(ctx, booking.Transitions(from, actor, reason)...); err != nil {
+		return fmt.Errorf("failed to record booking history: %w", err)
+	}
 	return nil
 }
 
-// GetUserBookings retrieves all bookings for a user
+// GetBooking retrieves one of a user's bookings
+func (b *BookingUsecase) GetBooking(ctx context.Context, bookingID, userID uuid.UUID) (*domain_booking.Booking, error) {
+	if err := b.bookingCache.CheckNotFound(ctx, bookingID); errors.Is(err, repository.ErrCachedNotFound) {
+		return nil, domain.ErrNotFound
+	}
+
+	booking, err := b.bookingRepo.GetByID(ctx, bookingID)
+	if errors.Is(err, domain.ErrNotFound) && b.notFoundTTL > 0 {
+		if err := b.bookingCache.SetNotFound(ctx, bookingID, b.notFoundTTL); err != nil {
+			b.logger.Warn("Failed to cache missing booking", "booking_id", bookingID, "error", err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Don't reveal other users' bookings
+	if booking.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	if booking.Items, err = b.bookingRepo.GetItems(ctx, booking.ID); err != nil {
+		return nil, fmt.Errorf("failed to load booking items: %w", err)
+	}
+	return booking, nil
+}
+
+// GetUserBookings retrieves all bookings for a user, from their summaries
+// when the read models are enabled
 func (b *BookingUsecase) GetUserBookings(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error) {
-	return b.bookingRepo.GetByUserID(ctx, userID)
+	if b.readModels.Enabled() {
+		return b.readModels.GetUserBookings(ctx, userID)
+	}
+	bookings, err := b.bookingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, booking := range bookings {
+		if booking.Items, err = b.bookingRepo.GetItems(ctx, booking.ID); err != nil {
+			return nil, fmt.Errorf("failed to load booking items: %w", err)
+		}
+	}
+	return bookings, nil
 }
 
 // getEventLock returns a mutex for the specific event
@@ -300,12 +1384,101 @@ func (b *BookingUsecase) getEventLock(eventID uuid.UUID) *sync.Mutex {
 
 // GetConcurrencyStats returns current booking statistics from the processor
 func (b *BookingUsecase) GetConcurrencyStats() map[string]interface{} {
-	return b.processor.GetStats()
+	stats := b.processor.GetStats()
+	stats["overload"] = b.overload.Stats()
+	if b.queries != nil {
+		stats["queries"] = b.queries.Stats()
+	}
+	return stats
+}
+
+// deadlineFor returns when a booking request queued at queuedAt stops being
+// worth processing: the caller's deadline, or the maximum request age if
+// that comes first
+func (b *BookingUsecase) deadlineFor(ctx context.Context, queuedAt time.Time) time.Time {
+	var deadline time.Time
+	if b.requestMaxAge > 0 {
+		deadline = queuedAt.Add(b.requestMaxAge)
+	}
+	if callerDeadline, ok := ctx.Deadline(); ok && (deadline.IsZero() || callerDeadline.Before(deadline)) {
+		deadline = callerDeadline
+	}
+	return deadline
+}
+
+// priorityFor returns the queue priority of a user's booking requests
+func (b *BookingUsecase) priorityFor(userID uuid.UUID) int {
+	if b.vipUsers[userID] {
+		return concurrency.RequestPriorityHigh
+	}
+	return concurrency.RequestPriorityNormal
+}
+
+// ListDeadLetters returns the most recently dead-lettered booking requests
+func (b *BookingUsecase) ListDeadLetters(ctx context.Context, limit int) ([]*domain_deadletter.DeadLetter, error) {
+	if limit <= 0 || limit > 500 {
+		limit = 100
+	}
+	return b.deadLetters.List(ctx, limit)
+}
+
+// GetDeadLetter returns a dead-lettered booking request
+func (b *BookingUsecase) GetDeadLetter(ctx context.Context, id uuid.UUID) (*domain_deadletter.DeadLetter, error) {
+	return b.deadLetters.GetByID(ctx, id)
+}
+
+// RequeueDeadLetter queues a dead-lettered booking request again with a
+// fresh set of attempts. It stays dead-lettered if the queue is full.
+func (b *BookingUsecase) RequeueDeadLetter(ctx context.Context, id uuid.UUID) (*domain_deadletter.DeadLetter, error) {
+	letter, err := b.deadLetters.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	var req concurrency.BookingRequest
+	if err := json.Unmarshal(letter.Request, &req); err != nil {
+		return nil, fmt.Errorf("failed to decode dead-lettered request: %w", err)
+	}
+	req.Attempts = 0
+	req.Timestamp = time.Now()
+	req.Deadline = b.deadlineFor(ctx, req.Timestamp)
+
+	// Deleting first means a concurrent requeue of the same letter finds nothing
+	if err := b.deadLetters.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	if err := b.processor.EnqueueBookingRequest(req); err != nil {
+		if restoreErr := b.deadLetters.Create(ctx, letter); restoreErr != nil {
+			b.logger.Error("Failed to restore dead letter", "dead_letter_id", id, "request_id", req.ID, "error", restoreErr)
+		}
+		if errors.Is(err, concurrency.ErrQueueFull) || errors.Is(err, concurrency.ErrDraining) {
+			return nil, fmt.Errorf("%w: booking queue is not accepting requests", domain.ErrConflict)
+		}
+		return nil, fmt.Errorf("failed to enqueue booking request: %w", err)
+	}
+
+	b.logger.Info("Dead-lettered booking request requeued", "dead_letter_id", id, "request_id", req.ID)
+	return letter, nil
+}
+
+// DiscardDeadLetter deletes a dead-lettered booking request for good
+func (b *BookingUsecase) DiscardDeadLetter(ctx context.Context, id uuid.UUID) error {
+	if err := b.deadLetters.Delete(ctx, id); err != nil {
+		return err
+	}
+	b.logger.Info("Dead-lettered booking request discarded", "dead_letter_id", id)
+	return nil
+}
+
+// QueueFill returns how full the booking processor's queues are
+func (b *BookingUsecase) QueueFill() (pending, capacity int) {
+	return b.processor.QueueFill()
 }
 
-// Shutdown gracefully shuts down the booking usecase and its processor
-func (b *BookingUsecase) Shutdown() {
+// Shutdown gracefully shuts down the booking usecase and its processor,
+// giving queued booking requests up to the drain timeout to be processed
+func (b *BookingUsecase) Shutdown() concurrency.DrainReport {
 	b.logger.Info("Shutting down booking usecase")
-	b.processor.Shutdown()
+	report := b.processor.Shutdown(b.drainTimeout)
 	b.logger.Info("Booking usecase stopped")
+	return report
 }
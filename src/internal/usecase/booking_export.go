@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// BookingExportUsecase exports bookings a page at a time, so an export of
+// any size is never held in memory whole
+type BookingExportUsecase struct {
+	exportRepo repository.BookingExportRepository
+	eventRepo  repository.EventRepository
+	users      *UserUsecase
+	logger     *utils.Logger
+
+	pageSize int
+}
+
+// NewBookingExportUsecase creates a new booking export usecase
+func NewBookingExportUsecase(exportRepo repository.BookingExportRepository, eventRepo repository.EventRepository, users *UserUsecase, config *utils.Config, logger *utils.Logger) *BookingExportUsecase {
+	return &BookingExportUsecase{
+		exportRepo: exportRepo,
+		eventRepo:  eventRepo,
+		users:      users,
+		logger:     logger,
+		pageSize:   max(config.BookingExportPageSize, 1),
+	}
+}
+
+// ExportEventAttendees passes an event's booked tickets to write a page at a
+// time, for organizers and admins. Write is called at least once, with an
+// empty page if there are no bookings, and only once the export is allowed.
+func (u *BookingExportUsecase) ExportEventAttendees(ctx context.Context, userID, eventID uuid.UUID, write func(page []*domain_booking.Attendee) error) error {
+	if _, err := u.users.Authorize(ctx, userID, reportRoles...); err != nil {
+		return err
+	}
+	if _, err := u.eventRepo.GetByID(ctx, eventID); err != nil {
+		return err
+	}
+	return u.export(ctx, domain_booking.AttendeeFilter{EventID: &eventID}, write)
+}
+
+// ExportUserBookings passes a user's booked tickets to write a page at a
+// time, as ExportEventAttendees does
+func (u *BookingExportUsecase) ExportUserBookings(ctx context.Context, userID uuid.UUID, write func(page []*domain_booking.Attendee) error) error {
+	if _, err := u.users.GetUser(ctx, userID); err != nil {
+		return err
+	}
+	return u.export(ctx, domain_booking.AttendeeFilter{UserID: &userID}, write)
+}
+
+// export reads pages until one comes back short
+func (u *BookingExportUsecase) export(ctx context.Context, filter domain_booking.AttendeeFilter, write func(page []*domain_booking.Attendee) error) error {
+	var after *domain_booking.Attendee
+	for {
+		page, err := u.exportRepo.ListAttendees(ctx, filter, after, u.pageSize)
+		if err != nil {
+			return err
+		}
+		if err := write(page); err != nil {
+			return err
+		}
+		if len(page) < u.pageSize {
+			return nil
+		}
+		after = page[len(page)-1]
+	}
+}
@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+)
+
+// bookingMetricsDefaultRange is how far back metrics go when no start is given
+const bookingMetricsDefaultRange = time.Hour
+
+// pendingMinuteKey is one tenant's minute of counts not yet saved
+type pendingMinuteKey struct {
+	tenant string
+	minute time.Time
+}
+
+// BookingMetricsUsecase counts what the booking processor does per minute.
+// Counts are added up in memory and saved every flush interval, so the
+// queue workers never wait on Redis, and kept for the retention so trends
+// survive restarts, unlike the processor's own stats.
+type BookingMetricsUsecase struct {
+	repo   repository.BookingMetricsRepository
+	logger *utils.Logger
+
+	flushInterval time.Duration
+	retention     time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	pending map[pendingMinuteKey]domain_stats.BookingCounts
+}
+
+// NewBookingMetricsUsecase creates a new booking metrics usecase
+func NewBookingMetricsUsecase(repo repository.BookingMetricsRepository, config *utils.Config, logger *utils.Logger) *BookingMetricsUsecase {
+	return &BookingMetricsUsecase{
+		repo:          repo,
+		logger:        logger,
+		flushInterval: time.Duration(max(config.BookingMetricsFlushSeconds, 1)) * time.Second,
+		retention:     time.Duration(max(config.BookingMetricsRetentionDays, 1)) * 24 * time.Hour,
+		now:           time.Now,
+		pending:       make(map[pendingMinuteKey]domain_stats.BookingCounts),
+	}
+}
+
+// RequestReceived counts a request taken up by the processor
+func (m *BookingMetricsUsecase) RequestReceived(ctx context.Context) {
+	m.add(ctx, domain_stats.BookingCounts{Requests: 1})
+}
+
+// BookingSucceeded counts a booking created and its value
+func (m *BookingMetricsUsecase) BookingSucceeded(ctx context.Context, booking *domain_booking.Booking) {
+	m.add(ctx, domain_stats.BookingCounts{Successes: 1, RevenueCents: revenueCents(booking.TotalAmount, booking.ExchangeRate)})
+}
+
+// BookingFailed counts a failed attempt at a booking
+func (m *BookingMetricsUsecase) BookingFailed(ctx context.Context) {
+	m.add(ctx, domain_stats.BookingCounts{Failures: 1})
+}
+
+func (m *BookingMetricsUsecase) add(ctx context.Context, counts domain_stats.BookingCounts) {
+	key := pendingMinuteKey{tenant: tenant.FromContext(ctx), minute: m.now().UTC().Truncate(time.Minute)}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	total := m.pending[key]
+	total.Add(counts)
+	m.pending[key] = total
+}
+
+// Flush saves the counts added since the last flush. Counts that fail to
+// save are kept for the next one. It returns how many minutes were saved.
+func (m *BookingMetricsUsecase) Flush(ctx context.Context) int {
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = make(map[pendingMinuteKey]domain_stats.BookingCounts)
+	m.mu.Unlock()
+
+	saved := 0
+	for key, counts := range pending {
+		minute := &domain_stats.BookingMinute{Minute: key.minute, BookingCounts: counts}
+		if err := m.repo.Add(tenant.WithID(ctx, key.tenant), minute, m.retention); err != nil {
+			m.logger.Warn("Failed to save booking metrics", "tenant", key.tenant, "minute", key.minute, "error", err)
+			m.mu.Lock()
+			total := m.pending[key]
+			total.Add(counts)
+			m.pending[key] = total
+			m.mu.Unlock()
+			continue
+		}
+		saved++
+	}
+	return saved
+}
+
+// Run flushes the counts on each tick until the context is cancelled.
+// Counts added after that are saved by a last Flush once the processor has
+// drained.
+func (m *BookingMetricsUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Flush(ctx)
+		}
+	}
+}
+
+// GetMetrics returns the counts from from until to, by default the last
+// hour, including those not yet saved by this instance. The range may not
+// reach back further than the retention.
+func (m *BookingMetricsUsecase) GetMetrics(ctx context.Context, from, to *time.Time) (*domain_stats.BookingMetrics, error) {
+	now := m.now().UTC()
+	metrics := &domain_stats.BookingMetrics{To: now, Minutes: []domain_stats.BookingMinute{}}
+	if to != nil {
+		metrics.To = to.UTC()
+	}
+	metrics.From = metrics.To.Add(-bookingMetricsDefaultRange)
+	if from != nil {
+		metrics.From = from.UTC()
+	}
+	if !metrics.From.Before(metrics.To) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidInput)
+	}
+	if metrics.From.Before(now.Add(-m.retention)) {
+		return nil, fmt.Errorf("%w: metrics are only kept for %d days", domain.ErrInvalidInput, int(m.retention/(24*time.Hour)))
+	}
+
+	saved, err := m.repo.Range(ctx, metrics.From, metrics.To)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read booking metrics: %w", err)
+	}
+	minutes := make(map[time.Time]domain_stats.BookingCounts, len(saved))
+	for _, minute := range saved {
+		minutes[minute.Minute] = minute.BookingCounts
+	}
+	m.mu.Lock()
+	tenantID := tenant.FromContext(ctx)
+	for key, counts := range m.pending {
+		if key.tenant == tenantID && !key.minute.Before(metrics.From) && key.minute.Before(metrics.To) {
+			total := minutes[key.minute]
+			total.Add(counts)
+			minutes[key.minute] = total
+		}
+	}
+	m.mu.Unlock()
+
+	for start, counts := range minutes {
+		metrics.Totals.Add(counts)
+		metrics.Minutes = append(metrics.Minutes, domain_stats.BookingMinute{Minute: start, BookingCounts: counts})
+	}
+	sort.Slice(metrics.Minutes, func(i, j int) bool { return metrics.Minutes[i].Minute.Before(metrics.Minutes[j].Minute) })
+	return metrics, nil
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+)
+
+func TestBookingMetricsSurviveRestarts(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	config := &utils.Config{BookingMetricsFlushSeconds: 1, BookingMetricsRetentionDays: 1}
+	metrics := NewBookingMetricsUsecase(repos.BookingMetrics, config, utils.NewLogger())
+	at := time.Now().UTC().Truncate(time.Minute).Add(30 * time.Second)
+	metrics.now = func() time.Time { return at }
+	ctx := tenant.WithID(context.Background(), "acme")
+
+	// Charged 55.00 EUR at 1.1 EUR per USD, so 50.00 USD of revenue
+	rate := 1.1
+	metrics.RequestReceived(ctx)
+	metrics.BookingFailed(ctx)
+	metrics.BookingSucceeded(ctx, &domain_booking.Booking{TotalAmount: 55, ExchangeRate: &rate})
+	metrics.RequestReceived(tenant.WithID(context.Background(), "other"))
+
+	want := domain_stats.BookingCounts{Requests: 1, Successes: 1, Failures: 1, RevenueCents: 5000}
+	check := func(metrics *BookingMetricsUsecase) {
+		t.Helper()
+		got, err := metrics.GetMetrics(ctx, nil, nil)
+		if err != nil {
+			t.Fatalf("get metrics: %v", err)
+		}
+		if got.Totals != want || len(got.Minutes) != 1 || got.Minutes[0].BookingCounts != want {
+			t.Fatalf("metrics = %+v, want %+v in one minute", got, want)
+		}
+		if !got.Minutes[0].Minute.Equal(at.Truncate(time.Minute)) {
+			t.Fatalf("minute = %v, want %v", got.Minutes[0].Minute, at.Truncate(time.Minute))
+		}
+	}
+
+	// Counts not yet saved are reported by the instance holding them
+	check(metrics)
+
+	if saved := metrics.Flush(context.Background()); saved != 2 {
+		t.Fatalf("flushed %d minutes, want one per tenant", saved)
+	}
+	check(metrics)
+
+	// A new instance reads them back
+	check(NewBookingMetricsUsecase(repos.BookingMetrics, config, utils.NewLogger()))
+
+	now, hourAgo := time.Now(), time.Now().Add(-time.Hour)
+	if _, err := metrics.GetMetrics(ctx, &now, &hourAgo); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("range ending before it starts: got %v, want ErrInvalidInput", err)
+	}
+	weekAgo := time.Now().Add(-7 * 24 * time.Hour)
+	if _, err := metrics.GetMetrics(ctx, &weekAgo, nil); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("range past the retention: got %v, want ErrInvalidInput", err)
+	}
+}
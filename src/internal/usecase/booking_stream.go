@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+
+	"github.com/google/uuid"
+)
+
+// GetBookingEvents returns a booking's event stream, oldest first. Like the
+// history, the stream outlives the booking.
+func (b *BookingUsecase) GetBookingEvents(ctx context.Context, bookingID uuid.UUID) ([]*domain_booking.BookingEvent, error) {
+	events, err := b.streamRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking events: %w", err)
+	}
+	if len(events) == 0 {
+		// Bookings made before the store was kept have none
+		if _, err := b.bookingRepo.GetByID(ctx, bookingID); err != nil {
+			return nil, err
+		}
+	}
+	return events, nil
+}
+
+// ListBookingEvents returns the events of every booking stored after the
+// given position, oldest first. Consumers read the store in order by passing
+// the position of the last event they saw.
+func (b *BookingUsecase) ListBookingEvents(ctx context.Context, after int64, limit int) ([]*domain_booking.BookingEvent, error) {
+	if after < 0 {
+		return nil, fmt.Errorf("%w: position must not be negative", domain.ErrInvalidInput)
+	}
+	if limit <= 0 || limit > 1000 {
+		limit = 100
+	}
+	return b.streamRepo.ListAfter(ctx, after, limit)
+}
+
+// RebuildBooking replays a booking's stream and writes the result over its
+// row and line items, repairing a projection that drifted from its events.
+// Tickets and passes are left as they are. Bookings made before the stream
+// was kept cannot be rebuilt.
+func (b *BookingUsecase) RebuildBooking(ctx context.Context, bookingID uuid.UUID) (*domain_booking.Booking, error) {
+	events, err := b.GetBookingEvents(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	rebuilt, err := domain_booking.Replay(events)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrConflict, err)
+	}
+
+	if _, err := b.bookingRepo.GetByID(ctx, bookingID); err != nil {
+		return nil, err
+	}
+	items, err := b.bookingRepo.GetItems(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load booking items: %w", err)
+	}
+	stale := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		stale[i] = item.TicketID
+	}
+
+	err = b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := b.bookingRepo.Update(ctx, rebuilt); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		if err := b.bookingRepo.SwapItems(ctx, bookingID, stale, rebuilt.Items); err != nil {
+			return fmt.Errorf("failed to update booking items: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	b.logger.Info("Booking rebuilt from its events",
+		"booking_id", bookingID,
+		"events", len(events),
+		"status", rebuilt.Status)
+	return rebuilt, nil
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestRebuildBookingReplaysItsEvents(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	bookings := &BookingUsecase{
+		bookingRepo: repos.Booking,
+		streamRepo:  repos.BookingEvent,
+		transactor:  repos.Transactor,
+		logger:      utils.NewLogger(),
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	fan := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan"}
+	if err := repos.User.Create(ctx, fan); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: now.Add(24 * time.Hour), TotalSeats: 10, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+
+	kept, moved, seat := uuid.New(), uuid.New(), uuid.New()
+	bk := &domain_booking.Booking{
+		ID:          uuid.New(),
+		UserID:      fan.ID,
+		EventID:     event.ID,
+		TicketIDs:   []uuid.UUID{kept, moved},
+		Status:      domain_booking.BookingStatusPending,
+		TotalAmount: 100,
+		Currency:    "USD",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   now.Add(15 * time.Minute),
+		Items: []domain_booking.LineItem{
+			{TicketID: kept, SeatNumber: 1, UnitPrice: 50, Currency: "USD"},
+			{TicketID: moved, SeatNumber: 2, UnitPrice: 50, Currency: "USD"},
+		},
+	}
+	if err := bk.RecordCreated(domain_booking.ActorCustomer); err != nil {
+		t.Fatalf("record creation: %v", err)
+	}
+	if err := repos.Booking.Create(ctx, bk); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+	if err := bookings.storeChanges(ctx, bk); err != nil {
+		t.Fatalf("store creation: %v", err)
+	}
+
+	// Confirm and move a seat, then lose the changes to the projection
+	bk.Changes = nil
+	reference := "pay_123"
+	if err := bk.Record(domain_booking.PaymentReceived, domain_booking.ActorPayments, now.Add(time.Minute), domain_booking.PaymentData{PaymentReference: &reference}); err != nil {
+		t.Fatalf("record payment: %v", err)
+	}
+	if err := bk.Record(domain_booking.BookingConfirmed, domain_booking.ActorPayments, now.Add(time.Minute), nil); err != nil {
+		t.Fatalf("record confirmation: %v", err)
+	}
+	err := bk.Record(domain_booking.SeatsChanged, domain_booking.ActorCustomer, now.Add(2*time.Minute), domain_booking.SeatsChangedData{
+		Released:    []uuid.UUID{moved},
+		Reserved:    []domain_booking.LineItem{{BookingID: bk.ID, TicketID: seat, SeatNumber: 7, UnitPrice: 80, Currency: "USD"}},
+		TotalAmount: 130,
+	})
+	if err != nil {
+		t.Fatalf("record seat change: %v", err)
+	}
+	if bk.TicketIDs[0] != kept || bk.TicketIDs[1] != seat || bk.TotalAmount != 130 {
+		t.Fatalf("seat change applied as %v totalling %v", bk.TicketIDs, bk.TotalAmount)
+	}
+	if err := bookings.storeChanges(ctx, bk); err != nil {
+		t.Fatalf("store changes: %v", err)
+	}
+
+	rebuilt, err := bookings.RebuildBooking(ctx, bk.ID)
+	if err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if rebuilt.Status != domain_booking.BookingStatusConfirmed || rebuilt.TotalAmount != 130 || *rebuilt.PaymentReference != reference {
+		t.Fatalf("rebuilt: got %+v", rebuilt)
+	}
+	stored, err := repos.Booking.GetByID(ctx, bk.ID)
+	if err != nil {
+		t.Fatalf("get booking: %v", err)
+	}
+	if stored.Status != domain_booking.BookingStatusConfirmed || len(stored.TicketIDs) != 2 || stored.TicketIDs[1] != seat || !stored.UpdatedAt.Equal(now.Add(2*time.Minute)) {
+		t.Fatalf("projection after rebuild: got %+v", stored)
+	}
+	items, err := repos.Booking.GetItems(ctx, bk.ID)
+	if err != nil || len(items) != 2 {
+		t.Fatalf("items after rebuild: got %+v, %v", items, err)
+	}
+	for _, item := range items {
+		if item.TicketID == moved {
+			t.Fatalf("released seat still has a line item: %+v", item)
+		}
+	}
+
+	events, err := bookings.ListBookingEvents(ctx, 2, 0)
+	if err != nil || len(events) != 3 || events[0].Type != domain_booking.PaymentReceived || events[2].Version != 5 {
+		t.Fatalf("events after 2: got %+v, %v", events, err)
+	}
+	if _, err := bookings.RebuildBooking(ctx, uuid.New()); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("rebuild unknown booking: got %v, want ErrNotFound", err)
+	}
+}
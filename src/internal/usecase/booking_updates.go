@@ -0,0 +1,292 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/concurrency"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// bookingWatchBuffer is how many updates a watcher may fall behind by
+	// before it is dropped
+	bookingWatchBuffer = 32
+	// maxWatchedRequests bounds the requests one watcher follows
+	maxWatchedRequests = 100
+)
+
+// ErrTooManyWatchedRequests is returned when a watcher already follows as
+// many requests as it may
+var ErrTooManyWatchedRequests = fmt.Errorf("%w: too many booking requests watched", domain.ErrInvalidInput)
+
+// bookingWatchTopic is one tenant's user
+type bookingWatchTopic struct {
+	tenant string
+	userID uuid.UUID
+}
+
+// BookingWatcher follows some of a user's booking requests, receiving each
+// update to them. Updates may repeat after a reconnect to Redis.
+type BookingWatcher struct {
+	feed  *BookingUpdateFeed
+	topic bookingWatchTopic
+	ch    chan *domain_booking.RequestUpdate
+
+	// Guarded by feed.mu
+	requests map[string]bool
+	closed   bool
+}
+
+// BookingUpdateFeed passes updates to queued booking requests, made on any
+// instance, to the users watching them on this one. It runs on every
+// instance that serves requests.
+type BookingUpdateFeed struct {
+	updates repository.BookingUpdateRepository
+	logger  *utils.Logger
+
+	mu       sync.Mutex
+	watchers map[bookingWatchTopic]map[*BookingWatcher]struct{}
+}
+
+// NewBookingUpdateFeed creates a new booking update feed
+func NewBookingUpdateFeed(updates repository.BookingUpdateRepository, logger *utils.Logger) *BookingUpdateFeed {
+	return &BookingUpdateFeed{
+		updates:  updates,
+		logger:   logger,
+		watchers: make(map[bookingWatchTopic]map[*BookingWatcher]struct{}),
+	}
+}
+
+// Publish records a request's latest update and tells every instance.
+// Failures are logged rather than returned; the booking itself still has
+// the final say.
+func (f *BookingUpdateFeed) Publish(ctx context.Context, update *domain_booking.RequestUpdate) {
+	if update.At.IsZero() {
+		update.At = time.Now().UTC()
+	}
+	if err := f.updates.Publish(ctx, update); err != nil {
+		f.logger.Warn("Failed to publish booking update", "request_id", update.RequestID, "kind", update.Kind, "error", err)
+	}
+}
+
+// BookingCreated reports a request processed into a booking
+func (f *BookingUpdateFeed) BookingCreated(ctx context.Context, requestID string, booking *domain_booking.Booking) {
+	bookingID := booking.ID
+	f.Publish(ctx, &domain_booking.RequestUpdate{
+		RequestID: requestID,
+		UserID:    booking.UserID,
+		Kind:      domain_booking.RequestCreated,
+		BookingID: &bookingID,
+	})
+}
+
+// RequestFailed reports a request that failed for good, without saying
+// more about why than the user needs to know
+func (f *BookingUpdateFeed) RequestFailed(ctx context.Context, req concurrency.BookingRequest, cause error) {
+	update := &domain_booking.RequestUpdate{RequestID: req.ID, UserID: req.UserID, Kind: domain_booking.RequestFailed}
+	switch {
+	case errors.Is(cause, concurrency.ErrRequestExpired):
+		update.Kind = domain_booking.RequestExpired
+		update.Reason = "not processed in time"
+	case errors.Is(cause, domain.ErrConflict):
+		update.Reason = "tickets are no longer available"
+	default:
+		update.Reason = "booking could not be completed"
+	}
+	f.Publish(ctx, update)
+}
+
+// BookingChanged reports a later change to a booking made from a queued
+// request. Bookings made any other way, or long enough ago that their
+// request is forgotten, have nobody watching.
+func (f *BookingUpdateFeed) BookingChanged(ctx context.Context, booking *domain_booking.Booking, kind domain_booking.RequestUpdateKind, reason string) {
+	requestID, err := f.updates.RequestOf(ctx, booking.ID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return
+	}
+	if err != nil {
+		f.logger.Warn("Failed to find booking request", "booking_id", booking.ID, "error", err)
+		return
+	}
+	bookingID := booking.ID
+	f.Publish(ctx, &domain_booking.RequestUpdate{
+		RequestID: requestID,
+		UserID:    booking.UserID,
+		Kind:      kind,
+		BookingID: &bookingID,
+		Reason:    reason,
+	})
+}
+
+// Watch starts following a user's requests; none are followed until added
+// with Follow. Close the watcher when done.
+func (f *BookingUpdateFeed) Watch(ctx context.Context, userID uuid.UUID) *BookingWatcher {
+	w := &BookingWatcher{
+		feed:     f,
+		topic:    bookingWatchTopic{tenant: tenant.FromContext(ctx), userID: userID},
+		ch:       make(chan *domain_booking.RequestUpdate, bookingWatchBuffer),
+		requests: make(map[string]bool),
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.watchers[w.topic] == nil {
+		f.watchers[w.topic] = make(map[*BookingWatcher]struct{})
+	}
+	f.watchers[w.topic][w] = struct{}{}
+	return w
+}
+
+// Updates returns the watcher's updates. The channel is closed when the
+// watcher is closed, falls too far behind, or the feed shuts down.
+func (w *BookingWatcher) Updates() <-chan *domain_booking.RequestUpdate {
+	return w.ch
+}
+
+// Follow adds one of the user's requests. If it already has news, such as
+// a request processed before the client asked, that is sent at once.
+func (w *BookingWatcher) Follow(ctx context.Context, requestID string) error {
+	f := w.feed
+	f.mu.Lock()
+	if w.closed {
+		f.mu.Unlock()
+		return nil
+	}
+	if !w.requests[requestID] && len(w.requests) >= maxWatchedRequests {
+		f.mu.Unlock()
+		return ErrTooManyWatchedRequests
+	}
+	w.requests[requestID] = true
+	f.mu.Unlock()
+
+	latest, err := f.updates.Latest(ctx, requestID)
+	if errors.Is(err, domain.ErrNotFound) {
+		// Still queued
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.send(w, latest)
+	return nil
+}
+
+// Close stops watching and closes the updates channel
+func (w *BookingWatcher) Close() {
+	w.feed.mu.Lock()
+	defer w.feed.mu.Unlock()
+	w.feed.drop(w)
+}
+
+// send hands an update to a watcher following its request, dropping the
+// watcher if it is too far behind to take it. Callers hold mu.
+func (f *BookingUpdateFeed) send(w *BookingWatcher, update *domain_booking.RequestUpdate) {
+	if w.closed || !w.requests[update.RequestID] || update.UserID != w.topic.userID {
+		return
+	}
+	select {
+	case w.ch <- update:
+	default:
+		f.logger.Debug("Dropped slow booking watcher", "user_id", w.topic.userID)
+		f.drop(w)
+	}
+}
+
+// drop closes a watcher's channel and forgets it. Callers hold mu.
+func (f *BookingUpdateFeed) drop(w *BookingWatcher) {
+	if w.closed {
+		return
+	}
+	w.closed = true
+	close(w.ch)
+	delete(f.watchers[w.topic], w)
+	if len(f.watchers[w.topic]) == 0 {
+		delete(f.watchers, w.topic)
+	}
+}
+
+// Run listens until ctx is cancelled, subscribing again a second after
+// the subscription fails. Every watcher is closed on the way out.
+func (f *BookingUpdateFeed) Run(ctx context.Context) {
+	defer f.closeAll()
+	for {
+		err := f.updates.Subscribe(ctx, f.deliver)
+		if ctx.Err() != nil {
+			return
+		}
+		f.logger.Warn("Booking update subscription failed", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// deliver hands an update to the watchers following its request. An empty
+// update means some may have been missed, so every followed request's
+// latest update is sent again.
+func (f *BookingUpdateFeed) deliver(ctx context.Context, update *domain_booking.RequestUpdate) {
+	if update.RequestID == "" {
+		f.resend(ctx)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	topic := bookingWatchTopic{tenant: tenant.FromContext(ctx), userID: update.UserID}
+	for w := range f.watchers[topic] {
+		f.send(w, update)
+	}
+}
+
+func (f *BookingUpdateFeed) resend(ctx context.Context) {
+	type followed struct {
+		w         *BookingWatcher
+		requestID string
+	}
+	f.mu.Lock()
+	var all []followed
+	for _, watchers := range f.watchers {
+		for w := range watchers {
+			for requestID := range w.requests {
+				all = append(all, followed{w: w, requestID: requestID})
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	for _, fw := range all {
+		latest, err := f.updates.Latest(tenant.WithID(ctx, fw.w.topic.tenant), fw.requestID)
+		if err != nil {
+			continue
+		}
+		f.mu.Lock()
+		f.send(fw.w, latest)
+		f.mu.Unlock()
+	}
+}
+
+func (f *BookingUpdateFeed) closeAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, watchers := range f.watchers {
+		for w := range watchers {
+			f.drop(w)
+		}
+	}
+}
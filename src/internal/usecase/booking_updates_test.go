@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/concurrency"
+
+	"github.com/google/uuid"
+)
+
+func TestBookingUpdatesReachTheUserWatchingTheRequest(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	feed := NewBookingUpdateFeed(repos.BookingUpdates, utils.NewLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	userID := uuid.New()
+	watcher := feed.Watch(ctx, userID)
+	stopped := make(chan struct{})
+	go func() {
+		feed.Run(ctx)
+		close(stopped)
+	}()
+
+	// Updates may repeat: one published just before a request is followed
+	// is also sent as its latest
+	var last domain_booking.RequestUpdate
+	next := func() *domain_booking.RequestUpdate {
+		t.Helper()
+		for {
+			select {
+			case update := <-watcher.Updates():
+				if update.RequestID != "ready" && (update.RequestID != last.RequestID || update.Kind != last.Kind) {
+					last = *update
+					return update
+				}
+			case <-time.After(time.Second):
+				t.Fatalf("no booking update")
+				return nil
+			}
+		}
+	}
+
+	// Wait for the feed to subscribe, so nothing below is sent twice
+	if err := watcher.Follow(ctx, "ready"); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	for subscribed := false; !subscribed; {
+		feed.Publish(ctx, &domain_booking.RequestUpdate{RequestID: "ready", UserID: userID, Kind: domain_booking.RequestCreated})
+		select {
+		case <-watcher.Updates():
+			subscribed = true
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	// Processed before the client followed it: the outcome is sent on follow
+	booking := &domain_booking.Booking{ID: uuid.New(), UserID: userID, Status: domain_booking.BookingStatusPending}
+	feed.BookingCreated(ctx, "req-1", booking)
+	if err := watcher.Follow(ctx, "req-1"); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	if update := next(); update.Kind != domain_booking.RequestCreated || update.BookingID == nil || *update.BookingID != booking.ID {
+		t.Fatalf("update = %+v, want req-1 created as booking %s", update, booking.ID)
+	}
+
+	// Later changes to the booking reach the request it came from
+	feed.BookingChanged(ctx, booking, domain_booking.RequestConfirmed, "")
+	if update := next(); update.RequestID != "req-1" || update.Kind != domain_booking.RequestConfirmed {
+		t.Fatalf("update = %+v, want req-1 confirmed", update)
+	}
+
+	// Requests not followed, and other users' requests, are not sent
+	if err := watcher.Follow(ctx, "req-2"); err != nil {
+		t.Fatalf("follow: %v", err)
+	}
+	feed.RequestFailed(ctx, concurrency.BookingRequest{ID: "req-3", UserID: userID}, concurrency.ErrRequestExpired)
+	feed.RequestFailed(ctx, concurrency.BookingRequest{ID: "req-2", UserID: uuid.New()}, concurrency.ErrRequestExpired)
+	feed.RequestFailed(ctx, concurrency.BookingRequest{ID: "req-2", UserID: userID}, concurrency.ErrRequestExpired)
+	if update := next(); update.RequestID != "req-2" || update.UserID != userID || update.Kind != domain_booking.RequestExpired {
+		t.Fatalf("update = %+v, want req-2 expired", update)
+	}
+
+	// Shutting down closes every watcher
+	cancel()
+	<-stopped
+	for range watcher.Updates() {
+	}
+}
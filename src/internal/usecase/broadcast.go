@@ -0,0 +1,235 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+
+	"github.com/google/uuid"
+)
+
+// JobKindEventBroadcast sends a broadcast to an event's attendees
+const JobKindEventBroadcast = "event_broadcast"
+
+const (
+	broadcastChunk      = 100
+	maxBroadcastSubject = 200
+	maxBroadcastMessage = 5000
+)
+
+type BroadcastUsecase struct {
+	broadcastRepo repository.BroadcastRepository
+	eventRepo     repository.EventRepository
+	userRepo      repository.UserRepository
+	transactor    repository.Transactor
+	notifications *NotificationUsecase
+	notifier      notify.Notifier
+	jobs          *JobUsecase
+	logger        *utils.Logger
+
+	sendInterval time.Duration
+}
+
+// NewBroadcastUsecase creates a new event broadcast usecase
+func NewBroadcastUsecase(
+	broadcastRepo repository.BroadcastRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	transactor repository.Transactor,
+	notifications *NotificationUsecase,
+	notifier notify.Notifier,
+	jobs *JobUsecase,
+	config *utils.Config,
+	logger *utils.Logger,
+) *BroadcastUsecase {
+	b := &BroadcastUsecase{
+		broadcastRepo: broadcastRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+		transactor:    transactor,
+		notifications: notifications,
+		notifier:      notifier,
+		jobs:          jobs,
+		logger:        logger,
+		sendInterval:  time.Second / time.Duration(max(config.BroadcastRatePerSecond, 1)),
+	}
+	jobs.Register(JobKindEventBroadcast, b.send)
+	return b
+}
+
+// broadcastParams are the parameters of a broadcast job
+type broadcastParams struct {
+	EventID uuid.UUID `json:"event_id"`
+}
+
+// CreateBroadcast records a message to everyone with a confirmed booking for
+// the event and queues a job sending it. The broadcast takes the job's ID,
+// and its recipients are fixed when it is created.
+func (b *BroadcastUsecase) CreateBroadcast(ctx context.Context, eventID uuid.UUID, req domain_broadcast.CreateBroadcastRequest) (*domain_job.Job, error) {
+	req.Subject = strings.TrimSpace(req.Subject)
+	req.Message = strings.TrimSpace(req.Message)
+	if req.Subject == "" || len(req.Subject) > maxBroadcastSubject {
+		return nil, fmt.Errorf("%w: subject must be between 1 and %d characters", domain.ErrInvalidInput, maxBroadcastSubject)
+	}
+	if req.Message == "" || len(req.Message) > maxBroadcastMessage {
+		return nil, fmt.Errorf("%w: message must be between 1 and %d characters", domain.ErrInvalidInput, maxBroadcastMessage)
+	}
+	if _, err := b.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	// The job, broadcast and deliveries commit together, so the worker never
+	// picks up a job whose broadcast does not exist yet
+	var job *domain_job.Job
+	err := b.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		if job, err = b.jobs.Submit(ctx, JobKindEventBroadcast, broadcastParams{EventID: eventID}); err != nil {
+			return err
+		}
+		broadcast := &domain_broadcast.Broadcast{
+			ID:        job.ID,
+			EventID:   eventID,
+			Subject:   req.Subject,
+			Message:   req.Message,
+			CreatedAt: time.Now(),
+		}
+		if err := b.broadcastRepo.Create(ctx, broadcast); err != nil {
+			return fmt.Errorf("failed to save broadcast: %w", err)
+		}
+		if err := b.broadcastRepo.AddAttendees(ctx, broadcast); err != nil {
+			return fmt.Errorf("failed to add broadcast recipients: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// GetBroadcast returns a broadcast with its delivery report
+func (b *BroadcastUsecase) GetBroadcast(ctx context.Context, eventID, broadcastID uuid.UUID) (*domain_broadcast.Broadcast, error) {
+	return b.broadcastRepo.GetByID(ctx, eventID, broadcastID)
+}
+
+// ListBroadcasts returns an event's broadcasts, newest first
+func (b *BroadcastUsecase) ListBroadcasts(ctx context.Context, eventID uuid.UUID) ([]*domain_broadcast.Broadcast, error) {
+	if _, err := b.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return b.broadcastRepo.GetByEventID(ctx, eventID)
+}
+
+// send runs a broadcast job, delivering to pending recipients in chunks at
+// no more than BROADCAST_RATE_PER_SECOND. A job picked up again after a crash
+// only sends to recipients not yet delivered to.
+func (b *BroadcastUsecase) send(ctx context.Context, job *domain_job.Job, progress ProgressFunc) (string, error) {
+	var params broadcastParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return "", fmt.Errorf("invalid job params: %w", err)
+	}
+
+	broadcast, err := b.broadcastRepo.GetByID(ctx, params.EventID, job.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load broadcast: %w", err)
+	}
+	event, err := b.eventRepo.GetByID(ctx, params.EventID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load event: %w", err)
+	}
+
+	throttle := time.NewTicker(b.sendInterval)
+	defer throttle.Stop()
+
+	total, done := broadcast.Report.Recipients, broadcast.Report.Recipients-broadcast.Report.Pending
+	for {
+		deliveries, err := b.broadcastRepo.GetPendingDeliveries(ctx, broadcast.ID, broadcastChunk)
+		if err != nil {
+			return "", fmt.Errorf("failed to load deliveries: %w", err)
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		for _, delivery := range deliveries {
+			email, skipReason, err := b.render(ctx, broadcast, event, delivery.UserID)
+			if err == nil && email != nil {
+				select {
+				case <-ctx.Done():
+					return "", ctx.Err()
+				case <-throttle.C:
+				}
+				sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+				err = b.notifier.Send(sendCtx, *email)
+				cancel()
+			}
+
+			delivery.UpdatedAt = time.Now()
+			switch {
+			case err != nil:
+				msg := err.Error()
+				delivery.Status = domain_broadcast.DeliveryFailed
+				delivery.Error = &msg
+			case email == nil:
+				delivery.Status = domain_broadcast.DeliverySkipped
+				delivery.Error = &skipReason
+			default:
+				channel := domain_notification.ChannelEmail
+				delivery.Status = domain_broadcast.DeliverySent
+				delivery.Channel = &channel
+			}
+			if err := b.broadcastRepo.UpdateDelivery(ctx, delivery); err != nil {
+				return "", fmt.Errorf("failed to record delivery: %w", err)
+			}
+		}
+		done += len(deliveries)
+		progress(done, total)
+	}
+
+	now := time.Now()
+	broadcast.CompletedAt = &now
+	if err := b.broadcastRepo.Complete(ctx, broadcast); err != nil {
+		return "", fmt.Errorf("failed to complete broadcast: %w", err)
+	}
+
+	b.logger.Info("Broadcast sent", "event_id", broadcast.EventID, "broadcast_id", broadcast.ID, "recipients", total)
+	return fmt.Sprintf("/api/events/%s/broadcasts/%s", broadcast.EventID, broadcast.ID), nil
+}
+
+// render builds the email for one recipient. It returns nil and the reason
+// when the broadcast is not sent to them: email is the only channel with a
+// provider, so users who only want SMS or webhooks are skipped too.
+func (b *BroadcastUsecase) render(ctx context.Context, broadcast *domain_broadcast.Broadcast, event *domain_event.Event, userID uuid.UUID) (*notify.Email, string, error) {
+	preferences, err := b.notifications.preferences(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load preferences: %w", err)
+	}
+	channels := preferences.For(domain_notification.KindEventBroadcast)
+	if !channels.Allows(domain_notification.ChannelEmail) {
+		if channels.SMS || channels.Webhook {
+			return nil, "no provider for the user's channels", nil
+		}
+		return nil, "opted out", nil
+	}
+
+	user, err := b.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load user: %w", err)
+	}
+	email, err := emailTemplates[domain_notification.KindEventBroadcast].render(EmailData{User: user, Event: event, Broadcast: broadcast})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to render email: %w", err)
+	}
+	return &email, "", nil
+}
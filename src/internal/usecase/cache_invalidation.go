@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// CacheInvalidationListener applies the cache invalidations every instance
+// publishes, so each forgets what it remembered about changed events and
+// users. It runs on every instance.
+type CacheInvalidationListener struct {
+	invalidations repository.CacheInvalidationRepository
+	events        *EventUsecase
+	users         *UserUsecase
+	logger        *utils.Logger
+}
+
+// NewCacheInvalidationListener creates a new cache invalidation listener
+func NewCacheInvalidationListener(invalidations repository.CacheInvalidationRepository, events *EventUsecase, users *UserUsecase, logger *utils.Logger) *CacheInvalidationListener {
+	return &CacheInvalidationListener{
+		invalidations: invalidations,
+		events:        events,
+		users:         users,
+		logger:        logger,
+	}
+}
+
+// Run listens until ctx is cancelled, subscribing again a second after
+// the subscription fails
+func (l *CacheInvalidationListener) Run(ctx context.Context) {
+	for {
+		err := l.invalidations.Subscribe(ctx, l.apply)
+		if ctx.Err() != nil {
+			return
+		}
+		l.logger.Warn("Cache invalidation subscription failed", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// apply handles one invalidation. Unknown kinds, from newer instances,
+// drop everything remembered.
+func (l *CacheInvalidationListener) apply(ctx context.Context, inv repository.CacheInvalidation) {
+	switch inv.Kind {
+	case repository.CacheInvalidateEvents:
+		l.events.forgetVersion(ctx)
+	case repository.CacheInvalidateUser:
+		l.users.dropCached(ctx, inv.ID)
+	default:
+		l.events.forgetVersions()
+	}
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+func TestEventCacheVersionFollowsOtherInstances(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	config := &utils.Config{CacheVersionTTLMs: int(time.Hour / time.Millisecond)}
+	logger := utils.NewLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Two instances sharing the cache and the invalidation channel
+	local := NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, repos.CacheInvalidation, nil, config, logger)
+	remote := NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, repos.CacheInvalidation, nil, config, logger)
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+
+	applied := make(chan repository.CacheInvalidationKind, 4)
+	listener := NewCacheInvalidationListener(repos.CacheInvalidation, local, users, logger)
+	go repos.CacheInvalidation.Subscribe(ctx, func(ctx context.Context, inv repository.CacheInvalidation) {
+		listener.apply(ctx, inv)
+		applied <- inv.Kind
+	})
+	if kind := <-applied; kind != repository.CacheInvalidateAll {
+		t.Fatalf("first invalidation is %q, want %q", kind, repository.CacheInvalidateAll)
+	}
+
+	before, err := local.cacheVersion(ctx)
+	if err != nil {
+		t.Fatalf("cache version: %v", err)
+	}
+
+	remote.invalidateCache(ctx)
+	if kind := <-applied; kind != repository.CacheInvalidateEvents {
+		t.Fatalf("published invalidation is %q, want %q", kind, repository.CacheInvalidateEvents)
+	}
+
+	after, err := local.cacheVersion(ctx)
+	if err != nil {
+		t.Fatalf("cache version: %v", err)
+	}
+	if after == before {
+		t.Fatalf("instance kept cache version %d after another instance invalidated it", before)
+	}
+}
@@ -0,0 +1,49 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestWarmCacheCachesListingAndUpcomingEvents(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	events := NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, repos.CacheInvalidation, nil, &utils.Config{}, utils.NewLogger())
+	ctx := context.Background()
+
+	upcoming := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: time.Now().Add(24 * time.Hour), Status: domain_event.EventStatusPublished}
+	past := &domain_event.Event{ID: uuid.New(), Name: "Spring Jam", Date: time.Now().Add(-24 * time.Hour), Status: domain_event.EventStatusArchived}
+	for _, evt := range []*domain_event.Event{upcoming, past} {
+		if err := repos.Event.Create(ctx, evt); err != nil {
+			t.Fatalf("create event: %v", err)
+		}
+	}
+
+	cached, err := events.WarmCache(ctx)
+	if err != nil {
+		t.Fatalf("warm cache: %v", err)
+	}
+	if cached != 1 {
+		t.Fatalf("cached %d events on their own, want 1", cached)
+	}
+
+	version, err := repos.EventCache.Version(ctx)
+	if err != nil {
+		t.Fatalf("cache version: %v", err)
+	}
+	if listing, err := repos.EventCache.GetAll(ctx, version); err != nil || len(listing) != 2 {
+		t.Fatalf("cached listing has %d events, err %v; want 2", len(listing), err)
+	}
+	if _, err := repos.EventCache.GetByID(ctx, version, upcoming.ID); err != nil {
+		t.Fatalf("upcoming event not cached: %v", err)
+	}
+	if _, err := repos.EventCache.GetByID(ctx, version, past.ID); err == nil {
+		t.Fatalf("past event was cached")
+	}
+}
@@ -0,0 +1,140 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/ical"
+
+	"github.com/google/uuid"
+)
+
+// calendarRefresh is how often subscribed calendar apps are asked to fetch
+// a user's feed again
+const calendarRefresh = time.Hour
+
+type CalendarUsecase struct {
+	events      *EventUsecase
+	users       *UserUsecase
+	bookingRepo repository.BookingRepository
+	eventRepo   repository.EventRepository
+	logger      *utils.Logger
+
+	duration time.Duration // events have a start but no end
+}
+
+// NewCalendarUsecase creates a new calendar usecase
+func NewCalendarUsecase(events *EventUsecase, users *UserUsecase, bookingRepo repository.BookingRepository, eventRepo repository.EventRepository, config *utils.Config, logger *utils.Logger) *CalendarUsecase {
+	return &CalendarUsecase{
+		events:      events,
+		users:       users,
+		bookingRepo: bookingRepo,
+		eventRepo:   eventRepo,
+		logger:      logger,
+		duration:    time.Duration(max(config.CalendarEventMinutes, 1)) * time.Minute,
+	}
+}
+
+// calendarEvent is an event as a calendar entry
+func (u *CalendarUsecase) calendarEvent(uid string, event *domain_event.Event) ical.Event {
+	return ical.Event{
+		UID:         uid,
+		Start:       event.Date,
+		End:         event.Date.Add(u.duration),
+		Summary:     event.Name,
+		Location:    event.Venue,
+		Description: event.Artist,
+		Updated:     event.UpdatedAt,
+	}
+}
+
+// EventCalendar returns a customer-visible event as an iCalendar file
+func (u *CalendarUsecase) EventCalendar(ctx context.Context, eventID uuid.UUID) ([]byte, error) {
+	event, err := u.events.GetEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	cal := &ical.Calendar{Name: event.Name, Events: []ical.Event{u.calendarEvent("event-"+event.ID.String()+"@booking-manager", event)}}
+	return cal.Bytes(), nil
+}
+
+// UserCalendar returns a user's confirmed bookings as an iCalendar feed,
+// one entry per booking. Bookings cancelled or refunded drop out of the
+// feed, and subscribed calendars remove them on their next fetch.
+func (u *CalendarUsecase) UserCalendar(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	user, err := u.users.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	bookings, err := u.bookingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+
+	var confirmed []*domain_booking.Booking
+	var eventIDs []uuid.UUID
+	for _, bk := range bookings {
+		if bk.Status == domain_booking.BookingStatusConfirmed {
+			confirmed = append(confirmed, bk)
+			eventIDs = append(eventIDs, bk.EventID)
+		}
+	}
+	events := make(map[uuid.UUID]*domain_event.Event)
+	if len(eventIDs) > 0 {
+		loaded, err := u.eventRepo.GetByIDs(ctx, eventIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events: %w", err)
+		}
+		for _, event := range loaded {
+			events[event.ID] = event
+		}
+	}
+
+	cal := &ical.Calendar{Name: user.Name + "'s bookings", RefreshInterval: calendarRefresh}
+	for _, bk := range confirmed {
+		event, ok := events[bk.EventID]
+		if !ok {
+			continue
+		}
+		items, err := u.bookingRepo.GetItems(ctx, bk.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get booking items: %w", err)
+		}
+
+		entry := u.calendarEvent("booking-"+bk.ID.String()+"@booking-manager", event)
+		entry.Description = bookingDescription(event, items, bk.ID)
+		if bk.UpdatedAt.After(entry.Updated) {
+			entry.Updated = bk.UpdatedAt
+		}
+		cal.Events = append(cal.Events, entry)
+	}
+	sort.Slice(cal.Events, func(i, j int) bool { return cal.Events[i].Start.Before(cal.Events[j].Start) })
+	return cal.Bytes(), nil
+}
+
+// bookingDescription describes a booking's tickets for its calendar entry
+func bookingDescription(event *domain_event.Event, items []domain_booking.LineItem, bookingID uuid.UUID) string {
+	seats := make([]string, len(items))
+	for i, item := range items {
+		seats[i] = strconv.Itoa(item.SeatNumber)
+	}
+	tickets := "1 ticket, seat "
+	if len(items) != 1 {
+		tickets = strconv.Itoa(len(items)) + " tickets, seats "
+	}
+
+	var lines []string
+	if event.Artist != "" {
+		lines = append(lines, event.Artist)
+	}
+	lines = append(lines, tickets+strings.Join(seats, ", "), "Booking "+bookingID.String())
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestUserCalendarListsConfirmedBookings(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{CalendarEventMinutes: 150}
+	events := NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, repos.CacheInvalidation, nil, config, logger)
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	calendars := NewCalendarUsecase(events, users, repos.Booking, repos.Event, config, logger)
+	ctx := context.Background()
+
+	fan := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan"}
+	if err := repos.User.Create(ctx, fan); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	date := time.Date(2026, 7, 1, 20, 0, 0, 0, time.UTC)
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Artist: "The Band", Venue: "Arena", Date: date, TotalSeats: 10, Status: domain_event.EventStatusPublished}
+	draft := &domain_event.Event{ID: uuid.New(), Name: "Secret Show", Date: date, TotalSeats: 10, Status: domain_event.EventStatusDraft}
+	for _, evt := range []*domain_event.Event{event, draft} {
+		if err := repos.Event.Create(ctx, evt); err != nil {
+			t.Fatalf("create event: %v", err)
+		}
+	}
+
+	confirmed := &domain_booking.Booking{ID: uuid.New(), UserID: fan.ID, EventID: event.ID, Status: domain_booking.BookingStatusConfirmed, Currency: "USD"}
+	cancelled := &domain_booking.Booking{ID: uuid.New(), UserID: fan.ID, EventID: event.ID, Status: domain_booking.BookingStatusCancelled, Currency: "USD"}
+	for i, bk := range []*domain_booking.Booking{confirmed, cancelled} {
+		for seat := 2*i + 1; seat <= 2*i+2; seat++ {
+			bk.Items = append(bk.Items, domain_booking.LineItem{BookingID: bk.ID, TicketID: uuid.New(), SeatNumber: seat, UnitPrice: 50, Currency: "USD"})
+		}
+		if err := repos.Booking.Create(ctx, bk); err != nil {
+			t.Fatalf("create booking: %v", err)
+		}
+	}
+
+	feed, err := calendars.UserCalendar(ctx, fan.ID)
+	if err != nil {
+		t.Fatalf("user calendar: %v", err)
+	}
+	unfolded := strings.ReplaceAll(string(feed), "\r\n ", "")
+	if n := strings.Count(unfolded, "BEGIN:VEVENT"); n != 1 {
+		t.Fatalf("feed has %d entries, want only the confirmed booking:\n%s", n, unfolded)
+	}
+	for _, want := range []string{
+		"UID:booking-" + confirmed.ID.String() + "@booking-manager",
+		"DTSTART:20260701T200000Z",
+		"DTEND:20260701T223000Z",
+		`DESCRIPTION:The Band\n2 tickets\, seats 1\, 2\nBooking ` + confirmed.ID.String(),
+	} {
+		if !strings.Contains(unfolded, want+"\r\n") {
+			t.Errorf("feed is missing %q:\n%s", want, unfolded)
+		}
+	}
+
+	// Single events are only downloadable once customers can see them
+	if _, err := calendars.EventCalendar(ctx, draft.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("draft event calendar: got %v, want ErrNotFound", err)
+	}
+	if _, err := calendars.UserCalendar(ctx, uuid.New()); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("unknown user's calendar: got %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// ErrCheckInRejected is returned when a genuine pass cannot be admitted
+var ErrCheckInRejected = fmt.Errorf("%w: ticket cannot be admitted", domain.ErrInvalidInput)
+
+// ErrAlreadyCheckedIn is returned when a pass is scanned again
+var ErrAlreadyCheckedIn = fmt.Errorf("%w: ticket already checked in", domain.ErrConflict)
+
+type CheckInUsecase struct {
+	passRepo    repository.TicketPassRepository
+	bookingRepo repository.BookingRepository
+	eventRepo   repository.EventRepository
+	ticketRepo  repository.TicketRepository
+	userRepo    repository.UserRepository
+	passes      *TicketPassUsecase
+	logger      *utils.Logger
+
+	opensBefore time.Duration
+	closesAfter time.Duration
+}
+
+// NewCheckInUsecase creates a new door check-in usecase
+func NewCheckInUsecase(
+	passRepo repository.TicketPassRepository,
+	bookingRepo repository.BookingRepository,
+	eventRepo repository.EventRepository,
+	ticketRepo repository.TicketRepository,
+	userRepo repository.UserRepository,
+	passes *TicketPassUsecase,
+	config *utils.Config,
+	logger *utils.Logger,
+) *CheckInUsecase {
+	return &CheckInUsecase{
+		passRepo:    passRepo,
+		bookingRepo: bookingRepo,
+		eventRepo:   eventRepo,
+		ticketRepo:  ticketRepo,
+		userRepo:    userRepo,
+		passes:      passes,
+		logger:      logger,
+		opensBefore: time.Duration(config.CheckInOpensMinutesBefore) * time.Minute,
+		closesAfter: time.Duration(config.CheckInClosesMinutesAfter) * time.Minute,
+	}
+}
+
+// CheckIn validates a scanned pass and admits its ticket. A pass is admitted
+// at most once; later scans fail with ErrAlreadyCheckedIn.
+func (c *CheckInUsecase) CheckIn(ctx context.Context, req domain_ticket.CheckInRequest) (*domain_ticket.CheckInResponse, error) {
+	bookingID, ticketID, err := c.passes.ParseToken(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	pass, err := c.passRepo.GetByToken(ctx, req.Token)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return nil, fmt.Errorf("%w: no pass was issued for this ticket", ErrCheckInRejected)
+		}
+		return nil, fmt.Errorf("failed to load ticket pass: %w", err)
+	}
+	if pass.CheckedInAt != nil {
+		return nil, fmt.Errorf("%w at %s", ErrAlreadyCheckedIn, pass.CheckedInAt.Format(time.RFC3339))
+	}
+
+	booking, err := c.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load booking: %w", err)
+	}
+	if booking.Status != domain_booking.BookingStatusConfirmed {
+		return nil, fmt.Errorf("%w: booking is %s", ErrCheckInRejected, booking.Status)
+	}
+	if req.EventID != nil && *req.EventID != booking.EventID {
+		return nil, fmt.Errorf("%w: ticket is for another event", ErrCheckInRejected)
+	}
+
+	ticket, err := c.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ticket: %w", err)
+	}
+	if ticket.EventID != booking.EventID || ticket.Status != domain_ticket.TicketStatusSold {
+		return nil, fmt.Errorf("%w: ticket is %s", ErrCheckInRejected, ticket.Status)
+	}
+
+	// A seat changed off the booking may since have been sold to someone else
+	holder, err := c.bookingRepo.GetByTicketID(ctx, ticketID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("failed to load ticket holder: %w", err)
+	}
+	if err != nil || holder.ID != booking.ID {
+		return nil, fmt.Errorf("%w: ticket no longer belongs to this booking", ErrCheckInRejected)
+	}
+
+	event, err := c.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event: %w", err)
+	}
+	now := time.Now()
+	if opens := event.Date.Add(-c.opensBefore); now.Before(opens) {
+		return nil, fmt.Errorf("%w: check-in opens at %s", ErrCheckInRejected, opens.Format(time.RFC3339))
+	}
+	if closes := event.Date.Add(c.closesAfter); now.After(closes) {
+		return nil, fmt.Errorf("%w: check-in closed at %s", ErrCheckInRejected, closes.Format(time.RFC3339))
+	}
+
+	user, err := c.userRepo.GetByID(ctx, booking.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	// The conditional update decides between concurrent scans of the same pass
+	if err := c.passRepo.CheckIn(ctx, pass.ID, now); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return nil, ErrAlreadyCheckedIn
+		}
+		return nil, fmt.Errorf("failed to check in: %w", err)
+	}
+
+	c.logger.Info("Ticket checked in",
+		"event_id", event.ID,
+		"booking_id", booking.ID,
+		"ticket_id", ticket.ID)
+
+	return &domain_ticket.CheckInResponse{
+		BookingID:     booking.ID,
+		TicketID:      ticket.ID,
+		EventID:       event.ID,
+		EventName:     event.Name,
+		SeatNumber:    ticket.SeatNumber,
+		AttendeeName:  user.Name,
+		AttendeeEmail: user.Email,
+		CheckedInAt:   now,
+	}, nil
+}
+
+// GetCounts returns the check-in counters of an event
+func (c *CheckInUsecase) GetCounts(ctx context.Context, eventID uuid.UUID) (*domain_ticket.CheckInCounts, error) {
+	if _, err := c.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return c.passRepo.CountCheckIns(ctx, eventID)
+}
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// ErrConfirmationRequirementNotMet is returned when a confirmation gate rejects a booking
+var ErrConfirmationRequirementNotMet = fmt.Errorf("%w: confirmation requirement not met", domain.ErrInvalidInput)
+
+// Built-in confirmation gate names
+const (
+	GateIDVerification    = "id_verification"
+	GateHealthAttestation = "health_attestation"
+	GateMembership        = "membership"
+)
+
+// GateInput carries everything a confirmation gate may inspect
+type GateInput struct {
+	Booking      *domain_booking.Booking
+	Event        *domain_event.Event
+	Attestations map[string]string
+}
+
+// ConfirmationGate is a single check evaluated before a booking is confirmed
+type ConfirmationGate interface {
+	Name() string
+	Evaluate(ctx context.Context, input GateInput) error
+}
+
+// ConfirmationGateRegistry holds the gates organizers can require on events
+type ConfirmationGateRegistry struct {
+	gates map[string]ConfirmationGate
+}
+
+// NewConfirmationGateRegistry creates a registry with the given gates
+func NewConfirmationGateRegistry(gates ...ConfirmationGate) *ConfirmationGateRegistry {
+	registry := &ConfirmationGateRegistry{gates: make(map[string]ConfirmationGate)}
+	for _, gate := range gates {
+		registry.Register(gate)
+	}
+	return registry
+}
+
+// NewDefaultConfirmationGates creates a registry with the built-in gates
+func NewDefaultConfirmationGates(config *utils.Config) *ConfirmationGateRegistry {
+	return NewConfirmationGateRegistry(
+		NewIDVerificationGate(),
+		NewHealthAttestationGate(),
+		NewMembershipGate(config.MembershipHookURL, 5*time.Second),
+	)
+}
+
+// Register adds or replaces a gate
+func (r *ConfirmationGateRegistry) Register(gate ConfirmationGate) {
+	r.gates[gate.Name()] = gate
+}
+
+// Has reports whether a gate with the given name is registered
+func (r *ConfirmationGateRegistry) Has(name string) bool {
+	_, ok := r.gates[name]
+	return ok
+}
+
+// Evaluate runs every gate required by the event, stopping at the first failure
+func (r *ConfirmationGateRegistry) Evaluate(ctx context.Context, input GateInput) error {
+	for _, name := range input.Event.ConfirmationRequirements {
+		gate, ok := r.gates[name]
+		if !ok {
+			return fmt.Errorf("unknown confirmation gate %q", name)
+		}
+		if err := gate.Evaluate(ctx, input); err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrConfirmationRequirementNotMet, name, err)
+		}
+	}
+	return nil
+}
+
+// attestationGate passes when the customer affirms a flag in the confirm request
+type attestationGate struct {
+	name string
+	key  string
+}
+
+// NewIDVerificationGate requires "id_verified": "true" in the attestations
+func NewIDVerificationGate() ConfirmationGate {
+	return &attestationGate{name: GateIDVerification, key: "id_verified"}
+}
+
+// NewHealthAttestationGate requires "health_attestation": "true" in the attestations
+func NewHealthAttestationGate() ConfirmationGate {
+	return &attestationGate{name: GateHealthAttestation, key: "health_attestation"}
+}
+
+func (g *attestationGate) Name() string {
+	return g.name
+}
+
+func (g *attestationGate) Evaluate(ctx context.Context, input GateInput) error {
+	if input.Attestations[g.key] != "true" {
+		return fmt.Errorf("%s must be attested", g.key)
+	}
+	return nil
+}
+
+// membershipGate validates a membership number against an external hook
+type membershipGate struct {
+	hookURL string
+	client  *http.Client
+}
+
+// NewMembershipGate validates "membership_number" by POSTing it to hookURL;
+// any 2xx response accepts the membership
+func NewMembershipGate(hookURL string, timeout time.Duration) ConfirmationGate {
+	return &membershipGate{
+		hookURL: hookURL,
+		client:  &http.Client{Timeout: timeout},
+	}
+}
+
+func (g *membershipGate) Name() string {
+	return GateMembership
+}
+
+func (g *membershipGate) Evaluate(ctx context.Context, input GateInput) error {
+	number := input.Attestations["membership_number"]
+	if number == "" {
+		return fmt.Errorf("membership_number is required")
+	}
+	if g.hookURL == "" {
+		return fmt.Errorf("membership validation is not configured")
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"membership_number": number,
+		"user_id":           input.Booking.UserID.String(),
+		"event_id":          input.Event.ID.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.hookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("membership validation unavailable: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("membership number was rejected")
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// DeletionUsecase lets admins delete events and undo or finish deletions.
+// Users and events are soft deleted: they disappear everywhere but keep
+// their bookings and tickets until an admin restores them or purges them,
+// which deletes them and everything that belongs to them for good.
+type DeletionUsecase struct {
+	userRepo  repository.UserRepository
+	eventRepo repository.EventRepository
+	users     *UserUsecase
+	events    *EventUsecase
+	logger    *utils.Logger
+}
+
+// NewDeletionUsecase creates a new deletion usecase
+func NewDeletionUsecase(userRepo repository.UserRepository, eventRepo repository.EventRepository, users *UserUsecase, events *EventUsecase, logger *utils.Logger) *DeletionUsecase {
+	return &DeletionUsecase{
+		userRepo:  userRepo,
+		eventRepo: eventRepo,
+		users:     users,
+		events:    events,
+		logger:    logger,
+	}
+}
+
+// DeleteEvent soft deletes an event
+func (u *DeletionUsecase) DeleteEvent(ctx context.Context, adminID, eventID uuid.UUID) error {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return err
+	}
+	if err := u.eventRepo.Delete(ctx, eventID); err != nil {
+		return err
+	}
+	u.events.invalidateCache(ctx)
+	u.logger.Info("Event deleted", "event_id", eventID, "by", adminID)
+	return nil
+}
+
+// RestoreEvent undoes DeleteEvent
+func (u *DeletionUsecase) RestoreEvent(ctx context.Context, adminID, eventID uuid.UUID) (*domain_event.Event, error) {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := u.eventRepo.Restore(ctx, eventID); err != nil {
+		return nil, err
+	}
+	u.events.invalidateCache(ctx)
+	u.logger.Info("Event restored", "event_id", eventID, "by", adminID)
+	return u.eventRepo.GetByID(ctx, eventID)
+}
+
+// PurgeEvent deletes a soft deleted event for good, with its tickets and
+// bookings. Bookings still holding tickets must be cancelled first.
+func (u *DeletionUsecase) PurgeEvent(ctx context.Context, adminID, eventID uuid.UUID) error {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return err
+	}
+	err := u.eventRepo.Purge(ctx, eventID)
+	if errors.Is(err, domain.ErrNotFound) {
+		if _, getErr := u.eventRepo.GetByID(ctx, eventID); getErr == nil {
+			return fmt.Errorf("%w: event %s must be deleted before it is purged", domain.ErrConflict, eventID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	u.events.invalidateCache(ctx)
+	u.logger.Info("Event purged", "event_id", eventID, "by", adminID)
+	return nil
+}
+
+// RestoreUser undoes UserUsecase.DeleteUser
+func (u *DeletionUsecase) RestoreUser(ctx context.Context, adminID, userID uuid.UUID) (*domain_user.User, error) {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if err := u.userRepo.Restore(ctx, userID); err != nil {
+		return nil, err
+	}
+	// Lookups made while the user was deleted are cached as not found
+	u.users.dropCached(ctx, userID)
+	u.users.publishInvalidation(ctx, userID)
+	u.logger.Info("User restored", "user_id", userID, "by", adminID)
+	return u.userRepo.GetByID(ctx, userID)
+}
+
+// PurgeUser deletes a soft deleted user for good, with their bookings.
+// Bookings still holding tickets must be cancelled first.
+func (u *DeletionUsecase) PurgeUser(ctx context.Context, adminID, userID uuid.UUID) error {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return err
+	}
+	err := u.userRepo.Purge(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		if _, getErr := u.userRepo.GetByID(ctx, userID); getErr == nil {
+			return fmt.Errorf("%w: user %s must be deleted before they are purged", domain.ErrConflict, userID)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	u.users.dropCached(ctx, userID)
+	u.users.publishInvalidation(ctx, userID)
+	u.logger.Info("User purged", "user_id", userID, "by", adminID)
+	return nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestDeletedUsersCanBeRestoredOrPurged(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{}
+	events := NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, repos.CacheInvalidation, nil, config, logger)
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	deletion := NewDeletionUsecase(repos.User, repos.Event, users, events, logger)
+	ctx := context.Background()
+
+	admin := &domain_user.User{ID: uuid.New(), Email: "admin@example.com", Name: "Admin", Role: domain_user.RoleAdmin}
+	fan := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan"}
+	for _, usr := range []*domain_user.User{admin, fan} {
+		if err := repos.User.Create(ctx, usr); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: time.Now().Add(24 * time.Hour), TotalSeats: 10, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	bk := &domain_booking.Booking{ID: uuid.New(), UserID: fan.ID, EventID: event.ID, Status: domain_booking.BookingStatusConfirmed, Currency: "USD"}
+	if err := repos.Booking.Create(ctx, bk); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+
+	if err := deletion.PurgeUser(ctx, admin.ID, fan.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("purge live user: got %v, want ErrConflict", err)
+	}
+	if err := users.DeleteUser(ctx, fan.ID, fan.ID); err != nil {
+		t.Fatalf("delete user: %v", err)
+	}
+	if _, err := users.GetUser(ctx, fan.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("deleted user: got %v, want ErrNotFound", err)
+	}
+	if _, err := repos.Booking.GetByID(ctx, bk.ID); err != nil {
+		t.Fatalf("booking of a deleted user: %v", err)
+	}
+	if _, err := deletion.RestoreUser(ctx, fan.ID, fan.ID); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("restore by a deleted user: got %v, want ErrUnauthorized", err)
+	}
+
+	restored, err := deletion.RestoreUser(ctx, admin.ID, fan.ID)
+	if err != nil || restored.DeletedAt != nil {
+		t.Fatalf("restore user: got %+v, %v", restored, err)
+	}
+	if _, err := users.GetUser(ctx, fan.ID); err != nil {
+		t.Fatalf("restored user: %v", err)
+	}
+
+	if err := users.DeleteUser(ctx, admin.ID, fan.ID); err != nil {
+		t.Fatalf("delete user again: %v", err)
+	}
+	if err := deletion.PurgeUser(ctx, admin.ID, fan.ID); !errors.Is(err, domain_booking.ErrActiveBookings) {
+		t.Fatalf("purge user with a confirmed booking: got %v, want ErrActiveBookings", err)
+	}
+	bk.Status = domain_booking.BookingStatusCancelled
+	if err := repos.Booking.Update(ctx, bk); err != nil {
+		t.Fatalf("cancel booking: %v", err)
+	}
+	if err := deletion.PurgeUser(ctx, admin.ID, fan.ID); err != nil {
+		t.Fatalf("purge user: %v", err)
+	}
+	if _, err := repos.Booking.GetByID(ctx, bk.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("booking of a purged user: got %v, want ErrNotFound", err)
+	}
+	if _, err := deletion.RestoreUser(ctx, admin.ID, fan.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("restore purged user: got %v, want ErrNotFound", err)
+	}
+
+	// Events are deleted by admins only
+	if err := deletion.DeleteEvent(ctx, admin.ID, event.ID); err != nil {
+		t.Fatalf("delete event: %v", err)
+	}
+	if _, err := events.GetEvent(ctx, event.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("deleted event: got %v, want ErrNotFound", err)
+	}
+	if _, err := deletion.RestoreEvent(ctx, admin.ID, event.ID); err != nil {
+		t.Fatalf("restore event: %v", err)
+	}
+	if err := deletion.PurgeEvent(ctx, admin.ID, event.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("purge live event: got %v, want ErrConflict", err)
+	}
+}
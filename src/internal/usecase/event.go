@@ -2,31 +2,58 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
 	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
 	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
 	"github.com/ojaswiii/booking-manager/src/internal/repository"
 	"github.com/ojaswiii/booking-manager/src/utils"
+	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
 
 	"github.com/google/uuid"
 )
 
 type EventUsecase struct {
-	eventRepo  repository.EventRepository
-	cacheRepo  repository.EventCacheRepository
-	ticketRepo repository.TicketRepository
-	logger     *utils.Logger
+	eventRepo     repository.EventRepository
+	cacheRepo     repository.EventCacheRepository
+	ticketRepo    repository.TicketRepository
+	invalidations repository.CacheInvalidationRepository
+	gates         *ConfirmationGateRegistry
+	loads         *concurrency.SingleFlight // one database load per missed cache key
+	notFoundTTL   time.Duration             // how long missing events are remembered; 0 for never
+	logger        *utils.Logger
+
+	// Cache versions remembered per tenant, dropped when any instance
+	// publishes an event invalidation
+	versionTTL time.Duration
+	versionsMu sync.Mutex
+	versions   map[string]rememberedVersion
+	forgotten  uint64 // bumped whenever versions are dropped
+}
+
+type rememberedVersion struct {
+	version   int64
+	expiresAt time.Time
 }
 
 // NewEventUsecase creates a new event usecase
-func NewEventUsecase(eventRepo repository.EventRepository, cacheRepo repository.EventCacheRepository, ticketRepo repository.TicketRepository, logger *utils.Logger) *EventUsecase {
+func NewEventUsecase(eventRepo repository.EventRepository, cacheRepo repository.EventCacheRepository, ticketRepo repository.TicketRepository, invalidations repository.CacheInvalidationRepository, gates *ConfirmationGateRegistry, config *utils.Config, logger *utils.Logger) *EventUsecase {
 	return &EventUsecase{
-		eventRepo:  eventRepo,
-		cacheRepo:  cacheRepo,
-		ticketRepo: ticketRepo,
-		logger:     logger,
+		eventRepo:     eventRepo,
+		cacheRepo:     cacheRepo,
+		ticketRepo:    ticketRepo,
+		invalidations: invalidations,
+		gates:         gates,
+		loads:         concurrency.NewSingleFlight(),
+		notFoundTTL:   time.Duration(config.CacheNotFoundTTLMs) * time.Millisecond,
+		logger:        logger,
+		versionTTL:    time.Duration(config.CacheVersionTTLMs) * time.Millisecond,
+		versions:      make(map[string]rememberedVersion),
 	}
 }
 
@@ -38,6 +65,14 @@ type CreateEventRequest struct {
 	Date       string  `json:"date"` // ISO 8601 format
 	TotalSeats int     `json:"total_seats"`
 	Price      float64 `json:"price"`
+	Status     string  `json:"status,omitempty"` // defaults to draft
+
+	SalesStartAt   string `json:"sales_start_at,omitempty"`   // ISO 8601 format
+	SalesEndAt     string `json:"sales_end_at,omitempty"`     // ISO 8601 format
+	PresaleStartAt string `json:"presale_start_at,omitempty"` // ISO 8601 format
+
+	ConfirmationRequirements []string `json:"confirmation_requirements,omitempty"`
+	WaitingRoomEnabled       bool     `json:"waiting_room_enabled,omitempty"`
 }
 
 // CreateEventResponse represents the response of creating an event
@@ -49,27 +84,85 @@ type CreateEventResponse struct {
 	Date       string    `json:"date"`
 	TotalSeats int       `json:"total_seats"`
 	Price      float64   `json:"price"`
+	Status     string    `json:"status"`
+
+	SalesStartAt   string `json:"sales_start_at,omitempty"`
+	SalesEndAt     string `json:"sales_end_at,omitempty"`
+	PresaleStartAt string `json:"presale_start_at,omitempty"`
+
+	ConfirmationRequirements []string `json:"confirmation_requirements,omitempty"`
+	WaitingRoomEnabled       bool     `json:"waiting_room_enabled"`
 }
 
 // CreateEvent creates a new event with tickets
 func (e *EventUsecase) CreateEvent(ctx context.Context, req CreateEventRequest) (*CreateEventResponse, error) {
+	return e.createEvent(ctx, req, func(int) float64 { return req.Price })
+}
+
+// createEvent creates the event and one ticket per seat, priced by seatPrice
+func (e *EventUsecase) createEvent(ctx context.Context, req CreateEventRequest, seatPrice func(seat int) float64) (*CreateEventResponse, error) {
 	// Parse date
 	date, err := utils.ParseTime(req.Date)
 	if err != nil {
 		return nil, fmt.Errorf("invalid date format: %w", err)
 	}
 
+	// New events start as drafts unless a status is given
+	status := domain_event.EventStatusDraft
+	if req.Status != "" {
+		status = domain_event.EventStatus(req.Status)
+		if !status.IsValid() {
+			return nil, fmt.Errorf("%w: unknown event status %q", domain.ErrInvalidInput, req.Status)
+		}
+	}
+
+	// Parse optional sales window
+	salesStartAt, err := parseOptionalTime(req.SalesStartAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid sales_start_at: %v", domain.ErrInvalidInput, err)
+	}
+	salesEndAt, err := parseOptionalTime(req.SalesEndAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid sales_end_at: %v", domain.ErrInvalidInput, err)
+	}
+	if salesStartAt != nil && salesEndAt != nil && !salesStartAt.Before(*salesEndAt) {
+		return nil, fmt.Errorf("%w: sales_start_at must be before sales_end_at", domain.ErrInvalidInput)
+	}
+	presaleStartAt, err := parseOptionalTime(req.PresaleStartAt)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid presale_start_at: %v", domain.ErrInvalidInput, err)
+	}
+	if presaleStartAt != nil && (salesStartAt == nil || !presaleStartAt.Before(*salesStartAt)) {
+		return nil, fmt.Errorf("%w: presale_start_at must be before sales_start_at", domain.ErrInvalidInput)
+	}
+
+	// Every requirement must map to a registered confirmation gate
+	for _, name := range req.ConfirmationRequirements {
+		if !e.gates.Has(name) {
+			return nil, fmt.Errorf("%w: unknown confirmation requirement %q", domain.ErrInvalidInput, name)
+		}
+	}
+
 	// Create event
 	event := &domain_event.Event{
-		ID:         uuid.New(),
-		Name:       req.Name,
-		Artist:     req.Artist,
-		Venue:      req.Venue,
-		Date:       date,
-		TotalSeats: req.TotalSeats,
-		Price:      req.Price,
-		CreatedAt:  time.Now(),
-		UpdatedAt:  time.Now(),
+		ID:           uuid.New(),
+		Name:         req.Name,
+		Artist:       req.Artist,
+		Venue:        req.Venue,
+		Date:         date,
+		TotalSeats:   req.TotalSeats,
+		Price:        req.Price,
+		Status:       status,
+		SalesStartAt: salesStartAt,
+		SalesEndAt:   salesEndAt,
+
+		PresaleStartAt: presaleStartAt,
+
+		ConfirmationRequirements: req.ConfirmationRequirements,
+		WaitingRoomEnabled:       req.WaitingRoomEnabled,
+
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 	}
 
 	// Save event to database
@@ -77,11 +170,6 @@ func (e *EventUsecase) CreateEvent(ctx context.Context, req CreateEventRequest)
 		return nil, fmt.Errorf("failed to save event: %w", err)
 	}
 
-	// Cache event
-	if err := e.cacheRepo.Create(ctx, event); err != nil {
-		e.logger.Warn("Failed to cache event", "event_id", event.ID, "error", err)
-	}
-
 	// Create tickets for the event
 	for i := 1; i <= req.TotalSeats; i++ {
 		ticket := &domain_ticket.Ticket{
@@ -89,19 +177,23 @@ func (e *EventUsecase) CreateEvent(ctx context.Context, req CreateEventRequest)
 			EventID:    event.ID,
 			SeatNumber: i,
 			Status:     domain_ticket.TicketStatusAvailable,
-			Price:      req.Price,
+			Price:      seatPrice(i),
 			CreatedAt:  time.Now(),
 			UpdatedAt:  time.Now(),
 		}
 
 		if err := e.ticketRepo.Create(ctx, ticket); err != nil {
+			e.invalidateCache(ctx)
 			return nil, fmt.Errorf("failed to save ticket %d: %w", i, err)
 		}
 	}
 
+	// The listing gains the event once its tickets exist
+	e.invalidateCache(ctx)
+
 	e.logger.Info("Event created successfully", "event_id", event.ID, "name", event.Name, "total_seats", event.TotalSeats)
 
-	return &CreateEventResponse{
+	response := &CreateEventResponse{
 		EventID:    event.ID,
 		Name:       event.Name,
 		Artist:     event.Artist,
@@ -109,59 +201,342 @@ func (e *EventUsecase) CreateEvent(ctx context.Context, req CreateEventRequest)
 		Date:       event.Date.Format("2006-01-02T15:04:05Z"),
 		TotalSeats: event.TotalSeats,
 		Price:      event.Price,
-	}, nil
+		Status:     string(event.Status),
+
+		ConfirmationRequirements: event.ConfirmationRequirements,
+		WaitingRoomEnabled:       event.WaitingRoomEnabled,
+	}
+	if event.SalesStartAt != nil {
+		response.SalesStartAt = event.SalesStartAt.Format("2006-01-02T15:04:05Z")
+	}
+	if event.SalesEndAt != nil {
+		response.SalesEndAt = event.SalesEndAt.Format("2006-01-02T15:04:05Z")
+	}
+	if event.PresaleStartAt != nil {
+		response.PresaleStartAt = event.PresaleStartAt.Format("2006-01-02T15:04:05Z")
+	}
+
+	return response, nil
+}
+
+// parseOptionalTime parses an ISO 8601 time, returning nil for an empty string
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := utils.ParseTime(value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
 
-// GetEvent retrieves an event by ID
+// GetEvent retrieves a customer-visible event by ID
 func (e *EventUsecase) GetEvent(ctx context.Context, eventID uuid.UUID) (*domain_event.Event, error) {
+	event, err := e.getEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Draft events are hidden from customers
+	if !event.IsVisible() {
+		return nil, domain.ErrNotFound
+	}
+
+	return event.WithSalesCountdown(time.Now()), nil
+}
+
+// getEvent retrieves an event by ID regardless of its status
+func (e *EventUsecase) getEvent(ctx context.Context, eventID uuid.UUID) (*domain_event.Event, error) {
+	// Without a cache version nothing can be read from or written to the cache
+	version, err := e.cacheVersion(ctx)
+	if err != nil {
+		return e.eventRepo.GetByID(ctx, eventID)
+	}
+
 	// Try cache first
-	event, err := e.cacheRepo.GetByID(ctx, eventID)
+	event, err := e.cacheRepo.GetByID(ctx, version, eventID)
 	if err == nil && event != nil {
 		return event, nil
 	}
+	if errors.Is(err, repository.ErrCachedNotFound) {
+		return nil, domain.ErrNotFound
+	}
+
+	// Fallback to database, once for every caller that missed the same key
+	key := fmt.Sprintf("%s:event:%s:%d", tenant.FromContext(ctx), eventID, version)
+	loaded, err := e.loads.Do(ctx, key, func() (interface{}, error) {
+		event, err := e.eventRepo.GetByID(ctx, eventID)
+		if errors.Is(err, domain.ErrNotFound) && e.notFoundTTL > 0 {
+			if err := e.cacheRepo.SetNotFound(ctx, version, eventID, e.notFoundTTL); err != nil {
+				e.logger.Warn("Failed to cache missing event", "event_id", eventID, "error", err)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Cache the result under the version read before loading it
+		if err := e.cacheRepo.Set(ctx, version, event); err != nil {
+			e.logger.Warn("Failed to cache event", "event_id", eventID, "error", err)
+		}
+		return event, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Callers change the event they get, so each gets a copy of the shared one
+	copied := *loaded.(*domain_event.Event)
+	return &copied, nil
+}
 
-	// Fallback to database
-	event, err = e.eventRepo.GetByID(ctx, eventID)
+// GetAllEvents retrieves all customer-visible events
+func (e *EventUsecase) GetAllEvents(ctx context.Context) ([]*domain_event.Event, error) {
+	events, err := e.getAllEvents(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	// Cache the result
-	if err := e.cacheRepo.Create(ctx, event); err != nil {
-		e.logger.Warn("Failed to cache event", "event_id", eventID, "error", err)
+	now := time.Now()
+	visible := make([]*domain_event.Event, 0, len(events))
+	for _, event := range events {
+		if event.IsVisible() {
+			visible = append(visible, event.WithSalesCountdown(now))
+		}
 	}
 
-	return event, nil
+	return visible, nil
 }
 
-// GetAllEvents retrieves all events
-func (e *EventUsecase) GetAllEvents(ctx context.Context) ([]*domain_event.Event, error) {
+// GetAllEventsAdmin retrieves all events including drafts
+func (e *EventUsecase) GetAllEventsAdmin(ctx context.Context) ([]*domain_event.Event, error) {
+	events, err := e.getAllEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, event := range events {
+		event.WithSalesCountdown(now)
+	}
+
+	return events, nil
+}
+
+// SearchEvents retrieves customer-visible events matching the filter, the
+// same statuses GetAllEvents lists
+func (e *EventUsecase) SearchEvents(ctx context.Context, filter domain_event.EventFilter) ([]*domain_event.Event, error) {
+	filter.Statuses = []domain_event.EventStatus{domain_event.EventStatusPublished, domain_event.EventStatusArchived}
+	return e.searchEvents(ctx, filter)
+}
+
+// SearchEventsAdmin retrieves events of any status matching the filter
+func (e *EventUsecase) SearchEventsAdmin(ctx context.Context, filter domain_event.EventFilter) ([]*domain_event.Event, error) {
+	return e.searchEvents(ctx, filter)
+}
+
+// searchEvents always reads from the database; filtered listings are not cached
+func (e *EventUsecase) searchEvents(ctx context.Context, filter domain_event.EventFilter) ([]*domain_event.Event, error) {
+	events, err := e.eventRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, event := range events {
+		event.WithSalesCountdown(now)
+	}
+
+	return events, nil
+}
+
+// getAllEvents retrieves all events regardless of their status
+func (e *EventUsecase) getAllEvents(ctx context.Context) ([]*domain_event.Event, error) {
+	version, err := e.cacheVersion(ctx)
+	if err != nil {
+		return e.eventRepo.GetAll(ctx)
+	}
+
 	// Try cache first
-	events, err := e.cacheRepo.GetAll(ctx)
+	events, err := e.cacheRepo.GetAll(ctx, version)
 	if err == nil && events != nil {
 		return events, nil
 	}
 
-	// Fallback to database
-	events, err = e.eventRepo.GetAll(ctx)
+	// Fallback to database, once for every caller that missed the listing
+	key := fmt.Sprintf("%s:events:all:%d", tenant.FromContext(ctx), version)
+	loaded, err := e.loads.Do(ctx, key, func() (interface{}, error) {
+		events, err := e.eventRepo.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// Cache the result under the version read before loading it
+		if err := e.cacheRepo.SetAllEvents(ctx, version, events); err != nil {
+			e.logger.Warn("Failed to cache all events", "error", err)
+		}
+		return events, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Cache the result
-	if err := e.cacheRepo.SetAllEvents(ctx, events); err != nil {
-		e.logger.Warn("Failed to cache all events", "error", err)
+	// Callers change the events they get, so each gets copies of the shared ones
+	shared := loaded.([]*domain_event.Event)
+	events = make([]*domain_event.Event, len(shared))
+	for i, event := range shared {
+		copied := *event
+		events[i] = &copied
 	}
-
 	return events, nil
 }
 
+// WarmCache loads every event from the database and caches the listing
+// and each upcoming event, so the first requests after a start do not all
+// miss. It returns how many events were cached on their own.
+func (e *EventUsecase) WarmCache(ctx context.Context) (int, error) {
+	version, err := e.cacheVersion(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read event cache version: %w", err)
+	}
+	events, err := e.eventRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if err := e.cacheRepo.SetAllEvents(ctx, version, events); err != nil {
+		return 0, fmt.Errorf("failed to cache all events: %w", err)
+	}
+
+	now := time.Now()
+	cached := 0
+	for _, event := range events {
+		if !event.Date.After(now) {
+			continue
+		}
+		if err := e.cacheRepo.Set(ctx, version, event); err != nil {
+			return cached, fmt.Errorf("failed to cache event %s: %w", event.ID, err)
+		}
+		cached++
+	}
+	return cached, nil
+}
+
+// CacheLoadStats reports how many event cache misses loaded from the
+// database and how many shared a load already running
+func (e *EventUsecase) CacheLoadStats() map[string]interface{} {
+	return e.loads.Stats()
+}
+
 // GetEventTickets retrieves all tickets for an event
 func (e *EventUsecase) GetEventTickets(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	if _, err := e.GetEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
 	return e.ticketRepo.GetByEventID(ctx, eventID)
 }
 
+// GetEventsByIDs retrieves events by ID regardless of their status, for
+// resolving the events that bookings refer to. Missing IDs are skipped.
+func (e *EventUsecase) GetEventsByIDs(ctx context.Context, eventIDs []uuid.UUID) ([]*domain_event.Event, error) {
+	return e.eventRepo.GetByIDs(ctx, eventIDs)
+}
+
+// GetTicketsByIDs retrieves tickets by ID. Missing IDs are skipped.
+func (e *EventUsecase) GetTicketsByIDs(ctx context.Context, ticketIDs []uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	return e.ticketRepo.GetByIDs(ctx, ticketIDs)
+}
+
 // GetAvailableTickets retrieves available tickets for an event
 func (e *EventUsecase) GetAvailableTickets(ctx context.Context, eventID uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	if _, err := e.GetEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
 	return e.ticketRepo.GetAvailableByEventID(ctx, eventID)
 }
+
+// TransitionEventStatus moves an event to a new lifecycle state
+func (e *EventUsecase) TransitionEventStatus(ctx context.Context, eventID uuid.UUID, status domain_event.EventStatus) (*domain_event.Event, error) {
+	if !status.IsValid() {
+		return nil, fmt.Errorf("%w: unknown event status %q", domain.ErrInvalidInput, status)
+	}
+
+	// Always read from the database so the transition is based on the latest state
+	event, err := e.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("%w: cannot transition event from %s to %s", domain.ErrConflict, event.Status, status)
+	}
+
+	previous := event.Status
+	event.Status = status
+	event.UpdatedAt = time.Now()
+
+	if err := e.eventRepo.Update(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+
+	// Drop cached copies so visibility changes take effect immediately
+	e.invalidateCache(ctx)
+
+	e.logger.Info("Event status changed", "event_id", eventID, "from", previous, "to", status)
+
+	return event, nil
+}
+
+// invalidateCache drops every cached event and listing after an event
+// changed, and tells the other instances to forget the old cache version
+func (e *EventUsecase) invalidateCache(ctx context.Context) {
+	if err := e.cacheRepo.Invalidate(ctx); err != nil {
+		e.logger.Warn("Failed to invalidate event cache", "error", err)
+	}
+	e.forgetVersion(ctx)
+	if err := e.invalidations.Publish(ctx, repository.CacheInvalidation{Kind: repository.CacheInvalidateEvents}); err != nil {
+		e.logger.Warn("Failed to publish event cache invalidation", "error", err)
+	}
+}
+
+// cacheVersion returns the tenant's event cache version, remembered for
+// versionTTL so most reads skip asking Redis for it
+func (e *EventUsecase) cacheVersion(ctx context.Context) (int64, error) {
+	id := tenant.FromContext(ctx)
+	e.versionsMu.Lock()
+	remembered, ok := e.versions[id]
+	forgotten := e.forgotten
+	e.versionsMu.Unlock()
+	if ok && time.Now().Before(remembered.expiresAt) {
+		return remembered.version, nil
+	}
+
+	version, err := e.cacheRepo.Version(ctx)
+	if err != nil || e.versionTTL == 0 {
+		return version, err
+	}
+
+	e.versionsMu.Lock()
+	defer e.versionsMu.Unlock()
+	// An invalidation that arrived while reading may predate this version
+	if e.forgotten == forgotten {
+		e.versions[id] = rememberedVersion{version: version, expiresAt: time.Now().Add(e.versionTTL)}
+	}
+	return version, nil
+}
+
+// forgetVersion drops the remembered cache version of ctx's tenant
+func (e *EventUsecase) forgetVersion(ctx context.Context) {
+	e.versionsMu.Lock()
+	defer e.versionsMu.Unlock()
+	delete(e.versions, tenant.FromContext(ctx))
+	e.forgotten++
+}
+
+// forgetVersions drops the remembered cache versions of every tenant
+func (e *EventUsecase) forgetVersions() {
+	e.versionsMu.Lock()
+	defer e.versionsMu.Unlock()
+	e.versions = make(map[string]rememberedVersion)
+	e.forgotten++
+}
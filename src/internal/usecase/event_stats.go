@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// Events are reconciled until a day after they start, by when late
+// confirmations and cancellations have settled
+const eventStatsReconcileGrace = 24 * time.Hour
+
+type EventStatsUsecase struct {
+	statsRepo  repository.EventStatsRepository
+	statsCache repository.EventStatsCacheRepository
+	eventRepo  repository.EventRepository
+	logger     *utils.Logger
+
+	reconcileInterval time.Duration
+}
+
+// NewEventStatsUsecase creates a new event statistics usecase
+func NewEventStatsUsecase(statsRepo repository.EventStatsRepository, statsCache repository.EventStatsCacheRepository, eventRepo repository.EventRepository, config *utils.Config, logger *utils.Logger) *EventStatsUsecase {
+	return &EventStatsUsecase{
+		statsRepo:         statsRepo,
+		statsCache:        statsCache,
+		eventRepo:         eventRepo,
+		logger:            logger,
+		reconcileInterval: time.Duration(max(config.EventStatsReconcileSeconds, 1)) * time.Second,
+	}
+}
+
+// BookingCreated counts a new booking
+func (s *EventStatsUsecase) BookingCreated(ctx context.Context, booking *domain_booking.Booking) {
+	s.add(ctx, booking.EventID, domain_stats.Delta{Created: 1})
+}
+
+// BookingConfirmed counts a confirmed booking, its tickets and its revenue
+func (s *EventStatsUsecase) BookingConfirmed(ctx context.Context, booking *domain_booking.Booking) {
+	s.add(ctx, booking.EventID, domain_stats.Delta{
+		Confirmed:    1,
+		RevenueCents: revenueCents(booking.TotalAmount, booking.ExchangeRate),
+		TicketsSold:  int64(len(booking.TicketIDs)),
+	})
+}
+
+// BookingCancelled counts a booking cancelled by its customer
+func (s *EventStatsUsecase) BookingCancelled(ctx context.Context, booking *domain_booking.Booking) {
+	s.add(ctx, booking.EventID, domain_stats.Delta{Cancelled: 1})
+}
+
+// TicketsReturned counts tickets of a booking given back for resale
+func (s *EventStatsUsecase) TicketsReturned(ctx context.Context, booking *domain_booking.Booking, count int) {
+	s.add(ctx, booking.EventID, domain_stats.Delta{TicketsReturned: int64(count)})
+}
+
+// BookingRepriced moves a confirmed booking's revenue from its previous
+// total to its current one
+func (s *EventStatsUsecase) BookingRepriced(ctx context.Context, booking *domain_booking.Booking, previousTotal float64) {
+	delta := revenueCents(booking.TotalAmount, booking.ExchangeRate) - revenueCents(previousTotal, booking.ExchangeRate)
+	s.add(ctx, booking.EventID, domain_stats.Delta{RevenueCents: delta})
+}
+
+// add applies a change to the cached counters. Failures are logged rather
+// than returned so statistics never block the booking flow; the next
+// reconciliation corrects them.
+func (s *EventStatsUsecase) add(ctx context.Context, eventID uuid.UUID, delta domain_stats.Delta) {
+	if err := s.statsCache.Add(ctx, eventID, delta); err != nil {
+		s.logger.Warn("Failed to update event stats", "event_id", eventID, "error", err)
+	}
+}
+
+// revenueCents converts an amount charged at a booking's exchange rate back
+// to base currency cents, rounding the way Postgres does when reconciling
+func revenueCents(amount float64, exchangeRate *float64) int64 {
+	if exchangeRate != nil && *exchangeRate > 0 {
+		amount /= *exchangeRate
+	}
+	return int64(math.Round(amount * 100))
+}
+
+// GetEventStats returns an event's counters from the cache, seeding them
+// from Postgres the first time they are asked for
+func (s *EventStatsUsecase) GetEventStats(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error) {
+	if _, err := s.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.statsCache.Get(ctx, eventID)
+	if err == nil {
+		return stats, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		s.logger.Warn("Failed to read cached event stats", "event_id", eventID, "error", err)
+	}
+	return s.reconcile(ctx, eventID)
+}
+
+// reconcile recounts one event's bookings and replaces its cached counters
+func (s *EventStatsUsecase) reconcile(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error) {
+	stats, err := s.statsRepo.GetByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	stats.ReconciledAt = time.Now().UTC()
+	if err := s.statsCache.Set(ctx, stats); err != nil {
+		s.logger.Warn("Failed to cache event stats", "event_id", eventID, "error", err)
+	}
+	return stats, nil
+}
+
+// Reconcile recounts the bookings of upcoming and recent events and replaces
+// their cached counters, correcting any drift from missed updates. It
+// returns how many events were reconciled.
+func (s *EventStatsUsecase) Reconcile(ctx context.Context) (int, error) {
+	all, err := s.statsRepo.GetForEventsFrom(ctx, time.Now().Add(-eventStatsReconcileGrace))
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now().UTC()
+	reconciled := 0
+	for _, stats := range all {
+		stats.ReconciledAt = now
+		if err := s.statsCache.Set(ctx, stats); err != nil {
+			s.logger.Warn("Failed to cache event stats", "event_id", stats.EventID, "error", err)
+			continue
+		}
+		reconciled++
+	}
+	return reconciled, nil
+}
+
+// Run reconciles the cached counters on each tick until the context is cancelled
+func (s *EventStatsUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			count, err := s.Reconcile(ctx)
+			if err != nil {
+				s.logger.Error("Failed to reconcile event stats", "error", err)
+				continue
+			}
+			s.logger.Debug("Reconciled event stats", "events", count)
+		}
+	}
+}
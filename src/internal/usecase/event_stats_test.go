@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// recordingStatsCache sums the changes made to each event's counters
+type recordingStatsCache struct {
+	totals map[uuid.UUID]domain_stats.Delta
+}
+
+func (c *recordingStatsCache) Get(ctx context.Context, eventID uuid.UUID) (*domain_stats.EventStats, error) {
+	return nil, nil
+}
+
+func (c *recordingStatsCache) Set(ctx context.Context, stats *domain_stats.EventStats) error {
+	return nil
+}
+
+func (c *recordingStatsCache) Add(ctx context.Context, eventID uuid.UUID, delta domain_stats.Delta) error {
+	total := c.totals[eventID]
+	total.Created += delta.Created
+	total.Confirmed += delta.Confirmed
+	total.Cancelled += delta.Cancelled
+	total.RevenueCents += delta.RevenueCents
+	total.TicketsSold += delta.TicketsSold
+	total.TicketsReturned += delta.TicketsReturned
+	c.totals[eventID] = total
+	return nil
+}
+
+func TestEventStatsHooks(t *testing.T) {
+	cache := &recordingStatsCache{totals: map[uuid.UUID]domain_stats.Delta{}}
+	stats := NewEventStatsUsecase(nil, cache, nil, &utils.Config{}, utils.NewLogger())
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	// Charged 55.00 EUR at 1.1 EUR per USD, so 50.00 USD of revenue
+	rate := 1.1
+	paid := &domain_booking.Booking{EventID: eventID, TicketIDs: []uuid.UUID{uuid.New(), uuid.New()}, TotalAmount: 55, ExchangeRate: &rate}
+	stats.BookingCreated(ctx, paid)
+	stats.BookingConfirmed(ctx, paid)
+
+	// Moved to cheaper seats after confirming
+	paid.TotalAmount = 44
+	stats.BookingRepriced(ctx, paid, 55)
+
+	// One of its tickets given back for resale
+	stats.TicketsReturned(ctx, paid, 1)
+
+	abandoned := &domain_booking.Booking{EventID: eventID, TotalAmount: 20}
+	stats.BookingCreated(ctx, abandoned)
+	stats.BookingCancelled(ctx, abandoned)
+
+	want := domain_stats.Delta{Created: 2, Confirmed: 1, Cancelled: 1, RevenueCents: 4000, TicketsSold: 2, TicketsReturned: 1}
+	if got := cache.totals[eventID]; got != want {
+		t.Errorf("counters = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/health"
+)
+
+// Liveness is what /healthz reports: only that the process is running
+type Liveness struct {
+	Status    string    `json:"status"`
+	StartedAt time.Time `json:"started_at"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// HealthUsecase answers liveness and readiness probes. Readiness checks the
+// booking queue's saturation and processor here; the storage checks are
+// added by whatever opened the connections, and workers report through
+// Heartbeats.
+type HealthUsecase struct {
+	checker    *health.Checker
+	heartbeats *health.Heartbeats
+	startedAt  time.Time
+	logger     *utils.Logger
+}
+
+// NewHealthUsecase creates a new health usecase
+func NewHealthUsecase(bookings *BookingUsecase, config *utils.Config, logger *utils.Logger) *HealthUsecase {
+	heartbeats := health.NewHeartbeats(time.Duration(config.HealthWorkerStaleSeconds) * time.Second)
+	h := &HealthUsecase{
+		checker:    health.NewChecker(time.Duration(config.HealthCheckTimeoutMs)*time.Millisecond, heartbeats),
+		heartbeats: heartbeats,
+		startedAt:  time.Now().UTC(),
+		logger:     logger,
+	}
+	if bookings != nil {
+		bookings.processor.Heartbeat(heartbeats, "booking_processor")
+	}
+	if saturation := config.HealthQueueSaturation; saturation > 0 && bookings != nil {
+		h.AddCheck("booking_queue", false, func(ctx context.Context) error {
+			pending, capacity := bookings.QueueFill()
+			if capacity > 0 && float64(pending)/float64(capacity) >= saturation {
+				return fmt.Errorf("saturated: %d of %d slots in use", pending, capacity)
+			}
+			return nil
+		})
+	}
+	return h
+}
+
+// AddCheck registers a dependency for readiness. A critical one being down
+// makes the instance unavailable; any other only degrades it.
+func (h *HealthUsecase) AddCheck(name string, critical bool, check health.CheckFunc) {
+	h.checker.Add(name, critical, check)
+}
+
+// Heartbeats returns the registry background workers beat into
+func (h *HealthUsecase) Heartbeats() *health.Heartbeats {
+	return h.heartbeats
+}
+
+// Liveness reports the process as alive. It checks no dependencies, so an
+// outage elsewhere does not get healthy instances restarted.
+func (h *HealthUsecase) Liveness() Liveness {
+	return Liveness{Status: "alive", StartedAt: h.startedAt, Timestamp: time.Now().UTC()}
+}
+
+// Readiness checks every dependency, logging those found down
+func (h *HealthUsecase) Readiness(ctx context.Context) health.Report {
+	report := h.checker.Check(ctx)
+	for name, result := range report.Checks {
+		if result.Status == health.StatusDown {
+			h.logger.Warn("Health check failed", "check", name, "critical", result.Critical, "error", result.Error)
+		}
+	}
+	return report
+}
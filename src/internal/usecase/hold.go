@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_hold "github.com/ojaswiii/booking-manager/src/internal/domain/hold"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+type HoldUsecase struct {
+	holdRepo     repository.HoldRepository
+	eventRepo    repository.EventRepository
+	availability *AvailabilityUsecase
+	logger       *utils.Logger
+}
+
+// NewHoldUsecase creates a new seat hold usecase
+func NewHoldUsecase(holdRepo repository.HoldRepository, eventRepo repository.EventRepository, availability *AvailabilityUsecase, logger *utils.Logger) *HoldUsecase {
+	return &HoldUsecase{
+		holdRepo:     holdRepo,
+		eventRepo:    eventRepo,
+		availability: availability,
+		logger:       logger,
+	}
+}
+
+// CreateHold takes a range of seats off public sale for a sponsor, artist or press
+func (h *HoldUsecase) CreateHold(ctx context.Context, eventID uuid.UUID, req domain_hold.CreateHoldRequest) (*domain_hold.Hold, error) {
+	if !req.Kind.Valid() {
+		return nil, fmt.Errorf("%w: kind must be sponsor, artist or press", domain.ErrInvalidInput)
+	}
+	if req.FromSeat < 1 || req.ToSeat < req.FromSeat {
+		return nil, fmt.Errorf("%w: invalid seat range %d-%d", domain.ErrInvalidInput, req.FromSeat, req.ToSeat)
+	}
+
+	event, err := h.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ToSeat > event.TotalSeats {
+		return nil, fmt.Errorf("%w: event has only %d seats", domain.ErrInvalidInput, event.TotalSeats)
+	}
+
+	hold := &domain_hold.Hold{
+		ID:        uuid.New(),
+		EventID:   event.ID,
+		Kind:      req.Kind,
+		Label:     strings.TrimSpace(req.Label),
+		FromSeat:  req.FromSeat,
+		ToSeat:    req.ToSeat,
+		Status:    domain_hold.StatusActive,
+		CreatedAt: time.Now(),
+	}
+	if err := h.holdRepo.Create(ctx, hold); err != nil {
+		return nil, err
+	}
+
+	h.logger.Info("Seats held",
+		"event_id", event.ID,
+		"hold_id", hold.ID,
+		"kind", hold.Kind,
+		"seats", hold.Seats)
+	h.availability.Refresh(ctx, event.ID)
+	return hold, nil
+}
+
+// ListHolds returns every hold of an event, active and released
+func (h *HoldUsecase) ListHolds(ctx context.Context, eventID uuid.UUID) ([]*domain_hold.Hold, error) {
+	if _, err := h.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return h.holdRepo.GetByEventID(ctx, eventID)
+}
+
+// ReleaseHold puts the seats of a hold back on public sale
+func (h *HoldUsecase) ReleaseHold(ctx context.Context, eventID, holdID uuid.UUID) (*domain_hold.ReleaseHoldResponse, error) {
+	hold, err := h.holdRepo.GetByID(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+	if hold.EventID != eventID {
+		return nil, domain.ErrNotFound
+	}
+	if hold.Status != domain_hold.StatusActive {
+		return nil, fmt.Errorf("%w: hold already released", domain.ErrConflict)
+	}
+
+	now := time.Now()
+	released, err := h.holdRepo.Release(ctx, hold.ID, now)
+	if err != nil {
+		return nil, err
+	}
+	hold.Status = domain_hold.StatusReleased
+	hold.ReleasedAt = &now
+
+	h.logger.Info("Held seats released", "event_id", eventID, "hold_id", hold.ID, "seats", released)
+	h.availability.Refresh(ctx, eventID)
+	return &domain_hold.ReleaseHoldResponse{Hold: hold, Released: released}, nil
+}
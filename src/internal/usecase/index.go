@@ -3,20 +3,117 @@ package usecase
 import (
 	"github.com/ojaswiii/booking-manager/src/internal/repository"
 	"github.com/ojaswiii/booking-manager/src/utils"
+	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+	"github.com/ojaswiii/booking-manager/src/utils/oauth"
+	"github.com/ojaswiii/booking-manager/src/utils/payments"
 )
 
 // UsecaseContainer holds all usecase instances
 type UsecaseContainer struct {
-	User    *UserUsecase
-	Event   *EventUsecase
-	Booking *BookingUsecase
+	User      *UserUsecase
+	UserAdmin *UserAdminUsecase
+	Auth      *AuthUsecase
+	APIKey    *APIKeyUsecase
+	Event     *EventUsecase
+	Booking   *BookingUsecase
+	Quote     *QuoteUsecase
+
+	WaitingRoom *WaitingRoomUsecase
+	Presale     *PresaleUsecase
+	Webhook     *WebhookUsecase
+	Template    *TemplateUsecase
+
+	Notification *NotificationUsecase
+	Status       *StatusUsecase
+	TicketPass   *TicketPassUsecase
+	CheckIn      *CheckInUsecase
+	Hold         *HoldUsecase
+	Job          *JobUsecase
+	Refund       *RefundUsecase
+	Broadcast    *BroadcastUsecase
+	Payment      *PaymentUsecase
+
+	ColumnMigration *ColumnMigrationUsecase
+	SeatSuggestion  *SeatSuggestionUsecase
+	EventStats      *EventStatsUsecase
+	Report          *ReportUsecase
+	BookingExport   *BookingExportUsecase
+	Calendar        *CalendarUsecase
+	Privacy         *PrivacyUsecase
+	Deletion        *DeletionUsecase
+	Availability    *AvailabilityUsecase
+	ReadModel       *ReadModelUsecase
+
+	AvailabilityFeed  *AvailabilityFeed
+	BookingUpdates    *BookingUpdateFeed
+	BookingMetrics    *BookingMetricsUsecase
+	CacheInvalidation *CacheInvalidationListener
+	Health            *HealthUsecase
 }
 
 // NewUsecaseContainer creates a new usecase container
-func NewUsecaseContainer(repos *repository.RepositoryContainer, logger *utils.Logger) *UsecaseContainer {
+func NewUsecaseContainer(repos *repository.RepositoryContainer, notifier notify.Notifier, provider payments.Provider, overload *concurrency.OverloadPolicy, durable *concurrency.DurableQueue, config *utils.Config, logger *utils.Logger) *UsecaseContainer {
+	quotes := NewQuoteUsecase(repos.Event, repos.Ticket, config, logger)
+	gates := NewDefaultConfirmationGates(config)
+	sla := NewBookingSLATracker(config, logger)
+	waitingRoom := NewWaitingRoomUsecase(repos.Event, repos.WaitingRoom, sla, overload, config, logger)
+	jobs := NewJobUsecase(repos.Job, config, logger)
+	presale := NewPresaleUsecase(repos.Presale, repos.Event, jobs, logger)
+	events := NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, repos.CacheInvalidation, gates, config, logger)
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	webhooks := NewWebhookUsecase(repos.Webhook, users, config, logger)
+	notifications := NewNotificationUsecase(repos.Notification, repos.Booking, repos.Event, repos.User, notifier, config, logger)
+	passes := NewTicketPassUsecase(repos.TicketPass, repos.Booking, repos.Event, repos.Ticket, repos.User, config, logger)
+	stats := NewEventStatsUsecase(repos.EventStats, repos.EventStatsCache, repos.Event, config, logger)
+	availabilityFeed := NewAvailabilityFeed(repos.AvailabilityFeed, logger)
+	bookingUpdates := NewBookingUpdateFeed(repos.BookingUpdates, logger)
+	bookingMetrics := NewBookingMetricsUsecase(repos.BookingMetrics, config, logger)
+	availability := NewAvailabilityUsecase(repos.Ticket, repos.Event, repos.Availability, availabilityFeed, config, logger)
+	readModels := NewReadModelUsecase(repos.ReadModel, repos.BookingEvent, repos.Event, repos.Ticket, config, logger)
+	bookings := NewBookingUsecase(repos.Booking, repos.BookingCache, repos.Ticket, repos.Event, repos.User, repos.Outbox, repos.BookingTransition, repos.BookingEvent, repos.DeadLetter, repos.Transactor, repos.Queries, quotes, gates, waitingRoom, presale, sla, overload, durable, webhooks, notifications, passes, stats, availability, bookingUpdates, bookingMetrics, readModels, config, logger)
+
+	auth := NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, users, oauth.NewProviders(config), notifier, config, logger)
+
 	return &UsecaseContainer{
-		User:    NewUserUsecase(repos.User, repos.UserCache, logger),
-		Event:   NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, logger),
-		Booking: NewBookingUsecase(repos.Booking, repos.Ticket, repos.Event, repos.User, logger),
+		User:      users,
+		UserAdmin: NewUserAdminUsecase(repos.User, repos.Audit, repos.Transactor, users, bookings, auth, logger),
+		Auth:      auth,
+		APIKey:    NewAPIKeyUsecase(repos.APIKey, repos.RateLimit, users, config, logger),
+		Event:     events,
+		Booking:   bookings,
+		Quote:     quotes,
+
+		WaitingRoom: waitingRoom,
+		Presale:     presale,
+		Webhook:     webhooks,
+		Template:    NewTemplateUsecase(repos.Template, repos.Event, repos.Ticket, events, logger),
+
+		Notification: notifications,
+		Status:       NewStatusUsecase(sla, overload, logger),
+		TicketPass:   passes,
+		CheckIn:      NewCheckInUsecase(repos.TicketPass, repos.Booking, repos.Event, repos.Ticket, repos.User, passes, config, logger),
+		Hold:         NewHoldUsecase(repos.Hold, repos.Event, availability, logger),
+		Job:          jobs,
+		Refund:       NewRefundUsecase(repos.Refund, repos.Booking, repos.Event, repos.Ticket, repos.TicketPass, repos.Outbox, repos.BookingTransition, repos.BookingEvent, repos.Transactor, provider, webhooks, stats, availability, config, logger),
+		Payment:      NewPaymentUsecase(provider, bookings, logger),
+		Broadcast:    NewBroadcastUsecase(repos.Broadcast, repos.Event, repos.User, repos.Transactor, notifications, notifier, jobs, config, logger),
+
+		ColumnMigration: NewColumnMigrationUsecase(repos.ColumnMigration, jobs, logger),
+		SeatSuggestion:  NewSeatSuggestionUsecase(repos.Event, repos.Ticket, repos.SeatSuggestion, config, logger),
+		EventStats:      stats,
+		Report:          NewReportUsecase(repos.SalesReport, users, logger),
+		BookingExport:   NewBookingExportUsecase(repos.BookingExport, repos.Event, users, config, logger),
+		Calendar:        NewCalendarUsecase(events, users, repos.Booking, repos.Event, config, logger),
+		Privacy:         NewPrivacyUsecase(repos.User, repos.Booking, repos.TicketPass, repos.Notification, repos.Audit, repos.Transactor, users, logger),
+		Deletion:        NewDeletionUsecase(repos.User, repos.Event, users, events, logger),
+		Availability:    availability,
+		ReadModel:       readModels,
+
+		AvailabilityFeed:  availabilityFeed,
+		BookingUpdates:    bookingUpdates,
+		BookingMetrics:    bookingMetrics,
+		CacheInvalidation: NewCacheInvalidationListener(repos.CacheInvalidation, events, users, logger),
+		Health:            NewHealthUsecase(bookings, config, logger),
 	}
 }
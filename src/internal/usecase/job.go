@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/health"
+
+	"github.com/google/uuid"
+)
+
+// JobHandler runs one job of a kind. It reports progress as it goes and
+// returns the URL the result can be fetched from, if any.
+type JobHandler func(ctx context.Context, job *domain_job.Job, progress ProgressFunc) (resultURL string, err error)
+
+// ProgressFunc records that done out of total units of work are complete
+type ProgressFunc func(done, total int)
+
+type JobUsecase struct {
+	jobRepo  repository.JobRepository
+	handlers map[string]JobHandler
+	logger   *utils.Logger
+
+	pollInterval time.Duration
+	lease        time.Duration
+	maxAttempts  int
+	slots        chan struct{}
+}
+
+// NewJobUsecase creates a new background job usecase
+func NewJobUsecase(jobRepo repository.JobRepository, config *utils.Config, logger *utils.Logger) *JobUsecase {
+	return &JobUsecase{
+		jobRepo:      jobRepo,
+		handlers:     make(map[string]JobHandler),
+		logger:       logger,
+		pollInterval: time.Duration(config.JobPollIntervalMs) * time.Millisecond,
+		lease:        time.Duration(config.JobLeaseSeconds) * time.Second,
+		maxAttempts:  config.JobMaxAttempts,
+		slots:        make(chan struct{}, max(config.JobConcurrency, 1)),
+	}
+}
+
+// Register sets the handler for a kind of job. Handlers are registered at
+// startup, before Run is called.
+func (j *JobUsecase) Register(kind string, handler JobHandler) {
+	j.handlers[kind] = handler
+}
+
+// Submit queues a job of a registered kind with its parameters
+func (j *JobUsecase) Submit(ctx context.Context, kind string, params interface{}) (*domain_job.Job, error) {
+	if _, ok := j.handlers[kind]; !ok {
+		return nil, fmt.Errorf("no handler for job kind %q", kind)
+	}
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job params: %w", err)
+	}
+
+	now := time.Now()
+	job := &domain_job.Job{
+		ID:        uuid.New(),
+		Kind:      kind,
+		State:     domain_job.StateQueued,
+		Params:    raw,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := j.jobRepo.Create(ctx, job); err != nil {
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	j.logger.Info("Job queued", "job_id", job.ID, "kind", kind)
+	return job, nil
+}
+
+// GetJob returns the current state of a job
+func (j *JobUsecase) GetJob(ctx context.Context, id uuid.UUID) (*domain_job.Job, error) {
+	return j.jobRepo.GetByID(ctx, id)
+}
+
+// Run claims and executes queued jobs until the context is cancelled. At
+// most JOB_CONCURRENCY jobs run at a time on this instance.
+func (j *JobUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.dispatch(ctx)
+			health.Beat(ctx)
+		}
+	}
+}
+
+// dispatch claims as many jobs as there are free slots and starts them
+func (j *JobUsecase) dispatch(ctx context.Context) {
+	free := cap(j.slots) - len(j.slots)
+	if free == 0 {
+		return
+	}
+	jobs, err := j.jobRepo.ClaimDue(ctx, time.Now(), j.lease, free)
+	if err != nil {
+		j.logger.Error("Failed to claim jobs", "error", err)
+		return
+	}
+
+	for _, job := range jobs {
+		j.slots <- struct{}{}
+		go func(job *domain_job.Job) {
+			defer func() { <-j.slots }()
+			j.execute(ctx, job)
+		}(job)
+	}
+}
+
+// execute runs a claimed job and records its outcome. Jobs whose worker died
+// are picked up again once their lease lapses, up to JOB_MAX_ATTEMPTS times.
+func (j *JobUsecase) execute(ctx context.Context, job *domain_job.Job) {
+	handler, ok := j.handlers[job.Kind]
+	switch {
+	case !ok:
+		j.finish(ctx, job, "", fmt.Errorf("no handler for job kind %q", job.Kind))
+		return
+	case job.Attempts > j.maxAttempts:
+		j.finish(ctx, job, "", fmt.Errorf("abandoned after %d attempts", j.maxAttempts))
+		return
+	}
+
+	j.logger.Info("Job started", "job_id", job.ID, "kind", job.Kind, "attempt", job.Attempts)
+
+	progress := func(done, total int) {
+		if total <= 0 {
+			return
+		}
+		// Reporting progress also renews the lease
+		now := time.Now()
+		leaseUntil := now.Add(j.lease)
+		job.Progress = min(done*100/total, 100)
+		job.LeaseUntil = &leaseUntil
+		job.UpdatedAt = now
+		if err := j.jobRepo.Update(ctx, job); err != nil {
+			j.logger.Warn("Failed to record job progress", "job_id", job.ID, "error", err)
+		}
+	}
+
+	resultURL, err := handler(ctx, job, progress)
+	j.finish(ctx, job, resultURL, err)
+}
+
+// finish moves a job to its terminal state
+func (j *JobUsecase) finish(ctx context.Context, job *domain_job.Job, resultURL string, err error) {
+	now := time.Now()
+	job.LeaseUntil = nil
+	job.FinishedAt = &now
+	job.UpdatedAt = now
+
+	if err != nil {
+		msg := err.Error()
+		job.State = domain_job.StateFailed
+		job.Error = &msg
+		j.logger.Warn("Job failed", "job_id", job.ID, "kind", job.Kind, "error", err)
+	} else {
+		job.State = domain_job.StateSucceeded
+		job.Progress = 100
+		job.Error = nil
+		if resultURL != "" {
+			job.ResultURL = &resultURL
+		}
+		j.logger.Info("Job succeeded", "job_id", job.ID, "kind", job.Kind)
+	}
+
+	if err := j.jobRepo.Update(ctx, job); err != nil {
+		j.logger.Error("Failed to record job outcome", "job_id", job.ID, "error", err)
+	}
+}
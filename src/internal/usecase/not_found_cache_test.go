@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestMissingUserIsAnsweredFromCache(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	config := &utils.Config{CacheNotFoundTTLMs: int(time.Hour / time.Millisecond)}
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, utils.NewLogger())
+	ctx := context.Background()
+
+	userID := uuid.New()
+	if _, err := users.GetUser(ctx, userID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("first lookup got %v, want %v", err, domain.ErrNotFound)
+	}
+
+	// Written behind the usecase's back, so only the database has it
+	if err := repos.User.Create(ctx, &domain_user.User{ID: userID, Email: "late@example.com"}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if _, err := users.GetUser(ctx, userID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("second lookup got %v, want the cached %v", err, domain.ErrNotFound)
+	}
+
+	// Caching the user replaces the marker
+	if err := repos.UserCache.Create(ctx, &domain_user.User{ID: userID, Email: "late@example.com"}); err != nil {
+		t.Fatalf("cache user: %v", err)
+	}
+	if _, err := users.GetUser(ctx, userID); err != nil {
+		t.Fatalf("lookup after caching the user: %v", err)
+	}
+}
+
+func TestMissingUserIsNotCachedWhenDisabled(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, &utils.Config{}, utils.NewLogger())
+	ctx := context.Background()
+
+	userID := uuid.New()
+	if _, err := users.GetUser(ctx, userID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("first lookup got %v, want %v", err, domain.ErrNotFound)
+	}
+	if err := repos.User.Create(ctx, &domain_user.User{ID: userID, Email: "late@example.com"}); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	if _, err := users.GetUser(ctx, userID); err != nil {
+		t.Fatalf("second lookup: %v", err)
+	}
+}
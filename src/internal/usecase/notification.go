@@ -0,0 +1,322 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/health"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+
+	"github.com/google/uuid"
+)
+
+// Notification delivery tuning
+const (
+	notificationClaimBatch   = 50
+	notificationSendTimeout  = 30 * time.Second
+	notificationBaseBackoff  = 30 * time.Second
+	notificationMaxBackoff   = time.Hour
+	notificationScanInterval = time.Minute
+)
+
+type NotificationUsecase struct {
+	notificationRepo repository.NotificationRepository
+	bookingRepo      repository.BookingRepository
+	eventRepo        repository.EventRepository
+	userRepo         repository.UserRepository
+	notifier         notify.Notifier
+	logger           *utils.Logger
+
+	maxAttempts    int
+	pollInterval   time.Duration
+	expiryWarning  time.Duration
+	reminderWindow time.Duration
+}
+
+// NewNotificationUsecase creates a new notification usecase
+func NewNotificationUsecase(
+	notificationRepo repository.NotificationRepository,
+	bookingRepo repository.BookingRepository,
+	eventRepo repository.EventRepository,
+	userRepo repository.UserRepository,
+	notifier notify.Notifier,
+	config *utils.Config,
+	logger *utils.Logger,
+) *NotificationUsecase {
+	return &NotificationUsecase{
+		notificationRepo: notificationRepo,
+		bookingRepo:      bookingRepo,
+		eventRepo:        eventRepo,
+		userRepo:         userRepo,
+		notifier:         notifier,
+		logger:           logger,
+		maxAttempts:      config.NotifyMaxAttempts,
+		pollInterval:     time.Duration(config.NotifyPollIntervalSeconds) * time.Second,
+		expiryWarning:    time.Duration(config.NotifyExpiryWarningMinutes) * time.Minute,
+		reminderWindow:   time.Duration(config.NotifyEventReminderHours) * time.Hour,
+	}
+}
+
+// Notify queues an email about the booking for the worker. Failures are
+// logged rather than returned so email never blocks the booking flow.
+func (n *NotificationUsecase) Notify(ctx context.Context, kind domain_notification.Kind, booking *domain_booking.Booking) {
+	if err := n.notificationRepo.Enqueue(ctx, newNotification(kind, booking)); err != nil {
+		n.logger.Error("Failed to queue notification", "kind", kind, "booking_id", booking.ID, "error", err)
+	}
+}
+
+// GetPreferences returns a user's notification preferences with every kind
+// filled in; users who never set any get the defaults
+func (n *NotificationUsecase) GetPreferences(ctx context.Context, userID uuid.UUID) (*domain_notification.Preferences, error) {
+	if _, err := n.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	preferences, err := n.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return preferences.Resolved(), nil
+}
+
+// UpdatePreferences replaces a user's notification preferences
+func (n *NotificationUsecase) UpdatePreferences(ctx context.Context, userID uuid.UUID, req domain_notification.UpdatePreferencesRequest) (*domain_notification.Preferences, error) {
+	for kind := range req.Channels {
+		if !kind.Valid() {
+			return nil, fmt.Errorf("%w: unknown notification kind %q", domain.ErrInvalidInput, kind)
+		}
+	}
+	if _, err := n.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	preferences, err := n.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	preferences.Channels = req.Channels
+	preferences.UpdatedAt = time.Now()
+	if err := n.notificationRepo.SavePreferences(ctx, preferences); err != nil {
+		return nil, fmt.Errorf("failed to save preferences: %w", err)
+	}
+
+	n.logger.Info("Notification preferences updated", "user_id", userID)
+	return preferences.Resolved(), nil
+}
+
+// preferences loads a user's stored preferences, or defaults if there are none
+func (n *NotificationUsecase) preferences(ctx context.Context, userID uuid.UUID) (*domain_notification.Preferences, error) {
+	preferences, err := n.notificationRepo.GetPreferences(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		now := time.Now()
+		return &domain_notification.Preferences{UserID: userID, CreatedAt: now, UpdatedAt: now}, nil
+	}
+	return preferences, err
+}
+
+func newNotification(kind domain_notification.Kind, booking *domain_booking.Booking) *domain_notification.Notification {
+	now := time.Now()
+	return &domain_notification.Notification{
+		ID:            uuid.New(),
+		Kind:          kind,
+		BookingID:     booking.ID,
+		UserID:        booking.UserID,
+		EventID:       booking.EventID,
+		Status:        domain_notification.StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Run sends due notifications and schedules expiry warnings and event
+// reminders until the context is cancelled
+func (n *NotificationUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(n.pollInterval)
+	defer ticker.Stop()
+	scan := time.NewTicker(notificationScanInterval)
+	defer scan.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n.dispatch(ctx)
+			health.Beat(ctx)
+		case <-scan.C:
+			n.scheduleExpiryWarnings(ctx)
+			n.scheduleEventReminders(ctx)
+		}
+	}
+}
+
+// scheduleExpiryWarnings queues a warning for pending bookings about to lose their hold
+func (n *NotificationUsecase) scheduleExpiryWarnings(ctx context.Context) {
+	if n.expiryWarning <= 0 {
+		return
+	}
+	now := time.Now()
+	bookings, err := n.bookingRepo.GetExpiringBetween(ctx, now, now.Add(n.expiryWarning))
+	if err != nil {
+		n.logger.Error("Failed to load expiring bookings", "error", err)
+		return
+	}
+	n.enqueueAll(ctx, domain_notification.KindExpiryWarning, bookings)
+}
+
+// scheduleEventReminders queues a reminder for confirmed bookings of upcoming events
+func (n *NotificationUsecase) scheduleEventReminders(ctx context.Context) {
+	if n.reminderWindow <= 0 {
+		return
+	}
+	now := time.Now()
+	bookings, err := n.bookingRepo.GetConfirmedForEventsBetween(ctx, now, now.Add(n.reminderWindow))
+	if err != nil {
+		n.logger.Error("Failed to load bookings for event reminders", "error", err)
+		return
+	}
+	n.enqueueAll(ctx, domain_notification.KindEventReminder, bookings)
+}
+
+// enqueueAll queues one notification per booking; bookings already notified are skipped
+func (n *NotificationUsecase) enqueueAll(ctx context.Context, kind domain_notification.Kind, bookings []*domain_booking.Booking) {
+	if len(bookings) == 0 {
+		return
+	}
+	notifications := make([]*domain_notification.Notification, len(bookings))
+	for i, booking := range bookings {
+		notifications[i] = newNotification(kind, booking)
+	}
+	if err := n.notificationRepo.Enqueue(ctx, notifications...); err != nil {
+		n.logger.Error("Failed to queue notifications", "kind", kind, "error", err)
+	}
+}
+
+// dispatch claims a batch of due notifications and attempts each once
+func (n *NotificationUsecase) dispatch(ctx context.Context) {
+	// Lease long enough that a slow send is not picked up again mid-flight
+	lease := notificationSendTimeout + 30*time.Second
+	notifications, err := n.notificationRepo.ClaimDue(ctx, time.Now(), lease, notificationClaimBatch)
+	if err != nil {
+		n.logger.Error("Failed to claim notifications", "error", err)
+		return
+	}
+
+	for _, notification := range notifications {
+		n.attempt(ctx, notification)
+	}
+}
+
+// attempt renders and sends a notification and records the outcome,
+// scheduling a retry on failure
+func (n *NotificationUsecase) attempt(ctx context.Context, notification *domain_notification.Notification) {
+	now := time.Now()
+	notification.Attempts++
+	notification.UpdatedAt = now
+
+	email, err := n.render(ctx, notification)
+	if err == nil && email != nil {
+		sendCtx, cancel := context.WithTimeout(ctx, notificationSendTimeout)
+		err = n.notifier.Send(sendCtx, *email)
+		cancel()
+	}
+
+	switch {
+	case err == nil && email == nil:
+		notification.Status = domain_notification.StatusSkipped
+		notification.LastError = nil
+	case err == nil:
+		notification.Status = domain_notification.StatusSent
+		notification.LastError = nil
+		notification.SentAt = &now
+	case notification.Attempts >= n.maxAttempts:
+		msg := err.Error()
+		notification.Status = domain_notification.StatusFailed
+		notification.LastError = &msg
+		n.logger.Warn("Notification failed permanently",
+			"notification_id", notification.ID,
+			"kind", notification.Kind,
+			"attempts", notification.Attempts,
+			"error", err)
+	default:
+		msg := err.Error()
+		notification.LastError = &msg
+		notification.NextAttemptAt = now.Add(notificationBackoff(notification.Attempts))
+	}
+
+	if err := n.notificationRepo.Update(ctx, notification); err != nil {
+		n.logger.Error("Failed to record notification", "notification_id", notification.ID, "error", err)
+	}
+}
+
+// render loads the booking, event and user and renders the email. It returns
+// nil when the user has turned off email for this kind or the booking no
+// longer warrants the notification.
+func (n *NotificationUsecase) render(ctx context.Context, notification *domain_notification.Notification) (*notify.Email, error) {
+	tmpl, ok := emailTemplates[notification.Kind]
+	if !ok {
+		return nil, fmt.Errorf("no email template for %q", notification.Kind)
+	}
+
+	// Preferences are checked at send time so opting out also covers
+	// notifications that were already queued
+	preferences, err := n.preferences(ctx, notification.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load preferences: %w", err)
+	}
+	if !preferences.For(notification.Kind).Allows(domain_notification.ChannelEmail) {
+		return nil, nil
+	}
+
+	booking, err := n.bookingRepo.GetByID(ctx, notification.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load booking: %w", err)
+	}
+	if !stillApplies(notification.Kind, booking) {
+		return nil, nil
+	}
+	event, err := n.eventRepo.GetByID(ctx, notification.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event: %w", err)
+	}
+	user, err := n.userRepo.GetByID(ctx, notification.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	email, err := tmpl.render(EmailData{User: user, Event: event, Booking: booking})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render email: %w", err)
+	}
+	return &email, nil
+}
+
+// stillApplies reports whether the booking is still in the state the notification is about
+func stillApplies(kind domain_notification.Kind, booking *domain_booking.Booking) bool {
+	switch kind {
+	case domain_notification.KindExpiryWarning:
+		return booking.Status == domain_booking.BookingStatusPending && booking.ExpiresAt.After(time.Now())
+	case domain_notification.KindEventReminder:
+		return booking.Status == domain_booking.BookingStatusConfirmed
+	}
+	return true
+}
+
+// notificationBackoff doubles the retry delay after each failed attempt
+func notificationBackoff(attempts int) time.Duration {
+	backoff := notificationBaseBackoff
+	for i := 1; i < attempts && backoff < notificationMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > notificationMaxBackoff {
+		backoff = notificationMaxBackoff
+	}
+	return backoff
+}
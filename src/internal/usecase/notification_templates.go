@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"text/template"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+)
+
+// EmailData is what notification templates are rendered with. Broadcast is
+// only set for event broadcasts, which have no booking, VerifyURL only for
+// the verification email and the Reset fields only for password resets.
+type EmailData struct {
+	User      *domain_user.User
+	Event     *domain_event.Event
+	Booking   *domain_booking.Booking
+	Broadcast *domain_broadcast.Broadcast
+	VerifyURL string
+
+	ResetToken   string
+	ResetURL     string // empty when no reset page is configured
+	ResetMinutes int    // how long the token works
+}
+
+// emailTemplate holds the parsed subject, plain text and HTML bodies of one kind of email
+type emailTemplate struct {
+	subject *template.Template
+	text    *template.Template
+	html    *htmltemplate.Template
+}
+
+var emailFuncs = map[string]interface{}{
+	"datetime": func(t time.Time) string { return t.Format("Mon, 02 Jan 2006 15:04 MST") },
+	"money":    func(v float64) string { return fmt.Sprintf("%.2f", v) },
+}
+
+func newEmailTemplate(kind domain_notification.Kind, subject, text, html string) emailTemplate {
+	name := string(kind)
+	return emailTemplate{
+		subject: template.Must(template.New(name).Funcs(emailFuncs).Parse(subject)),
+		text:    template.Must(template.New(name).Funcs(emailFuncs).Parse(text)),
+		html:    htmltemplate.Must(htmltemplate.New(name).Funcs(emailFuncs).Parse(html)),
+	}
+}
+
+// render builds the email for the data's user
+func (t emailTemplate) render(data EmailData) (notify.Email, error) {
+	var subject, text, html bytes.Buffer
+	if err := t.subject.Execute(&subject, data); err != nil {
+		return notify.Email{}, err
+	}
+	if err := t.text.Execute(&text, data); err != nil {
+		return notify.Email{}, err
+	}
+	if err := t.html.Execute(&html, data); err != nil {
+		return notify.Email{}, err
+	}
+	return notify.Email{
+		To:      data.User.Email,
+		Subject: subject.String(),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+var emailTemplates = map[domain_notification.Kind]emailTemplate{
+	domain_notification.KindBookingConfirmed: newEmailTemplate(domain_notification.KindBookingConfirmed,
+		`Your tickets for {{.Event.Name}} are confirmed`,
+		`Hi {{.User.Name}},
+
+Your booking {{.Booking.ID}} is confirmed.
+
+{{.Event.Name}} - {{.Event.Artist}}
+{{.Event.Venue}}, {{datetime .Event.Date}}
+Tickets: {{len .Booking.TicketIDs}}
+Total: {{money .Booking.TotalAmount}} {{.Booking.Currency}}
+
+See you there!
+`,
+		`<p>Hi {{.User.Name}},</p>
+<p>Your booking <strong>{{.Booking.ID}}</strong> is confirmed.</p>
+<p><strong>{{.Event.Name}}</strong> &ndash; {{.Event.Artist}}<br>
+{{.Event.Venue}}, {{datetime .Event.Date}}<br>
+Tickets: {{len .Booking.TicketIDs}}<br>
+Total: {{money .Booking.TotalAmount}} {{.Booking.Currency}}</p>
+<p>See you there!</p>
+`),
+
+	domain_notification.KindExpiryWarning: newEmailTemplate(domain_notification.KindExpiryWarning,
+		`Your hold on {{.Event.Name}} tickets is about to expire`,
+		`Hi {{.User.Name}},
+
+Your {{len .Booking.TicketIDs}} ticket(s) for {{.Event.Name}} are held until {{datetime .Booking.ExpiresAt}}.
+Confirm booking {{.Booking.ID}} before then or the tickets will be released.
+`,
+		`<p>Hi {{.User.Name}},</p>
+<p>Your {{len .Booking.TicketIDs}} ticket(s) for <strong>{{.Event.Name}}</strong> are held until {{datetime .Booking.ExpiresAt}}.</p>
+<p>Confirm booking <strong>{{.Booking.ID}}</strong> before then or the tickets will be released.</p>
+`),
+
+	domain_notification.KindBookingCancelled: newEmailTemplate(domain_notification.KindBookingCancelled,
+		`Your booking for {{.Event.Name}} was cancelled`,
+		`Hi {{.User.Name}},
+
+Your booking {{.Booking.ID}} for {{.Event.Name}} on {{datetime .Event.Date}} has been cancelled and its tickets released.
+`,
+		`<p>Hi {{.User.Name}},</p>
+<p>Your booking <strong>{{.Booking.ID}}</strong> for <strong>{{.Event.Name}}</strong> on {{datetime .Event.Date}} has been cancelled and its tickets released.</p>
+`),
+
+	domain_notification.KindEventReminder: newEmailTemplate(domain_notification.KindEventReminder,
+		`Reminder: {{.Event.Name}} is coming up`,
+		`Hi {{.User.Name}},
+
+{{.Event.Name}} - {{.Event.Artist}} starts {{datetime .Event.Date}} at {{.Event.Venue}}.
+You have {{len .Booking.TicketIDs}} ticket(s) on booking {{.Booking.ID}}.
+`,
+		`<p>Hi {{.User.Name}},</p>
+<p><strong>{{.Event.Name}}</strong> &ndash; {{.Event.Artist}} starts {{datetime .Event.Date}} at {{.Event.Venue}}.</p>
+<p>You have {{len .Booking.TicketIDs}} ticket(s) on booking <strong>{{.Booking.ID}}</strong>.</p>
+`),
+
+	domain_notification.KindEventBroadcast: newEmailTemplate(domain_notification.KindEventBroadcast,
+		`{{.Event.Name}}: {{.Broadcast.Subject}}`,
+		`Hi {{.User.Name}},
+
+{{.Broadcast.Message}}
+
+{{.Event.Name}} - {{.Event.Artist}}
+{{.Event.Venue}}, {{datetime .Event.Date}}
+`,
+		`<p>Hi {{.User.Name}},</p>
+<p style="white-space: pre-line">{{.Broadcast.Message}}</p>
+<p><strong>{{.Event.Name}}</strong> &ndash; {{.Event.Artist}}<br>
+{{.Event.Venue}}, {{datetime .Event.Date}}</p>
+`),
+}
+
+// verificationEmail asks a new user to verify their email. It is not a
+// notification kind, as users cannot opt out of it.
+var verificationEmail = newEmailTemplate("email_verification",
+	`Verify your email address`,
+	`Hi {{.User.Name}},
+
+Open this link to verify {{.User.Email}}:
+{{.VerifyURL}}
+
+If you did not sign up, you can ignore this email.
+`,
+	`<p>Hi {{.User.Name}},</p>
+<p><a href="{{.VerifyURL}}">Verify {{.User.Email}}</a></p>
+<p>If you did not sign up, you can ignore this email.</p>
+`)
+
+// passwordResetEmail sends a user a token to reset their password with,
+// linked to the reset page when there is one
+var passwordResetEmail = newEmailTemplate("password_reset",
+	`Reset your password`,
+	`Hi {{.User.Name}},
+
+{{if .ResetURL}}Open this link to choose a new password:
+{{.ResetURL}}{{else}}Use this code to choose a new password:
+{{.ResetToken}}{{end}}
+
+It works once, for the next {{.ResetMinutes}} minutes. If you did not ask to reset your password, you can ignore this email.
+`,
+	`<p>Hi {{.User.Name}},</p>
+{{if .ResetURL}}<p><a href="{{.ResetURL}}">Choose a new password</a></p>{{else}}<p>Use this code to choose a new password:<br><code>{{.ResetToken}}</code></p>{{end}}
+<p>It works once, for the next {{.ResetMinutes}} minutes. If you did not ask to reset your password, you can ignore this email.</p>
+`)
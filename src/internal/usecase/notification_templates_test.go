@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_broadcast "github.com/ojaswiii/booking-manager/src/internal/domain/broadcast"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+
+	"github.com/google/uuid"
+)
+
+func TestEmailTemplatesRenderEveryKind(t *testing.T) {
+	data := EmailData{
+		User:  &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Sam <Fan>"},
+		Event: &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Artist: "The Band", Venue: "Arena", Date: time.Now().Add(24 * time.Hour)},
+		Booking: &domain_booking.Booking{
+			ID:          uuid.New(),
+			TicketIDs:   []uuid.UUID{uuid.New(), uuid.New()},
+			TotalAmount: 99.5,
+			ExpiresAt:   time.Now().Add(5 * time.Minute),
+		},
+		Broadcast: &domain_broadcast.Broadcast{Subject: "Doors move to 7pm", Message: "Parking opens at 6pm."},
+	}
+
+	kinds := []domain_notification.Kind{
+		domain_notification.KindBookingConfirmed,
+		domain_notification.KindExpiryWarning,
+		domain_notification.KindBookingCancelled,
+		domain_notification.KindEventReminder,
+		domain_notification.KindEventBroadcast,
+	}
+	for _, kind := range kinds {
+		tmpl, ok := emailTemplates[kind]
+		if !ok {
+			t.Fatalf("no template for %s", kind)
+		}
+		email, err := tmpl.render(data)
+		if err != nil {
+			t.Fatalf("%s: %v", kind, err)
+		}
+		if email.To != "fan@example.com" {
+			t.Errorf("%s: To = %q", kind, email.To)
+		}
+		if !strings.Contains(email.Subject, "Summer Jam") {
+			t.Errorf("%s: subject %q does not name the event", kind, email.Subject)
+		}
+		if !strings.Contains(email.Text, "Sam <Fan>") {
+			t.Errorf("%s: text body does not greet the user", kind)
+		}
+		if !strings.Contains(email.HTML, "Sam &lt;Fan&gt;") {
+			t.Errorf("%s: HTML body does not escape the user name", kind)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/health"
+	"github.com/ojaswiii/booking-manager/src/utils/messaging"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+// Outbox relay tuning
+const (
+	outboxLease       = time.Minute
+	outboxBaseBackoff = time.Second
+	outboxMaxBackoff  = 5 * time.Minute
+	outboxPurgeEvery  = time.Hour
+)
+
+// EventPublisher delivers outbox messages to the message broker
+type EventPublisher interface {
+	Publish(ctx context.Context, messages []messaging.Message) error
+}
+
+// OutboxRelay publishes domain events recorded in the outbox. Delivery is
+// at-least-once: consumers should de-duplicate on the envelope id.
+type OutboxRelay struct {
+	outboxRepo repository.OutboxRepository
+	publisher  EventPublisher
+	logger     *utils.Logger
+
+	batchSize    int
+	pollInterval time.Duration
+	retention    time.Duration
+}
+
+// NewOutboxRelay creates a new outbox relay
+func NewOutboxRelay(outboxRepo repository.OutboxRepository, publisher EventPublisher, config *utils.Config, logger *utils.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		outboxRepo:   outboxRepo,
+		publisher:    publisher,
+		logger:       logger,
+		batchSize:    config.OutboxBatchSize,
+		pollInterval: time.Duration(config.OutboxPollIntervalMs) * time.Millisecond,
+		retention:    time.Duration(config.OutboxRetentionHours) * time.Hour,
+	}
+}
+
+// Run relays due messages and purges old published ones until the context is cancelled
+func (o *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(o.pollInterval)
+	defer ticker.Stop()
+	purge := time.NewTicker(outboxPurgeEvery)
+	defer purge.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			// Keep draining while full batches come back
+			for {
+				if n := o.relay(ctx); n == 0 || n < o.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+			health.Beat(ctx)
+		case <-purge.C:
+			o.purge(ctx)
+		}
+	}
+}
+
+// relay claims a batch of due messages and publishes it, returning the batch size
+func (o *OutboxRelay) relay(ctx context.Context) int {
+	messages, err := o.outboxRepo.ClaimDue(ctx, time.Now(), outboxLease, o.batchSize)
+	if err != nil {
+		o.logger.Error("Failed to claim outbox messages", "error", err)
+		return 0
+	}
+	if len(messages) == 0 {
+		return 0
+	}
+
+	tenantID := tenant.FromContext(ctx)
+	records := make([]messaging.Message, len(messages))
+	ids := make([]uuid.UUID, len(messages))
+	for i, m := range messages {
+		headers := map[string]string{
+			"message-id":     m.ID.String(),
+			"event-type":     m.EventType,
+			"aggregate-type": m.AggregateType,
+		}
+		if tenantID != "" {
+			headers["tenant-id"] = tenantID
+		}
+		records[i] = messaging.Message{
+			Key:     []byte(m.AggregateID.String()),
+			Value:   m.Payload,
+			Headers: headers,
+		}
+		ids[i] = m.ID
+	}
+
+	if err := o.publisher.Publish(ctx, records); err != nil {
+		o.logger.Warn("Failed to publish outbox messages", "count", len(messages), "error", err)
+		o.scheduleRetry(ctx, messages, err)
+		return 0
+	}
+
+	if err := o.outboxRepo.MarkPublished(ctx, ids, time.Now()); err != nil {
+		// The lease expires and the batch is published again
+		o.logger.Error("Failed to mark outbox messages published", "count", len(ids), "error", err)
+	}
+	return len(messages)
+}
+
+// scheduleRetry records the failure and backs each message off
+func (o *OutboxRelay) scheduleRetry(ctx context.Context, messages []*domain_outbox.Message, cause error) {
+	msg := cause.Error()
+	now := time.Now()
+	for _, m := range messages {
+		m.Attempts++
+		m.LastError = &msg
+		m.NextAttemptAt = now.Add(outboxBackoff(m.Attempts))
+		if err := o.outboxRepo.MarkFailed(ctx, m); err != nil {
+			o.logger.Error("Failed to record outbox failure", "message_id", m.ID, "error", err)
+		}
+	}
+}
+
+// purge deletes messages published longer ago than the retention period
+func (o *OutboxRelay) purge(ctx context.Context) {
+	count, err := o.outboxRepo.DeletePublishedBefore(ctx, time.Now().Add(-o.retention))
+	if err != nil {
+		o.logger.Error("Failed to purge outbox", "error", err)
+		return
+	}
+	if count > 0 {
+		o.logger.Info("Purged published outbox messages", "count", count)
+	}
+}
+
+// outboxBackoff doubles the retry delay after each failed attempt. Messages
+// are never dropped; they wait for the broker to come back.
+func outboxBackoff(attempts int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > outboxMaxBackoff {
+		backoff = outboxMaxBackoff
+	}
+	return backoff
+}
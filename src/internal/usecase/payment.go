@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/payments"
+
+	"github.com/google/uuid"
+)
+
+type PaymentUsecase struct {
+	provider payments.Provider
+	bookings *BookingUsecase
+	logger   *utils.Logger
+}
+
+// NewPaymentUsecase creates a new payment usecase
+func NewPaymentUsecase(provider payments.Provider, bookings *BookingUsecase, logger *utils.Logger) *PaymentUsecase {
+	return &PaymentUsecase{
+		provider: provider,
+		bookings: bookings,
+		logger:   logger,
+	}
+}
+
+// HandleWebhook verifies a webhook from the payment provider and records the
+// payment it reports against its booking. Other events are ignored and
+// return a nil booking.
+func (p *PaymentUsecase) HandleWebhook(ctx context.Context, payload []byte, header http.Header) (*domain_booking.Booking, error) {
+	payment, err := p.provider.ParseWebhook(payload, header)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, nil
+	}
+
+	bookingID, err := uuid.Parse(payment.BookingID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: payment does not name a booking", domain.ErrInvalidInput)
+	}
+
+	p.logger.Info("Payment received",
+		"booking_id", bookingID,
+		"payment_reference", payment.PaymentReference,
+		"risk_score", payment.RiskScore)
+
+	return p.bookings.RecordPayment(ctx, RecordPaymentRequest{
+		BookingID:        bookingID,
+		PaymentReference: payment.PaymentReference,
+		RiskScore:        payment.RiskScore,
+	})
+}
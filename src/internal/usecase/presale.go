@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_job "github.com/ojaswiii/booking-manager/src/internal/domain/job"
+	domain_presale "github.com/ojaswiii/booking-manager/src/internal/domain/presale"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// Presale errors
+var (
+	ErrPresaleCodeUsed     = fmt.Errorf("%w: presale code has already been redeemed", domain.ErrConflict)
+	ErrPresaleAccessDenied = fmt.Errorf("%w: presale access required", domain.ErrForbidden)
+)
+
+// maxPresaleBatch caps how many codes a single request may generate
+const maxPresaleBatch = 10000
+
+// presaleCodeAlphabet avoids characters that are easily confused (0/O, 1/I)
+const presaleCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+type PresaleUsecase struct {
+	presaleRepo repository.PresaleCodeRepository
+	eventRepo   repository.EventRepository
+	jobs        *JobUsecase
+	logger      *utils.Logger
+}
+
+// NewPresaleUsecase creates a new presale usecase
+func NewPresaleUsecase(presaleRepo repository.PresaleCodeRepository, eventRepo repository.EventRepository, jobs *JobUsecase, logger *utils.Logger) *PresaleUsecase {
+	p := &PresaleUsecase{
+		presaleRepo: presaleRepo,
+		eventRepo:   eventRepo,
+		jobs:        jobs,
+		logger:      logger,
+	}
+	jobs.Register(JobKindPresaleCodes, p.generateCodes)
+	return p
+}
+
+// JobKindPresaleCodes generates a batch of presale codes in the background
+const JobKindPresaleCodes = "presale_codes"
+
+// presaleCodeChunk is how many codes are generated and stored per transaction
+const presaleCodeChunk = 500
+
+// presaleCodesParams are the parameters of a presale code job
+type presaleCodesParams struct {
+	EventID uuid.UUID `json:"event_id"`
+	Count   int       `json:"count"`
+}
+
+// GenerateCodes queues a job creating a batch of single-use presale codes
+// for an event. The batch takes the job's ID.
+func (p *PresaleUsecase) GenerateCodes(ctx context.Context, eventID uuid.UUID, req domain_presale.GenerateCodesRequest) (*domain_job.Job, error) {
+	if req.Count <= 0 || req.Count > maxPresaleBatch {
+		return nil, fmt.Errorf("%w: count must be between 1 and %d", domain.ErrInvalidInput, maxPresaleBatch)
+	}
+
+	event, err := p.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.PresaleStartAt == nil {
+		return nil, fmt.Errorf("%w: event has no presale window", domain.ErrInvalidInput)
+	}
+
+	return p.jobs.Submit(ctx, JobKindPresaleCodes, presaleCodesParams{EventID: eventID, Count: req.Count})
+}
+
+// generateCodes runs a presale code job. Codes are stored in chunks; a job
+// picked up again after a crash keeps the codes already stored.
+func (p *PresaleUsecase) generateCodes(ctx context.Context, job *domain_job.Job, progress ProgressFunc) (string, error) {
+	var params presaleCodesParams
+	if err := json.Unmarshal(job.Params, &params); err != nil {
+		return "", fmt.Errorf("invalid job params: %w", err)
+	}
+
+	batchID := job.ID
+	existing, err := p.presaleRepo.GetBatch(ctx, params.EventID, batchID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load presale codes: %w", err)
+	}
+
+	for done := len(existing); done < params.Count; {
+		now := time.Now()
+		codes := make([]*domain_presale.PresaleCode, min(presaleCodeChunk, params.Count-done))
+		for i := range codes {
+			code, err := generatePresaleCode(10)
+			if err != nil {
+				return "", fmt.Errorf("failed to generate presale code: %w", err)
+			}
+			codes[i] = &domain_presale.PresaleCode{
+				ID:        uuid.New(),
+				EventID:   params.EventID,
+				BatchID:   batchID,
+				Code:      code,
+				CreatedAt: now,
+			}
+		}
+		if err := p.presaleRepo.CreateBatch(ctx, codes); err != nil {
+			return "", fmt.Errorf("failed to save presale codes: %w", err)
+		}
+		done += len(codes)
+		progress(done, params.Count)
+	}
+
+	p.logger.Info("Presale codes generated", "event_id", params.EventID, "batch_id", batchID, "count", params.Count)
+	return fmt.Sprintf("/api/admin/events/%s/presale-codes/batches/%s", params.EventID, batchID), nil
+}
+
+// GetBatch returns the codes of a generated batch
+func (p *PresaleUsecase) GetBatch(ctx context.Context, eventID, batchID uuid.UUID) (*domain_presale.GenerateCodesResponse, error) {
+	codes, err := p.presaleRepo.GetBatch(ctx, eventID, batchID)
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, domain.ErrNotFound
+	}
+
+	values := make([]string, len(codes))
+	for i, code := range codes {
+		values[i] = code.Code
+	}
+	return &domain_presale.GenerateCodesResponse{
+		EventID: eventID,
+		BatchID: batchID,
+		Codes:   values,
+	}, nil
+}
+
+// RedeemCode binds a presale code to a user, granting early access to the event
+func (p *PresaleUsecase) RedeemCode(ctx context.Context, eventID uuid.UUID, req domain_presale.RedeemCodeRequest) error {
+	if req.Code == "" {
+		return fmt.Errorf("%w: code is required", domain.ErrInvalidInput)
+	}
+
+	if err := p.presaleRepo.Redeem(ctx, eventID, req.Code, req.UserID); err != nil {
+		if errors.Is(err, domain.ErrConflict) {
+			return ErrPresaleCodeUsed
+		}
+		return err
+	}
+
+	p.logger.Info("Presale code redeemed", "event_id", eventID, "user_id", req.UserID)
+	return nil
+}
+
+// CheckAccess verifies that a user holds a redeemed code for the event
+func (p *PresaleUsecase) CheckAccess(ctx context.Context, eventID, userID uuid.UUID) error {
+	entitled, err := p.presaleRepo.HasEntitlement(ctx, eventID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to check presale access: %w", err)
+	}
+	if !entitled {
+		return ErrPresaleAccessDenied
+	}
+	return nil
+}
+
+// generatePresaleCode returns a random code of the given length
+func generatePresaleCode(length int) (string, error) {
+	buf := make([]byte, length)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	for i, b := range buf {
+		buf[i] = presaleCodeAlphabet[int(b)%len(presaleCodeAlphabet)]
+	}
+	return string(buf), nil
+}
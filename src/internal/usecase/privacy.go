@@ -0,0 +1,184 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// PrivacyUsecase hands users a copy of their data and erases it on request.
+// Erasing anonymizes a user rather than deleting them: their bookings are
+// part of events' sales and stay, no longer tied to a person.
+type PrivacyUsecase struct {
+	userRepo         repository.UserRepository
+	bookingRepo      repository.BookingRepository
+	passRepo         repository.TicketPassRepository
+	notificationRepo repository.NotificationRepository
+	auditRepo        repository.AuditRepository
+	transactor       repository.Transactor
+	users            *UserUsecase
+	logger           *utils.Logger
+}
+
+// NewPrivacyUsecase creates a new privacy usecase
+func NewPrivacyUsecase(userRepo repository.UserRepository, bookingRepo repository.BookingRepository, passRepo repository.TicketPassRepository, notificationRepo repository.NotificationRepository, auditRepo repository.AuditRepository, transactor repository.Transactor, users *UserUsecase, logger *utils.Logger) *PrivacyUsecase {
+	return &PrivacyUsecase{
+		userRepo:         userRepo,
+		bookingRepo:      bookingRepo,
+		passRepo:         passRepo,
+		notificationRepo: notificationRepo,
+		auditRepo:        auditRepo,
+		transactor:       transactor,
+		users:            users,
+		logger:           logger,
+	}
+}
+
+// UserDataExport is everything kept about a user
+type UserDataExport struct {
+	ExportedAt    time.Time                           `json:"exported_at"`
+	Profile       *domain_user.User                   `json:"profile"`
+	Bookings      []ExportedBooking                   `json:"bookings"`
+	Notifications []*domain_notification.Notification `json:"notifications"`
+	Preferences   *domain_notification.Preferences    `json:"notification_preferences,omitempty"` // nil if never set
+}
+
+// ExportedBooking is a booking with its seats and the tickets issued for it
+type ExportedBooking struct {
+	*domain_booking.Booking
+	Tickets []*domain_ticket.Pass `json:"tickets"`
+}
+
+// UserErasure is what an erasure kept and changed, as recorded in its audit
+// entry
+type UserErasure struct {
+	BookingsKept         int `json:"bookings_kept"`
+	NotificationsSkipped int `json:"notifications_skipped"`
+}
+
+// ExportUserData collects a user's profile, bookings, tickets and
+// notifications
+func (u *PrivacyUsecase) ExportUserData(ctx context.Context, userID uuid.UUID) (*UserDataExport, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	export := &UserDataExport{
+		ExportedAt:    time.Now(),
+		Profile:       user,
+		Bookings:      []ExportedBooking{},
+		Notifications: []*domain_notification.Notification{},
+	}
+
+	bookings, err := u.bookingRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bookings: %w", err)
+	}
+	for _, bk := range bookings {
+		if bk.Items, err = u.bookingRepo.GetItems(ctx, bk.ID); err != nil {
+			return nil, fmt.Errorf("failed to get booking items: %w", err)
+		}
+		passes, err := u.passRepo.GetByBookingID(ctx, bk.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tickets: %w", err)
+		}
+		if passes == nil {
+			passes = []*domain_ticket.Pass{}
+		}
+		export.Bookings = append(export.Bookings, ExportedBooking{Booking: bk, Tickets: passes})
+	}
+
+	notifications, err := u.notificationRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+	if notifications != nil {
+		export.Notifications = notifications
+	}
+	preferences, err := u.notificationRepo.GetPreferences(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	export.Preferences = preferences
+
+	u.logger.Info("User data exported", "user_id", userID, "bookings", len(export.Bookings))
+	return export, nil
+}
+
+// EraseUser anonymizes userID, on their own request or an admin's. Their
+// notification preferences are deleted and pending notifications skipped;
+// bookings and tickets are kept. The erasure is recorded in the audit log
+// in the same transaction.
+func (u *PrivacyUsecase) EraseUser(ctx context.Context, actorID, userID uuid.UUID) (*domain_audit.Entry, error) {
+	if actorID != userID {
+		if _, err := u.users.Authorize(ctx, actorID, domain_user.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+
+	var entry *domain_audit.Entry
+	err := u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		user, err := u.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.Erased() {
+			return fmt.Errorf("%w: user %s is already erased", domain.ErrConflict, userID)
+		}
+
+		now := time.Now()
+		user.Erase(now)
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to anonymize user: %w", err)
+		}
+		if err := u.userRepo.SetPasswordHash(ctx, userID, ""); err != nil {
+			return fmt.Errorf("failed to drop password: %w", err)
+		}
+		var erasure UserErasure
+		if erasure.NotificationsSkipped, err = u.notificationRepo.ForgetUser(ctx, userID, now); err != nil {
+			return fmt.Errorf("failed to forget notifications: %w", err)
+		}
+		bookings, err := u.bookingRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to get bookings: %w", err)
+		}
+		erasure.BookingsKept = len(bookings)
+
+		details, err := json.Marshal(erasure)
+		if err != nil {
+			return err
+		}
+		entry = &domain_audit.Entry{
+			ID:        uuid.New(),
+			Action:    domain_audit.ActionUserErased,
+			ActorID:   actorID,
+			SubjectID: userID,
+			Details:   details,
+			CreatedAt: now,
+		}
+		return u.auditRepo.Create(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cached copies still hold the personal data
+	u.users.dropCached(ctx, userID)
+	u.users.publishInvalidation(ctx, userID)
+
+	u.logger.Info("User erased", "user_id", userID, "by", actorID)
+	return entry, nil
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_notification "github.com/ojaswiii/booking-manager/src/internal/domain/notification"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestEraseUserKeepsBookings(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, &utils.Config{}, logger)
+	privacy := NewPrivacyUsecase(repos.User, repos.Booking, repos.TicketPass, repos.Notification, repos.Audit, repos.Transactor, users, logger)
+	ctx := context.Background()
+	now := time.Now()
+
+	fan := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", Role: domain_user.RoleOrganizer}
+	other := &domain_user.User{ID: uuid.New(), Email: "other@example.com", Name: "Other"}
+	for _, usr := range []*domain_user.User{fan, other} {
+		if err := repos.User.Create(ctx, usr); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: now.Add(24 * time.Hour), TotalSeats: 10, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	bk := &domain_booking.Booking{ID: uuid.New(), UserID: fan.ID, EventID: event.ID, Status: domain_booking.BookingStatusConfirmed, Currency: "USD"}
+	bk.Items = []domain_booking.LineItem{{BookingID: bk.ID, TicketID: uuid.New(), SeatNumber: 1, UnitPrice: 50, Currency: "USD"}}
+	if err := repos.Booking.Create(ctx, bk); err != nil {
+		t.Fatalf("create booking: %v", err)
+	}
+	reminder := &domain_notification.Notification{ID: uuid.New(), Kind: domain_notification.KindEventReminder, BookingID: bk.ID, UserID: fan.ID, EventID: event.ID, Status: domain_notification.StatusPending, NextAttemptAt: now.Add(time.Hour)}
+	if err := repos.Notification.Enqueue(ctx, reminder); err != nil {
+		t.Fatalf("enqueue notification: %v", err)
+	}
+	if err := repos.Notification.SavePreferences(ctx, &domain_notification.Preferences{UserID: fan.ID}); err != nil {
+		t.Fatalf("save preferences: %v", err)
+	}
+
+	export, err := privacy.ExportUserData(ctx, fan.ID)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if export.Profile.Email != fan.Email || len(export.Bookings) != 1 || len(export.Bookings[0].Items) != 1 || len(export.Notifications) != 1 || export.Preferences == nil {
+		t.Fatalf("export = %+v, want the profile, booking, notification and preferences", export)
+	}
+
+	// Only the user themselves or an admin may erase them
+	if _, err := privacy.EraseUser(ctx, other.ID, fan.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("erase by another customer: got %v, want ErrForbidden", err)
+	}
+	entry, err := privacy.EraseUser(ctx, fan.ID, fan.ID)
+	if err != nil {
+		t.Fatalf("erase: %v", err)
+	}
+	if entry.Action != domain_audit.ActionUserErased || entry.ActorID != fan.ID || entry.SubjectID != fan.ID {
+		t.Fatalf("audit entry = %+v", entry)
+	}
+	var erasure UserErasure
+	if err := json.Unmarshal(entry.Details, &erasure); err != nil || erasure != (UserErasure{BookingsKept: 1, NotificationsSkipped: 1}) {
+		t.Fatalf("audit details = %s, %v", entry.Details, err)
+	}
+	if entries, err := repos.Audit.GetBySubject(ctx, fan.ID); err != nil || len(entries) != 1 {
+		t.Fatalf("audit log: got %d entries, %v", len(entries), err)
+	}
+
+	erased, err := users.GetUser(ctx, fan.ID)
+	if err != nil {
+		t.Fatalf("get erased user: %v", err)
+	}
+	if !erased.Erased() || erased.Name != domain_user.ErasedName || erased.Role != domain_user.RoleCustomer {
+		t.Fatalf("erased user = %+v", erased)
+	}
+	if _, err := repos.User.GetByEmail(ctx, fan.Email); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("lookup by old email: got %v, want ErrNotFound", err)
+	}
+	if _, err := repos.Booking.GetByID(ctx, bk.ID); err != nil {
+		t.Fatalf("booking was not kept: %v", err)
+	}
+	if _, err := repos.Notification.GetPreferences(ctx, fan.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("preferences: got %v, want ErrNotFound", err)
+	}
+	if due, err := repos.Notification.ClaimDue(ctx, now.Add(2*time.Hour), time.Minute, 10); err != nil || len(due) != 0 {
+		t.Fatalf("claim: got %d, %v; want the reminder skipped", len(due), err)
+	}
+
+	if _, err := privacy.EraseUser(ctx, fan.ID, fan.ID); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("second erase: got %v, want ErrConflict", err)
+	}
+}
@@ -0,0 +1,271 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// Quote token errors
+var (
+	ErrQuoteInvalid = fmt.Errorf("%w: quote token is invalid", domain.ErrInvalidInput)
+	ErrQuoteExpired = fmt.Errorf("%w: quote token has expired", domain.ErrConflict)
+)
+
+type QuoteUsecase struct {
+	eventRepo  repository.EventRepository
+	ticketRepo repository.TicketRepository
+	logger     *utils.Logger
+
+	secret        []byte
+	ttl           time.Duration
+	pricing       domain_booking.Pricing
+	currency      string
+	exchangeRates map[string]float64
+}
+
+// NewQuoteUsecase creates a new quote usecase
+func NewQuoteUsecase(eventRepo repository.EventRepository, ticketRepo repository.TicketRepository, config *utils.Config, logger *utils.Logger) *QuoteUsecase {
+	secret := []byte(config.QuoteSigningSecret)
+	if len(secret) == 0 {
+		// Without a configured secret, quotes are only valid on this instance until restart
+		secret = make([]byte, 32)
+		rand.Read(secret)
+		logger.Warn("QUOTE_SIGNING_SECRET not set, using an ephemeral signing key")
+	}
+
+	return &QuoteUsecase{
+		eventRepo:     eventRepo,
+		ticketRepo:    ticketRepo,
+		logger:        logger,
+		secret:        secret,
+		ttl:           time.Duration(config.QuoteTTLSeconds) * time.Second,
+		pricing:       NewBookingPricing(config),
+		currency:      config.Currency,
+		exchangeRates: config.ExchangeRates,
+	}
+}
+
+// CreateQuoteRequest represents a request to price a prospective selection
+type CreateQuoteRequest struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	EventID   uuid.UUID   `json:"event_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids"`
+}
+
+// QuoteLineItem represents the price of a single ticket in a quote
+type QuoteLineItem struct {
+	TicketID   uuid.UUID `json:"ticket_id"`
+	SeatNumber int       `json:"seat_number"`
+	Price      float64   `json:"price"`
+}
+
+// CreateQuoteResponse represents a priced selection with a signed token
+type CreateQuoteResponse struct {
+	EventID    uuid.UUID       `json:"event_id"`
+	LineItems  []QuoteLineItem `json:"line_items"`
+	Subtotal   float64         `json:"subtotal"`
+	Discount   float64         `json:"discount"`
+	ServiceFee float64         `json:"service_fee"`
+	Tax        float64         `json:"tax"`
+	Total      float64         `json:"total"`
+	ExpiresAt  string          `json:"expires_at"`
+	QuoteToken string          `json:"quote_token"`
+}
+
+// QuoteClaims is the signed payload carried in a quote token
+type QuoteClaims struct {
+	UserID    uuid.UUID   `json:"user_id"`
+	EventID   uuid.UUID   `json:"event_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids"`
+	Total     float64     `json:"total"`
+	ExpiresAt int64       `json:"exp"`
+}
+
+// CreateQuote prices the requested tickets for a user without placing any
+// holds
+func (q *QuoteUsecase) CreateQuote(ctx context.Context, req CreateQuoteRequest) (*CreateQuoteResponse, error) {
+	if req.UserID == uuid.Nil {
+		return nil, fmt.Errorf("%w: user_id is required", domain.ErrInvalidInput)
+	}
+	if len(req.TicketIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one ticket is required", domain.ErrInvalidInput)
+	}
+	seen := make(map[uuid.UUID]bool, len(req.TicketIDs))
+	for _, ticketID := range req.TicketIDs {
+		if seen[ticketID] {
+			return nil, fmt.Errorf("%w: ticket %s is listed more than once", domain.ErrInvalidInput, ticketID)
+		}
+		seen[ticketID] = true
+	}
+
+	event, err := q.eventRepo.GetByID(ctx, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsBookable() && !event.IsPresaleOpen() {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotBookable, event.NotBookableReason())
+	}
+
+	// Read current availability; nothing is locked or reserved here
+	availableTickets, err := q.ticketRepo.GetAvailableByEventID(ctx, req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get available tickets: %w", err)
+	}
+	availableTicketMap := make(map[uuid.UUID]*domain_ticket.Ticket, len(availableTickets))
+	for _, ticket := range availableTickets {
+		availableTicketMap[ticket.ID] = ticket
+	}
+
+	lineItems := make([]QuoteLineItem, 0, len(req.TicketIDs))
+	var subtotal float64
+	for _, ticketID := range req.TicketIDs {
+		ticket, exists := availableTicketMap[ticketID]
+		if !exists {
+			return nil, fmt.Errorf("%w: ticket %s is not available", domain.ErrConflict, ticketID)
+		}
+		lineItems = append(lineItems, QuoteLineItem{
+			TicketID:   ticket.ID,
+			SeatNumber: ticket.SeatNumber,
+			Price:      ticket.Price,
+		})
+		subtotal += ticket.Price
+	}
+
+	price := q.pricing.Price(subtotal, len(lineItems))
+	expiresAt := time.Now().Add(q.ttl)
+
+	token, err := q.signQuote(QuoteClaims{
+		UserID:    req.UserID,
+		EventID:   req.EventID,
+		TicketIDs: req.TicketIDs,
+		Total:     price.Total,
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign quote: %w", err)
+	}
+
+	return &CreateQuoteResponse{
+		EventID:    req.EventID,
+		LineItems:  lineItems,
+		Subtotal:   price.Subtotal,
+		Discount:   price.Discount,
+		ServiceFee: price.ServiceFee,
+		Tax:        price.Tax,
+		Total:      price.Total,
+		ExpiresAt:  expiresAt.UTC().Format("2006-01-02T15:04:05Z"),
+		QuoteToken: token,
+	}, nil
+}
+
+// VerifyQuote checks a quote token against the user and selection it is
+// being redeemed for
+func (q *QuoteUsecase) VerifyQuote(token string, userID, eventID uuid.UUID, ticketIDs []uuid.UUID) (*QuoteClaims, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrQuoteInvalid
+	}
+
+	expected := q.sign([]byte(payload))
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, got) {
+		return nil, ErrQuoteInvalid
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrQuoteInvalid
+	}
+	var claims QuoteClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, ErrQuoteInvalid
+	}
+
+	if time.Now().Unix() > claims.ExpiresAt {
+		return nil, ErrQuoteExpired
+	}
+	if claims.UserID != userID {
+		return nil, fmt.Errorf("%w: quote was issued to another user", ErrQuoteInvalid)
+	}
+	if claims.EventID != eventID || !sameTicketSet(claims.TicketIDs, ticketIDs) {
+		return nil, fmt.Errorf("%w: selection does not match quote", ErrQuoteInvalid)
+	}
+
+	return &claims, nil
+}
+
+// LockCurrency resolves the currency a booking is charged in and the rate
+// from the base currency at this moment. An empty currency means the base
+// currency, for which no rate applies.
+func (q *QuoteUsecase) LockCurrency(currency string) (string, *float64, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" || currency == q.currency {
+		return q.currency, nil, nil
+	}
+	rate, ok := q.exchangeRates[currency]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: unsupported currency %s", domain.ErrInvalidInput, currency)
+	}
+	return currency, &rate, nil
+}
+
+// signQuote encodes claims as base64(payload).base64(hmac)
+func (q *QuoteUsecase) signQuote(claims QuoteClaims) (string, error) {
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	signature := base64.RawURLEncoding.EncodeToString(q.sign([]byte(payload)))
+	return payload + "." + signature, nil
+}
+
+// sign computes the HMAC-SHA256 of data
+func (q *QuoteUsecase) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, q.secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// sameTicketSet reports whether both slices hold the same ticket IDs in any order
+func sameTicketSet(a, b []uuid.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	as := make([]string, len(a))
+	bs := make([]string, len(b))
+	for i := range a {
+		as[i] = a[i].String()
+		bs[i] = b[i].String()
+	}
+	sort.Strings(as)
+	sort.Strings(bs)
+	for i := range as {
+		if as[i] != bs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// roundCents rounds an amount to two decimal places
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
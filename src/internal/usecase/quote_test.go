@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestQuoteIsBoundToItsUserAndSelection(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	quotes := NewQuoteUsecase(repos.Event, repos.Ticket, &utils.Config{QuoteSigningSecret: "secret", QuoteTTLSeconds: 60}, utils.NewLogger())
+	ctx := context.Background()
+
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: time.Now().Add(24 * time.Hour), TotalSeats: 2, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	var ticketIDs []uuid.UUID
+	for seat := 1; seat <= 2; seat++ {
+		tkt := &domain_ticket.Ticket{ID: uuid.New(), EventID: event.ID, SeatNumber: seat, Status: domain_ticket.TicketStatusAvailable, Price: 50}
+		if err := repos.Ticket.Create(ctx, tkt); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+		ticketIDs = append(ticketIDs, tkt.ID)
+	}
+
+	userID := uuid.New()
+	_, err := quotes.CreateQuote(ctx, CreateQuoteRequest{UserID: userID, EventID: event.ID, TicketIDs: []uuid.UUID{ticketIDs[0], ticketIDs[0]}})
+	if !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("quote with a repeated ticket: err = %v, want invalid input", err)
+	}
+
+	quote, err := quotes.CreateQuote(ctx, CreateQuoteRequest{UserID: userID, EventID: event.ID, TicketIDs: ticketIDs})
+	if err != nil {
+		t.Fatalf("create quote: %v", err)
+	}
+	if quote.Subtotal != 100 {
+		t.Fatalf("subtotal = %v, want 100", quote.Subtotal)
+	}
+	if _, err := quotes.VerifyQuote(quote.QuoteToken, userID, event.ID, ticketIDs); err != nil {
+		t.Fatalf("verify for its user: %v", err)
+	}
+	if _, err := quotes.VerifyQuote(quote.QuoteToken, uuid.New(), event.ID, ticketIDs); !errors.Is(err, ErrQuoteInvalid) {
+		t.Fatalf("verify for another user: err = %v, want %v", err, ErrQuoteInvalid)
+	}
+}
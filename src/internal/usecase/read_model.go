@@ -0,0 +1,284 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// ErrReadModelsDisabled is returned when a read model is asked for while
+// READ_MODELS_ENABLED is off and nothing keeps them
+var ErrReadModelsDisabled = fmt.Errorf("%w: read models are disabled", domain.ErrConflict)
+
+// ReadModelUsecase keeps the read models from the booking event store and
+// serves the queries that read them. The projector follows the store by
+// position, so the read models trail the bookings they summarize by up to
+// a poll; bookings made before the store was kept have no events and are
+// summarized once they next change.
+type ReadModelUsecase struct {
+	readModelRepo repository.ReadModelRepository
+	streamRepo    repository.BookingEventRepository
+	eventRepo     repository.EventRepository
+	ticketRepo    repository.TicketRepository
+	logger        *utils.Logger
+
+	enabled         bool
+	pollInterval    time.Duration
+	refreshInterval time.Duration
+	batchSize       int
+}
+
+// NewReadModelUsecase creates a new read model usecase
+func NewReadModelUsecase(readModelRepo repository.ReadModelRepository, streamRepo repository.BookingEventRepository, eventRepo repository.EventRepository, ticketRepo repository.TicketRepository, config *utils.Config, logger *utils.Logger) *ReadModelUsecase {
+	return &ReadModelUsecase{
+		readModelRepo:   readModelRepo,
+		streamRepo:      streamRepo,
+		eventRepo:       eventRepo,
+		ticketRepo:      ticketRepo,
+		logger:          logger,
+		enabled:         config.ReadModelsEnabled,
+		pollInterval:    time.Duration(max(config.ReadModelPollIntervalMs, 1)) * time.Millisecond,
+		refreshInterval: time.Duration(max(config.ReadModelRefreshSeconds, 1)) * time.Second,
+		batchSize:       max(config.ReadModelBatchSize, 1),
+	}
+}
+
+// Enabled reports whether the read models are kept and served
+func (r *ReadModelUsecase) Enabled() bool {
+	return r != nil && r.enabled
+}
+
+// GetUserBookings returns a user's bookings from their summaries, newest
+// first
+func (r *ReadModelUsecase) GetUserBookings(ctx context.Context, userID uuid.UUID) ([]*domain_booking.Booking, error) {
+	if !r.Enabled() {
+		return nil, ErrReadModelsDisabled
+	}
+	summaries, err := r.readModelRepo.GetBookingSummariesByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get booking summaries: %w", err)
+	}
+	bookings := make([]*domain_booking.Booking, len(summaries))
+	for i, summary := range summaries {
+		bookings[i] = summary.ToBooking()
+	}
+	return bookings, nil
+}
+
+// ListEventAvailability returns the ticket counts of events that have not
+// started yet, soonest first
+func (r *ReadModelUsecase) ListEventAvailability(ctx context.Context) ([]*domain_readmodel.EventAvailability, error) {
+	if !r.Enabled() {
+		return nil, ErrReadModelsDisabled
+	}
+	return r.readModelRepo.ListEventAvailability(ctx, time.Now())
+}
+
+// Project applies the booking events stored since the checkpoint to the
+// read models and moves the checkpoint past them. It returns how many
+// events were projected. Projecting is idempotent, so events applied before
+// a failure are applied again on the next call.
+func (r *ReadModelUsecase) Project(ctx context.Context) (int, error) {
+	checkpoint, err := r.readModelRepo.GetCheckpoint(ctx, domain_readmodel.BookingProjection)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	events, err := r.streamRepo.ListAfter(ctx, checkpoint, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list booking events: %w", err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	// A summary is folded from the booking's whole stream, so each booking
+	// is projected once however many of its events the batch holds
+	var bookingIDs []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for _, event := range events {
+		if !seen[event.BookingID] {
+			seen[event.BookingID] = true
+			bookingIDs = append(bookingIDs, event.BookingID)
+		}
+	}
+
+	// Every event with a booking in the batch has its tickets recounted
+	eventsByID := make(map[uuid.UUID]*domain_event.Event)
+	for _, bookingID := range bookingIDs {
+		if err := r.projectBooking(ctx, bookingID, eventsByID); err != nil {
+			return 0, err
+		}
+	}
+	for eventID, event := range eventsByID {
+		if err := r.refreshEvent(ctx, eventID, event); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := r.readModelRepo.SetCheckpoint(ctx, domain_readmodel.BookingProjection, events[len(events)-1].Position); err != nil {
+		return 0, fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return len(events), nil
+}
+
+// projectBooking replays a booking's stream into its summary. Its event is
+// looked up once per batch, through eventsByID, and left nil there if it no
+// longer exists.
+func (r *ReadModelUsecase) projectBooking(ctx context.Context, bookingID uuid.UUID, eventsByID map[uuid.UUID]*domain_event.Event) error {
+	stream, err := r.streamRepo.GetByBookingID(ctx, bookingID)
+	if err != nil {
+		return fmt.Errorf("failed to get booking events: %w", err)
+	}
+	booking, err := domain_booking.Replay(stream)
+	if err != nil {
+		// A broken stream must not hold up every booking behind it.
+		// Rebuilding the booking reports the same error.
+		r.logger.Error("Failed to replay booking events", "booking_id", bookingID, "error", err)
+		return nil
+	}
+
+	event, ok := eventsByID[booking.EventID]
+	if !ok {
+		event, err = r.eventRepo.GetByID(ctx, booking.EventID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("failed to get event: %w", err)
+		}
+		eventsByID[booking.EventID] = event
+	}
+
+	summary := domain_readmodel.NewBookingSummary(booking, "", time.Time{}, stream[len(stream)-1].Position)
+	if event != nil {
+		summary.EventName, summary.EventDate = event.Name, event.Date
+	}
+	if err := r.readModelRepo.UpsertBookingSummary(ctx, summary); err != nil {
+		return fmt.Errorf("failed to save booking summary: %w", err)
+	}
+	return nil
+}
+
+// refreshEvent recounts an event's tickets into its availability row, or
+// drops the row of an event that no longer exists
+func (r *ReadModelUsecase) refreshEvent(ctx context.Context, eventID uuid.UUID, event *domain_event.Event) error {
+	if event == nil {
+		if err := r.readModelRepo.DeleteEventAvailability(ctx, eventID); err != nil {
+			return fmt.Errorf("failed to delete event availability: %w", err)
+		}
+		return nil
+	}
+
+	// One section spanning every seat, so the counts come back per status
+	// and price only
+	counts, err := r.ticketRepo.CountByStatus(ctx, eventID, max(event.TotalSeats, 1))
+	if err != nil {
+		return fmt.Errorf("failed to count tickets: %w", err)
+	}
+	var total domain_ticket.StatusCounts
+	for _, count := range counts {
+		total.Add(count.Status, count.Tickets)
+	}
+	availability := domain_readmodel.NewEventAvailability(eventID, event.Name, event.Date, total, time.Now().UTC())
+	if err := r.readModelRepo.UpsertEventAvailability(ctx, availability); err != nil {
+		return fmt.Errorf("failed to save event availability: %w", err)
+	}
+	return nil
+}
+
+// RefreshAvailability recounts the availability row of every event that has
+// not started yet, picking up seat holds and other ticket changes that are
+// not booking events, and drops the rows of deleted events. It returns how
+// many events were recounted.
+func (r *ReadModelUsecase) RefreshAvailability(ctx context.Context) (int, error) {
+	events, err := r.eventRepo.GetAll(ctx)
+	if err != nil {
+		return 0, err
+	}
+	now := time.Now()
+	upcoming := make(map[uuid.UUID]bool)
+	for _, event := range events {
+		if !event.Date.After(now) {
+			continue
+		}
+		upcoming[event.ID] = true
+		if err := r.refreshEvent(ctx, event.ID, event); err != nil {
+			return 0, err
+		}
+	}
+
+	rows, err := r.readModelRepo.ListEventAvailability(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	for _, row := range rows {
+		if !upcoming[row.EventID] {
+			if err := r.refreshEvent(ctx, row.EventID, nil); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(upcoming), nil
+}
+
+// Rebuild projects the read models again from the start of the booking
+// event store, repairing rows that drifted from it. The projector replays
+// the store on its next polls; availability is recounted now.
+func (r *ReadModelUsecase) Rebuild(ctx context.Context) error {
+	if !r.Enabled() {
+		return ErrReadModelsDisabled
+	}
+	if err := r.readModelRepo.SetCheckpoint(ctx, domain_readmodel.BookingProjection, 0); err != nil {
+		return fmt.Errorf("failed to reset checkpoint: %w", err)
+	}
+	if _, err := r.RefreshAvailability(ctx); err != nil {
+		return fmt.Errorf("failed to recount availability: %w", err)
+	}
+	r.logger.Info("Read models reset to be rebuilt")
+	return nil
+}
+
+// Run projects booking events on each tick, draining the store a batch at
+// a time, and recounts availability on each refresh, until the context is
+// cancelled
+func (r *ReadModelUsecase) Run(ctx context.Context) {
+	poll := time.NewTicker(r.pollInterval)
+	defer poll.Stop()
+	refresh := time.NewTicker(r.refreshInterval)
+	defer refresh.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+			for {
+				count, err := r.Project(ctx)
+				if err != nil {
+					r.logger.Error("Failed to project booking events", "error", err)
+					break
+				}
+				if count < r.batchSize {
+					break
+				}
+			}
+		case <-refresh.C:
+			count, err := r.RefreshAvailability(ctx)
+			if err != nil {
+				r.logger.Error("Failed to refresh event availability", "error", err)
+				continue
+			}
+			if count > 0 {
+				r.logger.Info("Event availability refreshed", "events", count)
+			}
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_readmodel "github.com/ojaswiii/booking-manager/src/internal/domain/readmodel"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestReadModelsFollowTheBookingEventStore(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	readModels := NewReadModelUsecase(repos.ReadModel, repos.BookingEvent, repos.Event, repos.Ticket, &utils.Config{ReadModelsEnabled: true, ReadModelBatchSize: 2}, logger)
+	bookings := &BookingUsecase{
+		bookingRepo: repos.Booking,
+		streamRepo:  repos.BookingEvent,
+		transactor:  repos.Transactor,
+		readModels:  readModels,
+		logger:      logger,
+	}
+	ctx := context.Background()
+	now := time.Now()
+
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: now.Add(24 * time.Hour), TotalSeats: 4, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	tickets := make([]uuid.UUID, 4)
+	for i := range tickets {
+		tickets[i] = uuid.New()
+		status := domain_ticket.TicketStatusAvailable
+		if i < 2 {
+			status = domain_ticket.TicketStatusReserved
+		}
+		if err := repos.Ticket.Create(ctx, &domain_ticket.Ticket{ID: tickets[i], EventID: event.ID, SeatNumber: i + 1, Status: status, Price: 50}); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+	}
+
+	userID := uuid.New()
+	bk := &domain_booking.Booking{
+		ID:          uuid.New(),
+		UserID:      userID,
+		EventID:     event.ID,
+		TicketIDs:   tickets[:2],
+		Status:      domain_booking.BookingStatusPending,
+		TotalAmount: 100,
+		Currency:    "USD",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   now.Add(15 * time.Minute),
+	}
+	if err := bk.RecordCreated(domain_booking.ActorCustomer); err != nil {
+		t.Fatalf("record creation: %v", err)
+	}
+	if err := bk.Record(domain_booking.BookingConfirmed, domain_booking.ActorPayments, now.Add(time.Minute), nil); err != nil {
+		t.Fatalf("record confirmation: %v", err)
+	}
+	if err := bookings.storeChanges(ctx, bk); err != nil {
+		t.Fatalf("store changes: %v", err)
+	}
+
+	// Nothing is summarized until the projector catches up
+	listed, err := bookings.GetUserBookings(ctx, userID)
+	if err != nil || len(listed) != 0 {
+		t.Fatalf("bookings before projecting: got %d, %v; want none", len(listed), err)
+	}
+
+	// Three events in batches of two
+	if count, err := readModels.Project(ctx); err != nil || count != 2 {
+		t.Fatalf("first batch: got %d, %v; want 2", count, err)
+	}
+	if count, err := readModels.Project(ctx); err != nil || count != 1 {
+		t.Fatalf("second batch: got %d, %v; want 1", count, err)
+	}
+	if count, err := readModels.Project(ctx); err != nil || count != 0 {
+		t.Fatalf("caught up: got %d, %v; want 0", count, err)
+	}
+	if position, _ := repos.ReadModel.GetCheckpoint(ctx, domain_readmodel.BookingProjection); position != 3 {
+		t.Fatalf("checkpoint: got %d, want 3", position)
+	}
+
+	listed, err = bookings.GetUserBookings(ctx, userID)
+	if err != nil || len(listed) != 1 {
+		t.Fatalf("bookings: got %d, %v; want 1", len(listed), err)
+	}
+	if listed[0].ID != bk.ID || listed[0].Status != domain_booking.BookingStatusConfirmed || len(listed[0].TicketIDs) != 2 || listed[0].TotalAmount != 100 {
+		t.Fatalf("booking: got %+v", listed[0])
+	}
+
+	dashboard, err := readModels.ListEventAvailability(ctx)
+	if err != nil || len(dashboard) != 1 {
+		t.Fatalf("dashboard: got %d, %v; want 1", len(dashboard), err)
+	}
+	if row := dashboard[0]; row.EventName != "Summer Jam" || row.Reserved != 2 || row.Available != 2 || row.Total() != 4 {
+		t.Fatalf("availability: got %+v", row)
+	}
+
+	// A rebuild projects the store again from the start
+	if err := readModels.Rebuild(ctx); err != nil {
+		t.Fatalf("rebuild: %v", err)
+	}
+	if position, _ := repos.ReadModel.GetCheckpoint(ctx, domain_readmodel.BookingProjection); position != 0 {
+		t.Fatalf("checkpoint after rebuild: got %d, want 0", position)
+	}
+	if count, err := readModels.Project(ctx); err != nil || count != 2 {
+		t.Fatalf("replay: got %d, %v; want 2", count, err)
+	}
+}
@@ -0,0 +1,735 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/payments"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrRefundNotAllowed is returned when the event's refund policy rules out a customer refund
+	ErrRefundNotAllowed = fmt.Errorf("%w: refund not allowed", domain.ErrInvalidInput)
+	// ErrRefundFailed is returned when the payment provider rejects a refund
+	ErrRefundFailed = errors.New("payment provider refused the refund")
+)
+
+type RefundUsecase struct {
+	refundRepo     repository.RefundRepository
+	bookingRepo    repository.BookingRepository
+	eventRepo      repository.EventRepository
+	ticketRepo     repository.TicketRepository
+	passRepo       repository.TicketPassRepository
+	outboxRepo     repository.OutboxRepository
+	transitionRepo repository.BookingTransitionRepository
+	streamRepo     repository.BookingEventRepository
+	transactor     repository.Transactor
+	provider       payments.Provider
+	webhooks       *WebhookUsecase
+	stats          *EventStatsUsecase
+	availability   *AvailabilityUsecase
+	logger         *utils.Logger
+
+	defaultDeadlineHours int
+	defaultPercent       float64
+
+	// mu keeps two refunds or returns of one booking from both counting the
+	// same remaining tickets and amount
+	mu sync.Mutex
+}
+
+// NewRefundUsecase creates a new refund usecase
+func NewRefundUsecase(
+	refundRepo repository.RefundRepository,
+	bookingRepo repository.BookingRepository,
+	eventRepo repository.EventRepository,
+	ticketRepo repository.TicketRepository,
+	passRepo repository.TicketPassRepository,
+	outboxRepo repository.OutboxRepository,
+	transitionRepo repository.BookingTransitionRepository,
+	streamRepo repository.BookingEventRepository,
+	transactor repository.Transactor,
+	provider payments.Provider,
+	webhooks *WebhookUsecase,
+	stats *EventStatsUsecase,
+	availability *AvailabilityUsecase,
+	config *utils.Config,
+	logger *utils.Logger,
+) *RefundUsecase {
+	return &RefundUsecase{
+		refundRepo:           refundRepo,
+		bookingRepo:          bookingRepo,
+		eventRepo:            eventRepo,
+		ticketRepo:           ticketRepo,
+		passRepo:             passRepo,
+		outboxRepo:           outboxRepo,
+		transitionRepo:       transitionRepo,
+		streamRepo:           streamRepo,
+		transactor:           transactor,
+		provider:             provider,
+		webhooks:             webhooks,
+		stats:                stats,
+		availability:         availability,
+		logger:               logger,
+		defaultDeadlineHours: config.RefundDeadlineHours,
+		defaultPercent:       config.RefundPercent,
+	}
+}
+
+// GetPolicy returns the refund policy of an event, falling back to the
+// configured default for events without one
+func (r *RefundUsecase) GetPolicy(ctx context.Context, eventID uuid.UUID) (*domain_refund.Policy, error) {
+	if _, err := r.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return r.policy(ctx, eventID)
+}
+
+// UpdatePolicy sets the refund policy of an event
+func (r *RefundUsecase) UpdatePolicy(ctx context.Context, eventID uuid.UUID, req domain_refund.UpdatePolicyRequest) (*domain_refund.Policy, error) {
+	if req.Percent < 0 || req.Percent > 100 {
+		return nil, fmt.Errorf("%w: percent must be between 0 and 100", domain.ErrInvalidInput)
+	}
+	if req.DeadlineHours < 0 {
+		return nil, fmt.Errorf("%w: deadline_hours cannot be negative", domain.ErrInvalidInput)
+	}
+	if _, err := r.eventRepo.GetByID(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	policy := &domain_refund.Policy{
+		EventID:       eventID,
+		Refundable:    req.Refundable,
+		DeadlineHours: req.DeadlineHours,
+		Percent:       req.Percent,
+		AllowPartial:  req.AllowPartial,
+		UpdatedAt:     time.Now(),
+	}
+	if err := r.refundRepo.SavePolicy(ctx, policy); err != nil {
+		return nil, err
+	}
+
+	r.logger.Info("Refund policy updated", "event_id", eventID, "refundable", policy.Refundable, "percent", policy.Percent)
+	return policy, nil
+}
+
+// ListRefunds returns the refunds of a booking. A non-nil userID must own the booking.
+func (r *RefundUsecase) ListRefunds(ctx context.Context, bookingID uuid.UUID, userID *uuid.UUID) ([]*domain_refund.Refund, error) {
+	booking, err := r.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if userID != nil && booking.UserID != *userID {
+		return nil, domain.ErrNotFound
+	}
+	return r.refundRepo.GetByBookingID(ctx, bookingID)
+}
+
+// PreviewCancellation works out what the owner would get back for
+// cancelling a booking now: pending bookings cancel for free, confirmed ones
+// are refunded for their remaining tickets under the event's refund policy.
+func (r *RefundUsecase) PreviewCancellation(ctx context.Context, bookingID, userID uuid.UUID) (*domain_refund.CancellationPreview, error) {
+	booking, err := r.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+
+	preview := &domain_refund.CancellationPreview{
+		BookingID:    booking.ID,
+		Currency:     booking.Currency,
+		RefundMethod: domain_refund.MethodNone,
+	}
+	switch booking.Status {
+	case domain_booking.BookingStatusPending:
+		preview.Allowed = true
+		return preview, nil
+	case domain_booking.BookingStatusConfirmed:
+	default:
+		preview.Reason = fmt.Sprintf("booking is %s", booking.Status)
+		return preview, nil
+	}
+
+	event, err := r.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := r.policy(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	refunded, left, err := r.refundedSoFar(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+	preview.Paid = max(left, 0)
+	preview.RefundMethod = domain_refund.MethodOriginalPayment
+	if policy.Refundable {
+		deadline := policy.Deadline(event.Date)
+		preview.Deadline = &deadline
+	}
+	if preview.Reason = policyViolation(policy, event.Date, time.Now()); preview.Reason != "" {
+		return preview, nil
+	}
+
+	var outstanding []uuid.UUID
+	for _, id := range booking.TicketIDs {
+		if !refunded[id] {
+			outstanding = append(outstanding, id)
+		}
+	}
+	_, amount, err := r.priceTickets(ctx, booking, outstanding, policy.Percent)
+	if err != nil {
+		return nil, err
+	}
+
+	preview.RefundAmount = min(amount, preview.Paid)
+	preview.Fee = domain_booking.RoundCents(preview.Paid - preview.RefundAmount)
+	preview.Allowed = len(outstanding) > 0
+	if !preview.Allowed {
+		preview.Reason = "nothing left to refund"
+	}
+	return preview, nil
+}
+
+// RefundBooking refunds some or all of a confirmed booking through the
+// payment provider. Owners are held to the event's refund policy; admins may
+// refund at any time, in full, or for an amount of their choosing. Refunded
+// tickets go back on sale while the event is still ahead.
+func (r *RefundUsecase) RefundBooking(ctx context.Context, bookingID uuid.UUID, req domain_refund.RefundRequest, admin bool) (*domain_refund.Refund, error) {
+	booking, err := r.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if !admin && booking.UserID != req.UserID {
+		return nil, domain.ErrNotFound
+	}
+	if booking.Status != domain_booking.BookingStatusConfirmed {
+		return nil, fmt.Errorf("%w: booking is %s", domain.ErrConflict, booking.Status)
+	}
+	if !admin && req.Amount != nil {
+		return nil, fmt.Errorf("%w: only admins may set the refund amount", domain.ErrInvalidInput)
+	}
+
+	event, err := r.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := r.policy(ctx, event.ID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !admin {
+		if err := checkPolicy(policy, event.Date, now); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	refund, err := r.reserve(ctx, booking, policy, req, admin)
+	r.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	paymentReference := ""
+	if booking.PaymentReference != nil {
+		paymentReference = *booking.PaymentReference
+	}
+	providerRef, err := r.provider.Refund(ctx, payments.Refund{
+		ID:               refund.ID.String(),
+		PaymentReference: paymentReference,
+		Amount:           refund.Amount,
+		Currency:         refund.Currency,
+		Reason:           refund.Reason,
+	})
+	refund.UpdatedAt = time.Now()
+	if err != nil {
+		msg := err.Error()
+		refund.Status = domain_refund.StatusFailed
+		refund.Error = &msg
+		if err := r.refundRepo.Update(ctx, refund); err != nil {
+			r.logger.Error("Failed to record refund failure", "refund_id", refund.ID, "error", err)
+		}
+		r.logger.Warn("Refund failed", "booking_id", booking.ID, "refund_id", refund.ID, "error", msg)
+		return nil, fmt.Errorf("%w: %v", ErrRefundFailed, err)
+	}
+
+	// The money has moved; record that first so a failure below cannot
+	// lead to the same tickets being refunded twice
+	refund.Status = domain_refund.StatusSucceeded
+	refund.ProviderReference = &providerRef
+	if err := r.refundRepo.Update(ctx, refund); err != nil {
+		r.logger.Error("Failed to record refund", "refund_id", refund.ID, "provider_reference", providerRef, "error", err)
+		return nil, fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	r.mu.Lock()
+	err = r.applyRefund(ctx, refund, event.Date.After(now))
+	r.mu.Unlock()
+	if err != nil {
+		r.logger.Error("Refund issued but booking not updated", "booking_id", booking.ID, "refund_id", refund.ID, "error", err)
+		return nil, err
+	}
+
+	r.logger.Info("Booking refunded",
+		"booking_id", booking.ID,
+		"refund_id", refund.ID,
+		"amount", refund.Amount,
+		"tickets", len(refund.Items),
+		"requested_by", refund.RequestedBy)
+
+	r.webhooks.Publish(ctx, domain_webhook.EventBookingRefunded, refund)
+	return refund, nil
+}
+
+// reserve works out what a refund covers and stores it as pending, so it
+// counts against the booking before the payment provider is called
+func (r *RefundUsecase) reserve(ctx context.Context, booking *domain_booking.Booking, policy *domain_refund.Policy, req domain_refund.RefundRequest, admin bool) (*domain_refund.Refund, error) {
+	refunded, left, err := r.refundedSoFar(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+
+	var outstanding []uuid.UUID
+	inBooking := make(map[uuid.UUID]bool, len(booking.TicketIDs))
+	for _, id := range booking.TicketIDs {
+		inBooking[id] = true
+		if !refunded[id] {
+			outstanding = append(outstanding, id)
+		}
+	}
+
+	var ticketIDs []uuid.UUID
+	switch {
+	case len(req.TicketIDs) > 0:
+		seen := make(map[uuid.UUID]bool, len(req.TicketIDs))
+		for _, id := range req.TicketIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if !inBooking[id] {
+				return nil, fmt.Errorf("%w: ticket %s is not part of this booking", domain.ErrInvalidInput, id)
+			}
+			if refunded[id] {
+				return nil, fmt.Errorf("%w: ticket %s has already been refunded", domain.ErrConflict, id)
+			}
+			ticketIDs = append(ticketIDs, id)
+		}
+		if !admin && !policy.AllowPartial && len(ticketIDs) < len(outstanding) {
+			return nil, fmt.Errorf("%w: all tickets of the booking must be refunded together", ErrRefundNotAllowed)
+		}
+	case req.Amount == nil:
+		ticketIDs = outstanding
+	}
+
+	percent := policy.Percent
+	if admin {
+		percent = 100
+	}
+	items, amount, err := r.priceTickets(ctx, booking, ticketIDs, percent)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := &domain_refund.Refund{
+		ID:          uuid.New(),
+		BookingID:   booking.ID,
+		Currency:    booking.Currency,
+		Reason:      strings.TrimSpace(req.Reason),
+		Status:      domain_refund.StatusPending,
+		RequestedBy: "owner",
+		Items:       items,
+		CreatedAt:   time.Now(),
+	}
+	if admin {
+		refund.RequestedBy = "admin"
+	}
+	refund.UpdatedAt = refund.CreatedAt
+	for i := range refund.Items {
+		refund.Items[i].RefundID = refund.ID
+	}
+
+	if req.Amount != nil {
+		requested := domain_booking.RoundCents(*req.Amount)
+		if requested <= 0 {
+			return nil, fmt.Errorf("%w: amount must be positive", domain.ErrInvalidInput)
+		}
+		if requested > left {
+			return nil, fmt.Errorf("%w: amount exceeds the %.2f left to refund", domain.ErrInvalidInput, left)
+		}
+		// Spread the requested amount over the tickets by price
+		if amount > 0 {
+			for i := range refund.Items {
+				refund.Items[i].Amount = domain_booking.RoundCents(refund.Items[i].Amount * requested / amount)
+			}
+		}
+		amount = requested
+	}
+
+	refund.Amount = min(amount, left)
+	if refund.Amount <= 0 {
+		if len(ticketIDs) == 0 {
+			return nil, fmt.Errorf("%w: nothing left to refund", domain.ErrConflict)
+		}
+		return nil, fmt.Errorf("%w: the refund policy returns nothing for these tickets", ErrRefundNotAllowed)
+	}
+
+	if err := r.refundRepo.Create(ctx, refund); err != nil {
+		return nil, fmt.Errorf("failed to save refund: %w", err)
+	}
+	return refund, nil
+}
+
+// applyRefund takes refunded tickets off the booking, revokes their passes
+// and, if restock is set, puts them back on sale. A booking with nothing left
+// on it becomes refunded. The booking is reloaded since another refund of it
+// may have been applied while this one was with the provider.
+func (r *RefundUsecase) applyRefund(ctx context.Context, refund *domain_refund.Refund, restock bool) error {
+	booking, err := r.bookingRepo.GetByID(ctx, refund.BookingID)
+	if err != nil {
+		return err
+	}
+
+	refundedTickets := make([]uuid.UUID, len(refund.Items))
+	for i, item := range refund.Items {
+		refundedTickets[i] = item.TicketID
+	}
+
+	previous, err := r.refundRepo.GetByBookingID(ctx, booking.ID)
+	if err != nil {
+		return err
+	}
+	var refundedAmount float64
+	for _, prev := range previous {
+		if prev.Status == domain_refund.StatusSucceeded {
+			refundedAmount += prev.Amount
+		}
+	}
+
+	actor := domain_booking.ActorCustomer
+	if refund.RequestedBy == "admin" {
+		actor = domain_booking.ActorAdmin
+	}
+	now := time.Now()
+	from := booking.Status
+	if len(refundedTickets) > 0 {
+		if err := booking.Record(domain_booking.TicketsRefunded, actor, now, domain_booking.TicketsData{TicketIDs: refundedTickets}); err != nil {
+			return err
+		}
+	}
+	fullyRefunded := len(booking.TicketIDs) == 0 || domain_booking.RoundCents(booking.TotalAmount-refundedAmount) <= 0
+	if fullyRefunded && booking.Status != domain_booking.BookingStatusRefunded {
+		if err := booking.Record(domain_booking.BookingRefunded, actor, now, nil); err != nil {
+			return err
+		}
+	}
+	reason := "refunded"
+	if refund.Reason != "" {
+		reason += ": " + refund.Reason
+	}
+	var transitions []*domain_booking.Transition
+	if booking.Status != from {
+		transitions = booking.Transitions(from, actor, reason)
+	}
+	if restock {
+		transitions = append(transitions, booking.TicketTransitions(refundedTickets, domain_ticket.TicketStatusSold, domain_ticket.TicketStatusAvailable, actor, reason)...)
+	}
+
+	err = r.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if len(refundedTickets) > 0 {
+			if restock {
+				if err := r.ticketRepo.RestockTickets(ctx, refundedTickets); err != nil {
+					return fmt.Errorf("failed to restock tickets: %w", err)
+				}
+			}
+			if err := r.passRepo.Revoke(ctx, booking.ID, refundedTickets); err != nil {
+				return fmt.Errorf("failed to revoke passes: %w", err)
+			}
+		}
+		if err := r.bookingRepo.Update(ctx, booking); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		if err := r.streamRepo.Append(ctx, booking.Changes...); err != nil {
+			return fmt.Errorf("failed to record booking events: %w", err)
+		}
+		if err := r.transitionRepo.Append(ctx, transitions...); err != nil {
+			return fmt.Errorf("failed to record booking history: %w", err)
+		}
+
+		msg, err := domain_outbox.NewMessage(domain_outbox.AggregateBooking, booking.ID, domain_outbox.EventBookingRefunded, refund)
+		if err != nil {
+			return err
+		}
+		messages := []*domain_outbox.Message{msg}
+		if restock && len(refundedTickets) > 0 {
+			msg, err := domain_outbox.NewMessage(domain_outbox.AggregateEvent, booking.EventID, domain_outbox.EventInventoryReleased, domain_outbox.InventoryChange{
+				EventID:   booking.EventID,
+				BookingID: booking.ID,
+				TicketIDs: refundedTickets,
+			})
+			if err != nil {
+				return err
+			}
+			messages = append(messages, msg)
+		}
+		return r.outboxRepo.Append(ctx, messages...)
+	})
+	if err != nil {
+		return err
+	}
+	if restock && len(refundedTickets) > 0 {
+		r.availability.Restore(ctx, booking.EventID, refundedTickets)
+	}
+	return nil
+}
+
+// ReturnTickets gives some or all of a confirmed booking's tickets back to
+// the organizer for resale, without a refund or credit. The tickets go back
+// on sale and their passes are revoked; a booking with nothing left on it
+// becomes returned. Tickets can only be returned before the event starts.
+func (r *RefundUsecase) ReturnTickets(ctx context.Context, bookingID uuid.UUID, req domain_refund.ReturnRequest) (*domain_refund.Return, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	booking, err := r.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != req.UserID {
+		return nil, domain.ErrNotFound
+	}
+	if booking.Status != domain_booking.BookingStatusConfirmed {
+		return nil, fmt.Errorf("%w: booking is %s", domain.ErrConflict, booking.Status)
+	}
+
+	event, err := r.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if !event.Date.After(now) {
+		return nil, fmt.Errorf("%w: the event has already started", domain.ErrConflict)
+	}
+
+	// Tickets of a refund still with the provider are spoken for
+	refunded, _, err := r.refundedSoFar(ctx, booking)
+	if err != nil {
+		return nil, err
+	}
+	inBooking := make(map[uuid.UUID]bool, len(booking.TicketIDs))
+	var outstanding []uuid.UUID
+	for _, id := range booking.TicketIDs {
+		inBooking[id] = true
+		if !refunded[id] {
+			outstanding = append(outstanding, id)
+		}
+	}
+
+	ticketIDs := outstanding
+	if len(req.TicketIDs) > 0 {
+		ticketIDs = nil
+		seen := make(map[uuid.UUID]bool, len(req.TicketIDs))
+		for _, id := range req.TicketIDs {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			if !inBooking[id] {
+				return nil, fmt.Errorf("%w: ticket %s is not part of this booking", domain.ErrInvalidInput, id)
+			}
+			if refunded[id] {
+				return nil, fmt.Errorf("%w: ticket %s is being refunded", domain.ErrConflict, id)
+			}
+			ticketIDs = append(ticketIDs, id)
+		}
+	}
+	if len(ticketIDs) == 0 {
+		return nil, fmt.Errorf("%w: nothing left to return", domain.ErrConflict)
+	}
+
+	ret := &domain_refund.Return{
+		ID:        uuid.New(),
+		BookingID: booking.ID,
+		EventID:   booking.EventID,
+		TicketIDs: ticketIDs,
+		Reason:    strings.TrimSpace(req.Reason),
+		CreatedAt: now,
+	}
+
+	from := booking.Status
+	if err := booking.Record(domain_booking.TicketsReturned, domain_booking.ActorCustomer, now, domain_booking.TicketsData{TicketIDs: ticketIDs}); err != nil {
+		return nil, err
+	}
+	if len(booking.TicketIDs) == 0 {
+		if err := booking.Record(domain_booking.BookingReturned, domain_booking.ActorCustomer, now, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	reason := "returned for resale"
+	if ret.Reason != "" {
+		reason += ": " + ret.Reason
+	}
+	var transitions []*domain_booking.Transition
+	if booking.Status != from {
+		transitions = booking.Transitions(from, domain_booking.ActorCustomer, reason)
+	}
+	transitions = append(transitions, booking.TicketTransitions(ticketIDs, domain_ticket.TicketStatusSold, domain_ticket.TicketStatusAvailable, domain_booking.ActorCustomer, reason)...)
+
+	err = r.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := r.refundRepo.CreateReturn(ctx, ret); err != nil {
+			return fmt.Errorf("failed to save return: %w", err)
+		}
+		if err := r.ticketRepo.RestockTickets(ctx, ticketIDs); err != nil {
+			return fmt.Errorf("failed to restock tickets: %w", err)
+		}
+		if err := r.passRepo.Revoke(ctx, booking.ID, ticketIDs); err != nil {
+			return fmt.Errorf("failed to revoke passes: %w", err)
+		}
+		if err := r.bookingRepo.Update(ctx, booking); err != nil {
+			return fmt.Errorf("failed to update booking: %w", err)
+		}
+		if err := r.streamRepo.Append(ctx, booking.Changes...); err != nil {
+			return fmt.Errorf("failed to record booking events: %w", err)
+		}
+		if err := r.transitionRepo.Append(ctx, transitions...); err != nil {
+			return fmt.Errorf("failed to record booking history: %w", err)
+		}
+
+		msg, err := domain_outbox.NewMessage(domain_outbox.AggregateEvent, booking.EventID, domain_outbox.EventInventoryReleased, domain_outbox.InventoryChange{
+			EventID:   booking.EventID,
+			BookingID: booking.ID,
+			TicketIDs: ticketIDs,
+		})
+		if err != nil {
+			return err
+		}
+		return r.outboxRepo.Append(ctx, msg)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r.stats.TicketsReturned(ctx, booking, len(ticketIDs))
+	r.availability.Restore(ctx, booking.EventID, ticketIDs)
+	r.logger.Info("Tickets returned for resale",
+		"booking_id", booking.ID,
+		"return_id", ret.ID,
+		"tickets", len(ticketIDs),
+		"status", booking.Status)
+	return ret, nil
+}
+
+// ListReturns returns the ticket returns of a booking. The user must own the booking.
+func (r *RefundUsecase) ListReturns(ctx context.Context, bookingID, userID uuid.UUID) ([]*domain_refund.Return, error) {
+	booking, err := r.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	if booking.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	return r.refundRepo.GetReturnsByBookingID(ctx, bookingID)
+}
+
+// refundedSoFar returns the tickets already refunded on a booking, counting
+// refunds still with the provider, and the amount left to refund
+func (r *RefundUsecase) refundedSoFar(ctx context.Context, booking *domain_booking.Booking) (map[uuid.UUID]bool, float64, error) {
+	previous, err := r.refundRepo.GetByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	refunded := make(map[uuid.UUID]bool)
+	var refundedAmount float64
+	for _, prev := range previous {
+		if !prev.Counts() {
+			continue
+		}
+		refundedAmount += prev.Amount
+		for _, item := range prev.Items {
+			refunded[item.TicketID] = true
+		}
+	}
+	return refunded, domain_booking.RoundCents(booking.TotalAmount - refundedAmount), nil
+}
+
+// priceTickets returns a refund item for each ticket at percent of the price
+// locked on the booking, and their sum
+func (r *RefundUsecase) priceTickets(ctx context.Context, booking *domain_booking.Booking, ticketIDs []uuid.UUID, percent float64) ([]domain_refund.Item, float64, error) {
+	lineItems, err := r.bookingRepo.GetItems(ctx, booking.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	prices := make(map[uuid.UUID]float64, len(lineItems))
+	for _, item := range lineItems {
+		prices[item.TicketID] = item.UnitPrice
+	}
+
+	items := make([]domain_refund.Item, len(ticketIDs))
+	var amount float64
+	for i, id := range ticketIDs {
+		price, ok := prices[id]
+		if !ok {
+			price = booking.TotalAmount / float64(len(booking.TicketIDs))
+		}
+		items[i] = domain_refund.Item{
+			TicketID: id,
+			Amount:   domain_booking.RoundCents(price * percent / 100),
+		}
+		amount += items[i].Amount
+	}
+	return items, domain_booking.RoundCents(amount), nil
+}
+
+// checkPolicy verifies that a customer may still get a refund under the policy
+func checkPolicy(policy *domain_refund.Policy, eventDate, now time.Time) error {
+	if reason := policyViolation(policy, eventDate, now); reason != "" {
+		return fmt.Errorf("%w: %s", ErrRefundNotAllowed, reason)
+	}
+	return nil
+}
+
+// policyViolation returns why the policy rules out a customer refund now, if it does
+func policyViolation(policy *domain_refund.Policy, eventDate, now time.Time) string {
+	if !policy.Refundable {
+		return "tickets for this event are non-refundable"
+	}
+	if deadline := policy.Deadline(eventDate); now.After(deadline) {
+		return "refunds closed at " + deadline.Format(time.RFC3339)
+	}
+	return ""
+}
+
+// policy returns the stored policy of an event or the configured default
+func (r *RefundUsecase) policy(ctx context.Context, eventID uuid.UUID) (*domain_refund.Policy, error) {
+	policy, err := r.refundRepo.GetPolicy(ctx, eventID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return &domain_refund.Policy{
+			EventID:       eventID,
+			Refundable:    true,
+			DeadlineHours: r.defaultDeadlineHours,
+			Percent:       r.defaultPercent,
+			AllowPartial:  true,
+		}, nil
+	}
+	return policy, err
+}
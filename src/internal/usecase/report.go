@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// reportRoles may read sales reports
+var reportRoles = []domain_user.Role{domain_user.RoleOrganizer, domain_user.RoleAdmin}
+
+type ReportUsecase struct {
+	reportRepo repository.SalesReportRepository
+	users      *UserUsecase
+	logger     *utils.Logger
+}
+
+// NewReportUsecase creates a new sales report usecase
+func NewReportUsecase(reportRepo repository.SalesReportRepository, users *UserUsecase, logger *utils.Logger) *ReportUsecase {
+	return &ReportUsecase{
+		reportRepo: reportRepo,
+		users:      users,
+		logger:     logger,
+	}
+}
+
+// reportInterval checks a requested bucket interval, a day when none was given
+func reportInterval(interval domain_stats.ReportInterval) (domain_stats.ReportInterval, error) {
+	if interval == "" {
+		return domain_stats.IntervalDay, nil
+	}
+	if !interval.Valid() {
+		return "", fmt.Errorf("%w: interval must be hour, day, week or month", domain.ErrInvalidInput)
+	}
+	return interval, nil
+}
+
+// GetEventReport reports on one event's sales, bucketed by interval
+func (u *ReportUsecase) GetEventReport(ctx context.Context, userID, eventID uuid.UUID, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error) {
+	interval, err := reportInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.users.Authorize(ctx, userID, reportRoles...); err != nil {
+		return nil, err
+	}
+	return u.reportRepo.GetForEvent(ctx, eventID, interval)
+}
+
+// GetSalesReport reports on the sales of every event taking place from
+// from until to, either of which may be nil for no bound
+func (u *ReportUsecase) GetSalesReport(ctx context.Context, userID uuid.UUID, from, to *time.Time, interval domain_stats.ReportInterval) (*domain_stats.SalesReport, error) {
+	interval, err := reportInterval(interval)
+	if err != nil {
+		return nil, err
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidInput)
+	}
+	if _, err := u.users.Authorize(ctx, userID, reportRoles...); err != nil {
+		return nil, err
+	}
+	return u.reportRepo.GetForEvents(ctx, from, to, interval)
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_refund "github.com/ojaswiii/booking-manager/src/internal/domain/refund"
+	domain_stats "github.com/ojaswiii/booking-manager/src/internal/domain/stats"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestSalesReportsForOrganizers(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, &utils.Config{}, logger)
+	reports := NewReportUsecase(repos.SalesReport, users, logger)
+	ctx := context.Background()
+
+	newUser := func(email string, role domain_user.Role) uuid.UUID {
+		t.Helper()
+		usr := &domain_user.User{ID: uuid.New(), Email: email, Name: email, Role: role}
+		if err := repos.User.Create(ctx, usr); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+		return usr.ID
+	}
+	admin := newUser("admin@example.com", domain_user.RoleAdmin)
+	organizer := newUser("organizer@example.com", domain_user.RoleCustomer)
+	fan := newUser("fan@example.com", domain_user.RoleCustomer)
+
+	event := &domain_event.Event{ID: uuid.New(), Name: "Summer Jam", Date: time.Date(2026, 7, 1, 20, 0, 0, 0, time.UTC), TotalSeats: 4, Status: domain_event.EventStatusPublished}
+	if err := repos.Event.Create(ctx, event); err != nil {
+		t.Fatalf("create event: %v", err)
+	}
+	var ticketIDs []uuid.UUID
+	for seat := 1; seat <= 4; seat++ {
+		tkt := &domain_ticket.Ticket{ID: uuid.New(), EventID: event.ID, SeatNumber: seat, Status: domain_ticket.TicketStatusSold, Price: 50}
+		if err := repos.Ticket.Create(ctx, tkt); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+		ticketIDs = append(ticketIDs, tkt.ID)
+	}
+
+	// Two seats sold on June 1st, one of them refunded on June 3rd; one sold
+	// on June 2nd for 55.00 EUR at 1.1 EUR per USD; one booking abandoned
+	monday := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	rate := 1.1
+	bookings := []*domain_booking.Booking{
+		{ID: uuid.New(), TicketIDs: ticketIDs[:2], Status: domain_booking.BookingStatusRefunded, TotalAmount: 100, Currency: "USD", CreatedAt: monday},
+		{ID: uuid.New(), TicketIDs: ticketIDs[2:3], Status: domain_booking.BookingStatusConfirmed, TotalAmount: 55, Currency: "EUR", ExchangeRate: &rate, CreatedAt: monday.Add(24 * time.Hour)},
+		{ID: uuid.New(), TicketIDs: ticketIDs[3:], Status: domain_booking.BookingStatusCancelled, TotalAmount: 50, Currency: "USD", CreatedAt: monday},
+	}
+	for _, bk := range bookings {
+		bk.UserID, bk.EventID, bk.UpdatedAt = fan, event.ID, bk.CreatedAt
+		for _, id := range bk.TicketIDs {
+			bk.Items = append(bk.Items, domain_booking.LineItem{BookingID: bk.ID, TicketID: id, UnitPrice: 50, Currency: bk.Currency})
+		}
+		if err := repos.Booking.Create(ctx, bk); err != nil {
+			t.Fatalf("create booking: %v", err)
+		}
+	}
+	refund := &domain_refund.Refund{ID: uuid.New(), BookingID: bookings[0].ID, Amount: 100, Currency: "USD", Status: domain_refund.StatusSucceeded, RequestedBy: "admin", CreatedAt: monday.Add(48 * time.Hour)}
+	if err := repos.Refund.Create(ctx, refund); err != nil {
+		t.Fatalf("create refund: %v", err)
+	}
+
+	// Customers may not read reports until an admin makes them organizers
+	if _, err := reports.GetEventReport(ctx, organizer, event.ID, ""); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("customer read a report: got %v, want ErrForbidden", err)
+	}
+	if _, err := reports.GetEventReport(ctx, uuid.New(), event.ID, ""); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("unknown user read a report: got %v, want ErrUnauthorized", err)
+	}
+	if _, err := users.SetRole(ctx, organizer, organizer, domain_user.RoleAdmin); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("customer granted a role: got %v, want ErrForbidden", err)
+	}
+	if _, err := users.SetRole(ctx, admin, organizer, domain_user.RoleOrganizer); err != nil {
+		t.Fatalf("grant organizer: %v", err)
+	}
+
+	report, err := reports.GetEventReport(ctx, organizer, event.ID, domain_stats.IntervalDay)
+	if err != nil {
+		t.Fatalf("event report: %v", err)
+	}
+	if report.TicketsSold != 3 || report.RevenueCents != 15000 || report.RefundedCents != 10000 || report.FillRate() != 0.75 {
+		t.Fatalf("report = %+v, want 3 of 4 seats sold for 15000 cents with 10000 refunded", report)
+	}
+	wantBuckets := []domain_stats.SalesBucket{
+		{Start: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), Bookings: 1, TicketsSold: 2, RevenueCents: 10000},
+		{Start: time.Date(2026, 6, 2, 0, 0, 0, 0, time.UTC), Bookings: 1, TicketsSold: 1, RevenueCents: 5000},
+		{Start: time.Date(2026, 6, 3, 0, 0, 0, 0, time.UTC), RefundedCents: 10000},
+	}
+	if len(report.Buckets) != len(wantBuckets) {
+		t.Fatalf("buckets = %+v, want %+v", report.Buckets, wantBuckets)
+	}
+	for i, want := range wantBuckets {
+		if report.Buckets[i] != want {
+			t.Errorf("bucket %d = %+v, want %+v", i, report.Buckets[i], want)
+		}
+	}
+
+	// The whole week in one bucket, across every event in June
+	from, to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	if report, err := reports.GetSalesReport(ctx, admin, &from, &to, domain_stats.IntervalWeek); err != nil || report.Events != 0 {
+		t.Fatalf("June report: got %+v, %v; want no events", report, err)
+	}
+	to = to.Add(24 * time.Hour)
+	report, err = reports.GetSalesReport(ctx, admin, &from, &to, domain_stats.IntervalWeek)
+	if err != nil {
+		t.Fatalf("sales report: %v", err)
+	}
+	if report.Events != 1 || len(report.Buckets) != 1 || !report.Buckets[0].Start.Equal(monday.Truncate(24*time.Hour)) || report.NetRevenueCents() != 5000 {
+		t.Fatalf("weekly report = %+v, want one week netting 5000 cents", report)
+	}
+
+	if _, err := reports.GetSalesReport(ctx, admin, nil, nil, "fortnight"); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("unknown interval: got %v, want ErrInvalidInput", err)
+	}
+}
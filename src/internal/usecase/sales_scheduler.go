@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// SalesScheduler watches event sales windows and refreshes cached events
+// as they go on sale or close, so customers see the change immediately
+type SalesScheduler struct {
+	eventRepo repository.EventRepository
+	events    *EventUsecase
+	interval  time.Duration
+	logger    *utils.Logger
+}
+
+// NewSalesScheduler creates a new sales scheduler
+func NewSalesScheduler(eventRepo repository.EventRepository, events *EventUsecase, interval time.Duration, logger *utils.Logger) *SalesScheduler {
+	return &SalesScheduler{
+		eventRepo: eventRepo,
+		events:    events,
+		interval:  interval,
+		logger:    logger,
+	}
+}
+
+// Run checks for sales window boundaries until the context is cancelled
+func (s *SalesScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	lastRun := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.flip(ctx, lastRun, now)
+			lastRun = now
+		}
+	}
+}
+
+// flip refreshes events whose sales opened or closed in (from, to]
+func (s *SalesScheduler) flip(ctx context.Context, from, to time.Time) {
+	events, err := s.eventRepo.GetSalesWindowChanges(ctx, from, to)
+	if err != nil {
+		s.logger.Error("Failed to check sales windows", "error", err)
+		return
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	for _, event := range events {
+		s.logger.Info("Event sales state changed",
+			"event_id", event.ID,
+			"sales_state", event.SalesStateAt(to))
+	}
+
+	// Drop the cached events and listing so availability flips for all clients
+	s.events.invalidateCache(ctx)
+}
@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSeatSuggestions = 5
+	maxSeatSuggestions     = 20
+	maxSessionLength       = 128
+)
+
+// SeatPosition is a preference for where in the venue to sit
+type SeatPosition string
+
+const (
+	SeatPositionAny   SeatPosition = ""
+	SeatPositionFront SeatPosition = "front"
+	SeatPositionBack  SeatPosition = "back"
+)
+
+type SeatSuggestionUsecase struct {
+	eventRepo      repository.EventRepository
+	ticketRepo     repository.TicketRepository
+	suggestionRepo repository.SeatSuggestionRepository
+	logger         *utils.Logger
+
+	rowSize    int
+	sessionTTL time.Duration
+}
+
+// NewSeatSuggestionUsecase creates a new seat suggestion usecase
+func NewSeatSuggestionUsecase(eventRepo repository.EventRepository, ticketRepo repository.TicketRepository, suggestionRepo repository.SeatSuggestionRepository, config *utils.Config, logger *utils.Logger) *SeatSuggestionUsecase {
+	return &SeatSuggestionUsecase{
+		eventRepo:      eventRepo,
+		ticketRepo:     ticketRepo,
+		suggestionRepo: suggestionRepo,
+		logger:         logger,
+		rowSize:        max(config.SeatMapRowSize, 1),
+		sessionTTL:     time.Duration(config.SeatSuggestionSessionMinutes) * time.Minute,
+	}
+}
+
+// SeatSuggestionRequest describes the party looking for seats. Session, when
+// set, ranks seats already suggested to it after ones it has not seen.
+type SeatSuggestionRequest struct {
+	EventID   uuid.UUID
+	PartySize int
+	Position  SeatPosition
+	Aisle     bool
+	Tier      int // 1-based price tier, 0 for any
+	Session   string
+	Limit     int
+}
+
+// SeatSuggestion is a group of adjacent available seats in one row
+type SeatSuggestion struct {
+	TicketIDs   []uuid.UUID `json:"ticket_ids"`
+	SeatNumbers []int       `json:"seat_numbers"`
+	Row         int         `json:"row"`
+	Tier        string      `json:"tier"`
+	Aisle       bool        `json:"aisle"`
+	TotalPrice  float64     `json:"total_price"`
+}
+
+// SeatSuggestionResponse lists suggestions, best first
+type SeatSuggestionResponse struct {
+	EventID     uuid.UUID        `json:"event_id"`
+	PartySize   int              `json:"party_size"`
+	Suggestions []SeatSuggestion `json:"suggestions"`
+}
+
+// SuggestSeats ranks groups of seats for a party from current availability.
+// Nothing is reserved: the ticket IDs of a suggestion are booked as usual.
+func (s *SeatSuggestionUsecase) SuggestSeats(ctx context.Context, req SeatSuggestionRequest) (*SeatSuggestionResponse, error) {
+	if req.PartySize < 1 || req.PartySize > s.rowSize {
+		return nil, fmt.Errorf("%w: party size must be between 1 and %d", domain.ErrInvalidInput, s.rowSize)
+	}
+	if req.Position != SeatPositionAny && req.Position != SeatPositionFront && req.Position != SeatPositionBack {
+		return nil, fmt.Errorf("%w: position must be front or back", domain.ErrInvalidInput)
+	}
+	if len(req.Session) > maxSessionLength {
+		return nil, fmt.Errorf("%w: session must be at most %d characters", domain.ErrInvalidInput, maxSessionLength)
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultSeatSuggestions
+	}
+	req.Limit = min(req.Limit, maxSeatSuggestions)
+
+	event, err := s.eventRepo.GetByID(ctx, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsBookable() && !event.IsPresaleOpen() {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotBookable, event.NotBookableReason())
+	}
+
+	tickets, err := s.ticketRepo.GetByEventID(ctx, req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+	tiers := seatTiers(event, tickets)
+	if req.Tier < 0 || req.Tier > len(tiers) {
+		return nil, fmt.Errorf("%w: event has %d price tiers", domain.ErrInvalidInput, len(tiers))
+	}
+
+	shown := map[int]bool{}
+	if req.Session != "" {
+		if shown, err = s.suggestionRepo.GetShown(ctx, req.EventID, req.Session); err != nil {
+			// Suggestions still work, just without the session's history
+			s.logger.Warn("Failed to load shown seats", "event_id", req.EventID, "error", err)
+			shown = map[int]bool{}
+		}
+	}
+
+	seatMap := seatMap{rowSize: s.rowSize, totalSeats: event.TotalSeats, tiers: tiers}
+	suggestions := seatMap.suggest(tickets, req, shown)
+
+	if req.Session != "" {
+		var seats []int
+		for _, suggestion := range suggestions {
+			seats = append(seats, suggestion.SeatNumbers...)
+		}
+		if err := s.suggestionRepo.AddShown(ctx, req.EventID, req.Session, seats, s.sessionTTL); err != nil {
+			s.logger.Warn("Failed to record shown seats", "event_id", req.EventID, "error", err)
+		}
+	}
+
+	return &SeatSuggestionResponse{
+		EventID:     req.EventID,
+		PartySize:   req.PartySize,
+		Suggestions: suggestions,
+	}, nil
+}
+
+// seatTiers groups the event's seats into price tiers the same way event
+// templates do
+func seatTiers(event *domain_event.Event, tickets []*domain_ticket.Ticket) domain_template.PriceTiers {
+	prices := make([]float64, event.TotalSeats)
+	for i := range prices {
+		prices[i] = event.Price
+	}
+	for _, ticket := range tickets {
+		if ticket.SeatNumber >= 1 && ticket.SeatNumber <= event.TotalSeats {
+			prices[ticket.SeatNumber-1] = ticket.Price
+		}
+	}
+	return domain_template.TiersFromSeatPrices(prices)
+}
+
+// seatMap lays seats out in rows of rowSize, numbered from the front row,
+// with an aisle at each end of every row
+type seatMap struct {
+	rowSize    int
+	totalSeats int
+	tiers      domain_template.PriceTiers
+}
+
+func (m seatMap) row(seat int) int {
+	return (seat-1)/m.rowSize + 1
+}
+
+func (m seatMap) rows() int {
+	return m.row(m.totalSeats)
+}
+
+func (m seatMap) tier(seat int) int {
+	for i, tier := range m.tiers {
+		if seat >= tier.FromSeat && seat <= tier.ToSeat {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+func (m seatMap) isAisle(seat int) bool {
+	return (seat-1)%m.rowSize == 0 || seat%m.rowSize == 0 || seat == m.totalSeats
+}
+
+type seatGroup struct {
+	tickets []*domain_ticket.Ticket
+	row     int
+	tier    int
+	aisle   bool
+	score   float64
+}
+
+// suggest finds every run of adjacent available seats in one row and tier
+// that fits the party, scores each against the preferences and returns the
+// best ones that do not share seats. Lower scores are better.
+func (m seatMap) suggest(tickets []*domain_ticket.Ticket, req SeatSuggestionRequest, shown map[int]bool) []SeatSuggestion {
+	available := make([]*domain_ticket.Ticket, 0, len(tickets))
+	for _, ticket := range tickets {
+		if ticket.Status == domain_ticket.TicketStatusAvailable && ticket.SeatNumber >= 1 && ticket.SeatNumber <= m.totalSeats {
+			available = append(available, ticket)
+		}
+	}
+	sort.Slice(available, func(i, j int) bool { return available[i].SeatNumber < available[j].SeatNumber })
+
+	var groups []seatGroup
+	for start := 0; start+req.PartySize <= len(available); start++ {
+		window := available[start : start+req.PartySize]
+		first, last := window[0].SeatNumber, window[len(window)-1].SeatNumber
+		if last-first != req.PartySize-1 || m.row(first) != m.row(last) || m.tier(first) != m.tier(last) {
+			continue
+		}
+		group := seatGroup{tickets: window, row: m.row(first), tier: m.tier(first), aisle: m.isAisle(first) || m.isAisle(last)}
+		if req.Tier != 0 && group.tier != req.Tier {
+			continue
+		}
+		group.score = m.score(group, req, shown)
+		groups = append(groups, group)
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].score < groups[j].score })
+
+	suggestions := []SeatSuggestion{}
+	taken := map[int]bool{}
+	for _, group := range groups {
+		if len(suggestions) == req.Limit {
+			break
+		}
+		overlaps := false
+		for _, ticket := range group.tickets {
+			overlaps = overlaps || taken[ticket.SeatNumber]
+		}
+		if overlaps {
+			continue
+		}
+
+		suggestion := SeatSuggestion{Row: group.row, Aisle: group.aisle}
+		if group.tier > 0 {
+			suggestion.Tier = m.tiers[group.tier-1].Name
+		}
+		for _, ticket := range group.tickets {
+			taken[ticket.SeatNumber] = true
+			suggestion.TicketIDs = append(suggestion.TicketIDs, ticket.ID)
+			suggestion.SeatNumbers = append(suggestion.SeatNumbers, ticket.SeatNumber)
+			suggestion.TotalPrice += ticket.Price
+		}
+		suggestion.TotalPrice = roundCents(suggestion.TotalPrice)
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions
+}
+
+func (m seatMap) score(group seatGroup, req SeatSuggestionRequest, shown map[int]bool) float64 {
+	var score float64
+
+	// Row preference, 0 for the preferred end of the venue and 1 for the other
+	if rows := m.rows(); rows > 1 {
+		depth := float64(group.row-1) / float64(rows-1)
+		switch req.Position {
+		case SeatPositionFront:
+			score += depth
+		case SeatPositionBack:
+			score += 1 - depth
+		}
+	}
+
+	// Seats nearer the middle of the row see better, up to half a row of preference
+	first := (group.tickets[0].SeatNumber - 1) % m.rowSize
+	center := float64(first) + float64(len(group.tickets)-1)/2
+	score += math.Abs(center-float64(m.rowSize-1)/2) / float64(m.rowSize)
+
+	if req.Aisle && !group.aisle {
+		score += 1
+	}
+
+	// Seats this session has already been shown go after everything new
+	for _, ticket := range group.tickets {
+		if shown[ticket.SeatNumber] {
+			score += 10
+			break
+		}
+	}
+	return score
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+func TestSeatMapSuggest(t *testing.T) {
+	// Three rows of six seats; row 1 is pricier, and seats 3 and 10 are sold
+	tiers := domain_template.PriceTiers{
+		{Name: "Tier 1", FromSeat: 1, ToSeat: 6, Price: 50},
+		{Name: "Tier 2", FromSeat: 7, ToSeat: 18, Price: 20},
+	}
+	m := seatMap{rowSize: 6, totalSeats: 18, tiers: tiers}
+	var tickets []*domain_ticket.Ticket
+	for seat := 1; seat <= 18; seat++ {
+		price, _ := tiers.PriceFor(seat)
+		status := domain_ticket.TicketStatusAvailable
+		if seat == 3 || seat == 10 {
+			status = domain_ticket.TicketStatusSold
+		}
+		tickets = append(tickets, &domain_ticket.Ticket{ID: uuid.New(), SeatNumber: seat, Status: status, Price: price})
+	}
+
+	seats := func(suggestions []SeatSuggestion) [][]int {
+		var got [][]int
+		for _, s := range suggestions {
+			got = append(got, s.SeatNumbers)
+		}
+		return got
+	}
+
+	tests := []struct {
+		name  string
+		req   SeatSuggestionRequest
+		shown map[int]bool
+		want  [][]int
+	}{
+		{
+			name: "front, centered first",
+			req:  SeatSuggestionRequest{PartySize: 2, Position: SeatPositionFront, Limit: 3},
+			want: [][]int{{4, 5}, {1, 2}, {8, 9}},
+		},
+		{
+			name: "back",
+			req:  SeatSuggestionRequest{PartySize: 3, Position: SeatPositionBack, Limit: 2},
+			want: [][]int{{14, 15, 16}, {7, 8, 9}},
+		},
+		{
+			name: "aisle in the cheaper tier",
+			req:  SeatSuggestionRequest{PartySize: 2, Aisle: true, Tier: 2, Limit: 2},
+			want: [][]int{{7, 8}, {11, 12}},
+		},
+		{
+			name:  "seats already shown go last",
+			req:   SeatSuggestionRequest{PartySize: 2, Position: SeatPositionFront, Limit: 2},
+			shown: map[int]bool{4: true, 5: true},
+			want:  [][]int{{1, 2}, {8, 9}},
+		},
+		{
+			name: "only a full row fits",
+			req:  SeatSuggestionRequest{PartySize: 6, Limit: 5},
+			want: [][]int{{13, 14, 15, 16, 17, 18}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := m.suggest(tickets, tt.req, tt.shown)
+			if !reflect.DeepEqual(seats(got), tt.want) {
+				t.Errorf("suggest() = %v, want %v", seats(got), tt.want)
+			}
+		})
+	}
+
+	got := m.suggest(tickets, SeatSuggestionRequest{PartySize: 2, Position: SeatPositionFront, Limit: 1}, nil)
+	if got[0].Tier != "Tier 1" || got[0].Row != 1 || got[0].TotalPrice != 100 || got[0].Aisle {
+		t.Errorf("suggestion = %+v, want row 1, Tier 1, 100.00, no aisle", got[0])
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+)
+
+// Overall states shown on the public status page
+const (
+	StatusOperational   = "operational"
+	StatusDegraded      = "degraded"
+	StatusPartialOutage = "partial_outage"
+)
+
+// On-sale load levels shown on the public status page
+const (
+	LoadNormal   = "normal"
+	LoadElevated = "elevated"
+	LoadHigh     = "high"
+	LoadCritical = "critical"
+)
+
+// Fractions of the queue latency target at which the load level rises
+const (
+	loadElevatedAt = 0.5
+	loadHighAt     = 0.8
+)
+
+// Degradation is a known problem affecting customers
+type Degradation struct {
+	Component string `json:"component"`
+	Summary   string `json:"summary"`
+}
+
+// PublicStatus is the status page view of the system. It carries no
+// internal metrics, only levels and customer-facing summaries.
+type PublicStatus struct {
+	Status       string        `json:"status"`
+	OnSaleLoad   string        `json:"on_sale_load"`
+	Degradations []Degradation `json:"degradations"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+}
+
+type StatusUsecase struct {
+	sla      *concurrency.SLATracker
+	overload *concurrency.OverloadPolicy
+	logger   *utils.Logger
+}
+
+// NewStatusUsecase creates a new public status usecase
+func NewStatusUsecase(sla *concurrency.SLATracker, overload *concurrency.OverloadPolicy, logger *utils.Logger) *StatusUsecase {
+	return &StatusUsecase{
+		sla:      sla,
+		overload: overload,
+		logger:   logger,
+	}
+}
+
+// GetStatus derives the public status from the booking SLA tracker and the
+// queue overload policy
+func (s *StatusUsecase) GetStatus() *PublicStatus {
+	status := &PublicStatus{
+		Status:       StatusOperational,
+		OnSaleLoad:   LoadNormal,
+		Degradations: []Degradation{},
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	rejecting := s.overload.Rejecting() || s.sla.Shedding()
+	breached := s.sla.Breached()
+	saturated := s.overload.Saturated()
+	load := s.sla.Load()
+
+	switch {
+	case rejecting || breached:
+		status.OnSaleLoad = LoadCritical
+	case saturated || load >= loadHighAt:
+		status.OnSaleLoad = LoadHigh
+	case load >= loadElevatedAt:
+		status.OnSaleLoad = LoadElevated
+	}
+
+	if rejecting {
+		status.Status = StatusPartialOutage
+		status.Degradations = append(status.Degradations, Degradation{
+			Component: "bookings",
+			Summary:   "Demand is very high; some booking requests are being held in the waiting room.",
+		})
+	}
+	if breached {
+		if status.Status == StatusOperational {
+			status.Status = StatusDegraded
+		}
+		status.Degradations = append(status.Degradations, Degradation{
+			Component: "bookings",
+			Summary:   "Bookings are taking longer than usual to be confirmed.",
+		})
+	}
+
+	return status
+}
@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_template "github.com/ojaswiii/booking-manager/src/internal/domain/template"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+type TemplateUsecase struct {
+	templateRepo repository.TemplateRepository
+	eventRepo    repository.EventRepository
+	ticketRepo   repository.TicketRepository
+	events       *EventUsecase
+	logger       *utils.Logger
+}
+
+// NewTemplateUsecase creates a new event template usecase
+func NewTemplateUsecase(templateRepo repository.TemplateRepository, eventRepo repository.EventRepository, ticketRepo repository.TicketRepository, events *EventUsecase, logger *utils.Logger) *TemplateUsecase {
+	return &TemplateUsecase{
+		templateRepo: templateRepo,
+		eventRepo:    eventRepo,
+		ticketRepo:   ticketRepo,
+		events:       events,
+		logger:       logger,
+	}
+}
+
+// CreateTemplate saves an existing event, including its seat prices, as a template
+func (t *TemplateUsecase) CreateTemplate(ctx context.Context, req domain_template.CreateTemplateRequest) (*domain_template.EventTemplate, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalidInput)
+	}
+
+	event, err := t.eventRepo.GetByID(ctx, req.EventID)
+	if err != nil {
+		return nil, err
+	}
+	tickets, err := t.ticketRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tickets: %w", err)
+	}
+
+	// Seats without a ticket fall back to the event price
+	prices := make([]float64, event.TotalSeats)
+	for i := range prices {
+		prices[i] = event.Price
+	}
+	for _, ticket := range tickets {
+		if ticket.SeatNumber >= 1 && ticket.SeatNumber <= event.TotalSeats {
+			prices[ticket.SeatNumber-1] = ticket.Price
+		}
+	}
+
+	now := time.Now()
+	tmpl := &domain_template.EventTemplate{
+		ID:            uuid.New(),
+		Name:          name,
+		SourceEventID: &event.ID,
+
+		EventName:  event.Name,
+		Artist:     event.Artist,
+		Venue:      event.Venue,
+		TotalSeats: event.TotalSeats,
+		Price:      event.Price,
+		Tiers:      domain_template.TiersFromSeatPrices(prices),
+
+		SalesStartOffset:   domain_template.OffsetBefore(event.Date, event.SalesStartAt),
+		SalesEndOffset:     domain_template.OffsetBefore(event.Date, event.SalesEndAt),
+		PresaleStartOffset: domain_template.OffsetBefore(event.Date, event.PresaleStartAt),
+
+		ConfirmationRequirements: event.ConfirmationRequirements,
+		WaitingRoomEnabled:       event.WaitingRoomEnabled,
+
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := t.templateRepo.Create(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to save template: %w", err)
+	}
+
+	t.logger.Info("Event template created", "template_id", tmpl.ID, "event_id", event.ID, "tiers", len(tmpl.Tiers))
+	return tmpl, nil
+}
+
+// GetTemplate returns a template by ID
+func (t *TemplateUsecase) GetTemplate(ctx context.Context, id uuid.UUID) (*domain_template.EventTemplate, error) {
+	return t.templateRepo.GetByID(ctx, id)
+}
+
+// ListTemplates returns every template
+func (t *TemplateUsecase) ListTemplates(ctx context.Context) ([]*domain_template.EventTemplate, error) {
+	return t.templateRepo.GetAll(ctx)
+}
+
+// DeleteTemplate removes a template; events created from it are unaffected
+func (t *TemplateUsecase) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	return t.templateRepo.Delete(ctx, id)
+}
+
+// Instantiate creates a new event from a template on the requested date.
+// Sales windows keep their distance from the event date and tickets are
+// priced by the template's tiers.
+func (t *TemplateUsecase) Instantiate(ctx context.Context, id uuid.UUID, req domain_template.InstantiateRequest) (*CreateEventResponse, error) {
+	tmpl, err := t.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Date == "" {
+		return nil, fmt.Errorf("%w: date is required", domain.ErrInvalidInput)
+	}
+	date, err := utils.ParseTime(req.Date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid date: %v", domain.ErrInvalidInput, err)
+	}
+
+	event := CreateEventRequest{
+		Name:       firstNonEmpty(req.Name, tmpl.EventName),
+		Artist:     firstNonEmpty(req.Artist, tmpl.Artist),
+		Venue:      firstNonEmpty(req.Venue, tmpl.Venue),
+		Date:       date.Format(time.RFC3339),
+		TotalSeats: tmpl.TotalSeats,
+		Price:      tmpl.Price,
+		Status:     req.Status,
+
+		SalesStartAt:   formatOptionalTime(domain_template.TimeBefore(date, tmpl.SalesStartOffset)),
+		SalesEndAt:     formatOptionalTime(domain_template.TimeBefore(date, tmpl.SalesEndOffset)),
+		PresaleStartAt: formatOptionalTime(domain_template.TimeBefore(date, tmpl.PresaleStartOffset)),
+
+		ConfirmationRequirements: tmpl.ConfirmationRequirements,
+		WaitingRoomEnabled:       tmpl.WaitingRoomEnabled,
+	}
+
+	response, err := t.events.createEvent(ctx, event, func(seat int) float64 {
+		if price, ok := tmpl.Tiers.PriceFor(seat); ok {
+			return price
+		}
+		return tmpl.Price
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.logger.Info("Event created from template", "template_id", tmpl.ID, "event_id", response.EventID)
+	return response, nil
+}
+
+// firstNonEmpty returns override unless it is blank
+func firstNonEmpty(override, fallback string) string {
+	if strings.TrimSpace(override) != "" {
+		return override
+	}
+	return fallback
+}
+
+// formatOptionalTime formats a time as RFC 3339, returning "" for nil
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/pdf"
+	"github.com/ojaswiii/booking-manager/src/utils/qrcode"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidTicketPass is returned for tokens that are malformed or not signed by us
+var ErrInvalidTicketPass = fmt.Errorf("%w: invalid ticket pass", domain.ErrInvalidInput)
+
+// ErrTicketsNotIssued is returned when tickets are requested for an unconfirmed booking
+var ErrTicketsNotIssued = fmt.Errorf("%w: tickets are only issued for confirmed bookings", domain.ErrConflict)
+
+// Ticket PDF layout, in points
+const (
+	ticketMargin = 56.0
+	ticketQRSize = 220.0
+)
+
+type TicketPassUsecase struct {
+	passRepo    repository.TicketPassRepository
+	bookingRepo repository.BookingRepository
+	eventRepo   repository.EventRepository
+	ticketRepo  repository.TicketRepository
+	userRepo    repository.UserRepository
+	logger      *utils.Logger
+	secret      []byte
+}
+
+// NewTicketPassUsecase creates a new ticket pass usecase
+func NewTicketPassUsecase(
+	passRepo repository.TicketPassRepository,
+	bookingRepo repository.BookingRepository,
+	eventRepo repository.EventRepository,
+	ticketRepo repository.TicketRepository,
+	userRepo repository.UserRepository,
+	config *utils.Config,
+	logger *utils.Logger,
+) *TicketPassUsecase {
+	secret := []byte(config.TicketSigningSecret)
+	if len(secret) == 0 {
+		// Without a configured secret, issued tickets stop scanning after a restart
+		secret = make([]byte, 32)
+		rand.Read(secret)
+		logger.Warn("TICKET_SIGNING_SECRET not set, using an ephemeral signing key")
+	}
+
+	return &TicketPassUsecase{
+		passRepo:    passRepo,
+		bookingRepo: bookingRepo,
+		eventRepo:   eventRepo,
+		ticketRepo:  ticketRepo,
+		userRepo:    userRepo,
+		logger:      logger,
+		secret:      secret,
+	}
+}
+
+// Issue creates a pass for every ticket of a confirmed booking. Passes that
+// already exist are kept, so issuing twice is harmless.
+func (t *TicketPassUsecase) Issue(ctx context.Context, booking *domain_booking.Booking) error {
+	now := time.Now()
+	passes := make([]*domain_ticket.Pass, len(booking.TicketIDs))
+	for i, ticketID := range booking.TicketIDs {
+		passes[i] = &domain_ticket.Pass{
+			ID:        uuid.New(),
+			BookingID: booking.ID,
+			TicketID:  ticketID,
+			Token:     t.Token(booking.ID, ticketID),
+			IssuedAt:  now,
+		}
+	}
+	return t.passRepo.Create(ctx, passes...)
+}
+
+// Reissue revokes the passes of tickets taken off a confirmed booking and
+// issues passes for the tickets now on it. A ticket that has been checked in
+// cannot be taken off.
+func (t *TicketPassUsecase) Reissue(ctx context.Context, booking *domain_booking.Booking, removed []uuid.UUID) error {
+	passes, err := t.passRepo.GetByBookingID(ctx, booking.ID)
+	if err != nil {
+		return err
+	}
+	for _, pass := range passes {
+		if pass.CheckedInAt == nil {
+			continue
+		}
+		for _, ticketID := range removed {
+			if pass.TicketID == ticketID {
+				return fmt.Errorf("%w: ticket %s has already been checked in", domain.ErrConflict, ticketID)
+			}
+		}
+	}
+
+	if err := t.passRepo.Revoke(ctx, booking.ID, removed); err != nil {
+		return err
+	}
+	return t.Issue(ctx, booking)
+}
+
+// Token returns the signed QR token for a ticket of a booking
+func (t *TicketPassUsecase) Token(bookingID, ticketID uuid.UUID) string {
+	raw := make([]byte, 0, 32)
+	raw = append(raw, bookingID[:]...)
+	raw = append(raw, ticketID[:]...)
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(t.sign([]byte(payload)))
+}
+
+// ParseToken verifies a scanned token and returns the booking and ticket it is for
+func (t *TicketPassUsecase) ParseToken(token string) (bookingID, ticketID uuid.UUID, err error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicketPass
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sig, t.sign([]byte(payload))) {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicketPass
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil || len(raw) != 32 {
+		return uuid.Nil, uuid.Nil, ErrInvalidTicketPass
+	}
+
+	copy(bookingID[:], raw[:16])
+	copy(ticketID[:], raw[16:])
+	return bookingID, ticketID, nil
+}
+
+func (t *TicketPassUsecase) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, t.secret)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// TicketsPDF renders the tickets of a confirmed booking, one per page, each
+// with the QR code of its pass
+func (t *TicketPassUsecase) TicketsPDF(ctx context.Context, bookingID, userID uuid.UUID) ([]byte, error) {
+	booking, err := t.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	// Don't reveal other users' bookings
+	if booking.UserID != userID {
+		return nil, domain.ErrNotFound
+	}
+	if booking.Status != domain_booking.BookingStatusConfirmed {
+		return nil, ErrTicketsNotIssued
+	}
+
+	passes, err := t.passRepo.GetByBookingID(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load ticket passes: %w", err)
+	}
+	if len(passes) < len(booking.TicketIDs) {
+		// Bookings confirmed before passes existed get them on first download
+		if err := t.Issue(ctx, booking); err != nil {
+			return nil, fmt.Errorf("failed to issue ticket passes: %w", err)
+		}
+		if passes, err = t.passRepo.GetByBookingID(ctx, booking.ID); err != nil {
+			return nil, fmt.Errorf("failed to load ticket passes: %w", err)
+		}
+	}
+
+	event, err := t.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event: %w", err)
+	}
+	user, err := t.userRepo.GetByID(ctx, booking.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	// Print the prices locked at reservation, not the current ones
+	items, err := t.bookingRepo.GetItems(ctx, booking.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load booking items: %w", err)
+	}
+	prices := make(map[uuid.UUID]string, len(items))
+	for _, item := range items {
+		prices[item.TicketID] = fmt.Sprintf("%.2f %s", item.UnitPrice, item.Currency)
+	}
+
+	doc := pdf.New()
+	for i, pass := range passes {
+		ticket, err := t.ticketRepo.GetByID(ctx, pass.TicketID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load ticket: %w", err)
+		}
+		price, ok := prices[ticket.ID]
+		if !ok {
+			// Bookings made before line items were recorded
+			price = fmt.Sprintf("%.2f %s", ticket.Price, booking.Currency)
+		}
+		if err := drawTicket(doc.AddPage(), event, ticket, pass, price, user.Name, i+1, len(passes)); err != nil {
+			return nil, err
+		}
+	}
+
+	t.logger.Info("Tickets rendered", "booking_id", booking.ID, "tickets", len(passes))
+	return doc.Bytes(), nil
+}
+
+// drawTicket lays out one ticket on a page
+func drawTicket(page *pdf.Page, event *domain_event.Event, ticket *domain_ticket.Ticket, pass *domain_ticket.Pass, price, attendee string, n, total int) error {
+	code, err := qrcode.Encode([]byte(pass.Token))
+	if err != nil {
+		return fmt.Errorf("failed to encode ticket pass: %w", err)
+	}
+
+	left := ticketMargin
+	top := pdf.PageHeight - ticketMargin
+
+	page.Text(left, top-10, pdf.Bold, 10, fmt.Sprintf("ADMIT ONE - TICKET %d OF %d", n, total))
+	page.Text(left, top-44, pdf.Bold, 26, event.Name)
+	page.Text(left, top-68, pdf.Regular, 14, event.Artist)
+	page.Text(left, top-88, pdf.Regular, 12, event.Venue+" - "+event.Date.Format("Mon, 02 Jan 2006 15:04 MST"))
+	page.Line(left, top-104, pdf.PageWidth-ticketMargin, top-104, 0.5)
+
+	details := [][2]string{
+		{"Seat", fmt.Sprintf("%d", ticket.SeatNumber)},
+		{"Price", price},
+		{"Attendee", attendee},
+		{"Booking", pass.BookingID.String()},
+		{"Ticket", pass.TicketID.String()},
+	}
+	y := top - 136
+	for _, detail := range details {
+		page.Text(left, y, pdf.Bold, 10, detail[0])
+		page.Text(left+70, y, pdf.Regular, 10, detail[1])
+		y -= 18
+	}
+
+	// The quiet zone is left blank around the code so scanners can find it
+	module := ticketQRSize / float64(code.Size+2*qrcode.QuietZone)
+	originX := (pdf.PageWidth - ticketQRSize) / 2
+	originY := y - 24 - ticketQRSize
+	for row := 0; row < code.Size; row++ {
+		rowY := originY + ticketQRSize - float64(row+qrcode.QuietZone+1)*module
+		for col := 0; col < code.Size; {
+			if !code.Dark(row, col) {
+				col++
+				continue
+			}
+			start := col
+			for col < code.Size && code.Dark(row, col) {
+				col++
+			}
+			page.Rect(originX+float64(start+qrcode.QuietZone)*module, rowY, float64(col-start)*module, module)
+		}
+	}
+
+	page.Text(left, originY-28, pdf.Regular, 10, "Present this code at the entrance.")
+	return nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestTicketPassTokenRoundTrip(t *testing.T) {
+	passes := NewTicketPassUsecase(nil, nil, nil, nil, nil, &utils.Config{TicketSigningSecret: "secret"}, utils.NewLogger())
+	bookingID, ticketID := uuid.New(), uuid.New()
+
+	token := passes.Token(bookingID, ticketID)
+	gotBooking, gotTicket, err := passes.ParseToken(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotBooking != bookingID || gotTicket != ticketID {
+		t.Fatalf("got %s/%s, want %s/%s", gotBooking, gotTicket, bookingID, ticketID)
+	}
+
+	other := NewTicketPassUsecase(nil, nil, nil, nil, nil, &utils.Config{TicketSigningSecret: "other"}, utils.NewLogger())
+	forged := passes.Token(uuid.New(), ticketID)[:43] + token[43:]
+	for name, bad := range map[string]string{
+		"other key":   other.Token(bookingID, ticketID),
+		"swapped ids": forged,
+		"no dot":      "garbage",
+	} {
+		if _, _, err := passes.ParseToken(bad); !errors.Is(err, ErrInvalidTicketPass) {
+			t.Errorf("%s: got %v, want ErrInvalidTicketPass", name, err)
+		}
+	}
+}
@@ -2,37 +2,49 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
 	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
 	"github.com/ojaswiii/booking-manager/src/internal/repository"
 	"github.com/ojaswiii/booking-manager/src/utils"
+	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
 
 	"github.com/google/uuid"
 )
 
 type UserUsecase struct {
-	userRepo  repository.UserRepository
-	cacheRepo repository.UserCacheRepository
-	logger    *utils.Logger
+	userRepo      repository.UserRepository
+	cacheRepo     repository.UserCacheRepository
+	invalidations repository.CacheInvalidationRepository
+	loads         *concurrency.SingleFlight // one database load per missed cache key
+	notFoundTTL   time.Duration             // how long missing users are remembered; 0 for never
+	logger        *utils.Logger
 }
 
 // UserRepository and UserCacheRepository interfaces are defined in repository/index.go
 
 // NewUserUsecase creates a new user usecase
-func NewUserUsecase(userRepo repository.UserRepository, cacheRepo repository.UserCacheRepository, logger *utils.Logger) *UserUsecase {
+func NewUserUsecase(userRepo repository.UserRepository, cacheRepo repository.UserCacheRepository, invalidations repository.CacheInvalidationRepository, config *utils.Config, logger *utils.Logger) *UserUsecase {
 	return &UserUsecase{
-		userRepo:  userRepo,
-		cacheRepo: cacheRepo,
-		logger:    logger,
+		userRepo:      userRepo,
+		cacheRepo:     cacheRepo,
+		invalidations: invalidations,
+		loads:         concurrency.NewSingleFlight(),
+		notFoundTTL:   time.Duration(config.CacheNotFoundTTLMs) * time.Millisecond,
+		logger:        logger,
 	}
 }
 
-// CreateUserRequest represents a request to create a user
+// CreateUserRequest represents a request to create a user, who signs in
+// with the password
 type CreateUserRequest struct {
-	Email string `json:"email"`
-	Name  string `json:"name"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
 }
 
 // CreateUserResponse represents the response of creating a user
@@ -42,12 +54,23 @@ type CreateUserResponse struct {
 	Name   string    `json:"name"`
 }
 
-// CreateUser creates a new user
+// CreateUser creates a new user with a password
 func (u *UserUsecase) CreateUser(ctx context.Context, req CreateUserRequest) (*CreateUserResponse, error) {
-	// Check if user already exists
+	hash, err := domain_user.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+	return u.create(ctx, req, hash)
+}
+
+// create saves a new user with a password hash, or none for users who only
+// sign in through a provider until they set one
+func (u *UserUsecase) create(ctx context.Context, req CreateUserRequest, passwordHash string) (*CreateUserResponse, error) {
+	// Check if user already exists. Two requests can both get past this,
+	// so the unique index on email has the final say in Create.
 	existingUser, err := u.userRepo.GetByEmail(ctx, req.Email)
 	if err == nil && existingUser != nil {
-		return nil, fmt.Errorf("user with email %s already exists", req.Email)
+		return nil, domain_user.ErrEmailTaken
 	}
 
 	// Create user
@@ -55,14 +78,23 @@ func (u *UserUsecase) CreateUser(ctx context.Context, req CreateUserRequest) (*C
 		ID:        uuid.New(),
 		Email:     req.Email,
 		Name:      req.Name,
+		Role:      domain_user.RoleCustomer,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
 
 	// Save user to database
 	if err := u.userRepo.Create(ctx, user); err != nil {
+		if errors.Is(err, domain_user.ErrEmailTaken) {
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to save user: %w", err)
 	}
+	if passwordHash != "" {
+		if err := u.userRepo.SetPasswordHash(ctx, user.ID, passwordHash); err != nil {
+			return nil, fmt.Errorf("failed to save password: %w", err)
+		}
+	}
 
 	// Cache user
 	if err := u.cacheRepo.Create(ctx, user); err != nil {
@@ -90,19 +122,36 @@ func (u *UserUsecase) GetUser(ctx context.Context, userID uuid.UUID) (*domain_us
 	if err == nil && user != nil {
 		return user, nil
 	}
+	if errors.Is(err, repository.ErrCachedNotFound) {
+		return nil, domain.ErrNotFound
+	}
 
-	// Fallback to database
-	user, err = u.userRepo.GetByID(ctx, userID)
+	// Fallback to database, once for every caller that missed the same user
+	key := tenant.FromContext(ctx) + ":user:" + userID.String()
+	loaded, err := u.loads.Do(ctx, key, func() (interface{}, error) {
+		user, err := u.userRepo.GetByID(ctx, userID)
+		if errors.Is(err, domain.ErrNotFound) && u.notFoundTTL > 0 {
+			if err := u.cacheRepo.SetNotFound(ctx, userID, u.notFoundTTL); err != nil {
+				u.logger.Warn("Failed to cache missing user", "user_id", userID, "error", err)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// Cache the result
+		if err := u.cacheRepo.Create(ctx, user); err != nil {
+			u.logger.Warn("Failed to cache user", "user_id", userID, "error", err)
+		}
+		return user, nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Cache the result
-	if err := u.cacheRepo.Create(ctx, user); err != nil {
-		u.logger.Warn("Failed to cache user", "user_id", userID, "error", err)
-	}
-
-	return user, nil
+	// Each caller gets its own copy of the shared user
+	copied := *loaded.(*domain_user.User)
+	return &copied, nil
 }
 
 // GetUserByEmail retrieves a user by email
@@ -138,13 +187,158 @@ func (u *UserUsecase) UpdateUser(ctx context.Context, user *domain_user.User) er
 	if err := u.cacheRepo.Update(ctx, user); err != nil {
 		u.logger.Warn("Failed to update user cache", "user_id", user.ID, "error", err)
 	}
+	u.publishInvalidation(ctx, user.ID)
 
 	u.logger.Info("User updated successfully", "user_id", user.ID)
 	return nil
 }
 
-// DeleteUser deletes a user
-func (u *UserUsecase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
+// UpdateProfileRequest is a change to a user's name and email
+type UpdateProfileRequest struct {
+	Email           string
+	Name            string
+	CurrentPassword string // needed when users change their own email
+}
+
+// UpdateProfile changes userID's name and email, for the user themselves
+// or an admin. Users changing their own email confirm it with their
+// password, since password resets are sent to the new address. The second
+// result is whether the email changed, and so needs verifying again.
+func (u *UserUsecase) UpdateProfile(ctx context.Context, actorID, userID uuid.UUID, req UpdateProfileRequest) (*domain_user.User, bool, error) {
+	if err := u.authorizeSelfOrAdmin(ctx, actorID, userID); err != nil {
+		return nil, false, err
+	}
+	user, err := u.GetUser(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := user.Email != req.Email
+	if changed && actorID == userID {
+		if err := u.CheckPassword(ctx, userID, req.CurrentPassword); err != nil {
+			return nil, false, fmt.Errorf("%w: changing your email needs your current password", domain.ErrForbidden)
+		}
+	}
+	user.ChangeEmail(req.Email)
+	user.Name = req.Name
+	if err := u.UpdateUser(ctx, user); err != nil {
+		return nil, false, err
+	}
+	return user, changed, nil
+}
+
+// authorizeSelfOrAdmin lets actorID manage userID's account when they are
+// that user or an admin
+func (u *UserUsecase) authorizeSelfOrAdmin(ctx context.Context, actorID, userID uuid.UUID) error {
+	if actorID == userID {
+		return nil
+	}
+	_, err := u.Authorize(ctx, actorID, domain_user.RoleAdmin)
+	return err
+}
+
+// Authorize returns userID's user if they have one of roles and are not
+// locked. Unknown users are unauthorized and everyone else forbidden.
+func (u *UserUsecase) Authorize(ctx context.Context, userID uuid.UUID, roles ...domain_user.Role) (*domain_user.User, error) {
+	user, err := u.GetUser(ctx, userID)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, fmt.Errorf("%w: unknown user", domain.ErrUnauthorized)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Locked() {
+		return nil, domain_user.ErrAccountLocked
+	}
+	if !user.HasRole(roles...) {
+		return nil, fmt.Errorf("%w: needs the role %v", domain.ErrForbidden, roles)
+	}
+	return user, nil
+}
+
+// SetRole gives userID a role. Only admins may grant roles.
+func (u *UserUsecase) SetRole(ctx context.Context, adminID, userID uuid.UUID, role domain_user.Role) (*domain_user.User, error) {
+	if !role.Valid() {
+		return nil, fmt.Errorf("%w: unknown role %q", domain.ErrInvalidInput, role)
+	}
+	if _, err := u.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	if err := u.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	u.logger.Info("User role changed", "user_id", userID, "role", role, "by", adminID)
+	return user, nil
+}
+
+// VerifyEmail marks email as verified for userID, if it is still their
+// email. Verifying again changes nothing.
+func (u *UserUsecase) VerifyEmail(ctx context.Context, userID uuid.UUID, email string) (*domain_user.User, error) {
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != email {
+		return nil, fmt.Errorf("%w: email has changed since it was sent for verification", domain.ErrConflict)
+	}
+	if user.EmailVerified() {
+		return user, nil
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	user.UpdatedAt = now
+	if err := u.UpdateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	u.logger.Info("Email verified", "user_id", userID)
+	return user, nil
+}
+
+// SetPassword replaces userID's password
+func (u *UserUsecase) SetPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	hash, err := domain_user.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	if err := u.userRepo.SetPasswordHash(ctx, userID, hash); err != nil {
+		return err
+	}
+	u.logger.Info("Password changed", "user_id", userID)
+	return nil
+}
+
+// CheckPassword returns ErrWrongPassword unless password is userID's, and
+// ErrNoPassword for users who never set one: they sign in through a
+// provider, or set one by resetting it.
+func (u *UserUsecase) CheckPassword(ctx context.Context, userID uuid.UUID, password string) error {
+	hash, err := u.userRepo.GetPasswordHash(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if hash == "" {
+		return ErrNoPassword
+	}
+	if !domain_user.CheckPassword(hash, password) {
+		return ErrWrongPassword
+	}
+	return nil
+}
+
+// DeleteUser soft deletes a user, for the user themselves or an admin.
+// Their bookings stay; an admin can restore or purge them through
+// DeletionUsecase.
+func (u *UserUsecase) DeleteUser(ctx context.Context, actorID, userID uuid.UUID) error {
+	if err := u.authorizeSelfOrAdmin(ctx, actorID, userID); err != nil {
+		return err
+	}
 	// Delete from database
 	if err := u.userRepo.Delete(ctx, userID); err != nil {
 		return err
@@ -154,7 +348,24 @@ func (u *UserUsecase) DeleteUser(ctx context.Context, userID uuid.UUID) error {
 	if err := u.cacheRepo.Delete(ctx, userID); err != nil {
 		u.logger.Warn("Failed to delete user from cache", "user_id", userID, "error", err)
 	}
+	u.publishInvalidation(ctx, userID)
 
 	u.logger.Info("User deleted successfully", "user_id", userID)
 	return nil
 }
+
+// publishInvalidation tells every instance that userID changed
+func (u *UserUsecase) publishInvalidation(ctx context.Context, userID uuid.UUID) {
+	inv := repository.CacheInvalidation{Kind: repository.CacheInvalidateUser, ID: userID}
+	if err := u.invalidations.Publish(ctx, inv); err != nil {
+		u.logger.Warn("Failed to publish user cache invalidation", "user_id", userID, "error", err)
+	}
+}
+
+// dropCached deletes userID's cache entry once every instance has heard of
+// the change, in case another instance cached a copy read before it
+func (u *UserUsecase) dropCached(ctx context.Context, userID uuid.UUID) {
+	if err := u.cacheRepo.Delete(ctx, userID); err != nil {
+		u.logger.Warn("Failed to delete user from cache", "user_id", userID, "error", err)
+	}
+}
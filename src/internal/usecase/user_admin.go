@@ -0,0 +1,208 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_audit "github.com/ojaswiii/booking-manager/src/internal/domain/audit"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// UserAdminUsecase lets admins find users and look after their accounts:
+// list their bookings, lock them out and let them back in, and act as them
+// for support. Locks and impersonations are recorded in the audit log.
+type UserAdminUsecase struct {
+	userRepo   repository.UserRepository
+	auditRepo  repository.AuditRepository
+	transactor repository.Transactor
+	users      *UserUsecase
+	bookings   *BookingUsecase
+	auth       *AuthUsecase
+	logger     *utils.Logger
+}
+
+// NewUserAdminUsecase creates a new user admin usecase
+func NewUserAdminUsecase(userRepo repository.UserRepository, auditRepo repository.AuditRepository, transactor repository.Transactor, users *UserUsecase, bookings *BookingUsecase, auth *AuthUsecase, logger *utils.Logger) *UserAdminUsecase {
+	return &UserAdminUsecase{
+		userRepo:   userRepo,
+		auditRepo:  auditRepo,
+		transactor: transactor,
+		users:      users,
+		bookings:   bookings,
+		auth:       auth,
+		logger:     logger,
+	}
+}
+
+// UserAdminActionRequest represents why an admin locks, unlocks or acts as
+// a user, kept in the audit log
+type UserAdminActionRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SearchUsers lists the users matching filter, newest first
+func (u *UserAdminUsecase) SearchUsers(ctx context.Context, adminID uuid.UUID, filter domain_user.UserFilter) ([]*domain_user.User, error) {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return u.userRepo.Search(ctx, filter)
+}
+
+// GetUserBookings lists a user's bookings
+func (u *UserAdminUsecase) GetUserBookings(ctx context.Context, adminID, userID uuid.UUID) ([]*domain_booking.Booking, error) {
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := u.users.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return u.bookings.GetUserBookings(ctx, userID)
+}
+
+// LockUser locks userID out and ends their sessions, returning the user and
+// how many sessions were ended. Access tokens already handed out are
+// accepted until they expire.
+func (u *UserAdminUsecase) LockUser(ctx context.Context, adminID, userID uuid.UUID, req UserAdminActionRequest) (*domain_user.User, int, error) {
+	if adminID == userID {
+		return nil, 0, fmt.Errorf("%w: admins cannot lock themselves out", domain.ErrInvalidInput)
+	}
+	user, err := u.setLock(ctx, adminID, userID, true, req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ended, err := u.auth.EndSessions(ctx, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	u.logger.Info("User locked", "user_id", userID, "by", adminID, "sessions", ended)
+	return user, ended, nil
+}
+
+// UnlockUser lets a locked user sign in again
+func (u *UserAdminUsecase) UnlockUser(ctx context.Context, adminID, userID uuid.UUID, req UserAdminActionRequest) (*domain_user.User, error) {
+	user, err := u.setLock(ctx, adminID, userID, false, req)
+	if err != nil {
+		return nil, err
+	}
+	u.logger.Info("User unlocked", "user_id", userID, "by", adminID)
+	return user, nil
+}
+
+// Impersonate hands adminID an access token to act as userID. Other admins
+// cannot be impersonated, nor locked users, who must be unlocked first.
+func (u *UserAdminUsecase) Impersonate(ctx context.Context, adminID, userID uuid.UUID, req UserAdminActionRequest) (*ImpersonationResponse, error) {
+	reason, err := actionReason(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	user, err := u.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.HasRole(domain_user.RoleAdmin) {
+		return nil, fmt.Errorf("%w: admins cannot be impersonated", domain.ErrForbidden)
+	}
+	if user.Locked() {
+		return nil, domain_user.ErrAccountLocked
+	}
+
+	token, err := u.auth.Impersonate(user, adminID)
+	if err != nil {
+		return nil, err
+	}
+	// The token is only handed out once the impersonation is on record
+	if err := u.audit(ctx, domain_audit.ActionUserImpersonated, adminID, userID, reason, time.Now()); err != nil {
+		return nil, err
+	}
+	u.logger.Info("User impersonated", "user_id", userID, "by", adminID)
+	return token, nil
+}
+
+// setLock locks or unlocks userID, recording it in the audit log in the
+// same transaction
+func (u *UserAdminUsecase) setLock(ctx context.Context, adminID, userID uuid.UUID, lock bool, req UserAdminActionRequest) (*domain_user.User, error) {
+	reason, err := actionReason(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := u.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+
+	var user *domain_user.User
+	err = u.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if user, err = u.userRepo.GetByID(ctx, userID); err != nil {
+			return err
+		}
+		if user.Locked() == lock {
+			return fmt.Errorf("%w: user %s is already %s", domain.ErrConflict, userID, lockState(lock))
+		}
+
+		now := time.Now()
+		action := domain_audit.ActionUserUnlocked
+		user.LockedAt = nil
+		if lock {
+			action = domain_audit.ActionUserLocked
+			user.LockedAt = &now
+		}
+		user.UpdatedAt = now
+		if err := u.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("failed to update user: %w", err)
+		}
+		return u.audit(ctx, action, adminID, userID, reason, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Cached copies still have the old lock
+	u.users.dropCached(ctx, userID)
+	u.users.publishInvalidation(ctx, userID)
+	return user, nil
+}
+
+// audit records an admin's action on a user with its reason
+func (u *UserAdminUsecase) audit(ctx context.Context, action domain_audit.Action, adminID, userID uuid.UUID, reason string, at time.Time) error {
+	details, err := json.Marshal(UserAdminActionRequest{Reason: reason})
+	if err != nil {
+		return err
+	}
+	return u.auditRepo.Create(ctx, &domain_audit.Entry{
+		ID:        uuid.New(),
+		Action:    action,
+		ActorID:   adminID,
+		SubjectID: userID,
+		Details:   details,
+		CreatedAt: at,
+	})
+}
+
+// actionReason returns the reason an admin gave, which every audited
+// action needs
+func actionReason(req UserAdminActionRequest) (string, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return "", fmt.Errorf("%w: reason is required", domain.ErrInvalidInput)
+	}
+	return reason, nil
+}
+
+func lockState(locked bool) string {
+	if locked {
+		return "locked"
+	}
+	return "unlocked"
+}
@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_session "github.com/ojaswiii/booking-manager/src/internal/domain/session"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestAdminSearchesLocksAndImpersonatesUsers(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{AuthSigningSecret: "secret", AccessTokenTTLSeconds: 60, SessionIdleHours: 1}
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	auth := NewAuthUsecase(repos.Session, repos.Identity, repos.PasswordReset, repos.RateLimit, users, nil, nil, config, logger)
+	admin := NewUserAdminUsecase(repos.User, repos.Audit, repos.Transactor, users, nil, auth, logger)
+	ctx := context.Background()
+	client := domain_session.Client{UserAgent: "phone", IPAddress: "10.0.0.1"}
+	signup := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	boss := &domain_user.User{ID: uuid.New(), Email: "boss@example.com", Name: "Boss", Role: domain_user.RoleAdmin, CreatedAt: signup}
+	fan := &domain_user.User{ID: uuid.New(), Email: "Fan@example.com", Name: "Fan", CreatedAt: signup.Add(24 * time.Hour)}
+	fanatic := &domain_user.User{ID: uuid.New(), Email: "fanatic@example.com", Name: "Fanatic", CreatedAt: signup.Add(48 * time.Hour)}
+	for _, usr := range []*domain_user.User{boss, fan, fanatic} {
+		if err := repos.User.Create(ctx, usr); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	// Email prefixes ignore case, and the newest users come first
+	found, err := admin.SearchUsers(ctx, boss.ID, domain_user.UserFilter{EmailPrefix: "fan"})
+	if err != nil || len(found) != 2 || found[0].ID != fanatic.ID || found[1].ID != fan.ID {
+		t.Fatalf("search by prefix: got %d users, %v", len(found), err)
+	}
+	to := signup.Add(36 * time.Hour)
+	found, err = admin.SearchUsers(ctx, boss.ID, domain_user.UserFilter{CreatedTo: &to, Limit: 1, Offset: 1})
+	if err != nil || len(found) != 1 || found[0].ID != boss.ID {
+		t.Fatalf("search by signup date: got %d users, %v", len(found), err)
+	}
+	if _, err := admin.SearchUsers(ctx, fan.ID, domain_user.UserFilter{}); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("search by customer: got %v, want forbidden", err)
+	}
+
+	// Locking ends the user's sessions and keeps them out until unlocked
+	if err := users.SetPassword(ctx, fan.ID, "correct horse"); err != nil {
+		t.Fatalf("set password: %v", err)
+	}
+	login := LoginRequest{Email: fan.Email, Password: "correct horse"}
+	tokens, err := auth.Login(ctx, login, client)
+	if err != nil {
+		t.Fatalf("login: %v", err)
+	}
+	if _, _, err := admin.LockUser(ctx, boss.ID, fan.ID, UserAdminActionRequest{}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("lock without reason: got %v, want invalid input", err)
+	}
+	if _, _, err := admin.LockUser(ctx, boss.ID, boss.ID, UserAdminActionRequest{Reason: "testing"}); !errors.Is(err, domain.ErrInvalidInput) {
+		t.Fatalf("lock self: got %v, want invalid input", err)
+	}
+	locked, ended, err := admin.LockUser(ctx, boss.ID, fan.ID, UserAdminActionRequest{Reason: "chargeback fraud"})
+	if err != nil || !locked.Locked() || ended != 1 {
+		t.Fatalf("lock: got %+v, %d, %v", locked, ended, err)
+	}
+	if _, err := auth.Refresh(ctx, RefreshRequest{RefreshToken: tokens.RefreshToken}, client); err == nil {
+		t.Fatal("refresh after lock succeeded")
+	}
+	if _, err := auth.Authenticate(ctx, tokens.AccessToken); !errors.Is(err, ErrInvalidAccessToken) {
+		t.Fatalf("access token after lock: got %v, want ErrInvalidAccessToken", err)
+	}
+	if _, err := auth.Login(ctx, login, client); !errors.Is(err, domain_user.ErrAccountLocked) {
+		t.Fatalf("login while locked: got %v", err)
+	}
+	if _, err := users.Authorize(ctx, fan.ID, domain_user.RoleCustomer); !errors.Is(err, domain_user.ErrAccountLocked) {
+		t.Fatalf("authorize while locked: got %v", err)
+	}
+	if _, _, err := admin.LockUser(ctx, boss.ID, fan.ID, UserAdminActionRequest{Reason: "again"}); !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("lock twice: got %v, want conflict", err)
+	}
+	if _, err := admin.Impersonate(ctx, boss.ID, fan.ID, UserAdminActionRequest{Reason: "support"}); !errors.Is(err, domain_user.ErrAccountLocked) {
+		t.Fatalf("impersonate while locked: got %v", err)
+	}
+	if _, err := admin.UnlockUser(ctx, boss.ID, fan.ID, UserAdminActionRequest{Reason: "resolved"}); err != nil {
+		t.Fatalf("unlock: %v", err)
+	}
+	if _, err := auth.Login(ctx, login, client); err != nil {
+		t.Fatalf("login after unlock: %v", err)
+	}
+
+	// Impersonation tokens name the admin and have no session
+	token, err := admin.Impersonate(ctx, boss.ID, fan.ID, UserAdminActionRequest{Reason: "ticket #42"})
+	if err != nil {
+		t.Fatalf("impersonate: %v", err)
+	}
+	claims, err := auth.Authenticate(ctx, token.AccessToken)
+	if err != nil || claims.UserID != fan.ID || claims.ImpersonatorID == nil || *claims.ImpersonatorID != boss.ID || claims.SessionID != uuid.Nil {
+		t.Fatalf("impersonation claims: got %+v, %v", claims, err)
+	}
+	if _, err := admin.Impersonate(ctx, boss.ID, boss.ID, UserAdminActionRequest{Reason: "curious"}); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("impersonate an admin: got %v, want forbidden", err)
+	}
+	// Impersonation tokens stop working once their admin is no longer one
+	if _, err := users.SetRole(ctx, boss.ID, boss.ID, domain_user.RoleCustomer); err != nil {
+		t.Fatalf("demote admin: %v", err)
+	}
+	if _, err := auth.Authenticate(ctx, token.AccessToken); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("impersonation token of a former admin: got %v, want forbidden", err)
+	}
+
+	entries, err := repos.Audit.GetBySubject(ctx, fan.ID)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("audit entries: got %d, %v", len(entries), err)
+	}
+	if entries[0].ActorID != boss.ID || string(entries[0].Details) != `{"reason":"chargeback fraud"}` {
+		t.Fatalf("lock entry: got %+v", entries[0])
+	}
+}
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestProfilesAreChangedByTheirUserOrAnAdmin(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, &utils.Config{}, utils.NewLogger())
+	ctx := context.Background()
+
+	boss := &domain_user.User{ID: uuid.New(), Email: "boss@example.com", Name: "Boss", Role: domain_user.RoleAdmin, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	fan := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Name: "Fan", CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	for _, usr := range []*domain_user.User{boss, fan} {
+		if err := repos.User.Create(ctx, usr); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+	if err := users.SetPassword(ctx, fan.ID, "correct horse"); err != nil {
+		t.Fatalf("set password: %v", err)
+	}
+
+	// Nobody else may take over an account by moving its email
+	takeover := UpdateProfileRequest{Email: "thief@example.com", Name: "Fan"}
+	if _, _, err := users.UpdateProfile(ctx, uuid.New(), boss.ID, takeover); !errors.Is(err, domain.ErrUnauthorized) {
+		t.Fatalf("update by an unknown user: got %v, want unauthorized", err)
+	}
+	if _, _, err := users.UpdateProfile(ctx, fan.ID, boss.ID, takeover); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("update by another customer: got %v, want forbidden", err)
+	}
+	if err := users.DeleteUser(ctx, fan.ID, boss.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("delete by another customer: got %v, want forbidden", err)
+	}
+
+	// Users rename themselves freely but confirm a new email with their password
+	renamed, changed, err := users.UpdateProfile(ctx, fan.ID, fan.ID, UpdateProfileRequest{Email: fan.Email, Name: "Super Fan"})
+	if err != nil || changed || renamed.Name != "Super Fan" {
+		t.Fatalf("rename: got %+v, %v, %v", renamed, changed, err)
+	}
+	moved := UpdateProfileRequest{Email: "new@example.com", Name: "Super Fan", CurrentPassword: "wrong horse"}
+	if _, _, err := users.UpdateProfile(ctx, fan.ID, fan.ID, moved); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("email change with the wrong password: got %v, want forbidden", err)
+	}
+	moved.CurrentPassword = "correct horse"
+	updated, changed, err := users.UpdateProfile(ctx, fan.ID, fan.ID, moved)
+	if err != nil || !changed || updated.Email != "new@example.com" || updated.EmailVerifiedAt != nil {
+		t.Fatalf("email change: got %+v, %v, %v", updated, changed, err)
+	}
+
+	// Admins manage any account
+	if _, _, err := users.UpdateProfile(ctx, boss.ID, fan.ID, UpdateProfileRequest{Email: "fan@example.com", Name: "Fan"}); err != nil {
+		t.Fatalf("update by an admin: %v", err)
+	}
+	if err := users.DeleteUser(ctx, boss.ID, fan.ID); err != nil {
+		t.Fatalf("delete by an admin: %v", err)
+	}
+}
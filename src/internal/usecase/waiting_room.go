@@ -0,0 +1,200 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+
+	"github.com/google/uuid"
+)
+
+// Waiting room errors
+var (
+	ErrWaitingRoomDisabled = fmt.Errorf("%w: event has no waiting room", domain.ErrInvalidInput)
+	ErrNotYourTurn         = fmt.Errorf("%w: waiting room turn has not come yet", domain.ErrForbidden)
+)
+
+type WaitingRoomUsecase struct {
+	eventRepo repository.EventRepository
+	roomRepo  repository.WaitingRoomRepository
+	sla       *concurrency.SLATracker
+	overload  *concurrency.OverloadPolicy
+	logger    *utils.Logger
+
+	admitBatch    int64
+	admitInterval time.Duration
+}
+
+// NewWaitingRoomUsecase creates a new waiting room usecase
+func NewWaitingRoomUsecase(eventRepo repository.EventRepository, roomRepo repository.WaitingRoomRepository, sla *concurrency.SLATracker, overload *concurrency.OverloadPolicy, config *utils.Config, logger *utils.Logger) *WaitingRoomUsecase {
+	return &WaitingRoomUsecase{
+		eventRepo:     eventRepo,
+		roomRepo:      roomRepo,
+		sla:           sla,
+		overload:      overload,
+		logger:        logger,
+		admitBatch:    int64(config.WaitingRoomAdmitBatch),
+		admitInterval: time.Duration(config.WaitingRoomAdmitIntervalSeconds) * time.Second,
+	}
+}
+
+// WaitingRoomStatus reports a user's place in an event's waiting room
+type WaitingRoomStatus struct {
+	EventID              uuid.UUID `json:"event_id"`
+	Token                string    `json:"token"`
+	Position             int64     `json:"position"` // 0 once admitted
+	QueueSize            int64     `json:"queue_size"`
+	Admitted             bool      `json:"admitted"`
+	EstimatedWaitSeconds int64     `json:"estimated_wait_seconds"`
+}
+
+// Join places a user in the event's waiting room, keeping their place on repeat joins.
+// Events without a waiting room still accept joins while bookings are being shed
+// or turned away by the overload policy.
+func (w *WaitingRoomUsecase) Join(ctx context.Context, eventID, userID uuid.UUID) (*WaitingRoomStatus, error) {
+	event, err := w.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.WaitingRoomEnabled && !w.sla.Breached() && !w.overload.Rejecting() {
+		return nil, ErrWaitingRoomDisabled
+	}
+	if event.Status != domain_event.EventStatusPublished || event.SalesStateAt(time.Now()) == domain_event.SalesStateEnded {
+		return nil, fmt.Errorf("%w: %s", ErrEventNotBookable, event.NotBookableReason())
+	}
+
+	token, err := w.roomRepo.Join(ctx, eventID, userID, uuid.New().String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to join waiting room: %w", err)
+	}
+
+	return w.GetStatus(ctx, eventID, token)
+}
+
+// GetStatus reports the position and estimated wait for a waiting room token
+func (w *WaitingRoomUsecase) GetStatus(ctx context.Context, eventID uuid.UUID, token string) (*WaitingRoomStatus, error) {
+	rank, err := w.roomRepo.Position(ctx, eventID, token)
+	if err != nil {
+		return nil, err
+	}
+	size, err := w.roomRepo.Size(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	admitted, err := w.roomRepo.Admitted(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &WaitingRoomStatus{
+		EventID:   eventID,
+		Token:     token,
+		QueueSize: size,
+		Admitted:  rank < admitted,
+	}
+	if !status.Admitted {
+		// Positions are 1-based for the people still waiting
+		status.Position = rank - admitted + 1
+		if w.admitBatch > 0 {
+			ticks := (status.Position + w.admitBatch - 1) / w.admitBatch
+			status.EstimatedWaitSeconds = ticks * int64(w.admitInterval.Seconds())
+		}
+	}
+
+	return status, nil
+}
+
+// CheckAdmitted verifies that a user holding the token may book now
+func (w *WaitingRoomUsecase) CheckAdmitted(ctx context.Context, eventID, userID uuid.UUID, token string) error {
+	if token == "" {
+		return fmt.Errorf("%w: waiting room token required", ErrNotYourTurn)
+	}
+
+	owner, err := w.roomRepo.GetUserID(ctx, eventID, token)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			return fmt.Errorf("%w: unknown waiting room token", ErrNotYourTurn)
+		}
+		return err
+	}
+	if owner != userID {
+		return fmt.Errorf("%w: token belongs to another user", ErrNotYourTurn)
+	}
+
+	status, err := w.GetStatus(ctx, eventID, token)
+	if err != nil {
+		return err
+	}
+	if !status.Admitted {
+		return fmt.Errorf("%w: position %d", ErrNotYourTurn, status.Position)
+	}
+	return nil
+}
+
+// Run admits the next batch from every active waiting room on each tick
+func (w *WaitingRoomUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.admitInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.admitNext(ctx)
+		}
+	}
+}
+
+// admitNext advances each open room while its event is on sale
+func (w *WaitingRoomUsecase) admitNext(ctx context.Context) {
+	eventIDs, err := w.roomRepo.ActiveEvents(ctx)
+	if err != nil {
+		w.logger.Error("Failed to list waiting rooms", "error", err)
+		return
+	}
+
+	now := time.Now()
+	for _, eventID := range eventIDs {
+		event, err := w.eventRepo.GetByID(ctx, eventID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			w.logger.Warn("Failed to load waiting room event", "event_id", eventID, "error", err)
+			continue
+		}
+
+		// Tear down rooms whose on-sale is over
+		if event == nil || event.Status != domain_event.EventStatusPublished || event.SalesStateAt(now) == domain_event.SalesStateEnded {
+			if err := w.roomRepo.Close(ctx, eventID); err != nil {
+				w.logger.Warn("Failed to close waiting room", "event_id", eventID, "error", err)
+			}
+			continue
+		}
+
+		// Hold everyone until sales actually open
+		if event.SalesStateAt(now) != domain_event.SalesStateOnSale {
+			continue
+		}
+
+		size, err := w.roomRepo.Size(ctx, eventID)
+		if err != nil {
+			continue
+		}
+		admitted, err := w.roomRepo.Admitted(ctx, eventID)
+		if err != nil || admitted >= size {
+			continue
+		}
+
+		// Count only those waiting, so people joining later queue behind
+		// the batch instead of being admitted on arrival
+		if _, err := w.roomRepo.Admit(ctx, eventID, min(w.admitBatch, size-admitted)); err != nil {
+			w.logger.Warn("Failed to admit from waiting room", "event_id", eventID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/health"
+
+	"github.com/google/uuid"
+)
+
+// Webhook delivery tuning
+const (
+	webhookClaimBatch    = 50
+	webhookBaseBackoff   = 10 * time.Second
+	webhookMaxBackoff    = time.Hour
+	webhookDeliveryLimit = 100
+)
+
+// errWebhookTargetNotPublic refuses a delivery to an address inside the
+// network the service runs in
+var errWebhookTargetNotPublic = errors.New("webhook target is not a public address")
+
+// WebhookUsecase manages subscriptions, for admins, and delivers every
+// booking event on the platform to them
+type WebhookUsecase struct {
+	webhookRepo repository.WebhookRepository
+	users       *UserUsecase
+	client      *http.Client
+	logger      *utils.Logger
+
+	maxAttempts  int
+	pollInterval time.Duration
+}
+
+// NewWebhookUsecase creates a new webhook usecase
+func NewWebhookUsecase(webhookRepo repository.WebhookRepository, users *UserUsecase, config *utils.Config, logger *utils.Logger) *WebhookUsecase {
+	// Checked again as each delivery connects, since a name can resolve
+	// elsewhere after the subscription was made
+	dialer := &net.Dialer{Control: func(network, address string, c syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+			return fmt.Errorf("%w: %s", errWebhookTargetNotPublic, host)
+		}
+		return nil
+	}}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &WebhookUsecase{
+		webhookRepo:  webhookRepo,
+		users:        users,
+		client:       &http.Client{Timeout: time.Duration(config.WebhookTimeoutSeconds) * time.Second, Transport: transport},
+		logger:       logger,
+		maxAttempts:  config.WebhookMaxAttempts,
+		pollInterval: time.Duration(config.WebhookPollIntervalSeconds) * time.Second,
+	}
+}
+
+// CreateSubscription registers a callback URL for the given event types
+func (w *WebhookUsecase) CreateSubscription(ctx context.Context, adminID uuid.UUID, req domain_webhook.CreateSubscriptionRequest) (*domain_webhook.CreateSubscriptionResponse, error) {
+	if _, err := w.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Hostname() == "" {
+		return nil, fmt.Errorf("%w: url must be an absolute http(s) URL", domain.ErrInvalidInput)
+	}
+	host := strings.ToLower(target.Hostname())
+	if ip := net.ParseIP(host); (ip != nil && !isPublicIP(ip)) || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return nil, fmt.Errorf("%w: url must not point at a private, loopback or link-local address", domain.ErrInvalidInput)
+	}
+
+	eventTypes := req.EventTypes
+	if len(eventTypes) == 0 {
+		eventTypes = domain_webhook.EventTypes
+	}
+	for _, t := range eventTypes {
+		if !domain_webhook.IsValidEventType(t) {
+			return nil, fmt.Errorf("%w: unknown event type %q", domain.ErrInvalidInput, t)
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		buf := make([]byte, 32)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+		secret = hex.EncodeToString(buf)
+	}
+
+	now := time.Now()
+	sub := &domain_webhook.Subscription{
+		ID:         uuid.New(),
+		URL:        req.URL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := w.webhookRepo.CreateSubscription(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	w.logger.Info("Webhook subscription created", "subscription_id", sub.ID, "url", sub.URL)
+
+	return &domain_webhook.CreateSubscriptionResponse{Subscription: sub, Secret: secret}, nil
+}
+
+// ListSubscriptions returns every registered subscription, for admins
+func (w *WebhookUsecase) ListSubscriptions(ctx context.Context, adminID uuid.UUID) ([]*domain_webhook.Subscription, error) {
+	if _, err := w.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	return w.webhookRepo.ListSubscriptions(ctx)
+}
+
+// DeleteSubscription removes a subscription and its delivery log, for admins
+func (w *WebhookUsecase) DeleteSubscription(ctx context.Context, adminID, id uuid.UUID) error {
+	if _, err := w.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return err
+	}
+	return w.webhookRepo.DeleteSubscription(ctx, id)
+}
+
+// ListDeliveries returns the most recent deliveries for a subscription, for
+// admins
+func (w *WebhookUsecase) ListDeliveries(ctx context.Context, adminID, subscriptionID uuid.UUID) ([]*domain_webhook.Delivery, error) {
+	if _, err := w.users.Authorize(ctx, adminID, domain_user.RoleAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := w.webhookRepo.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, err
+	}
+	return w.webhookRepo.ListDeliveries(ctx, subscriptionID, webhookDeliveryLimit)
+}
+
+// Publish queues an event for every subscription that wants it. Failures are
+// logged rather than returned so webhooks never block the booking flow.
+func (w *WebhookUsecase) Publish(ctx context.Context, eventType string, data interface{}) {
+	subs, err := w.webhookRepo.ListSubscriptions(ctx)
+	if err != nil {
+		w.logger.Error("Failed to load webhook subscriptions", "event_type", eventType, "error", err)
+		return
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(domain_webhook.Payload{
+		ID:        uuid.New(),
+		Type:      eventType,
+		CreatedAt: now.UTC(),
+		Data:      data,
+	})
+	if err != nil {
+		w.logger.Error("Failed to encode webhook payload", "event_type", eventType, "error", err)
+		return
+	}
+
+	var deliveries []*domain_webhook.Delivery
+	for _, sub := range subs {
+		if !sub.Active || !sub.Wants(eventType) {
+			continue
+		}
+		deliveries = append(deliveries, &domain_webhook.Delivery{
+			ID:             uuid.New(),
+			SubscriptionID: sub.ID,
+			EventType:      eventType,
+			Payload:        payload,
+			Status:         domain_webhook.DeliveryStatusPending,
+			NextAttemptAt:  now,
+			CreatedAt:      now,
+			UpdatedAt:      now,
+		})
+	}
+
+	if err := w.webhookRepo.CreateDeliveries(ctx, deliveries); err != nil {
+		w.logger.Error("Failed to queue webhook deliveries", "event_type", eventType, "error", err)
+	}
+}
+
+// Run sends due deliveries until the context is cancelled
+func (w *WebhookUsecase) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.dispatch(ctx)
+			health.Beat(ctx)
+		}
+	}
+}
+
+// dispatch claims a batch of due deliveries and attempts each once
+func (w *WebhookUsecase) dispatch(ctx context.Context) {
+	// Lease long enough that a slow attempt is not picked up again mid-flight
+	lease := w.client.Timeout + 30*time.Second
+	deliveries, err := w.webhookRepo.ClaimDueDeliveries(ctx, time.Now(), lease, webhookClaimBatch)
+	if err != nil {
+		w.logger.Error("Failed to claim webhook deliveries", "error", err)
+		return
+	}
+
+	subs := make(map[uuid.UUID]*domain_webhook.Subscription)
+	for _, delivery := range deliveries {
+		sub, ok := subs[delivery.SubscriptionID]
+		if !ok {
+			sub, err = w.webhookRepo.GetSubscription(ctx, delivery.SubscriptionID)
+			if err != nil {
+				w.logger.Warn("Failed to load webhook subscription", "subscription_id", delivery.SubscriptionID, "error", err)
+				continue
+			}
+			subs[delivery.SubscriptionID] = sub
+		}
+
+		w.attempt(ctx, sub, delivery)
+	}
+}
+
+// attempt posts a delivery and records the outcome, scheduling a retry on failure
+func (w *WebhookUsecase) attempt(ctx context.Context, sub *domain_webhook.Subscription, delivery *domain_webhook.Delivery) {
+	now := time.Now()
+	delivery.Attempts++
+	delivery.UpdatedAt = now
+
+	statusCode, err := w.send(ctx, sub, delivery)
+	if statusCode != 0 {
+		delivery.LastStatusCode = &statusCode
+	}
+
+	switch {
+	case err == nil:
+		delivery.Status = domain_webhook.DeliveryStatusSucceeded
+		delivery.LastError = nil
+	case !sub.Active || delivery.Attempts >= w.maxAttempts:
+		msg := err.Error()
+		delivery.Status = domain_webhook.DeliveryStatusFailed
+		delivery.LastError = &msg
+		w.logger.Warn("Webhook delivery failed permanently",
+			"delivery_id", delivery.ID,
+			"subscription_id", sub.ID,
+			"attempts", delivery.Attempts,
+			"error", err)
+	default:
+		msg := err.Error()
+		delivery.LastError = &msg
+		delivery.NextAttemptAt = now.Add(webhookBackoff(delivery.Attempts))
+	}
+
+	if err := w.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		w.logger.Error("Failed to record webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// send posts the signed payload; any 2xx response counts as delivered
+func (w *WebhookUsecase) send(ctx context.Context, sub *domain_webhook.Subscription, delivery *domain_webhook.Delivery) (int, error) {
+	if !sub.Active {
+		return 0, fmt.Errorf("subscription is inactive")
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-ID", delivery.ID.String())
+	req.Header.Set("X-Webhook-Event", delivery.EventType)
+	req.Header.Set("X-Webhook-Timestamp", timestamp)
+	req.Header.Set("X-Webhook-Signature", "sha256="+SignWebhookPayload(sub.Secret, timestamp, delivery.Payload))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("endpoint responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// isPublicIP reports whether ip is reachable on the internet rather than
+// only from inside the network the service runs in
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast()
+}
+
+// SignWebhookPayload computes the hex HMAC-SHA256 of "timestamp.body" that
+// receivers use to verify a delivery
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookBackoff doubles the retry delay after each failed attempt
+func webhookBackoff(attempts int) time.Duration {
+	backoff := webhookBaseBackoff
+	for i := 1; i < attempts && backoff < webhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > webhookMaxBackoff {
+		backoff = webhookMaxBackoff
+	}
+	return backoff
+}
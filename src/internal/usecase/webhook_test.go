@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	domain_webhook "github.com/ojaswiii/booking-manager/src/internal/domain/webhook"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+func TestWebhookSubscriptionsAreForAdminsAndPublicTargets(t *testing.T) {
+	repos := repository.NewMemoryRepositoryContainer(nil, repository.DefaultCacheTTLs())
+	logger := utils.NewLogger()
+	config := &utils.Config{WebhookTimeoutSeconds: 1}
+	users := NewUserUsecase(repos.User, repos.UserCache, repos.CacheInvalidation, config, logger)
+	webhooks := NewWebhookUsecase(repos.Webhook, users, config, logger)
+	ctx := context.Background()
+
+	admin := &domain_user.User{ID: uuid.New(), Email: "admin@example.com", Role: domain_user.RoleAdmin, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	customer := &domain_user.User{ID: uuid.New(), Email: "fan@example.com", Role: domain_user.RoleCustomer, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	for _, user := range []*domain_user.User{admin, customer} {
+		if err := repos.User.Create(ctx, user); err != nil {
+			t.Fatalf("create user: %v", err)
+		}
+	}
+
+	public := domain_webhook.CreateSubscriptionRequest{URL: "https://hooks.example.com/bookings"}
+	if _, err := webhooks.CreateSubscription(ctx, customer.ID, public); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("subscribed by a customer: got %v, want forbidden", err)
+	}
+	if _, err := webhooks.ListSubscriptions(ctx, customer.ID); !errors.Is(err, domain.ErrForbidden) {
+		t.Fatalf("listed by a customer: got %v, want forbidden", err)
+	}
+
+	for _, target := range []string{
+		"http://127.0.0.1:8080/hooks",
+		"http://localhost/hooks",
+		"http://10.0.0.5/hooks",
+		"http://192.168.1.1/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hooks",
+		"http://[fe80::1]/hooks",
+		"http://0.0.0.0/hooks",
+	} {
+		req := domain_webhook.CreateSubscriptionRequest{URL: target}
+		if _, err := webhooks.CreateSubscription(ctx, admin.ID, req); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Errorf("%s: got %v, want invalid input", target, err)
+		}
+	}
+
+	created, err := webhooks.CreateSubscription(ctx, admin.ID, public)
+	if err != nil {
+		t.Fatalf("create subscription: %v", err)
+	}
+	subs, err := webhooks.ListSubscriptions(ctx, admin.ID)
+	if err != nil || len(subs) != 1 || subs[0].ID != created.Subscription.ID {
+		t.Fatalf("list subscriptions: got %v, %v", subs, err)
+	}
+}
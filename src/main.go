@@ -1,133 +1,95 @@
 package main
 
 import (
-	"context"
-	"net/http"
+	"errors"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
-	"time"
 
-	"github.com/ojaswiii/booking-manager/src/delivery/rest"
-	"github.com/ojaswiii/booking-manager/src/internal/repository"
-	"github.com/ojaswiii/booking-manager/src/internal/usecase"
 	"github.com/ojaswiii/booking-manager/src/utils"
-	"github.com/ojaswiii/booking-manager/src/utils/database"
 )
 
-func main() {
-	// Load configuration
-	config := utils.LoadConfig()
+const usage = `Usage: booking-manager [command] [flags]
+
+Commands:
+  serve    Run the API server (default)
+  worker   Run the booking processor and scheduled jobs without the API
+  migrate  Apply or revert schema migrations
+  seed     Load demo data
 
+Configuration comes from the environment and CONFIG_FILE; flags override it.
+Run "booking-manager <command> -h" for a command's flags.`
+
+func main() {
 	// Initialize logger
 	logger := utils.NewLogger()
-	logger.Info("Starting booking system with integrated concurrency", "environment", config.Environment)
 
-	// Initialize database connections
-	postgresClient, err := database.NewPostgresClient(config)
-	if err != nil {
-		logger.Error("Failed to connect to PostgreSQL", "error", err)
-		os.Exit(1)
+	command, args := "serve", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
 	}
-	defer postgresClient.Close()
 
-	redisClient, err := database.NewRedisClient(config)
-	if err != nil {
-		logger.Error("Failed to connect to Redis", "error", err)
-		os.Exit(1)
+	run := map[string]func(args []string, config *utils.Config, logger *utils.Logger) int{
+		"serve":   runServe,
+		"worker":  runWorker,
+		"migrate": runMigrate,
+		"seed":    runSeed,
+	}[command]
+	if run == nil {
+		fmt.Fprintln(os.Stderr, usage)
+		if command == "help" {
+			os.Exit(0)
+		}
+		os.Exit(2)
 	}
-	defer redisClient.Close()
-
-	// Initialize repositories
-	repos := repository.NewRepositoryContainer(postgresClient.DB, redisClient.Client)
-	logger.Info("Repositories initialized")
 
-	// Initialize usecases
-	userUsecase := usecase.NewUserUsecase(repos.User, repos.UserCache, logger)
-	eventUsecase := usecase.NewEventUsecase(repos.Event, repos.EventCache, repos.Ticket, logger)
-	bookingUsecase := usecase.NewBookingUsecase(repos.Booking, repos.Ticket, repos.Event, repos.User, logger)
-	defer bookingUsecase.Shutdown()
-
-	// Create usecase container
-	usecases := &usecase.UsecaseContainer{
-		User:    userUsecase,
-		Event:   eventUsecase,
-		Booking: bookingUsecase,
+	// Load configuration
+	config, err := utils.LoadConfig()
+	if err != nil {
+		logger.Error("Invalid configuration", "error", err)
+		os.Exit(1)
 	}
 
-	logger.Info("Usecases initialized with integrated concurrency")
-
-	// Initialize REST delivery
-	restContainer := rest.NewRestContainer(usecases, logger)
-	router := restContainer.Router.SetupRoutes()
-	logger.Info("REST delivery initialized")
+	os.Exit(run(args, config, logger))
+}
 
-	// Create server
-	server := &http.Server{
-		Addr:         config.ServerHost + ":" + config.ServerPort,
-		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+// newFlagSet creates the flag set of a command
+func newFlagSet(command, synopsis string) *flag.FlagSet {
+	flags := flag.NewFlagSet(command, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: booking-manager %s %s\n", command, synopsis)
+		flags.PrintDefaults()
 	}
+	return flags
+}
 
-	// Create context for graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Start server in a goroutine
-	go func() {
-		logger.Info("Starting server with integrated concurrency",
-			"host", config.ServerHost,
-			"port", config.ServerPort,
-			"features", []string{
-				"integrated_concurrency",
-				"ticket_locks_with_expiration",
-				"load_balanced_queues",
-				"race_condition_handling",
-				"automatic_cleanup",
-			})
-
-		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			logger.Error("Server failed to start", "error", err)
-			os.Exit(1)
-		}
-	}()
-
-	// Start metrics reporting goroutine
-	go func() {
-		ticker := time.NewTicker(30 * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case <-ticker.C:
-				stats := bookingUsecase.GetConcurrencyStats()
-				logger.Info("Booking concurrency metrics", "stats", stats)
-			}
+// parseFlags parses a command's flags over the loaded configuration and
+// validates the result. When it returns false the command should exit
+// with the returned code.
+func parseFlags(flags *flag.FlagSet, args []string, config *utils.Config, logger *utils.Logger) (int, bool) {
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0, false
 		}
-	}()
+		return 2, false
+	}
+	if flags.NArg() > 0 {
+		flags.Usage()
+		return 2, false
+	}
+	if err := config.Validate(); err != nil {
+		logger.Error("Invalid configuration", "error", err)
+		return 1, false
+	}
+	return 0, true
+}
 
-	// Wait for interrupt signal to gracefully shutdown the server
+// waitForSignal returns a channel that receives SIGINT or SIGTERM
+func waitForSignal() <-chan os.Signal {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
-
-	logger.Info("Shutting down server...")
-
-	// Cancel context to stop background services
-	cancel()
-
-	// Give outstanding requests 30 seconds to complete
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
-
-	if err := server.Shutdown(shutdownCtx); err != nil {
-		logger.Error("Server forced to shutdown", "error", err)
-		os.Exit(1)
-	}
-
-	logger.Info("Server exited gracefully")
+	return quit
 }
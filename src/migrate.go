@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/migrations"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const migrateUsage = `Usage: booking-manager migrate [command]
+  up                 Apply every pending migration (default)
+  down [n|all]       Revert the last n applied migrations (default 1)
+  status             List migrations and when each was applied
+  baseline <version> Record migrations up to version as applied without running them
+  tenant <id>        Create schema tenant_<id> and apply every pending migration in it`
+
+// runMigrate runs the migrate subcommand and returns the process exit code
+func runMigrate(args []string, config *utils.Config, logger *utils.Logger) int {
+	command := "up"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	var run func(ctx context.Context, db *sqlx.DB, migrator *database.Migrator) error
+	switch {
+	case command == "-h" || command == "help":
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 0
+	case command == "up" && len(args) == 0:
+		run = func(ctx context.Context, db *sqlx.DB, migrator *database.Migrator) error {
+			return migrateAll(ctx, db, config, logger)
+		}
+	case command == "down" && len(args) <= 1:
+		steps := 1
+		if len(args) == 1 && args[0] == "all" {
+			steps = math.MaxInt
+		} else if len(args) == 1 {
+			var err error
+			if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+				fmt.Fprintln(os.Stderr, migrateUsage)
+				return 2
+			}
+		}
+		run = func(ctx context.Context, db *sqlx.DB, migrator *database.Migrator) error {
+			_, err := migrator.Down(ctx, steps)
+			return err
+		}
+	case command == "status" && len(args) == 0:
+		run = func(ctx context.Context, db *sqlx.DB, migrator *database.Migrator) error {
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+			for _, status := range statuses {
+				applied := "pending"
+				if status.AppliedAt != nil {
+					applied = "applied " + status.AppliedAt.Format("2006-01-02 15:04:05 MST")
+				}
+				fmt.Printf("%-45s %s\n", status.Version, applied)
+			}
+			return nil
+		}
+	case command == "baseline" && len(args) == 1:
+		run = func(ctx context.Context, db *sqlx.DB, migrator *database.Migrator) error {
+			return migrator.Baseline(ctx, args[0])
+		}
+	case command == "tenant" && len(args) == 1 && tenant.Validate(args[0]) == nil:
+		run = func(ctx context.Context, db *sqlx.DB, migrator *database.Migrator) error {
+			_, err := migrator.InSchema(tenant.SchemaName(args[0])).Up(ctx)
+			return err
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	if config.StorageBackend != "database" {
+		logger.Error("migrate needs STORAGE_BACKEND=database", "storage_backend", config.StorageBackend)
+		return 1
+	}
+
+	db, _, err := connectDatabase(config, logger)
+	if err != nil {
+		logger.Error("Failed to connect to the database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db, migrations.ForDriver(config.DBDriver), logger)
+	if err != nil {
+		logger.Error("Failed to load migrations", "error", err)
+		return 1
+	}
+
+	if err := run(context.Background(), db, migrator); err != nil {
+		logger.Error("Migration failed", "command", command, "error", err)
+		return 1
+	}
+	return 0
+}
+
+// migrateAll applies pending migrations to the shared schema and, with
+// schema isolation, to the schema of every configured tenant
+func migrateAll(ctx context.Context, db *sqlx.DB, config *utils.Config, logger *utils.Logger) error {
+	migrator, err := database.NewMigrator(db, migrations.ForDriver(config.DBDriver), logger)
+	if err != nil {
+		return err
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	if repository.TenantIsolation(config.TenantIsolation) == repository.TenantIsolationSchema {
+		for _, id := range config.TenantIDs {
+			if err := tenant.Validate(id); err != nil {
+				return fmt.Errorf("tenant %q: %w", id, err)
+			}
+			n, err := migrator.InSchema(tenant.SchemaName(id)).Up(ctx)
+			if err != nil {
+				return fmt.Errorf("tenant %q: %w", id, err)
+			}
+			applied += n
+		}
+	}
+
+	logger.Info("Schema up to date", "applied", applied)
+	return nil
+}
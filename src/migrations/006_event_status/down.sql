@@ -0,0 +1,3 @@
+-- Rollback event lifecycle status
+DROP INDEX IF EXISTS idx_events_status;
+ALTER TABLE events DROP COLUMN IF EXISTS status;
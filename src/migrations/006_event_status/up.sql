@@ -0,0 +1,9 @@
+-- Add lifecycle status to events
+ALTER TABLE events ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft'
+    CHECK (status IN ('draft', 'published', 'archived'));
+
+-- Existing events were already live before lifecycle states existed
+UPDATE events SET status = 'published';
+
+-- Create index for customer-facing listings
+CREATE INDEX IF NOT EXISTS idx_events_status ON events(status);
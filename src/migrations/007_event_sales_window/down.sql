@@ -0,0 +1,6 @@
+-- Rollback event sales window
+DROP INDEX IF EXISTS idx_events_sales_start_at;
+DROP INDEX IF EXISTS idx_events_sales_end_at;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_sales_window_check;
+ALTER TABLE events DROP COLUMN IF EXISTS sales_end_at;
+ALTER TABLE events DROP COLUMN IF EXISTS sales_start_at;
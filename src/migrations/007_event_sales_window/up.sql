@@ -0,0 +1,10 @@
+-- Add scheduled on-sale window to events
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sales_start_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS sales_end_at TIMESTAMP WITH TIME ZONE;
+
+ALTER TABLE events ADD CONSTRAINT events_sales_window_check
+    CHECK (sales_start_at IS NULL OR sales_end_at IS NULL OR sales_start_at < sales_end_at);
+
+-- Create indexes for the on-sale scheduler
+CREATE INDEX IF NOT EXISTS idx_events_sales_start_at ON events(sales_start_at);
+CREATE INDEX IF NOT EXISTS idx_events_sales_end_at ON events(sales_end_at);
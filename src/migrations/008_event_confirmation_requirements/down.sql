@@ -0,0 +1,2 @@
+-- Rollback event confirmation requirements
+ALTER TABLE events DROP COLUMN IF EXISTS confirmation_requirements;
@@ -0,0 +1,2 @@
+-- Add organizer-configured confirmation gates to events
+ALTER TABLE events ADD COLUMN IF NOT EXISTS confirmation_requirements JSONB NOT NULL DEFAULT '[]'::jsonb;
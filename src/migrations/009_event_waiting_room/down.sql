@@ -0,0 +1,2 @@
+-- Rollback event waiting room flag
+ALTER TABLE events DROP COLUMN IF EXISTS waiting_room_enabled;
@@ -0,0 +1,2 @@
+-- Add opt-in virtual waiting room to events
+ALTER TABLE events ADD COLUMN IF NOT EXISTS waiting_room_enabled BOOLEAN NOT NULL DEFAULT FALSE;
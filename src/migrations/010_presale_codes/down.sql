@@ -0,0 +1,5 @@
+-- Rollback presale codes
+DROP INDEX IF EXISTS idx_presale_codes_event_redeemed_by;
+DROP INDEX IF EXISTS idx_presale_codes_batch_id;
+DROP TABLE IF EXISTS presale_codes;
+ALTER TABLE events DROP COLUMN IF EXISTS presale_start_at;
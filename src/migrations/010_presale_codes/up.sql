@@ -0,0 +1,18 @@
+-- Add presale window to events
+ALTER TABLE events ADD COLUMN IF NOT EXISTS presale_start_at TIMESTAMP WITH TIME ZONE;
+
+-- Create presale codes table
+CREATE TABLE IF NOT EXISTS presale_codes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    batch_id UUID NOT NULL,
+    code VARCHAR(32) NOT NULL,
+    redeemed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    redeemed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE(event_id, code)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_presale_codes_batch_id ON presale_codes(batch_id);
+CREATE INDEX IF NOT EXISTS idx_presale_codes_event_redeemed_by ON presale_codes(event_id, redeemed_by);
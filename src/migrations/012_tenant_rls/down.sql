@@ -0,0 +1,19 @@
+-- Rollback row-level security
+DROP INDEX IF EXISTS idx_users_tenant_email;
+ALTER TABLE users ADD CONSTRAINT users_email_key UNIQUE (email);
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['users', 'events', 'tickets', 'bookings', 'presale_codes', 'webhook_subscriptions', 'webhook_deliveries']
+    LOOP
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('ALTER TABLE %I NO FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I DISABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP INDEX IF EXISTS %I', 'idx_' || t || '_tenant_id');
+        EXECUTE format('ALTER TABLE %I DROP COLUMN IF EXISTS tenant_id', t);
+    END LOOP;
+END $$;
+
+DROP FUNCTION IF EXISTS current_tenant_id();
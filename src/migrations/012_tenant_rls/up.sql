@@ -0,0 +1,28 @@
+-- Row-level security for TENANT_ISOLATION=rls
+-- Every row is tagged with the tenant set by the application through the
+-- transaction-local app.tenant_id setting. Untenanted deployments never set
+-- it, so all rows carry the empty tenant and remain visible.
+
+CREATE OR REPLACE FUNCTION current_tenant_id()
+RETURNS VARCHAR AS $$
+    SELECT COALESCE(current_setting('app.tenant_id', true), '')
+$$ LANGUAGE sql STABLE;
+
+DO $$
+DECLARE
+    t TEXT;
+BEGIN
+    FOREACH t IN ARRAY ARRAY['users', 'events', 'tickets', 'bookings', 'presale_codes', 'webhook_subscriptions', 'webhook_deliveries']
+    LOOP
+        EXECUTE format('ALTER TABLE %I ADD COLUMN IF NOT EXISTS tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()', t);
+        EXECUTE format('CREATE INDEX IF NOT EXISTS %I ON %I(tenant_id)', 'idx_' || t || '_tenant_id', t);
+        EXECUTE format('ALTER TABLE %I ENABLE ROW LEVEL SECURITY', t);
+        EXECUTE format('ALTER TABLE %I FORCE ROW LEVEL SECURITY', t);
+        EXECUTE format('DROP POLICY IF EXISTS tenant_isolation ON %I', t);
+        EXECUTE format('CREATE POLICY tenant_isolation ON %I USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id())', t);
+    END LOOP;
+END $$;
+
+-- Emails only need to be unique within a tenant
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_email_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_email ON users(tenant_id, email);
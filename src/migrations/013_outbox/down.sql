@@ -0,0 +1,6 @@
+-- Rollback transactional outbox
+DROP POLICY IF EXISTS tenant_isolation ON outbox_events;
+DROP INDEX IF EXISTS idx_outbox_events_tenant_id;
+DROP INDEX IF EXISTS idx_outbox_events_published_at;
+DROP INDEX IF EXISTS idx_outbox_events_due;
+DROP TABLE IF EXISTS outbox_events;
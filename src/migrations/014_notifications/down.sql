@@ -0,0 +1,6 @@
+-- Rollback notifications
+DROP INDEX IF EXISTS idx_bookings_pending_expires_at;
+DROP POLICY IF EXISTS tenant_isolation ON notifications;
+DROP INDEX IF EXISTS idx_notifications_tenant_id;
+DROP INDEX IF EXISTS idx_notifications_due;
+DROP TABLE IF EXISTS notifications;
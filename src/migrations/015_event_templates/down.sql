@@ -0,0 +1,4 @@
+-- Rollback event templates
+DROP POLICY IF EXISTS tenant_isolation ON event_templates;
+DROP INDEX IF EXISTS idx_event_templates_tenant_id;
+DROP TABLE IF EXISTS event_templates;
@@ -0,0 +1,30 @@
+-- Create event templates table
+-- Sales windows are stored as offsets (in seconds) before the event date.
+CREATE TABLE IF NOT EXISTS event_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    source_event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+    event_name VARCHAR(255) NOT NULL,
+    artist VARCHAR(255) NOT NULL,
+    venue VARCHAR(255) NOT NULL,
+    total_seats INTEGER NOT NULL CHECK (total_seats > 0),
+    price DECIMAL(10,2) NOT NULL CHECK (price > 0),
+    tiers JSONB NOT NULL DEFAULT '[]'::jsonb,
+    sales_start_offset_seconds BIGINT,
+    sales_end_offset_seconds BIGINT,
+    presale_start_offset_seconds BIGINT,
+    confirmation_requirements JSONB NOT NULL DEFAULT '[]'::jsonb,
+    waiting_room_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_event_templates_tenant_id ON event_templates(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE event_templates ENABLE ROW LEVEL SECURITY;
+ALTER TABLE event_templates FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON event_templates;
+CREATE POLICY tenant_isolation ON event_templates USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
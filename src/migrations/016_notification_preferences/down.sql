@@ -0,0 +1,4 @@
+-- Rollback notification preferences
+DROP POLICY IF EXISTS tenant_isolation ON notification_preferences;
+DROP INDEX IF EXISTS idx_notification_preferences_tenant_id;
+DROP TABLE IF EXISTS notification_preferences;
@@ -0,0 +1,19 @@
+-- Create notification preferences table
+-- channels maps each notification kind to {"email", "sms", "webhook"} flags;
+-- kinds missing from it use the defaults (email only).
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    channels JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_notification_preferences_tenant_id ON notification_preferences(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE notification_preferences ENABLE ROW LEVEL SECURITY;
+ALTER TABLE notification_preferences FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON notification_preferences;
+CREATE POLICY tenant_isolation ON notification_preferences USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
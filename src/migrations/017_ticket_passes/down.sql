@@ -0,0 +1,4 @@
+-- Rollback ticket passes
+DROP POLICY IF EXISTS tenant_isolation ON ticket_passes;
+DROP INDEX IF EXISTS idx_ticket_passes_tenant_id;
+DROP TABLE IF EXISTS ticket_passes;
@@ -0,0 +1,20 @@
+-- Create ticket passes table
+-- One signed, scannable token per ticket of a confirmed booking.
+CREATE TABLE IF NOT EXISTS ticket_passes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    issued_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    UNIQUE (booking_id, ticket_id)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_ticket_passes_tenant_id ON ticket_passes(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE ticket_passes ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_passes FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON ticket_passes;
+CREATE POLICY tenant_isolation ON ticket_passes USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
@@ -0,0 +1,2 @@
+-- Rollback ticket check-ins
+ALTER TABLE ticket_passes DROP COLUMN IF EXISTS checked_in_at;
@@ -0,0 +1,2 @@
+-- Record when each ticket pass was scanned at the door
+ALTER TABLE ticket_passes ADD COLUMN IF NOT EXISTS checked_in_at TIMESTAMP WITH TIME ZONE;
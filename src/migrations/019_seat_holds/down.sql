@@ -0,0 +1,11 @@
+-- Rollback seat holds; held seats go back on sale
+UPDATE tickets SET status = 'available' WHERE status = 'held';
+DROP INDEX IF EXISTS idx_tickets_hold_id;
+ALTER TABLE tickets DROP COLUMN IF EXISTS hold_id;
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_status_check;
+ALTER TABLE tickets ADD CONSTRAINT tickets_status_check CHECK (status IN ('available', 'reserved', 'sold', 'cancelled'));
+
+DROP POLICY IF EXISTS tenant_isolation ON seat_holds;
+DROP INDEX IF EXISTS idx_seat_holds_tenant_id;
+DROP INDEX IF EXISTS idx_seat_holds_event_id;
+DROP TABLE IF EXISTS seat_holds;
@@ -0,0 +1,32 @@
+-- Create seat holds table
+-- A hold takes a range of seats off public sale for sponsors, artists or press.
+CREATE TABLE IF NOT EXISTS seat_holds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('sponsor', 'artist', 'press')),
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    from_seat INTEGER NOT NULL,
+    to_seat INTEGER NOT NULL,
+    seats INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'released')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    released_at TIMESTAMP WITH TIME ZONE,
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    CHECK (from_seat <= to_seat)
+);
+
+-- Held tickets get their own status and point at their hold
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_status_check;
+ALTER TABLE tickets ADD CONSTRAINT tickets_status_check CHECK (status IN ('available', 'reserved', 'sold', 'cancelled', 'held'));
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS hold_id UUID REFERENCES seat_holds(id) ON DELETE SET NULL;
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_seat_holds_event_id ON seat_holds(event_id);
+CREATE INDEX IF NOT EXISTS idx_seat_holds_tenant_id ON seat_holds(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_tickets_hold_id ON tickets(hold_id) WHERE hold_id IS NOT NULL;
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE seat_holds ENABLE ROW LEVEL SECURITY;
+ALTER TABLE seat_holds FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON seat_holds;
+CREATE POLICY tenant_isolation ON seat_holds USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
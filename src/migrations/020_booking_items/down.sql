@@ -0,0 +1,7 @@
+-- Rollback booking items
+DROP POLICY IF EXISTS tenant_isolation ON booking_items;
+DROP INDEX IF EXISTS idx_booking_items_tenant_id;
+DROP TABLE IF EXISTS booking_items;
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS exchange_rate;
+ALTER TABLE bookings DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,27 @@
+-- Record the currency a booking is charged in
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS exchange_rate DECIMAL(18,8);
+
+-- Create booking items table
+-- The price of each ticket, locked at reservation.
+CREATE TABLE IF NOT EXISTS booking_items (
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    seat_number INTEGER NOT NULL,
+    base_price DECIMAL(10,2) NOT NULL,
+    unit_price DECIMAL(10,2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    exchange_rate DECIMAL(18,8),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    PRIMARY KEY (booking_id, ticket_id)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_booking_items_tenant_id ON booking_items(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE booking_items ENABLE ROW LEVEL SECURITY;
+ALTER TABLE booking_items FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON booking_items;
+CREATE POLICY tenant_isolation ON booking_items USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
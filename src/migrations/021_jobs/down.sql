@@ -0,0 +1,5 @@
+-- Rollback jobs
+DROP POLICY IF EXISTS tenant_isolation ON jobs;
+DROP INDEX IF EXISTS idx_jobs_tenant_id;
+DROP INDEX IF EXISTS idx_jobs_due;
+DROP TABLE IF EXISTS jobs;
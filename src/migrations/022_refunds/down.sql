@@ -0,0 +1,12 @@
+-- Rollback refunds
+DROP POLICY IF EXISTS tenant_isolation ON refund_policies;
+DROP POLICY IF EXISTS tenant_isolation ON refund_items;
+DROP POLICY IF EXISTS tenant_isolation ON refunds;
+DROP TABLE IF EXISTS refund_policies;
+DROP TABLE IF EXISTS refund_items;
+DROP TABLE IF EXISTS refunds;
+
+UPDATE bookings SET status = 'cancelled' WHERE status = 'refunded';
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired'));
+ALTER TABLE bookings DROP COLUMN IF EXISTS payment_reference;
@@ -0,0 +1,62 @@
+-- Bookings may be refunded in full, and record the payment to refund against
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired', 'refunded'));
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS payment_reference VARCHAR(255);
+
+-- Create refunds table
+CREATE TABLE IF NOT EXISTS refunds (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    amount DECIMAL(10,2) NOT NULL CHECK (amount > 0),
+    currency CHAR(3) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    provider_reference VARCHAR(255),
+    error TEXT,
+    requested_by VARCHAR(20) NOT NULL CHECK (requested_by IN ('owner', 'admin')),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- Tickets returned by each refund
+CREATE TABLE IF NOT EXISTS refund_items (
+    refund_id UUID NOT NULL REFERENCES refunds(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    amount DECIMAL(10,2) NOT NULL,
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    PRIMARY KEY (refund_id, ticket_id)
+);
+
+-- Per-event refund policy; events without one use the configured defaults
+CREATE TABLE IF NOT EXISTS refund_policies (
+    event_id UUID PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    refundable BOOLEAN NOT NULL DEFAULT TRUE,
+    deadline_hours INTEGER NOT NULL DEFAULT 0,
+    percent DECIMAL(5,2) NOT NULL DEFAULT 100 CHECK (percent BETWEEN 0 AND 100),
+    allow_partial BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_refunds_booking_id ON refunds(booking_id);
+CREATE INDEX IF NOT EXISTS idx_refunds_tenant_id ON refunds(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_refund_items_tenant_id ON refund_items(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_refund_policies_tenant_id ON refund_policies(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE refunds ENABLE ROW LEVEL SECURITY;
+ALTER TABLE refunds FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON refunds;
+CREATE POLICY tenant_isolation ON refunds USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE refund_items ENABLE ROW LEVEL SECURITY;
+ALTER TABLE refund_items FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON refund_items;
+CREATE POLICY tenant_isolation ON refund_items USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE refund_policies ENABLE ROW LEVEL SECURITY;
+ALTER TABLE refund_policies FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON refund_policies;
+CREATE POLICY tenant_isolation ON refund_policies USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
@@ -0,0 +1,8 @@
+-- Rollback payment review
+DROP INDEX IF EXISTS idx_bookings_review_expires_at;
+
+UPDATE bookings SET status = 'pending' WHERE status = 'review';
+UPDATE bookings SET status = 'cancelled' WHERE status = 'rejected';
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired', 'refunded'));
+ALTER TABLE bookings DROP COLUMN IF EXISTS risk_score;
@@ -0,0 +1,7 @@
+-- Bookings whose payment the provider scored as high-risk wait for review
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired', 'refunded', 'review', 'rejected'));
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS risk_score INT CHECK (risk_score BETWEEN 0 AND 100);
+
+-- Reviews past their deadline are rejected by the expiry worker
+CREATE INDEX IF NOT EXISTS idx_bookings_review_expires_at ON bookings(expires_at) WHERE status = 'review';
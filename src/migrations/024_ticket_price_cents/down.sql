@@ -0,0 +1,6 @@
+-- Rollback ticket price cents, restoring prices only written in cents
+DROP INDEX IF EXISTS idx_tickets_price_cents_pending;
+
+UPDATE tickets SET price = price_cents / 100.0 WHERE price IS NULL;
+ALTER TABLE tickets ALTER COLUMN price SET NOT NULL;
+ALTER TABLE tickets DROP COLUMN IF EXISTS price_cents;
@@ -0,0 +1,10 @@
+-- Ticket prices move from DECIMAL to integer cents. The new column is
+-- filled by the application while COLUMN_MIGRATIONS moves
+-- ticket_price_cents through its phases, and by the backfill job.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS price_cents BIGINT CHECK (price_cents > 0);
+
+-- Once the migration reaches its "new" phase the old column is no longer written
+ALTER TABLE tickets ALTER COLUMN price DROP NOT NULL;
+
+-- The backfill job looks for rows it has not converted yet
+CREATE INDEX IF NOT EXISTS idx_tickets_price_cents_pending ON tickets(id) WHERE price_cents IS NULL;
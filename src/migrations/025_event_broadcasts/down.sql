@@ -0,0 +1,9 @@
+-- Rollback event broadcasts
+DROP POLICY IF EXISTS tenant_isolation ON event_broadcast_deliveries;
+DROP POLICY IF EXISTS tenant_isolation ON event_broadcasts;
+DROP INDEX IF EXISTS idx_event_broadcast_deliveries_tenant_id;
+DROP INDEX IF EXISTS idx_event_broadcast_deliveries_pending;
+DROP INDEX IF EXISTS idx_event_broadcasts_tenant_id;
+DROP INDEX IF EXISTS idx_event_broadcasts_event_id;
+DROP TABLE IF EXISTS event_broadcast_deliveries;
+DROP TABLE IF EXISTS event_broadcasts;
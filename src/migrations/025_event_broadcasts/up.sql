@@ -0,0 +1,40 @@
+-- Create event broadcasts tables
+-- A broadcast is sent by a background job; each attendee gets one delivery
+-- row, so a job picked up again after a crash only sends what is pending.
+CREATE TABLE IF NOT EXISTS event_broadcasts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    subject VARCHAR(200) NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE,
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+CREATE TABLE IF NOT EXISTS event_broadcast_deliveries (
+    broadcast_id UUID NOT NULL REFERENCES event_broadcasts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    channel VARCHAR(20),
+    error TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    PRIMARY KEY (broadcast_id, user_id)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_event_broadcasts_event_id ON event_broadcasts(event_id);
+CREATE INDEX IF NOT EXISTS idx_event_broadcasts_tenant_id ON event_broadcasts(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_event_broadcast_deliveries_pending ON event_broadcast_deliveries(broadcast_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_event_broadcast_deliveries_tenant_id ON event_broadcast_deliveries(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE event_broadcasts ENABLE ROW LEVEL SECURITY;
+ALTER TABLE event_broadcasts FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON event_broadcasts;
+CREATE POLICY tenant_isolation ON event_broadcasts USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE event_broadcast_deliveries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE event_broadcast_deliveries FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON event_broadcast_deliveries;
+CREATE POLICY tenant_isolation ON event_broadcast_deliveries USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
@@ -0,0 +1,9 @@
+-- Rollback ticket returns
+DROP POLICY IF EXISTS tenant_isolation ON ticket_returns;
+DROP INDEX IF EXISTS idx_ticket_returns_tenant_id;
+DROP INDEX IF EXISTS idx_ticket_returns_event_id;
+DROP TABLE IF EXISTS ticket_returns;
+
+UPDATE bookings SET status = 'cancelled' WHERE status = 'returned';
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired', 'refunded', 'review', 'rejected'));
@@ -0,0 +1,26 @@
+-- Bookings whose tickets were all given back for resale
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_status_check;
+ALTER TABLE bookings ADD CONSTRAINT bookings_status_check CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired', 'refunded', 'review', 'rejected', 'returned'));
+
+-- Tickets customers gave back without a refund, one row per ticket
+CREATE TABLE IF NOT EXISTS ticket_returns (
+    id UUID NOT NULL,
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    PRIMARY KEY (id, ticket_id),
+    UNIQUE (booking_id, ticket_id)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_ticket_returns_event_id ON ticket_returns(event_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_returns_tenant_id ON ticket_returns(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE ticket_returns ENABLE ROW LEVEL SECURITY;
+ALTER TABLE ticket_returns FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON ticket_returns;
+CREATE POLICY tenant_isolation ON ticket_returns USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
@@ -0,0 +1,5 @@
+-- Rollback booking dead letters
+DROP POLICY IF EXISTS tenant_isolation ON booking_dead_letters;
+DROP INDEX IF EXISTS idx_booking_dead_letters_tenant_id;
+DROP INDEX IF EXISTS idx_booking_dead_letters_created_at;
+DROP TABLE IF EXISTS booking_dead_letters;
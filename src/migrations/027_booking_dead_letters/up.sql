@@ -0,0 +1,23 @@
+-- Create booking dead letters table
+-- Queued booking requests that failed for good, kept for an admin to requeue or discard.
+CREATE TABLE IF NOT EXISTS booking_dead_letters (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    request_id VARCHAR(64) NOT NULL,
+    user_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    request JSONB NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_booking_dead_letters_created_at ON booking_dead_letters(created_at);
+CREATE INDEX IF NOT EXISTS idx_booking_dead_letters_tenant_id ON booking_dead_letters(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE booking_dead_letters ENABLE ROW LEVEL SECURITY;
+ALTER TABLE booking_dead_letters FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON booking_dead_letters;
+CREATE POLICY tenant_isolation ON booking_dead_letters USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
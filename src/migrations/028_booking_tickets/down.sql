@@ -0,0 +1,19 @@
+-- Rollback booking tickets
+ALTER TABLE bookings ADD COLUMN IF NOT EXISTS ticket_ids UUID[] NOT NULL DEFAULT '{}';
+
+ALTER TABLE bookings NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE booking_tickets NO FORCE ROW LEVEL SECURITY;
+UPDATE bookings b SET ticket_ids = bt.ticket_ids
+FROM (
+    SELECT booking_id, array_agg(ticket_id ORDER BY position) AS ticket_ids
+    FROM booking_tickets
+    GROUP BY booking_id
+) bt
+WHERE bt.booking_id = b.id;
+ALTER TABLE bookings FORCE ROW LEVEL SECURITY;
+ALTER TABLE bookings ALTER COLUMN ticket_ids DROP DEFAULT;
+
+DROP POLICY IF EXISTS tenant_isolation ON booking_tickets;
+DROP INDEX IF EXISTS idx_booking_tickets_tenant_id;
+DROP INDEX IF EXISTS idx_booking_tickets_ticket_id;
+DROP TABLE IF EXISTS booking_tickets;
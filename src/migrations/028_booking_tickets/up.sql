@@ -0,0 +1,35 @@
+-- Create booking tickets table
+-- The tickets each booking holds, replacing the bookings.ticket_ids array
+-- so tickets can be joined on and looked up by booking.
+CREATE TABLE IF NOT EXISTS booking_tickets (
+    booking_id UUID NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    PRIMARY KEY (booking_id, ticket_id)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_booking_tickets_ticket_id ON booking_tickets(ticket_id);
+CREATE INDEX IF NOT EXISTS idx_booking_tickets_tenant_id ON booking_tickets(tenant_id);
+
+-- Copy the tickets of existing bookings across every tenant. Row-level
+-- security is lifted for the owner meanwhile so no tenant's rows are missed.
+ALTER TABLE bookings NO FORCE ROW LEVEL SECURITY;
+ALTER TABLE tickets NO FORCE ROW LEVEL SECURITY;
+INSERT INTO booking_tickets (booking_id, ticket_id, position, tenant_id)
+SELECT b.id, t.ticket_id, t.position, b.tenant_id
+FROM bookings b
+CROSS JOIN LATERAL unnest(b.ticket_ids) WITH ORDINALITY AS t(ticket_id, position)
+JOIN tickets tk ON tk.id = t.ticket_id
+ON CONFLICT DO NOTHING;
+ALTER TABLE bookings FORCE ROW LEVEL SECURITY;
+ALTER TABLE tickets FORCE ROW LEVEL SECURITY;
+
+ALTER TABLE bookings DROP COLUMN IF EXISTS ticket_ids;
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE booking_tickets ENABLE ROW LEVEL SECURITY;
+ALTER TABLE booking_tickets FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON booking_tickets;
+CREATE POLICY tenant_isolation ON booking_tickets USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
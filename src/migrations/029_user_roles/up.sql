@@ -0,0 +1,6 @@
+-- Add user roles
+-- Customers book tickets; organizers also read sales reports and admins may
+-- grant roles. Grant the first admin directly:
+--   UPDATE users SET role = 'admin' WHERE email = '...';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'customer'
+    CHECK (role IN ('customer', 'organizer', 'admin'));
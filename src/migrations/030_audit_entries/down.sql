@@ -0,0 +1,5 @@
+-- Rollback audit entries
+DROP POLICY IF EXISTS tenant_isolation ON audit_entries;
+DROP INDEX IF EXISTS idx_audit_entries_tenant_id;
+DROP INDEX IF EXISTS idx_audit_entries_subject_id;
+DROP TABLE IF EXISTS audit_entries;
@@ -0,0 +1,22 @@
+-- Create audit entries table
+-- Actions that must be accounted for later, such as erasing a user. Subjects
+-- are not foreign keys: entries outlive what they describe.
+CREATE TABLE IF NOT EXISTS audit_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    action VARCHAR(64) NOT NULL,
+    actor_id UUID NOT NULL,
+    subject_id UUID NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_audit_entries_subject_id ON audit_entries(subject_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_entries_tenant_id ON audit_entries(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE audit_entries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE audit_entries FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON audit_entries;
+CREATE POLICY tenant_isolation ON audit_entries USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
@@ -0,0 +1,8 @@
+-- Rollback soft delete. Deleted rows are deleted for good first, as they
+-- would have been before.
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM events WHERE deleted_at IS NOT NULL;
+DROP INDEX IF EXISTS idx_events_deleted_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+ALTER TABLE events DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
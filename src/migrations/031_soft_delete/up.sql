@@ -0,0 +1,10 @@
+-- Soft delete users and events
+-- Deleting sets deleted_at and keeps the row, so the bookings and tickets
+-- that reference it survive an accidental deletion. Repositories skip
+-- deleted rows; admins restore them or purge them for good.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Only the few deleted rows are indexed
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_events_deleted_at ON events(deleted_at) WHERE deleted_at IS NOT NULL;
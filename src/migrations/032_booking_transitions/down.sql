@@ -0,0 +1,7 @@
+-- Rollback booking transitions
+DROP POLICY IF EXISTS tenant_isolation ON booking_transitions;
+DROP TRIGGER IF EXISTS booking_transitions_append_only ON booking_transitions;
+DROP FUNCTION IF EXISTS reject_booking_transition_change();
+DROP INDEX IF EXISTS idx_booking_transitions_tenant_id;
+DROP INDEX IF EXISTS idx_booking_transitions_booking_id;
+DROP TABLE IF EXISTS booking_transitions;
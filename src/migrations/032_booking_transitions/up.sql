@@ -0,0 +1,35 @@
+-- Create booking transitions table
+-- Every status change of a booking or one of its tickets, written with the
+-- change. Bookings are not foreign keys: the history outlives a purge.
+CREATE TABLE IF NOT EXISTS booking_transitions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    booking_id UUID NOT NULL,
+    ticket_id UUID,
+    from_status VARCHAR(20) NOT NULL DEFAULT '',
+    to_status VARCHAR(20) NOT NULL,
+    actor VARCHAR(20) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_booking_transitions_booking_id ON booking_transitions(booking_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_booking_transitions_tenant_id ON booking_transitions(tenant_id);
+
+-- The table is append-only
+CREATE OR REPLACE FUNCTION reject_booking_transition_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'booking_transitions is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER booking_transitions_append_only BEFORE UPDATE OR DELETE ON booking_transitions
+    FOR EACH ROW EXECUTE FUNCTION reject_booking_transition_change();
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE booking_transitions ENABLE ROW LEVEL SECURITY;
+ALTER TABLE booking_transitions FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON booking_transitions;
+CREATE POLICY tenant_isolation ON booking_transitions USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
@@ -0,0 +1,6 @@
+-- Rollback booking events
+DROP POLICY IF EXISTS tenant_isolation ON booking_events;
+DROP TRIGGER IF EXISTS booking_events_append_only ON booking_events;
+DROP FUNCTION IF EXISTS reject_booking_event_change();
+DROP INDEX IF EXISTS idx_booking_events_tenant_id;
+DROP TABLE IF EXISTS booking_events;
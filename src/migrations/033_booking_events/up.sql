@@ -0,0 +1,35 @@
+-- Create booking events table
+-- The event store for bookings: each booking's stream of events, numbered
+-- by version, with a position ordering all streams for consumers. The
+-- bookings table is the projection of these streams.
+CREATE TABLE IF NOT EXISTS booking_events (
+    position BIGSERIAL PRIMARY KEY,
+    booking_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    actor VARCHAR(20) NOT NULL,
+    data JSONB NOT NULL DEFAULT '{}'::jsonb,
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    UNIQUE (booking_id, version)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_booking_events_tenant_id ON booking_events(tenant_id);
+
+-- The table is append-only
+CREATE OR REPLACE FUNCTION reject_booking_event_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'booking_events is append-only';
+END;
+$$ language 'plpgsql';
+
+CREATE TRIGGER booking_events_append_only BEFORE UPDATE OR DELETE ON booking_events
+    FOR EACH ROW EXECUTE FUNCTION reject_booking_event_change();
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE booking_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE booking_events FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON booking_events;
+CREATE POLICY tenant_isolation ON booking_events USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
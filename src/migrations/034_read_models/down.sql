@@ -0,0 +1,11 @@
+-- Rollback read models
+DROP POLICY IF EXISTS tenant_isolation ON read_model_checkpoints;
+DROP POLICY IF EXISTS tenant_isolation ON event_availability;
+DROP POLICY IF EXISTS tenant_isolation ON user_booking_summaries;
+DROP INDEX IF EXISTS idx_event_availability_tenant_id;
+DROP INDEX IF EXISTS idx_event_availability_event_date;
+DROP INDEX IF EXISTS idx_user_booking_summaries_tenant_id;
+DROP INDEX IF EXISTS idx_user_booking_summaries_user_id;
+DROP TABLE IF EXISTS read_model_checkpoints;
+DROP TABLE IF EXISTS event_availability;
+DROP TABLE IF EXISTS user_booking_summaries;
@@ -0,0 +1,59 @@
+-- Create read model tables
+-- Denormalized copies of what hot list endpoints and dashboards read, kept
+-- by the projector from booking_events. They are rebuildable: dropping
+-- their rows and resetting the checkpoint projects them again.
+CREATE TABLE IF NOT EXISTS user_booking_summaries (
+    booking_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    event_name VARCHAR(255) NOT NULL DEFAULT '',
+    event_date TIMESTAMP WITH TIME ZONE,
+    status VARCHAR(20) NOT NULL,
+    booking JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    position BIGINT NOT NULL,
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+CREATE TABLE IF NOT EXISTS event_availability (
+    event_id UUID PRIMARY KEY,
+    event_name VARCHAR(255) NOT NULL DEFAULT '',
+    event_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    available BIGINT NOT NULL DEFAULT 0,
+    reserved BIGINT NOT NULL DEFAULT 0,
+    sold BIGINT NOT NULL DEFAULT 0,
+    held BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id()
+);
+
+-- The last booking event each projector has applied
+CREATE TABLE IF NOT EXISTS read_model_checkpoints (
+    name VARCHAR(50) NOT NULL,
+    position BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    PRIMARY KEY (tenant_id, name)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_user_booking_summaries_user_id ON user_booking_summaries(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_user_booking_summaries_tenant_id ON user_booking_summaries(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_event_availability_event_date ON event_availability(event_date);
+CREATE INDEX IF NOT EXISTS idx_event_availability_tenant_id ON event_availability(tenant_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE user_booking_summaries ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_booking_summaries FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON user_booking_summaries;
+CREATE POLICY tenant_isolation ON user_booking_summaries USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE event_availability ENABLE ROW LEVEL SECURITY;
+ALTER TABLE event_availability FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON event_availability;
+CREATE POLICY tenant_isolation ON event_availability USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
+
+ALTER TABLE read_model_checkpoints ENABLE ROW LEVEL SECURITY;
+ALTER TABLE read_model_checkpoints FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON read_model_checkpoints;
+CREATE POLICY tenant_isolation ON read_model_checkpoints USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
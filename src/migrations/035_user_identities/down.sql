@@ -0,0 +1,4 @@
+-- Rollback user identities
+DROP POLICY IF EXISTS tenant_isolation ON user_identities;
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,21 @@
+-- Create user identities table
+-- Accounts at external sign-in providers linked to users. A provider's
+-- account can be linked to one user per tenant.
+CREATE TABLE IF NOT EXISTS user_identities (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider VARCHAR(20) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    email VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    tenant_id VARCHAR(48) NOT NULL DEFAULT current_tenant_id(),
+    PRIMARY KEY (tenant_id, provider, subject)
+);
+
+-- Create indexes for better performance
+CREATE INDEX IF NOT EXISTS idx_user_identities_user_id ON user_identities(user_id);
+
+-- Same row-level security as the tables from 012_tenant_rls
+ALTER TABLE user_identities ENABLE ROW LEVEL SECURITY;
+ALTER TABLE user_identities FORCE ROW LEVEL SECURITY;
+DROP POLICY IF EXISTS tenant_isolation ON user_identities;
+CREATE POLICY tenant_isolation ON user_identities USING (tenant_id = current_tenant_id()) WITH CHECK (tenant_id = current_tenant_id());
@@ -0,0 +1,4 @@
+-- Rollback API keys
+DROP POLICY IF EXISTS tenant_isolation ON api_keys;
+DROP INDEX IF EXISTS idx_api_keys_tenant_id;
+DROP TABLE IF EXISTS api_keys;
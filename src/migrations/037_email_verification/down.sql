@@ -0,0 +1,2 @@
+-- Rollback email verification
+ALTER TABLE users DROP COLUMN IF EXISTS email_verified_at;
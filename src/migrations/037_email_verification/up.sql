@@ -0,0 +1,5 @@
+-- Email verification
+-- Set when the user opens the link emailed to them, or signs in through a
+-- provider that verified the address. Changing the email clears it. Users
+-- from before this migration start unverified.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS email_verified_at TIMESTAMP WITH TIME ZONE;
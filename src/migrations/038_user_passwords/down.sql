@@ -0,0 +1,2 @@
+-- Rollback user passwords
+ALTER TABLE users DROP COLUMN IF EXISTS password_hash;
@@ -0,0 +1,4 @@
+-- User passwords
+-- Users set a password through the reset flow; until then they have none
+-- and sign in by email alone. Only PBKDF2 hashes are stored.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,5 @@
+-- User locks
+-- Set while an admin has locked the user out. Admins also list users by
+-- when they signed up, newest first.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locked_at TIMESTAMP WITH TIME ZONE;
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
@@ -0,0 +1,45 @@
+// Package migrations embeds the SQL schema migrations. Each migration is a
+// directory named <version>_<name> holding an up.sql and a down.sql, and
+// they are applied in version order.
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// FS holds every migration directory
+//
+//go:embed */up.sql */down.sql
+var FS embed.FS
+
+// sqlite holds the migrations for DB_DRIVER=sqlite under sqlite/. They
+// start from the schema the Postgres migrations had reached when SQLite
+// support was added, and schema changes need a migration in both.
+//
+//go:embed sqlite/*/up.sql sqlite/*/down.sql
+var sqlite embed.FS
+
+// mysql holds the migrations for DB_DRIVER=mysql under mysql/. They only
+// cover the tables of the repositories MySQL has implementations for.
+//
+//go:embed mysql/*/up.sql mysql/*/down.sql
+var mysql embed.FS
+
+// ForDriver returns the migrations for a DB_DRIVER
+func ForDriver(driver string) fs.FS {
+	var dialect embed.FS
+	switch driver {
+	case "sqlite":
+		dialect = sqlite
+	case "mysql":
+		dialect = mysql
+	default:
+		return FS
+	}
+	sub, err := fs.Sub(dialect, driver)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
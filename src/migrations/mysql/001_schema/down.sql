@@ -0,0 +1,7 @@
+-- Rollback the MySQL schema
+DROP TABLE IF EXISTS booking_items;
+DROP TABLE IF EXISTS booking_tickets;
+DROP TABLE IF EXISTS bookings;
+DROP TABLE IF EXISTS tickets;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,95 @@
+-- MySQL schema for the tables the user, event, ticket and booking
+-- repositories use, matching the Postgres migrations up to
+-- 028_booking_tickets. UUIDs are stored as text and times as UTC. MySQL
+-- deployments are single-tenant, so there is no tenant_id.
+
+CREATE TABLE IF NOT EXISTS users (
+    id CHAR(36) PRIMARY KEY,
+    email VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE KEY idx_users_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Event search relies on the case-insensitive collation
+CREATE TABLE IF NOT EXISTS events (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    artist VARCHAR(255) NOT NULL,
+    venue VARCHAR(255) NOT NULL,
+    date DATETIME(6) NOT NULL,
+    total_seats INT NOT NULL CHECK (total_seats > 0),
+    price DECIMAL(10,2) NOT NULL CHECK (price > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published', 'archived')),
+    sales_start_at DATETIME(6) NULL,
+    sales_end_at DATETIME(6) NULL,
+    presale_start_at DATETIME(6) NULL,
+    confirmation_requirements TEXT NOT NULL,
+    waiting_room_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    CHECK (sales_start_at IS NULL OR sales_end_at IS NULL OR sales_start_at < sales_end_at),
+    KEY idx_events_date (date),
+    KEY idx_events_artist (artist),
+    KEY idx_events_status (status),
+    KEY idx_events_sales_start_at (sales_start_at),
+    KEY idx_events_sales_end_at (sales_end_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS tickets (
+    id CHAR(36) PRIMARY KEY,
+    event_id CHAR(36) NOT NULL,
+    seat_number INT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'available' CHECK (status IN ('available', 'reserved', 'sold', 'cancelled', 'held')),
+    price DECIMAL(10,2) NULL CHECK (price > 0),
+    price_cents BIGINT NULL CHECK (price_cents > 0),
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    UNIQUE KEY idx_tickets_event_seat (event_id, seat_number),
+    KEY idx_tickets_event_status (event_id, status),
+    CONSTRAINT fk_tickets_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS bookings (
+    id CHAR(36) PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    event_id CHAR(36) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired', 'refunded', 'review', 'rejected', 'returned')),
+    total_amount DECIMAL(10,2) NOT NULL CHECK (total_amount > 0),
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    exchange_rate DECIMAL(18,8) NULL,
+    payment_reference VARCHAR(255) NULL,
+    risk_score INT NULL CHECK (risk_score BETWEEN 0 AND 100),
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    expires_at DATETIME(6) NOT NULL,
+    KEY idx_bookings_user_status (user_id, status),
+    KEY idx_bookings_status_expires_at (status, expires_at),
+    CONSTRAINT fk_bookings_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_bookings_event FOREIGN KEY (event_id) REFERENCES events(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS booking_tickets (
+    booking_id CHAR(36) NOT NULL,
+    ticket_id CHAR(36) NOT NULL,
+    position INT NOT NULL,
+    PRIMARY KEY (booking_id, ticket_id),
+    KEY idx_booking_tickets_ticket_id (ticket_id),
+    CONSTRAINT fk_booking_tickets_booking FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+    CONSTRAINT fk_booking_tickets_ticket FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS booking_items (
+    booking_id CHAR(36) NOT NULL,
+    ticket_id CHAR(36) NOT NULL,
+    seat_number INT NOT NULL,
+    base_price DECIMAL(10,2) NOT NULL,
+    unit_price DECIMAL(10,2) NOT NULL,
+    currency CHAR(3) NOT NULL,
+    exchange_rate DECIMAL(18,8) NULL,
+    created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (booking_id, ticket_id),
+    CONSTRAINT fk_booking_items_booking FOREIGN KEY (booking_id) REFERENCES bookings(id) ON DELETE CASCADE,
+    CONSTRAINT fk_booking_items_ticket FOREIGN KEY (ticket_id) REFERENCES tickets(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
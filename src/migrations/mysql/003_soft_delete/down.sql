@@ -0,0 +1,5 @@
+-- Rollback soft delete
+DELETE FROM users WHERE deleted_at IS NOT NULL;
+DELETE FROM events WHERE deleted_at IS NOT NULL;
+ALTER TABLE events DROP COLUMN deleted_at;
+ALTER TABLE users DROP COLUMN deleted_at;
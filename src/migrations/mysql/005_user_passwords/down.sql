@@ -0,0 +1,2 @@
+-- Rollback user passwords
+ALTER TABLE users DROP COLUMN password_hash;
@@ -0,0 +1,2 @@
+-- User passwords, as in 038_user_passwords
+ALTER TABLE users ADD COLUMN password_hash VARCHAR(255) NOT NULL DEFAULT '';
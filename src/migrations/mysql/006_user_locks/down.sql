@@ -0,0 +1,2 @@
+-- Rollback user locks
+ALTER TABLE users DROP KEY idx_users_created_at, DROP COLUMN locked_at;
@@ -0,0 +1,2 @@
+-- User locks, as in 039_user_locks
+ALTER TABLE users ADD COLUMN locked_at DATETIME(6) NULL, ADD KEY idx_users_created_at (created_at);
@@ -0,0 +1,24 @@
+-- Rollback the SQLite schema
+DROP TABLE IF EXISTS booking_dead_letters;
+DROP TABLE IF EXISTS ticket_returns;
+DROP TABLE IF EXISTS event_broadcast_deliveries;
+DROP TABLE IF EXISTS event_broadcasts;
+DROP TABLE IF EXISTS refund_policies;
+DROP TABLE IF EXISTS refund_items;
+DROP TABLE IF EXISTS refunds;
+DROP TABLE IF EXISTS jobs;
+DROP TABLE IF EXISTS ticket_passes;
+DROP TABLE IF EXISTS event_templates;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
+DROP TABLE IF EXISTS outbox_events;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS presale_codes;
+DROP TABLE IF EXISTS booking_items;
+DROP TABLE IF EXISTS booking_tickets;
+DROP TABLE IF EXISTS bookings;
+DROP TABLE IF EXISTS tickets;
+DROP TABLE IF EXISTS seat_holds;
+DROP TABLE IF EXISTS events;
+DROP TABLE IF EXISTS users;
@@ -0,0 +1,346 @@
+-- SQLite schema, matching the Postgres migrations up to 028_booking_tickets
+-- UUIDs and JSON are stored as text and times as UTC text, which sorts in
+-- time order. SQLite deployments are single-tenant, so tenant_id is always
+-- empty.
+
+CREATE TABLE IF NOT EXISTS users (
+    id TEXT PRIMARY KEY,
+    email TEXT NOT NULL,
+    name TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_tenant_email ON users(tenant_id, email);
+
+CREATE TABLE IF NOT EXISTS events (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    venue TEXT NOT NULL,
+    date TIMESTAMP NOT NULL,
+    total_seats INTEGER NOT NULL CHECK (total_seats > 0),
+    price REAL NOT NULL CHECK (price > 0),
+    status TEXT NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published', 'archived')),
+    sales_start_at TIMESTAMP,
+    sales_end_at TIMESTAMP,
+    presale_start_at TIMESTAMP,
+    confirmation_requirements TEXT NOT NULL DEFAULT '[]',
+    waiting_room_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    CHECK (sales_start_at IS NULL OR sales_end_at IS NULL OR sales_start_at < sales_end_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_events_date ON events(date);
+CREATE INDEX IF NOT EXISTS idx_events_artist ON events(artist);
+CREATE INDEX IF NOT EXISTS idx_events_status ON events(status);
+CREATE INDEX IF NOT EXISTS idx_events_sales_start_at ON events(sales_start_at);
+CREATE INDEX IF NOT EXISTS idx_events_sales_end_at ON events(sales_end_at);
+
+CREATE TABLE IF NOT EXISTS seat_holds (
+    id TEXT PRIMARY KEY,
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('sponsor', 'artist', 'press')),
+    label TEXT NOT NULL DEFAULT '',
+    from_seat INTEGER NOT NULL,
+    to_seat INTEGER NOT NULL,
+    seats INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'released')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    released_at TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    CHECK (from_seat <= to_seat)
+);
+
+CREATE INDEX IF NOT EXISTS idx_seat_holds_event_id ON seat_holds(event_id);
+
+CREATE TABLE IF NOT EXISTS tickets (
+    id TEXT PRIMARY KEY,
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    seat_number INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'available' CHECK (status IN ('available', 'reserved', 'sold', 'cancelled', 'held')),
+    price REAL CHECK (price > 0),
+    price_cents INTEGER CHECK (price_cents > 0),
+    hold_id TEXT REFERENCES seat_holds(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    UNIQUE (event_id, seat_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tickets_event_status ON tickets(event_id, status);
+CREATE INDEX IF NOT EXISTS idx_tickets_hold_id ON tickets(hold_id) WHERE hold_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tickets_price_cents_pending ON tickets(id) WHERE price_cents IS NULL;
+
+CREATE TABLE IF NOT EXISTS bookings (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'confirmed', 'cancelled', 'expired', 'refunded', 'review', 'rejected', 'returned')),
+    total_amount REAL NOT NULL CHECK (total_amount > 0),
+    currency TEXT NOT NULL DEFAULT 'USD',
+    exchange_rate REAL,
+    payment_reference TEXT,
+    risk_score INTEGER CHECK (risk_score BETWEEN 0 AND 100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_bookings_event_id ON bookings(event_id);
+CREATE INDEX IF NOT EXISTS idx_bookings_user_status ON bookings(user_id, status);
+CREATE INDEX IF NOT EXISTS idx_bookings_pending_expires_at ON bookings(expires_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_bookings_review_expires_at ON bookings(expires_at) WHERE status = 'review';
+
+CREATE TABLE IF NOT EXISTS booking_tickets (
+    booking_id TEXT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    ticket_id TEXT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (booking_id, ticket_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_tickets_ticket_id ON booking_tickets(ticket_id);
+
+CREATE TABLE IF NOT EXISTS booking_items (
+    booking_id TEXT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    ticket_id TEXT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    seat_number INTEGER NOT NULL,
+    base_price REAL NOT NULL,
+    unit_price REAL NOT NULL,
+    currency TEXT NOT NULL,
+    exchange_rate REAL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (booking_id, ticket_id)
+);
+
+CREATE TABLE IF NOT EXISTS presale_codes (
+    id TEXT PRIMARY KEY,
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    batch_id TEXT NOT NULL,
+    code TEXT NOT NULL,
+    redeemed_by TEXT REFERENCES users(id) ON DELETE SET NULL,
+    redeemed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    UNIQUE (event_id, code)
+);
+
+CREATE INDEX IF NOT EXISTS idx_presale_codes_batch_id ON presale_codes(batch_id);
+CREATE INDEX IF NOT EXISTS idx_presale_codes_event_redeemed_by ON presale_codes(event_id, redeemed_by);
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id TEXT PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT NOT NULL DEFAULT '[]',
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id TEXT PRIMARY KEY,
+    subscription_id TEXT NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INTEGER,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries(subscription_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id TEXT PRIMARY KEY,
+    aggregate_type TEXT NOT NULL,
+    aggregate_id TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_due ON outbox_events(next_attempt_at, created_at) WHERE published_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events(published_at) WHERE published_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('booking_confirmed', 'booking_expiry_warning', 'booking_cancelled', 'event_reminder')),
+    booking_id TEXT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    sent_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    UNIQUE (booking_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_due ON notifications(next_attempt_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id TEXT PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    channels TEXT NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS event_templates (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    source_event_id TEXT REFERENCES events(id) ON DELETE SET NULL,
+    event_name TEXT NOT NULL,
+    artist TEXT NOT NULL,
+    venue TEXT NOT NULL,
+    total_seats INTEGER NOT NULL CHECK (total_seats > 0),
+    price REAL NOT NULL CHECK (price > 0),
+    tiers TEXT NOT NULL DEFAULT '[]',
+    sales_start_offset_seconds INTEGER,
+    sales_end_offset_seconds INTEGER,
+    presale_start_offset_seconds INTEGER,
+    confirmation_requirements TEXT NOT NULL DEFAULT '[]',
+    waiting_room_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS ticket_passes (
+    id TEXT PRIMARY KEY,
+    booking_id TEXT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    ticket_id TEXT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    token TEXT NOT NULL UNIQUE,
+    issued_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    checked_in_at TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    UNIQUE (booking_id, ticket_id)
+);
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    state TEXT NOT NULL DEFAULT 'queued' CHECK (state IN ('queued', 'running', 'succeeded', 'failed')),
+    params TEXT NOT NULL DEFAULT '{}',
+    progress INTEGER NOT NULL DEFAULT 0 CHECK (progress BETWEEN 0 AND 100),
+    result_url TEXT,
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    lease_until TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_due ON jobs(created_at) WHERE state IN ('queued', 'running');
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id TEXT PRIMARY KEY,
+    booking_id TEXT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    amount REAL NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+    provider_reference TEXT,
+    error TEXT,
+    requested_by TEXT NOT NULL CHECK (requested_by IN ('owner', 'admin')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_booking_id ON refunds(booking_id);
+
+CREATE TABLE IF NOT EXISTS refund_items (
+    refund_id TEXT NOT NULL REFERENCES refunds(id) ON DELETE CASCADE,
+    ticket_id TEXT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    amount REAL NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (refund_id, ticket_id)
+);
+
+CREATE TABLE IF NOT EXISTS refund_policies (
+    event_id TEXT PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    refundable BOOLEAN NOT NULL DEFAULT TRUE,
+    deadline_hours INTEGER NOT NULL DEFAULT 0,
+    percent REAL NOT NULL DEFAULT 100 CHECK (percent BETWEEN 0 AND 100),
+    allow_partial BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS event_broadcasts (
+    id TEXT PRIMARY KEY,
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    subject TEXT NOT NULL,
+    message TEXT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_broadcasts_event_id ON event_broadcasts(event_id);
+
+CREATE TABLE IF NOT EXISTS event_broadcast_deliveries (
+    broadcast_id TEXT NOT NULL REFERENCES event_broadcasts(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    channel TEXT,
+    error TEXT,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (broadcast_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_broadcast_deliveries_pending ON event_broadcast_deliveries(broadcast_id) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS ticket_returns (
+    id TEXT NOT NULL,
+    booking_id TEXT NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    event_id TEXT NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_id TEXT NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (id, ticket_id),
+    UNIQUE (booking_id, ticket_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_returns_event_id ON ticket_returns(event_id);
+
+CREATE TABLE IF NOT EXISTS booking_dead_letters (
+    id TEXT PRIMARY KEY,
+    request_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    request TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_dead_letters_created_at ON booking_dead_letters(created_at);
@@ -0,0 +1,3 @@
+-- Add user roles, as in 029_user_roles
+ALTER TABLE users ADD COLUMN role TEXT NOT NULL DEFAULT 'customer'
+    CHECK (role IN ('customer', 'organizer', 'admin'));
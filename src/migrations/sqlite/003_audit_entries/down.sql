@@ -0,0 +1,3 @@
+-- Rollback audit entries
+DROP INDEX IF EXISTS idx_audit_entries_subject_id;
+DROP TABLE IF EXISTS audit_entries;
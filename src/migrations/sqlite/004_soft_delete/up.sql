@@ -0,0 +1,3 @@
+-- Soft delete users and events, as in 031_soft_delete
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMP;
+ALTER TABLE events ADD COLUMN deleted_at TIMESTAMP;
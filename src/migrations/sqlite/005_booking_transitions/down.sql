@@ -0,0 +1,5 @@
+-- Rollback booking transitions
+DROP TRIGGER IF EXISTS booking_transitions_no_delete;
+DROP TRIGGER IF EXISTS booking_transitions_no_update;
+DROP INDEX IF EXISTS idx_booking_transitions_booking_id;
+DROP TABLE IF EXISTS booking_transitions;
@@ -0,0 +1,24 @@
+-- Create booking transitions table, as in 032_booking_transitions
+CREATE TABLE IF NOT EXISTS booking_transitions (
+    id TEXT PRIMARY KEY,
+    booking_id TEXT NOT NULL,
+    ticket_id TEXT,
+    from_status TEXT NOT NULL DEFAULT '',
+    to_status TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_booking_transitions_booking_id ON booking_transitions(booking_id, created_at);
+
+-- The table is append-only
+CREATE TRIGGER IF NOT EXISTS booking_transitions_no_update BEFORE UPDATE ON booking_transitions
+BEGIN
+    SELECT RAISE(ABORT, 'booking_transitions is append-only');
+END;
+CREATE TRIGGER IF NOT EXISTS booking_transitions_no_delete BEFORE DELETE ON booking_transitions
+BEGIN
+    SELECT RAISE(ABORT, 'booking_transitions is append-only');
+END;
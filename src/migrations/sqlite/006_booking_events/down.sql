@@ -0,0 +1,4 @@
+-- Rollback booking events
+DROP TRIGGER IF EXISTS booking_events_no_delete;
+DROP TRIGGER IF EXISTS booking_events_no_update;
+DROP TABLE IF EXISTS booking_events;
@@ -0,0 +1,22 @@
+-- Create booking events table, as in 033_booking_events
+CREATE TABLE IF NOT EXISTS booking_events (
+    position INTEGER PRIMARY KEY AUTOINCREMENT,
+    booking_id TEXT NOT NULL,
+    version INTEGER NOT NULL,
+    type TEXT NOT NULL,
+    actor TEXT NOT NULL,
+    data TEXT NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    UNIQUE (booking_id, version)
+);
+
+-- The table is append-only
+CREATE TRIGGER IF NOT EXISTS booking_events_no_update BEFORE UPDATE ON booking_events
+BEGIN
+    SELECT RAISE(ABORT, 'booking_events is append-only');
+END;
+CREATE TRIGGER IF NOT EXISTS booking_events_no_delete BEFORE DELETE ON booking_events
+BEGIN
+    SELECT RAISE(ABORT, 'booking_events is append-only');
+END;
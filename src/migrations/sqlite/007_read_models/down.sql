@@ -0,0 +1,6 @@
+-- Rollback read models
+DROP INDEX IF EXISTS idx_event_availability_event_date;
+DROP INDEX IF EXISTS idx_user_booking_summaries_user_id;
+DROP TABLE IF EXISTS read_model_checkpoints;
+DROP TABLE IF EXISTS event_availability;
+DROP TABLE IF EXISTS user_booking_summaries;
@@ -0,0 +1,36 @@
+-- Create read model tables, as in 034_read_models
+CREATE TABLE IF NOT EXISTS user_booking_summaries (
+    booking_id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    event_id TEXT NOT NULL,
+    event_name TEXT NOT NULL DEFAULT '',
+    event_date TIMESTAMP,
+    status TEXT NOT NULL,
+    booking TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    position INTEGER NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS event_availability (
+    event_id TEXT PRIMARY KEY,
+    event_name TEXT NOT NULL DEFAULT '',
+    event_date TIMESTAMP NOT NULL,
+    available INTEGER NOT NULL DEFAULT 0,
+    reserved INTEGER NOT NULL DEFAULT 0,
+    sold INTEGER NOT NULL DEFAULT 0,
+    held INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT ''
+);
+
+CREATE TABLE IF NOT EXISTS read_model_checkpoints (
+    name TEXT NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (tenant_id, name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_booking_summaries_user_id ON user_booking_summaries(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_event_availability_event_date ON event_availability(event_date);
@@ -0,0 +1,3 @@
+-- Rollback user identities
+DROP INDEX IF EXISTS idx_user_identities_user_id;
+DROP TABLE IF EXISTS user_identities;
@@ -0,0 +1,2 @@
+-- Rollback API keys
+DROP TABLE IF EXISTS api_keys;
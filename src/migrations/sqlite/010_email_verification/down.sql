@@ -0,0 +1,2 @@
+-- Rollback email verification
+ALTER TABLE users DROP COLUMN email_verified_at;
@@ -0,0 +1,2 @@
+-- Email verification, as in 037_email_verification
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP;
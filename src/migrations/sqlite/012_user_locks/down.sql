@@ -0,0 +1,3 @@
+-- Rollback user locks
+DROP INDEX IF EXISTS idx_users_created_at;
+ALTER TABLE users DROP COLUMN locked_at;
@@ -0,0 +1,3 @@
+-- User locks, as in 039_user_locks
+ALTER TABLE users ADD COLUMN locked_at TIMESTAMP;
+CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);
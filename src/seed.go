@@ -0,0 +1,336 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	domain_user "github.com/ojaswiii/booking-manager/src/internal/domain/user"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+
+	"github.com/google/uuid"
+)
+
+// seedEvent is one demo event. Sales open salesIn from now, and
+// roughly booked of its seats are taken by demo bookings.
+type seedEvent struct {
+	name, artist, venue string
+	date                time.Duration // from now
+	seats               int
+	price               float64
+	status              domain_event.EventStatus
+	salesIn             time.Duration
+	booked              float64
+}
+
+var seedEvents = []seedEvent{
+	{"Midnight Echoes World Tour", "Midnight Echoes", "Madison Square Garden", 30 * 24 * time.Hour, 200, 89.50, domain_event.EventStatusPublished, 0, 0.7},
+	{"Jazz on the Terrace", "Smooth Jazz Trio", "Blue Note", 14 * 24 * time.Hour, 60, 45, domain_event.EventStatusPublished, 0, 0.4},
+	{"Indie Spring Festival", "Various Artists", "Riverside Park", 60 * 24 * time.Hour, 150, 65, domain_event.EventStatusPublished, 72 * time.Hour, 0},
+	{"Symphony No. 9", "City Philharmonic", "Concert Hall", 90 * 24 * time.Hour, 120, 110, domain_event.EventStatusDraft, 0, 0},
+	{"Retro Synthwave Night", "Neon Drive", "The Warehouse", -7 * 24 * time.Hour, 80, 35, domain_event.EventStatusArchived, 0, 0.9},
+}
+
+var seedNames = []string{
+	"Alice Johnson", "Bob Smith", "Carol White", "David Brown", "Eve Davis",
+	"Frank Miller", "Grace Wilson", "Henry Moore", "Ivy Taylor", "Jack Anderson",
+	"Karen Thomas", "Leo Jackson", "Mia Martin", "Noah Lee", "Olivia Harris",
+	"Paul Clark", "Quinn Lewis", "Rosa Walker", "Sam Hall", "Tina Young",
+}
+
+// seedStatuses is the mix of states demo bookings end in, drawn uniformly
+var seedStatuses = []domain_booking.BookingStatus{
+	domain_booking.BookingStatusConfirmed,
+	domain_booking.BookingStatusConfirmed,
+	domain_booking.BookingStatusConfirmed,
+	domain_booking.BookingStatusConfirmed,
+	domain_booking.BookingStatusPending,
+	domain_booking.BookingStatusCancelled,
+	domain_booking.BookingStatusExpired,
+	domain_booking.BookingStatusRefunded,
+}
+
+// seedStatusEvents are the events that end a demo booking in each status
+var seedStatusEvents = map[domain_booking.BookingStatus]domain_booking.EventType{
+	domain_booking.BookingStatusConfirmed: domain_booking.BookingConfirmed,
+	domain_booking.BookingStatusCancelled: domain_booking.BookingCancelled,
+	domain_booking.BookingStatusExpired:   domain_booking.BookingExpired,
+	domain_booking.BookingStatusRefunded:  domain_booking.BookingRefunded,
+}
+
+// runSeed runs the seed subcommand and returns the process exit code
+func runSeed(args []string, config *utils.Config, logger *utils.Logger) int {
+	flags := newFlagSet("seed", "[-users n] [-tenant id]")
+	users := flags.Int("users", len(seedNames), "number of demo users to create")
+	tenantID := flags.String("tenant", "", "tenant to seed, with TENANT_ISOLATION set")
+	flags.BoolVar(&config.DBMigrateOnStartup, "migrate", config.DBMigrateOnStartup, "apply pending migrations first (DB_MIGRATE_ON_STARTUP)")
+	if code, ok := parseFlags(flags, args, config, logger); !ok {
+		return code
+	}
+	if *users < 1 {
+		flags.Usage()
+		return 2
+	}
+	if config.StorageBackend != "database" {
+		logger.Error("seed needs STORAGE_BACKEND=database; use STORAGE_SEED_MEMORY for a memory store", "storage_backend", config.StorageBackend)
+		return 1
+	}
+
+	ctx := context.Background()
+	if *tenantID != "" {
+		if err := tenant.Validate(*tenantID); err != nil {
+			logger.Error("Invalid tenant", "tenant", *tenantID, "error", err)
+			return 2
+		}
+		ctx = tenant.WithID(ctx, *tenantID)
+	}
+
+	isolation, err := repository.ParseTenantIsolation(config.TenantIsolation)
+	if err != nil {
+		logger.Error("Invalid tenancy configuration", "error", err)
+		return 1
+	}
+	columnMigrations, err := repository.ParseColumnMigrations(config.ColumnMigrations)
+	if err != nil {
+		logger.Error("Invalid column migration configuration", "error", err)
+		return 1
+	}
+
+	db, queries, err := connectDatabase(config, logger)
+	if err != nil {
+		logger.Error("Failed to connect to the database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	if config.DBMigrateOnStartup {
+		if err := migrateAll(ctx, db, config, logger); err != nil {
+			logger.Error("Failed to migrate database", "error", err)
+			return 1
+		}
+	}
+
+	// Seeding only touches the database, so the Redis-backed repositories stay unset
+	repos := repository.NewRepositoryContainer(db, nil, isolation, columnMigrations, repository.TxRetryPolicy{
+		MaxAttempts: config.DBTxMaxAttempts,
+		Backoff:     time.Duration(config.DBTxRetryBackoffMs) * time.Millisecond,
+		MaxBackoff:  time.Duration(config.DBTxRetryMaxBackoffMs) * time.Millisecond,
+	}, nil, queries, repository.CacheTTLs{})
+
+	seeder := &seeder{
+		repos:    repos,
+		pricing:  usecase.NewBookingPricing(config),
+		currency: config.Currency,
+		rand:     rand.New(rand.NewSource(1)),
+	}
+	if err := seeder.seed(ctx, *users); err != nil {
+		if errors.Is(err, errAlreadySeeded) {
+			logger.Info("Demo data already present; nothing to do")
+			return 0
+		}
+		logger.Error("Seeding failed", "error", err)
+		return 1
+	}
+
+	logger.Info("Seeded demo data",
+		"users", *users,
+		"events", len(seedEvents),
+		"tickets", seeder.tickets,
+		"bookings", seeder.bookings,
+	)
+	return 0
+}
+
+// errAlreadySeeded is returned when the demo users already exist
+var errAlreadySeeded = errors.New("demo data already seeded")
+
+// seeder writes demo data through the repositories, so it obeys the same
+// schema, tenancy and column migrations as the application
+type seeder struct {
+	repos    *repository.RepositoryContainer
+	pricing  domain_booking.Pricing
+	currency string
+	rand     *rand.Rand
+
+	tickets  int
+	bookings int
+}
+
+// seed creates count users and the demo events with their bookings, all
+// in one transaction so a failed run leaves nothing behind
+func (s *seeder) seed(ctx context.Context, count int) error {
+	if _, err := s.repos.User.GetByEmail(ctx, seedEmail(1)); err == nil {
+		return errAlreadySeeded
+	} else if !errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+
+	return s.repos.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		s.tickets, s.bookings = 0, 0
+		now := time.Now()
+		// Hashed once; every demo user shares it
+		passwordHash, err := domain_user.HashPassword(seedPassword)
+		if err != nil {
+			return err
+		}
+
+		users := make([]*domain_user.User, count)
+		for i := range users {
+			name := seedNames[i%len(seedNames)]
+			if i >= len(seedNames) {
+				name = fmt.Sprintf("%s %d", name, i/len(seedNames)+1)
+			}
+			users[i] = &domain_user.User{
+				ID:        uuid.New(),
+				Email:     seedEmail(i + 1),
+				Name:      name,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if err := s.repos.User.Create(ctx, users[i]); err != nil {
+				return fmt.Errorf("failed to create user %s: %w", users[i].Email, err)
+			}
+			if err := s.repos.User.SetPasswordHash(ctx, users[i].ID, passwordHash); err != nil {
+				return fmt.Errorf("failed to set password of user %s: %w", users[i].Email, err)
+			}
+		}
+
+		for _, spec := range seedEvents {
+			event, tickets, err := s.createEvent(ctx, spec, now)
+			if err != nil {
+				return fmt.Errorf("failed to create event %q: %w", spec.name, err)
+			}
+			if err := s.createBookings(ctx, event, tickets, users, spec.booked, now); err != nil {
+				return fmt.Errorf("failed to book event %q: %w", spec.name, err)
+			}
+		}
+		return nil
+	})
+}
+
+// createEvent creates the event and its tickets. The front tenth of the
+// seats are premium, at half as much again.
+func (s *seeder) createEvent(ctx context.Context, spec seedEvent, now time.Time) (*domain_event.Event, []*domain_ticket.Ticket, error) {
+	event := &domain_event.Event{
+		ID:         uuid.New(),
+		Name:       spec.name,
+		Artist:     spec.artist,
+		Venue:      spec.venue,
+		Date:       now.Add(spec.date).Truncate(time.Hour),
+		TotalSeats: spec.seats,
+		Price:      spec.price,
+		Status:     spec.status,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if spec.salesIn > 0 {
+		salesStartAt := now.Add(spec.salesIn).Truncate(time.Hour)
+		event.SalesStartAt = &salesStartAt
+	}
+	if err := s.repos.Event.Create(ctx, event); err != nil {
+		return nil, nil, err
+	}
+
+	tickets := make([]*domain_ticket.Ticket, spec.seats)
+	for i := range tickets {
+		price := spec.price
+		if i < spec.seats/10 {
+			price = domain_booking.RoundCents(price * 1.5)
+		}
+		tickets[i] = &domain_ticket.Ticket{
+			ID:         uuid.New(),
+			EventID:    event.ID,
+			SeatNumber: i + 1,
+			Status:     domain_ticket.TicketStatusAvailable,
+			Price:      price,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+		}
+		if err := s.repos.Ticket.Create(ctx, tickets[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	s.tickets += len(tickets)
+	return event, tickets, nil
+}
+
+// createBookings books consecutive runs of one to four seats for random
+// users until about booked of the seats are taken, ending each booking in
+// a random state with its tickets to match
+func (s *seeder) createBookings(ctx context.Context, event *domain_event.Event, tickets []*domain_ticket.Ticket, users []*domain_user.User, booked float64, now time.Time) error {
+	limit := int(float64(len(tickets)) * booked)
+	for seat := 0; seat < limit; {
+		size := min(1+s.rand.Intn(4), limit-seat)
+		held := tickets[seat : seat+size]
+		seat += size
+
+		status := seedStatuses[s.rand.Intn(len(seedStatuses))]
+		createdAt := now.Add(-time.Duration(s.rand.Intn(72*60)) * time.Minute)
+		if status == domain_booking.BookingStatusPending {
+			createdAt = now.Add(-time.Duration(s.rand.Intn(10)) * time.Minute)
+		}
+
+		booking := &domain_booking.Booking{
+			ID:        uuid.New(),
+			UserID:    users[s.rand.Intn(len(users))].ID,
+			EventID:   event.ID,
+			Status:    domain_booking.BookingStatusPending,
+			Currency:  s.currency,
+			CreatedAt: createdAt,
+			UpdatedAt: createdAt,
+			ExpiresAt: createdAt.Add(15 * time.Minute),
+		}
+		for _, ticket := range held {
+			booking.TicketIDs = append(booking.TicketIDs, ticket.ID)
+		}
+		booking.TotalAmount = s.pricing.Price(booking.LockPrices(held), len(held)).Total
+		if err := booking.RecordCreated(domain_booking.ActorSystem); err != nil {
+			return err
+		}
+		if status != domain_booking.BookingStatusPending {
+			if err := booking.Record(seedStatusEvents[status], domain_booking.ActorSystem, createdAt, nil); err != nil {
+				return err
+			}
+		}
+
+		if err := s.repos.Booking.Create(ctx, booking); err != nil {
+			return err
+		}
+		if err := s.repos.BookingEvent.Append(ctx, booking.Changes...); err != nil {
+			return err
+		}
+		// Cancelled, expired and refunded bookings gave their seats back
+		switch status {
+		case domain_booking.BookingStatusPending:
+			if err := s.repos.Ticket.ReserveTickets(ctx, booking.TicketIDs); err != nil {
+				return err
+			}
+		case domain_booking.BookingStatusConfirmed:
+			if err := s.repos.Ticket.ReserveTickets(ctx, booking.TicketIDs); err != nil {
+				return err
+			}
+			if err := s.repos.Ticket.ConfirmTickets(ctx, booking.TicketIDs); err != nil {
+				return err
+			}
+		}
+		s.bookings++
+	}
+	return nil
+}
+
+// seedPassword is what every demo user signs in with
+const seedPassword = "demo-password"
+
+// seedEmail returns the email of the nth demo user
+func seedEmail(n int) string {
+	return fmt.Sprintf("demo-user-%02d@example.com", n)
+}
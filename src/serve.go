@@ -0,0 +1,195 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/middlewares"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	concurrency "github.com/ojaswiii/booking-manager/src/utils/concurrency"
+)
+
+// runServe runs the API server, and unless told not to the scheduled jobs,
+// until interrupted. It returns the process exit code.
+func runServe(args []string, config *utils.Config, logger *utils.Logger) int {
+	flags := newFlagSet("serve", "[flags]")
+	flags.StringVar(&config.ServerHost, "host", config.ServerHost, "address to listen on (SERVER_HOST)")
+	flags.StringVar(&config.ServerPort, "port", config.ServerPort, "port to listen on (SERVER_PORT)")
+	flags.BoolVar(&config.DBMigrateOnStartup, "migrate", config.DBMigrateOnStartup, "apply pending migrations first (DB_MIGRATE_ON_STARTUP)")
+	flags.BoolVar(&config.CacheWarmupEnabled, "warm-cache", config.CacheWarmupEnabled, "fill the caches from the database before accepting traffic (CACHE_WARMUP_ENABLED)")
+	flags.BoolVar(&config.BookingProcessorEnabled, "process", config.BookingProcessorEnabled, "process queued bookings; disable to only enqueue them for workers (BOOKING_PROCESSOR_ENABLED)")
+	jobs := flags.Bool("jobs", true, "also run the scheduled jobs; disable when separate workers run them")
+	if code, ok := parseFlags(flags, args, config, logger); !ok {
+		return code
+	}
+
+	logger.Info("Starting booking system with integrated concurrency", "environment", config.Environment)
+
+	if !config.TLSEnabled() && (config.TLSCertFile != "" || config.TLSKeyFile != "") {
+		logger.Error("Invalid TLS configuration", "error", "TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+		return 1
+	}
+
+	a, err := newApp(config, logger)
+	if err != nil {
+		logger.Error("Failed to start", "error", err)
+		return 1
+	}
+	defer a.Close()
+
+	// Initialize REST delivery
+	restContainer := rest.NewRestContainer(a.usecases, logger)
+	router := restContainer.Router.SetupRoutes(middlewares.TenantPolicy{
+		Header:   config.TenantHeader,
+		Allowed:  config.TenantIDs,
+		Required: config.IsMultiTenant(),
+	})
+	probes := concurrency.HealthProbes{QueueFill: a.usecases.Booking.QueueFill}
+	if a.db != nil {
+		probes.PingDB = a.db.PingContext
+	}
+	loadShedder := usecase.NewLoadShedder(config, probes, logger)
+	router.Use(middlewares.LoadShedding(loadShedder))
+	router.Use(middlewares.BodyLimit(int64(config.MaxRequestBodyBytes)))
+	router.Use(middlewares.Timeout(middlewares.TimeoutPolicy{
+		Default: time.Duration(config.RequestTimeoutMs) * time.Millisecond,
+		Booking: time.Duration(config.BookingRequestTimeoutMs) * time.Millisecond,
+	}))
+	cors := middlewares.CORS(middlewares.CORSPolicy{
+		AllowedOrigins:   config.CORSAllowedOrigins,
+		AllowedMethods:   config.CORSAllowedMethods,
+		AllowedHeaders:   config.CORSAllowedHeaders,
+		AllowCredentials: config.CORSAllowCredentials,
+		MaxAgeSeconds:    config.CORSMaxAgeSeconds,
+	})
+	var handler http.Handler = router
+	if config.CompressionEnabled {
+		handler = middlewares.Compression(middlewares.CompressionPolicy{
+			MinBytes:      config.CompressionMinBytes,
+			ExcludedTypes: config.CompressionExcludedTypes,
+		})(handler)
+	}
+	logger.Info("REST delivery initialized")
+
+	// Create server
+	server := &http.Server{
+		Addr:         config.ServerHost + ":" + config.ServerPort,
+		Handler:      cors(handler),
+		ReadTimeout:  15 * time.Second,
+		WriteTimeout: 15 * time.Second,
+		IdleTimeout:  60 * time.Second,
+	}
+
+	// Terminate TLS here for deployments without a load balancer in front.
+	// net/http negotiates HTTP/2 over TLS on its own.
+	var redirectServer *http.Server
+	if config.TLSEnabled() {
+		server.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if config.TLSRedirectPort != "" {
+			redirectServer = &http.Server{
+				Addr:              config.ServerHost + ":" + config.TLSRedirectPort,
+				Handler:           middlewares.HTTPSRedirect(config.ServerPort),
+				ReadHeaderTimeout: 5 * time.Second,
+			}
+		}
+	}
+
+	// Create context for graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Sample system health for load shedding
+	go loadShedder.Run(ctx)
+
+	a.monitorReplica(ctx)
+	a.listenForInvalidations(ctx)
+	// Pass seat changes to live availability streams; they end on shutdown
+	go a.usecases.AvailabilityFeed.Run(ctx)
+	// Pass booking request outcomes to the users watching them
+	go a.usecases.BookingUpdates.Run(ctx)
+	// Save the booking processor's per-minute counts
+	go a.usecases.BookingMetrics.Run(ctx)
+	if config.CacheWarmupEnabled {
+		a.warmCaches(ctx)
+	}
+	if *jobs {
+		a.runJobs(ctx)
+	} else {
+		logger.Info("Scheduled jobs disabled; run them with the worker command")
+	}
+
+	// Start server in a goroutine
+	failed := make(chan error, 2)
+	go func() {
+		logger.Info("Starting server with integrated concurrency",
+			"host", config.ServerHost,
+			"port", config.ServerPort,
+			"tls", config.TLSEnabled(),
+			"features", []string{
+				"integrated_concurrency",
+				"ticket_locks_with_expiration",
+				"load_balanced_queues",
+				"race_condition_handling",
+				"automatic_cleanup",
+			})
+
+		var err error
+		if config.TLSEnabled() {
+			err = server.ListenAndServeTLS(config.TLSCertFile, config.TLSKeyFile)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err != nil && err != http.ErrServerClosed {
+			failed <- fmt.Errorf("server failed: %w", err)
+		}
+	}()
+
+	// Redirect plain HTTP to HTTPS
+	if redirectServer != nil {
+		go func() {
+			logger.Info("Redirecting HTTP to HTTPS", "port", config.TLSRedirectPort)
+			if err := redirectServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				failed <- fmt.Errorf("HTTP redirect server failed: %w", err)
+			}
+		}()
+	}
+
+	// Start metrics reporting goroutine
+	go a.reportMetrics(ctx, 30*time.Second, func() {
+		logger.Info("Load shedding metrics", "stats", loadShedder.Stats())
+	})
+
+	// Wait for an interrupt signal, or a server failing, to shut down
+	exitCode := 0
+	select {
+	case <-waitForSignal():
+	case err := <-failed:
+		logger.Error("Server failed to start", "error", err)
+		exitCode = 1
+	}
+
+	logger.Info("Shutting down server...")
+
+	// Cancel context to stop background services
+	cancel()
+
+	// Give outstanding requests 30 seconds to complete
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer shutdownCancel()
+
+	if redirectServer != nil {
+		redirectServer.Shutdown(shutdownCtx)
+	}
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		logger.Error("Server forced to shutdown", "error", err)
+		return 1
+	}
+
+	logger.Info("Server exited gracefully")
+	return exitCode
+}
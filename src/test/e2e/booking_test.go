@@ -0,0 +1,270 @@
+//go:build integration
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_event "github.com/ojaswiii/booking-manager/src/internal/domain/event"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+func TestConcurrentBookingsForOneSeat(t *testing.T) {
+	const contenders = 25
+	eventID, tickets := createEvent(t, 5)
+	seat := tickets[0].ID
+
+	users := make([]uuid.UUID, contenders)
+	for i := range users {
+		users[i] = createUser(t)
+	}
+
+	before := processed(t)
+	var wg sync.WaitGroup
+	statuses := make([]int, contenders)
+	errs := make([]error, contenders)
+	for i, userID := range users {
+		wg.Add(1)
+		go func(i int, userID uuid.UUID) {
+			defer wg.Done()
+			statuses[i], errs[i] = submitBooking(userID, eventID, seat)
+		}(i, userID)
+	}
+	wg.Wait()
+
+	accepted := 0
+	for i, status := range statuses {
+		if errs[i] != nil {
+			t.Fatalf("booking request: %v", errs[i])
+		}
+		if status == http.StatusCreated {
+			accepted++
+		}
+	}
+	waitProcessed(t, before+int64(accepted))
+
+	var holders int
+	err := app.db.Get(&holders, `SELECT COUNT(*) FROM bookings b JOIN booking_tickets bt ON bt.booking_id = b.id WHERE bt.ticket_id = $1 AND b.status = 'pending'`, seat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holders != 1 {
+		t.Errorf("%d pending bookings hold the seat, want exactly 1", holders)
+	}
+	if status := ticketStatus(t, seat); status != domain_ticket.TicketStatusReserved {
+		t.Errorf("seat is %s, want reserved", status)
+	}
+	for _, ticket := range tickets[1:] {
+		if status := ticketStatus(t, ticket.ID); status != domain_ticket.TicketStatusAvailable {
+			t.Errorf("uncontested seat %d is %s, want available", ticket.SeatNumber, status)
+		}
+	}
+}
+
+func TestConcurrentBookingsForDistinctSeats(t *testing.T) {
+	const buyers = 10
+	eventID, tickets := createEvent(t, buyers)
+
+	users := make([]uuid.UUID, buyers)
+	for i := range users {
+		users[i] = createUser(t)
+	}
+
+	before := processed(t)
+	var wg sync.WaitGroup
+	statuses := make([]int, buyers)
+	errs := make([]error, buyers)
+	for i, userID := range users {
+		wg.Add(1)
+		go func(i int, userID uuid.UUID) {
+			defer wg.Done()
+			statuses[i], errs[i] = submitBooking(userID, eventID, tickets[i].ID)
+		}(i, userID)
+	}
+	wg.Wait()
+	for i := range statuses {
+		if errs[i] != nil || statuses[i] != http.StatusCreated {
+			t.Fatalf("booking request %d: status %d, error %v", i, statuses[i], errs[i])
+		}
+	}
+	waitProcessed(t, before+buyers)
+
+	for i, userID := range users {
+		bookings := userBookings(t, userID)
+		if len(bookings) != 1 {
+			t.Errorf("user %d has %d bookings, want 1", i, len(bookings))
+			continue
+		}
+		if got := bookings[0].TicketIDs; len(got) != 1 || got[0] != tickets[i].ID {
+			t.Errorf("user %d booked %v, want seat %d", i, got, tickets[i].SeatNumber)
+		}
+		if status := ticketStatus(t, tickets[i].ID); status != domain_ticket.TicketStatusReserved {
+			t.Errorf("seat %d is %s, want reserved", tickets[i].SeatNumber, status)
+		}
+	}
+}
+
+func TestConfirmBooking(t *testing.T) {
+	eventID, tickets := createEvent(t, 2)
+	userID := createUser(t)
+	booking := book(t, userID, eventID, tickets[0].ID, tickets[1].ID)
+
+	// Only the owner may confirm
+	if status := call(t, "POST", fmt.Sprintf("/api/bookings/%s/confirm", booking.ID), map[string]interface{}{"user_id": createUser(t)}, nil); status == http.StatusOK {
+		t.Fatal("another user confirmed the booking")
+	}
+
+	mustCall(t, http.StatusOK, "POST", fmt.Sprintf("/api/bookings/%s/confirm", booking.ID), map[string]interface{}{"user_id": userID}, nil)
+
+	bookings := userBookings(t, userID)
+	if len(bookings) != 1 || bookings[0].Status != domain_booking.BookingStatusConfirmed {
+		t.Fatalf("bookings after confirm: %+v", bookings)
+	}
+	for _, ticket := range tickets {
+		if status := ticketStatus(t, ticket.ID); status != domain_ticket.TicketStatusSold {
+			t.Errorf("seat %d is %s, want sold", ticket.SeatNumber, status)
+		}
+	}
+
+	// Confirmed bookings are final
+	if status := call(t, "POST", fmt.Sprintf("/api/bookings/%s/cancel", booking.ID), map[string]interface{}{"user_id": userID}, nil); status == http.StatusOK {
+		t.Error("confirmed booking was cancelled")
+	}
+
+	// The history has the booking and each seat being booked, then confirmed
+	var history []struct {
+		TicketID   *uuid.UUID `json:"ticket_id"`
+		FromStatus string     `json:"from_status"`
+		ToStatus   string     `json:"to_status"`
+	}
+	mustCall(t, http.StatusOK, "GET", fmt.Sprintf("/api/admin/bookings/%s/history", booking.ID), nil, &history)
+	if len(history) != 6 {
+		t.Fatalf("history has %d transitions, want 6: %+v", len(history), history)
+	}
+	if first, last := history[0], history[3]; first.TicketID != nil || first.ToStatus != "pending" || last.TicketID != nil || last.FromStatus != "pending" || last.ToStatus != "confirmed" {
+		t.Errorf("booking transitions: %+v then %+v", first, last)
+	}
+
+	// The event stream rebuilds the same booking
+	var events []struct {
+		Type    domain_booking.EventType `json:"type"`
+		Version int                      `json:"version"`
+	}
+	mustCall(t, http.StatusOK, "GET", fmt.Sprintf("/api/admin/bookings/%s/events", booking.ID), nil, &events)
+	want := []domain_booking.EventType{domain_booking.BookingCreated, domain_booking.TicketsReserved, domain_booking.BookingConfirmed}
+	if len(events) != len(want) {
+		t.Fatalf("stream has %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, event := range events {
+		if event.Type != want[i] || event.Version != i+1 {
+			t.Errorf("event %d: got %+v, want %s", i+1, event, want[i])
+		}
+	}
+	var rebuilt domain_booking.Booking
+	mustCall(t, http.StatusOK, "POST", fmt.Sprintf("/api/admin/bookings/%s/rebuild", booking.ID), nil, &rebuilt)
+	if rebuilt.Status != domain_booking.BookingStatusConfirmed || len(rebuilt.TicketIDs) != 2 {
+		t.Errorf("rebuilt booking: %+v", rebuilt)
+	}
+}
+
+func TestCancelBooking(t *testing.T) {
+	eventID, tickets := createEvent(t, 1)
+	userID := createUser(t)
+	booking := book(t, userID, eventID, tickets[0].ID)
+
+	mustCall(t, http.StatusOK, "POST", fmt.Sprintf("/api/bookings/%s/cancel", booking.ID), map[string]interface{}{"user_id": userID}, nil)
+
+	bookings := userBookings(t, userID)
+	if len(bookings) != 1 || bookings[0].Status != domain_booking.BookingStatusCancelled {
+		t.Fatalf("bookings after cancel: %+v", bookings)
+	}
+	if status := ticketStatus(t, tickets[0].ID); status != domain_ticket.TicketStatusAvailable {
+		t.Errorf("seat is %s after cancellation, want available", status)
+	}
+}
+
+func TestExpirePendingBookings(t *testing.T) {
+	eventID, tickets := createEvent(t, 2)
+	userID := createUser(t)
+	stale := book(t, userID, eventID, tickets[0].ID)
+	fresh := book(t, createUser(t), eventID, tickets[1].ID)
+
+	// Age the first hold past its expiry instead of waiting it out
+	if _, err := app.db.Exec(`UPDATE bookings SET expires_at = $2 WHERE id = $1`, stale.ID, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := app.usecases.Booking.ExpirePendingBookings(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	var status domain_booking.BookingStatus
+	if err := app.db.Get(&status, `SELECT status FROM bookings WHERE id = $1`, stale.ID); err != nil {
+		t.Fatal(err)
+	}
+	if status != domain_booking.BookingStatusExpired {
+		t.Errorf("stale booking is %s, want expired", status)
+	}
+	if status := ticketStatus(t, tickets[0].ID); status != domain_ticket.TicketStatusAvailable {
+		t.Errorf("expired seat is %s, want available", status)
+	}
+
+	if err := app.db.Get(&status, `SELECT status FROM bookings WHERE id = $1`, fresh.ID); err != nil {
+		t.Fatal(err)
+	}
+	if status != domain_booking.BookingStatusPending {
+		t.Errorf("unexpired booking is %s, want pending", status)
+	}
+	if status := ticketStatus(t, tickets[1].ID); status != domain_ticket.TicketStatusReserved {
+		t.Errorf("unexpired seat is %s, want reserved", status)
+	}
+}
+
+func TestEventCacheFollowsStatusChanges(t *testing.T) {
+	eventID, _ := createEvent(t, 1)
+
+	// Reading the event and the listing warms both cache entries
+	var event domain_event.Event
+	mustCall(t, http.StatusOK, "GET", fmt.Sprintf("/api/events/%s", eventID), nil, &event)
+	var listing []*domain_event.Event
+	mustCall(t, http.StatusOK, "GET", "/api/events", nil, &listing)
+
+	mustCall(t, http.StatusOK, "PUT", fmt.Sprintf("/api/admin/events/%s/status", eventID), map[string]string{"status": "archived"}, nil)
+
+	mustCall(t, http.StatusOK, "GET", fmt.Sprintf("/api/events/%s", eventID), nil, &event)
+	if event.Status != domain_event.EventStatusArchived {
+		t.Errorf("API returns status %s, want archived", event.Status)
+	}
+
+	version, err := app.repos.EventCache.Version(context.Background())
+	if err != nil {
+		t.Fatalf("reading cache version: %v", err)
+	}
+	cached, err := app.repos.EventCache.GetByID(context.Background(), version, eventID)
+	if err != nil {
+		t.Fatalf("event missing from cache: %v", err)
+	}
+	if cached.Status != domain_event.EventStatusArchived {
+		t.Errorf("cache holds status %s, want archived", cached.Status)
+	}
+
+	mustCall(t, http.StatusOK, "GET", "/api/events", nil, &listing)
+	for _, e := range listing {
+		if e.ID == eventID && e.Status != domain_event.EventStatusArchived {
+			t.Errorf("listing shows status %s, want archived", e.Status)
+		}
+	}
+
+	// Archived events no longer take bookings
+	if status := requestBooking(t, createUser(t), eventID, uuid.New()); status != http.StatusConflict {
+		t.Errorf("booking an archived event: status %d, want %d", status, http.StatusConflict)
+	}
+}
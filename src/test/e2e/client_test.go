@@ -0,0 +1,183 @@
+//go:build integration
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+
+	"github.com/google/uuid"
+)
+
+// call sends body as JSON and decodes the response into out when given,
+// returning the status code
+func call(t *testing.T, method, path string, body, out interface{}) int {
+	t.Helper()
+	status, err := send(method, path, body, out)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	return status
+}
+
+// send is call without a *testing.T, for use from other goroutines
+func send(method, path string, body, out interface{}) (int, error) {
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			return 0, err
+		}
+	}
+	req, err := http.NewRequest(method, app.server.URL+path, &payload)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := app.server.Client().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode < 300 {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return resp.StatusCode, fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// mustCall is call that fails the test unless the response has the wanted status
+func mustCall(t *testing.T, want int, method, path string, body, out interface{}) {
+	t.Helper()
+	if got := call(t, method, path, body, out); got != want {
+		t.Fatalf("%s %s: status %d, want %d", method, path, got, want)
+	}
+}
+
+// createUser registers a user with a unique email
+func createUser(t *testing.T) uuid.UUID {
+	t.Helper()
+	var resp usecase.CreateUserResponse
+	mustCall(t, http.StatusCreated, "POST", "/api/users", usecase.CreateUserRequest{
+		Email:    fmt.Sprintf("fan-%s@example.com", uuid.NewString()),
+		Name:     "E2E Fan",
+		Password: "e2e password",
+	}, &resp)
+	return resp.UserID
+}
+
+// createEvent publishes an on-sale event and returns it with its tickets in seat order
+func createEvent(t *testing.T, seats int) (uuid.UUID, []*domain_ticket.Ticket) {
+	t.Helper()
+	var resp usecase.CreateEventResponse
+	mustCall(t, http.StatusCreated, "POST", "/api/events", usecase.CreateEventRequest{
+		Name:       "E2E Event " + uuid.NewString()[:8],
+		Artist:     "The Testers",
+		Venue:      "Container Hall",
+		Date:       time.Now().Add(30 * 24 * time.Hour).UTC().Format(time.RFC3339),
+		TotalSeats: seats,
+		Price:      50,
+		Status:     "published",
+	}, &resp)
+
+	var tickets []*domain_ticket.Ticket
+	mustCall(t, http.StatusOK, "GET", fmt.Sprintf("/api/events/%s/tickets", resp.EventID), nil, &tickets)
+	if len(tickets) != seats {
+		t.Fatalf("event has %d tickets, want %d", len(tickets), seats)
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].SeatNumber < tickets[j].SeatNumber })
+	return resp.EventID, tickets
+}
+
+// requestBooking submits a booking; it is created asynchronously
+func requestBooking(t *testing.T, userID, eventID uuid.UUID, ticketIDs ...uuid.UUID) int {
+	t.Helper()
+	status, err := submitBooking(userID, eventID, ticketIDs...)
+	if err != nil {
+		t.Fatalf("booking request: %v", err)
+	}
+	return status
+}
+
+// submitBooking is requestBooking for use from other goroutines
+func submitBooking(userID, eventID uuid.UUID, ticketIDs ...uuid.UUID) (int, error) {
+	return send("POST", "/api/bookings", usecase.CreateBookingRequest{
+		UserID:    userID,
+		EventID:   eventID,
+		TicketIDs: ticketIDs,
+	}, nil)
+}
+
+// book submits a booking and waits for the processor to create it
+func book(t *testing.T, userID, eventID uuid.UUID, ticketIDs ...uuid.UUID) *domain_booking.Booking {
+	t.Helper()
+	before := processed(t)
+	if status := requestBooking(t, userID, eventID, ticketIDs...); status != http.StatusCreated {
+		t.Fatalf("booking request: status %d", status)
+	}
+	waitProcessed(t, before+1)
+
+	bookings := userBookings(t, userID)
+	for _, b := range bookings {
+		if b.EventID == eventID {
+			return b
+		}
+	}
+	t.Fatalf("no booking created for user %s", userID)
+	return nil
+}
+
+// userBookings lists a user's bookings through the API
+func userBookings(t *testing.T, userID uuid.UUID) []*domain_booking.Booking {
+	t.Helper()
+	var bookings []*domain_booking.Booking
+	mustCall(t, http.StatusOK, "GET", fmt.Sprintf("/api/users/%s/bookings", userID), nil, &bookings)
+	return bookings
+}
+
+// processed returns how many queued booking requests have finished, successfully or not
+func processed(t *testing.T) int64 {
+	t.Helper()
+	var stats struct {
+		Successful int64 `json:"successful_bookings"`
+		Failed     int64 `json:"failed_bookings"`
+	}
+	mustCall(t, http.StatusOK, "GET", "/api/bookings/stats", nil, &stats)
+	return stats.Successful + stats.Failed
+}
+
+// waitProcessed blocks until at least n booking requests have finished
+func waitProcessed(t *testing.T, n int64) {
+	t.Helper()
+	err := waitFor(context.Background(), 30*time.Second, func() error {
+		if got := processed(t); got < n {
+			return fmt.Errorf("%d of %d booking requests processed", got, n)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// ticketStatus reads a ticket's status straight from the database
+func ticketStatus(t *testing.T, ticketID uuid.UUID) domain_ticket.TicketStatus {
+	t.Helper()
+	var status domain_ticket.TicketStatus
+	if err := app.db.Get(&status, `SELECT status FROM tickets WHERE id = $1`, ticketID); err != nil {
+		t.Fatalf("ticket %s: %v", ticketID, err)
+	}
+	return status
+}
@@ -0,0 +1,100 @@
+//go:build integration
+
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// container is a throwaway Docker container published on a random local port
+type container struct {
+	id   string
+	host string
+	port string
+}
+
+// startContainer runs image detached with the given environment and returns
+// once the container port accepts TCP connections
+func startContainer(ctx context.Context, image, port string, env ...string) (*container, error) {
+	args := []string{"run", "--detach", "--rm", "--publish", "127.0.0.1::" + port}
+	for _, e := range env {
+		args = append(args, "--env", e)
+	}
+	args = append(args, image)
+
+	id, err := docker(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", image, err)
+	}
+	c := &container{id: id}
+
+	mapped, err := docker(ctx, "port", id, port+"/tcp")
+	if err != nil {
+		c.stop()
+		return nil, fmt.Errorf("failed to look up port of %s: %w", image, err)
+	}
+	// "docker port" prints one mapping per line, e.g. 127.0.0.1:49153
+	host, hostPort, err := net.SplitHostPort(strings.SplitN(mapped, "\n", 2)[0])
+	if err != nil {
+		c.stop()
+		return nil, fmt.Errorf("unexpected port mapping %q: %w", mapped, err)
+	}
+	c.host, c.port = host, hostPort
+
+	if err := waitFor(ctx, 30*time.Second, func() error {
+		conn, err := net.DialTimeout("tcp", net.JoinHostPort(c.host, c.port), time.Second)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}); err != nil {
+		c.stop()
+		return nil, fmt.Errorf("%s did not start listening: %w", image, err)
+	}
+
+	return c, nil
+}
+
+// stop removes the container; --rm discards its volumes with it
+func (c *container) stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	docker(ctx, "rm", "--force", c.id)
+}
+
+// docker runs the docker CLI and returns its trimmed output
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// waitFor retries check until it succeeds or the timeout elapses
+func waitFor(ctx context.Context, timeout time.Duration, check func() error) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(200 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,154 @@
+//go:build integration
+
+// Package e2e runs booking scenarios against the full HTTP stack backed by
+// real Postgres and Redis containers. Run with:
+//
+//	go test -tags integration ./src/test/e2e/...
+//
+// Docker must be available; each run starts fresh containers and removes them afterwards.
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/middlewares"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/internal/usecase"
+	"github.com/ojaswiii/booking-manager/src/migrations"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/database"
+	"github.com/ojaswiii/booking-manager/src/utils/notify"
+	"github.com/ojaswiii/booking-manager/src/utils/payments"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Images the suite runs against
+const (
+	postgresImage = "postgres:15-alpine"
+	redisImage    = "redis:7-alpine"
+)
+
+// stack is the running application shared by every test in the package
+type stack struct {
+	server   *httptest.Server
+	db       *sqlx.DB
+	repos    *repository.RepositoryContainer
+	usecases *usecase.UsecaseContainer
+}
+
+var app *stack
+
+func TestMain(m *testing.M) {
+	if _, err := exec.LookPath("docker"); err != nil {
+		fmt.Fprintln(os.Stderr, "e2e: docker is required to run the integration suite")
+		os.Exit(1)
+	}
+
+	code, err := run(m)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "e2e:", err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+// run starts the dependencies and the application, runs the tests and tears everything down
+func run(m *testing.M) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	pg, err := startContainer(ctx, postgresImage, "5432",
+		"POSTGRES_USER=booking", "POSTGRES_PASSWORD=booking", "POSTGRES_DB=booking_e2e")
+	if err != nil {
+		return 0, err
+	}
+	defer pg.stop()
+
+	rd, err := startContainer(ctx, redisImage, "6379")
+	if err != nil {
+		return 0, err
+	}
+	defer rd.stop()
+
+	config, err := utils.LoadConfig()
+	if err != nil {
+		return 0, err
+	}
+	config.DBHost, config.DBPort = pg.host, pg.port
+	config.DBUser, config.DBPassword, config.DBName, config.DBSSLMode = "booking", "booking", "booking_e2e", "disable"
+	config.RedisHost, config.RedisPort, config.RedisPassword, config.RedisDB = rd.host, rd.port, "", 0
+	config.TenantIsolation, config.TenantIDs = "", nil
+	config.NotifyProvider = "log"
+	config.OutboxKafkaBrokers = nil
+	// Races are asserted on outcomes, so load shedding must not turn requests away
+	config.BookingSLAShedEnabled = false
+
+	logger := utils.NewLogger()
+
+	// Postgres accepts connections briefly before its init scripts finish
+	var pgClient *database.PostgresClient
+	if err := waitFor(ctx, time.Minute, func() error {
+		pgClient, err = database.NewPostgresClient(config, logger)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	defer pgClient.Close()
+
+	migrator, err := database.NewMigrator(pgClient.DB, migrations.FS, logger)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		return 0, err
+	}
+
+	redisClient, err := database.NewRedisClient(config, logger)
+	if err != nil {
+		return 0, err
+	}
+	defer redisClient.Close()
+	if !redisClient.Breaker.Available() {
+		return 0, fmt.Errorf("redis is not reachable")
+	}
+
+	repos := repository.NewRepositoryContainer(pgClient.DB, redisClient.Client, repository.TenantIsolationNone, nil, repository.TxRetryPolicy{MaxAttempts: 3, Backoff: 10 * time.Millisecond}, nil, pgClient.Queries, repository.DefaultCacheTTLs())
+	notifier, err := notify.NewNotifier(config, logger)
+	if err != nil {
+		return 0, err
+	}
+	provider, err := payments.NewProvider(config, logger)
+	if err != nil {
+		return 0, err
+	}
+	overload, err := usecase.NewBookingOverloadPolicy(config, logger)
+	if err != nil {
+		return 0, err
+	}
+	usecases := usecase.NewUsecaseContainer(repos, notifier, provider, overload, nil, config, logger)
+	defer usecases.Booking.Shutdown()
+
+	router := rest.NewRestContainer(usecases, logger).Router.SetupRoutes(middlewares.TenantPolicy{
+		Header:   config.TenantHeader,
+		Allowed:  config.TenantIDs,
+		Required: config.IsMultiTenant(),
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	app = &stack{
+		server:   server,
+		db:       pgClient.DB,
+		repos:    repos,
+		usecases: usecases,
+	}
+	return m.Run(), nil
+}
@@ -0,0 +1,103 @@
+//go:build integration
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+
+	"github.com/google/uuid"
+)
+
+func TestTicketStatusChangesBindEveryID(t *testing.T) {
+	_, tickets := createEvent(t, 103)
+	ctx := context.Background()
+	repo := app.repos.Ticket
+
+	// Slices of 1, 2 and 100 tickets, none overlapping
+	for _, ids := range [][]*domain_ticket.Ticket{tickets[:1], tickets[1:3], tickets[3:]} {
+		ticketIDs := make([]uuid.UUID, len(ids))
+		for i, ticket := range ids {
+			ticketIDs[i] = ticket.ID
+		}
+
+		t.Run(fmt.Sprintf("%d tickets", len(ticketIDs)), func(t *testing.T) {
+			expectStatus := func(want domain_ticket.TicketStatus) {
+				t.Helper()
+				for _, id := range ticketIDs {
+					if status := ticketStatus(t, id); status != want {
+						t.Fatalf("ticket %s is %s, want %s", id, status, want)
+					}
+				}
+			}
+
+			if err := repo.ReserveTickets(ctx, ticketIDs); err != nil {
+				t.Fatalf("reserve: %v", err)
+			}
+			expectStatus(domain_ticket.TicketStatusReserved)
+			if err := repo.ReserveTickets(ctx, ticketIDs); err == nil {
+				t.Fatal("reserving reserved tickets again succeeded")
+			}
+
+			if err := repo.ReleaseTickets(ctx, ticketIDs); err != nil {
+				t.Fatalf("release: %v", err)
+			}
+			expectStatus(domain_ticket.TicketStatusAvailable)
+
+			if err := repo.ReserveTickets(ctx, ticketIDs); err != nil {
+				t.Fatalf("reserve after release: %v", err)
+			}
+			if err := repo.ConfirmTickets(ctx, ticketIDs); err != nil {
+				t.Fatalf("confirm: %v", err)
+			}
+			expectStatus(domain_ticket.TicketStatusSold)
+		})
+	}
+}
+
+func TestFailedReservationRollsBackBooking(t *testing.T) {
+	eventID, tickets := createEvent(t, 2)
+	ctx := context.Background()
+
+	// Another booking takes the second seat first
+	if err := app.repos.Ticket.ReserveTickets(ctx, []uuid.UUID{tickets[1].ID}); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	booking := &domain_booking.Booking{
+		ID:          uuid.New(),
+		UserID:      createUser(t),
+		EventID:     eventID,
+		TicketIDs:   []uuid.UUID{tickets[0].ID, tickets[1].ID},
+		Status:      domain_booking.BookingStatusPending,
+		TotalAmount: 100,
+		Currency:    "USD",
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		ExpiresAt:   now.Add(15 * time.Minute),
+	}
+	err := app.repos.Transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := app.repos.Booking.Create(ctx, booking); err != nil {
+			return err
+		}
+		return app.repos.Ticket.ReserveTickets(ctx, booking.TicketIDs)
+	})
+	if !errors.Is(err, domain.ErrConflict) {
+		t.Fatalf("reserving a taken seat: got %v, want ErrConflict", err)
+	}
+
+	if _, err := app.repos.Booking.GetByID(ctx, booking.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("booking after rollback: got %v, want ErrNotFound", err)
+	}
+	if status := ticketStatus(t, tickets[0].ID); status != domain_ticket.TicketStatusAvailable {
+		t.Errorf("free seat is %s after rollback, want available", status)
+	}
+}
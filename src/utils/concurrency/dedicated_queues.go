@@ -0,0 +1,147 @@
+package concurrency
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DedicatedQueueConfig sets queues aside for high-demand events, so an
+// on-sale does not slow bookings for the events sharing its hashed queue
+type DedicatedQueueConfig struct {
+	EventIDs []uuid.UUID   // always served from a queue of their own
+	HotSlots int           // further queues for events detected as hot
+	HotRate  float64       // requests per second at which an event is hot; 0 disables detection
+	Window   time.Duration // how long request rates are measured over
+}
+
+// QueueChange is an event moved onto or off a dedicated queue
+type QueueChange struct {
+	EventID uuid.UUID
+	Queue   int
+	Rate    float64 // requests per second over the last window
+	Moved   bool    // onto the queue; false when it was released
+}
+
+// dedicatedQueues assigns events to the queues after the shared ones.
+// Configured events keep theirs; hot events hold one while their request
+// rate stays at the threshold, and give it back once it has dropped and
+// the queue is empty.
+type dedicatedQueues struct {
+	config DedicatedQueueConfig
+
+	mu          sync.Mutex
+	assigned    map[uuid.UUID]int // queue index by event
+	hot         map[uuid.UUID]bool
+	free        []int // hot queues not assigned
+	counts      map[uuid.UUID]int
+	windowStart time.Time
+}
+
+func newDedicatedQueues(first int, config DedicatedQueueConfig) *dedicatedQueues {
+	if config.Window <= 0 {
+		config.Window = 10 * time.Second
+	}
+	d := &dedicatedQueues{
+		config:      config,
+		assigned:    make(map[uuid.UUID]int),
+		hot:         make(map[uuid.UUID]bool),
+		counts:      make(map[uuid.UUID]int),
+		windowStart: time.Now(),
+	}
+	next := first
+	for _, eventID := range config.EventIDs {
+		if _, ok := d.assigned[eventID]; ok {
+			continue
+		}
+		d.assigned[eventID] = next
+		next++
+	}
+	for i := 0; i < config.HotSlots; i++ {
+		d.free = append(d.free, next+i)
+	}
+	return d
+}
+
+// count returns how many queues are set aside, configured and hot
+func (d *dedicatedQueues) count() int {
+	return len(d.assigned) - len(d.hot) + d.config.HotSlots
+}
+
+// queue returns the dedicated queue serving an event, if it has one
+func (d *dedicatedQueues) queue(eventID uuid.UUID) (int, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	index, ok := d.assigned[eventID]
+	return index, ok
+}
+
+// observe counts a request for an event. Once a window has passed, events
+// at the hot rate are moved onto free hot queues, and hot events below it
+// whose queue is empty are released.
+func (d *dedicatedQueues) observe(eventID uuid.UUID, now time.Time, empty func(index int) bool) []QueueChange {
+	if d.config.HotRate <= 0 || d.config.HotSlots == 0 {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.counts[eventID]++
+
+	elapsed := now.Sub(d.windowStart)
+	if elapsed < d.config.Window {
+		return nil
+	}
+	rates := make(map[uuid.UUID]float64, len(d.counts))
+	for id, count := range d.counts {
+		rates[id] = float64(count) / elapsed.Seconds()
+	}
+	d.counts = make(map[uuid.UUID]int)
+	d.windowStart = now
+
+	var changes []QueueChange
+	for id := range d.hot {
+		index := d.assigned[id]
+		if rates[id] >= d.config.HotRate || !empty(index) {
+			continue
+		}
+		delete(d.hot, id)
+		delete(d.assigned, id)
+		d.free = append(d.free, index)
+		changes = append(changes, QueueChange{EventID: id, Queue: index, Rate: rates[id]})
+	}
+	for id, rate := range rates {
+		if len(d.free) == 0 {
+			break
+		}
+		if _, ok := d.assigned[id]; ok || rate < d.config.HotRate {
+			continue
+		}
+		index := d.free[0]
+		d.free = d.free[1:]
+		d.assigned[id] = index
+		d.hot[id] = true
+		changes = append(changes, QueueChange{EventID: id, Queue: index, Rate: rate, Moved: true})
+	}
+	return changes
+}
+
+// Stats returns the events on dedicated queues for reporting
+func (d *dedicatedQueues) Stats() map[string]interface{} {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	events := make(map[string]interface{}, len(d.assigned))
+	for id, index := range d.assigned {
+		events[id.String()] = map[string]interface{}{
+			"queue": index,
+			"hot":   d.hot[id],
+		}
+	}
+	return map[string]interface{}{
+		"events":          events,
+		"free_hot_queues": len(d.free),
+		"hot_rate":        d.config.HotRate,
+	}
+}
@@ -0,0 +1,44 @@
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestHotEventsGetDedicatedQueueUntilTheyCoolDown(t *testing.T) {
+	configured, hot, quiet := uuid.New(), uuid.New(), uuid.New()
+	d := newDedicatedQueues(3, DedicatedQueueConfig{EventIDs: []uuid.UUID{configured}, HotSlots: 1, HotRate: 5, Window: time.Second})
+	if d.count() != 2 {
+		t.Fatalf("set aside %d queues, want one configured and one hot", d.count())
+	}
+	if index, ok := d.queue(configured); !ok || index != 3 {
+		t.Fatalf("configured event on queue %d (%v), want 3", index, ok)
+	}
+
+	empty := func(int) bool { return true }
+	start := d.windowStart
+	for i := 0; i < 10; i++ {
+		d.observe(hot, start, empty)
+	}
+	d.observe(quiet, start, empty)
+	changes := d.observe(quiet, start.Add(time.Second), empty)
+	if len(changes) != 1 || changes[0].EventID != hot || !changes[0].Moved {
+		t.Fatalf("changes %+v, want the hot event moved", changes)
+	}
+	if index, ok := d.queue(hot); !ok || index != 4 {
+		t.Fatalf("hot event on queue %d (%v), want 4", index, ok)
+	}
+	if _, ok := d.queue(quiet); ok {
+		t.Fatal("quiet event should stay on its shared queue")
+	}
+
+	changes = d.observe(quiet, start.Add(2*time.Second), empty)
+	if len(changes) != 1 || changes[0].EventID != hot || changes[0].Moved {
+		t.Fatalf("changes %+v, want the hot event released", changes)
+	}
+	if _, ok := d.queue(configured); !ok {
+		t.Fatal("configured event should keep its queue")
+	}
+}
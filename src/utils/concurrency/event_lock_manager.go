@@ -27,8 +27,9 @@ type EventLockManager struct {
 	maxIdle       time.Duration
 }
 
-// NewEventLockManager creates a new event lock manager with automatic cleanup
-func NewEventLockManager(ttl, maxIdle time.Duration) *EventLockManager {
+// NewEventLockManager creates a new event lock manager that removes expired
+// and idle locks every cleanupInterval
+func NewEventLockManager(ttl, maxIdle, cleanupInterval time.Duration) *EventLockManager {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	elm := &EventLockManager{
@@ -37,7 +38,7 @@ func NewEventLockManager(ttl, maxIdle time.Duration) *EventLockManager {
 		maxIdle:       maxIdle,
 		ctx:           ctx,
 		cancel:        cancel,
-		cleanupTicker: time.NewTicker(1 * time.Minute), // Cleanup every minute
+		cleanupTicker: time.NewTicker(cleanupInterval),
 	}
 
 	// Start background cleanup
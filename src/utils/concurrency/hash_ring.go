@@ -0,0 +1,62 @@
+package concurrency
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math"
+	"sort"
+)
+
+// ringReplicas is how many points each queue has on the ring. More points
+// spread events more evenly between queues.
+const ringReplicas = 128
+
+// hashRing assigns keys to queues by consistent hashing. The ring is built
+// once at startup; a different queue count on the next start only moves the
+// keys the added or removed queues gain or lose.
+type hashRing struct {
+	points []ringPoint // sorted by hash
+}
+
+type ringPoint struct {
+	hash  uint32
+	queue int
+}
+
+// newHashRing places queues 0 to queueCount-1 on the ring
+func newHashRing(queueCount int) *hashRing {
+	r := &hashRing{}
+	for queue := 0; queue < queueCount; queue++ {
+		for i := 0; i < ringReplicas; i++ {
+			hash := crc32.ChecksumIEEE([]byte(fmt.Sprintf("queue-%d-%d", queue, i)))
+			r.points = append(r.points, ringPoint{hash: hash, queue: queue})
+		}
+	}
+	sort.Slice(r.points, func(i, j int) bool { return r.points[i].hash < r.points[j].hash })
+	return r
+}
+
+// get returns the queue owning a key: that of the first point at or after
+// the key's hash, wrapping around
+func (r *hashRing) get(key []byte) int {
+	if len(r.points) == 0 {
+		return 0
+	}
+	hash := crc32.ChecksumIEEE(key)
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= hash })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].queue
+}
+
+// shares returns the fraction of the hash space each queue owns
+func (r *hashRing) shares() map[int]float64 {
+	shares := make(map[int]float64)
+	for i, point := range r.points {
+		// Each point owns the span from the point before it
+		previous := r.points[(i+len(r.points)-1)%len(r.points)].hash
+		shares[point.queue] += float64(point.hash-previous) / (math.MaxUint32 + 1)
+	}
+	return shares
+}
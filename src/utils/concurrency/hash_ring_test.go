@@ -0,0 +1,41 @@
+package concurrency
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHashRingMovesFewKeysWhenTheQueueCountGrows(t *testing.T) {
+	ring := newHashRing(3)
+	keys := make([]uuid.UUID, 3000)
+	before := make([]int, len(keys))
+	for i := range keys {
+		keys[i] = uuid.New()
+		before[i] = ring.get(keys[i][:])
+	}
+
+	ring = newHashRing(4)
+	moved := 0
+	for i, key := range keys {
+		if after := ring.get(key[:]); after != before[i] {
+			if after != 3 {
+				t.Fatalf("key moved from queue %d to %d, want only moves to the new queue", before[i], after)
+			}
+			moved++
+		}
+	}
+	// About a quarter should move to the new queue
+	if moved < len(keys)/8 || moved > len(keys)/2 {
+		t.Fatalf("%d of %d keys moved", moved, len(keys))
+	}
+
+	total := 0.0
+	for _, share := range ring.shares() {
+		total += share
+	}
+	if math.Abs(total-1) > 1e-9 {
+		t.Fatalf("shares add up to %v, want 1", total)
+	}
+}
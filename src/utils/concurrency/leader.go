@@ -0,0 +1,163 @@
+package concurrency
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
+)
+
+// LeaderElector runs singleton jobs on one instance at a time. Each job is
+// guarded by a lease the leader renews every third of its TTL; if the
+// leader dies, the lease expires and another instance takes the job over
+// within a TTL.
+type LeaderElector struct {
+	leases repository.LeaseRepository
+	holder string // this instance's name on the leases it holds
+	ttl    time.Duration
+	logger *utils.Logger
+
+	mu       sync.Mutex
+	leading  map[string]bool // jobs this instance leads, by tenant and name
+	acquired int64
+	lost     int64
+}
+
+// NewLeaderElector creates an elector that holds leases as holder
+func NewLeaderElector(leases repository.LeaseRepository, holder string, ttl time.Duration, logger *utils.Logger) *LeaderElector {
+	return &LeaderElector{
+		leases:  leases,
+		holder:  holder,
+		ttl:     ttl,
+		logger:  logger,
+		leading: make(map[string]bool),
+	}
+}
+
+// Run runs job whenever this instance holds the lease called name, until
+// ctx is cancelled. The job's context is cancelled if the lease is lost,
+// and Run waits for it to return before campaigning again.
+func (e *LeaderElector) Run(ctx context.Context, name string, job func(ctx context.Context)) {
+	key := name
+	if id := tenant.FromContext(ctx); id != "" {
+		key = id + "/" + name
+	}
+	ticker := time.NewTicker(e.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		if e.acquire(ctx, name) {
+			e.lead(ctx, key, name, job, ticker.C)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// lead runs job while the lease is renewed on each tick, returning once
+// the lease is lost, the job returns or ctx is cancelled
+func (e *LeaderElector) lead(ctx context.Context, key, name string, job func(ctx context.Context), renew <-chan time.Time) {
+	e.setLeading(key, true)
+	e.logger.Info("Acquired leadership", "job", key, "holder", e.holder)
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		job(jobCtx)
+	}()
+
+	held := true
+	for running := true; running; {
+		select {
+		case <-ctx.Done():
+			running = false
+		case <-done:
+			running = false
+		case <-renew:
+			held = e.acquire(ctx, name)
+			running = held
+		}
+	}
+	cancel()
+	<-done
+	e.setLeading(key, false)
+
+	if !held {
+		e.mu.Lock()
+		e.lost++
+		e.mu.Unlock()
+		e.logger.Warn("Lost leadership", "job", key, "holder", e.holder)
+		return
+	}
+
+	// Hand the lease back so another instance need not wait for it to
+	// expire. ctx may be done, so the release gets a context of its own.
+	releaseCtx, releaseCancel := context.WithTimeout(tenant.WithID(context.Background(), tenant.FromContext(ctx)), time.Second)
+	defer releaseCancel()
+	if err := e.leases.Release(releaseCtx, name, e.holder); err != nil {
+		e.logger.Warn("Failed to release leadership", "job", key, "error", err)
+	}
+}
+
+// acquire takes or renews the lease. A failure to reach the store counts
+// as not holding it, so a leader cut off from Redis stops before its lease
+// can pass to another instance.
+func (e *LeaderElector) acquire(ctx context.Context, name string) bool {
+	reqCtx, cancel := context.WithTimeout(ctx, e.ttl/3)
+	defer cancel()
+	held, err := e.leases.Acquire(reqCtx, name, e.holder, e.ttl)
+	if err != nil {
+		if ctx.Err() == nil {
+			e.logger.Warn("Failed to acquire leadership", "job", name, "error", err)
+		}
+		return false
+	}
+	return held
+}
+
+func (e *LeaderElector) setLeading(key string, leading bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if leading {
+		e.leading[key] = true
+		e.acquired++
+	} else {
+		delete(e.leading, key)
+	}
+}
+
+// Leading reports whether this instance currently runs the job, by tenant
+// and name as in Stats
+func (e *LeaderElector) Leading(key string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leading[key]
+}
+
+// Stats returns the jobs this instance leads and how often leadership has
+// changed hands
+func (e *LeaderElector) Stats() map[string]interface{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	leading := make([]string, 0, len(e.leading))
+	for key := range e.leading {
+		leading = append(leading, key)
+	}
+	sort.Strings(leading)
+	return map[string]interface{}{
+		"holder":      e.holder,
+		"ttl_seconds": e.ttl.Seconds(),
+		"leading":     leading,
+		"acquired":    e.acquired,
+		"lost":        e.lost,
+	}
+}
@@ -0,0 +1,96 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// memoryLeases keeps leases in a map, expiring them like Redis keys
+type memoryLeases struct {
+	mu      sync.Mutex
+	holders map[string]string
+	expires map[string]time.Time
+}
+
+func newMemoryLeases() *memoryLeases {
+	return &memoryLeases{holders: map[string]string{}, expires: map[string]time.Time{}}
+}
+
+func (m *memoryLeases) Acquire(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := m.holders[name]; ok && current != holder && time.Now().Before(m.expires[name]) {
+		return false, nil
+	}
+	m.holders[name] = holder
+	m.expires[name] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (m *memoryLeases) Release(ctx context.Context, name string, holder string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.holders[name] == holder {
+		delete(m.holders, name)
+	}
+	return nil
+}
+
+func TestLeaderElectorRunsJobOnOneInstanceAndFailsOver(t *testing.T) {
+	leases := newMemoryLeases()
+	logger := utils.NewLogger()
+
+	var running, overlap atomic.Int32
+	ran := make(chan string, 10)
+	job := func(holder string) func(ctx context.Context) {
+		return func(ctx context.Context) {
+			if running.Add(1) > 1 {
+				overlap.Add(1)
+			}
+			ran <- holder
+			<-ctx.Done()
+			running.Add(-1)
+		}
+	}
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	a := NewLeaderElector(leases, "a", 30*time.Millisecond, logger)
+	doneA := make(chan struct{})
+	go func() {
+		defer close(doneA)
+		a.Run(ctxA, "expiry", job("a"))
+	}()
+	if got := <-ran; got != "a" {
+		t.Fatalf("first leader: got %s, want a", got)
+	}
+
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	b := NewLeaderElector(leases, "b", 30*time.Millisecond, logger)
+	go b.Run(ctxB, "expiry", job("b"))
+
+	// b must keep waiting while a renews its lease
+	time.Sleep(100 * time.Millisecond)
+	if b.Leading("expiry") {
+		t.Fatal("b took over a lease a is still renewing")
+	}
+
+	cancelA()
+	<-doneA
+	select {
+	case got := <-ran:
+		if got != "b" {
+			t.Fatalf("after failover: got %s, want b", got)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("b did not take over after a stopped")
+	}
+	if overlap.Load() != 0 {
+		t.Fatal("job ran on two instances at once")
+	}
+}
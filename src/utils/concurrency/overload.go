@@ -0,0 +1,203 @@
+package concurrency
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// overloadRejectHold is how long after a rejection the policy still reports
+// that it is turning clients away, so the waiting room stays open to them
+const overloadRejectHold = 30 * time.Second
+
+// OverloadAction is what happens to a booking request once the queue is saturated
+type OverloadAction string
+
+const (
+	OverloadActionFallback OverloadAction = "fallback" // book synchronously on the legacy path
+	OverloadActionReject   OverloadAction = "reject"   // send the client to the waiting room
+)
+
+// ParseOverloadAction validates a configured overload action
+func ParseOverloadAction(s string) (OverloadAction, error) {
+	switch action := OverloadAction(s); action {
+	case OverloadActionFallback, OverloadActionReject:
+		return action, nil
+	}
+	return "", fmt.Errorf("unknown overload action %q", s)
+}
+
+// FullQueueAction is what queueing a booking request does when its queue
+// has no room
+type FullQueueAction string
+
+const (
+	FullQueueReject FullQueueAction = "reject" // fail at once
+	FullQueueBlock  FullQueueAction = "block"  // wait for room, up to a timeout
+)
+
+// ParseFullQueueAction validates a configured full queue action
+func ParseFullQueueAction(s string) (FullQueueAction, error) {
+	switch action := FullQueueAction(s); action {
+	case FullQueueReject, FullQueueBlock:
+		return action, nil
+	}
+	return "", fmt.Errorf("unknown full queue action %q", s)
+}
+
+// OverloadPolicyConfig defines when the booking queue counts as saturated
+// and what is done with requests that arrive while it is
+type OverloadPolicyConfig struct {
+	MaxQueueDepth int            // requests waiting in the event's queue; 0 disables the check
+	MaxQueueWait  time.Duration  // queue latency at the SLA percentile; 0 disables the check
+	Action        OverloadAction // applied once either limit is exceeded
+	MaxFallbacks  int            // concurrent synchronous bookings; further requests are rejected
+
+	FullQueue     FullQueueAction // what queueing does when the queue has no room
+	FullQueueWait time.Duration   // how long a blocked request waits for room
+}
+
+// QueueLoad describes the queue a booking request would join
+type QueueLoad struct {
+	Depth int
+	Wait  time.Duration
+	Full  bool // the enqueue itself failed
+}
+
+// OverloadDecision is the outcome of applying the policy to a request.
+// A zero decision means the request should be queued as usual.
+type OverloadDecision struct {
+	Action OverloadAction
+	Reason string
+}
+
+// OverloadPolicy decides how booking requests are handled when the async
+// queue cannot take them in time
+type OverloadPolicy struct {
+	config    OverloadPolicyConfig
+	fallbacks chan struct{}
+	logger    *utils.Logger
+
+	mu             sync.Mutex
+	saturated      bool
+	fallbackTotal  int64
+	rejectTotal    int64
+	rejectingUntil time.Time
+}
+
+// NewOverloadPolicy creates a new overload policy
+func NewOverloadPolicy(config OverloadPolicyConfig, logger *utils.Logger) *OverloadPolicy {
+	return &OverloadPolicy{
+		config:    config,
+		fallbacks: make(chan struct{}, config.MaxFallbacks),
+		logger:    logger,
+	}
+}
+
+// Decide applies the policy to the load of the queue a request would join.
+// A fallback decision holds a synchronous booking slot that the caller must
+// return with ReleaseFallback; when none is free the request is rejected.
+func (p *OverloadPolicy) Decide(load QueueLoad) OverloadDecision {
+	reason := p.saturation(load)
+	p.setSaturated(reason != "", reason)
+	if reason == "" {
+		return OverloadDecision{}
+	}
+
+	if p.config.Action == OverloadActionFallback {
+		select {
+		case p.fallbacks <- struct{}{}:
+			p.mu.Lock()
+			p.fallbackTotal++
+			p.mu.Unlock()
+			return OverloadDecision{Action: OverloadActionFallback, Reason: reason}
+		default:
+			reason += "; synchronous booking capacity exhausted"
+		}
+	}
+
+	p.mu.Lock()
+	p.rejectTotal++
+	p.rejectingUntil = time.Now().Add(overloadRejectHold)
+	p.mu.Unlock()
+	return OverloadDecision{Action: OverloadActionReject, Reason: reason}
+}
+
+// EnqueueWait returns how long a request may wait for room in a full queue
+// before the queue counts as full
+func (p *OverloadPolicy) EnqueueWait() time.Duration {
+	if p.config.FullQueue != FullQueueBlock {
+		return 0
+	}
+	return p.config.FullQueueWait
+}
+
+// ReleaseFallback returns the slot held by a fallback decision
+func (p *OverloadPolicy) ReleaseFallback() {
+	<-p.fallbacks
+}
+
+// Saturated reports whether the last request saw a saturated queue
+func (p *OverloadPolicy) Saturated() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.saturated
+}
+
+// Rejecting reports whether requests have recently been turned away
+func (p *OverloadPolicy) Rejecting() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return time.Now().Before(p.rejectingUntil)
+}
+
+// Stats returns overload metrics for reporting
+func (p *OverloadPolicy) Stats() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return map[string]interface{}{
+		"action":             p.config.Action,
+		"max_queue_depth":    p.config.MaxQueueDepth,
+		"max_queue_wait_ms":  p.config.MaxQueueWait.Milliseconds(),
+		"max_fallbacks":      p.config.MaxFallbacks,
+		"full_queue":         p.config.FullQueue,
+		"full_queue_wait_ms": p.EnqueueWait().Milliseconds(),
+		"active_fallbacks":   len(p.fallbacks),
+		"saturated":          p.saturated,
+		"fallback_total":     p.fallbackTotal,
+		"rejected_total":     p.rejectTotal,
+		"rejecting":          time.Now().Before(p.rejectingUntil),
+	}
+}
+
+// saturation returns why the queue is saturated, or "" if it is not
+func (p *OverloadPolicy) saturation(load QueueLoad) string {
+	switch {
+	case load.Full:
+		return "queue is full"
+	case p.config.MaxQueueDepth > 0 && load.Depth >= p.config.MaxQueueDepth:
+		return fmt.Sprintf("queue depth %d reached limit %d", load.Depth, p.config.MaxQueueDepth)
+	case p.config.MaxQueueWait > 0 && load.Wait > p.config.MaxQueueWait:
+		return fmt.Sprintf("queue wait %s exceeds limit %s", load.Wait, p.config.MaxQueueWait)
+	}
+	return ""
+}
+
+// setSaturated logs transitions so operators can see when the policy kicks in
+func (p *OverloadPolicy) setSaturated(saturated bool, reason string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if saturated == p.saturated {
+		return
+	}
+	p.saturated = saturated
+
+	if saturated {
+		p.logger.Warn("Booking queue saturated", "reason", reason, "action", p.config.Action)
+		return
+	}
+	p.logger.Info("Booking queue recovered")
+}
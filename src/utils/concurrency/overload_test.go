@@ -0,0 +1,35 @@
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+func TestOverloadPolicyFallsBackUntilCapacityIsExhausted(t *testing.T) {
+	policy := NewOverloadPolicy(OverloadPolicyConfig{
+		MaxQueueDepth: 10,
+		MaxQueueWait:  time.Second,
+		Action:        OverloadActionFallback,
+		MaxFallbacks:  1,
+	}, utils.NewLogger())
+
+	if d := policy.Decide(QueueLoad{Depth: 9, Wait: time.Second}); d.Action != "" {
+		t.Fatalf("unsaturated queue: got %q", d.Action)
+	}
+	if d := policy.Decide(QueueLoad{Depth: 10}); d.Action != OverloadActionFallback {
+		t.Fatalf("deep queue: got %q, want fallback", d.Action)
+	}
+	if d := policy.Decide(QueueLoad{Wait: 2 * time.Second}); d.Action != OverloadActionReject {
+		t.Fatalf("no fallback slot left: got %q, want reject", d.Action)
+	}
+	if !policy.Rejecting() {
+		t.Error("policy should report rejecting after a rejection")
+	}
+
+	policy.ReleaseFallback()
+	if d := policy.Decide(QueueLoad{Full: true}); d.Action != OverloadActionFallback {
+		t.Fatalf("slot released: got %q, want fallback", d.Action)
+	}
+}
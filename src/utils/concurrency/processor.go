@@ -2,35 +2,141 @@ package concurrency
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
 	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_deadletter "github.com/ojaswiii/booking-manager/src/internal/domain/deadletter"
+	domain_outbox "github.com/ojaswiii/booking-manager/src/internal/domain/outbox"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
 	"github.com/ojaswiii/booking-manager/src/internal/repository"
 	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/health"
+	"github.com/ojaswiii/booking-manager/src/utils/requestid"
+	"github.com/ojaswiii/booking-manager/src/utils/tenant"
 
 	"github.com/google/uuid"
 )
 
 // BookingProcessor handles concurrent booking processing
 type BookingProcessor struct {
-	bookingRepo repository.BookingRepository
-	ticketRepo  repository.TicketRepository
-	eventRepo   repository.EventRepository
-	userRepo    repository.UserRepository
-	logger      *utils.Logger
+	bookingRepo    repository.BookingRepository
+	ticketRepo     repository.TicketRepository
+	eventRepo      repository.EventRepository
+	userRepo       repository.UserRepository
+	outboxRepo     repository.OutboxRepository
+	transitionRepo repository.BookingTransitionRepository
+	streamRepo     repository.BookingEventRepository
+	deadLetters    repository.DeadLetterRepository
+	transactor     repository.Transactor
+	logger         *utils.Logger
 
 	// Concurrency components
 	queueManager *QueueManager
 	ticketLocks  *TicketLockManager
 	eventLocks   *EventLockManager
+	sla          *SLATracker
+	retry        RetryPolicy
+	workers      *workerPool
+	enqueueOnly  bool
+	lockCleanup  time.Duration
+	timeout      time.Duration
+	pricing      domain_booking.Pricing
+
+	// Called after a booking has been created and its tickets reserved, and
+	// once a request has failed for good
+	onCreated func(ctx context.Context, requestID string, booking *domain_booking.Booking)
+	onFailed  func(ctx context.Context, req BookingRequest, cause error)
+	metrics   ProcessorMetrics
+
+	// Context carrying the heartbeat the autoscaler beats, see Heartbeat,
+	// and how many requests the workers have finished
+	heartbeat atomic.Pointer[context.Context]
+	processed atomic.Int64
+
+	// Control. Background routines stop on stop and workers when the pool
+	// is stopped; ctx stays live until they have, so requests still being
+	// processed at shutdown can finish.
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stop     chan struct{}
+	wg       sync.WaitGroup
+	feedStop chan struct{} // stops reading durable queues, ahead of the workers
+	feeders  sync.WaitGroup
+	mu       sync.RWMutex
+	stats    BookingStats
+	draining bool
+	inFlight atomic.Int64
+
+	// Enqueues under way, which may be waiting for room in a full queue.
+	// They wait outside mu, so workers can make that room.
+	enqueuing sync.WaitGroup
+
+	// Failed requests waiting out their backoff, by request ID, and those
+	// whose backoff has passed that are being queued again
+	retryMu    sync.Mutex
+	retries    map[string]*pendingRetry
+	requeueing sync.WaitGroup
+	requeued   atomic.Int64
+}
+
+// pendingRetry is a failed request scheduled to be queued again
+type pendingRetry struct {
+	req   BookingRequest
+	err   error // why the last attempt failed
+	timer *time.Timer
+}
 
-	// Control
-	ctx    context.Context
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
-	mu     sync.RWMutex
-	stats  BookingStats
+// ProcessorMetrics hears of each request the processor takes up, and of
+// each attempt at one that created a booking or failed. Calls come from the
+// queue workers, so they must be quick.
+type ProcessorMetrics interface {
+	RequestReceived(ctx context.Context)
+	BookingSucceeded(ctx context.Context, booking *domain_booking.Booking)
+	BookingFailed(ctx context.Context)
+}
+
+// ErrDraining is returned for requests enqueued once shutdown has begun
+var ErrDraining = errors.New("booking processor is shutting down")
+
+// ErrRequestExpired is the cause given for requests whose deadline passed
+// before they were processed
+var ErrRequestExpired = errors.New("booking request expired before it was processed")
+
+// DrainReport says what became of the requests queued when shutdown began
+type DrainReport struct {
+	Pending  int // queued or being processed when shutdown began
+	Drained  int // processed before the deadline
+	Dropped  int // still queued at the deadline and abandoned
+	Released int // left pending in the durable queue for another instance
+	// dead-lettered because they were still queued, or waiting to be
+	// retried, at the deadline
+	DeadLettered int
+	Duration     time.Duration
+}
+
+// ProcessorConfig sizes the booking queues and sets how long the locks
+// taken while processing last
+type ProcessorConfig struct {
+	QueueCount          int
+	QueueBufferSize     int // requests each lane of a queue holds
+	MaxQueuedPerUser    int // requests one user may have waiting in a lane; 0 for no limit
+	TicketLockTTL       time.Duration
+	EventLockTTL        time.Duration
+	EventLockMaxIdle    time.Duration
+	LockCleanupInterval time.Duration // how often expired ticket and event locks are removed
+	ProcessingTimeout   time.Duration // how long one attempt at a booking may take; 0 for no limit
+	Pricing             domain_booking.Pricing
+	Dedicated           DedicatedQueueConfig
+	// Only queue requests, leaving other instances to process them from the
+	// durable queue. Ignored without one.
+	EnqueueOnly bool
 }
 
 // BookingStats holds booking statistics
@@ -38,6 +144,9 @@ type BookingStats struct {
 	TotalRequests      int64
 	SuccessfulBookings int64
 	FailedBookings     int64
+	RetriedRequests    int64
+	ExpiredRequests    int64 // skipped because their deadline had passed
+	DeadLettered       int64
 	QueueLength        int
 	ActiveLocks        int
 	StartTime          time.Time
@@ -49,31 +158,60 @@ func NewBookingProcessor(
 	ticketRepo repository.TicketRepository,
 	eventRepo repository.EventRepository,
 	userRepo repository.UserRepository,
+	outboxRepo repository.OutboxRepository,
+	transitionRepo repository.BookingTransitionRepository,
+	streamRepo repository.BookingEventRepository,
+	deadLetters repository.DeadLetterRepository,
+	transactor repository.Transactor,
+	sla *SLATracker,
+	durable *DurableQueue,
+	retry RetryPolicy,
+	config ProcessorConfig,
+	workers WorkerPoolConfig,
 	logger *utils.Logger,
 ) *BookingProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	// Initialize concurrency components
-	queueManager := NewQueueManager(3, 100, logger) // 3 queues, 100 buffer each
-	ticketLocks := NewTicketLockManager()
-	eventLocks := NewEventLockManager(30*time.Minute, 5*time.Minute) // 30min TTL, 5min max idle
+	queueManager := NewQueueManager(config.QueueCount, config.QueueBufferSize, config.MaxQueuedPerUser, config.Dedicated, logger)
+	if durable != nil {
+		queueManager = NewDurableQueueManager(config.QueueCount, config.QueueBufferSize, durable, config.Dedicated, logger)
+	}
+	ticketLocks := NewTicketLockManager(config.TicketLockTTL)
+	eventLocks := NewEventLockManager(config.EventLockTTL, config.EventLockMaxIdle, config.LockCleanupInterval)
 
 	bp := &BookingProcessor{
-		bookingRepo:  bookingRepo,
-		ticketRepo:   ticketRepo,
-		eventRepo:    eventRepo,
-		userRepo:     userRepo,
-		logger:       logger,
-		queueManager: queueManager,
-		ticketLocks:  ticketLocks,
-		eventLocks:   eventLocks,
-		ctx:          ctx,
-		cancel:       cancel,
+		bookingRepo:    bookingRepo,
+		ticketRepo:     ticketRepo,
+		eventRepo:      eventRepo,
+		userRepo:       userRepo,
+		outboxRepo:     outboxRepo,
+		transitionRepo: transitionRepo,
+		streamRepo:     streamRepo,
+		deadLetters:    deadLetters,
+		transactor:     transactor,
+		logger:         logger,
+		queueManager:   queueManager,
+		ticketLocks:    ticketLocks,
+		eventLocks:     eventLocks,
+		sla:            sla,
+		retry:          retry,
+		retries:        make(map[string]*pendingRetry),
+		ctx:            ctx,
+		cancel:         cancel,
+		enqueueOnly:    config.EnqueueOnly && durable != nil,
+		lockCleanup:    config.LockCleanupInterval,
+		timeout:        config.ProcessingTimeout,
+		pricing:        config.Pricing,
+		stop:           make(chan struct{}),
+		feedStop:       make(chan struct{}),
 		stats: BookingStats{
 			StartTime: time.Now(),
 		},
 	}
 
+	bp.workers = newWorkerPool(len(queueManager.Queues), workers, &bp.wg, bp.processQueue)
+
 	// Start background processors
 	bp.startProcessors()
 
@@ -82,58 +220,205 @@ func NewBookingProcessor(
 
 // startProcessors starts background processors for each queue
 func (bp *BookingProcessor) startProcessors() {
-	// Start processors for each queue
-	for i := 0; i < 3; i++ {
-		bp.wg.Add(1)
-		go bp.processQueue(i)
+	if bp.enqueueOnly {
+		bp.logger.Info("Booking processor only enqueuing; workers process the durable queues",
+			"queues", len(bp.queueManager.Queues))
+		return
+	}
+
+	// Start the minimum number of workers for each queue, and scale them
+	// with queue depth from there
+	bp.workers.start()
+	bp.wg.Add(1)
+	go bp.autoscaleWorkers()
+
+	// Feed each queue from the durable store
+	if bp.queueManager.Durable() {
+		for i := range bp.queueManager.Queues {
+			bp.feeders.Add(1)
+			go bp.feedQueue(i)
+		}
 	}
 
 	// Start cleanup routine
 	bp.wg.Add(1)
 	go bp.cleanupExpiredLocks()
 
-	bp.logger.Info("Booking processor started with 3 queue processors")
+	bp.logger.Info("Booking processor started",
+		"queues", len(bp.queueManager.Queues),
+		"min_workers_per_queue", bp.workers.config.MinWorkers,
+		"max_workers_per_queue", bp.workers.config.MaxWorkers,
+		"durable", bp.queueManager.Durable())
 }
 
-// processQueue processes requests from a specific queue
-func (bp *BookingProcessor) processQueue(queueIndex int) {
+// autoscaleWorkers adds workers to queues that are backing up and removes
+// them from queues that have gone idle
+func (bp *BookingProcessor) autoscaleWorkers() {
 	defer bp.wg.Done()
 
+	ticker := time.NewTicker(bp.workers.config.Interval)
+	defer ticker.Stop()
+
+	var processed int64
+	for {
+		select {
+		case <-bp.stop:
+			return
+		case <-ticker.C:
+		}
+
+		// Alive while the workers are idle or getting through requests;
+		// workers stuck on theirs let the heartbeat go stale
+		if done := bp.processed.Load(); done != processed || bp.inFlight.Load() == 0 {
+			processed = done
+			if ctx := bp.heartbeat.Load(); ctx != nil {
+				health.Beat(*ctx)
+			}
+		}
+
+		latency := bp.sla.Current()
+		for i := range bp.queueManager.Queues {
+			depth := bp.queueManager.Length(i)
+			switch bp.workers.scale(i, depth, latency, time.Now()) {
+			case 1:
+				bp.logger.Info("Added booking queue worker", "queue", i, "depth", depth, "latency", latency)
+			case -1:
+				bp.logger.Info("Removed idle booking queue worker", "queue", i)
+			}
+		}
+	}
+}
+
+// feedQueue moves requests from a durable queue into its local channel, one
+// at a time as the worker takes them. A request still in hand when feeding
+// stops stays pending in the store and is reclaimed later.
+func (bp *BookingProcessor) feedQueue(queueIndex int) {
+	defer bp.feeders.Done()
+
 	queue := bp.queueManager.Queues[queueIndex]
 
 	for {
 		select {
-		case req := <-queue:
-			bp.processBookingRequest(req)
-		case <-bp.ctx.Done():
+		case <-bp.feedStop:
 			return
+		default:
+		}
+
+		requests, err := bp.queueManager.Receive(bp.ctx, queueIndex)
+		if err != nil {
+			bp.logger.Error("Failed to read durable booking queue", "queue_index", queueIndex, "error", err)
+			select {
+			case <-bp.feedStop:
+				return
+			case <-time.After(time.Second):
+			}
+			continue
+		}
+
+		for _, req := range requests {
+			if !queue.Push(req, bp.feedStop) {
+				return
+			}
 		}
 	}
 }
 
-// processBookingRequest processes a single booking request
-func (bp *BookingProcessor) processBookingRequest(req BookingRequest) {
+// processQueue processes requests from a specific queue, high priority
+// first, until quit is closed
+func (bp *BookingProcessor) processQueue(queueIndex int, quit <-chan struct{}) {
+	queue := bp.queueManager.Queues[queueIndex]
+
+	for {
+		req, ok := queue.Next(quit)
+		if !ok {
+			return
+		}
+		bp.inFlight.Add(1)
+		if err := bp.processBookingRequest(req); err != nil {
+			bp.handleFailure(req, err)
+		}
+		bp.queueManager.Ack(bp.ctx, req)
+		bp.inFlight.Add(-1)
+		bp.processed.Add(1)
+	}
+}
+
+// processBookingRequest processes a single booking request, returning why
+// it failed. Failures that may pass on their own are marked retryable.
+func (bp *BookingProcessor) processBookingRequest(req BookingRequest) (err error) {
 	start := time.Now()
 
+	// Run every query in the tenant the request was made for, within the
+	// processing timeout
+	ctx := requestid.WithID(tenant.WithID(bp.ctx, req.TenantID), req.TraceID)
+	if bp.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, bp.timeout)
+		defer cancel()
+	}
+
+	// A panic fails this request only; the queue worker keeps running
+	defer func() {
+		if rec := recover(); rec != nil {
+			bp.releaseTickets(req.TicketIDs, req.UserID)
+			bp.logger.Error("Panic processing booking request",
+				"request_id", req.ID,
+				"panic", rec,
+				"stack", string(debug.Stack()),
+			)
+			bp.recordFailure(ctx)
+			err = fmt.Errorf("panic: %v", rec)
+		}
+	}()
+
+	// Queue-to-processed latency counts towards the SLA whatever the outcome
+	defer func() {
+		bp.sla.Observe(time.Since(req.Timestamp))
+	}()
+
 	bp.mu.Lock()
 	bp.stats.TotalRequests++
 	bp.mu.Unlock()
+	if bp.metrics != nil {
+		bp.metrics.RequestReceived(ctx)
+	}
+
+	// Nobody is waiting for a booking whose submitter has given up
+	if !req.Deadline.IsZero() && start.After(req.Deadline) {
+		bp.logger.Warn("Skipping expired booking request",
+			"request_id", req.ID,
+			"trace_id", req.TraceID,
+			"deadline", req.Deadline,
+			"queued_for", start.Sub(req.Timestamp))
+		bp.mu.Lock()
+		bp.stats.ExpiredRequests++
+		bp.mu.Unlock()
+		if bp.onFailed != nil {
+			bp.onFailed(ctx, req, ErrRequestExpired)
+		}
+		return nil
+	}
 
 	// Validate user exists
-	user, err := bp.userRepo.GetByID(bp.ctx, req.UserID)
+	user, err := bp.userRepo.GetByID(ctx, req.UserID)
 	if err != nil {
 		bp.logger.Error("User not found", "user_id", req.UserID, "error", err)
-		bp.recordFailure()
-		return
+		bp.recordFailure(ctx)
+		return unlessNotFound(fmt.Errorf("user not found: %w", err))
 	}
 	_ = user
 
-	// Validate event exists
-	_, err = bp.eventRepo.GetByID(bp.ctx, req.EventID)
+	// Validate event exists and is open for booking
+	event, err := bp.eventRepo.GetByID(ctx, req.EventID)
 	if err != nil {
 		bp.logger.Error("Event not found", "event_id", req.EventID, "error", err)
-		bp.recordFailure()
-		return
+		bp.recordFailure(ctx)
+		return unlessNotFound(fmt.Errorf("event not found: %w", err))
+	}
+	if !event.IsBookable() && !(req.PresaleAccess && event.IsPresaleOpen()) {
+		bp.logger.Warn("Event is not open for booking", "event_id", req.EventID, "reason", event.NotBookableReason())
+		bp.recordFailure(ctx)
+		return fmt.Errorf("event is not open for booking: %s", event.NotBookableReason())
 	}
 
 	// Try to lock all requested tickets
@@ -146,52 +431,226 @@ func (bp *BookingProcessor) processBookingRequest(req BookingRequest) {
 			// Failed to lock ticket, release already locked tickets
 			bp.releaseTickets(lockedTickets, req.UserID)
 			bp.logger.Warn("Failed to lock ticket", "ticket_id", ticketID, "user_id", req.UserID)
-			bp.recordFailure()
-			return
+			bp.recordFailure(ctx)
+			return retryable(fmt.Errorf("ticket %s is locked by another request", ticketID))
 		}
 	}
 
+	// Read the current prices of the locked tickets
+	tickets, err := bp.loadTickets(ctx, lockedTickets)
+	if err != nil {
+		bp.releaseTickets(lockedTickets, req.UserID)
+		bp.logger.Warn("Ticket not available", "user_id", req.UserID, "error", err)
+		bp.recordFailure(ctx)
+		return err
+	}
+
 	// All tickets locked successfully, create booking
 	booking := &domain_booking.Booking{
-		ID:          uuid.New(),
-		UserID:      req.UserID,
-		EventID:     req.EventID,
-		TicketIDs:   lockedTickets,
-		Status:      domain_booking.BookingStatusPending,
-		TotalAmount: bp.calculateTotalAmount(lockedTickets),
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		ExpiresAt:   time.Now().Add(15 * time.Minute),
-	}
-
-	// Save booking to database
-	if err := bp.bookingRepo.Create(bp.ctx, booking); err != nil {
-		// Release tickets if booking save fails
+		ID:           uuid.New(),
+		UserID:       req.UserID,
+		EventID:      req.EventID,
+		TicketIDs:    lockedTickets,
+		Status:       domain_booking.BookingStatusPending,
+		Currency:     req.Currency,
+		ExchangeRate: req.ExchangeRate,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+		ExpiresAt:    time.Now().Add(15 * time.Minute),
+	}
+	booking.TotalAmount = bp.pricing.Price(booking.LockPrices(tickets), len(tickets)).Total
+	if req.QuotedTotal > 0 {
+		// A verified quote locks in the price
+		booking.TotalAmount = booking.Convert(req.QuotedTotal)
+	}
+	if err := booking.RecordCreated(domain_booking.ActorCustomer); err != nil {
 		bp.releaseTickets(lockedTickets, req.UserID)
-		bp.logger.Error("Failed to save booking", "error", err)
-		bp.recordFailure()
-		return
+		bp.recordFailure(ctx)
+		return err
 	}
 
-	// Reserve tickets in database
-	if err := bp.ticketRepo.ReserveTickets(bp.ctx, lockedTickets); err != nil {
-		// Rollback booking and release tickets
-		bp.bookingRepo.Delete(bp.ctx, booking.ID)
+	// Save the booking, reserve its tickets and record the events atomically
+	err = bp.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		if err := bp.bookingRepo.Create(ctx, booking); err != nil {
+			return fmt.Errorf("failed to save booking: %w", err)
+		}
+		if err := bp.ticketRepo.ReserveTickets(ctx, lockedTickets); err != nil {
+			return fmt.Errorf("failed to reserve tickets: %w", err)
+		}
+		if err := bp.streamRepo.Append(ctx, booking.Changes...); err != nil {
+			return fmt.Errorf("failed to record booking events: %w", err)
+		}
+		if err := bp.transitionRepo.Append(ctx, booking.Transitions("", domain_booking.ActorCustomer, "booked")...); err != nil {
+			return fmt.Errorf("failed to record booking history: %w", err)
+		}
+		messages, err := domain_outbox.BookingMessages(domain_outbox.EventBookingCreated, domain_outbox.EventInventoryReserved, booking)
+		if err != nil {
+			return err
+		}
+		return bp.outboxRepo.Append(ctx, messages...)
+	})
+	if err != nil {
 		bp.releaseTickets(lockedTickets, req.UserID)
-		bp.logger.Error("Failed to reserve tickets", "error", err)
-		bp.recordFailure()
-		return
+		bp.logger.Error("Failed to create booking", "error", err)
+		bp.recordFailure(ctx)
+		// A ticket taken by another booking stays taken
+		if errors.Is(err, domain.ErrConflict) {
+			return err
+		}
+		return retryable(err)
 	}
 
 	duration := time.Since(start)
 	bp.logger.Info("Booking created successfully",
 		"booking_id", booking.ID,
+		"trace_id", req.TraceID,
 		"user_id", req.UserID,
 		"event_id", req.EventID,
 		"tickets", len(lockedTickets),
 		"duration", duration)
 
-	bp.recordSuccess()
+	bp.recordSuccess(ctx, booking)
+
+	if bp.onCreated != nil {
+		bp.onCreated(ctx, req.ID, booking)
+	}
+	return nil
+}
+
+// handleFailure schedules a failed request to be retried after its backoff,
+// or dead-letters it once the failure is permanent or its attempts run out
+func (bp *BookingProcessor) handleFailure(req BookingRequest, err error) {
+	req.Attempts++
+	if !isRetryable(err) || req.Attempts >= bp.retry.MaxAttempts {
+		bp.deadLetter(req, err)
+		return
+	}
+
+	delay := bp.retry.delay(req.Attempts)
+	bp.logger.Info("Retrying booking request",
+		"request_id", req.ID,
+		"trace_id", req.TraceID,
+		"attempt", req.Attempts,
+		"delay", delay,
+		"error", err)
+
+	bp.mu.Lock()
+	bp.stats.RetriedRequests++
+	bp.mu.Unlock()
+
+	bp.retryMu.Lock()
+	defer bp.retryMu.Unlock()
+	bp.retries[req.ID] = &pendingRetry{
+		req:   req,
+		err:   err,
+		timer: time.AfterFunc(delay, func() { bp.requeueRetry(req.ID) }),
+	}
+}
+
+// requeueRetry queues a request again once its backoff has passed. Retries
+// are accepted while draining, so requests already taken are not lost.
+func (bp *BookingProcessor) requeueRetry(requestID string) {
+	bp.retryMu.Lock()
+	retry, ok := bp.retries[requestID]
+	if !ok {
+		// Shutdown took it first
+		bp.retryMu.Unlock()
+		return
+	}
+	delete(bp.retries, requestID)
+	bp.requeueing.Add(1)
+	bp.requeued.Add(1)
+	bp.retryMu.Unlock()
+
+	defer func() {
+		bp.requeued.Add(-1)
+		bp.requeueing.Done()
+	}()
+
+	// A retry has already waited its turn once
+	req := retry.req
+	req.entryID, req.entryQueue = "", ""
+	req.Priority = max(req.Priority, RequestPriorityHigh)
+	if err := bp.queueManager.Enqueue(req); err != nil {
+		bp.deadLetter(req, fmt.Errorf("%w; retry could not be queued: %v", retry.err, err))
+	}
+}
+
+// pendingRetries returns how many failed requests are waiting to be retried
+func (bp *BookingProcessor) pendingRetries() int {
+	bp.retryMu.Lock()
+	defer bp.retryMu.Unlock()
+	return len(bp.retries) + int(bp.requeued.Load())
+}
+
+// deadLetter stores a request that failed for good, reporting whether it
+// was stored. A request that cannot be stored is logged and lost.
+func (bp *BookingProcessor) deadLetter(req BookingRequest, cause error) bool {
+	// bp.ctx may already be cancelled when this runs during shutdown
+	ctx, cancel := context.WithTimeout(tenant.WithID(context.Background(), req.TenantID), 5*time.Second)
+	defer cancel()
+	if bp.onFailed != nil {
+		defer bp.onFailed(ctx, req, cause)
+	}
+
+	payload, err := json.Marshal(req)
+	if err == nil {
+		err = bp.deadLetters.Create(ctx, &domain_deadletter.DeadLetter{
+			ID:        uuid.New(),
+			RequestID: req.ID,
+			UserID:    req.UserID,
+			EventID:   req.EventID,
+			Request:   payload,
+			Reason:    cause.Error(),
+			Attempts:  req.Attempts,
+			CreatedAt: time.Now(),
+		})
+	}
+	if err != nil {
+		bp.logger.Error("Failed to dead-letter booking request",
+			"request_id", req.ID,
+			"event_id", req.EventID,
+			"user_id", req.UserID,
+			"tenant_id", req.TenantID,
+			"reason", cause,
+			"error", err)
+		return false
+	}
+
+	bp.mu.Lock()
+	bp.stats.DeadLettered++
+	bp.mu.Unlock()
+	bp.logger.Warn("Booking request dead-lettered",
+		"request_id", req.ID,
+		"attempts", req.Attempts,
+		"reason", cause)
+	return true
+}
+
+// loadTickets reads tickets in one query, in the order given, failing
+// unless every one exists and is available
+func (bp *BookingProcessor) loadTickets(ctx context.Context, ticketIDs []uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	found, err := bp.ticketRepo.GetByIDs(ctx, ticketIDs)
+	if err != nil {
+		return nil, retryable(fmt.Errorf("failed to get tickets: %w", err))
+	}
+	byID := make(map[uuid.UUID]*domain_ticket.Ticket, len(found))
+	for _, ticket := range found {
+		byID[ticket.ID] = ticket
+	}
+
+	tickets := make([]*domain_ticket.Ticket, 0, len(ticketIDs))
+	for _, ticketID := range ticketIDs {
+		ticket, ok := byID[ticketID]
+		if !ok {
+			return nil, fmt.Errorf("ticket %s not found: %w", ticketID, domain.ErrNotFound)
+		}
+		if ticket.Status != domain_ticket.TicketStatusAvailable {
+			return nil, fmt.Errorf("ticket %s is %s", ticketID, ticket.Status)
+		}
+		tickets = append(tickets, ticket)
+	}
+	return tickets, nil
 }
 
 // releaseTickets releases multiple tickets
@@ -201,37 +660,36 @@ func (bp *BookingProcessor) releaseTickets(ticketIDs []uuid.UUID, userID uuid.UU
 	}
 }
 
-// calculateTotalAmount calculates the total amount for tickets
-func (bp *BookingProcessor) calculateTotalAmount(ticketIDs []uuid.UUID) float64 {
-	// This would need to be implemented based on your ticket pricing logic
-	// For now, return a placeholder
-	return float64(len(ticketIDs)) * 50.0 // $50 per ticket
-}
-
 // recordSuccess records a successful booking
-func (bp *BookingProcessor) recordSuccess() {
+func (bp *BookingProcessor) recordSuccess(ctx context.Context, booking *domain_booking.Booking) {
 	bp.mu.Lock()
-	defer bp.mu.Unlock()
 	bp.stats.SuccessfulBookings++
+	bp.mu.Unlock()
+	if bp.metrics != nil {
+		bp.metrics.BookingSucceeded(ctx, booking)
+	}
 }
 
 // recordFailure records a failed booking
-func (bp *BookingProcessor) recordFailure() {
+func (bp *BookingProcessor) recordFailure(ctx context.Context) {
 	bp.mu.Lock()
-	defer bp.mu.Unlock()
 	bp.stats.FailedBookings++
+	bp.mu.Unlock()
+	if bp.metrics != nil {
+		bp.metrics.BookingFailed(ctx)
+	}
 }
 
 // cleanupExpiredLocks periodically cleans up expired locks
 func (bp *BookingProcessor) cleanupExpiredLocks() {
 	defer bp.wg.Done()
 
-	ticker := time.NewTicker(1 * time.Minute)
+	ticker := time.NewTicker(bp.lockCleanup)
 	defer ticker.Stop()
 
 	for {
 		select {
-		case <-bp.ctx.Done():
+		case <-bp.stop:
 			return
 		case <-ticker.C:
 			expiredCount := bp.ticketLocks.CleanupExpiredLocks()
@@ -242,9 +700,68 @@ func (bp *BookingProcessor) cleanupExpiredLocks() {
 	}
 }
 
+// OnBookingCreated registers a callback run after each booking is created,
+// with the ID of the request it was made for. It must be set before
+// requests are enqueued.
+func (bp *BookingProcessor) OnBookingCreated(fn func(ctx context.Context, requestID string, booking *domain_booking.Booking)) {
+	bp.onCreated = fn
+}
+
+// OnRequestFailed registers a callback run once a request has failed for
+// good: dead-lettered, or skipped with ErrRequestExpired. It must be set
+// before requests are enqueued.
+func (bp *BookingProcessor) OnRequestFailed(fn func(ctx context.Context, req BookingRequest, cause error)) {
+	bp.onFailed = fn
+}
+
+// Heartbeat registers the processor's workers with heartbeats under name,
+// for readiness. A processor that only enqueues has none to report.
+func (bp *BookingProcessor) Heartbeat(heartbeats *health.Heartbeats, name string) {
+	if bp.enqueueOnly || heartbeats == nil {
+		return
+	}
+	ctx := heartbeats.Start(bp.ctx, name)
+	bp.heartbeat.Store(&ctx)
+}
+
+// SetMetrics registers metrics to count each request taken up and how
+// each attempt at one ended. It must be set before requests are enqueued.
+func (bp *BookingProcessor) SetMetrics(metrics ProcessorMetrics) {
+	bp.metrics = metrics
+}
+
+// ShouldShed reports whether new booking requests should be rejected
+// because queue latency is breaching the SLA
+func (bp *BookingProcessor) ShouldShed() bool {
+	return bp.sla.ShouldShed()
+}
+
+// QueueLoad reports how busy the queue serving the event is
+func (bp *BookingProcessor) QueueLoad(eventID uuid.UUID) QueueLoad {
+	return QueueLoad{
+		Depth: bp.queueManager.Depth(eventID),
+		Wait:  bp.sla.Current(),
+	}
+}
+
 // EnqueueBookingRequest enqueues a booking request for processing
 func (bp *BookingProcessor) EnqueueBookingRequest(req BookingRequest) error {
-	return bp.queueManager.Enqueue(req)
+	return bp.EnqueueBookingRequestWithin(req, 0)
+}
+
+// EnqueueBookingRequestWithin enqueues a booking request, waiting up to wait
+// for room in a full queue. Shutdown waits for it to return.
+func (bp *BookingProcessor) EnqueueBookingRequestWithin(req BookingRequest, wait time.Duration) error {
+	bp.mu.RLock()
+	if bp.draining {
+		bp.mu.RUnlock()
+		return ErrDraining
+	}
+	bp.enqueuing.Add(1)
+	bp.mu.RUnlock()
+	defer bp.enqueuing.Done()
+
+	return bp.queueManager.EnqueueWithin(req, wait)
 }
 
 // GetStats returns current booking statistics
@@ -260,28 +777,120 @@ func (bp *BookingProcessor) GetStats() map[string]interface{} {
 		"total_requests":      bp.stats.TotalRequests,
 		"successful_bookings": bp.stats.SuccessfulBookings,
 		"failed_bookings":     bp.stats.FailedBookings,
+		"retried_requests":    bp.stats.RetriedRequests,
+		"expired_requests":    bp.stats.ExpiredRequests,
+		"dead_lettered":       bp.stats.DeadLettered,
+		"pending_retries":     bp.pendingRetries(),
 		"queue_length":        bp.getTotalQueueLength(),
 		"uptime_seconds":      uptime.Seconds(),
 		"requests_per_second": float64(bp.stats.TotalRequests) / uptime.Seconds(),
 		"lock_stats":          lockStats,
 		"queue_stats":         queueStats,
+		"sla":                 bp.sla.Stats(),
+		"worker_stats":        bp.workers.Stats(),
+		"enqueue_only":        bp.enqueueOnly,
 	}
 }
 
 // getTotalQueueLength returns the total length of all queues
 func (bp *BookingProcessor) getTotalQueueLength() int {
 	total := 0
-	for _, queue := range bp.queueManager.Queues {
-		total += len(queue)
+	for i := range bp.queueManager.Queues {
+		total += bp.queueManager.Length(i)
 	}
 	return total
 }
 
-// Shutdown gracefully shuts down the booking processor
-func (bp *BookingProcessor) Shutdown() {
-	bp.logger.Info("Shutting down booking processor")
-	bp.cancel()
+// QueueFill returns how many requests are waiting across all queues and
+// how many they can hold
+func (bp *BookingProcessor) QueueFill() (pending, capacity int) {
+	for i := range bp.queueManager.Queues {
+		pending += bp.queueManager.Length(i)
+		capacity += bp.queueManager.Capacity(i)
+	}
+	return pending, capacity
+}
+
+// Shutdown stops accepting requests, keeps processing the queued ones until
+// they are done or the timeout passes, then stops the workers. Requests
+// still queued or waiting to be retried by then are dead-lettered, or
+// dropped and logged if that fails. With a durable queue only
+// the requests this instance has taken are drained, and any left are
+// released to the store rather than dropped.
+func (bp *BookingProcessor) Shutdown(timeout time.Duration) DrainReport {
+	start := time.Now()
+	bp.mu.Lock()
+	bp.draining = true
+	bp.mu.Unlock()
+	bp.enqueuing.Wait()
+
+	close(bp.feedStop)
+	bp.feeders.Wait()
+
+	report := DrainReport{Pending: bp.queueManager.Buffered() + int(bp.inFlight.Load()) + bp.pendingRetries()}
+	bp.logger.Info("Draining booking queues", "pending", report.Pending, "timeout", timeout)
+
+	deadline := time.After(timeout)
+	ticker := time.NewTicker(10 * time.Millisecond)
+	defer ticker.Stop()
+drain:
+	for bp.queueManager.Buffered() > 0 || bp.inFlight.Load() > 0 || bp.pendingRetries() > 0 {
+		select {
+		case <-deadline:
+			break drain
+		case <-ticker.C:
+		}
+	}
+
+	// Workers finish the request in hand before returning, and may still
+	// schedule retries for it
+	close(bp.stop)
+	bp.workers.stop()
 	bp.wg.Wait()
+
+	// Take the retries still waiting, and let those already being queued
+	// again finish so the sweep below finds them
+	bp.retryMu.Lock()
+	retries := bp.retries
+	bp.retries = make(map[string]*pendingRetry)
+	bp.retryMu.Unlock()
+	bp.requeueing.Wait()
+	for _, retry := range retries {
+		retry.timer.Stop()
+		if bp.deadLetter(retry.req, fmt.Errorf("%w; shut down before retrying", retry.err)) {
+			report.DeadLettered++
+		} else {
+			report.Dropped++
+		}
+	}
+
+	for _, queue := range bp.queueManager.Queues {
+		for {
+			req, ok := queue.TryNext()
+			if !ok {
+				break
+			}
+			if bp.queueManager.Durable() {
+				report.Released++
+				continue
+			}
+			if bp.deadLetter(req, errors.New("shut down before processing")) {
+				report.DeadLettered++
+			} else {
+				report.Dropped++
+			}
+		}
+	}
+	report.Drained = max(report.Pending-report.Dropped-report.Released-report.DeadLettered, 0)
+	report.Duration = time.Since(start)
+
+	bp.cancel()
 	bp.eventLocks.Shutdown()
-	bp.logger.Info("Booking processor stopped")
+	bp.logger.Info("Booking processor stopped",
+		"drained", report.Drained,
+		"dropped", report.Dropped,
+		"released", report.Released,
+		"dead_lettered", report.DeadLettered,
+		"duration", report.Duration)
+	return report
 }
@@ -0,0 +1,210 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+	domain_booking "github.com/ojaswiii/booking-manager/src/internal/domain/booking"
+	domain_ticket "github.com/ojaswiii/booking-manager/src/internal/domain/ticket"
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+	"github.com/ojaswiii/booking-manager/src/utils/health"
+
+	"github.com/google/uuid"
+)
+
+func newTestProcessor() *BookingProcessor {
+	logger := utils.NewLogger()
+	return NewBookingProcessor(nil, nil, nil, nil, nil, nil, nil, nil, nil, NewSLATracker(SLAConfig{}, logger), nil, RetryPolicy{}, ProcessorConfig{
+		QueueCount:          3,
+		QueueBufferSize:     10,
+		TicketLockTTL:       time.Minute,
+		EventLockTTL:        time.Minute,
+		EventLockMaxIdle:    time.Minute,
+		LockCleanupInterval: time.Minute,
+	}, WorkerPoolConfig{}, logger)
+}
+
+func TestProcessorRejectsRequestsOnceDraining(t *testing.T) {
+	bp := newTestProcessor()
+
+	report := bp.Shutdown(time.Second)
+	if report.Pending != 0 || report.Drained != 0 || report.Dropped != 0 {
+		t.Fatalf("empty processor: got %+v", report)
+	}
+	if err := bp.EnqueueBookingRequest(BookingRequest{ID: "late"}); !errors.Is(err, ErrDraining) {
+		t.Fatalf("enqueue after shutdown: got %v, want ErrDraining", err)
+	}
+}
+
+func TestProcessorSkipsRequestsPastTheirDeadline(t *testing.T) {
+	bp := newTestProcessor()
+	defer bp.Shutdown(time.Second)
+
+	// The repositories are nil, so reaching them would panic and fail the request
+	req := BookingRequest{ID: "stale", Timestamp: time.Now().Add(-time.Hour), Deadline: time.Now().Add(-time.Minute)}
+	if err := bp.processBookingRequest(req); err != nil {
+		t.Fatalf("expired request: got %v, want it skipped", err)
+	}
+	if bp.stats.ExpiredRequests != 1 || bp.stats.FailedBookings != 0 {
+		t.Fatalf("stats %+v, want one expired request and no failures", bp.stats)
+	}
+}
+
+func TestProcessorBeatsWhileIdleOrProgressing(t *testing.T) {
+	logger := utils.NewLogger()
+	bp := NewBookingProcessor(nil, nil, nil, nil, nil, nil, nil, nil, nil, NewSLATracker(SLAConfig{}, logger), nil, RetryPolicy{}, ProcessorConfig{
+		QueueCount:          1,
+		QueueBufferSize:     10,
+		TicketLockTTL:       time.Minute,
+		EventLockTTL:        time.Minute,
+		EventLockMaxIdle:    time.Minute,
+		LockCleanupInterval: time.Minute,
+	}, WorkerPoolConfig{Interval: 5 * time.Millisecond}, logger)
+	defer bp.Shutdown(time.Second)
+
+	heartbeats := health.NewHeartbeats(time.Hour)
+	checker := health.NewChecker(time.Second, heartbeats)
+	lastBeat := func() time.Time {
+		result, ok := checker.Check(context.Background()).Checks["worker:booking_processor"]
+		if !ok || result.LastBeat == nil {
+			t.Fatal("processor has no heartbeat")
+		}
+		return *result.LastBeat
+	}
+	bp.Heartbeat(heartbeats, "booking_processor")
+
+	registered := lastBeat()
+	time.Sleep(30 * time.Millisecond)
+	if idle := lastBeat(); !idle.After(registered) {
+		t.Fatal("idle processor did not beat")
+	}
+
+	// A request taken up and never finished stops the beats
+	bp.inFlight.Add(1)
+	time.Sleep(15 * time.Millisecond)
+	stuck := lastBeat()
+	time.Sleep(30 * time.Millisecond)
+	if got := lastBeat(); !got.Equal(stuck) {
+		t.Fatalf("stuck processor beat at %v, after %v", got, stuck)
+	}
+	bp.inFlight.Add(-1)
+}
+
+func TestEnqueueOnlyProcessorLeavesRequestsInTheDurableQueue(t *testing.T) {
+	logger := utils.NewLogger()
+	store := &memoryQueueStore{entries: map[string][]repository.QueuedMessage{}, acked: map[string]bool{}}
+	bp := NewBookingProcessor(nil, nil, nil, nil, nil, nil, nil, nil, nil, NewSLATracker(SLAConfig{}, logger), &DurableQueue{Store: store, Consumer: "api"}, RetryPolicy{}, ProcessorConfig{
+		QueueCount:          3,
+		QueueBufferSize:     10,
+		TicketLockTTL:       time.Minute,
+		EventLockTTL:        time.Minute,
+		EventLockMaxIdle:    time.Minute,
+		LockCleanupInterval: time.Minute,
+		EnqueueOnly:         true,
+	}, WorkerPoolConfig{}, logger)
+
+	// The repositories are nil, so processing the request would panic
+	if err := bp.EnqueueBookingRequest(BookingRequest{ID: "queued", EventID: uuid.New(), Timestamp: time.Now()}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if pending, _ := bp.QueueFill(); pending != 1 {
+		t.Fatalf("pending requests: got %d, want 1", pending)
+	}
+
+	report := bp.Shutdown(time.Second)
+	if report.Pending != 0 || report.Released != 0 || len(store.acked) != 0 {
+		t.Fatalf("shutdown touched the queued request: report %+v, acked %v", report, store.acked)
+	}
+}
+
+// ticketsByID serves GetByIDs from memory; other methods are not implemented
+type ticketsByID struct {
+	repository.TicketRepository
+	tickets map[uuid.UUID]*domain_ticket.Ticket
+}
+
+func (r ticketsByID) GetByIDs(_ context.Context, ids []uuid.UUID) ([]*domain_ticket.Ticket, error) {
+	var found []*domain_ticket.Ticket
+	for _, id := range ids {
+		if ticket, ok := r.tickets[id]; ok {
+			found = append(found, ticket)
+		}
+	}
+	return found, nil
+}
+
+func TestProcessorPricesMixedTickets(t *testing.T) {
+	bp := newTestProcessor()
+	defer bp.Shutdown(time.Second)
+
+	repo := ticketsByID{tickets: make(map[uuid.UUID]*domain_ticket.Ticket)}
+	var ids []uuid.UUID
+	for _, price := range []float64{120, 45.5, 45.5, 30} {
+		ticket := &domain_ticket.Ticket{ID: uuid.New(), Price: price, Status: domain_ticket.TicketStatusAvailable}
+		repo.tickets[ticket.ID] = ticket
+		ids = append(ids, ticket.ID)
+	}
+	bp.ticketRepo = repo
+	bp.pricing = domain_booking.Pricing{
+		ServiceFeePercent: 10,
+		TaxPercent:        5,
+		VolumeDiscounts:   []domain_booking.VolumeDiscount{{MinTickets: 2, Percent: 5}, {MinTickets: 4, Percent: 10}},
+	}
+
+	tickets, err := bp.loadTickets(context.Background(), ids)
+	if err != nil {
+		t.Fatalf("loadTickets: %v", err)
+	}
+	booking := &domain_booking.Booking{}
+	price := bp.pricing.Price(booking.LockPrices(tickets), len(tickets))
+	// 241 less 10% is 216.90, plus a 21.69 fee and 11.93 tax
+	want := domain_booking.PriceBreakdown{Subtotal: 241, Discount: 24.1, ServiceFee: 21.69, Tax: 11.93, Total: 250.52}
+	if price != want {
+		t.Fatalf("price %+v, want %+v", price, want)
+	}
+
+	delete(repo.tickets, ids[2])
+	if _, err := bp.loadTickets(context.Background(), ids); !errors.Is(err, domain.ErrNotFound) {
+		t.Fatalf("missing ticket: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestWorkersKeepProcessingWhileEnqueuesWaitForRoom(t *testing.T) {
+	logger := utils.NewLogger()
+	bp := NewBookingProcessor(nil, nil, nil, nil, nil, nil, nil, nil, nil, NewSLATracker(SLAConfig{}, logger), nil, RetryPolicy{}, ProcessorConfig{
+		QueueCount:          1,
+		QueueBufferSize:     1,
+		TicketLockTTL:       time.Minute,
+		EventLockTTL:        time.Minute,
+		EventLockMaxIdle:    time.Minute,
+		LockCleanupInterval: time.Minute,
+	}, WorkerPoolConfig{}, logger)
+	defer bp.Shutdown(time.Second)
+
+	// Expired requests are skipped without reaching the nil repositories,
+	// so the single worker frees a slot as fast as it can take one
+	expired := func(id string) BookingRequest {
+		return BookingRequest{ID: id, Timestamp: time.Now(), Deadline: time.Now().Add(-time.Minute)}
+	}
+	const waiting = 4
+	errs := make(chan error, waiting)
+	start := time.Now()
+	for i := 0; i < waiting; i++ {
+		go func(i int) {
+			errs <- bp.EnqueueBookingRequestWithin(expired(fmt.Sprintf("req-%d", i)), 5*time.Second)
+		}(i)
+	}
+	for i := 0; i < waiting; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("enqueues took %v; workers were held up by those waiting for room", elapsed)
+	}
+}
@@ -2,10 +2,13 @@ package concurrency
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
 	"github.com/ojaswiii/booking-manager/src/utils"
 
 	"github.com/google/uuid"
@@ -13,71 +16,585 @@ import (
 
 // BookingRequest represents a booking request in the queue
 type BookingRequest struct {
-	ID        string
-	UserID    uuid.UUID
-	EventID   uuid.UUID
-	TicketIDs []uuid.UUID
-	Timestamp time.Time
-	Priority  int // Higher number = higher priority
+	ID            string
+	UserID        uuid.UUID
+	EventID       uuid.UUID
+	TicketIDs     []uuid.UUID
+	QuotedTotal   float64  // Price guaranteed by a quote, zero if none
+	Currency      string   // Currency the booking is charged in
+	ExchangeRate  *float64 // Rate from the base currency, nil if charged in it
+	PresaleAccess bool     // User holds a redeemed presale code for the event
+	TenantID      string   // Tenant the request was made for, empty if untenanted
+	Timestamp     time.Time
+	Deadline      time.Time // The submitter has given up by then; zero for never
+	TraceID       string    // ID of the API request that queued it, for logs
+	Priority      int       // Higher number = higher priority
+	Attempts      int       // Processing attempts that have failed so far
+
+	entryID    string // durable queue entry to acknowledge once processed
+	entryQueue string // durable queue the entry was read from
+}
+
+// Booking request priorities. Requests at RequestPriorityHigh or above go in
+// a lane served ahead of normal traffic.
+const (
+	RequestPriorityNormal = 1
+	RequestPriorityHigh   = 2 // VIP users and retries
+)
+
+// After this many high priority requests in a row, a waiting normal one is
+// served, so a steady stream of high priority traffic cannot starve the rest
+const priorityFairness = 8
+
+// ErrQueueFull is returned when the lane a request would join has no room
+var ErrQueueFull = errors.New("booking queue is full")
+
+// How long an event counts towards the queue distribution after its last
+// request
+const eventSeenWindow = 10 * time.Minute
+
+// How often a request waiting for room in a full queue checks again
+const queueFullPoll = 50 * time.Millisecond
+
+// ErrUserQueueFull is returned when a user already has as many requests
+// waiting in a queue as one user may
+var ErrUserQueueFull = errors.New("too many booking requests queued for this user")
+
+// userLane holds a lane's waiting requests in a sub-queue per user and
+// serves the users in turn, so one user's backlog cannot hold up the rest
+type userLane struct {
+	waiting map[uuid.UUID][]BookingRequest
+	turns   []uuid.UUID // users with requests waiting, next to be served first
+	size    int
+}
+
+func newUserLane() *userLane {
+	return &userLane{waiting: make(map[uuid.UUID][]BookingRequest)}
+}
+
+func (l *userLane) push(req BookingRequest) {
+	if len(l.waiting[req.UserID]) == 0 {
+		l.turns = append(l.turns, req.UserID)
+	}
+	l.waiting[req.UserID] = append(l.waiting[req.UserID], req)
+	l.size++
+}
+
+// pop takes the oldest request of the user whose turn it is, sending the
+// user to the back of the line if they have more waiting
+func (l *userLane) pop() (BookingRequest, bool) {
+	if len(l.turns) == 0 {
+		return BookingRequest{}, false
+	}
+	userID := l.turns[0]
+	l.turns = l.turns[1:]
+
+	requests := l.waiting[userID]
+	req := requests[0]
+	if len(requests) == 1 {
+		delete(l.waiting, userID)
+	} else {
+		l.waiting[userID] = requests[1:]
+		l.turns = append(l.turns, userID)
+	}
+	l.size--
+	return req, true
+}
+
+// PriorityQueue is one booking queue, with a lane for high priority requests
+// and one for the rest. Within a lane users are served in turn.
+type PriorityQueue struct {
+	mu         sync.Mutex
+	high       *userLane
+	normal     *userLane
+	capacity   int // requests each lane holds
+	maxPerUser int // requests one user may have waiting in a lane; 0 for no limit
+	streak     int // high priority requests served in a row
+
+	// Each holds a value while the other side may have something to do
+	ready chan struct{} // a request was added
+	space chan struct{} // a request was taken
+}
+
+func newPriorityQueue(capacity int, maxPerUser int) *PriorityQueue {
+	return &PriorityQueue{
+		high:       newUserLane(),
+		normal:     newUserLane(),
+		capacity:   capacity,
+		maxPerUser: maxPerUser,
+		ready:      make(chan struct{}, 1),
+		space:      make(chan struct{}, 1),
+	}
+}
+
+func (q *PriorityQueue) lane(priority int) *userLane {
+	if priority >= RequestPriorityHigh {
+		return q.high
+	}
+	return q.normal
+}
+
+// TryPush adds a request without waiting, failing with ErrQueueFull when
+// its lane is full or ErrUserQueueFull when
+// its user has reached their limit. Each lane fills up on its own, so high
+// priority requests are still accepted while normal traffic is refused.
+func (q *PriorityQueue) TryPush(req BookingRequest) error {
+	q.mu.Lock()
+	lane := q.lane(req.Priority)
+	if lane.size >= q.capacity {
+		q.mu.Unlock()
+		return ErrQueueFull
+	}
+	if q.maxPerUser > 0 && len(lane.waiting[req.UserID]) >= q.maxPerUser {
+		q.mu.Unlock()
+		return ErrUserQueueFull
+	}
+	lane.push(req)
+	q.mu.Unlock()
+
+	signal(q.ready)
+	return nil
+}
+
+// Push adds a request, waiting for room in its lane until stop is closed.
+// The per-user limit does not apply.
+func (q *PriorityQueue) Push(req BookingRequest, stop <-chan struct{}) bool {
+	for {
+		q.mu.Lock()
+		lane := q.lane(req.Priority)
+		if lane.size < q.capacity {
+			lane.push(req)
+			q.mu.Unlock()
+			signal(q.ready)
+			return true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.space:
+		case <-stop:
+			return false
+		}
+	}
+}
+
+// Len returns how many requests are waiting in both lanes
+func (q *PriorityQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.high.size + q.normal.size
+}
+
+// Pressure returns how full the fuller lane is, from 0 to 1
+func (q *PriorityQueue) Pressure() float64 {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.capacity <= 0 {
+		return 0
+	}
+	return float64(max(q.high.size, q.normal.size)) / float64(q.capacity)
+}
+
+// Next waits for the next request, high priority first, until stop is
+// closed. Any number of workers may wait on the same queue.
+func (q *PriorityQueue) Next(stop <-chan struct{}) (BookingRequest, bool) {
+	for {
+		if req, ok := q.TryNext(); ok {
+			return req, true
+		}
+		select {
+		case <-q.ready:
+		case <-stop:
+			return BookingRequest{}, false
+		}
+	}
+}
+
+// TryNext takes a waiting request without blocking, high priority first
+func (q *PriorityQueue) TryNext() (BookingRequest, bool) {
+	q.mu.Lock()
+	var req BookingRequest
+	ok := false
+	if q.streak >= priorityFairness {
+		if req, ok = q.normal.pop(); ok {
+			q.streak = 0
+		}
+	}
+	if !ok {
+		if req, ok = q.high.pop(); ok {
+			q.streak++
+		}
+	}
+	if !ok {
+		if req, ok = q.normal.pop(); ok {
+			q.streak = 0
+		}
+	}
+	remaining := q.high.size + q.normal.size
+	q.mu.Unlock()
+
+	if ok {
+		signal(q.space)
+	}
+	if remaining > 0 {
+		// Wake another worker on this queue for what is left
+		signal(q.ready)
+	}
+	return req, ok
+}
+
+// signal wakes whoever is waiting on ch, if anyone
+func signal(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
 }
 
+// DurableQueue backs the booking queues with a shared store, so queued
+// requests survive restarts and are shared between instances
+type DurableQueue struct {
+	Store     repository.BookingQueueRepository
+	Consumer  string        // this instance's name in the consumer group
+	ClaimIdle time.Duration // entries pending this long are taken over from their consumer
+}
+
+// How long a durable queue read waits for new entries
+const durableReadBlock = time.Second
+
 // QueueManager manages booking requests with load balancing
 type QueueManager struct {
-	Queues     []chan BookingRequest
+	Queues     []*PriorityQueue
 	queueCount int
+	bufferSize int
+	durable    *DurableQueue // nil when requests are only queued in memory
+	dedicated  *dedicatedQueues
+	ring       *hashRing // assigns events to the shared queues
 	mu         sync.RWMutex
 	logger     *utils.Logger
+
+	// When each event last had a request queued, for the distribution of
+	// events between queues
+	seenMu    sync.Mutex
+	seen      map[uuid.UUID]time.Time
+	lastPrune time.Time
 }
 
-// NewQueueManager creates a new queue manager with load balancing
-func NewQueueManager(queueCount int, bufferSize int, logger *utils.Logger) *QueueManager {
-	queues := make([]chan BookingRequest, queueCount)
-	for i := 0; i < queueCount; i++ {
-		queues[i] = make(chan BookingRequest, bufferSize)
+// NewQueueManager creates a new queue manager with load balancing. Events
+// share queueCount queues by hash, and the queues set aside for dedicated
+// events follow them. Each user may have up to maxPerUser requests waiting
+// in a queue's lane; 0 for no limit.
+func NewQueueManager(queueCount int, bufferSize int, maxPerUser int, dedicated DedicatedQueueConfig, logger *utils.Logger) *QueueManager {
+	d := newDedicatedQueues(queueCount, dedicated)
+	queues := make([]*PriorityQueue, queueCount+d.count())
+	for i := range queues {
+		queues[i] = newPriorityQueue(bufferSize, maxPerUser)
 	}
 
 	return &QueueManager{
 		Queues:     queues,
 		queueCount: queueCount,
+		bufferSize: bufferSize,
+		dedicated:  d,
+		ring:       newHashRing(queueCount),
+		seen:       make(map[uuid.UUID]time.Time),
+		lastPrune:  time.Now(),
 		logger:     logger,
 	}
 }
 
-// GetQueue returns the appropriate queue for an event (round-robin)
-func (qm *QueueManager) GetQueue(eventID uuid.UUID) chan BookingRequest {
-	// Use event ID hash for consistent queue assignment
-	hash := eventID.String()
-	queueIndex := 0
-	for _, char := range hash {
-		queueIndex = (queueIndex + int(char)) % qm.queueCount
-	}
-	return qm.Queues[queueIndex]
+// NewDurableQueueManager creates a queue manager whose queues live in the
+// durable store, as a stream per queue and lane. Each local lane holds only
+// the request about to be processed, so instances do not hoard entries
+// other instances could take. Streams are served in order, so per-user
+// fairness only applies to in-memory queues.
+func NewDurableQueueManager(queueCount int, bufferSize int, durable *DurableQueue, dedicated DedicatedQueueConfig, logger *utils.Logger) *QueueManager {
+	qm := NewQueueManager(queueCount, 1, 0, dedicated, logger)
+	qm.bufferSize = bufferSize
+	qm.durable = durable
+	return qm
+}
+
+// Durable reports whether the queues are backed by the durable store
+func (qm *QueueManager) Durable() bool {
+	return qm.durable != nil
+}
+
+// GetQueue returns the appropriate queue for an event
+func (qm *QueueManager) GetQueue(eventID uuid.UUID) *PriorityQueue {
+	return qm.Queues[qm.getQueueIndex(eventID)]
 }
 
 // Enqueue adds a booking request to the appropriate queue
 func (qm *QueueManager) Enqueue(req BookingRequest) error {
-	queue := qm.GetQueue(req.EventID)
+	return qm.EnqueueWithin(req, 0)
+}
 
-	select {
-	case queue <- req:
-		qm.logger.Debug("Booking request enqueued",
-			"request_id", req.ID,
-			"event_id", req.EventID,
-			"queue_index", qm.getQueueIndex(req.EventID))
-		return nil
-	default:
-		return context.DeadlineExceeded // Queue is full
+// EnqueueWithin adds a booking request to the appropriate queue, waiting up
+// to wait for room when its lane is full before failing with ErrQueueFull
+func (qm *QueueManager) EnqueueWithin(req BookingRequest, wait time.Duration) error {
+	qm.observe(req.EventID)
+
+	deadline := time.Now().Add(wait)
+	for {
+		err := qm.tryEnqueue(req)
+		remaining := time.Until(deadline)
+		if !errors.Is(err, ErrQueueFull) || remaining <= 0 {
+			return err
+		}
+
+		timer := time.NewTimer(min(remaining, queueFullPoll))
+		if qm.durable == nil {
+			select {
+			case <-qm.GetQueue(req.EventID).space:
+			case <-timer.C:
+			}
+		} else {
+			<-timer.C
+		}
+		timer.Stop()
+	}
+}
+
+// tryEnqueue adds a booking request without waiting for room
+func (qm *QueueManager) tryEnqueue(req BookingRequest) error {
+	if qm.durable != nil {
+		return qm.enqueueDurable(req)
+	}
+
+	if err := qm.GetQueue(req.EventID).TryPush(req); err != nil {
+		return err
+	}
+	qm.logger.Debug("Booking request enqueued",
+		"request_id", req.ID,
+		"event_id", req.EventID,
+		"priority", req.Priority,
+		"queue_index", qm.getQueueIndex(req.EventID))
+	return nil
+}
+
+// streamNames returns the durable queues behind a queue's lanes, high
+// priority first
+func streamNames(index int) []string {
+	return []string{fmt.Sprintf("%d:high", index), fmt.Sprintf("%d", index)}
+}
+
+// streamName returns the durable queue behind the lane for a priority
+func streamName(index int, priority int) string {
+	names := streamNames(index)
+	if priority >= RequestPriorityHigh {
+		return names[0]
+	}
+	return names[1]
+}
+
+// enqueueDurable appends the request to its lane's stream, refusing it like
+// a full channel once bufferSize requests are waiting across all instances
+func (qm *QueueManager) enqueueDurable(req BookingRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	index := qm.getQueueIndex(req.EventID)
+	stream := streamName(index, req.Priority)
+	length, err := qm.durable.Store.Len(ctx, stream)
+	if err != nil {
+		return fmt.Errorf("failed to read queue length: %w", err)
+	}
+	if length >= int64(qm.bufferSize) {
+		return ErrQueueFull
+	}
+
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to encode booking request: %w", err)
+	}
+	if err := qm.durable.Store.Append(ctx, stream, payload); err != nil {
+		return fmt.Errorf("failed to append booking request: %w", err)
+	}
+
+	qm.logger.Debug("Booking request enqueued",
+		"request_id", req.ID,
+		"event_id", req.EventID,
+		"priority", req.Priority,
+		"queue_index", index,
+		"durable", true)
+	return nil
+}
+
+// Receive reads the next requests for a queue from the durable store,
+// taking over entries another consumer left pending for ClaimIdle first.
+// High priority entries come first. It returns no requests if none arrive
+// within a second.
+func (qm *QueueManager) Receive(ctx context.Context, index int) ([]BookingRequest, error) {
+	streams := streamNames(index)
+
+	var messages []repository.QueuedMessage
+	for _, stream := range streams {
+		reclaimed, err := qm.durable.Store.Reclaim(ctx, stream, qm.durable.Consumer, qm.durable.ClaimIdle, 1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reclaim queue entries: %w", err)
+		}
+		if len(reclaimed) > 0 {
+			messages = reclaimed
+			break
+		}
+	}
+	if len(messages) == 0 {
+		var err error
+		messages, err = qm.durable.Store.Read(ctx, streams, qm.durable.Consumer, durableReadBlock)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read queue entries: %w", err)
+		}
+	}
+
+	requests := make([]BookingRequest, 0, len(messages))
+	for _, message := range messages {
+		var req BookingRequest
+		if err := json.Unmarshal(message.Payload, &req); err != nil {
+			// An unreadable entry would be redelivered forever
+			qm.logger.Error("Discarding unreadable queue entry", "queue", message.Queue, "entry_id", message.ID, "error", err)
+			qm.ack(ctx, message.Queue, message.ID)
+			continue
+		}
+		req.entryID = message.ID
+		req.entryQueue = message.Queue
+		requests = append(requests, req)
+	}
+	return requests, nil
+}
+
+// Ack removes a processed request from the durable store. Requests queued
+// only in memory need no acknowledgement.
+func (qm *QueueManager) Ack(ctx context.Context, req BookingRequest) {
+	if qm.durable == nil || req.entryID == "" {
+		return
+	}
+	qm.ack(ctx, req.entryQueue, req.entryID)
+}
+
+func (qm *QueueManager) ack(ctx context.Context, queue string, entryID string) {
+	if err := qm.durable.Store.Ack(ctx, queue, entryID); err != nil {
+		// The entry will be reclaimed and processed again; its tickets are
+		// reserved by then, so the repeat fails harmlessly
+		qm.logger.Error("Failed to acknowledge queue entry", "queue", queue, "entry_id", entryID, "error", err)
+	}
+}
+
+// Length returns how many requests are waiting in a queue. Durable queues
+// count waiting and in-flight requests across all instances.
+func (qm *QueueManager) Length(index int) int {
+	if qm.durable == nil {
+		return qm.Queues[index].Len()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	total := 0
+	for _, stream := range streamNames(index) {
+		length, err := qm.durable.Store.Len(ctx, stream)
+		if err != nil {
+			qm.logger.Warn("Failed to read queue length", "queue", stream, "error", err)
+			return qm.Queues[index].Len()
+		}
+		total += int(length)
+	}
+	return total
+}
+
+// Capacity returns how many requests a queue holds, across both lanes,
+// before refusing more
+func (qm *QueueManager) Capacity(index int) int {
+	return 2 * qm.bufferSize
+}
+
+// Pressure returns how full a queue's fuller lane is, from 0 to 1. A lane
+// at 1 refuses new requests.
+func (qm *QueueManager) Pressure(index int) float64 {
+	if qm.durable == nil {
+		return qm.Queues[index].Pressure()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	fullest := int64(0)
+	for _, stream := range streamNames(index) {
+		length, err := qm.durable.Store.Len(ctx, stream)
+		if err != nil {
+			qm.logger.Warn("Failed to read queue length", "queue", stream, "error", err)
+			return 0
+		}
+		fullest = max(fullest, length)
+	}
+	return min(float64(fullest)/float64(qm.bufferSize), 1)
+}
+
+// Depth returns how many requests are waiting in the queue serving an event
+func (qm *QueueManager) Depth(eventID uuid.UUID) int {
+	return qm.Length(qm.getQueueIndex(eventID))
+}
+
+// Buffered returns how many requests are held in this instance's channels
+func (qm *QueueManager) Buffered() int {
+	total := 0
+	for _, queue := range qm.Queues {
+		total += queue.Len()
+	}
+	return total
+}
+
+// observe counts a request towards its event's rate, moving events onto
+// and off the hot queues as their rates change
+func (qm *QueueManager) observe(eventID uuid.UUID) {
+	now := time.Now()
+	qm.seenMu.Lock()
+	qm.seen[eventID] = now
+	if now.Sub(qm.lastPrune) >= eventSeenWindow {
+		qm.pruneSeen(now)
+	}
+	qm.seenMu.Unlock()
+
+	empty := func(index int) bool { return qm.Length(index) == 0 }
+	for _, change := range qm.dedicated.observe(eventID, now, empty) {
+		if change.Moved {
+			qm.logger.Info("Hot event moved to a dedicated queue", "event_id", change.EventID, "queue_index", change.Queue, "rate", change.Rate)
+		} else {
+			qm.logger.Info("Event released from its dedicated queue", "event_id", change.EventID, "queue_index", change.Queue, "rate", change.Rate)
+		}
 	}
 }
 
-// getQueueIndex returns the queue index for an event
+// pruneSeen forgets events with no requests within the window. The caller
+// holds qm.seenMu.
+func (qm *QueueManager) pruneSeen(now time.Time) {
+	for eventID, at := range qm.seen {
+		if now.Sub(at) >= eventSeenWindow {
+			delete(qm.seen, eventID)
+		}
+	}
+	qm.lastPrune = now
+}
+
+// eventDistribution returns how many events had requests queued within
+// the window, per queue
+func (qm *QueueManager) eventDistribution() []int {
+	qm.seenMu.Lock()
+	defer qm.seenMu.Unlock()
+	qm.pruneSeen(time.Now())
+
+	events := make([]int, len(qm.Queues))
+	for eventID := range qm.seen {
+		events[qm.getQueueIndex(eventID)]++
+	}
+	return events
+}
+
+// getQueueIndex returns the queue index for an event: its dedicated queue
+// if it has one, or a shared queue from the hash ring
 func (qm *QueueManager) getQueueIndex(eventID uuid.UUID) int {
-	hash := eventID.String()
-	queueIndex := 0
-	for _, char := range hash {
-		queueIndex = (queueIndex + int(char)) % qm.queueCount
+	if index, ok := qm.dedicated.queue(eventID); ok {
+		return index
 	}
-	return queueIndex
+	return qm.ring.get(eventID[:])
 }
 
 // GetQueueStats returns statistics for all queues
@@ -87,19 +604,34 @@ func (qm *QueueManager) GetQueueStats() map[string]interface{} {
 
 	stats := make(map[string]interface{})
 	totalPending := 0
+	pressure := 0.0
+	events := qm.eventDistribution()
+	shares := qm.ring.shares()
 
-	for i, queue := range qm.Queues {
+	for i := range qm.Queues {
 		queueName := fmt.Sprintf("queue_%d", i)
-		queueLength := len(queue)
+		queueLength := qm.Length(i)
+		queuePressure := qm.Pressure(i)
 		totalPending += queueLength
+		pressure = max(pressure, queuePressure)
 
-		stats[queueName] = map[string]interface{}{
+		queueStats := map[string]interface{}{
 			"length":   queueLength,
-			"capacity": cap(queue),
+			"capacity": qm.Capacity(i),
+			"pressure": queuePressure,
+			"events":   events[i], // with requests queued in the last 10 minutes
+		}
+		if i < qm.queueCount {
+			queueStats["ring_share"] = shares[i]
 		}
+		stats[queueName] = queueStats
 	}
 
-	stats["total_queues"] = qm.queueCount
+	stats["total_queues"] = len(qm.Queues)
+	stats["shared_queues"] = qm.queueCount
+	stats["dedicated"] = qm.dedicated.Stats()
 	stats["total_pending"] = totalPending
+	stats["pressure"] = pressure // of the fullest queue
+	stats["durable"] = qm.durable != nil
 	return stats
 }
@@ -0,0 +1,154 @@
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/repository"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/google/uuid"
+)
+
+// memoryQueueStore keeps durable queue entries in maps, without consumer groups
+type memoryQueueStore struct {
+	entries map[string][]repository.QueuedMessage
+	acked   map[string]bool
+	next    int
+}
+
+func (m *memoryQueueStore) Append(ctx context.Context, queue string, payload []byte) error {
+	m.next++
+	m.entries[queue] = append(m.entries[queue], repository.QueuedMessage{Queue: queue, ID: fmt.Sprint(m.next), Payload: payload})
+	return nil
+}
+
+func (m *memoryQueueStore) Read(ctx context.Context, queues []string, consumer string, block time.Duration) ([]repository.QueuedMessage, error) {
+	for _, queue := range queues {
+		for _, entry := range m.entries[queue] {
+			if !m.acked[entry.ID] {
+				return []repository.QueuedMessage{entry}, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryQueueStore) Reclaim(ctx context.Context, queue string, consumer string, minIdle time.Duration, count int) ([]repository.QueuedMessage, error) {
+	return nil, nil
+}
+
+func (m *memoryQueueStore) Ack(ctx context.Context, queue string, id string) error {
+	m.acked[id] = true
+	return nil
+}
+
+func (m *memoryQueueStore) Len(ctx context.Context, queue string) (int64, error) {
+	var n int64
+	for _, entry := range m.entries[queue] {
+		if !m.acked[entry.ID] {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestDurableQueueRoundTripsRequestsUntilAcknowledged(t *testing.T) {
+	store := &memoryQueueStore{entries: map[string][]repository.QueuedMessage{}, acked: map[string]bool{}}
+	qm := NewDurableQueueManager(3, 1, &DurableQueue{Store: store, Consumer: "test"}, DedicatedQueueConfig{}, utils.NewLogger())
+
+	rate := 1.1
+	sent := BookingRequest{ID: "req-1", EventID: uuid.New(), TicketIDs: []uuid.UUID{uuid.New()}, ExchangeRate: &rate, TenantID: "acme"}
+	if err := qm.Enqueue(sent); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if err := qm.Enqueue(BookingRequest{ID: "req-2", EventID: sent.EventID}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("enqueue past capacity: got %v, want ErrQueueFull", err)
+	}
+
+	index := qm.getQueueIndex(sent.EventID)
+	received, err := qm.Receive(context.Background(), index)
+	if err != nil || len(received) != 1 {
+		t.Fatalf("receive: got %d requests, err %v", len(received), err)
+	}
+	got := received[0]
+	if got.ID != sent.ID || got.TenantID != "acme" || got.TicketIDs[0] != sent.TicketIDs[0] || *got.ExchangeRate != rate {
+		t.Fatalf("received %+v, want %+v", got, sent)
+	}
+
+	if qm.Depth(sent.EventID) != 1 {
+		t.Fatalf("unacknowledged request should still count towards depth")
+	}
+	qm.Ack(context.Background(), got)
+	if qm.Depth(sent.EventID) != 0 {
+		t.Fatalf("acknowledged request should leave the queue")
+	}
+}
+
+func TestPriorityQueueServesHighPriorityFirstWithoutStarvingNormal(t *testing.T) {
+	q := newPriorityQueue(2*priorityFairness, 0)
+	q.TryPush(BookingRequest{ID: "normal", Priority: RequestPriorityNormal})
+	for i := 0; i < priorityFairness+1; i++ {
+		q.TryPush(BookingRequest{ID: "high", Priority: RequestPriorityHigh})
+	}
+
+	var order []string
+	for q.Len() > 0 {
+		req, _ := q.Next(nil)
+		order = append(order, req.ID)
+	}
+	if order[0] != "high" {
+		t.Fatalf("first served %q, want high", order[0])
+	}
+	if order[priorityFairness] != "normal" {
+		t.Fatalf("normal request served at %v, want after %d high ones", order, priorityFairness)
+	}
+}
+
+func TestPriorityQueueServesUsersInTurnAndCapsEach(t *testing.T) {
+	q := newPriorityQueue(10, 3)
+	busy, other := uuid.New(), uuid.New()
+	for i := 0; i < 3; i++ {
+		if err := q.TryPush(BookingRequest{ID: fmt.Sprint("busy-", i), UserID: busy}); err != nil {
+			t.Fatalf("push %d: %v", i, err)
+		}
+	}
+	if err := q.TryPush(BookingRequest{ID: "busy-3", UserID: busy}); !errors.Is(err, ErrUserQueueFull) {
+		t.Fatalf("push past the user's limit: got %v, want ErrUserQueueFull", err)
+	}
+	q.TryPush(BookingRequest{ID: "other", UserID: other})
+
+	var order []string
+	for q.Len() > 0 {
+		req, _ := q.TryNext()
+		order = append(order, req.ID)
+	}
+	if fmt.Sprint(order) != "[busy-0 other busy-1 busy-2]" {
+		t.Fatalf("served %v, want the other user's request second", order)
+	}
+}
+
+func TestEnqueueWithinWaitsForRoomInFullQueue(t *testing.T) {
+	qm := NewQueueManager(1, 1, 0, DedicatedQueueConfig{}, utils.NewLogger())
+	eventID := uuid.New()
+	if err := qm.Enqueue(BookingRequest{ID: "first", EventID: eventID}); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	if pressure := qm.Pressure(0); pressure != 1 {
+		t.Fatalf("pressure %v, want 1 for a full lane", pressure)
+	}
+	if err := qm.Enqueue(BookingRequest{ID: "rejected", EventID: eventID}); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("enqueue into full queue: got %v, want ErrQueueFull", err)
+	}
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		qm.Queues[0].TryNext()
+	}()
+	if err := qm.EnqueueWithin(BookingRequest{ID: "waited", EventID: eventID}, time.Second); err != nil {
+		t.Fatalf("enqueue once room is made: %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package concurrency
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+)
+
+// RetryPolicy defines how failed booking requests are retried before they
+// are dead-lettered
+type RetryPolicy struct {
+	MaxAttempts int           // processing attempts in total; 1 disables retries
+	Backoff     time.Duration // wait before the first retry, doubled for each one after
+	MaxBackoff  time.Duration // longest wait between attempts
+}
+
+// delay returns the wait before the retry following the given attempt
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if p.MaxBackoff > 0 {
+		delay = min(delay, p.MaxBackoff)
+	}
+	return delay
+}
+
+// retryableError marks a failure that may pass on its own, such as lock
+// contention or a database error
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+func retryable(err error) error {
+	return &retryableError{err: err}
+}
+
+// unlessNotFound marks a lookup failure retryable unless the record is
+// really missing
+func unlessNotFound(err error) error {
+	if errors.Is(err, domain.ErrNotFound) {
+		return err
+	}
+	return retryable(err)
+}
+
+// isRetryable reports whether a failed request should be tried again
+func isRetryable(err error) bool {
+	var r *retryableError
+	return errors.As(err, &r)
+}
@@ -0,0 +1,31 @@
+package concurrency
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/internal/domain"
+)
+
+func TestRetryPolicyDelayDoublesUpToMaximum(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, Backoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond}
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 3: 300 * time.Millisecond, 4: 300 * time.Millisecond} {
+		if got := policy.delay(attempt); got != want {
+			t.Errorf("attempt %d: got %v, want %v", attempt, got, want)
+		}
+	}
+}
+
+func TestOnlyTransientFailuresAreRetried(t *testing.T) {
+	if isRetryable(unlessNotFound(fmt.Errorf("user: %w", domain.ErrNotFound))) {
+		t.Error("a missing record should not be retried")
+	}
+	if !isRetryable(unlessNotFound(errors.New("connection reset"))) {
+		t.Error("a database error should be retried")
+	}
+	if !isRetryable(fmt.Errorf("wrapped: %w", retryable(errors.New("ticket locked")))) {
+		t.Error("a wrapped retryable error should be retried")
+	}
+}
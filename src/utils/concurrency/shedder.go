@@ -0,0 +1,228 @@
+package concurrency
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// Priority ranks HTTP traffic for load shedding
+type Priority int
+
+const (
+	PriorityLow      Priority = iota // browsing that clients retry or poll, e.g. listings and availability
+	PriorityNormal                   // everything not classified otherwise
+	PriorityCritical                 // booking and payment flows, never shed
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityLow:
+		return "low"
+	case PriorityCritical:
+		return "critical"
+	}
+	return "normal"
+}
+
+// LoadShedConfig defines the health limits past which traffic is shed. Each
+// signal's pressure is its reading over its limit; a zero limit disables it.
+type LoadShedConfig struct {
+	Enabled        bool
+	Interval       time.Duration // how often health is sampled
+	DBLatency      time.Duration // database ping latency
+	QueueFraction  float64       // share of booking queue capacity in use
+	MemoryBytes    uint64        // Go heap in use
+	LowPriorityAt  float64       // pressure at which low priority traffic is shed; normal goes at 1
+	ProbeTimeout   time.Duration // a ping slower than this counts as a failure
+	RetryAfterSecs int           // suggested to shed clients
+}
+
+// HealthProbes read the signals the shedder watches. Nil probes are skipped.
+type HealthProbes struct {
+	PingDB    func(ctx context.Context) error
+	QueueFill func() (pending, capacity int)
+}
+
+// HealthSample is one reading of the watched signals
+type HealthSample struct {
+	DBLatency   time.Duration
+	DBDown      bool
+	QueueFill   float64 // share of queue capacity in use
+	MemoryBytes uint64
+}
+
+// LoadShedder turns away lower priority HTTP traffic while the system is
+// under pressure, so capacity is kept for bookings and payments
+type LoadShedder struct {
+	config LoadShedConfig
+	probes HealthProbes
+	logger *utils.Logger
+
+	mu       sync.Mutex
+	sample   HealthSample
+	pressure float64
+	shedding Priority // traffic below this priority is shed
+	shed     map[Priority]int64
+	admitted map[Priority]int64
+}
+
+// NewLoadShedder creates a new load shedder. Until Run takes its first
+// sample, no traffic is shed.
+func NewLoadShedder(config LoadShedConfig, probes HealthProbes, logger *utils.Logger) *LoadShedder {
+	return &LoadShedder{
+		config:   config,
+		probes:   probes,
+		logger:   logger,
+		shedding: PriorityLow,
+		shed:     make(map[Priority]int64),
+		admitted: make(map[Priority]int64),
+	}
+}
+
+// Admit reports whether a request of the given priority should be served,
+// counting the outcome
+func (s *LoadShedder) Admit(priority Priority) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.config.Enabled && priority < s.shedding {
+		s.shed[priority]++
+		return false
+	}
+	s.admitted[priority]++
+	return true
+}
+
+// RetryAfter returns the seconds shed clients are asked to wait
+func (s *LoadShedder) RetryAfter() int {
+	return max(s.config.RetryAfterSecs, 1)
+}
+
+// Run samples health on each tick until the context is cancelled
+func (s *LoadShedder) Run(ctx context.Context) {
+	ticker := time.NewTicker(max(s.config.Interval, 100*time.Millisecond))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Update(s.Sample(ctx))
+		}
+	}
+}
+
+// Sample reads the watched signals
+func (s *LoadShedder) Sample(ctx context.Context) HealthSample {
+	var sample HealthSample
+
+	if s.probes.PingDB != nil {
+		timeout := s.config.ProbeTimeout
+		if timeout <= 0 {
+			timeout = time.Second
+		}
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		err := s.probes.PingDB(pingCtx)
+		sample.DBLatency = time.Since(start)
+		sample.DBDown = err != nil
+		cancel()
+	}
+
+	if s.probes.QueueFill != nil {
+		if pending, capacity := s.probes.QueueFill(); capacity > 0 {
+			sample.QueueFill = float64(pending) / float64(capacity)
+		}
+	}
+
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+	sample.MemoryBytes = mem.HeapAlloc
+
+	return sample
+}
+
+// Update applies a health sample, deciding which priorities are shed until
+// the next one
+func (s *LoadShedder) Update(sample HealthSample) {
+	pressure := s.pressureOf(sample)
+
+	shedding := PriorityLow
+	switch {
+	case pressure >= 1:
+		shedding = PriorityCritical
+	case s.config.LowPriorityAt > 0 && pressure >= s.config.LowPriorityAt:
+		shedding = PriorityNormal
+	}
+
+	s.mu.Lock()
+	previous := s.shedding
+	s.sample = sample
+	s.pressure = pressure
+	s.shedding = shedding
+	s.mu.Unlock()
+
+	if shedding != previous && s.config.Enabled {
+		if shedding == PriorityLow {
+			s.logger.Info("Load shedding stopped", "pressure", pressure)
+		} else {
+			s.logger.Warn("Load shedding traffic",
+				"below_priority", shedding.String(),
+				"pressure", pressure,
+				"db_latency_ms", sample.DBLatency.Milliseconds(),
+				"db_down", sample.DBDown,
+				"queue_fill", sample.QueueFill,
+				"heap_mb", sample.MemoryBytes>>20)
+		}
+	}
+}
+
+// pressureOf returns the highest reading relative to its limit. A database
+// that does not answer counts as full pressure.
+func (s *LoadShedder) pressureOf(sample HealthSample) float64 {
+	var pressure float64
+	if sample.DBDown {
+		pressure = 1
+	}
+	if s.config.DBLatency > 0 {
+		pressure = max(pressure, float64(sample.DBLatency)/float64(s.config.DBLatency))
+	}
+	if s.config.QueueFraction > 0 {
+		pressure = max(pressure, sample.QueueFill/s.config.QueueFraction)
+	}
+	if s.config.MemoryBytes > 0 {
+		pressure = max(pressure, float64(sample.MemoryBytes)/float64(s.config.MemoryBytes))
+	}
+	return pressure
+}
+
+// Stats returns load shedding metrics for reporting
+func (s *LoadShedder) Stats() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	shed := make(map[string]int64, len(s.shed))
+	for priority, count := range s.shed {
+		shed[priority.String()] = count
+	}
+	admitted := make(map[string]int64, len(s.admitted))
+	for priority, count := range s.admitted {
+		admitted[priority.String()] = count
+	}
+	return map[string]interface{}{
+		"enabled":        s.config.Enabled,
+		"pressure":       s.pressure,
+		"shedding_below": s.shedding.String(),
+		"db_latency_ms":  s.sample.DBLatency.Milliseconds(),
+		"db_down":        s.sample.DBDown,
+		"queue_fill":     s.sample.QueueFill,
+		"heap_bytes":     s.sample.MemoryBytes,
+		"shed_total":     shed,
+		"admitted_total": admitted,
+	}
+}
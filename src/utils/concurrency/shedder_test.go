@@ -0,0 +1,42 @@
+package concurrency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+func TestLoadShedderShedsLowPriorityFirst(t *testing.T) {
+	shedder := NewLoadShedder(LoadShedConfig{
+		Enabled:       true,
+		DBLatency:     100 * time.Millisecond,
+		QueueFraction: 0.8,
+		LowPriorityAt: 0.7,
+	}, HealthProbes{}, utils.NewLogger())
+
+	admits := func() [3]bool {
+		return [3]bool{shedder.Admit(PriorityLow), shedder.Admit(PriorityNormal), shedder.Admit(PriorityCritical)}
+	}
+
+	shedder.Update(HealthSample{DBLatency: 20 * time.Millisecond, QueueFill: 0.2})
+	if got := admits(); got != [3]bool{true, true, true} {
+		t.Errorf("healthy: admitted %v, want all", got)
+	}
+
+	// Queue at 60% of capacity is 0.75 of its 0.8 limit
+	shedder.Update(HealthSample{DBLatency: 20 * time.Millisecond, QueueFill: 0.6})
+	if got := admits(); got != [3]bool{false, true, true} {
+		t.Errorf("elevated: admitted %v, want normal and critical", got)
+	}
+
+	shedder.Update(HealthSample{DBDown: true})
+	if got := admits(); got != [3]bool{false, false, true} {
+		t.Errorf("database down: admitted %v, want critical only", got)
+	}
+
+	shed := shedder.Stats()["shed_total"].(map[string]int64)
+	if shed["low"] != 2 || shed["normal"] != 1 || shed["critical"] != 0 {
+		t.Errorf("shed_total = %v, want low 2, normal 1", shed)
+	}
+}
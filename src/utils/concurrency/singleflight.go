@@ -0,0 +1,80 @@
+package concurrency
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SingleFlight runs at most one load per key at a time. Callers asking for
+// a key that is already loading wait for that load and share its result,
+// so a cache entry expiring under load reaches the database once.
+type SingleFlight struct {
+	mu     sync.Mutex
+	calls  map[string]*flightCall
+	loads  int64
+	shared int64
+}
+
+type flightCall struct {
+	done  chan struct{} // closed once value and err are set
+	value interface{}
+	err   error
+}
+
+// NewSingleFlight creates an empty SingleFlight
+func NewSingleFlight() *SingleFlight {
+	return &SingleFlight{calls: make(map[string]*flightCall)}
+}
+
+// Do runs load for key, or waits for the load of key already running and
+// returns its result. Every caller gets the same value, so callers must
+// not modify it. A waiter whose ctx ends stops waiting; the load goes on.
+func (g *SingleFlight) Do(ctx context.Context, key string, load func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.shared++
+		g.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.value, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	call := &flightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.loads++
+	g.mu.Unlock()
+
+	defer func() {
+		// A panicking load fails its waiters rather than leaving them blocked
+		if r := recover(); r != nil {
+			call.err = fmt.Errorf("load of %s panicked: %v", key, r)
+			g.finish(key, call)
+			panic(r)
+		}
+		g.finish(key, call)
+	}()
+	call.value, call.err = load()
+	return call.value, call.err
+}
+
+// finish releases the waiters on call and lets the next caller load key again
+func (g *SingleFlight) finish(key string, call *flightCall) {
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	close(call.done)
+}
+
+// Stats reports how many loads ran and how many callers shared one instead
+func (g *SingleFlight) Stats() map[string]interface{} {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return map[string]interface{}{
+		"loads":    g.loads,
+		"shared":   g.shared,
+		"inflight": len(g.calls),
+	}
+}
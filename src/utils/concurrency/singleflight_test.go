@@ -0,0 +1,73 @@
+package concurrency
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSingleFlightSharesOneLoad(t *testing.T) {
+	flight := NewSingleFlight()
+	release := make(chan struct{})
+	var loads int32
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = flight.Do(context.Background(), "events:all", func() (interface{}, error) {
+				atomic.AddInt32(&loads, 1)
+				<-release
+				return "loaded", nil
+			})
+		}(i)
+	}
+
+	// Let every caller reach Do before the load finishes
+	for flight.Stats()["shared"].(int64) < int64(len(results)-1) {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Fatalf("ran %d loads, want 1", loads)
+	}
+	for i, result := range results {
+		if result != "loaded" {
+			t.Fatalf("caller %d got %v, want the shared result", i, result)
+		}
+	}
+
+	// The next miss loads again
+	if _, err := flight.Do(context.Background(), "events:all", func() (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		return "reloaded", nil
+	}); err != nil || loads != 2 {
+		t.Fatalf("second load: ran %d loads, err %v; want 2 loads", loads, err)
+	}
+}
+
+func TestSingleFlightWaiterGivesUp(t *testing.T) {
+	flight := NewSingleFlight()
+	release := make(chan struct{})
+	defer close(release)
+
+	go flight.Do(context.Background(), "event:1", func() (interface{}, error) {
+		<-release
+		return nil, nil
+	})
+	for flight.Stats()["inflight"].(int) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := flight.Do(ctx, "event:1", func() (interface{}, error) { return nil, nil }); err != context.DeadlineExceeded {
+		t.Fatalf("waiter got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
@@ -0,0 +1,185 @@
+package concurrency
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// maxSLASamples bounds memory use of the latency window under heavy load
+const maxSLASamples = 10000
+
+// slaRecheckInterval limits how often the window percentile is recomputed
+const slaRecheckInterval = time.Second
+
+// SLAConfig defines the queue latency objective for booking requests
+type SLAConfig struct {
+	Target     time.Duration // e.g. 2s
+	Percentile float64       // e.g. 99 for p99
+	Window     time.Duration // how far back samples are considered
+	MinSamples int           // no verdict until the window has this many samples
+	Shed       bool          // reject new load while the SLA is breached
+}
+
+type slaSample struct {
+	at      time.Time
+	latency time.Duration
+}
+
+// SLATracker measures time from enqueue to processed for booking requests
+// and reports when the configured percentile exceeds the target
+type SLATracker struct {
+	config SLAConfig
+	logger *utils.Logger
+
+	mu         sync.Mutex
+	samples    []slaSample
+	observed   int64
+	breaches   int64
+	shed       int64
+	breached   bool
+	current    time.Duration
+	lastCheck  time.Time
+	breachedAt time.Time
+}
+
+// NewSLATracker creates a new SLA tracker
+func NewSLATracker(config SLAConfig, logger *utils.Logger) *SLATracker {
+	return &SLATracker{
+		config:  config,
+		logger:  logger,
+		samples: make([]slaSample, 0, 1024),
+	}
+}
+
+// Observe records how long a request waited from enqueue until it was processed
+func (t *SLATracker) Observe(latency time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.observed++
+	if latency > t.config.Target {
+		t.breaches++
+	}
+
+	if len(t.samples) >= maxSLASamples {
+		t.samples = t.samples[1:]
+	}
+	t.samples = append(t.samples, slaSample{at: time.Now(), latency: latency})
+}
+
+// Breached reports whether the windowed percentile currently exceeds the target
+func (t *SLATracker) Breached() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.refresh(time.Now())
+	return t.breached
+}
+
+// Current returns the queue latency at the configured percentile over the window
+func (t *SLATracker) Current() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.refresh(time.Now())
+	return t.current
+}
+
+// Load returns the current latency as a fraction of the target
+func (t *SLATracker) Load() float64 {
+	if t.config.Target <= 0 {
+		return 0
+	}
+	return float64(t.Current()) / float64(t.config.Target)
+}
+
+// Shedding reports whether new load is currently being rejected
+func (t *SLATracker) Shedding() bool {
+	return t.config.Shed && t.Breached()
+}
+
+// ShouldShed reports whether new load should be rejected, counting the rejection
+func (t *SLATracker) ShouldShed() bool {
+	if !t.Shedding() {
+		return false
+	}
+	t.mu.Lock()
+	t.shed++
+	t.mu.Unlock()
+	return true
+}
+
+// Stats returns SLA metrics for reporting
+func (t *SLATracker) Stats() map[string]interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.refresh(time.Now())
+
+	return map[string]interface{}{
+		"target_ms":          t.config.Target.Milliseconds(),
+		"percentile":         t.config.Percentile,
+		"window_seconds":     t.config.Window.Seconds(),
+		"window_samples":     len(t.samples),
+		"current_latency_ms": t.current.Milliseconds(),
+		"breached":           t.breached,
+		"observed_total":     t.observed,
+		"breach_total":       t.breaches,
+		"shed_total":         t.shed,
+	}
+}
+
+// refresh drops samples outside the window and recomputes the percentile.
+// Callers must hold t.mu.
+func (t *SLATracker) refresh(now time.Time) {
+	if now.Sub(t.lastCheck) < slaRecheckInterval {
+		return
+	}
+	t.lastCheck = now
+
+	cutoff := now.Add(-t.config.Window)
+	i := 0
+	for i < len(t.samples) && t.samples[i].at.Before(cutoff) {
+		i++
+	}
+	t.samples = t.samples[i:]
+
+	if len(t.samples) < t.config.MinSamples || len(t.samples) == 0 {
+		t.current = 0
+		t.setBreached(false, now)
+		return
+	}
+
+	latencies := make([]time.Duration, len(t.samples))
+	for i, s := range t.samples {
+		latencies[i] = s.latency
+	}
+	sort.Slice(latencies, func(a, b int) bool { return latencies[a] < latencies[b] })
+
+	rank := int(math.Ceil(t.config.Percentile/100*float64(len(latencies)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	t.current = latencies[rank]
+	t.setBreached(t.current > t.config.Target, now)
+}
+
+// setBreached logs transitions so operators are alerted when the SLA is lost or recovered
+func (t *SLATracker) setBreached(breached bool, now time.Time) {
+	if breached == t.breached {
+		return
+	}
+	t.breached = breached
+
+	if breached {
+		t.breachedAt = now
+		t.logger.Warn("Booking SLA breached",
+			"percentile", t.config.Percentile,
+			"latency", t.current,
+			"target", t.config.Target,
+			"shedding", t.config.Shed)
+		return
+	}
+	t.logger.Info("Booking SLA recovered", "breach_duration", now.Sub(t.breachedAt))
+}
@@ -18,13 +18,16 @@ type TicketLock struct {
 // TicketLockManager manages ticket locks with automatic expiration
 type TicketLockManager struct {
 	locks map[uuid.UUID]*TicketLock
+	ttl   time.Duration
 	mu    sync.RWMutex
 }
 
-// NewTicketLockManager creates a new ticket lock manager
-func NewTicketLockManager() *TicketLockManager {
+// NewTicketLockManager creates a new ticket lock manager whose locks expire
+// after ttl
+func NewTicketLockManager(ttl time.Duration) *TicketLockManager {
 	return &TicketLockManager{
 		locks: make(map[uuid.UUID]*TicketLock),
+		ttl:   ttl,
 	}
 }
 
@@ -46,7 +49,7 @@ func (tlm *TicketLockManager) LockTicket(ticketID, userID uuid.UUID) bool {
 		TicketID:  ticketID,
 		UserID:    userID,
 		LockedAt:  now,
-		ExpiresAt: now.Add(10 * time.Minute), // 10 minutes expiration
+		ExpiresAt: now.Add(tlm.ttl),
 	}
 
 	return true
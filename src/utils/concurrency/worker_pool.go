@@ -0,0 +1,150 @@
+package concurrency
+
+import (
+	"sync"
+	"time"
+)
+
+// WorkerPoolConfig bounds how many workers serve each booking queue and
+// when workers are added or removed
+type WorkerPoolConfig struct {
+	MinWorkers     int           // per queue, always running
+	MaxWorkers     int           // per queue
+	ScaleUpDepth   int           // waiting requests per worker above which a worker is added; 0 to ignore
+	ScaleUpLatency time.Duration // queue latency at the SLA percentile above which a backlogged queue gets a worker; 0 to ignore
+	IdleTimeout    time.Duration // a queue left empty this long loses a worker
+	Interval       time.Duration // how often queues are checked
+}
+
+// normalized fills in bounds that would leave a queue without workers
+func (c WorkerPoolConfig) normalized() WorkerPoolConfig {
+	c.MinWorkers = max(c.MinWorkers, 1)
+	c.MaxWorkers = max(c.MaxWorkers, c.MinWorkers)
+	if c.Interval <= 0 {
+		c.Interval = time.Second
+	}
+	return c
+}
+
+// workerPool runs a variable number of workers per queue. Each worker is
+// stopped through its own quit channel.
+type workerPool struct {
+	config WorkerPoolConfig
+	run    func(queueIndex int, quit <-chan struct{})
+	wg     *sync.WaitGroup
+
+	mu         sync.Mutex
+	workers    [][]chan struct{} // quit channel of each worker, per queue
+	emptySince []time.Time       // when each queue was last seen empty after having work; zero while busy
+	stopped    bool
+	scaledUp   int64
+	scaledDown int64
+}
+
+func newWorkerPool(queueCount int, config WorkerPoolConfig, wg *sync.WaitGroup, run func(queueIndex int, quit <-chan struct{})) *workerPool {
+	return &workerPool{
+		config:     config.normalized(),
+		run:        run,
+		wg:         wg,
+		workers:    make([][]chan struct{}, queueCount),
+		emptySince: make([]time.Time, queueCount),
+	}
+}
+
+// start brings every queue up to the minimum number of workers
+func (p *workerPool) start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i := range p.workers {
+		for len(p.workers[i]) < p.config.MinWorkers {
+			p.add(i)
+		}
+	}
+}
+
+// add starts a worker on a queue. The caller holds p.mu.
+func (p *workerPool) add(queueIndex int) {
+	quit := make(chan struct{})
+	p.workers[queueIndex] = append(p.workers[queueIndex], quit)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.run(queueIndex, quit)
+	}()
+}
+
+// remove stops a queue's most recent worker once it finishes the request in
+// hand. The caller holds p.mu.
+func (p *workerPool) remove(queueIndex int) {
+	workers := p.workers[queueIndex]
+	close(workers[len(workers)-1])
+	p.workers[queueIndex] = workers[:len(workers)-1]
+}
+
+// scale adds a worker to a queue whose backlog is more than its workers keep
+// up with, and removes one from a queue that has stayed empty. It returns
+// the change in workers.
+func (p *workerPool) scale(queueIndex int, depth int, latency time.Duration, now time.Time) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return 0
+	}
+
+	workers := len(p.workers[queueIndex])
+	backlogged := p.config.ScaleUpDepth > 0 && depth > workers*p.config.ScaleUpDepth
+	slow := p.config.ScaleUpLatency > 0 && depth > 0 && latency > p.config.ScaleUpLatency
+
+	if depth > 0 {
+		p.emptySince[queueIndex] = time.Time{}
+	} else if p.emptySince[queueIndex].IsZero() {
+		p.emptySince[queueIndex] = now
+	}
+
+	switch {
+	case (backlogged || slow) && workers < p.config.MaxWorkers:
+		p.add(queueIndex)
+		p.scaledUp++
+		return 1
+	case depth == 0 && workers > p.config.MinWorkers && now.Sub(p.emptySince[queueIndex]) >= p.config.IdleTimeout:
+		p.remove(queueIndex)
+		p.scaledDown++
+		// Another worker goes only after another idle period
+		p.emptySince[queueIndex] = now
+		return -1
+	}
+	return 0
+}
+
+// stop stops every worker and keeps new ones from starting
+func (p *workerPool) stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stopped = true
+	for i := range p.workers {
+		for len(p.workers[i]) > 0 {
+			p.remove(i)
+		}
+	}
+}
+
+// Stats returns worker counts for reporting
+func (p *workerPool) Stats() map[string]interface{} {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	perQueue := make([]int, len(p.workers))
+	total := 0
+	for i, workers := range p.workers {
+		perQueue[i] = len(workers)
+		total += len(workers)
+	}
+	return map[string]interface{}{
+		"workers":           total,
+		"workers_per_queue": perQueue,
+		"min_per_queue":     p.config.MinWorkers,
+		"max_per_queue":     p.config.MaxWorkers,
+		"scaled_up_total":   p.scaledUp,
+		"scaled_down_total": p.scaledDown,
+	}
+}
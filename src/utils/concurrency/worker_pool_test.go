@@ -0,0 +1,58 @@
+package concurrency
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolScalesWithDepth(t *testing.T) {
+	var wg sync.WaitGroup
+	pool := newWorkerPool(1, WorkerPoolConfig{MinWorkers: 1, MaxWorkers: 2, ScaleUpDepth: 10, IdleTimeout: time.Minute}, &wg,
+		func(queueIndex int, quit <-chan struct{}) { <-quit })
+	pool.start()
+	defer func() {
+		pool.stop()
+		wg.Wait()
+	}()
+
+	now := time.Now()
+	if change := pool.scale(0, 10, 0, now); change != 0 {
+		t.Fatalf("expected no change at the threshold, got %d", change)
+	}
+	if change := pool.scale(0, 11, 0, now); change != 1 {
+		t.Fatalf("expected a worker to be added, got %d", change)
+	}
+	if change := pool.scale(0, 100, 0, now); change != 0 {
+		t.Fatalf("expected the maximum to hold, got %d", change)
+	}
+
+	// Empty, but not yet for the idle timeout
+	if change := pool.scale(0, 0, 0, now); change != 0 {
+		t.Fatalf("expected no change before the idle timeout, got %d", change)
+	}
+	if change := pool.scale(0, 0, 0, now.Add(time.Minute)); change != -1 {
+		t.Fatalf("expected an idle worker to be removed, got %d", change)
+	}
+	if change := pool.scale(0, 0, 0, now.Add(time.Hour)); change != 0 {
+		t.Fatalf("expected the minimum to hold, got %d", change)
+	}
+}
+
+func TestWorkerPoolScalesOnLatency(t *testing.T) {
+	var wg sync.WaitGroup
+	pool := newWorkerPool(1, WorkerPoolConfig{MaxWorkers: 2, ScaleUpLatency: time.Second}, &wg,
+		func(queueIndex int, quit <-chan struct{}) { <-quit })
+	pool.start()
+	defer func() {
+		pool.stop()
+		wg.Wait()
+	}()
+
+	if change := pool.scale(0, 0, 2*time.Second, time.Now()); change != 0 {
+		t.Fatalf("expected no worker for an empty queue, got %d", change)
+	}
+	if change := pool.scale(0, 1, 2*time.Second, time.Now()); change != 1 {
+		t.Fatalf("expected a worker while latency is high, got %d", change)
+	}
+}
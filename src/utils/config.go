@@ -1,9 +1,12 @@
 package utils
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Config holds application configuration
@@ -12,13 +15,57 @@ type Config struct {
 	ServerPort string
 	ServerHost string
 
-	// Database configuration
-	DBHost     string
-	DBPort     string
-	DBUser     string
-	DBPassword string
-	DBName     string
-	DBSSLMode  string
+	// Port the worker command serves /healthz and /readyz on, empty for none
+	WorkerHealthPort string
+
+	// TLS configuration; the server speaks HTTP/2 whenever TLS is on
+	TLSCertFile     string
+	TLSKeyFile      string
+	TLSRedirectPort string // plain HTTP port redirected to HTTPS, empty for none
+
+	// Where the repositories keep their data. memory needs neither a
+	// database nor Redis, and loses everything on exit; it suits tests and
+	// local runs.
+	StorageBackend    string // database | memory
+	StorageSeedMemory bool   // load the seed command's demo data into a memory store
+
+	// Database configuration. SQLite keeps everything in the one file at
+	// DBSQLitePath and ignores the connection settings, which MySQL shares
+	// with Postgres.
+	DBDriver     string // postgres | sqlite | mysql
+	DBSQLitePath string
+	DBHost       string
+	DBPort       string
+	DBUser       string
+	DBPassword   string
+	DBName       string
+	DBSSLMode    string
+
+	// Connection pool, shared by the primary and the read replica, and
+	// timeouts; a zero timeout means none
+	DBMaxOpenConns           int
+	DBMaxIdleConns           int
+	DBConnMaxLifetimeSeconds int
+	DBConnectTimeoutSeconds  int
+	DBStatementTimeoutMs     int
+
+	// Statements slower than this are logged; 0 disables the log
+	DBSlowQueryMs int
+
+	// Apply pending schema migrations before serving
+	DBMigrateOnStartup bool
+
+	// Transactions lost to a serialization conflict or deadlock are run
+	// again with jittered, doubling backoff
+	DBTxMaxAttempts       int
+	DBTxRetryBackoffMs    int
+	DBTxRetryMaxBackoffMs int
+
+	// Lag-tolerant reads go to the replica at DBReadDSN, if set, while it
+	// answers health checks and is within DBReplicaMaxLagMs of the primary
+	DBReadDSN                string
+	DBReplicaMaxLagMs        int
+	DBReplicaCheckIntervalMs int
 
 	// Redis configuration
 	RedisHost     string
@@ -26,28 +73,310 @@ type Config struct {
 	RedisPassword string
 	RedisDB       int
 
+	// After RedisBreakerFailures consecutive failed commands Redis is
+	// bypassed for RedisBreakerCooldownMs, then probed with one command
+	RedisBreakerFailures   int
+	RedisBreakerCooldownMs int
+
+	// Each instance remembers the event cache version for
+	// CacheVersionTTLMs, or until another instance publishes an
+	// invalidation. Zero reads it from Redis on every lookup.
+	CacheVersionTTLMs int
+
+	// Lookups of users, events and bookings that do not exist are
+	// answered from the cache for CacheNotFoundTTLMs. Zero disables it.
+	CacheNotFoundTTLMs int
+
+	// How long users, single events and the event listing stay cached,
+	// each moved by up to CacheTTLJitterPercent either way so entries
+	// cached together expire apart. Zero disables caching that entity.
+	CacheUserTTLSeconds      int
+	CacheEventTTLSeconds     int
+	CacheEventListTTLSeconds int
+	CacheTTLJitterPercent    float64
+
+	// The serve command fills the event caches and booking counters
+	// before accepting traffic, giving up after CacheWarmupTimeoutSeconds
+	CacheWarmupEnabled        bool
+	CacheWarmupTimeoutSeconds int
+
 	// Application configuration
 	Environment string
 	LogLevel    string
 
 	// Booking configuration
 	BookingExpiryMinutes int
+	Currency             string             // currency ticket prices are set in
+	ExchangeRates        map[string]float64 // units of each other currency per unit of Currency
+
+	// Quote configuration. The fee, tax and discounts apply to every
+	// booking, quoted or not.
+	QuoteSigningSecret     string
+	QuoteTTLSeconds        int
+	QuoteServiceFeePercent float64
+	QuoteTaxPercent        float64
+	BookingVolumeDiscounts map[int]float64 // percent off by minimum number of tickets
+
+	// Seat suggestion configuration
+	SeatMapRowSize               int // seats per row; seat numbers run row by row from the front
+	SeatSuggestionSessionMinutes int // how long a session remembers the seats it was shown
+
+	// Ticket pass configuration
+	TicketSigningSecret       string
+	CheckInOpensMinutesBefore int
+	CheckInClosesMinutesAfter int
+
+	// Calendar configuration
+	CalendarEventMinutes int // how long calendar entries for events last, as events have no end time
+
+	// Confirmation gate configuration
+	MembershipHookURL string
+
+	// Waiting room configuration
+	WaitingRoomAdmitBatch           int
+	WaitingRoomAdmitIntervalSeconds int
+
+	// Booking SLA configuration
+	BookingSLATargetMs      int
+	BookingSLAPercentile    float64
+	BookingSLAWindowSeconds int
+	BookingSLAMinSamples    int
+	BookingSLAShedEnabled   bool
+
+	// Booking queue overload policy
+	BookingQueueMaxDepth       int
+	BookingQueueMaxWaitMs      int
+	BookingOverloadAction      string
+	BookingFallbackMaxInFlight int
+	BookingQueueFullAction     string // reject | block
+	BookingQueueFullWaitMs     int    // how long a blocked request waits for room
+	BookingDrainTimeoutSeconds int    // how long queued requests get to be processed at shutdown
+
+	// Limits on processing one booking request
+	BookingProcessingTimeoutMs  int // per attempt; 0 for no limit
+	BookingRequestMaxAgeSeconds int // queued requests older than this are skipped; 0 for never
+
+	// Failed booking requests are retried with exponential backoff, then dead-lettered
+	BookingRetryMaxAttempts  int // processing attempts in total; 1 disables retries
+	BookingRetryBackoffMs    int
+	BookingRetryMaxBackoffMs int
+
+	// Users whose booking requests are queued ahead of normal traffic
+	BookingVIPUserIDs []string
+
+	// Booking requests one user may have waiting in a queue; 0 for no limit
+	BookingQueueMaxPerUser int
+
+	// Booking queue sizing and the locks taken while processing
+	BookingQueueCount          int
+	BookingQueueBufferSize     int // requests each lane of a queue holds
+	TicketLockTTLSeconds       int
+	EventLockTTLSeconds        int
+	EventLockMaxIdleSeconds    int
+	LockCleanupIntervalSeconds int
+
+	// Events served from queues of their own, configured or detected by request rate
+	BookingDedicatedEventIDs     []string
+	BookingHotEventQueues        int     // queues set aside for events detected as hot
+	BookingHotEventRate          float64 // requests per second at which an event is hot; 0 disables detection
+	BookingHotEventWindowSeconds int
+
+	// Workers per booking queue, scaled between the bounds with queue depth
+	BookingWorkersMin              int
+	BookingWorkersMax              int
+	BookingWorkersScaleUpDepth     int // waiting requests per worker before another is added; 0 to ignore
+	BookingWorkersScaleUpLatencyMs int // queue latency before a backlogged queue gets another worker; 0 to ignore
+	BookingWorkersScaleIntervalMs  int
+	BookingWorkersIdleSeconds      int // a queue empty this long loses a worker
+
+	// Booking queue storage
+	BookingQueueBackend          string // memory | redis
+	BookingQueueConsumer         string // this instance's consumer name; defaults to host and pid
+	BookingQueueClaimIdleSeconds int    // pending entries idle this long are taken over from their consumer
+	BookingProcessorEnabled      bool   // process queued bookings here; false leaves them to workers
+
+	// Singleton background jobs run on one instance, elected through Redis
+	LeaderElectionEnabled bool
+	LeaderLeaseTTLSeconds int // how long a dead leader's jobs wait for a new one
+
+	// HTTP load shedding; each limit is where its signal reaches full pressure
+	LoadShedEnabled           bool
+	LoadShedIntervalMs        int     // how often health is sampled
+	LoadShedDBLatencyMs       int     // database ping latency limit, 0 to ignore
+	LoadShedQueueFraction     float64 // booking queue fill limit, 0 to ignore
+	LoadShedMemoryMB          int     // Go heap limit, 0 to ignore
+	LoadShedLowPriorityAt     float64 // pressure at which low priority traffic is shed; normal goes at 1
+	LoadShedRetryAfterSeconds int
+
+	// Webhook configuration
+	WebhookMaxAttempts         int
+	WebhookTimeoutSeconds      int
+	WebhookPollIntervalSeconds int
+
+	// Outbox configuration
+	OutboxKafkaBrokers   []string
+	OutboxKafkaTopic     string
+	OutboxBatchSize      int
+	OutboxPollIntervalMs int
+	OutboxRetentionHours int
+
+	// Background job configuration
+	JobPollIntervalMs int
+	JobLeaseSeconds   int
+	JobMaxAttempts    int
+	JobConcurrency    int
+
+	// Notification configuration
+	NotifyProvider             string
+	NotifyFromAddress          string
+	NotifyFromName             string
+	NotifyMaxAttempts          int
+	NotifyPollIntervalSeconds  int
+	NotifyExpiryWarningMinutes int
+	NotifyEventReminderHours   int
+	BroadcastRatePerSecond     int // emails per second a broadcast sends, to stay within provider limits
+	SMTPHost                   string
+	SMTPPort                   string
+	SMTPUsername               string
+	SMTPPassword               string
+	SESRegion                  string
+	AWSAccessKeyID             string
+	AWSSecretAccessKey         string
+	AWSSessionToken            string
+	SendGridAPIKey             string
+
+	// Payment configuration
+	PaymentProvider     string
+	StripeSecretKey     string
+	StripeWebhookSecret string
+	RefundDeadlineHours int     // default policy: refunds close this long before the event
+	RefundPercent       float64 // default policy: share of the ticket price refunded
+
+	// Fraud review configuration
+	PaymentReviewRiskScore      int // payments scored at or above this wait for review instead of confirming
+	PaymentReviewTimeoutMinutes int // bookings not reviewed in time are rejected and their seats released
+
+	// Analytics configuration
+	EventStatsReconcileSeconds  int // how often cached event counters are checked against Postgres
+	BookingMetricsFlushSeconds  int // how often the booking processor's per-minute counters are saved to Redis
+	BookingMetricsRetentionDays int // how long per-minute counters are kept
+	BookingExportPageSize       int // rows read from the database per chunk of a CSV export
+
+	// Availability configuration
+	AvailabilityReconcileSeconds int // how often cached available-ticket counters are recounted
+
+	// Read model configuration
+	ReadModelsEnabled       bool // serve user booking lists from user_booking_summaries
+	ReadModelPollIntervalMs int  // how often the booking event store is checked for events to project
+	ReadModelBatchSize      int  // booking events projected per poll
+	ReadModelRefreshSeconds int  // how often every upcoming event's availability row is recounted
+
+	// Session configuration
+	AuthSigningSecret     string // signs access tokens; shared across instances
+	AccessTokenTTLSeconds int    // how long an access token is accepted after it is issued
+	SessionIdleHours      int    // a session ends once its refresh token goes unused this long
+
+	// Social login configuration. A provider is offered once its client ID is set.
+	OAuthRedirectBaseURL    string // public base URL of the API; callbacks are <base>/api/v1/auth/oauth/<provider>/callback
+	OAuthGoogleClientID     string
+	OAuthGoogleClientSecret string
+	OAuthGitHubClientID     string
+	OAuthGitHubClientSecret string
+
+	// Email verification configuration
+	EmailVerificationTTLHours int  // how long the link emailed on registration works
+	RequireVerifiedEmail      bool // only users who verified their email may confirm bookings
+
+	// Password reset configuration
+	PasswordResetTTLMinutes int    // how long an emailed reset token works
+	PasswordResetPerHour    int    // reset emails one address may be sent per hour
+	PasswordResetURL        string // page the emailed link opens, given the token; the bare token is emailed when unset
+
+	// API key configuration
+	APIKeyRateLimitPerMinute int // requests a partner key may make per minute unless it was issued with its own limit
+
+	// CORS configuration
+	CORSAllowedOrigins   []string // origins browsers may call the API from, "*" for any
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSAllowCredentials bool // allow cookies and auth headers from listed origins
+	CORSMaxAgeSeconds    int  // how long browsers may cache a preflight response
+
+	// Response compression configuration
+	CompressionEnabled       bool
+	CompressionMinBytes      int      // smaller responses are sent uncompressed
+	CompressionExcludedTypes []string // media types, or prefixes like image/, never compressed
+
+	// Request limit configuration
+	MaxRequestBodyBytes     int // larger request bodies get 413; 0 for no limit
+	RequestTimeoutMs        int // handlers running longer get 504; 0 for no limit
+	BookingRequestTimeoutMs int // the same for creating bookings, which should be quick
+
+	// Health check configuration
+	HealthCheckTimeoutMs     int     // a dependency slower than this to answer /readyz is down
+	HealthQueueSaturation    float64 // booking queue fill at which readiness degrades, 0 to ignore
+	HealthWorkerStaleSeconds int     // a worker without a heartbeat for this long is down
+
+	// Tenancy configuration
+	TenantIsolation string
+	TenantHeader    string
+	TenantIDs       []string
+
+	// Schema change configuration
+	ColumnMigrations map[string]string // phase of each in-flight column migration, by name
 }
 
-// LoadConfig loads configuration from environment variables
-func LoadConfig() *Config {
+// LoadConfig loads configuration from environment variables, falling back
+// to the file named by CONFIG_FILE for variables the environment leaves
+// unset, and validates it
+func LoadConfig() (*Config, error) {
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		if err := loadConfigFile(path); err != nil {
+			return nil, err
+		}
+	}
+
 	config := &Config{
 		// Server configuration
 		ServerPort: getEnv("SERVER_PORT", "8080"),
 		ServerHost: getEnv("SERVER_HOST", "localhost"),
 
+		WorkerHealthPort: getEnv("WORKER_HEALTH_PORT", "8081"),
+
+		// TLS configuration
+		TLSCertFile:     getEnv("TLS_CERT_FILE", ""),
+		TLSKeyFile:      getEnv("TLS_KEY_FILE", ""),
+		TLSRedirectPort: getEnv("TLS_REDIRECT_PORT", ""),
+
+		StorageBackend:    getEnv("STORAGE_BACKEND", "database"),
+		StorageSeedMemory: getEnvAsBool("STORAGE_SEED_MEMORY", false),
+
 		// Database configuration
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBUser:     getEnv("DB_USER", "ojaswi"),
-		DBPassword: getEnv("DB_PASSWORD", ""),
-		DBName:     getEnv("DB_NAME", "ticket_booking"),
-		DBSSLMode:  getEnv("DB_SSL_MODE", "disable"),
+		DBDriver:     getEnv("DB_DRIVER", "postgres"),
+		DBSQLitePath: getEnv("DB_SQLITE_PATH", "booking-manager.db"),
+		DBHost:       getEnv("DB_HOST", "localhost"),
+		DBPort:       getEnv("DB_PORT", "5432"),
+		DBUser:       getEnv("DB_USER", "ojaswi"),
+		DBPassword:   getEnv("DB_PASSWORD", ""),
+		DBName:       getEnv("DB_NAME", "ticket_booking"),
+		DBSSLMode:    getEnv("DB_SSL_MODE", "disable"),
+
+		DBMaxOpenConns:           getEnvAsInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:           getEnvAsInt("DB_MAX_IDLE_CONNS", 5),
+		DBConnMaxLifetimeSeconds: getEnvAsInt("DB_CONN_MAX_LIFETIME_SECONDS", 300),
+		DBConnectTimeoutSeconds:  getEnvAsInt("DB_CONNECT_TIMEOUT_SECONDS", 10),
+		DBStatementTimeoutMs:     getEnvAsInt("DB_STATEMENT_TIMEOUT_MS", 0),
+
+		DBSlowQueryMs:      getEnvAsInt("DB_SLOW_QUERY_MS", 200),
+		DBMigrateOnStartup: getEnvAsBool("DB_MIGRATE_ON_STARTUP", true),
+
+		DBTxMaxAttempts:       getEnvAsInt("DB_TX_MAX_ATTEMPTS", 3),
+		DBTxRetryBackoffMs:    getEnvAsInt("DB_TX_RETRY_BACKOFF_MS", 20),
+		DBTxRetryMaxBackoffMs: getEnvAsInt("DB_TX_RETRY_MAX_BACKOFF_MS", 500),
+
+		DBReadDSN:                getEnv("DB_READ_DSN", ""),
+		DBReplicaMaxLagMs:        getEnvAsInt("DB_REPLICA_MAX_LAG_MS", 5000),
+		DBReplicaCheckIntervalMs: getEnvAsInt("DB_REPLICA_CHECK_INTERVAL_MS", 2000),
 
 		// Redis configuration
 		RedisHost:     getEnv("REDIS_HOST", "localhost"),
@@ -55,15 +384,411 @@ func LoadConfig() *Config {
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       getEnvAsInt("REDIS_DB", 0),
 
+		RedisBreakerFailures:   getEnvAsInt("REDIS_BREAKER_FAILURES", 5),
+		RedisBreakerCooldownMs: getEnvAsInt("REDIS_BREAKER_COOLDOWN_MS", 5000),
+
+		CacheVersionTTLMs:  getEnvAsInt("CACHE_VERSION_TTL_MS", 5000),
+		CacheNotFoundTTLMs: getEnvAsInt("CACHE_NOT_FOUND_TTL_MS", 30000),
+
+		CacheUserTTLSeconds:      getEnvAsInt("CACHE_USER_TTL_SECONDS", 3600),
+		CacheEventTTLSeconds:     getEnvAsInt("CACHE_EVENT_TTL_SECONDS", 7200),
+		CacheEventListTTLSeconds: getEnvAsInt("CACHE_EVENT_LIST_TTL_SECONDS", 3600),
+		CacheTTLJitterPercent:    getEnvAsFloat("CACHE_TTL_JITTER_PERCENT", 10),
+
+		CacheWarmupEnabled:        getEnvAsBool("CACHE_WARMUP_ENABLED", false),
+		CacheWarmupTimeoutSeconds: getEnvAsInt("CACHE_WARMUP_TIMEOUT_SECONDS", 30),
+
 		// Application configuration
 		Environment: getEnv("ENV", "development"),
 		LogLevel:    getEnv("LOG_LEVEL", "info"),
 
 		// Booking configuration
 		BookingExpiryMinutes: getEnvAsInt("BOOKING_EXPIRY_MINUTES", 15),
+		Currency:             strings.ToUpper(getEnv("CURRENCY", "USD")),
+		ExchangeRates:        getEnvAsRates("EXCHANGE_RATES"),
+
+		// Quote configuration
+		QuoteSigningSecret:     getEnv("QUOTE_SIGNING_SECRET", ""),
+		QuoteTTLSeconds:        getEnvAsInt("QUOTE_TTL_SECONDS", 300),
+		QuoteServiceFeePercent: getEnvAsFloat("QUOTE_SERVICE_FEE_PERCENT", 0),
+		QuoteTaxPercent:        getEnvAsFloat("QUOTE_TAX_PERCENT", 0),
+		BookingVolumeDiscounts: getEnvAsDiscounts("BOOKING_VOLUME_DISCOUNTS"),
+
+		// Seat suggestion configuration
+		SeatMapRowSize:               getEnvAsInt("SEAT_MAP_ROW_SIZE", 20),
+		SeatSuggestionSessionMinutes: getEnvAsInt("SEAT_SUGGESTION_SESSION_MINUTES", 15),
+
+		// Ticket pass configuration
+		TicketSigningSecret:       getEnv("TICKET_SIGNING_SECRET", ""),
+		CheckInOpensMinutesBefore: getEnvAsInt("CHECKIN_OPENS_MINUTES_BEFORE", 240),
+		CheckInClosesMinutesAfter: getEnvAsInt("CHECKIN_CLOSES_MINUTES_AFTER", 360),
+
+		// Calendar configuration
+		CalendarEventMinutes: getEnvAsInt("CALENDAR_EVENT_MINUTES", 180),
+
+		// Confirmation gate configuration
+		MembershipHookURL: getEnv("MEMBERSHIP_HOOK_URL", ""),
+
+		// Waiting room configuration
+		WaitingRoomAdmitBatch:           getEnvAsInt("WAITING_ROOM_ADMIT_BATCH", 100),
+		WaitingRoomAdmitIntervalSeconds: getEnvAsInt("WAITING_ROOM_ADMIT_INTERVAL_SECONDS", 10),
+
+		// Booking SLA configuration
+		BookingSLATargetMs:      getEnvAsInt("BOOKING_SLA_TARGET_MS", 2000),
+		BookingSLAPercentile:    getEnvAsFloat("BOOKING_SLA_PERCENTILE", 99),
+		BookingSLAWindowSeconds: getEnvAsInt("BOOKING_SLA_WINDOW_SECONDS", 60),
+		BookingSLAMinSamples:    getEnvAsInt("BOOKING_SLA_MIN_SAMPLES", 50),
+		BookingSLAShedEnabled:   getEnvAsBool("BOOKING_SLA_SHED_ENABLED", true),
+
+		// Booking queue overload policy
+		BookingQueueMaxDepth:       getEnvAsInt("BOOKING_QUEUE_MAX_DEPTH", 80),
+		BookingQueueMaxWaitMs:      getEnvAsInt("BOOKING_QUEUE_MAX_WAIT_MS", 5000),
+		BookingOverloadAction:      getEnv("BOOKING_OVERLOAD_ACTION", "fallback"),
+		BookingFallbackMaxInFlight: getEnvAsInt("BOOKING_FALLBACK_MAX_IN_FLIGHT", 20),
+		BookingQueueFullAction:     getEnv("BOOKING_QUEUE_FULL_ACTION", "reject"),
+		BookingQueueFullWaitMs:     getEnvAsInt("BOOKING_QUEUE_FULL_WAIT_MS", 500),
+		BookingDrainTimeoutSeconds: getEnvAsInt("BOOKING_DRAIN_TIMEOUT_SECONDS", 20),
+
+		// Booking request processing limits
+		BookingProcessingTimeoutMs:  getEnvAsInt("BOOKING_PROCESSING_TIMEOUT_MS", 10000),
+		BookingRequestMaxAgeSeconds: getEnvAsInt("BOOKING_REQUEST_MAX_AGE_SECONDS", 300),
+
+		// Booking request retries
+		BookingRetryMaxAttempts:  getEnvAsInt("BOOKING_RETRY_MAX_ATTEMPTS", 3),
+		BookingRetryBackoffMs:    getEnvAsInt("BOOKING_RETRY_BACKOFF_MS", 200),
+		BookingRetryMaxBackoffMs: getEnvAsInt("BOOKING_RETRY_MAX_BACKOFF_MS", 5000),
+		BookingVIPUserIDs:        getEnvAsList("BOOKING_VIP_USER_IDS"),
+		BookingQueueMaxPerUser:   getEnvAsInt("BOOKING_QUEUE_MAX_PER_USER", 5),
+
+		// Booking queue sizing and locks
+		BookingQueueCount:          getEnvAsInt("BOOKING_QUEUE_COUNT", 3),
+		BookingQueueBufferSize:     getEnvAsInt("BOOKING_QUEUE_BUFFER_SIZE", 100),
+		TicketLockTTLSeconds:       getEnvAsInt("TICKET_LOCK_TTL_SECONDS", 600),
+		EventLockTTLSeconds:        getEnvAsInt("EVENT_LOCK_TTL_SECONDS", 1800),
+		EventLockMaxIdleSeconds:    getEnvAsInt("EVENT_LOCK_MAX_IDLE_SECONDS", 300),
+		LockCleanupIntervalSeconds: getEnvAsInt("LOCK_CLEANUP_INTERVAL_SECONDS", 60),
+
+		// Dedicated event queues
+		BookingDedicatedEventIDs:     getEnvAsList("BOOKING_DEDICATED_EVENT_IDS"),
+		BookingHotEventQueues:        getEnvAsInt("BOOKING_HOT_EVENT_QUEUES", 2),
+		BookingHotEventRate:          getEnvAsFloat("BOOKING_HOT_EVENT_RATE", 50),
+		BookingHotEventWindowSeconds: getEnvAsInt("BOOKING_HOT_EVENT_WINDOW_SECONDS", 10),
+
+		// Booking queue workers
+		BookingWorkersMin:              getEnvAsInt("BOOKING_WORKERS_MIN", 1),
+		BookingWorkersMax:              getEnvAsInt("BOOKING_WORKERS_MAX", 8),
+		BookingWorkersScaleUpDepth:     getEnvAsInt("BOOKING_WORKERS_SCALE_UP_DEPTH", 10),
+		BookingWorkersScaleUpLatencyMs: getEnvAsInt("BOOKING_WORKERS_SCALE_UP_LATENCY_MS", 1000),
+		BookingWorkersScaleIntervalMs:  getEnvAsInt("BOOKING_WORKERS_SCALE_INTERVAL_MS", 1000),
+		BookingWorkersIdleSeconds:      getEnvAsInt("BOOKING_WORKERS_IDLE_SECONDS", 30),
+
+		// Booking queue storage
+		BookingQueueBackend:          getEnv("BOOKING_QUEUE_BACKEND", "memory"),
+		BookingQueueConsumer:         getEnv("BOOKING_QUEUE_CONSUMER", ""),
+		BookingQueueClaimIdleSeconds: getEnvAsInt("BOOKING_QUEUE_CLAIM_IDLE_SECONDS", 60),
+		BookingProcessorEnabled:      getEnvAsBool("BOOKING_PROCESSOR_ENABLED", true),
+
+		// Leader election
+		LeaderElectionEnabled: getEnvAsBool("LEADER_ELECTION_ENABLED", true),
+		LeaderLeaseTTLSeconds: getEnvAsInt("LEADER_LEASE_TTL_SECONDS", 15),
+
+		// HTTP load shedding
+		LoadShedEnabled:           getEnvAsBool("LOAD_SHED_ENABLED", true),
+		LoadShedIntervalMs:        getEnvAsInt("LOAD_SHED_INTERVAL_MS", 1000),
+		LoadShedDBLatencyMs:       getEnvAsInt("LOAD_SHED_DB_LATENCY_MS", 250),
+		LoadShedQueueFraction:     getEnvAsFloat("LOAD_SHED_QUEUE_FRACTION", 0.9),
+		LoadShedMemoryMB:          getEnvAsInt("LOAD_SHED_MEMORY_MB", 0),
+		LoadShedLowPriorityAt:     getEnvAsFloat("LOAD_SHED_LOW_PRIORITY_AT", 0.7),
+		LoadShedRetryAfterSeconds: getEnvAsInt("LOAD_SHED_RETRY_AFTER_SECONDS", 5),
+
+		// Webhook configuration
+		WebhookMaxAttempts:         getEnvAsInt("WEBHOOK_MAX_ATTEMPTS", 8),
+		WebhookTimeoutSeconds:      getEnvAsInt("WEBHOOK_TIMEOUT_SECONDS", 10),
+		WebhookPollIntervalSeconds: getEnvAsInt("WEBHOOK_POLL_INTERVAL_SECONDS", 5),
+
+		// Outbox configuration
+		OutboxKafkaBrokers:   getEnvAsList("OUTBOX_KAFKA_BROKERS"),
+		OutboxKafkaTopic:     getEnv("OUTBOX_KAFKA_TOPIC", "booking-manager.events"),
+		OutboxBatchSize:      getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+		OutboxPollIntervalMs: getEnvAsInt("OUTBOX_POLL_INTERVAL_MS", 500),
+		OutboxRetentionHours: getEnvAsInt("OUTBOX_RETENTION_HOURS", 72),
+
+		// Background job configuration
+		JobPollIntervalMs: getEnvAsInt("JOB_POLL_INTERVAL_MS", 1000),
+		JobLeaseSeconds:   getEnvAsInt("JOB_LEASE_SECONDS", 300),
+		JobMaxAttempts:    getEnvAsInt("JOB_MAX_ATTEMPTS", 3),
+		JobConcurrency:    getEnvAsInt("JOB_CONCURRENCY", 2),
+
+		// Notification configuration
+		NotifyProvider:             getEnv("NOTIFY_PROVIDER", "log"),
+		NotifyFromAddress:          getEnv("NOTIFY_FROM_ADDRESS", "no-reply@booking-manager.local"),
+		NotifyFromName:             getEnv("NOTIFY_FROM_NAME", "Booking Manager"),
+		NotifyMaxAttempts:          getEnvAsInt("NOTIFY_MAX_ATTEMPTS", 5),
+		NotifyPollIntervalSeconds:  getEnvAsInt("NOTIFY_POLL_INTERVAL_SECONDS", 5),
+		NotifyExpiryWarningMinutes: getEnvAsInt("NOTIFY_EXPIRY_WARNING_MINUTES", 5),
+		NotifyEventReminderHours:   getEnvAsInt("NOTIFY_EVENT_REMINDER_HOURS", 24),
+		BroadcastRatePerSecond:     getEnvAsInt("BROADCAST_RATE_PER_SECOND", 10),
+		SMTPHost:                   getEnv("SMTP_HOST", "localhost"),
+		SMTPPort:                   getEnv("SMTP_PORT", "587"),
+		SMTPUsername:               getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:               getEnv("SMTP_PASSWORD", ""),
+		SESRegion:                  getEnv("SES_REGION", getEnv("AWS_REGION", "us-east-1")),
+		AWSAccessKeyID:             getEnv("AWS_ACCESS_KEY_ID", ""),
+		AWSSecretAccessKey:         getEnv("AWS_SECRET_ACCESS_KEY", ""),
+		AWSSessionToken:            getEnv("AWS_SESSION_TOKEN", ""),
+		SendGridAPIKey:             getEnv("SENDGRID_API_KEY", ""),
+
+		// Payment configuration
+		PaymentProvider:     getEnv("PAYMENT_PROVIDER", "log"),
+		StripeSecretKey:     getEnv("STRIPE_SECRET_KEY", ""),
+		StripeWebhookSecret: getEnv("STRIPE_WEBHOOK_SECRET", ""),
+		RefundDeadlineHours: getEnvAsInt("REFUND_DEADLINE_HOURS", 48),
+		RefundPercent:       getEnvAsFloat("REFUND_PERCENT", 100),
+
+		// Fraud review configuration
+		PaymentReviewRiskScore:      getEnvAsInt("PAYMENT_REVIEW_RISK_SCORE", 75),
+		PaymentReviewTimeoutMinutes: getEnvAsInt("PAYMENT_REVIEW_TIMEOUT_MINUTES", 1440),
+
+		// Analytics configuration
+		EventStatsReconcileSeconds:  getEnvAsInt("EVENT_STATS_RECONCILE_SECONDS", 300),
+		BookingMetricsFlushSeconds:  getEnvAsInt("BOOKING_METRICS_FLUSH_SECONDS", 10),
+		BookingMetricsRetentionDays: getEnvAsInt("BOOKING_METRICS_RETENTION_DAYS", 7),
+		BookingExportPageSize:       getEnvAsInt("BOOKING_EXPORT_PAGE_SIZE", 500),
+
+		// Availability configuration
+		AvailabilityReconcileSeconds: getEnvAsInt("AVAILABILITY_RECONCILE_SECONDS", 60),
+
+		// Read model configuration
+		ReadModelsEnabled:       getEnvAsBool("READ_MODELS_ENABLED", false),
+		ReadModelPollIntervalMs: getEnvAsInt("READ_MODEL_POLL_INTERVAL_MS", 1000),
+		ReadModelBatchSize:      getEnvAsInt("READ_MODEL_BATCH_SIZE", 500),
+		ReadModelRefreshSeconds: getEnvAsInt("READ_MODEL_REFRESH_SECONDS", 300),
+
+		// Session configuration
+		AuthSigningSecret:     getEnv("AUTH_SIGNING_SECRET", ""),
+		AccessTokenTTLSeconds: getEnvAsInt("ACCESS_TOKEN_TTL_SECONDS", 900),
+		SessionIdleHours:      getEnvAsInt("SESSION_IDLE_HOURS", 720),
+
+		// Social login configuration
+		OAuthRedirectBaseURL:    getEnv("OAUTH_REDIRECT_BASE_URL", "http://localhost:8080"),
+		OAuthGoogleClientID:     getEnv("OAUTH_GOOGLE_CLIENT_ID", ""),
+		OAuthGoogleClientSecret: getEnv("OAUTH_GOOGLE_CLIENT_SECRET", ""),
+		OAuthGitHubClientID:     getEnv("OAUTH_GITHUB_CLIENT_ID", ""),
+		OAuthGitHubClientSecret: getEnv("OAUTH_GITHUB_CLIENT_SECRET", ""),
+
+		// Email verification configuration
+		EmailVerificationTTLHours: getEnvAsInt("EMAIL_VERIFICATION_TTL_HOURS", 48),
+		RequireVerifiedEmail:      getEnvAsBool("REQUIRE_VERIFIED_EMAIL", false),
+
+		// Password reset configuration
+		PasswordResetTTLMinutes: getEnvAsInt("PASSWORD_RESET_TTL_MINUTES", 30),
+		PasswordResetPerHour:    getEnvAsInt("PASSWORD_RESET_PER_HOUR", 3),
+		PasswordResetURL:        getEnv("PASSWORD_RESET_URL", ""),
+
+		// API key configuration
+		APIKeyRateLimitPerMinute: getEnvAsInt("API_KEY_RATE_LIMIT_PER_MINUTE", 120),
+
+		// CORS configuration
+		CORSAllowedOrigins:   getEnvAsListOr("CORS_ALLOWED_ORIGINS", []string{"*"}),
+		CORSAllowedMethods:   getEnvAsListOr("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
+		CORSAllowedHeaders:   getEnvAsListOr("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Authorization", "X-Request-ID", getEnv("TENANT_HEADER", "X-Tenant-ID")}),
+		CORSAllowCredentials: getEnvAsBool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAgeSeconds:    getEnvAsInt("CORS_MAX_AGE_SECONDS", 600),
+
+		// Response compression configuration
+		CompressionEnabled:       getEnvAsBool("COMPRESSION_ENABLED", true),
+		CompressionMinBytes:      getEnvAsInt("COMPRESSION_MIN_BYTES", 1024),
+		CompressionExcludedTypes: getEnvAsListOr("COMPRESSION_EXCLUDED_TYPES", []string{"application/pdf", "application/zip", "application/gzip", "image/", "video/", "audio/", "text/event-stream"}),
+
+		// Request limit configuration
+		MaxRequestBodyBytes:     getEnvAsInt("MAX_REQUEST_BODY_BYTES", 1<<20),
+		RequestTimeoutMs:        getEnvAsInt("REQUEST_TIMEOUT_MS", 10000),
+		BookingRequestTimeoutMs: getEnvAsInt("BOOKING_REQUEST_TIMEOUT_MS", 3000),
+
+		// Health check configuration
+		HealthCheckTimeoutMs:     getEnvAsInt("HEALTH_CHECK_TIMEOUT_MS", 2000),
+		HealthQueueSaturation:    getEnvAsFloat("HEALTH_QUEUE_SATURATION", 0.9),
+		HealthWorkerStaleSeconds: getEnvAsInt("HEALTH_WORKER_STALE_SECONDS", 120),
+
+		// Tenancy configuration
+		TenantIsolation: getEnv("TENANT_ISOLATION", "none"),
+		TenantHeader:    getEnv("TENANT_HEADER", "X-Tenant-ID"),
+		TenantIDs:       getEnvAsList("TENANT_IDS"),
+
+		// Schema change configuration
+		ColumnMigrations: getEnvAsMap("COLUMN_MIGRATIONS"),
 	}
 
-	return config
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// loadConfigFile sets the variables in a file of KEY=VALUE lines that are
+// not already set in the environment. Blank lines and lines starting with
+// # are skipped, and values may be quoted.
+func loadConfigFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open config file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		key, value, ok := strings.Cut(text, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("config file %s line %d: expected KEY=VALUE", path, line)
+		}
+		if _, set := os.LookupEnv(key); set {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	return nil
+}
+
+// Validate checks the settings the booking processor cannot run without
+func (c *Config) Validate() error {
+	var errs []error
+	positive := func(name string, value int) {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive, got %d", name, value))
+		}
+	}
+	nonNegative := func(name string, value int) {
+		if value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative, got %d", name, value))
+		}
+	}
+	if c.StorageBackend != "database" && c.StorageBackend != "memory" {
+		errs = append(errs, fmt.Errorf("STORAGE_BACKEND must be database or memory, got %q", c.StorageBackend))
+	}
+	switch c.DBDriver {
+	case "postgres":
+	case "sqlite", "mysql":
+		if c.DBDriver == "sqlite" && c.DBSQLitePath == "" {
+			errs = append(errs, errors.New("DB_SQLITE_PATH is required with DB_DRIVER=sqlite"))
+		}
+		// Only Postgres has the schemas, row-level security and replicas
+		// these rely on
+		if c.IsMultiTenant() {
+			errs = append(errs, errors.New("TENANT_ISOLATION requires DB_DRIVER=postgres"))
+		}
+		if c.DBReadDSN != "" {
+			errs = append(errs, errors.New("DB_READ_DSN requires DB_DRIVER=postgres"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("DB_DRIVER must be postgres, sqlite or mysql, got %q", c.DBDriver))
+	}
+	if c.IsMultiTenant() && len(c.TenantIDs) == 0 {
+		// Background jobs run once per listed tenant, and would otherwise
+		// run outside every tenant
+		errs = append(errs, errors.New("TENANT_ISOLATION requires TENANT_IDS to list the tenants"))
+	}
+	positive("DB_MAX_OPEN_CONNS", c.DBMaxOpenConns)
+	if c.DBMaxIdleConns < 0 || c.DBMaxIdleConns > c.DBMaxOpenConns {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS (%d), got %d", c.DBMaxOpenConns, c.DBMaxIdleConns))
+	}
+	nonNegative("DB_CONN_MAX_LIFETIME_SECONDS", c.DBConnMaxLifetimeSeconds)
+	nonNegative("DB_CONNECT_TIMEOUT_SECONDS", c.DBConnectTimeoutSeconds)
+	nonNegative("DB_STATEMENT_TIMEOUT_MS", c.DBStatementTimeoutMs)
+	nonNegative("DB_SLOW_QUERY_MS", c.DBSlowQueryMs)
+	positive("DB_TX_MAX_ATTEMPTS", c.DBTxMaxAttempts)
+	if c.DBReadDSN != "" {
+		positive("DB_REPLICA_CHECK_INTERVAL_MS", c.DBReplicaCheckIntervalMs)
+		if c.DBReplicaMaxLagMs < 0 {
+			errs = append(errs, fmt.Errorf("DB_REPLICA_MAX_LAG_MS must not be negative, got %d", c.DBReplicaMaxLagMs))
+		}
+	}
+	positive("REDIS_BREAKER_FAILURES", c.RedisBreakerFailures)
+	positive("REDIS_BREAKER_COOLDOWN_MS", c.RedisBreakerCooldownMs)
+	nonNegative("CACHE_VERSION_TTL_MS", c.CacheVersionTTLMs)
+	nonNegative("CACHE_NOT_FOUND_TTL_MS", c.CacheNotFoundTTLMs)
+	nonNegative("CACHE_USER_TTL_SECONDS", c.CacheUserTTLSeconds)
+	nonNegative("CACHE_EVENT_TTL_SECONDS", c.CacheEventTTLSeconds)
+	nonNegative("CACHE_EVENT_LIST_TTL_SECONDS", c.CacheEventListTTLSeconds)
+	positive("CACHE_WARMUP_TIMEOUT_SECONDS", c.CacheWarmupTimeoutSeconds)
+	if c.CacheTTLJitterPercent < 0 || c.CacheTTLJitterPercent > 100 {
+		errs = append(errs, fmt.Errorf("CACHE_TTL_JITTER_PERCENT must be between 0 and 100, got %v", c.CacheTTLJitterPercent))
+	}
+	positive("BOOKING_QUEUE_COUNT", c.BookingQueueCount)
+	positive("BOOKING_QUEUE_BUFFER_SIZE", c.BookingQueueBufferSize)
+	positive("TICKET_LOCK_TTL_SECONDS", c.TicketLockTTLSeconds)
+	positive("EVENT_LOCK_TTL_SECONDS", c.EventLockTTLSeconds)
+	positive("EVENT_LOCK_MAX_IDLE_SECONDS", c.EventLockMaxIdleSeconds)
+	positive("LOCK_CLEANUP_INTERVAL_SECONDS", c.LockCleanupIntervalSeconds)
+	positive("BOOKING_WORKERS_MIN", c.BookingWorkersMin)
+	positive("BOOKING_WORKERS_SCALE_INTERVAL_MS", c.BookingWorkersScaleIntervalMs)
+
+	if c.BookingWorkersMax < c.BookingWorkersMin {
+		errs = append(errs, fmt.Errorf("BOOKING_WORKERS_MAX (%d) must not be below BOOKING_WORKERS_MIN (%d)", c.BookingWorkersMax, c.BookingWorkersMin))
+	}
+	if c.BookingQueueMaxPerUser < 0 {
+		errs = append(errs, fmt.Errorf("BOOKING_QUEUE_MAX_PER_USER must not be negative, got %d", c.BookingQueueMaxPerUser))
+	}
+	if c.BookingHotEventQueues < 0 {
+		errs = append(errs, fmt.Errorf("BOOKING_HOT_EVENT_QUEUES must not be negative, got %d", c.BookingHotEventQueues))
+	}
+	if c.BookingHotEventRate > 0 {
+		positive("BOOKING_HOT_EVENT_WINDOW_SECONDS", c.BookingHotEventWindowSeconds)
+	}
+	if c.BookingProcessingTimeoutMs < 0 {
+		errs = append(errs, fmt.Errorf("BOOKING_PROCESSING_TIMEOUT_MS must not be negative, got %d", c.BookingProcessingTimeoutMs))
+	}
+	if c.BookingRequestMaxAgeSeconds < 0 {
+		errs = append(errs, fmt.Errorf("BOOKING_REQUEST_MAX_AGE_SECONDS must not be negative, got %d", c.BookingRequestMaxAgeSeconds))
+	}
+	if c.BookingQueueFullWaitMs < 0 {
+		errs = append(errs, fmt.Errorf("BOOKING_QUEUE_FULL_WAIT_MS must not be negative, got %d", c.BookingQueueFullWaitMs))
+	}
+	positive("WAITING_ROOM_ADMIT_BATCH", c.WaitingRoomAdmitBatch)
+	positive("WAITING_ROOM_ADMIT_INTERVAL_SECONDS", c.WaitingRoomAdmitIntervalSeconds)
+	positive("WEBHOOK_POLL_INTERVAL_SECONDS", c.WebhookPollIntervalSeconds)
+	positive("OUTBOX_POLL_INTERVAL_MS", c.OutboxPollIntervalMs)
+	positive("JOB_POLL_INTERVAL_MS", c.JobPollIntervalMs)
+	positive("NOTIFY_POLL_INTERVAL_SECONDS", c.NotifyPollIntervalSeconds)
+	positive("BROADCAST_RATE_PER_SECOND", c.BroadcastRatePerSecond)
+	positive("EVENT_STATS_RECONCILE_SECONDS", c.EventStatsReconcileSeconds)
+	positive("BOOKING_METRICS_FLUSH_SECONDS", c.BookingMetricsFlushSeconds)
+	positive("AVAILABILITY_RECONCILE_SECONDS", c.AvailabilityReconcileSeconds)
+	positive("READ_MODEL_POLL_INTERVAL_MS", c.ReadModelPollIntervalMs)
+	positive("READ_MODEL_REFRESH_SECONDS", c.ReadModelRefreshSeconds)
+	nonNegative("MAX_REQUEST_BODY_BYTES", c.MaxRequestBodyBytes)
+	nonNegative("REQUEST_TIMEOUT_MS", c.RequestTimeoutMs)
+	nonNegative("BOOKING_REQUEST_TIMEOUT_MS", c.BookingRequestTimeoutMs)
+	positive("HEALTH_CHECK_TIMEOUT_MS", c.HealthCheckTimeoutMs)
+	positive("HEALTH_WORKER_STALE_SECONDS", c.HealthWorkerStaleSeconds)
+	if c.HealthQueueSaturation < 0 || c.HealthQueueSaturation > 1 {
+		errs = append(errs, fmt.Errorf("HEALTH_QUEUE_SATURATION must be between 0 and 1, got %v", c.HealthQueueSaturation))
+	}
+	if c.LeaderElectionEnabled {
+		positive("LEADER_LEASE_TTL_SECONDS", c.LeaderLeaseTTLSeconds)
+	}
+	if !c.BookingProcessorEnabled && c.BookingQueueBackend != "redis" {
+		// Nothing else could read an in-memory queue
+		errs = append(errs, errors.New("BOOKING_PROCESSOR_ENABLED=false requires BOOKING_QUEUE_BACKEND=redis"))
+	}
+	if !c.BookingProcessorEnabled && c.StorageBackend == "memory" {
+		// A memory store is private to this process, queue included
+		errs = append(errs, errors.New("BOOKING_PROCESSOR_ENABLED=false requires STORAGE_BACKEND=database"))
+	}
+	return errors.Join(errs...)
 }
 
 // getEnv gets an environment variable with a default value
@@ -84,11 +809,115 @@ func getEnvAsInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvAsFloat gets an environment variable as float with a default value
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsBool gets an environment variable as bool with a default value
+func getEnvAsBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvAsList gets a comma-separated environment variable as a list
+func getEnvAsList(key string) []string {
+	var list []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// getEnvAsListOr gets a comma-separated environment variable as a list, or
+// the default when it is unset or empty
+func getEnvAsListOr(key string, defaultValue []string) []string {
+	if list := getEnvAsList(key); len(list) > 0 {
+		return list
+	}
+	return defaultValue
+}
+
+// getEnvAsRates gets a comma-separated list of CODE=rate pairs, e.g.
+// "EUR=0.92,GBP=0.79". Malformed or non-positive entries are skipped.
+func getEnvAsRates(key string) map[string]float64 {
+	rates := make(map[string]float64)
+	for _, item := range getEnvAsList(key) {
+		code, value, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || rate <= 0 {
+			continue
+		}
+		rates[strings.ToUpper(strings.TrimSpace(code))] = rate
+	}
+	return rates
+}
+
+// getEnvAsDiscounts gets a comma-separated list of tickets=percent pairs,
+// e.g. "4=5,10=10". Entries that are not a positive count and a percent up
+// to 100 are skipped.
+func getEnvAsDiscounts(key string) map[int]float64 {
+	discounts := make(map[int]float64)
+	for _, item := range getEnvAsList(key) {
+		count, value, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		tickets, err := strconv.Atoi(strings.TrimSpace(count))
+		if err != nil || tickets < 1 {
+			continue
+		}
+		percent, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			continue
+		}
+		discounts[tickets] = percent
+	}
+	return discounts
+}
+
+// getEnvAsMap gets a comma-separated list of key=value pairs, e.g.
+// "ticket_price_cents=dual_write". Entries without a value are skipped.
+func getEnvAsMap(key string) map[string]string {
+	values := make(map[string]string)
+	for _, item := range getEnvAsList(key) {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			continue
+		}
+		if name, value = strings.TrimSpace(name), strings.TrimSpace(value); name != "" && value != "" {
+			values[name] = value
+		}
+	}
+	return values
+}
+
 // GetDBConnectionString returns the database connection string
 func (c *Config) GetDBConnectionString() string {
 	// Use URL format for more reliable connection
 	connStr := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=%s",
 		c.DBUser, c.DBPassword, c.DBHost, c.DBPort, c.DBName, c.DBSSLMode)
+	if c.DBConnectTimeoutSeconds > 0 {
+		connStr += fmt.Sprintf("&connect_timeout=%d", c.DBConnectTimeoutSeconds)
+	}
+	// Sent as a session setting, so every statement is cancelled after it
+	if c.DBStatementTimeoutMs > 0 {
+		connStr += fmt.Sprintf("&statement_timeout=%d", c.DBStatementTimeoutMs)
+	}
 
 	return connStr
 }
@@ -98,6 +927,16 @@ func (c *Config) GetRedisAddr() string {
 	return c.RedisHost + ":" + c.RedisPort
 }
 
+// TLSEnabled returns true if the server terminates TLS itself
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != "" && c.TLSKeyFile != ""
+}
+
+// IsMultiTenant returns true if tenants are isolated from each other
+func (c *Config) IsMultiTenant() bool {
+	return c.TenantIsolation != "" && c.TenantIsolation != "none"
+}
+
 // IsProduction returns true if environment is production
 func (c *Config) IsProduction() bool {
 	return c.Environment == "production"
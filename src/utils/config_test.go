@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadConfigReadsFileUnderEnvironment(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "booking.env")
+	contents := "# queue sizing\nBOOKING_QUEUE_COUNT=5\nBOOKING_QUEUE_BUFFER_SIZE=\"250\"\n"
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("BOOKING_QUEUE_BUFFER_SIZE", "50")
+	// Unset here, and restored when the test ends since the file sets it
+	t.Setenv("BOOKING_QUEUE_COUNT", "")
+	os.Unsetenv("BOOKING_QUEUE_COUNT")
+
+	config, err := LoadConfig()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if config.BookingQueueCount != 5 || config.BookingQueueBufferSize != 50 {
+		t.Fatalf("got %d queues of %d, want 5 from the file and 50 from the environment", config.BookingQueueCount, config.BookingQueueBufferSize)
+	}
+
+	t.Setenv("BOOKING_WORKERS_MIN", "4")
+	t.Setenv("BOOKING_WORKERS_MAX", "2")
+	if _, err := LoadConfig(); err == nil {
+		t.Fatal("expected a maximum below the minimum to be rejected")
+	}
+}
+
+func TestDBConnectionStringCarriesTimeouts(t *testing.T) {
+	config := &Config{DBUser: "app", DBPassword: "secret", DBHost: "db", DBPort: "5432", DBName: "tickets", DBSSLMode: "disable"}
+	if got, want := config.GetDBConnectionString(), "postgres://app:secret@db:5432/tickets?sslmode=disable"; got != want {
+		t.Fatalf("without timeouts: got %q, want %q", got, want)
+	}
+
+	config.DBConnectTimeoutSeconds = 5
+	config.DBStatementTimeoutMs = 3000
+	if got, want := config.GetDBConnectionString(), "postgres://app:secret@db:5432/tickets?sslmode=disable&connect_timeout=5&statement_timeout=3000"; got != want {
+		t.Fatalf("with timeouts: got %q, want %q", got, want)
+	}
+}
+
+func TestValidateRejectsUnusableSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Config)
+	}{
+		{"zero admission interval", func(c *Config) { c.WaitingRoomAdmitIntervalSeconds = 0 }},
+		{"zero admission batch", func(c *Config) { c.WaitingRoomAdmitBatch = 0 }},
+		{"zero outbox poll interval", func(c *Config) { c.OutboxPollIntervalMs = 0 }},
+		{"negative job poll interval", func(c *Config) { c.JobPollIntervalMs = -1 }},
+		{"zero read model refresh", func(c *Config) { c.ReadModelRefreshSeconds = 0 }},
+		{"isolated tenants without a list", func(c *Config) { c.TenantIsolation, c.TenantIDs = "schema", nil }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := LoadConfig()
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			tt.mutate(config)
+			if err := config.Validate(); err == nil {
+				t.Fatal("expected the setting to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// migrationLockID is the advisory lock held while migrating, so instances
+// starting together apply each migration once. MySQL's locks are named.
+const (
+	migrationLockID   = 7243104582
+	migrationLockName = "booking_manager_migrations"
+)
+
+const createSchemaMigrations = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`
+
+const createSQLiteSchemaMigrations = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    TEXT PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`
+
+const createMySQLSchemaMigrations = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    VARCHAR(255) PRIMARY KEY,
+		applied_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`
+
+// Migration is one schema change, named by its directory, such as
+// "028_booking_tickets"
+type Migration struct {
+	Version string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied, and when
+type MigrationStatus struct {
+	Version   string
+	AppliedAt *time.Time
+}
+
+// Migrator applies and reverts migrations, recording the applied versions
+// in schema_migrations
+type Migrator struct {
+	db         *sqlx.DB
+	migrations []Migration
+	schema     string // migrate this schema instead of the default search path
+	driver     string
+	logger     *utils.Logger
+}
+
+// NewMigrator reads the migrations in source. Each top-level directory is
+// a migration holding an up.sql and a down.sql. db may be Postgres, SQLite
+// or MySQL, and source must hold that database's migrations.
+func NewMigrator(db *sqlx.DB, source fs.FS, logger *utils.Logger) (*Migrator, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var migrations []Migration
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		up, err := fs.ReadFile(source, path.Join(entry.Name(), "up.sql"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		down, err := fs.ReadFile(source, path.Join(entry.Name(), "down.sql"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
+		}
+		migrations = append(migrations, Migration{Version: entry.Name(), Up: string(up), Down: string(down)})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	migrator := &Migrator{db: db, migrations: migrations, logger: logger}
+	if db != nil {
+		migrator.driver = db.DriverName()
+	}
+	return migrator, nil
+}
+
+// InSchema returns a migrator for the given schema, which is created if
+// missing. Unqualified names in the migrations, schema_migrations included,
+// then resolve to that schema.
+func (m *Migrator) InSchema(schema string) *Migrator {
+	scoped := *m
+	scoped.schema = schema
+	return &scoped
+}
+
+// Up applies every pending migration in order, each in its own
+// transaction, and returns how many it applied
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withConn(ctx, func(conn *sqlx.Conn, done map[string]time.Time) error {
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration.Version, migration.Up,
+				`INSERT INTO schema_migrations (version) VALUES (?)`); err != nil {
+				return err
+			}
+			m.logger.Info("Applied migration", "version", migration.Version, "schema", m.schema)
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the most recently applied migrations, at most steps of
+// them, and returns how many it reverted
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withConn(ctx, func(conn *sqlx.Conn, done map[string]time.Time) error {
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+			if err := m.apply(ctx, conn, migration.Version, migration.Down,
+				`DELETE FROM schema_migrations WHERE version = ?`); err != nil {
+				return err
+			}
+			m.logger.Info("Reverted migration", "version", migration.Version, "schema", m.schema)
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Baseline records every migration up to and including version as applied
+// without running it, for databases whose schema was set up before
+// migrations were tracked
+func (m *Migrator) Baseline(ctx context.Context, version string) error {
+	index := sort.Search(len(m.migrations), func(i int) bool { return m.migrations[i].Version >= version })
+	if index == len(m.migrations) || m.migrations[index].Version != version {
+		return fmt.Errorf("unknown migration %q", version)
+	}
+
+	record := `INSERT INTO schema_migrations (version) VALUES ($1) ON CONFLICT (version) DO NOTHING`
+	if m.driver == "mysql" {
+		record = `INSERT IGNORE INTO schema_migrations (version) VALUES (?)`
+	}
+	return m.withConn(ctx, func(conn *sqlx.Conn, done map[string]time.Time) error {
+		for _, migration := range m.migrations[:index+1] {
+			if _, err := conn.ExecContext(ctx, record, migration.Version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Status lists every migration with when it was applied, if it has been
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var statuses []MigrationStatus
+	err := m.withConn(ctx, func(conn *sqlx.Conn, done map[string]time.Time) error {
+		for _, migration := range m.migrations {
+			status := MigrationStatus{Version: migration.Version}
+			if appliedAt, ok := done[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// apply runs script and records the change with record in one transaction.
+// MySQL commits schema changes as it makes them, so there a failed script
+// may leave part of its changes behind.
+func (m *Migrator) apply(ctx context.Context, conn *sqlx.Conn, version, script, record string) error {
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return fmt.Errorf("migration %s failed: %w", version, err)
+	}
+	if _, err := tx.ExecContext(ctx, tx.Rebind(record), version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// withConn runs fn on one connection holding the migration lock, scoped to
+// the migrator's schema, with the versions applied so far
+func (m *Migrator) withConn(ctx context.Context, fn func(conn *sqlx.Conn, done map[string]time.Time) error) error {
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	switch m.driver {
+	case "sqlite3":
+		// A SQLite database belongs to one installation, which has no
+		// tenant schemas or other instances to race
+		if m.schema != "" {
+			return fmt.Errorf("SQLite databases have no schemas, cannot migrate %s", m.schema)
+		}
+		if _, err := conn.ExecContext(ctx, createSQLiteSchemaMigrations); err != nil {
+			return err
+		}
+		return m.withApplied(ctx, conn, fn)
+	case "mysql":
+		if m.schema != "" {
+			return fmt.Errorf("MySQL databases are single-tenant, cannot migrate %s", m.schema)
+		}
+		if _, err := conn.ExecContext(ctx, `SELECT GET_LOCK(?, -1)`, migrationLockName); err != nil {
+			return fmt.Errorf("failed to take migration lock: %w", err)
+		}
+		defer conn.ExecContext(context.Background(), `SELECT RELEASE_LOCK(?)`, migrationLockName)
+
+		if _, err := conn.ExecContext(ctx, createMySQLSchemaMigrations); err != nil {
+			return err
+		}
+		return m.withApplied(ctx, conn, fn)
+	}
+
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("failed to take migration lock: %w", err)
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+
+	if m.schema != "" {
+		schema := pq.QuoteIdentifier(m.schema)
+		if _, err := conn.ExecContext(ctx, `CREATE SCHEMA IF NOT EXISTS `+schema); err != nil {
+			return err
+		}
+		if _, err := conn.ExecContext(ctx, `SET search_path TO `+schema+`, public`); err != nil {
+			return err
+		}
+		defer conn.ExecContext(context.Background(), `RESET search_path`)
+	}
+
+	if _, err := conn.ExecContext(ctx, createSchemaMigrations); err != nil {
+		return err
+	}
+	return m.withApplied(ctx, conn, fn)
+}
+
+// withApplied runs fn with the versions applied so far
+func (m *Migrator) withApplied(ctx context.Context, conn *sqlx.Conn, fn func(conn *sqlx.Conn, done map[string]time.Time) error) error {
+	var rows []struct {
+		Version   string    `db:"version"`
+		AppliedAt time.Time `db:"applied_at"`
+	}
+	if err := conn.SelectContext(ctx, &rows, `SELECT version, applied_at FROM schema_migrations`); err != nil {
+		return err
+	}
+	done := make(map[string]time.Time, len(rows))
+	for _, row := range rows {
+		done[row.Version] = row.AppliedAt
+	}
+
+	return fn(conn, done)
+}
@@ -0,0 +1,32 @@
+package database
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestNewMigratorOrdersByVersion(t *testing.T) {
+	source := fstest.MapFS{
+		"002_users/up.sql":     {Data: []byte("CREATE TABLE users ()")},
+		"002_users/down.sql":   {Data: []byte("DROP TABLE users")},
+		"001_initial/up.sql":   {Data: []byte("SELECT 1")},
+		"001_initial/down.sql": {Data: []byte("SELECT 1")},
+		"README.md":            {Data: []byte("not a migration")},
+	}
+
+	migrator, err := NewMigrator(nil, source, nil)
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	if len(migrator.migrations) != 2 || migrator.migrations[0].Version != "001_initial" || migrator.migrations[1].Version != "002_users" {
+		t.Fatalf("got migrations %+v, want 001_initial then 002_users", migrator.migrations)
+	}
+	if migrator.migrations[1].Down != "DROP TABLE users" {
+		t.Fatalf("got down script %q", migrator.migrations[1].Down)
+	}
+
+	delete(source, "002_users/down.sql")
+	if _, err := NewMigrator(nil, source, nil); err == nil {
+		t.Fatalf("expected an error for a migration without down.sql")
+	}
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jmoiron/sqlx"
+)
+
+// MySQLClient represents a MySQL client, for installations on managed
+// MySQL that cannot run Postgres
+type MySQLClient struct {
+	DB      *sqlx.DB
+	Queries *QueryStats
+}
+
+// NewMySQLClient connects with the DB_* connection settings. Statements are
+// timed per repository operation like Postgres's.
+func NewMySQLClient(config *utils.Config, logger *utils.Logger) (*MySQLClient, error) {
+	queries := NewQueryStats(time.Duration(config.DBSlowQueryMs)*time.Millisecond, logger)
+
+	connector, err := mysql.NewConnector(mysqlConfig(config))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
+	}
+	db := sqlx.NewDb(sql.OpenDB(&instrumentedConnector{connector: connector, stats: queries}), "mysql")
+
+	configurePool(db, config)
+
+	// Test connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping MySQL: %w", err)
+	}
+
+	return &MySQLClient{DB: db, Queries: queries}, nil
+}
+
+// mysqlConfig translates the DB_* settings into the driver's
+func mysqlConfig(config *utils.Config) *mysql.Config {
+	cfg := mysql.NewConfig()
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(config.DBHost, config.DBPort)
+	cfg.User = config.DBUser
+	cfg.Passwd = config.DBPassword
+	cfg.DBName = config.DBName
+	cfg.Timeout = time.Duration(config.DBConnectTimeoutSeconds) * time.Second
+
+	// Times are stored and read back in UTC, NOW() included
+	cfg.ParseTime = true
+	cfg.Loc = time.UTC
+	cfg.Params = map[string]string{"time_zone": "'+00:00'"}
+	// MySQL only applies the limit to SELECTs
+	if config.DBStatementTimeoutMs > 0 {
+		cfg.Params["max_execution_time"] = strconv.Itoa(config.DBStatementTimeoutMs)
+	}
+
+	// Updates report the rows they matched, as Postgres does, not only
+	// those whose values changed
+	cfg.ClientFoundRows = true
+	// Migrations run as one script
+	cfg.MultiStatements = true
+
+	switch config.DBSSLMode {
+	case "require":
+		cfg.TLSConfig = "skip-verify"
+	case "verify-ca", "verify-full":
+		cfg.TLSConfig = "true"
+	}
+	return cfg
+}
+
+// Close closes the database connection
+func (c *MySQLClient) Close() error {
+	return c.DB.Close()
+}
+
+// Ping tests the database connection
+func (c *MySQLClient) Ping(ctx context.Context) error {
+	return c.DB.PingContext(ctx)
+}
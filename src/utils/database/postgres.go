@@ -2,42 +2,58 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
 	"github.com/ojaswiii/booking-manager/src/utils"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 )
 
 // PostgresClient represents a PostgreSQL client
 type PostgresClient struct {
-	DB *sqlx.DB
+	DB      *sqlx.DB
+	Queries *QueryStats
 }
 
-// NewPostgresClient creates a new PostgreSQL client
-func NewPostgresClient(config *utils.Config) (*PostgresClient, error) {
-	// Create connection string
-	connStr := config.GetDBConnectionString()
+// NewPostgresClient creates a new PostgreSQL client whose statements are
+// timed per repository operation
+func NewPostgresClient(config *utils.Config, logger *utils.Logger) (*PostgresClient, error) {
+	queries := NewQueryStats(time.Duration(config.DBSlowQueryMs)*time.Millisecond, logger)
 
 	// Connect to database
-	db, err := sqlx.Connect("postgres", connStr)
+	db, err := openPostgres(config.GetDBConnectionString(), queries)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
 
-	// Configure connection pool
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	configurePool(db, config)
 
 	// Test connection
 	if err := db.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
 	}
 
-	return &PostgresClient{DB: db}, nil
+	return &PostgresClient{DB: db, Queries: queries}, nil
+}
+
+// openPostgres opens a connection pool to dsn whose statements are recorded
+// in queries
+func openPostgres(dsn string, queries *QueryStats) (*sqlx.DB, error) {
+	connector, err := pq.NewConnector(dsn)
+	if err != nil {
+		return nil, err
+	}
+	return sqlx.NewDb(sql.OpenDB(&instrumentedConnector{connector: connector, stats: queries}), "postgres"), nil
+}
+
+// configurePool applies the configured pool limits to db
+func configurePool(db *sqlx.DB, config *utils.Config) {
+	db.SetMaxOpenConns(config.DBMaxOpenConns)
+	db.SetMaxIdleConns(config.DBMaxIdleConns)
+	db.SetConnMaxLifetime(time.Duration(config.DBConnMaxLifetimeSeconds) * time.Second)
 }
 
 // Close closes the database connection
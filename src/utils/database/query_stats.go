@@ -0,0 +1,298 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// latencyBuckets are the upper bounds of the per-operation latency
+// histograms. Queries slower than the last bound land in an overflow bucket.
+var latencyBuckets = []time.Duration{
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	25 * time.Millisecond,
+	50 * time.Millisecond,
+	100 * time.Millisecond,
+	250 * time.Millisecond,
+	500 * time.Millisecond,
+	time.Second,
+	2500 * time.Millisecond,
+	5 * time.Second,
+}
+
+// maxLoggedQueryLength caps the normalized query text in slow query logs
+const maxLoggedQueryLength = 500
+
+// QueryStats records the latency of every statement sent through an
+// instrumented connection, grouped by the repository method that issued it
+type QueryStats struct {
+	slow   time.Duration
+	logger *utils.Logger
+
+	mu  sync.Mutex
+	ops map[string]*opStats
+}
+
+type opStats struct {
+	count   int64
+	errors  int64
+	slow    int64
+	total   time.Duration
+	max     time.Duration
+	buckets []int64 // one per latencyBuckets entry, plus overflow
+}
+
+// NewQueryStats creates query stats that log statements slower than slow.
+// A zero threshold disables slow query logging.
+func NewQueryStats(slow time.Duration, logger *utils.Logger) *QueryStats {
+	return &QueryStats{
+		slow:   slow,
+		logger: logger,
+		ops:    make(map[string]*opStats),
+	}
+}
+
+// observe records one statement and logs it if it was slow. Statements
+// the driver skipped are run again another way and recorded then.
+func (s *QueryStats) observe(query string, d time.Duration, err error) {
+	if err == driver.ErrSkip {
+		return
+	}
+
+	op := operationName()
+	if op == "" {
+		op = normalizeQuery(query)
+	}
+	slow := s.slow > 0 && d >= s.slow
+
+	s.mu.Lock()
+	stats, ok := s.ops[op]
+	if !ok {
+		stats = &opStats{buckets: make([]int64, len(latencyBuckets)+1)}
+		s.ops[op] = stats
+	}
+	stats.count++
+	stats.total += d
+	stats.max = max(stats.max, d)
+	if err != nil {
+		stats.errors++
+	}
+	if slow {
+		stats.slow++
+	}
+	stats.buckets[sort.Search(len(latencyBuckets), func(i int) bool { return d <= latencyBuckets[i] })]++
+	s.mu.Unlock()
+
+	if slow && s.logger != nil {
+		s.logger.Warn("Slow query",
+			"operation", op,
+			"duration_ms", d.Milliseconds(),
+			"query", normalizeQuery(query),
+		)
+	}
+}
+
+// Stats returns the call count, error count and latency histogram of each
+// operation
+func (s *QueryStats) Stats() map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ops := make(map[string]interface{}, len(s.ops))
+	for op, stats := range s.ops {
+		histogram := make([]map[string]interface{}, 0, len(stats.buckets))
+		for i, n := range stats.buckets {
+			bound := "+Inf"
+			if i < len(latencyBuckets) {
+				bound = latencyBuckets[i].String()
+			}
+			histogram = append(histogram, map[string]interface{}{"le": bound, "count": n})
+		}
+		ops[op] = map[string]interface{}{
+			"count":     stats.count,
+			"errors":    stats.errors,
+			"slow":      stats.slow,
+			"avg_ms":    float64(stats.total.Microseconds()) / float64(stats.count) / 1000,
+			"max_ms":    float64(stats.max.Microseconds()) / 1000,
+			"histogram": histogram,
+		}
+	}
+
+	return map[string]interface{}{
+		"slow_threshold_ms": s.slow.Milliseconds(),
+		"operations":        ops,
+	}
+}
+
+// operationName names the repository method on the call stack, such as
+// "postgresBookingRepository.GetByID", or returns "" outside a repository
+func operationName() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		fn := frame.Function
+		if strings.Contains(fn, "/internal/repository") && strings.Contains(fn, "Repository).") {
+			name := fn[strings.Index(fn, "(*")+2:]
+			name = strings.Replace(name, ").", ".", 1)
+			if i := strings.Index(name, ".func"); i >= 0 {
+				name = name[:i]
+			}
+			return name
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// normalizeQuery collapses whitespace and replaces literals with "?" so
+// that statements differing only in their values read the same. Bind
+// parameters like $1 are kept.
+func normalizeQuery(query string) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(query); i++ {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			space = b.Len() > 0
+			continue
+		case space:
+			b.WriteByte(' ')
+			space = false
+		}
+
+		switch {
+		case c == '\'':
+			for i++; i < len(query); i++ {
+				if query[i] == '\'' {
+					if i+1 < len(query) && query[i+1] == '\'' {
+						i++
+						continue
+					}
+					break
+				}
+			}
+			b.WriteByte('?')
+		case c >= '0' && c <= '9' && !partOfWord(query, i):
+			for i+1 < len(query) && (query[i+1] >= '0' && query[i+1] <= '9' || query[i+1] == '.') {
+				i++
+			}
+			b.WriteByte('?')
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	normalized := b.String()
+	if len(normalized) > maxLoggedQueryLength {
+		normalized = normalized[:maxLoggedQueryLength] + "..."
+	}
+	return normalized
+}
+
+// partOfWord reports whether the digit at query[i] belongs to an
+// identifier or bind parameter rather than being a numeric literal
+func partOfWord(query string, i int) bool {
+	if i == 0 {
+		return false
+	}
+	c := query[i-1]
+	return c == '$' || c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// instrumentedConnector opens pq or MySQL connections that report to stats
+type instrumentedConnector struct {
+	connector driver.Connector
+	stats     *QueryStats
+}
+
+func (c *instrumentedConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pc, ok := conn.(pqConn)
+	if !ok {
+		return conn, nil
+	}
+	return &instrumentedConn{conn: pc, stats: c.stats}, nil
+}
+
+func (c *instrumentedConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
+
+// pqConn is the set of driver interfaces a pq connection implements, and a
+// MySQL one too
+type pqConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.QueryerContext
+	driver.ExecerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+}
+
+// instrumentedConn times the statements run on a pq or MySQL connection
+type instrumentedConn struct {
+	conn  pqConn
+	stats *QueryStats
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.conn.QueryContext(ctx, query, args)
+	c.stats.observe(query, time.Since(start), err)
+	return rows, err
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := c.conn.ExecContext(ctx, query, args)
+	c.stats.observe(query, time.Since(start), err)
+	return result, err
+}
+
+func (c *instrumentedConn) Prepare(query string) (driver.Stmt, error) {
+	return c.conn.Prepare(query)
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	return c.conn.PrepareContext(ctx, query)
+}
+
+func (c *instrumentedConn) Begin() (driver.Tx, error) {
+	return c.conn.Begin()
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.conn.BeginTx(ctx, opts)
+}
+
+func (c *instrumentedConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+func (c *instrumentedConn) ResetSession(ctx context.Context) error {
+	return c.conn.ResetSession(ctx)
+}
+
+func (c *instrumentedConn) IsValid() bool {
+	return c.conn.IsValid()
+}
@@ -0,0 +1,43 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNormalizeQueryReplacesLiterals(t *testing.T) {
+	query := `
+		SELECT id, price_2
+		FROM tickets
+		WHERE event_id = $1 AND status = 'it''s held' AND price > 10.50
+		LIMIT 100`
+
+	want := "SELECT id, price_2 FROM tickets WHERE event_id = $1 AND status = ? AND price > ? LIMIT ?"
+	if got := normalizeQuery(query); got != want {
+		t.Fatalf("normalizeQuery:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestQueryStatsBucketsLatencies(t *testing.T) {
+	stats := NewQueryStats(100*time.Millisecond, nil)
+	stats.observe("SELECT 1", 3*time.Millisecond, nil)
+	stats.observe("SELECT 2", 150*time.Millisecond, errors.New("canceled"))
+	stats.observe("SELECT 3", time.Minute, nil)
+
+	op, ok := stats.Stats()["operations"].(map[string]interface{})["SELECT ?"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("no stats for the normalized query")
+	}
+	if op["count"] != int64(3) || op["errors"] != int64(1) || op["slow"] != int64(2) {
+		t.Fatalf("got count %v, errors %v, slow %v; want 3, 1, 2", op["count"], op["errors"], op["slow"])
+	}
+
+	counts := map[string]int64{}
+	for _, bucket := range op["histogram"].([]map[string]interface{}) {
+		counts[bucket["le"].(string)] = bucket["count"].(int64)
+	}
+	if counts["5ms"] != 1 || counts["250ms"] != 1 || counts["+Inf"] != 1 || counts["1ms"] != 0 {
+		t.Fatalf("unexpected histogram %v", counts)
+	}
+}
@@ -12,11 +12,15 @@ import (
 
 // RedisClient represents a Redis client
 type RedisClient struct {
-	Client *redis.Client
+	Client  *redis.Client
+	Breaker *RedisBreaker
 }
 
-// NewRedisClient creates a new Redis client
-func NewRedisClient(config *utils.Config) (*RedisClient, error) {
+// NewRedisClient creates a new Redis client whose commands pass through a
+// circuit breaker. A Redis that cannot be reached at startup does not stop
+// the client being returned: the breaker starts open and closes once Redis
+// answers.
+func NewRedisClient(config *utils.Config, logger *utils.Logger) (*RedisClient, error) {
 	// Create Redis options
 	opts := &redis.Options{
 		Addr:     config.GetRedisAddr(),
@@ -26,16 +30,18 @@ func NewRedisClient(config *utils.Config) (*RedisClient, error) {
 
 	// Create Redis client
 	client := redis.NewClient(opts)
+	breaker := NewRedisBreaker(config.RedisBreakerFailures, time.Duration(config.RedisBreakerCooldownMs)*time.Millisecond, logger)
+	client.AddHook(breaker)
 
 	// Test connection
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	if err := client.Ping(ctx).Err(); err != nil {
-		return nil, fmt.Errorf("failed to connect to Redis: %w", err)
+		breaker.Trip(fmt.Errorf("failed to connect to Redis: %w", err))
 	}
 
-	return &RedisClient{Client: client}, nil
+	return &RedisClient{Client: client, Breaker: breaker}, nil
 }
 
 // Close closes the Redis connection
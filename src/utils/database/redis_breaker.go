@@ -0,0 +1,153 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrRedisUnavailable is returned for Redis commands refused while the
+// breaker is open, without contacting Redis
+var ErrRedisUnavailable = errors.New("redis unavailable")
+
+// RedisBreaker is a circuit breaker for a Redis client. After a run of
+// failed commands it opens and refuses commands, so callers fall back at
+// once instead of waiting on timeouts. Once the cooldown passes, one command
+// is let through to probe Redis, and the breaker closes when it succeeds.
+type RedisBreaker struct {
+	failures int           // consecutive failed commands that open the breaker
+	cooldown time.Duration // how long commands are refused before a probe
+	logger   *utils.Logger
+	now      func() time.Time
+
+	mu        sync.Mutex
+	failed    int
+	openUntil time.Time // zero while closed
+	probing   bool
+}
+
+// NewRedisBreaker creates a closed breaker
+func NewRedisBreaker(failures int, cooldown time.Duration, logger *utils.Logger) *RedisBreaker {
+	return &RedisBreaker{failures: failures, cooldown: cooldown, logger: logger, now: time.Now}
+}
+
+// Available reports whether commands are being sent to Redis
+func (b *RedisBreaker) Available() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openUntil.IsZero()
+}
+
+// Trip opens the breaker straight away, as when Redis is down at startup
+func (b *RedisBreaker) Trip(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.open(err)
+}
+
+// allow reports whether a command may be sent, letting one probe through
+// once the breaker has been open for the cooldown
+func (b *RedisBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openUntil.IsZero() {
+		return true
+	}
+	if b.probing || b.now().Before(b.openUntil) {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+// record updates the breaker with the outcome of a command sent to Redis
+func (b *RedisBreaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err != nil && ctx.Err() != nil {
+		// The caller gave up, which says nothing about Redis
+		b.probing = false
+		return
+	}
+
+	switch {
+	case !isRedisFailure(err):
+		if !b.openUntil.IsZero() {
+			b.logger.Info("Redis available again")
+		}
+		b.failed = 0
+		b.openUntil = time.Time{}
+		b.probing = false
+	case b.probing:
+		// The probe failed; wait out another cooldown
+		b.probing = false
+		b.openUntil = b.now().Add(b.cooldown)
+	case b.openUntil.IsZero():
+		b.failed++
+		if b.failed >= b.failures {
+			b.open(err)
+		}
+	}
+}
+
+// open refuses commands for the cooldown. Callers hold mu.
+func (b *RedisBreaker) open(err error) {
+	if b.openUntil.IsZero() {
+		b.logger.Warn("Redis unavailable, bypassing it", "error", err, "retry_in", b.cooldown)
+	}
+	b.openUntil = b.now().Add(b.cooldown)
+	b.probing = false
+}
+
+// isRedisFailure reports whether err means Redis could not be reached.
+// Misses and error replies come from a working server.
+func isRedisFailure(err error) bool {
+	if err == nil || err == redis.Nil {
+		return false
+	}
+	var reply redis.Error
+	return !errors.As(err, &reply)
+}
+
+// DialHook passes dials through; failures surface on the commands
+func (b *RedisBreaker) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		return next(ctx, network, addr)
+	}
+}
+
+// ProcessHook refuses commands while the breaker is open and records the
+// outcome of the ones sent
+func (b *RedisBreaker) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		if !b.allow() {
+			return ErrRedisUnavailable
+		}
+		err := next(ctx, cmd)
+		b.record(ctx, err)
+		return err
+	}
+}
+
+// ProcessPipelineHook does the same for pipelines, failing every command
+// in a refused pipeline
+func (b *RedisBreaker) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		if !b.allow() {
+			for _, cmd := range cmds {
+				cmd.SetErr(ErrRedisUnavailable)
+			}
+			return ErrRedisUnavailable
+		}
+		err := next(ctx, cmds)
+		b.record(ctx, err)
+		return err
+	}
+}
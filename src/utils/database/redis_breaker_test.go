@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestRedisBreakerOpensAndRecovers(t *testing.T) {
+	now := time.Unix(0, 0)
+	breaker := NewRedisBreaker(2, time.Second, utils.NewLogger())
+	breaker.now = func() time.Time { return now }
+
+	var redisErr error
+	process := breaker.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error { return redisErr })
+	ctx := context.Background()
+	cmd := func() redis.Cmder { return redis.NewStatusCmd(ctx, "ping") }
+
+	redisErr = errors.New("connection refused")
+	process(ctx, cmd())
+	if !breaker.Available() {
+		t.Fatalf("breaker opened after one failure")
+	}
+	process(ctx, cmd())
+	if breaker.Available() {
+		t.Fatalf("breaker still closed after two failures")
+	}
+	if err := process(ctx, cmd()); !errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("open breaker sent a command: %v", err)
+	}
+
+	// A failed probe waits out another cooldown
+	now = now.Add(time.Second)
+	if err := process(ctx, cmd()); errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("no probe after the cooldown")
+	}
+	if err := process(ctx, cmd()); !errors.Is(err, ErrRedisUnavailable) {
+		t.Fatalf("command sent after a failed probe: %v", err)
+	}
+
+	redisErr = nil
+	now = now.Add(time.Second)
+	if err := process(ctx, cmd()); err != nil {
+		t.Fatalf("probe: %v", err)
+	}
+	if !breaker.Available() {
+		t.Fatalf("breaker still open after a successful probe")
+	}
+}
+
+func TestRedisBreakerIgnoresMissesAndReplies(t *testing.T) {
+	breaker := NewRedisBreaker(1, time.Second, utils.NewLogger())
+	ctx := context.Background()
+
+	for _, err := range []error{redis.Nil, redis.TxFailedErr} {
+		process := breaker.ProcessHook(func(ctx context.Context, cmd redis.Cmder) error { return err })
+		process(ctx, redis.NewStatusCmd(ctx, "get"))
+	}
+	if !breaker.Available() {
+		t.Fatalf("breaker opened on answers from a working server")
+	}
+}
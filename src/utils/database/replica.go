@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// replicaLagQuery measures how far a replica's replay is behind. A replica
+// that has replayed everything it received is not lagging, however long
+// ago the primary last wrote.
+const replicaLagQuery = `
+	SELECT COALESCE(CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())
+	END, 0)`
+
+// Replica is a read replica that lag-tolerant reads are sent to while it
+// answers and stays within the allowed lag of the primary
+type Replica struct {
+	DB     *sqlx.DB
+	maxLag time.Duration
+
+	healthy atomic.Bool
+	lag     atomic.Int64 // nanoseconds, as last measured
+}
+
+// NewReplica connects to the replica at DB_READ_DSN, or returns nil when
+// none is configured. A replica that cannot be reached at startup is
+// still returned, unhealthy, and used once a check succeeds. Its
+// statements are recorded in queries alongside the primary's.
+func NewReplica(config *utils.Config, queries *QueryStats) (*Replica, error) {
+	if config.DBReadDSN == "" {
+		return nil, nil
+	}
+
+	db, err := openPostgres(config.DBReadDSN, queries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PostgreSQL replica: %w", err)
+	}
+
+	configurePool(db, config)
+
+	r := &Replica{
+		DB:     db,
+		maxLag: time.Duration(config.DBReplicaMaxLagMs) * time.Millisecond,
+	}
+	r.Check(context.Background())
+	return r, nil
+}
+
+// Conn returns the replica's connection pool
+func (r *Replica) Conn() *sqlx.DB {
+	return r.DB
+}
+
+// Healthy reports whether the last check found the replica answering and
+// within the allowed lag
+func (r *Replica) Healthy() bool {
+	return r.healthy.Load()
+}
+
+// Check measures the replica's lag and updates its health
+func (r *Replica) Check(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var seconds float64
+	if err := r.DB.GetContext(ctx, &seconds, replicaLagQuery); err != nil {
+		r.healthy.Store(false)
+		return fmt.Errorf("failed to check replica: %w", err)
+	}
+	lag := time.Duration(seconds * float64(time.Second))
+	r.lag.Store(int64(lag))
+	if lag > r.maxLag {
+		r.healthy.Store(false)
+		return fmt.Errorf("replica is %s behind, more than %s", lag, r.maxLag)
+	}
+	r.healthy.Store(true)
+	return nil
+}
+
+// Monitor checks the replica every interval until ctx is cancelled,
+// logging when reads move off or back onto it
+func (r *Replica) Monitor(ctx context.Context, interval time.Duration, logger *utils.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			wasHealthy := r.Healthy()
+			err := r.Check(ctx)
+			switch {
+			case err != nil && wasHealthy:
+				logger.Warn("Read replica unhealthy, reading from primary", "error", err)
+			case err == nil && !wasHealthy:
+				logger.Info("Read replica healthy, reading from replica", "lag", time.Duration(r.lag.Load()))
+			}
+		}
+	}
+}
+
+// Close closes the replica's connections
+func (r *Replica) Close() error {
+	return r.DB.Close()
+}
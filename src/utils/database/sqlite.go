@@ -0,0 +1,304 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteBusyTimeout is how long a statement waits for another connection's
+// write transaction to finish before failing
+const sqliteBusyTimeout = 5 * time.Second
+
+// sqliteTimeFormat is how times are stored. Times are written in UTC, so
+// they sort as text in time order.
+var sqliteTimeFormat = sqlite3.SQLiteTimestampFormats[0]
+
+// sqliteTimestamp matches the text of a stored time: the format above, or
+// CURRENT_TIMESTAMP's for column defaults
+var sqliteTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}(\.\d+)?(\+00:00)?$`)
+
+// The Postgres syntax the repositories use that SQLite lacks
+var (
+	sqliteAnyParam = regexp.MustCompile(`=\s*ANY\((\$\d+)\)`)
+	sqliteCast     = regexp.MustCompile(`::[A-Za-z]+`)
+	sqliteRowLock  = regexp.MustCompile(`\s+FOR UPDATE(\s+SKIP LOCKED)?`)
+	sqliteILike    = regexp.MustCompile(`\bILIKE\b`)
+	sqliteParam    = regexp.MustCompile(`\$(\d+)`)
+)
+
+// SQLiteClient represents a SQLite database kept in a single file, for
+// installations too small to run Postgres
+type SQLiteClient struct {
+	DB      *sqlx.DB
+	Queries *QueryStats
+}
+
+// NewSQLiteClient opens the database at DB_SQLITE_PATH, creating it if
+// missing. Statements written for Postgres are translated as they are
+// sent, and timed per repository operation like Postgres's.
+func NewSQLiteClient(config *utils.Config, logger *utils.Logger) (*SQLiteClient, error) {
+	queries := NewQueryStats(time.Duration(config.DBSlowQueryMs)*time.Millisecond, logger)
+
+	// Write transactions take the lock as they begin, so two of them never
+	// deadlock upgrading a read lock
+	dsn := fmt.Sprintf("%s?_foreign_keys=on&_journal_mode=WAL&_txlock=immediate&_busy_timeout=%d",
+		config.DBSQLitePath, sqliteBusyTimeout.Milliseconds())
+	connector := &sqliteConnector{
+		dsn:   dsn,
+		stats: queries,
+		driver: &sqlite3.SQLiteDriver{ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			if err := conn.RegisterFunc("now", func() string { return time.Now().UTC().Format(sqliteTimeFormat) }, false); err != nil {
+				return err
+			}
+			return conn.RegisterFunc("date_trunc", sqliteDateTrunc, true)
+		}},
+	}
+	db := sqlx.NewDb(sql.OpenDB(connector), "sqlite3")
+
+	configurePool(db, config)
+
+	// Test connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open SQLite database %s: %w", config.DBSQLitePath, err)
+	}
+
+	return &SQLiteClient{DB: db, Queries: queries}, nil
+}
+
+// Close closes the database
+func (c *SQLiteClient) Close() error {
+	return c.DB.Close()
+}
+
+// Ping tests the database connection
+func (c *SQLiteClient) Ping(ctx context.Context) error {
+	return c.DB.PingContext(ctx)
+}
+
+// sqliteDateTrunc is Postgres's date_trunc over a stored time, for the
+// units reports group by. Weeks start on Monday.
+func sqliteDateTrunc(unit, stored string) (string, error) {
+	var t time.Time
+	var err error
+	for _, format := range sqlite3.SQLiteTimestampFormats {
+		if t, err = time.ParseInLocation(format, stored, time.UTC); err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return "", fmt.Errorf("date_trunc: %q is not a time", stored)
+	}
+
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch unit {
+	case "hour":
+		t = t.Truncate(time.Hour)
+	case "day":
+		t = day
+	case "week":
+		t = day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case "month":
+		t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return "", fmt.Errorf("date_trunc: unit %q not supported", unit)
+	}
+	return t.Format(sqliteTimeFormat), nil
+}
+
+// sqliteQueries caches translated statements, which repositories send
+// over and over
+var sqliteQueries sync.Map
+
+// sqliteQuery translates a statement written for Postgres. Arrays are sent
+// as JSON, so ANY over one becomes a lookup in its elements, and numbered
+// parameters become SQLite's. Casts and row locks are dropped: SQLite
+// types values as they are stored, and a write transaction locks the
+// whole database.
+func sqliteQuery(query string) string {
+	if translated, ok := sqliteQueries.Load(query); ok {
+		return translated.(string)
+	}
+	translated := sqliteAnyParam.ReplaceAllString(query, "IN (SELECT value FROM json_each($1))")
+	translated = sqliteCast.ReplaceAllString(translated, "")
+	translated = sqliteRowLock.ReplaceAllString(translated, "")
+	translated = sqliteILike.ReplaceAllString(translated, "LIKE")
+	translated = sqliteParam.ReplaceAllString(translated, "?$1")
+	sqliteQueries.Store(query, translated)
+	return translated
+}
+
+// sqliteArray returns the elements of an array bound with pq.Array
+func sqliteArray(value interface{}) (interface{}, bool) {
+	switch v := value.(type) {
+	case pq.GenericArray:
+		return v.A, true
+	case *pq.StringArray:
+		return []string(*v), true
+	case *pq.Int64Array:
+		return []int64(*v), true
+	}
+	return nil, false
+}
+
+// sqliteConnector opens SQLite connections that translate statements and
+// report to stats
+type sqliteConnector struct {
+	dsn    string
+	driver *sqlite3.SQLiteDriver
+	stats  *QueryStats
+}
+
+func (c *sqliteConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteConn{conn: conn.(*sqlite3.SQLiteConn), stats: c.stats}, nil
+}
+
+func (c *sqliteConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// sqliteConn translates and times the statements run on a SQLite
+// connection
+type sqliteConn struct {
+	conn  *sqlite3.SQLiteConn
+	stats *QueryStats
+}
+
+// CheckNamedValue sends arrays as JSON and times in UTC
+func (c *sqliteConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if elements, ok := sqliteArray(nv.Value); ok {
+		encoded, err := json.Marshal(elements)
+		if err != nil {
+			return err
+		}
+		nv.Value = string(encoded)
+		return nil
+	}
+
+	value, err := driver.DefaultParameterConverter.ConvertValue(nv.Value)
+	if err != nil {
+		return err
+	}
+	if t, ok := value.(time.Time); ok {
+		value = t.UTC()
+	}
+	nv.Value = value
+	return nil
+}
+
+func (c *sqliteConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := c.conn.QueryContext(ctx, sqliteQuery(query), args)
+	c.stats.observe(query, time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows}, nil
+}
+
+func (c *sqliteConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	start := time.Now()
+	result, err := c.conn.ExecContext(ctx, sqliteQuery(query), args)
+	c.stats.observe(query, time.Since(start), err)
+	return result, err
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+func (c *sqliteConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	stmt, err := c.conn.PrepareContext(ctx, sqliteQuery(query))
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteStmt{Stmt: stmt}, nil
+}
+
+func (c *sqliteConn) Begin() (driver.Tx, error) {
+	return c.conn.Begin()
+}
+
+func (c *sqliteConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	return c.conn.BeginTx(ctx, opts)
+}
+
+func (c *sqliteConn) Close() error {
+	return c.conn.Close()
+}
+
+func (c *sqliteConn) Ping(ctx context.Context) error {
+	return c.conn.Ping(ctx)
+}
+
+// sqliteStmt is a prepared statement whose rows read back stored times
+type sqliteStmt struct {
+	driver.Stmt
+}
+
+func (s *sqliteStmt) Query(args []driver.Value) (driver.Rows, error) {
+	rows, err := s.Stmt.Query(args)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows}, nil
+}
+
+func (s *sqliteStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	rows, err := s.Stmt.(driver.StmtQueryContext).QueryContext(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteRows{Rows: rows}, nil
+}
+
+func (s *sqliteStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.Stmt.(driver.StmtExecContext).ExecContext(ctx, args)
+}
+
+// sqliteRows reads stored times back as times, which SQLite only does for
+// columns declared as timestamps, not for expressions over them. Other text
+// is read as bytes, as pq reads JSON, so it scans into json.RawMessage.
+type sqliteRows struct {
+	driver.Rows
+}
+
+func (r *sqliteRows) Next(dest []driver.Value) error {
+	if err := r.Rows.Next(dest); err != nil {
+		return err
+	}
+	for i, value := range dest {
+		s, ok := value.(string)
+		if !ok {
+			continue
+		}
+		dest[i] = []byte(s)
+		if !sqliteTimestamp.MatchString(s) {
+			continue
+		}
+		for _, format := range sqlite3.SQLiteTimestampFormats {
+			if t, err := time.ParseInLocation(format, s, time.UTC); err == nil {
+				dest[i] = t
+				break
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+// Package health checks what an instance depends on to serve traffic, and
+// the heartbeats of its background workers, for readiness probes.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Status of a single dependency
+type Status string
+
+const (
+	StatusUp   Status = "up"
+	StatusDown Status = "down"
+)
+
+// Overall readiness of an instance
+const (
+	ReadinessReady       = "ready"       // every dependency is up
+	ReadinessDegraded    = "degraded"    // a non-critical dependency is down; traffic is still served
+	ReadinessUnavailable = "unavailable" // a critical dependency is down
+)
+
+// CheckFunc reports whether a dependency can be used; nil means it can
+type CheckFunc func(ctx context.Context) error
+
+type check struct {
+	name     string
+	critical bool
+	fn       CheckFunc
+}
+
+// CheckResult is the outcome of one dependency check
+type CheckResult struct {
+	Status    Status     `json:"status"`
+	Critical  bool       `json:"critical"`
+	LatencyMs int64      `json:"latency_ms"`
+	LastBeat  *time.Time `json:"last_beat,omitempty"` // workers only
+	Error     string     `json:"error,omitempty"`
+}
+
+// Report is the outcome of every check
+type Report struct {
+	Status    string                 `json:"status"`
+	Checks    map[string]CheckResult `json:"checks"`
+	CheckedAt time.Time              `json:"checked_at"`
+}
+
+// Ready reports whether the instance should be sent traffic
+func (r Report) Ready() bool {
+	return r.Status != ReadinessUnavailable
+}
+
+// Checker runs the registered dependency checks and reads worker
+// heartbeats. Checks run concurrently, each bounded by the timeout.
+type Checker struct {
+	timeout    time.Duration
+	heartbeats *Heartbeats
+
+	mu     sync.Mutex
+	checks []check
+}
+
+// NewChecker creates a checker. A nil heartbeats leaves workers unchecked.
+func NewChecker(timeout time.Duration, heartbeats *Heartbeats) *Checker {
+	return &Checker{timeout: timeout, heartbeats: heartbeats}
+}
+
+// Add registers a dependency check. A critical dependency being down makes
+// the instance unavailable; any other only degrades it.
+func (c *Checker) Add(name string, critical bool, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, check{name: name, critical: critical, fn: fn})
+}
+
+// Check runs every check and reads the heartbeats
+func (c *Checker) Check(ctx context.Context) Report {
+	c.mu.Lock()
+	checks := append([]check(nil), c.checks...)
+	c.mu.Unlock()
+
+	results := make([]CheckResult, len(checks))
+	var wg sync.WaitGroup
+	for i, chk := range checks {
+		wg.Add(1)
+		go func(i int, chk check) {
+			defer wg.Done()
+			results[i] = c.run(ctx, chk)
+		}(i, chk)
+	}
+	wg.Wait()
+
+	now := time.Now()
+	report := Report{Status: ReadinessReady, Checks: make(map[string]CheckResult, len(checks)), CheckedAt: now.UTC()}
+	for i, chk := range checks {
+		report.Checks[chk.name] = results[i]
+	}
+	if c.heartbeats != nil {
+		for name, result := range c.heartbeats.check(now) {
+			report.Checks["worker:"+name] = result
+		}
+	}
+
+	for _, result := range report.Checks {
+		if result.Status == StatusUp {
+			continue
+		}
+		if result.Critical {
+			report.Status = ReadinessUnavailable
+			break
+		}
+		report.Status = ReadinessDegraded
+	}
+	return report
+}
+
+// run runs one check under the timeout. A check still running when it
+// expires is reported down without waiting for it.
+func (c *Checker) run(ctx context.Context, chk check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() { done <- chk.fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = errors.New("timed out after " + c.timeout.String())
+	}
+
+	result := CheckResult{Status: StatusUp, Critical: chk.critical, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Heartbeats tracks when each background worker last went round its loop.
+// A worker silent for longer than maxAge is reported down, though never
+// critical: the instance can still serve traffic without it.
+type Heartbeats struct {
+	maxAge time.Duration
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+// NewHeartbeats creates a heartbeat registry
+func NewHeartbeats(maxAge time.Duration) *Heartbeats {
+	return &Heartbeats{maxAge: maxAge, last: make(map[string]time.Time)}
+}
+
+type contextKey struct{}
+
+type heartbeat struct {
+	registry *Heartbeats
+	name     string
+}
+
+// Start registers a worker as alive now and returns a context carrying its
+// heartbeat, for the worker to Beat with. A nil registry leaves ctx as is.
+func (h *Heartbeats) Start(ctx context.Context, name string) context.Context {
+	if h == nil {
+		return ctx
+	}
+	h.beat(name)
+	return context.WithValue(ctx, contextKey{}, heartbeat{registry: h, name: name})
+}
+
+// Beat records that the worker running under ctx is alive. Workers call it
+// each time round their loop; it does nothing for a ctx without a heartbeat.
+func Beat(ctx context.Context) {
+	if hb, ok := ctx.Value(contextKey{}).(heartbeat); ok {
+		hb.registry.beat(hb.name)
+	}
+}
+
+func (h *Heartbeats) beat(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.last[name] = time.Now()
+}
+
+// check reports each worker up unless its last beat is older than maxAge
+func (h *Heartbeats) check(now time.Time) map[string]CheckResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	results := make(map[string]CheckResult, len(h.last))
+	for name, last := range h.last {
+		last := last.UTC()
+		result := CheckResult{Status: StatusUp, LastBeat: &last}
+		if age := now.Sub(last); age > h.maxAge {
+			result.Status = StatusDown
+			result.Error = "no heartbeat for " + age.Round(time.Second).String()
+		}
+		results[name] = result
+	}
+	return results
+}
@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckerDegradesAndFailsReadiness(t *testing.T) {
+	heartbeats := NewHeartbeats(time.Minute)
+	checker := NewChecker(50*time.Millisecond, heartbeats)
+	ctx := context.Background()
+
+	var redisErr, dbErr error
+	checker.Add("postgres", true, func(ctx context.Context) error { return dbErr })
+	checker.Add("redis", false, func(ctx context.Context) error { return redisErr })
+	workerCtx := heartbeats.Start(ctx, "notifications")
+
+	report := checker.Check(ctx)
+	if report.Status != ReadinessReady || len(report.Checks) != 3 {
+		t.Fatalf("all up: got %s with %d checks", report.Status, len(report.Checks))
+	}
+	if beat := report.Checks["worker:notifications"]; beat.Status != StatusUp || beat.LastBeat == nil {
+		t.Errorf("fresh heartbeat: got %+v", beat)
+	}
+
+	// A non-critical dependency down only degrades readiness
+	redisErr = errors.New("connection refused")
+	report = checker.Check(ctx)
+	if report.Status != ReadinessDegraded || !report.Ready() || report.Checks["redis"].Error != "connection refused" {
+		t.Errorf("redis down: got %s, %+v", report.Status, report.Checks["redis"])
+	}
+
+	// A critical one makes the instance unavailable
+	dbErr = errors.New("connection refused")
+	if report = checker.Check(ctx); report.Status != ReadinessUnavailable || report.Ready() {
+		t.Errorf("database down: got %s", report.Status)
+	}
+
+	// A hung check is cut off at the timeout
+	dbErr, redisErr = nil, nil
+	checker.Add("slow", false, func(ctx context.Context) error {
+		time.Sleep(time.Second)
+		return nil
+	})
+	start := time.Now()
+	report = checker.Check(ctx)
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("hung check took %s", elapsed)
+	}
+	if report.Status != ReadinessDegraded || report.Checks["slow"].Status != StatusDown {
+		t.Errorf("hung check: got %s, %+v", report.Status, report.Checks["slow"])
+	}
+
+	// Workers that stop beating are reported down; Beat revives them
+	stale := heartbeats.check(time.Now().Add(2 * time.Minute))
+	if stale["notifications"].Status != StatusDown {
+		t.Errorf("stale heartbeat: got %+v", stale["notifications"])
+	}
+	Beat(workerCtx)
+	Beat(ctx) // no heartbeat in ctx; ignored
+	if fresh := heartbeats.check(time.Now()); fresh["notifications"].Status != StatusUp || len(fresh) != 1 {
+		t.Errorf("after beat: got %+v", fresh)
+	}
+}
@@ -0,0 +1,127 @@
+// Package ical writes iCalendar (RFC 5545) files: calendars of timed events
+// that calendar apps import once or subscribe to as a feed.
+package ical
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ProdID identifies the product that wrote a calendar
+const ProdID = "-//booking-manager//Events//EN"
+
+// maxLineOctets is the longest a content line may be before it is folded
+const maxLineOctets = 75
+
+const timeFormat = "20060102T150405Z"
+
+// Calendar is a set of events
+type Calendar struct {
+	Name string // shown by calendar apps for a subscribed feed
+
+	// How often subscribers should fetch the feed again, zero to leave it
+	// to the calendar app
+	RefreshInterval time.Duration
+
+	Events []Event
+}
+
+// Event is a timed event of a calendar
+type Event struct {
+	// UID identifies the event across versions of the calendar, so a
+	// changed event replaces the copy a subscriber has
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Location    string
+	Description string
+	Updated     time.Time // when the event last changed
+}
+
+// Bytes serializes the calendar, with CRLF line endings and long lines
+// folded
+func (c *Calendar) Bytes() []byte {
+	var out bytes.Buffer
+	line := func(name, value string) {
+		fold(&out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escape(c.Name))
+	}
+	if c.RefreshInterval > 0 {
+		interval := duration(c.RefreshInterval)
+		line("REFRESH-INTERVAL;VALUE=DURATION", interval)
+		line("X-PUBLISHED-TTL", interval)
+	}
+	for _, event := range c.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("DTSTAMP", event.Updated.UTC().Format(timeFormat))
+		line("LAST-MODIFIED", event.Updated.UTC().Format(timeFormat))
+		line("DTSTART", event.Start.UTC().Format(timeFormat))
+		line("DTEND", event.End.UTC().Format(timeFormat))
+		line("SUMMARY", escape(event.Summary))
+		if event.Location != "" {
+			line("LOCATION", escape(event.Location))
+		}
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		line("STATUS", "CONFIRMED")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return out.Bytes()
+}
+
+// escape escapes a TEXT value: backslashes, semicolons, commas and line
+// breaks
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// fold writes a content line, breaking it every 75 octets with a CRLF and a
+// space. Lines are never broken inside a UTF-8 character.
+func fold(out *bytes.Buffer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		out.WriteString(line[:cut])
+		out.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space counts towards the next line's length
+		limit = maxLineOctets - 1
+	}
+	out.WriteString(line)
+	out.WriteString("\r\n")
+}
+
+// duration formats a whole number of minutes as an RFC 5545 duration
+func duration(d time.Duration) string {
+	minutes := int64(d / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	var sb strings.Builder
+	sb.WriteString("PT")
+	if hours := minutes / 60; hours > 0 {
+		sb.WriteString(strconv.FormatInt(hours, 10) + "H")
+	}
+	if minutes%60 > 0 {
+		sb.WriteString(strconv.FormatInt(minutes%60, 10) + "M")
+	}
+	return sb.String()
+}
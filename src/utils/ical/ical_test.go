@@ -0,0 +1,56 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestBytesEscapesAndFoldsLines(t *testing.T) {
+	start := time.Date(2026, 7, 1, 20, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+	cal := &Calendar{
+		Name:            "Summer Jam",
+		RefreshInterval: 90 * time.Minute,
+		Events: []Event{{
+			UID:         "booking-1@booking-manager",
+			Start:       start,
+			End:         start.Add(3 * time.Hour),
+			Summary:     "Summer Jam; Night 2, encore",
+			Location:    `Arena \ Hall B`,
+			Description: strings.Repeat("Café ", 30) + "\nSeats 1, 2",
+			Updated:     start.Add(-24 * time.Hour),
+		}},
+	}
+	out := string(cal.Bytes())
+
+	if !strings.HasPrefix(out, "BEGIN:VCALENDAR\r\n") || !strings.HasSuffix(out, "END:VCALENDAR\r\n") {
+		t.Fatalf("not a CRLF-terminated calendar:\n%s", out)
+	}
+	for _, want := range []string{
+		"\r\nREFRESH-INTERVAL;VALUE=DURATION:PT1H30M\r\n",
+		"\r\nDTSTART:20260701T180000Z\r\n",
+		"\r\nDTEND:20260701T210000Z\r\n",
+		"\r\nSUMMARY:Summer Jam\\; Night 2\\, encore\r\n",
+		"\r\nLOCATION:Arena \\\\ Hall B\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+
+	// Unfolding restores the description, and no line runs past 75 octets
+	// or splits a character
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line of %d octets: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits a character: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if want := "DESCRIPTION:" + strings.Repeat("Café ", 30) + `\nSeats 1\, 2` + "\r\n"; !strings.Contains(unfolded, want) {
+		t.Errorf("unfolded calendar is missing %q", want)
+	}
+}
@@ -0,0 +1,55 @@
+package messaging
+
+import (
+	"context"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Message is a keyed record handed to the broker
+type Message struct {
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// KafkaPublisher writes messages to a single Kafka topic
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher creates a publisher for the configured brokers and topic.
+// Messages are hashed on their key so each key keeps its order.
+func NewKafkaPublisher(config *utils.Config) *KafkaPublisher {
+	return &KafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(config.OutboxKafkaBrokers...),
+			Topic:        config.OutboxKafkaTopic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+			BatchSize:    config.OutboxBatchSize,
+			BatchTimeout: 10 * time.Millisecond,
+		},
+	}
+}
+
+// Publish writes the messages and returns once every one is acknowledged
+func (p *KafkaPublisher) Publish(ctx context.Context, messages []Message) error {
+	records := make([]kafka.Message, len(messages))
+	for i, m := range messages {
+		headers := make([]kafka.Header, 0, len(m.Headers))
+		for k, v := range m.Headers {
+			headers = append(headers, kafka.Header{Key: k, Value: []byte(v)})
+		}
+		records[i] = kafka.Message{Key: m.Key, Value: m.Value, Headers: headers}
+	}
+	return p.writer.WriteMessages(ctx, records...)
+}
+
+// Close flushes pending writes and closes broker connections
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,56 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// Email is a rendered message ready to be sent
+type Email struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Notifier delivers emails through a provider
+type Notifier interface {
+	Send(ctx context.Context, email Email) error
+}
+
+// NewNotifier creates the notifier selected by NOTIFY_PROVIDER
+func NewNotifier(config *utils.Config, logger *utils.Logger) (Notifier, error) {
+	from := mail.Address{Name: config.NotifyFromName, Address: config.NotifyFromAddress}
+
+	switch config.NotifyProvider {
+	case "", "log":
+		return &logNotifier{logger: logger}, nil
+	case "smtp":
+		return newSMTPNotifier(config, from), nil
+	case "ses":
+		if config.AWSAccessKeyID == "" || config.AWSSecretAccessKey == "" {
+			return nil, fmt.Errorf("ses notifier requires AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY")
+		}
+		return newSESNotifier(config, from), nil
+	case "sendgrid":
+		if config.SendGridAPIKey == "" {
+			return nil, fmt.Errorf("sendgrid notifier requires SENDGRID_API_KEY")
+		}
+		return newSendGridNotifier(config, from), nil
+	}
+	return nil, fmt.Errorf("unknown notify provider %q", config.NotifyProvider)
+}
+
+// logNotifier writes emails to the log instead of sending them; used in development
+type logNotifier struct {
+	logger *utils.Logger
+}
+
+func (n *logNotifier) Send(ctx context.Context, email Email) error {
+	n.logger.Info("Email notification", "to", email.To, "subject", email.Subject)
+	n.logger.Debug(email.Text)
+	return nil
+}
@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+const sendGridEndpoint = "https://api.sendgrid.com/v3/mail/send"
+
+// sendGridNotifier sends emails through the SendGrid v3 mail API
+type sendGridNotifier struct {
+	apiKey string
+	from   mail.Address
+	client *http.Client
+}
+
+func newSendGridNotifier(config *utils.Config, from mail.Address) *sendGridNotifier {
+	return &sendGridNotifier{
+		apiKey: config.SendGridAPIKey,
+		from:   from,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridPersonalization struct {
+	To []sendGridAddress `json:"to"`
+}
+
+type sendGridRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+}
+
+func (n *sendGridNotifier) Send(ctx context.Context, email Email) error {
+	payload := sendGridRequest{
+		Personalizations: []sendGridPersonalization{{To: []sendGridAddress{{Email: email.To}}}},
+		From:             sendGridAddress{Email: n.from.Address, Name: n.from.Name},
+		Subject:          email.Subject,
+	}
+	// SendGrid requires text/plain to come before text/html
+	if email.Text != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/plain", Value: email.Text})
+	}
+	if email.HTML != "" {
+		payload.Content = append(payload.Content, sendGridContent{Type: "text/html", Value: email.HTML})
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sendGridEndpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+n.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("sendgrid responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(detail))
+	}
+	return nil
+}
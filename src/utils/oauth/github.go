@@ -0,0 +1,83 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+const (
+	githubAuthEndpoint   = "https://github.com/login/oauth/authorize"
+	githubTokenEndpoint  = "https://github.com/login/oauth/access_token"
+	githubUserEndpoint   = "https://api.github.com/user"
+	githubEmailsEndpoint = "https://api.github.com/user/emails"
+)
+
+// githubProvider signs users in with GitHub, which speaks OAuth2 but not
+// OpenID Connect. The account's email is its primary one, which the
+// profile only shows when the user made it public, so it is read from the
+// emails endpoint with whether GitHub verified it.
+type githubProvider struct {
+	client
+	userURL   string
+	emailsURL string
+}
+
+func newGitHubProvider(clientID, clientSecret string) *githubProvider {
+	return &githubProvider{
+		client:    newClient(clientID, clientSecret, githubAuthEndpoint, githubTokenEndpoint, "read:user", "user:email"),
+		userURL:   githubUserEndpoint,
+		emailsURL: githubEmailsEndpoint,
+	}
+}
+
+func (p *githubProvider) Name() string {
+	return "github"
+}
+
+func (p *githubProvider) AuthCodeURL(state, challenge, redirectURL string) string {
+	return p.authCodeURL(state, challenge, redirectURL)
+}
+
+type githubUser struct {
+	ID    int64  `json:"id"`
+	Login string `json:"login"`
+	Name  string `json:"name"`
+}
+
+type githubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
+}
+
+func (p *githubProvider) Identify(ctx context.Context, code, verifier, redirectURL string) (*Identity, error) {
+	accessToken, err := p.exchange(ctx, code, verifier, redirectURL)
+	if err != nil {
+		return nil, err
+	}
+	var user githubUser
+	if err := p.get(ctx, p.userURL, accessToken, &user); err != nil {
+		return nil, err
+	}
+	if user.ID == 0 {
+		return nil, fmt.Errorf("github user has no id")
+	}
+	var emails []githubEmail
+	if err := p.get(ctx, p.emailsURL, accessToken, &emails); err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{Subject: strconv.FormatInt(user.ID, 10), Name: user.Name}
+	if identity.Name == "" {
+		identity.Name = user.Login
+	}
+	for _, email := range emails {
+		if email.Primary {
+			identity.Email = email.Email
+			identity.EmailVerified = email.Verified
+			break
+		}
+	}
+	return identity, nil
+}
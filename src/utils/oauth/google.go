@@ -0,0 +1,62 @@
+package oauth
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	googleAuthEndpoint     = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenEndpoint    = "https://oauth2.googleapis.com/token"
+	googleUserInfoEndpoint = "https://openidconnect.googleapis.com/v1/userinfo"
+)
+
+// googleProvider signs users in with Google through OpenID Connect. The
+// account is read from the userinfo endpoint with the access token, which
+// came straight from Google over TLS, so the ID token need not be verified.
+type googleProvider struct {
+	client
+	userInfoURL string
+}
+
+func newGoogleProvider(clientID, clientSecret string) *googleProvider {
+	return &googleProvider{
+		client:      newClient(clientID, clientSecret, googleAuthEndpoint, googleTokenEndpoint, "openid", "email", "profile"),
+		userInfoURL: googleUserInfoEndpoint,
+	}
+}
+
+func (p *googleProvider) Name() string {
+	return "google"
+}
+
+func (p *googleProvider) AuthCodeURL(state, challenge, redirectURL string) string {
+	return p.authCodeURL(state, challenge, redirectURL)
+}
+
+type googleUserInfo struct {
+	Subject       string `json:"sub"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+}
+
+func (p *googleProvider) Identify(ctx context.Context, code, verifier, redirectURL string) (*Identity, error) {
+	accessToken, err := p.exchange(ctx, code, verifier, redirectURL)
+	if err != nil {
+		return nil, err
+	}
+	var info googleUserInfo
+	if err := p.get(ctx, p.userInfoURL, accessToken, &info); err != nil {
+		return nil, err
+	}
+	if info.Subject == "" {
+		return nil, fmt.Errorf("google userinfo has no subject")
+	}
+	return &Identity{
+		Subject:       info.Subject,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		Name:          info.Name,
+	}, nil
+}
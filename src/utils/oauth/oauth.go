@@ -0,0 +1,161 @@
+// Package oauth signs users in through external OAuth2 / OpenID Connect
+// providers. Each provider runs the authorization code flow with PKCE and
+// reports the account that signed in; linking it to a user is up to the
+// caller.
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// ErrRejected is returned when the provider turns down the sign-in, such as
+// for a code that is unknown, expired or already used
+var ErrRejected = errors.New("sign-in rejected by provider")
+
+// Identity is the account at a provider that signed in
+type Identity struct {
+	Subject       string // the provider's ID for the account
+	Email         string
+	EmailVerified bool // whether the provider has checked the account owns the email
+	Name          string
+}
+
+// Provider is an external sign-in provider
+type Provider interface {
+	// Name is how the provider is referred to in routes and stored identities
+	Name() string
+	// AuthCodeURL is where to send the user to sign in. The provider sends
+	// them back to redirectURL with state and a code.
+	AuthCodeURL(state, challenge, redirectURL string) string
+	// Identify swaps a code for the account that signed in. verifier is the
+	// PKCE verifier the challenge was derived from.
+	Identify(ctx context.Context, code, verifier, redirectURL string) (*Identity, error)
+}
+
+// NewProviders creates the providers with a client ID configured, by name
+func NewProviders(config *utils.Config) map[string]Provider {
+	providers := make(map[string]Provider)
+	if config.OAuthGoogleClientID != "" {
+		p := newGoogleProvider(config.OAuthGoogleClientID, config.OAuthGoogleClientSecret)
+		providers[p.Name()] = p
+	}
+	if config.OAuthGitHubClientID != "" {
+		p := newGitHubProvider(config.OAuthGitHubClientID, config.OAuthGitHubClientSecret)
+		providers[p.Name()] = p
+	}
+	return providers
+}
+
+// client is the OAuth2 half the providers share: the authorization and
+// token endpoints of the authorization code flow
+type client struct {
+	clientID     string
+	clientSecret string
+	authURL      string
+	tokenURL     string
+	scopes       []string
+	http         *http.Client
+}
+
+func newClient(clientID, clientSecret, authURL, tokenURL string, scopes ...string) client {
+	return client{
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		authURL:      authURL,
+		tokenURL:     tokenURL,
+		scopes:       scopes,
+		http:         &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+func (c *client) authCodeURL(state, challenge, redirectURL string) string {
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", c.clientID)
+	query.Set("redirect_uri", redirectURL)
+	query.Set("scope", strings.Join(c.scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", challenge)
+	query.Set("code_challenge_method", "S256")
+	return c.authURL + "?" + query.Encode()
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// exchange swaps a code for an access token to the provider's API
+func (c *client) exchange(ctx context.Context, code, verifier, redirectURL string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURL)
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+	form.Set("code_verifier", verifier)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var token tokenResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil {
+		return "", fmt.Errorf("failed to decode token response (status %d): %w", resp.StatusCode, err)
+	}
+	// GitHub reports a bad code with 200 and an error field
+	if token.Error != "" {
+		return "", fmt.Errorf("%w: %s: %s", ErrRejected, token.Error, token.ErrorDescription)
+	}
+	if resp.StatusCode >= 500 {
+		return "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+	if resp.StatusCode != http.StatusOK || token.AccessToken == "" {
+		return "", fmt.Errorf("%w: token request returned status %d", ErrRejected, resp.StatusCode)
+	}
+	return token.AccessToken, nil
+}
+
+// get reads a JSON resource of the provider's API on the user's behalf
+func (c *client) get(ctx context.Context, endpoint, accessToken string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s returned status %d", endpoint, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", endpoint, err)
+	}
+	return nil
+}
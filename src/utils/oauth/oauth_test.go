@@ -0,0 +1,78 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestGitHubIdentifiesThePrimaryEmail(t *testing.T) {
+	var form url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		form = r.PostForm
+		w.Write([]byte(`{"access_token": "gho_abc", "token_type": "bearer"}`))
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gho_abc" {
+			t.Errorf("Authorization = %q, want Bearer gho_abc", r.Header.Get("Authorization"))
+		}
+		w.Write([]byte(`{"id": 583231, "login": "octocat", "name": ""}`))
+	})
+	mux.HandleFunc("/emails", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"email": "old@example.com", "primary": false, "verified": true}, {"email": "octo@example.com", "primary": true, "verified": true}]`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := newGitHubProvider("client", "secret")
+	provider.tokenURL, provider.userURL, provider.emailsURL = server.URL+"/token", server.URL+"/user", server.URL+"/emails"
+	provider.http = server.Client()
+
+	identity, err := provider.Identify(context.Background(), "code-1", "verifier-1", "https://api.example.com/callback")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if form.Get("code") != "code-1" || form.Get("code_verifier") != "verifier-1" || form.Get("client_secret") != "secret" || form.Get("redirect_uri") != "https://api.example.com/callback" {
+		t.Errorf("unexpected token request %v", form)
+	}
+	want := Identity{Subject: "583231", Email: "octo@example.com", EmailVerified: true, Name: "octocat"}
+	if *identity != want {
+		t.Errorf("identity = %+v, want %+v", *identity, want)
+	}
+}
+
+func TestRejectedCodesAreReported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error": "bad_verification_code", "error_description": "The code passed is incorrect or expired."}`))
+	}))
+	defer server.Close()
+
+	provider := newGoogleProvider("client", "secret")
+	provider.tokenURL = server.URL
+	provider.http = server.Client()
+
+	_, err := provider.Identify(context.Background(), "stale", "verifier", "https://api.example.com/callback")
+	if !errors.Is(err, ErrRejected) {
+		t.Fatalf("err = %v, want ErrRejected", err)
+	}
+}
+
+func TestAuthCodeURLAsksForPKCE(t *testing.T) {
+	provider := newGoogleProvider("client", "secret")
+	raw := provider.AuthCodeURL("state-1", "challenge-1", "https://api.example.com/callback")
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := parsed.Query()
+	if query.Get("state") != "state-1" || query.Get("code_challenge") != "challenge-1" || query.Get("code_challenge_method") != "S256" || query.Get("scope") != "openid email profile" {
+		t.Errorf("unexpected query %v", query)
+	}
+}
@@ -0,0 +1,77 @@
+package payments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+// Refund asks the payment provider to return money for an earlier payment
+type Refund struct {
+	ID               string // our refund ID, sent as the idempotency key
+	PaymentReference string // the provider's reference for the original payment
+	Amount           float64
+	Currency         string
+	Reason           string
+}
+
+// PaymentEvent is a successful payment reported by the provider's webhook
+type PaymentEvent struct {
+	BookingID        string `json:"booking_id"`
+	PaymentReference string `json:"payment_reference"`
+	RiskScore        *int   `json:"risk_score,omitempty"` // 0 (safe) to 100 (fraudulent); nil if the provider gave none
+}
+
+// ErrInvalidSignature is returned for webhooks that were not signed by the provider
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Provider moves money through a payment processor
+type Provider interface {
+	// Refund returns the provider's reference for the refund
+	Refund(ctx context.Context, refund Refund) (string, error)
+	// ParseWebhook verifies a webhook from the provider and returns the
+	// payment it reports, or nil for events that are not successful payments
+	ParseWebhook(payload []byte, header http.Header) (*PaymentEvent, error)
+}
+
+// NewProvider creates the payment provider selected by PAYMENT_PROVIDER
+func NewProvider(config *utils.Config, logger *utils.Logger) (Provider, error) {
+	switch config.PaymentProvider {
+	case "", "log":
+		return &logProvider{logger: logger}, nil
+	case "stripe":
+		if config.StripeSecretKey == "" {
+			return nil, fmt.Errorf("stripe payment provider requires STRIPE_SECRET_KEY")
+		}
+		return newStripeProvider(config), nil
+	}
+	return nil, fmt.Errorf("unknown payment provider %q", config.PaymentProvider)
+}
+
+// logProvider records refunds in the log instead of moving money; used in development
+type logProvider struct {
+	logger *utils.Logger
+}
+
+func (p *logProvider) Refund(ctx context.Context, refund Refund) (string, error) {
+	p.logger.Info("Refund issued",
+		"refund_id", refund.ID,
+		"payment_reference", refund.PaymentReference,
+		"amount", refund.Amount,
+		"currency", refund.Currency)
+	return "log_" + refund.ID, nil
+}
+
+// ParseWebhook accepts an unsigned PaymentEvent as JSON, so payments can be
+// simulated in development
+func (p *logProvider) ParseWebhook(payload []byte, header http.Header) (*PaymentEvent, error) {
+	var event PaymentEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode payment event: %w", err)
+	}
+	return &event, nil
+}
@@ -0,0 +1,191 @@
+package payments
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/utils"
+)
+
+const stripeRefundsEndpoint = "https://api.stripe.com/v1/refunds"
+
+// stripeSignatureTolerance is how far a webhook's signed timestamp may be from now
+const stripeSignatureTolerance = 5 * time.Minute
+
+// stripeZeroDecimal lists the currencies Stripe takes in whole units
+var stripeZeroDecimal = map[string]bool{
+	"BIF": true, "CLP": true, "DJF": true, "GNF": true, "JPY": true, "KMF": true, "KRW": true, "MGA": true,
+	"PYG": true, "RWF": true, "UGX": true, "VND": true, "VUV": true, "XAF": true, "XOF": true, "XPF": true,
+}
+
+// stripeProvider refunds payments through the Stripe Refunds API
+type stripeProvider struct {
+	secretKey     string
+	webhookSecret string
+	endpoint      string
+	client        *http.Client
+}
+
+func newStripeProvider(config *utils.Config) *stripeProvider {
+	return &stripeProvider{
+		secretKey:     config.StripeSecretKey,
+		webhookSecret: config.StripeWebhookSecret,
+		endpoint:      stripeRefundsEndpoint,
+		client:        &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+type stripeRefund struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	FailureReason string `json:"failure_reason"`
+}
+
+func (p *stripeProvider) Refund(ctx context.Context, refund Refund) (string, error) {
+	if refund.PaymentReference == "" {
+		return "", fmt.Errorf("stripe refund requires a payment reference")
+	}
+
+	form := url.Values{}
+	form.Set("payment_intent", refund.PaymentReference)
+	form.Set("amount", strconv.FormatInt(stripeAmount(refund.Amount, refund.Currency), 10))
+	form.Set("reason", "requested_by_customer")
+	form.Set("metadata[refund_id]", refund.ID)
+	if refund.Reason != "" {
+		form.Set("metadata[reason]", refund.Reason)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.secretKey, "")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	// Retrying the same refund never refunds twice
+	req.Header.Set("Idempotency-Key", refund.ID)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<16))
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("stripe responded with status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	var result stripeRefund
+	if err := json.Unmarshal(body, &result); err != nil {
+		return "", fmt.Errorf("failed to decode stripe refund: %w", err)
+	}
+	if result.Status == "failed" || result.Status == "canceled" {
+		return "", fmt.Errorf("stripe refund %s %s: %s", result.ID, result.Status, result.FailureReason)
+	}
+	return result.ID, nil
+}
+
+// stripeEvent is the part of a Stripe webhook event we read. Payments are
+// taken through PaymentIntents carrying the booking ID in their metadata,
+// which Stripe copies onto the charge.
+type stripeEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		Object struct {
+			ID            string            `json:"id"`
+			PaymentIntent string            `json:"payment_intent"`
+			Metadata      map[string]string `json:"metadata"`
+			Outcome       *struct {
+				RiskScore *int `json:"risk_score"`
+			} `json:"outcome"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// ParseWebhook reads charge.succeeded events, whose outcome carries Radar's risk score
+func (p *stripeProvider) ParseWebhook(payload []byte, header http.Header) (*PaymentEvent, error) {
+	if err := p.verifySignature(payload, header.Get("Stripe-Signature"), time.Now()); err != nil {
+		return nil, err
+	}
+
+	var event stripeEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode stripe event: %w", err)
+	}
+	if event.Type != "charge.succeeded" {
+		return nil, nil
+	}
+
+	charge := event.Data.Object
+	payment := &PaymentEvent{
+		BookingID:        charge.Metadata["booking_id"],
+		PaymentReference: charge.PaymentIntent,
+	}
+	if payment.PaymentReference == "" {
+		payment.PaymentReference = charge.ID
+	}
+	if charge.Outcome != nil {
+		payment.RiskScore = charge.Outcome.RiskScore
+	}
+	return payment, nil
+}
+
+// verifySignature checks a Stripe-Signature header of the form t=<unix>,v1=<hex>
+func (p *stripeProvider) verifySignature(payload []byte, header string, now time.Time) error {
+	if p.webhookSecret == "" {
+		return fmt.Errorf("%w: STRIPE_WEBHOOK_SECRET is not set", ErrInvalidSignature)
+	}
+
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	if now.Sub(time.Unix(unix, 0)).Abs() > stripeSignatureTolerance {
+		return fmt.Errorf("%w: timestamp is too old", ErrInvalidSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.webhookSecret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+	for _, signature := range signatures {
+		if sig, err := hex.DecodeString(signature); err == nil && hmac.Equal(sig, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// stripeAmount converts an amount to the currency's smallest unit
+func stripeAmount(amount float64, currency string) int64 {
+	if stripeZeroDecimal[strings.ToUpper(currency)] {
+		return int64(math.Round(amount))
+	}
+	return int64(math.Round(amount * 100))
+}
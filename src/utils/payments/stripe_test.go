@@ -0,0 +1,108 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestStripeRefundSendsAmountInMinorUnits(t *testing.T) {
+	var form map[string]string
+	var idempotencyKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "sk_test" {
+			t.Errorf("basic auth user = %q, want sk_test", user)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		idempotencyKey = r.Header.Get("Idempotency-Key")
+		w.Write([]byte(`{"id": "re_123", "status": "succeeded"}`))
+	}))
+	defer server.Close()
+
+	provider := &stripeProvider{secretKey: "sk_test", endpoint: server.URL, client: server.Client()}
+	reference, err := provider.Refund(context.Background(), Refund{
+		ID:               "refund-1",
+		PaymentReference: "pi_abc",
+		Amount:           19.99,
+		Currency:         "EUR",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reference != "re_123" {
+		t.Errorf("reference = %q, want re_123", reference)
+	}
+	if form["payment_intent"] != "pi_abc" || form["amount"] != "1999" || form["metadata[refund_id]"] != "refund-1" {
+		t.Errorf("unexpected form %v", form)
+	}
+	if idempotencyKey != "refund-1" {
+		t.Errorf("Idempotency-Key = %q, want refund-1", idempotencyKey)
+	}
+}
+
+func TestStripeRefundReportsFailedRefunds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "re_123", "status": "failed", "failure_reason": "expired_or_canceled_card"}`))
+	}))
+	defer server.Close()
+
+	provider := &stripeProvider{secretKey: "sk_test", endpoint: server.URL, client: server.Client()}
+	if _, err := provider.Refund(context.Background(), Refund{ID: "refund-1", PaymentReference: "pi_abc", Amount: 5, Currency: "USD"}); err == nil {
+		t.Error("expected an error for a failed refund")
+	}
+}
+
+func TestStripeAmountZeroDecimalCurrencies(t *testing.T) {
+	if got := stripeAmount(1500, "JPY"); got != 1500 {
+		t.Errorf("stripeAmount(1500, JPY) = %d, want 1500", got)
+	}
+	if got := stripeAmount(0.1+0.2, "USD"); got != 30 {
+		t.Errorf("stripeAmount(0.3, USD) = %d, want 30", got)
+	}
+}
+
+func TestStripeWebhookSignature(t *testing.T) {
+	provider := &stripeProvider{webhookSecret: "whsec_test"}
+	payload := []byte(`{"type": "charge.succeeded", "data": {"object": {"id": "ch_1", "payment_intent": "pi_1", "metadata": {"booking_id": "b-1"}, "outcome": {"risk_score": 82}}}}`)
+
+	sign := func(secret string, at time.Time) http.Header {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write([]byte(timestamp + "."))
+		mac.Write(payload)
+		header := http.Header{}
+		header.Set("Stripe-Signature", "t="+timestamp+",v1="+hex.EncodeToString(mac.Sum(nil)))
+		return header
+	}
+
+	payment, err := provider.ParseWebhook(payload, sign("whsec_test", time.Now()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if payment.BookingID != "b-1" || payment.PaymentReference != "pi_1" {
+		t.Errorf("payment = %+v, want booking b-1 paid by pi_1", payment)
+	}
+	if payment.RiskScore == nil || *payment.RiskScore != 82 {
+		t.Errorf("risk score = %v, want 82", payment.RiskScore)
+	}
+
+	if _, err := provider.ParseWebhook(payload, sign("whsec_other", time.Now())); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("wrong secret: err = %v, want ErrInvalidSignature", err)
+	}
+	if _, err := provider.ParseWebhook(payload, sign("whsec_test", time.Now().Add(-time.Hour))); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("stale timestamp: err = %v, want ErrInvalidSignature", err)
+	}
+}
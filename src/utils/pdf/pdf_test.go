@@ -0,0 +1,46 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestBytesWritesValidCrossReferences(t *testing.T) {
+	doc := New()
+	doc.AddPage().Text(50, 800, Bold, 24, "Ticket (1 of 2)")
+	page := doc.AddPage()
+	page.Rect(10, 10, 5, 5)
+	page.Text(50, 800, Regular, 12, "Café")
+
+	out := doc.Bytes()
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatal("missing PDF header or trailer")
+	}
+
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if startxref == nil {
+		t.Fatal("missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(startxref[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n0 9\n")) {
+		t.Fatalf("startxref does not point at an xref table with 9 entries")
+	}
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	if len(entries) != 8 {
+		t.Fatalf("got %d xref entries, want 8", len(entries))
+	}
+	for i, entry := range entries {
+		offset, _ := strconv.Atoi(string(entry[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(out[offset:], []byte(want)) {
+			t.Errorf("xref entry %d does not point at %q", i+1, want)
+		}
+	}
+
+	if !bytes.Contains(out, []byte(`(Ticket \(1 of 2\)) Tj`)) || !bytes.Contains(out, []byte(`(Caf\351) Tj`)) {
+		t.Error("text was not escaped")
+	}
+}
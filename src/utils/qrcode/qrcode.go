@@ -0,0 +1,509 @@
+// Package qrcode encodes short payloads such as ticket tokens as QR codes.
+//
+// Only what tickets need is supported: byte mode at error correction level
+// M, versions 1 to 10 (up to 213 bytes).
+package qrcode
+
+import (
+	"errors"
+)
+
+// ErrTooLong is returned when data does not fit in the largest supported version
+var ErrTooLong = errors.New("qrcode: data too long")
+
+// QuietZone is the light border, in modules, scanners expect around a code
+const QuietZone = 4
+
+// versionInfo describes the block structure of a version at level M
+type versionInfo struct {
+	ecPerBlock int
+	blocks1    int // blocks in group 1
+	data1      int // data codewords per group 1 block
+	blocks2    int // blocks in group 2, one data codeword longer
+	alignment  []int
+}
+
+// versions[v-1] is version v at error correction level M
+var versions = []versionInfo{
+	{10, 1, 16, 0, nil},
+	{16, 1, 28, 0, []int{6, 18}},
+	{26, 1, 44, 0, []int{6, 22}},
+	{18, 2, 32, 0, []int{6, 26}},
+	{24, 2, 43, 0, []int{6, 30}},
+	{16, 4, 27, 0, []int{6, 34}},
+	{18, 4, 31, 0, []int{6, 22, 38}},
+	{22, 2, 38, 2, []int{6, 24, 42}},
+	{22, 3, 36, 2, []int{6, 26, 46}},
+	{26, 4, 43, 1, []int{6, 28, 50}},
+}
+
+func (v versionInfo) dataCodewords() int {
+	return v.blocks1*v.data1 + v.blocks2*(v.data1+1)
+}
+
+// Code is an encoded QR code
+type Code struct {
+	Version int
+	Size    int // modules per side, excluding the quiet zone
+
+	modules  [][]bool
+	function [][]bool // finder, timing, alignment and format modules
+}
+
+// Dark reports whether the module at row, col is dark
+func (c *Code) Dark(row, col int) bool {
+	return c.modules[row][col]
+}
+
+// Encode encodes data in the smallest version that fits
+func Encode(data []byte) (*Code, error) {
+	version := 0
+	for i, v := range versions {
+		if dataBits(i+1, len(data)) <= v.dataCodewords()*8 {
+			version = i + 1
+			break
+		}
+	}
+	if version == 0 {
+		return nil, ErrTooLong
+	}
+
+	info := versions[version-1]
+	codewords := interleave(info, encodeData(version, info, data))
+
+	size := 17 + 4*version
+	c := &Code{
+		Version:  version,
+		Size:     size,
+		modules:  makeGrid(size),
+		function: makeGrid(size),
+	}
+	c.drawFunctionPatterns(info)
+	c.drawCodewords(codewords)
+
+	// Keep the mask that leaves the fewest patterns scanners struggle with
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormat(mask)
+		if penalty := c.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			best, bestPenalty = mask, penalty
+		}
+		c.applyMask(mask) // masking is its own inverse
+	}
+	c.applyMask(best)
+	c.drawFormat(best)
+	return c, nil
+}
+
+func makeGrid(size int) [][]bool {
+	grid := make([][]bool, size)
+	for i := range grid {
+		grid[i] = make([]bool, size)
+	}
+	return grid
+}
+
+// dataBits is the length of the encoded segment for n bytes
+func dataBits(version, n int) int {
+	return 4 + countBits(version) + 8*n
+}
+
+// countBits is the width of the byte mode character count
+func countBits(version int) int {
+	if version <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// encodeData builds the padded data codewords for a byte mode segment
+func encodeData(version int, info versionInfo, data []byte) []byte {
+	var bits bitBuffer
+	bits.append(0x4, 4) // byte mode
+	bits.append(len(data), countBits(version))
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+
+	capacity := info.dataCodewords() * 8
+	terminator := capacity - bits.len()
+	if terminator > 4 {
+		terminator = 4
+	}
+	bits.append(0, terminator)
+	bits.append(0, (8-bits.len()%8)%8)
+
+	codewords := bits.bytes()
+	for pad := byte(0xEC); len(codewords) < info.dataCodewords(); pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+	return codewords
+}
+
+// interleave splits data into blocks, appends error correction to each and
+// interleaves the blocks as the symbol requires
+func interleave(info versionInfo, data []byte) []byte {
+	divisor := rsDivisor(info.ecPerBlock)
+
+	var dataBlocks, ecBlocks [][]byte
+	offset := 0
+	for i := 0; i < info.blocks1+info.blocks2; i++ {
+		n := info.data1
+		if i >= info.blocks1 {
+			n++
+		}
+		block := data[offset : offset+n]
+		offset += n
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, divisor))
+	}
+
+	var result []byte
+	for i := 0; i <= info.data1; i++ {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := 0; i < info.ecPerBlock; i++ {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+// drawFunctionPatterns draws everything except the data and format bits,
+// and reserves the format areas so data placement skips them
+func (c *Code) drawFunctionPatterns(info versionInfo) {
+	size := c.Size
+
+	for i := 0; i < size; i++ {
+		c.setFunction(6, i, i%2 == 0)
+		c.setFunction(i, 6, i%2 == 0)
+	}
+
+	c.drawFinder(3, 3)
+	c.drawFinder(3, size-4)
+	c.drawFinder(size-4, 3)
+
+	last := len(info.alignment) - 1
+	for i, row := range info.alignment {
+		for j, col := range info.alignment {
+			// Skip the three corners taken by finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			c.drawAlignment(row, col)
+		}
+	}
+
+	c.drawFormat(0)
+	c.drawVersion()
+}
+
+// drawFinder draws a finder pattern and its separator centred on row, col
+func (c *Code) drawFinder(row, col int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			r, cl := row+dy, col+dx
+			if r < 0 || r >= c.Size || cl < 0 || cl >= c.Size {
+				continue
+			}
+			dist := max(abs(dx), abs(dy))
+			c.setFunction(r, cl, dist != 2 && dist != 4)
+		}
+	}
+}
+
+// drawAlignment draws an alignment pattern centred on row, col
+func (c *Code) drawAlignment(row, col int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.setFunction(row+dy, col+dx, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+// drawFormat draws both copies of the format bits for level M and the mask,
+// plus the dark module
+func (c *Code) drawFormat(mask int) {
+	bits := formatBits(mask)
+	size := c.Size
+	for i := 0; i <= 5; i++ {
+		c.setFunction(i, 8, bit(bits, i))
+	}
+	c.setFunction(7, 8, bit(bits, 6))
+	c.setFunction(8, 8, bit(bits, 7))
+	c.setFunction(8, 7, bit(bits, 8))
+	for i := 9; i < 15; i++ {
+		c.setFunction(8, 14-i, bit(bits, i))
+	}
+
+	for i := 0; i < 8; i++ {
+		c.setFunction(8, size-1-i, bit(bits, i))
+	}
+	for i := 8; i < 15; i++ {
+		c.setFunction(size-15+i, 8, bit(bits, i))
+	}
+	c.setFunction(size-8, 8, true)
+}
+
+// drawVersion draws both copies of the version bits, present from version 7
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	bits := versionBits(c.Version)
+
+	for i := 0; i < 18; i++ {
+		a := c.Size - 11 + i%3
+		b := i / 3
+		c.setFunction(b, a, bit(bits, i))
+		c.setFunction(a, b, bit(bits, i))
+	}
+}
+
+// formatBits returns the 15 BCH-coded format bits for level M and the mask
+func formatBits(mask int) int {
+	const levelM = 0
+	data := levelM<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+// versionBits returns the 18 BCH-coded version bits
+func versionBits(version int) int {
+	rem := version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+func (c *Code) setFunction(row, col int, dark bool) {
+	c.modules[row][col] = dark
+	c.function[row][col] = true
+}
+
+// drawCodewords places the codewords in the zigzag order of the symbol,
+// two columns at a time from the bottom right
+func (c *Code) drawCodewords(codewords []byte) {
+	size := c.Size
+	i := 0
+	for right := size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // skip the vertical timing pattern
+		}
+		upward := (right+1)&2 == 0
+		for vert := 0; vert < size; vert++ {
+			row := vert
+			if upward {
+				row = size - 1 - vert
+			}
+			for j := 0; j < 2; j++ {
+				col := right - j
+				if c.function[row][col] || i >= len(codewords)*8 {
+					continue
+				}
+				c.modules[row][col] = bit(int(codewords[i>>3]), 7-i&7)
+				i++
+			}
+		}
+	}
+}
+
+// applyMask flips data modules matching the mask pattern
+func (c *Code) applyMask(mask int) {
+	for row := 0; row < c.Size; row++ {
+		for col := 0; col < c.Size; col++ {
+			if c.function[row][col] {
+				continue
+			}
+			var flip bool
+			switch mask {
+			case 0:
+				flip = (row+col)%2 == 0
+			case 1:
+				flip = row%2 == 0
+			case 2:
+				flip = col%3 == 0
+			case 3:
+				flip = (row+col)%3 == 0
+			case 4:
+				flip = (row/2+col/3)%2 == 0
+			case 5:
+				flip = row*col%2+row*col%3 == 0
+			case 6:
+				flip = (row*col%2+row*col%3)%2 == 0
+			case 7:
+				flip = ((row+col)%2+row*col%3)%2 == 0
+			}
+			if flip {
+				c.modules[row][col] = !c.modules[row][col]
+			}
+		}
+	}
+}
+
+// finderLike is the 1:1:3:1:1 pattern with four light modules on one side
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// penalty scores the symbol using the four rules of the specification
+func (c *Code) penalty() int {
+	size := c.Size
+	penalty := 0
+
+	// Runs of five or more modules of the same colour
+	for _, transposed := range []bool{false, true} {
+		for i := 0; i < size; i++ {
+			run := 1
+			for j := 1; j <= size; j++ {
+				if j < size && c.at(i, j, transposed) == c.at(i, j-1, transposed) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					penalty += 3 + run - 5
+				}
+				run = 1
+			}
+		}
+	}
+
+	// 2x2 blocks of the same colour
+	for row := 0; row < size-1; row++ {
+		for col := 0; col < size-1; col++ {
+			dark := c.modules[row][col]
+			if dark == c.modules[row][col+1] && dark == c.modules[row+1][col] && dark == c.modules[row+1][col+1] {
+				penalty += 3
+			}
+		}
+	}
+
+	// Patterns that look like finders
+	for _, transposed := range []bool{false, true} {
+		for i := 0; i < size; i++ {
+			for j := 0; j+len(finderLike[0]) <= size; j++ {
+				for _, pattern := range finderLike {
+					matched := true
+					for k, dark := range pattern {
+						if c.at(i, j+k, transposed) != dark {
+							matched = false
+							break
+						}
+					}
+					if matched {
+						penalty += 40
+					}
+				}
+			}
+		}
+	}
+
+	// Imbalance between dark and light modules
+	dark := 0
+	for _, row := range c.modules {
+		for _, m := range row {
+			if m {
+				dark++
+			}
+		}
+	}
+	total := size * size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	penalty += k * 10
+
+	return penalty
+}
+
+func (c *Code) at(i, j int, transposed bool) bool {
+	if transposed {
+		return c.modules[j][i]
+	}
+	return c.modules[i][j]
+}
+
+// rsDivisor returns the generator polynomial of the given degree, highest
+// coefficient first and the leading 1 omitted
+func rsDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = gfMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return result
+}
+
+// rsRemainder returns the error correction codewords for data
+func rsRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, coef := range divisor {
+			result[i] ^= gfMultiply(coef, factor)
+		}
+	}
+	return result
+}
+
+// gfMultiply multiplies in GF(2^8) modulo x^8 + x^4 + x^3 + x^2 + 1
+func gfMultiply(x, y byte) byte {
+	var z int
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// bitBuffer accumulates bits most significant first
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 == 1)
+	}
+}
+
+func (b bitBuffer) len() int {
+	return len(b)
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, set := range b {
+		if set {
+			result[i/8] |= 1 << (7 - i%8)
+		}
+	}
+	return result
+}
+
+func bit(value, i int) bool {
+	return (value>>i)&1 == 1
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
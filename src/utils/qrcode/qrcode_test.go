@@ -0,0 +1,77 @@
+package qrcode
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestReedSolomonMatchesSpecExample(t *testing.T) {
+	// "HELLO WORLD" at version 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+
+	if got := rsRemainder(data, rsDivisor(10)); !bytes.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	format := []string{
+		"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+		"100010111111001", "100000011001110", "100111110010111", "100101010100000",
+	}
+	for mask, want := range format {
+		if got := strconv.FormatInt(int64(formatBits(mask)), 2); got != want {
+			t.Errorf("mask %d: got %s, want %s", mask, got, want)
+		}
+	}
+
+	if got := strconv.FormatInt(int64(versionBits(7)), 2); got != "111110010010100" {
+		t.Errorf("version 7: got %s", got)
+	}
+}
+
+func TestEncodePicksSmallestVersion(t *testing.T) {
+	for _, tc := range []struct {
+		n       int
+		version int
+	}{
+		{14, 1}, {15, 2}, {84, 5}, {85, 6}, {213, 10},
+	} {
+		code, err := Encode([]byte(strings.Repeat("a", tc.n)))
+		if err != nil {
+			t.Fatalf("%d bytes: %v", tc.n, err)
+		}
+		if code.Version != tc.version || code.Size != 17+4*tc.version {
+			t.Errorf("%d bytes: got version %d size %d, want version %d", tc.n, code.Version, code.Size, tc.version)
+		}
+	}
+
+	if _, err := Encode(make([]byte, 214)); err != ErrTooLong {
+		t.Errorf("214 bytes: got %v, want ErrTooLong", err)
+	}
+}
+
+func TestEncodeDrawsFinderPatterns(t *testing.T) {
+	code, err := Encode([]byte("booking-manager"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ring := []bool{true, false, true, true, true, false, true}
+	last := code.Size - 7
+	for _, corner := range [][2]int{{0, 0}, {0, last}, {last, 0}} {
+		for i, dark := range ring {
+			if code.Dark(corner[0]+3, corner[1]+i) != dark || code.Dark(corner[0]+i, corner[1]+3) != dark {
+				t.Fatalf("finder at %v is malformed", corner)
+			}
+		}
+	}
+	for i := 8; i < code.Size-8; i++ {
+		if code.Dark(6, i) != (i%2 == 0) {
+			t.Fatalf("timing pattern broken at column %d", i)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Package querybuilder builds parameterized SELECT statements for
+// repositories that filter, sort and page on user input.
+//
+// Conditions are written with "?" placeholders, which are rewritten to $1,
+// $2, ... when the statement is rendered for PostgreSQL, so values are always
+// bound as arguments and never interpolated into the SQL text.
+package querybuilder
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidQuery is returned when a builder cannot render a valid statement
+var ErrInvalidQuery = errors.New("invalid query")
+
+// SortDirection is the direction of an ORDER BY term
+type SortDirection string
+
+const (
+	Asc  SortDirection = "ASC"
+	Desc SortDirection = "DESC"
+)
+
+// ParseSortDirection accepts "asc"/"desc" in any case, defaulting to ascending
+func ParseSortDirection(s string) (SortDirection, error) {
+	switch strings.ToUpper(s) {
+	case "", "ASC":
+		return Asc, nil
+	case "DESC":
+		return Desc, nil
+	default:
+		return "", fmt.Errorf("%w: unknown sort direction %q", ErrInvalidQuery, s)
+	}
+}
+
+// Dialect is the SQL dialect a statement is rendered in
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	MySQL
+)
+
+type condition struct {
+	expr string
+	args []interface{}
+}
+
+type orderTerm struct {
+	column    string
+	direction SortDirection
+}
+
+// SelectBuilder builds a SELECT statement
+type SelectBuilder struct {
+	columns []string
+	from    string
+	where   []condition
+	orderBy []orderTerm
+	limit   *uint64
+	offset  *uint64
+	dialect Dialect
+	err     error
+}
+
+// Select starts a SELECT statement for the given columns
+func Select(columns ...string) *SelectBuilder {
+	return &SelectBuilder{columns: columns}
+}
+
+// In renders the statement in dialect d instead of PostgreSQL
+func (b *SelectBuilder) In(d Dialect) *SelectBuilder {
+	b.dialect = d
+	return b
+}
+
+// From sets the table being selected from
+func (b *SelectBuilder) From(table string) *SelectBuilder {
+	b.from = table
+	return b
+}
+
+// Where adds a condition joined to the others with AND. The expression uses
+// "?" placeholders, one per argument.
+func (b *SelectBuilder) Where(expr string, args ...interface{}) *SelectBuilder {
+	if n := strings.Count(expr, "?"); n != len(args) {
+		b.setErr(fmt.Errorf("%w: %q has %d placeholders but %d arguments", ErrInvalidQuery, expr, n, len(args)))
+		return b
+	}
+	b.where = append(b.where, condition{expr: expr, args: args})
+	return b
+}
+
+// WhereIn adds a "column IN (...)" condition. An empty list matches nothing.
+func (b *SelectBuilder) WhereIn(column string, values []interface{}) *SelectBuilder {
+	if len(values) == 0 {
+		return b.Where("1 = 0")
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", ")
+	return b.Where(column+" IN ("+placeholders+")", values...)
+}
+
+// OrderBy appends an ORDER BY term. Column names are never bound as
+// arguments, so callers must only pass whitelisted identifiers.
+func (b *SelectBuilder) OrderBy(column string, direction SortDirection) *SelectBuilder {
+	if direction != Asc && direction != Desc {
+		b.setErr(fmt.Errorf("%w: unknown sort direction %q", ErrInvalidQuery, direction))
+		return b
+	}
+	b.orderBy = append(b.orderBy, orderTerm{column: column, direction: direction})
+	return b
+}
+
+// Limit caps the number of rows returned
+func (b *SelectBuilder) Limit(n uint64) *SelectBuilder {
+	b.limit = &n
+	return b
+}
+
+// Offset skips the first n rows
+func (b *SelectBuilder) Offset(n uint64) *SelectBuilder {
+	b.offset = &n
+	return b
+}
+
+// ToSQL renders the statement and its bound arguments
+func (b *SelectBuilder) ToSQL() (string, []interface{}, error) {
+	if b.err != nil {
+		return "", nil, b.err
+	}
+	if len(b.columns) == 0 {
+		return "", nil, fmt.Errorf("%w: no columns selected", ErrInvalidQuery)
+	}
+	if b.from == "" {
+		return "", nil, fmt.Errorf("%w: no table given", ErrInvalidQuery)
+	}
+
+	var sb strings.Builder
+	args := make([]interface{}, 0)
+
+	sb.WriteString("SELECT ")
+	sb.WriteString(strings.Join(b.columns, ", "))
+	sb.WriteString(" FROM ")
+	sb.WriteString(b.from)
+
+	if len(b.where) > 0 {
+		parts := make([]string, len(b.where))
+		for i, c := range b.where {
+			expr := c.expr
+			if len(b.where) > 1 {
+				expr = "(" + expr + ")"
+			}
+			parts[i] = expr
+			args = append(args, c.args...)
+		}
+		sb.WriteString(" WHERE ")
+		sb.WriteString(strings.Join(parts, " AND "))
+	}
+
+	if len(b.orderBy) > 0 {
+		parts := make([]string, len(b.orderBy))
+		for i, o := range b.orderBy {
+			parts[i] = o.column + " " + string(o.direction)
+		}
+		sb.WriteString(" ORDER BY ")
+		sb.WriteString(strings.Join(parts, ", "))
+	}
+
+	if b.limit != nil {
+		sb.WriteString(" LIMIT ?")
+		args = append(args, *b.limit)
+	}
+	if b.offset != nil {
+		sb.WriteString(" OFFSET ?")
+		args = append(args, *b.offset)
+	}
+
+	if b.dialect == MySQL {
+		return sb.String(), args, nil
+	}
+	return dollarPlaceholders(sb.String()), args, nil
+}
+
+// setErr keeps the first error so it is reported by ToSQL
+func (b *SelectBuilder) setErr(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+// dollarPlaceholders rewrites each "?" to PostgreSQL's numbered form
+func dollarPlaceholders(query string) string {
+	var sb strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&sb, "$%d", n)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
@@ -0,0 +1,129 @@
+package querybuilder
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSelectBuilderToSQL(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func() *SelectBuilder
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "columns only",
+			build:    func() *SelectBuilder { return Select("id", "name").From("events") },
+			wantSQL:  "SELECT id, name FROM events",
+			wantArgs: []interface{}{},
+		},
+		{
+			name:     "single condition is not parenthesized",
+			build:    func() *SelectBuilder { return Select("id").From("events").Where("artist = ?", "Muse") },
+			wantSQL:  "SELECT id FROM events WHERE artist = $1",
+			wantArgs: []interface{}{"Muse"},
+		},
+		{
+			name: "conditions are ANDed and numbered in order",
+			build: func() *SelectBuilder {
+				return Select("id").From("events").
+					Where("name ILIKE ? OR venue ILIKE ?", "%a%", "%a%").
+					Where("price >= ?", 10.0)
+			},
+			wantSQL:  "SELECT id FROM events WHERE (name ILIKE $1 OR venue ILIKE $2) AND (price >= $3)",
+			wantArgs: []interface{}{"%a%", "%a%", 10.0},
+		},
+		{
+			name: "where in",
+			build: func() *SelectBuilder {
+				return Select("id").From("events").WhereIn("status", []interface{}{"draft", "published"})
+			},
+			wantSQL:  "SELECT id FROM events WHERE status IN ($1, $2)",
+			wantArgs: []interface{}{"draft", "published"},
+		},
+		{
+			name:     "empty where in matches nothing",
+			build:    func() *SelectBuilder { return Select("id").From("events").WhereIn("status", nil) },
+			wantSQL:  "SELECT id FROM events WHERE 1 = 0",
+			wantArgs: []interface{}{},
+		},
+		{
+			name: "order limit and offset follow condition arguments",
+			build: func() *SelectBuilder {
+				return Select("id").From("events").
+					Where("venue = ?", "O2").
+					OrderBy("date", Desc).OrderBy("id", Asc).
+					Limit(20).Offset(40)
+			},
+			wantSQL:  "SELECT id FROM events WHERE venue = $1 ORDER BY date DESC, id ASC LIMIT $2 OFFSET $3",
+			wantArgs: []interface{}{"O2", uint64(20), uint64(40)},
+		},
+		{
+			name:     "limit without conditions",
+			build:    func() *SelectBuilder { return Select("id").From("events").Limit(5) },
+			wantSQL:  "SELECT id FROM events LIMIT $1",
+			wantArgs: []interface{}{uint64(5)},
+		},
+		{
+			name: "mysql keeps question mark placeholders",
+			build: func() *SelectBuilder {
+				return Select("id").From("events").In(MySQL).
+					Where("venue = ?", "O2").
+					WhereIn("status", []interface{}{"draft", "published"}).
+					Limit(20)
+			},
+			wantSQL:  "SELECT id FROM events WHERE (venue = ?) AND (status IN (?, ?)) LIMIT ?",
+			wantArgs: []interface{}{"O2", "draft", "published", uint64(20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.build().ToSQL()
+			if err != nil {
+				t.Fatalf("ToSQL() error = %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("ToSQL() sql = %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("ToSQL() args = %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSelectBuilderErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		build func() *SelectBuilder
+	}{
+		{"no columns", func() *SelectBuilder { return Select().From("events") }},
+		{"no table", func() *SelectBuilder { return Select("id") }},
+		{"too few arguments", func() *SelectBuilder { return Select("id").From("events").Where("a = ? AND b = ?", 1) }},
+		{"too many arguments", func() *SelectBuilder { return Select("id").From("events").Where("a = ?", 1, 2) }},
+		{"bad direction", func() *SelectBuilder { return Select("id").From("events").OrderBy("id", "SIDEWAYS") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := tt.build().ToSQL(); !errors.Is(err, ErrInvalidQuery) {
+				t.Errorf("ToSQL() error = %v, want ErrInvalidQuery", err)
+			}
+		})
+	}
+}
+
+func TestParseSortDirection(t *testing.T) {
+	for in, want := range map[string]SortDirection{"": Asc, "asc": Asc, "ASC": Asc, "desc": Desc, "Desc": Desc} {
+		got, err := ParseSortDirection(in)
+		if err != nil || got != want {
+			t.Errorf("ParseSortDirection(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseSortDirection("up; DROP TABLE events"); !errors.Is(err, ErrInvalidQuery) {
+		t.Errorf("ParseSortDirection() error = %v, want ErrInvalidQuery", err)
+	}
+}
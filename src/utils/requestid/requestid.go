@@ -0,0 +1,22 @@
+// Package requestid carries the ID of the API call that started some work
+// through contexts, so log lines from background processing can be tied
+// back to it.
+package requestid
+
+import "context"
+
+type contextKey struct{}
+
+// WithID returns a context carrying the request ID; an empty ID leaves ctx as is
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID, or "" when there is none
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,44 @@
+// Package tenant carries the current tenant through request contexts so the
+// repository layer can scope queries and cache keys to it.
+package tenant
+
+import (
+	"context"
+	"errors"
+	"regexp"
+)
+
+// ErrInvalidID is returned for tenant IDs that cannot be used as schema names
+var ErrInvalidID = errors.New("invalid tenant id")
+
+// idPattern keeps IDs safe to embed in schema names and cache keys
+var idPattern = regexp.MustCompile(`^[a-z0-9_]{1,48}$`)
+
+type contextKey struct{}
+
+// WithID returns a context scoped to the tenant; an empty ID leaves ctx unscoped
+func WithID(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the tenant ID, or "" when the context is unscoped
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Validate checks that id is lowercase alphanumeric/underscore, at most 48 characters
+func Validate(id string) error {
+	if !idPattern.MatchString(id) {
+		return ErrInvalidID
+	}
+	return nil
+}
+
+// SchemaName returns the Postgres schema holding a tenant's tables
+func SchemaName(id string) string {
+	return "tenant_" + id
+}
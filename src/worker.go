@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/controllers"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/middlewares"
+	"github.com/ojaswiii/booking-manager/src/delivery/rest/routers/health"
+	"github.com/ojaswiii/booking-manager/src/utils"
+
+	"github.com/gorilla/mux"
+)
+
+// runWorker runs the booking processor and the scheduled jobs without the
+// API, until interrupted, serving only the health probes. It returns the
+// process exit code.
+func runWorker(args []string, config *utils.Config, logger *utils.Logger) int {
+	// A worker is what processes bookings for API servers that only enqueue
+	config.BookingProcessorEnabled = true
+
+	flags := newFlagSet("worker", "[flags]")
+	flags.StringVar(&config.BookingQueueConsumer, "consumer", config.BookingQueueConsumer, "consumer name in the booking queue group (BOOKING_QUEUE_CONSUMER)")
+	flags.StringVar(&config.WorkerHealthPort, "health-port", config.WorkerHealthPort, "port to serve /healthz and /readyz on, empty for none (WORKER_HEALTH_PORT)")
+	flags.BoolVar(&config.DBMigrateOnStartup, "migrate", config.DBMigrateOnStartup, "apply pending migrations first (DB_MIGRATE_ON_STARTUP)")
+	jobs := flags.Bool("jobs", true, "run the scheduled jobs as well as the booking processor")
+	if code, ok := parseFlags(flags, args, config, logger); !ok {
+		return code
+	}
+
+	logger.Info("Starting booking worker", "environment", config.Environment, "queue_backend", config.BookingQueueBackend)
+	if config.BookingQueueBackend != "redis" {
+		// In-memory queues are only fed by the server process that owns them
+		logger.Warn("BOOKING_QUEUE_BACKEND is not redis; this worker will not receive booking requests")
+	}
+
+	a, err := newApp(config, logger)
+	if err != nil {
+		logger.Error("Failed to start", "error", err)
+		return 1
+	}
+	defer a.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	a.monitorReplica(ctx)
+	a.listenForInvalidations(ctx)
+	if *jobs {
+		a.runJobs(ctx)
+	}
+	go a.reportMetrics(ctx, 30*time.Second, nil)
+	go a.usecases.BookingMetrics.Run(ctx)
+
+	// Serve the probes, for orchestrators to restart or route around workers
+	failed := make(chan error, 1)
+	var probes *http.Server
+	if config.WorkerHealthPort != "" {
+		probes = newProbeServer(a, config, logger)
+		go func() {
+			logger.Info("Serving health probes", "host", config.ServerHost, "port", config.WorkerHealthPort)
+			if err := probes.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				failed <- fmt.Errorf("health probe server failed: %w", err)
+			}
+		}()
+	}
+
+	exitCode := 0
+	select {
+	case <-waitForSignal():
+	case err := <-failed:
+		logger.Error("Worker failed to start", "error", err)
+		exitCode = 1
+	}
+	logger.Info("Shutting down worker...")
+
+	// Stop the jobs, then drain the booking processor as the app closes
+	cancel()
+	if probes != nil {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		probes.Shutdown(shutdownCtx)
+	}
+	return exitCode
+}
+
+// newProbeServer serves the worker's liveness and readiness probes on
+// WORKER_HEALTH_PORT
+func newProbeServer(a *app, config *utils.Config, logger *utils.Logger) *http.Server {
+	router := mux.NewRouter()
+	router.Use(middlewares.Recovery(logger))
+	health.RegisterHealthRoutes(router, controllers.NewHealthController(a.usecases.Health, logger), logger)
+	return &http.Server{
+		Addr:              config.ServerHost + ":" + config.WorkerHealthPort,
+		Handler:           router,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+}